@@ -347,6 +347,8 @@ const (
 	Thoughtful
 	// Strict is a Type for `=` chain.
 	Strict
+	// UserDefined is a Type for user-defined chain contexts like `^trace`.
+	UserDefined
 )
 
 // MakeChain makes new Chain from main chain literal and additional chain literal.
@@ -372,12 +374,23 @@ func MakeChain(addChain string, mainChain string, chainArg Expr) *Chain {
 	}
 }
 
+// MakeContextChain makes new Chain from the name of a user-defined chain context and main chain literal.
+func MakeContextChain(context string, mainChain string, chainArg Expr) *Chain {
+	chain := MakeChain("", mainChain, chainArg)
+	chain.Token = "^" + context + mainChain
+	chain.Additional = UserDefined
+	chain.Context = context
+	return chain
+}
+
 // Chain is an ast element for chain like `.`.
 type Chain struct {
 	Token      string
 	Additional AdditionalChain
 	Main       MainChain
 	Arg        Expr
+	// Context is the name of the user-defined chain context (empty if Additional is not UserDefined)
+	Context string
 }
 
 func (c *Chain) String() string {
@@ -403,6 +416,8 @@ func (qp *KwargPair) String() string {
 type Pair struct {
 	Key Expr
 	Val Expr
+	// Doc is comment lines just above the pair (without leading `#`)
+	Doc string
 }
 
 func (p *Pair) String() string {
@@ -726,6 +741,8 @@ type FuncLiteral struct {
 	FuncComponent
 	Token string
 	Src   *Source
+	// Doc is comment lines just above the pair or assignment of the func (without leading `#`)
+	Doc string
 }
 
 func (fl *FuncLiteral) isExpr() {}
@@ -0,0 +1,282 @@
+// Package check statically finds obvious mismatches between literal arguments
+// and type annotations given by `Func#sig`.
+package check
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Syuparn/pangaea/ast"
+	"github.com/Syuparn/pangaea/object"
+)
+
+// Problem is a mismatch found in source code.
+type Problem struct {
+	Pos ast.Position
+	Msg string
+}
+
+func (p *Problem) String() string {
+	// NOTE: add 1 otherwise first element is shown as 0
+	return fmt.Sprintf("%s:%d:%d: %s", p.Pos.FileName, p.Pos.Line+1, p.Pos.Column+1, p.Msg)
+}
+
+// sig is type annotations of func assigned to a variable like `f := {|a| a}.sig(Int)`.
+type sig struct {
+	params     []string
+	paramTypes []ast.Expr
+	kwargTypes map[string]ast.Expr
+	ret        ast.Expr
+	body       []ast.Stmt
+}
+
+// Check reports calls of annotated funcs whose literal args do not match the annotations.
+// Only funcs assigned to variables directly are checked.
+func Check(program *ast.Program) []*Problem {
+	c := &checker{
+		scopes: []map[string]*sig{{}},
+		consts: object.NewEnvWithConsts(),
+	}
+
+	// NOTE: nodes are visited in source order so that reassigned variables are handled
+	walk(program, c)
+
+	sort.SliceStable(c.problems, func(i, j int) bool {
+		a, b := c.problems[i].Pos, c.problems[j].Pos
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return c.problems
+}
+
+type checker struct {
+	// scopes are sigs of variables in each func scope (the last one is the innermost).
+	// nil sig means the variable is not annotated (and it shadows outer ones).
+	scopes   []map[string]*sig
+	consts   *object.Env
+	problems []*Problem
+}
+
+func (c *checker) visit(node ast.Node) {
+	c.collectSig(node)
+	c.checkCall(node)
+}
+
+func (c *checker) enterScope(params []string) {
+	scope := map[string]*sig{}
+	for _, param := range params {
+		scope[param] = nil
+	}
+	c.scopes = append(c.scopes, scope)
+}
+
+func (c *checker) leaveScope() {
+	c.scopes = c.scopes[:len(c.scopes)-1]
+}
+
+// lookup returns the sig of the variable name in the innermost scope defining it.
+func (c *checker) lookup(name string) (*sig, bool) {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if s, ok := c.scopes[i][name]; ok {
+			return s, s != nil
+		}
+	}
+	return nil, false
+}
+
+func (c *checker) collectSig(node ast.Node) {
+	assign, ok := node.(*ast.AssignExpr)
+	if !ok {
+		return
+	}
+
+	// NOTE: assignment defines a variable in the current scope
+	scope := c.scopes[len(c.scopes)-1]
+
+	s, ok := findSig(assign.Right)
+	if !ok {
+		// NOTE: the variable may be reassigned to a func without annotations
+		scope[assign.Left.Value] = nil
+		return
+	}
+	scope[assign.Left.Value] = s
+	c.checkRet(assign.Left.Value, s)
+}
+
+func findSig(expr ast.Expr) (*sig, bool) {
+	call, ok := expr.(*ast.PropCallExpr)
+	if !ok || call.Prop.Value != "sig" || call.Chain.Main != ast.Scalar {
+		return nil, false
+	}
+
+	f, ok := call.Receiver.(*ast.FuncLiteral)
+	if !ok {
+		return nil, false
+	}
+
+	s := &sig{
+		paramTypes: call.Args,
+		kwargTypes: map[string]ast.Expr{},
+		body:       f.Body,
+	}
+	for _, param := range f.Args {
+		s.params = append(s.params, param.String())
+	}
+	for ident, typ := range call.Kwargs {
+		if ident.Value == "ret" {
+			s.ret = typ
+			continue
+		}
+		s.kwargTypes[ident.Value] = typ
+	}
+
+	return s, true
+}
+
+func (c *checker) checkCall(node ast.Node) {
+	// f(...)
+	call, ok := node.(*ast.PropCallExpr)
+	if !ok || call.Prop.Value != "call" {
+		return
+	}
+
+	ident, ok := call.Receiver.(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	s, ok := c.lookup(ident.Value)
+	if !ok {
+		return
+	}
+
+	for i, arg := range call.Args {
+		if isUnpacked(arg) {
+			// positions of the following args cannot be determined
+			break
+		}
+		if i >= len(s.paramTypes) {
+			break
+		}
+
+		c.checkLiteral(arg, s.paramTypes[i],
+			fmt.Sprintf("param `%s` of `%s`", s.params[i], ident.Value))
+	}
+
+	for kwarg, arg := range call.Kwargs {
+		typ, ok := s.kwargTypes[kwarg.Value]
+		if !ok {
+			continue
+		}
+		c.checkLiteral(arg, typ, fmt.Sprintf("kwarg `%s` of `%s`", kwarg.Value, ident.Value))
+	}
+}
+
+func (c *checker) checkRet(name string, s *sig) {
+	if s.ret == nil || len(s.body) == 0 {
+		return
+	}
+
+	var last ast.Expr
+	switch stmt := s.body[len(s.body)-1].(type) {
+	case *ast.ExprStmt:
+		last = stmt.Expr
+	case *ast.JumpStmt:
+		if stmt.JumpType != ast.ReturnJump {
+			return
+		}
+		last = stmt.Val
+	default:
+		return
+	}
+
+	c.checkLiteral(last, s.ret, fmt.Sprintf("return value of `%s`", name))
+}
+
+// checkLiteral checks whether literal expr is kind of typ.
+// Nothing is checked if expr is not a literal or typ is not a built-in constant.
+func (c *checker) checkLiteral(expr ast.Expr, typ ast.Expr, name string) {
+	if expr == nil {
+		return
+	}
+
+	v, ok := literalValue(expr)
+	if !ok {
+		return
+	}
+	t, ok := c.resolveType(typ)
+	if !ok {
+		return
+	}
+
+	if isKindOf(v, t) {
+		return
+	}
+
+	c.problems = append(c.problems, &Problem{
+		Pos: expr.Source().Pos,
+		Msg: fmt.Sprintf("%s must be %s (got %s)", name, typ.String(), expr.String()),
+	})
+}
+
+func (c *checker) resolveType(typ ast.Expr) (object.PanObject, bool) {
+	ident, ok := typ.(*ast.Ident)
+	if !ok || ident.Value == "nil" {
+		return nil, false
+	}
+	return c.consts.Get(object.GetSymHash(ident.Value))
+}
+
+// literalValue returns an object which has the same proto chain as the literal.
+func literalValue(expr ast.Expr) (object.PanObject, bool) {
+	switch e := expr.(type) {
+	case *ast.IntLiteral:
+		return object.NewPanInt(e.Value), true
+	case *ast.FloatLiteral:
+		return object.NewPanFloat(e.Value), true
+	case *ast.StrLiteral:
+		return object.NewPanStr(e.Value), true
+	case *ast.SymLiteral:
+		return object.NewPanStr(e.Value), true
+	case *ast.EmbeddedStr:
+		return object.NewPanStr(""), true
+	case *ast.ArrLiteral:
+		return object.NewPanArr(), true
+	case *ast.ObjLiteral:
+		return object.EmptyPanObjPtr(), true
+	case *ast.MapLiteral:
+		return object.NewPanMap(), true
+	case *ast.RangeLiteral:
+		return object.NewPanRange(object.BuiltInNil, object.BuiltInNil, object.BuiltInNil), true
+	case *ast.FuncLiteral:
+		return object.BuiltInFuncObj, true
+	case *ast.IterLiteral:
+		return object.BuiltInIterObj, true
+	case *ast.Ident:
+		switch e.Value {
+		case "nil":
+			return object.BuiltInNil, true
+		case "true":
+			return object.BuiltInTrue, true
+		case "false":
+			return object.BuiltInFalse, true
+		}
+	}
+	return nil, false
+}
+
+func isUnpacked(arg ast.Expr) bool {
+	prefix, ok := arg.(*ast.PrefixExpr)
+	return ok && (prefix.Operator == "*" || prefix.Operator == "**")
+}
+
+func isKindOf(o object.PanObject, typ object.PanObject) bool {
+	for ancestor := o; ancestor != nil; ancestor = ancestor.Proto() {
+		if ancestor == typ {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package check
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Syuparn/pangaea/parser"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			`f := {|a, b| a}.sig(Int, Str); f(1, "a")`,
+			[]string{},
+		},
+		{
+			`f := {|a, b| a}.sig(Int, Str); f("a", 1)`,
+			[]string{
+				"test:1:34: param `a` of `f` must be Int (got \"a\")",
+				"test:1:39: param `b` of `f` must be Str (got 1)",
+			},
+		},
+		// kind of proto
+		{
+			`f := {|a| a}.sig(Num); f(1); f(1.5); f(true)`,
+			[]string{},
+		},
+		{
+			`f := {|a| a}.sig(Arr); f({a: 1})`,
+			[]string{"test:1:31: param `a` of `f` must be Arr (got {a: 1})"},
+		},
+		// nil annotation accepts anything
+		{
+			`f := {|a, b| a}.sig(nil, Int); f("a", 2)`,
+			[]string{},
+		},
+		// non-literal args are not checked
+		{
+			`f := {|a| a}.sig(Int); x := "a"; f(x)`,
+			[]string{},
+		},
+		// unpacked args are not checked
+		{
+			`f := {|a, b| a}.sig(Int, Int); f(*["a", "b"], "c")`,
+			[]string{},
+		},
+		// user-defined objects are not checked
+		{
+			`f := {|a| a}.sig(MyObj); f(1)`,
+			[]string{},
+		},
+		{
+			`f := {|a, to: 1| a}.sig(nil, to: Int); f(1, to: "x")`,
+			[]string{"test:1:49: kwarg `to` of `f` must be Int (got \"x\")"},
+		},
+		{
+			`f := {|a| "a"}.sig(nil, ret: Int)`,
+			[]string{"test:1:11: return value of `f` must be Int (got \"a\")"},
+		},
+		{
+			`f := {|a| return 1}.sig(nil, ret: Int)`,
+			[]string{},
+		},
+		// reassigned func is not checked
+		{
+			`f := {|a| a}.sig(Int); f := {|a| a}; f("a")`,
+			[]string{},
+		},
+		// nested calls
+		{
+			`f := {|a| a}.sig(Int); [1].map {|i| f("a")}`,
+			[]string{"test:1:39: param `a` of `f` must be Int (got \"a\")"},
+		},
+		// params shadow annotated funcs
+		{
+			`g := {|a| a}.sig(Int); [1].map {|g| g("a")}`,
+			[]string{},
+		},
+		{
+			`g := {|a| a}.sig(Int); h := {|x, to: g| to("a")}`,
+			[]string{},
+		},
+		{
+			`g := {|a| a}.sig(Int); h := {|[g, b]| g("a")}`,
+			[]string{},
+		},
+		{
+			`g := {|a| a}.sig(Int); m := %{|g| g("a")}`,
+			[]string{},
+		},
+		// shadowing is limited to the func
+		{
+			`g := {|a| a}.sig(Int); [1].map {|g| g}; g("a")`,
+			[]string{"test:1:43: param `a` of `g` must be Int (got \"a\")"},
+		},
+		// assignment in a func defines a local variable
+		{
+			`g := {|a| a}.sig(Int); [1].map {|i| g := {|a| a}; g("a")}; g("b")`,
+			[]string{"test:1:62: param `a` of `g` must be Int (got \"b\")"},
+		},
+		{
+			`[1].map {|i| g := {|a| a}.sig(Int); g("a")}; g("b")`,
+			[]string{"test:1:39: param `a` of `g` must be Int (got \"a\")"},
+		},
+	}
+
+	for _, tt := range tests {
+		program, err := parser.Parse(parser.NewReader(strings.NewReader(tt.input), "test"))
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.input, err)
+		}
+
+		actual := []string{}
+		for _, p := range Check(program) {
+			actual = append(actual, p.String())
+		}
+
+		if !reflect.DeepEqual(actual, tt.expected) {
+			t.Errorf("wrong problems in `%s`. expected=%v, got=%v", tt.input, tt.expected, actual)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"ok.pangaea":  `f := {|a| a}.sig(Int); f(1)`,
+		"ng.pangaea":  `f := {|a| a}.sig(Int); f("a")`,
+		"ignored.txt": `f := {|a| a}.sig(Int); f("a")`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	exitCode := Run([]string{dir}, out, errOut)
+
+	if exitCode != 1 {
+		t.Errorf("exit code must be 1. got=%d", exitCode)
+	}
+
+	expected := filepath.Join(dir, "ng.pangaea") + ":1:26: param `a` of `f` must be Int (got \"a\")\n"
+	if out.String() != expected {
+		t.Errorf("wrong output. expected=%q, got=%q", expected, out.String())
+	}
+	if errOut.String() != "" {
+		t.Errorf("errOut must be empty. got=%q", errOut.String())
+	}
+}
+
+func TestRunWithoutArgs(t *testing.T) {
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+
+	if exitCode := Run([]string{}, out, errOut); exitCode != 2 {
+		t.Errorf("exit code must be 2. got=%d", exitCode)
+	}
+	if errOut.String() != usage {
+		t.Errorf("usage must be shown. got=%q", errOut.String())
+	}
+}
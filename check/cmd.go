@@ -0,0 +1,72 @@
+package check
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Syuparn/pangaea/parser"
+)
+
+const usage = `usage: pangaea check path...
+
+reports obvious mismatches between literal arguments and type annotations (Func#sig).
+if path is a directory, all .pangaea files in it are checked.
+`
+
+// Run runs `pangaea check` subcommand and returns exit code.
+func Run(args []string, out io.Writer, errOut io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprint(errOut, usage)
+		return 2
+	}
+
+	exitCode := 0
+	for _, arg := range args {
+		err := filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || (path != arg && !strings.HasSuffix(path, ".pangaea")) {
+				return nil
+			}
+
+			problems, err := checkFile(path)
+			if err != nil {
+				fmt.Fprintln(errOut, err.Error())
+				exitCode = 1
+				return nil
+			}
+
+			for _, p := range problems {
+				fmt.Fprintln(out, p.String())
+				exitCode = 1
+			}
+			return nil
+		})
+
+		if err != nil {
+			fmt.Fprintln(errOut, err.Error())
+			exitCode = 1
+		}
+	}
+
+	return exitCode
+}
+
+func checkFile(path string) ([]*Problem, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	program, err := parser.Parse(parser.NewReader(fp, path))
+	if err != nil {
+		return nil, err
+	}
+
+	return Check(program), nil
+}
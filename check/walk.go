@@ -0,0 +1,167 @@
+package check
+
+import (
+	"github.com/Syuparn/pangaea/ast"
+)
+
+// visitor is called back while nodes are traversed.
+type visitor interface {
+	// visit is called with each node.
+	visit(node ast.Node)
+	// enterScope is called before the body of a func is traversed.
+	// params are names of the params of the func.
+	enterScope(params []string)
+	// leaveScope is called after the body of a func is traversed.
+	leaveScope()
+}
+
+// walk traverses node and its descendants in depth-first order.
+func walk(node ast.Node, v visitor) {
+	if node == nil {
+		return
+	}
+	v.visit(node)
+
+	switch n := node.(type) {
+	case *ast.Program:
+		walkStmts(n.Stmts, v)
+	case *ast.ExprStmt:
+		walkExpr(n.Expr, v)
+	case *ast.JumpStmt:
+		walkExpr(n.Val, v)
+	case *ast.JumpIfStmt:
+		walk(n.JumpStmt, v)
+		walkExpr(n.Cond, v)
+	case *ast.PropCallExpr:
+		walkExpr(n.Receiver, v)
+		walkChainArg(n.Chain, v)
+		walkArgs(n.Args, n.Kwargs, v)
+	case *ast.LiteralCallExpr:
+		walkExpr(n.Receiver, v)
+		walkChainArg(n.Chain, v)
+		walk(n.Func, v)
+		walkArgs(n.Args, n.Kwargs, v)
+	case *ast.VarCallExpr:
+		walkExpr(n.Receiver, v)
+		walkChainArg(n.Chain, v)
+		walkArgs(n.Args, n.Kwargs, v)
+	case *ast.PrefixExpr:
+		walkExpr(n.Right, v)
+	case *ast.InfixExpr:
+		walkExpr(n.Left, v)
+		walkExpr(n.Right, v)
+	case *ast.AssignExpr:
+		walkExpr(n.Right, v)
+	case *ast.EmbeddedStr:
+		for piece := n.Former; piece != nil; piece = piece.Former {
+			walkExpr(piece.Expr, v)
+		}
+	case *ast.RangeLiteral:
+		walkExpr(n.Start, v)
+		walkExpr(n.Stop, v)
+		walkExpr(n.Step, v)
+	case *ast.IfExpr:
+		walkExpr(n.Cond, v)
+		walkExpr(n.Then, v)
+		walkExpr(n.Else, v)
+	case *ast.FuncLiteral:
+		walkFuncComponent(&n.FuncComponent, v)
+	case *ast.IterLiteral:
+		walkFuncComponent(&n.FuncComponent, v)
+	case *ast.MatchLiteral:
+		for _, pattern := range n.Patterns {
+			walkFuncComponent(pattern, v)
+		}
+	case *ast.ObjLiteral:
+		walkPairs(n.Pairs, v)
+		walkExprs(n.EmbeddedExprs, v)
+	case *ast.MapLiteral:
+		walkPairs(n.Pairs, v)
+		walkExprs(n.EmbeddedExprs, v)
+	case *ast.ArrLiteral:
+		walkExprs(n.Elems, v)
+	}
+}
+
+func walkExpr(expr ast.Expr, v visitor) {
+	// NOTE: nil interface check is necessary because typed nil cannot be detected in walk
+	if expr == nil {
+		return
+	}
+	walk(expr, v)
+}
+
+func walkExprs(exprs []ast.Expr, v visitor) {
+	for _, expr := range exprs {
+		walkExpr(expr, v)
+	}
+}
+
+func walkStmts(stmts []ast.Stmt, v visitor) {
+	for _, stmt := range stmts {
+		walk(stmt, v)
+	}
+}
+
+func walkArgs(args []ast.Expr, kwargs map[*ast.Ident]ast.Expr, v visitor) {
+	walkExprs(args, v)
+	for _, kwarg := range kwargs {
+		walkExpr(kwarg, v)
+	}
+}
+
+func walkPairs(pairs []*ast.Pair, v visitor) {
+	for _, pair := range pairs {
+		walkExpr(pair.Key, v)
+		walkExpr(pair.Val, v)
+	}
+}
+
+func walkChainArg(chain *ast.Chain, v visitor) {
+	if chain != nil {
+		walkExpr(chain.Arg, v)
+	}
+}
+
+func walkFuncComponent(component *ast.FuncComponent, v visitor) {
+	walkExprs(component.Args, v)
+	for _, kwarg := range component.Kwargs {
+		walkExpr(kwarg, v)
+	}
+
+	v.enterScope(paramNames(component))
+	walkStmts(component.Body, v)
+	v.leaveScope()
+}
+
+// paramNames returns names of all params and kwargs (including ones in destructuring patterns).
+func paramNames(component *ast.FuncComponent) []string {
+	names := []string{}
+	for _, arg := range component.Args {
+		walkExpr(arg, &identCollector{names: &names})
+	}
+	for kwarg := range component.Kwargs {
+		names = append(names, kwarg.Value)
+	}
+	return names
+}
+
+// identCollector collects names of idents except ones in nested funcs.
+type identCollector struct {
+	names *[]string
+	depth int
+}
+
+func (c *identCollector) visit(node ast.Node) {
+	if ident, ok := node.(*ast.Ident); ok && c.depth == 0 {
+		*c.names = append(*c.names, ident.Value)
+	}
+}
+
+func (c *identCollector) enterScope(params []string) {
+	c.depth++
+}
+
+func (c *identCollector) leaveScope() {
+	c.depth--
+}
@@ -25,6 +25,7 @@ func NewPropContainer() map[string]object.PanObject {
 		"Str_evalEnv":    &object.PanBuiltIn{Fn: strEvalEnv},
 		"Kernel_import":  &object.PanBuiltIn{Fn: kernelImport},
 		"Kernel_invite!": &object.PanBuiltIn{Fn: kernelInvite},
+		"Kernel_reload":  &object.PanBuiltIn{Fn: kernelReload},
 	}
 }
 
@@ -135,6 +136,7 @@ func injectBuiltInProps(
 	injectProps(object.BuiltInArrObj, toPairs(props.ArrProps(ctn)), arrNatives, iterableNatives)
 	injectProps(object.BuiltInAssertionErr, toPairs(props.AssertionErrProps(ctn)))
 	injectProps(object.BuiltInBaseObj, toPairs(props.BaseObjProps(ctn)), baseObjNatives)
+	injectProps(object.BuiltInBytesObj, toPairs(props.BytesProps(ctn)), iterableNatives)
 	injectProps(object.BuiltInComparableObj, toPairs(props.ComparableProps(ctn)), comparableNatives)
 	injectProps(object.BuiltInDiamondObj, toPairs(props.DiamondProps(ctn)), diamondNatives, iterableNatives)
 	injectProps(object.BuiltInEitherObj, toPairs(props.EitherProps(ctn)), eitherNatives, wrappableNatives)
@@ -150,13 +152,20 @@ func injectBuiltInProps(
 	injectProps(object.BuiltInKernelObj, toPairs(props.KernelProps(ctn)), kernelNatives)
 	injectProps(object.BuiltInMatchObj, toPairs(props.MatchProps(ctn)))
 	injectProps(object.BuiltInMapObj, toPairs(props.MapProps(ctn)), mapNatives, iterableNatives)
+	injectProps(object.BuiltInImportErr, toPairs(props.ImportErrProps(ctn)))
+	injectProps(object.BuiltInLimitErr, toPairs(props.LimitErrProps(ctn)))
+	injectProps(object.BuiltInMatchErr, toPairs(props.MatchErrProps(ctn)))
 	injectProps(object.BuiltInNameErr, toPairs(props.NameErrProps(ctn)))
 	injectProps(object.BuiltInNilObj, toPairs(props.NilProps(ctn)))
 	injectProps(object.BuiltInNoPropErr, toPairs(props.NoPropErrProps(ctn)))
 	injectProps(object.BuiltInNotImplementedErr, toPairs(props.NotImplementedErrProps(ctn)))
+	injectProps(object.BuiltInPermissionErr, toPairs(props.PermissionErrProps(ctn)))
+	injectProps(object.BuiltInRecursionErr, toPairs(props.RecursionErrProps(ctn)))
 	injectProps(object.BuiltInNumObj, toPairs(props.NumProps(ctn)), numNatives)
 	injectProps(object.BuiltInObjObj, toPairs(props.ObjProps(ctn)), objNatives, iterableNatives)
 	injectProps(object.BuiltInRangeObj, toPairs(props.RangeProps(ctn)), rangeNatives, iterableNatives)
+	injectProps(object.BuiltInRefObj, toPairs(props.RefProps(ctn)))
+	injectProps(object.BuiltInSetObj, toPairs(props.SetProps(ctn)), iterableNatives)
 	injectProps(object.BuiltInStopIterErr, toPairs(props.StopIterErrProps(ctn)))
 	injectProps(object.BuiltInStrObj, toPairs(props.StrProps(ctn)), strNatives, iterableNatives, comparableNatives)
 	injectProps(object.BuiltInSyntaxErr, toPairs(props.SyntaxErrProps(ctn)))
@@ -164,6 +173,8 @@ func injectBuiltInProps(
 	injectProps(object.BuiltInValueErr, toPairs(props.ValueErrProps(ctn)))
 	injectProps(object.BuiltInWrappableObj, toPairs(props.WrappableProps(ctn)), wrappableNatives)
 	injectProps(object.BuiltInZeroDivisionErr, toPairs(props.ZeroDivisionErrProps(ctn)))
+
+	props.AttachDocs(env)
 }
 
 func injectProps(
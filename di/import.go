@@ -3,16 +3,30 @@ package di
 import (
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/Syuparn/pangaea/envs"
+	"github.com/Syuparn/pangaea/mod"
 	"github.com/Syuparn/pangaea/native"
 	"github.com/Syuparn/pangaea/object"
 	"github.com/Syuparn/pangaea/parser"
 	"github.com/Syuparn/pangaea/props/modules"
 )
 
+// exportsVar is a variable name which lists names of exported variables in the module.
+const exportsVar = "_exports"
+
+// moduleCapabilities is a list of capabilities required to import standard modules.
+var moduleCapabilities = map[string]object.Capability{
+	"http":          object.CapHTTP,
+	"http/internal": object.CapHTTP,
+	"kv":            object.CapRead | object.CapWrite,
+	"kv/internal":   object.CapRead | object.CapWrite,
+}
+
 func kernelImport(
 	env *object.Env,
 	kwargs *object.PanObj,
@@ -27,7 +41,34 @@ func kernelImport(
 		return object.NewTypeErr("\\1 must be str")
 	}
 
-	return importModule(env, importPathObj.Value)
+	m := importModule(env, importPathObj.Value, false)
+	if m.Type() == object.ErrType {
+		return m
+	}
+
+	return selectExports(m.(*object.PanObj), importPathObj.Value, kwargs)
+}
+
+func kernelReload(
+	env *object.Env,
+	kwargs *object.PanObj,
+	args ...object.PanObject,
+) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("reload requires at least 1 arg")
+	}
+
+	importPathObj, ok := args[0].(*object.PanStr)
+	if !ok {
+		return object.NewTypeErr("\\1 must be str")
+	}
+
+	m := importModule(env, importPathObj.Value, true)
+	if m.Type() == object.ErrType {
+		return m
+	}
+
+	return selectExports(m.(*object.PanObj), importPathObj.Value, kwargs)
 }
 
 func kernelInvite(
@@ -44,31 +85,87 @@ func kernelInvite(
 		return object.NewTypeErr("\\1 must be str")
 	}
 
-	return inviteModule(env, importPathObj.Value)
+	m := importModule(env, importPathObj.Value, false)
+	if m.Type() == object.ErrType {
+		return m
+	}
+
+	selected := selectExports(m.(*object.PanObj), importPathObj.Value, kwargs)
+	if selected.Type() == object.ErrType {
+		return selected
+	}
+
+	override := false
+	if pair, ok := (*kwargs.Pairs)[object.GetSymHash("override?")]; ok {
+		override = pair.Value == object.BuiltInTrue
+	}
+
+	return inviteExports(env, selected.(*object.PanObj), importPathObj.Value, override)
 }
 
-func importModule(env *object.Env, importPath string) object.PanObject {
+// importModule evaluates the module and returns its exported variables as obj.
+// Imported modules are cached so that the same module obj is returned in every import.
+// If reload is true, the cached module is discarded and evaluated again.
+func importModule(env *object.Env, importPath string, reload bool) object.PanObject {
+	if !env.Permits(object.CapImport) {
+		return object.NewPermissionErr(fmt.Sprintf("import of %q is not permitted", importPath))
+	}
+
 	// relative path like "./foo/bar"
 	if strings.HasPrefix(importPath, ".") {
-		return importRelative(env, importPath)
+		return importRelative(env, importPath, reload)
 	}
 
-	// NOTE: object.NewEnv cannot be used because an empty env does not have built-in objects
-	// NOTE: object.NewEnclosedEnv(env) cannot be used otherwise variables in this env affects the imported module
-	newEnv := object.NewEnclosedEnv(env.Global())
+	// third-party module like "foo/bar"
+	if !isStandardModule(importPath) {
+		modulePath, ok, errObj := findThirdPartyModule(env, importPath)
+		if errObj != nil {
+			return errObj
+		}
+		if ok {
+			return importFile(env, modulePath, reload)
+		}
+	}
+
+	return importStandardModule(env, importPath, reload)
+}
+
+func importRelative(env *object.Env, importPath string, reload bool) object.PanObject {
+	importPath, errObj := sourceFilePath(env, importPath)
+	if errObj != nil {
+		return errObj
+	}
 
-	return injectStandardModule(newEnv, importPath)
+	return importFile(env, importPath, reload)
 }
 
-func importRelative(env *object.Env, importPath string) object.PanObject {
-	f, importPath, errObj := readSourceFile(env, importPath)
+func importFile(env *object.Env, importPath string, reload bool) object.PanObject {
+	key := moduleKey(env, importPath)
+	cache := env.Modules()
+	if reload {
+		cache.Delete(key)
+	}
+
+	if m, ok := cache.Get(key); ok {
+		return m
+	}
+
+	chain, errObj := extendImportChain(env, key)
 	if errObj != nil {
 		return errObj
 	}
 
+	f, err := openSource(env, importPath)
+	if err != nil {
+		return object.NewFileNotFoundErr(fmt.Sprintf("failed to open %q", importPath))
+	}
+	defer f.Close()
+
 	// NOTE: object.NewEnv cannot be used because an empty env does not have built-in objects
 	// NOTE: object.NewEnclosedEnv(env) cannot be used otherwise variables in this env affects the imported module
 	newEnv := object.NewEnclosedEnv(env.Global())
+	newEnv.SetImportChain(chain)
+	newEnv.SetSandbox(env.Sandbox())
 
 	// set imported file path to SourcePathVar for inner env
 	newEnv.SetSourceFilePath(importPath)
@@ -78,47 +175,219 @@ func importRelative(env *object.Env, importPath string) object.PanObject {
 		return result
 	}
 
-	return newEnv.Items()
+	m := exportedItems(newEnv)
+	if m.Type() == object.ErrType {
+		return m
+	}
+
+	cache.Set(key, m)
+	return m
 }
 
-func inviteModule(env *object.Env, importPath string) object.PanObject {
-	// relative path like "./foo/bar"
-	if strings.HasPrefix(importPath, ".") {
-		return inviteRelative(env, importPath)
+func importStandardModule(env *object.Env, importPath string, reload bool) object.PanObject {
+	if c, ok := moduleCapabilities[importPath]; ok && !env.Permits(c) {
+		return object.NewPermissionErr(fmt.Sprintf("module %q is not permitted", importPath))
+	}
+
+	cache := env.Modules()
+	if reload {
+		cache.Delete(importPath)
+	}
+
+	if m, ok := cache.Get(importPath); ok {
+		return m
+	}
+
+	// NOTE: object.NewEnv cannot be used because an empty env does not have built-in objects
+	// NOTE: object.NewEnclosedEnv(env) cannot be used otherwise variables in this env affects the imported module
+	newEnv := object.NewEnclosedEnv(env.Global())
+	newEnv.SetSandbox(env.Sandbox())
+
+	result := injectStandardModule(newEnv, importPath)
+	if result.Type() == object.ErrType {
+		return result
 	}
 
-	m := injectStandardModule(env, importPath)
+	m := exportedItems(newEnv)
 	if m.Type() == object.ErrType {
 		return m
 	}
 
-	return object.BuiltInNil
+	cache.Set(importPath, m)
+	return m
 }
 
-func inviteRelative(env *object.Env, importPath string) object.PanObject {
-	f, importPath, errObj := readSourceFile(env, importPath)
-	if errObj != nil {
-		return errObj
+// exportedItems returns variables exported from the module env as obj.
+// If the module defines `_exports`, only the variables listed in it are exported.
+// Otherwise, all public variables (not starting with `_`) are exported.
+func exportedItems(env *object.Env) object.PanObject {
+	exports, ok := env.Store[object.GetSymHash(exportsVar)]
+	if !ok {
+		pairs := map[object.SymHash]object.Pair{}
+		for k, pair := range *env.Items().(*object.PanObj).Pairs {
+			if pair.Key.(*object.PanStr).IsPublic {
+				pairs[k] = pair
+			}
+		}
+		return object.PanObjInstancePtr(&pairs)
+	}
+
+	arr, ok := object.TraceProtoOfArr(exports)
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("%s %s must be arr", exportsVar, exports.Repr()))
 	}
 
-	origPath, existsPath := env.Get(object.GetSymHash(object.SourcePathVar))
-	env.SetSourceFilePath(importPath)
-	// HACK: set the original value again for the following process
-	defer func() {
-		if existsPath {
-			env.Set(object.GetSymHash(object.SourcePathVar), origPath)
+	pairs := map[object.SymHash]object.Pair{}
+	for _, elem := range arr.Elems {
+		name, ok := object.TraceProtoOfStr(elem)
+		if !ok {
+			return object.NewTypeErr(fmt.Sprintf("%s in %s cannot be treated as str", elem.Repr(), exportsVar))
 		}
-	}()
 
-	result := eval(parser.NewReader(f, importPath), env)
-	if result.Type() == object.ErrType {
-		return result
+		v, ok := env.Store[object.GetSymHash(name.Value)]
+		if !ok {
+			return object.NewImportErr(fmt.Sprintf("exported variable `%s` is not defined", name.Value))
+		}
+		pairs[object.GetSymHash(name.Value)] = object.Pair{Key: object.NewPanStr(name.Value), Value: v}
+	}
+
+	return object.PanObjInstancePtr(&pairs)
+}
+
+// selectExports selects and renames exported variables of module m by kwargs `only` and `as`.
+func selectExports(m *object.PanObj, importPath string, kwargs *object.PanObj) object.PanObject {
+	selected := *m.Pairs
+
+	if pair, ok := (*kwargs.Pairs)[object.GetSymHash("only")]; ok {
+		names, ok := object.TraceProtoOfArr(pair.Value)
+		if !ok {
+			return object.NewTypeErr(fmt.Sprintf("only %s must be arr", pair.Value.Repr()))
+		}
+
+		selected = map[object.SymHash]object.Pair{}
+		for _, elem := range names.Elems {
+			name, ok := object.TraceProtoOfStr(elem)
+			if !ok {
+				return object.NewTypeErr(fmt.Sprintf("%s in only cannot be treated as str", elem.Repr()))
+			}
+
+			p, ok := (*m.Pairs)[object.GetSymHash(name.Value)]
+			if !ok {
+				return object.NewImportErr(fmt.Sprintf("%q does not export `%s`", importPath, name.Value))
+			}
+			selected[object.GetSymHash(name.Value)] = p
+		}
+	}
+
+	pair, ok := (*kwargs.Pairs)[object.GetSymHash("as")]
+	if !ok {
+		if len(selected) == len(*m.Pairs) {
+			// NOTE: return module itself so that the same obj is returned in every import
+			return m
+		}
+		return object.PanObjInstancePtr(&selected)
+	}
+
+	aliases, ok := object.TraceProtoOfObj(pair.Value)
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("as %s must be obj", pair.Value.Repr()))
+	}
+
+	// exports not renamed keep their names
+	renamed := map[object.SymHash]object.Pair{}
+	for k, p := range selected {
+		if _, ok := (*aliases.Pairs)[k]; !ok {
+			renamed[k] = p
+		}
+	}
+
+	// NOTE: keys are sorted so that the error of duplicated names is reported deterministically
+	keys := append(append([]object.SymHash{}, *aliases.Keys...), *aliases.PrivateKeys...)
+	for _, k := range keys {
+		alias := (*aliases.Pairs)[k]
+		newName, ok := object.TraceProtoOfStr(alias.Value)
+		if !ok {
+			return object.NewTypeErr(fmt.Sprintf("alias %s cannot be treated as str", alias.Value.Repr()))
+		}
+
+		p, ok := selected[k]
+		if !ok {
+			return object.NewImportErr(fmt.Sprintf("%q does not export `%s`",
+				importPath, alias.Key.(*object.PanStr).Value))
+		}
+
+		newKey := object.GetSymHash(newName.Value)
+		if _, ok := renamed[newKey]; ok {
+			return object.NewImportErr(fmt.Sprintf("%q is imported as `%s` more than once",
+				importPath, newName.Value))
+		}
+		renamed[newKey] = object.Pair{Key: object.NewPanStr(newName.Value), Value: p.Value}
+	}
+
+	return object.PanObjInstancePtr(&renamed)
+}
+
+// inviteExports sets exported variables to env.
+// If a variable with the same name already exists and its value differs, ImportErr is returned
+// unless override is true.
+func inviteExports(env *object.Env, m *object.PanObj, importPath string, override bool) object.PanObject {
+	if !override {
+		for k, pair := range *m.Pairs {
+			// NOTE: variables in outer scopes are not shadowed but hidden in the current scope
+			if v, ok := env.Store[k]; ok && v != pair.Value {
+				return object.NewImportErr(fmt.Sprintf(
+					"invite! of %q shadows existing variable `%s` (use override?: true to overwrite it)",
+					importPath, pair.Key.(*object.PanStr).Value))
+			}
+		}
+	}
+
+	for k, pair := range *m.Pairs {
+		env.Set(k, pair.Value)
 	}
 
 	return object.BuiltInNil
 }
 
-func readSourceFile(env *object.Env, importPath string) (io.Reader, string, *object.PanErr) {
+// moduleKey returns the key of the module cache.
+func moduleKey(env *object.Env, importPath string) string {
+	if env.FS() != nil {
+		return object.FSPath(importPath)
+	}
+
+	abspath, err := filepath.Abs(importPath)
+	if err != nil {
+		return importPath
+	}
+	return abspath
+}
+
+// openSource opens the source file of the module in the file system of env.
+func openSource(env *object.Env, importPath string) (io.ReadCloser, error) {
+	fsys := env.FS()
+	if fsys == nil {
+		return os.Open(importPath)
+	}
+	return fsys.Open(object.FSPath(importPath))
+}
+
+// extendImportChain appends key to the chain of modules being imported.
+// If key is already in the chain, ImportErr is returned because the import is cyclic.
+func extendImportChain(env *object.Env, key string) ([]string, *object.PanErr) {
+	chain := env.ImportChain()
+
+	for i, p := range chain {
+		if p == key {
+			cycle := append(append([]string{}, chain[i:]...), key)
+			return nil, object.NewImportErr(
+				fmt.Sprintf("import cycle detected: %s", strings.Join(cycle, " -> ")))
+		}
+	}
+
+	return append(append([]string{}, chain...), key), nil
+}
+
+func sourceFilePath(env *object.Env, importPath string) (string, *object.PanErr) {
 	// add extension
 	if !strings.HasSuffix(importPath, ".pangaea") {
 		importPath += ".pangaea"
@@ -127,18 +396,39 @@ func readSourceFile(env *object.Env, importPath string) (io.Reader, string, *obj
 	// NOTE: if importPath is relative, it is based on the evaluating source file (not based on where pangaea command is executed)
 	if p, ok := env.Get(object.GetSymHash(object.SourcePathVar)); ok {
 		if p.Type() != object.StrType {
-			return nil, "", object.NewTypeErr(fmt.Sprintf("%s %s must be str", object.SourcePathVar, p.Inspect()))
+			return "", object.NewTypeErr(fmt.Sprintf("%s %s must be str", object.SourcePathVar, p.Inspect()))
 		}
 		sourcePath := p.(*object.PanStr).Value
 		importPath = filepath.Join(filepath.Dir(sourcePath), importPath)
 	}
 
-	f, err := os.Open(importPath)
-	if err != nil {
-		return nil, "", object.NewFileNotFoundErr(fmt.Sprintf("failed to open %q", importPath))
+	return importPath, nil
+}
+
+// findThirdPartyModule finds the module in vendored directories and $PANGAEA_PATH.
+func findThirdPartyModule(env *object.Env, importPath string) (string, bool, *object.PanErr) {
+	// NOTE: vendored modules are searched from the evaluating source file (or the current directory)
+	fromDir := "."
+	if p, ok := env.Get(object.GetSymHash(object.SourcePathVar)); ok {
+		if p.Type() != object.StrType {
+			return "", false, object.NewTypeErr(fmt.Sprintf("%s %s must be str", object.SourcePathVar, p.Inspect()))
+		}
+		fromDir = filepath.Dir(p.(*object.PanStr).Value)
+	}
+
+	modulePath, ok := mod.Resolve(importPath, fromDir, envs.ModulePaths())
+	return modulePath, ok, nil
+}
+
+// isStandardModule reports whether importPath is a built-in or native standard module.
+// NOTE: standard modules take precedence over third-party modules
+func isStandardModule(importPath string) bool {
+	if _, ok := modules.Modules[importPath]; ok {
+		return true
 	}
 
-	return f, importPath, nil
+	_, err := fs.Stat(native.FS, fmt.Sprintf("modules/%s.pangaea", importPath))
+	return err == nil
 }
 
 func injectStandardModule(env *object.Env, importPath string) object.PanObject {
@@ -1,19 +1,18 @@
 package di
 
 import (
+	"context"
+	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
+	"github.com/Syuparn/pangaea/envs"
 	"github.com/Syuparn/pangaea/object"
 )
 
 func TestEvalKernelImport(t *testing.T) {
-	// NOTE: since form of Abspath is OS-dependent, we use fixture to prepare expected string
-	abspath := func(path string) string {
-		p, _ := filepath.Abs(path)
-		return p
-	}
-
 	tests := []struct {
 		input    string
 		expected object.PanObject
@@ -22,26 +21,59 @@ func TestEvalKernelImport(t *testing.T) {
 		{
 			`import("./testdata/testSuccess.pangaea")`,
 			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
-				object.GetSymHash("_PANGAEA_SOURCE_PATH"): {Key: object.NewPanStr("_PANGAEA_SOURCE_PATH"), Value: object.NewPanStr(abspath("./testdata/testSuccess.pangaea"))},
-				object.GetSymHash("a"):                    {Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
-				object.GetSymHash("b"):                    {Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+				object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
 			}),
 		},
 		// extension
 		{
 			`import("./testdata/testSuccess")`,
 			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
-				object.GetSymHash("_PANGAEA_SOURCE_PATH"): {Key: object.NewPanStr("_PANGAEA_SOURCE_PATH"), Value: object.NewPanStr(abspath("./testdata/testSuccess.pangaea"))},
-				object.GetSymHash("a"):                    {Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
-				object.GetSymHash("b"):                    {Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+				object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
 			}),
 		},
 		// nested import
 		{
 			`import("./testdata/importing")`,
 			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
-				object.GetSymHash("_PANGAEA_SOURCE_PATH"): {Key: object.NewPanStr("_PANGAEA_SOURCE_PATH"), Value: object.NewPanStr(abspath("./testdata/importing.pangaea"))},
-				object.GetSymHash("a"):                    {Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+			}),
+		},
+		// private variables are not exported
+		{
+			`import("./testdata/private")`,
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("double"): {Key: object.NewPanStr("double"), Value: object.NewPanInt(6)},
+			}),
+		},
+		// only variables listed in _exports are exported
+		{
+			`import("./testdata/exports")`,
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("a"):  {Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+				object.GetSymHash("_c"): {Key: object.NewPanStr("_c"), Value: object.NewPanInt(3)},
+			}),
+		},
+		// only
+		{
+			`import("./testdata/testSuccess", only: ['b])`,
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+			}),
+		},
+		// as
+		{
+			`import("./testdata/testSuccess", as: {a: "x"})`,
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("x"): {Key: object.NewPanStr("x"), Value: object.NewPanInt(1)},
+				object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+			}),
+		},
+		{
+			`import("./testdata/testSuccess", only: ['a], as: {a: "x"})`,
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("x"): {Key: object.NewPanStr("x"), Value: object.NewPanInt(1)},
 			}),
 		},
 		// standard module
@@ -100,6 +132,26 @@ func TestEvalKernelImportError(t *testing.T) {
 			`import("dummy_native_wrong")`,
 			object.NewSyntaxErr("failed to parse"),
 		},
+		{
+			`import("./testdata/exportsUndefined")`,
+			object.NewImportErr("exported variable `undefined` is not defined"),
+		},
+		{
+			`import("./testdata/private", only: ['_base])`,
+			object.NewImportErr("\"./testdata/private\" does not export `_base`"),
+		},
+		{
+			`import("./testdata/testSuccess", as: {c: "x"})`,
+			object.NewImportErr("\"./testdata/testSuccess\" does not export `c`"),
+		},
+		{
+			`import("./testdata/testSuccess", only: 'a)`,
+			object.NewTypeErr("only \"a\" must be arr"),
+		},
+		{
+			`import("./testdata/testSuccess", as: {a: 1})`,
+			object.NewTypeErr("alias 1 cannot be treated as str"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -127,11 +179,36 @@ func TestEvalKernelInvite(t *testing.T) {
 			`invite!("./testdata/testSuccess")`,
 			object.BuiltInNil,
 		},
-		// invite! update variables
+		// invite! overrides variables only if override? is true
 		{
-			`a := "original"; invite!("./testdata/testSuccess"); a`,
+			`a := "original"; invite!("./testdata/testSuccess", override?: true); a`,
 			object.NewPanInt(1),
 		},
+		// inviting the same module twice does not shadow variables
+		{
+			`invite!("./testdata/testSuccess"); invite!("./testdata/testSuccess"); b`,
+			object.NewPanInt(2),
+		},
+		// only and as
+		{
+			`a := "original"; invite!("./testdata/testSuccess", only: ['a], as: {a: "x"}); [a, x]`,
+			object.NewPanArr(object.NewPanStr("original"), object.NewPanInt(1)),
+		},
+		// names can be swapped
+		{
+			`invite!("./testdata/testSuccess", as: {a: "b", b: "a"}); [a, b]`,
+			object.NewPanArr(object.NewPanInt(2), object.NewPanInt(1)),
+		},
+		// variables in outer scopes are not regarded as shadowed
+		{
+			`a := "global"; f := {invite!("./testdata/testSuccess"); a}; [f(), a]`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanStr("global")),
+		},
+		// private variables are not invited
+		{
+			`invite!("./testdata/private"); [double, _base]`,
+			object.NewNameErr("name `_base` is not defined"),
+		},
 		// nested invite
 		{
 			`invite!("./testdata/inviting"); a`,
@@ -190,6 +267,182 @@ func TestEvalKernelInviteError(t *testing.T) {
 			`invite!("notfound")`,
 			object.NewFileNotFoundErr("failed to read native module \"notfound\": open modules/notfound.pangaea: file does not exist"),
 		},
+		{
+			`a := "original"; invite!("./testdata/testSuccess")`,
+			object.NewImportErr("invite! of \"./testdata/testSuccess\" shadows existing variable `a` (use override?: true to overwrite it)"),
+		},
+		{
+			`invite!("./testdata/testSuccess", only: ['c])`,
+			object.NewImportErr("\"./testdata/testSuccess\" does not export `c`"),
+		},
+		{
+			`f := {a := "local"; invite!("./testdata/testSuccess")}; f()`,
+			object.NewImportErr("invite! of \"./testdata/testSuccess\" shadows existing variable `a` (use override?: true to overwrite it)"),
+		},
+		{
+			`invite!("./testdata/testSuccess", as: {a: "x", b: "x"})`,
+			object.NewImportErr("\"./testdata/testSuccess\" is imported as `x` more than once"),
+		},
+		// renamed export conflicts with another export
+		{
+			`invite!("./testdata/testSuccess", as: {a: "b"})`,
+			object.NewImportErr("\"./testdata/testSuccess\" is imported as `b` more than once"),
+		},
+		{
+			`import("./testdata/testSuccess", as: {a: "x", b: "x"})`,
+			object.NewImportErr("\"./testdata/testSuccess\" is imported as `x` more than once"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			actual := testEval(t, tt.input)
+			testValue(t, actual, tt.expected)
+		})
+	}
+}
+
+func TestEvalKernelImportThirdParty(t *testing.T) {
+	abspath := func(path string) string {
+		p, _ := filepath.Abs(path)
+		return p
+	}
+	t.Setenv(envs.PathKey, abspath("./testdata/modpath"))
+
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		// module in $PANGAEA_PATH
+		{
+			`import("greeting").message`,
+			object.NewPanStr("hello"),
+		},
+		{
+			`import("greeting/polite").message`,
+			object.NewPanStr("good morning"),
+		},
+		// standard modules take precedence
+		{
+			`import("dummy").message`,
+			object.NewPanStr("This is a dummy module."),
+		},
+		// vendored module is searched from the evaluating source file
+		{
+			fmt.Sprintf(`_PANGAEA_SOURCE_PATH := %q; import("vendored").name`,
+				abspath("./testdata/thirdparty/src/main.pangaea")),
+			object.NewPanStr("vendored"),
+		},
+		{
+			`import("./testdata/thirdparty/src/main").v`,
+			object.NewPanStr("vendored"),
+		},
+		{
+			`invite!("greeting"); message`,
+			object.NewPanStr("hello"),
+		},
+		{
+			fmt.Sprintf(`_PANGAEA_SOURCE_PATH := %q; invite!("vendored"); [name, _PANGAEA_SOURCE_PATH]`,
+				abspath("./testdata/thirdparty/src/main.pangaea")),
+			object.NewPanArr(
+				object.NewPanStr("vendored"),
+				object.NewPanStr(abspath("./testdata/thirdparty/src/main.pangaea")),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			actual := testEval(t, tt.input)
+			testValue(t, actual, tt.expected)
+		})
+	}
+}
+
+func TestEvalKernelImportThirdPartyError(t *testing.T) {
+	t.Setenv(envs.PathKey, "")
+
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`import("greeting")`,
+			object.NewFileNotFoundErr("failed to read native module \"greeting\": open modules/greeting.pangaea: file does not exist"),
+		},
+		{
+			`invite!("greeting")`,
+			object.NewFileNotFoundErr("failed to read native module \"greeting\": open modules/greeting.pangaea: file does not exist"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			actual := testEval(t, tt.input)
+			testValue(t, actual, tt.expected)
+		})
+	}
+}
+
+func TestEvalKernelImportCache(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			"same module is returned",
+			`[import("./testdata/helper"), import("./testdata/helper.pangaea")]`,
+		},
+		{
+			"modules imported from different modules are shared",
+			`[import("./testdata/helperUser1").helper, import("./testdata/helperUser2").helper]`,
+		},
+		{
+			"standard module",
+			`[import("dummy"), import("dummy")]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := testEval(t, tt.input)
+			arr, ok := actual.(*object.PanArr)
+			if !ok {
+				t.Fatalf("actual must be *object.PanArr. got=%T (%s)", actual, actual.Inspect())
+			}
+
+			if arr.Elems[0] != arr.Elems[1] {
+				t.Errorf("modules must be identical: %p != %p", arr.Elems[0], arr.Elems[1])
+			}
+		})
+	}
+}
+
+func TestEvalKernelImportCycle(t *testing.T) {
+	abspath := func(path string) string {
+		p, _ := filepath.Abs(path)
+		return p
+	}
+
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`import("./testdata/cycleA")`,
+			object.NewImportErr(fmt.Sprintf("import cycle detected: %s -> %s -> %s",
+				abspath("./testdata/cycleA.pangaea"),
+				abspath("./testdata/cycleB.pangaea"),
+				abspath("./testdata/cycleA.pangaea"),
+			)),
+		},
+		{
+			`invite!("./testdata/inviteCycle")`,
+			object.NewImportErr(fmt.Sprintf("import cycle detected: %s -> %s",
+				abspath("./testdata/inviteCycle.pangaea"),
+				abspath("./testdata/inviteCycle.pangaea"),
+			)),
+		},
 	}
 
 	for _, tt := range tests {
@@ -199,3 +452,162 @@ func TestEvalKernelInviteError(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalKernelReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "m.pangaea")
+	if err := os.WriteFile(path, []byte(`a := 1`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := object.NewEnvWithConsts()
+	env.InjectFrom(object.BuiltInKernelObj)
+	env.SetSourceFilePath(filepath.Join(dir, "main.pangaea"))
+
+	testValue(t, testEvalInEnv(t, `import("./m").a`, env), object.NewPanInt(1))
+
+	if err := os.WriteFile(path, []byte(`a := 2`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// cached module is returned
+	testValue(t, testEvalInEnv(t, `import("./m").a`, env), object.NewPanInt(1))
+	// reload evaluates the module again
+	testValue(t, testEvalInEnv(t, `reload("./m").a`, env), object.NewPanInt(2))
+	// reloaded module is cached
+	testValue(t, testEvalInEnv(t, `import("./m").a`, env), object.NewPanInt(2))
+}
+
+func TestEvalKernelReloadError(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`reload(1)`,
+			object.NewTypeErr("\\1 must be str"),
+		},
+		{
+			`reload()`,
+			object.NewTypeErr("reload requires at least 1 arg"),
+		},
+		{
+			`reload("./testdata/notfound")`,
+			object.NewFileNotFoundErr("failed to open \"./testdata/notfound.pangaea\""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			actual := testEval(t, tt.input)
+			testValue(t, actual, tt.expected)
+		})
+	}
+}
+
+func TestEvalKernelImportSandbox(t *testing.T) {
+	tests := []struct {
+		input    string
+		denied   object.Capability
+		expected object.PanObject
+	}{
+		{
+			`import("./testdata/testSuccess")`,
+			object.CapImport,
+			object.NewPermissionErr("import of \"./testdata/testSuccess\" is not permitted"),
+		},
+		{
+			`invite!("dummy")`,
+			object.CapImport,
+			object.NewPermissionErr("import of \"dummy\" is not permitted"),
+		},
+		{
+			`import("http")`,
+			object.CapHTTP,
+			object.NewPermissionErr("module \"http\" is not permitted"),
+		},
+		{
+			`import("kv")`,
+			object.CapWrite,
+			object.NewPermissionErr("module \"kv\" is not permitted"),
+		},
+		// modules imported in the module are also restricted
+		{
+			`import("./testdata/importing")`,
+			object.CapHTTP,
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+			}),
+		},
+		{
+			`import("./testdata/importingHTTP")`,
+			object.CapHTTP,
+			object.NewPermissionErr("module \"http\" is not permitted"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			env := object.NewEnvWithConsts()
+			env.InjectFrom(object.BuiltInKernelObj)
+			env.SetSandbox(object.NewSandbox(context.Background(), object.Limits{Denied: tt.denied}))
+
+			actual := testEvalInEnv(t, tt.input, env)
+			testValue(t, actual, tt.expected)
+		})
+	}
+}
+
+func TestEvalKernelImportFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.pangaea":  {Data: []byte(`a := import("./lib/a").a`)},
+		"lib/a.pangaea": {Data: []byte(`a := import("./b").b + 1`)},
+		"lib/b.pangaea": {Data: []byte(`b := 1`)},
+		"cycle.pangaea": {Data: []byte(`import("./cycle")`)},
+	}
+
+	tests := []struct {
+		input      string
+		sourcePath string
+		expected   object.PanObject
+	}{
+		// relative paths are based on the importing module
+		{
+			`import("./lib/a").a`,
+			"",
+			object.NewPanInt(2),
+		},
+		{
+			`import("./a").a`,
+			"lib/main.pangaea",
+			object.NewPanInt(2),
+		},
+		{
+			`import("./main").a`,
+			"",
+			object.NewPanInt(2),
+		},
+		{
+			`import("./lib/c")`,
+			"",
+			object.NewFileNotFoundErr(`failed to open "./lib/c.pangaea"`),
+		},
+		{
+			`import("./cycle")`,
+			"",
+			object.NewImportErr("import cycle detected: cycle.pangaea -> cycle.pangaea"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			env := object.NewEnvWithConsts()
+			env.InjectFrom(object.BuiltInKernelObj)
+			env.SetFS(fsys)
+			env.SetSourceFilePath(tt.sourcePath)
+
+			actual := testEvalInEnv(t, tt.input, env)
+			testValue(t, actual, tt.expected)
+		})
+	}
+}
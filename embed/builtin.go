@@ -0,0 +1,91 @@
+package embed
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// wrapFunc converts Go func fv to built-in func.
+// Args are decoded to param types by Decode and the returned value is converted by ToPanObject.
+// The func can return at most one value optionally followed by error.
+// Non-nil error is raised as Err (or its original err if it is *Error).
+func wrapFunc(fv reflect.Value) (object.BuiltInFunc, error) {
+	ft := fv.Type()
+
+	switch ft.NumOut() {
+	case 0, 1:
+	case 2:
+		if ft.Out(1) != errorType {
+			return nil, fmt.Errorf("second return value of %s must be error", ft)
+		}
+	default:
+		return nil, fmt.Errorf("%s returns too many values", ft)
+	}
+
+	// required number of args
+	nArgs := ft.NumIn()
+	if ft.IsVariadic() {
+		nArgs--
+	}
+
+	fn := func(
+		env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+	) object.PanObject {
+		if len(args) < nArgs {
+			return object.NewTypeErr(fmt.Sprintf("%s requires at least %d arg(s)", ft, nArgs))
+		}
+		if !ft.IsVariadic() && len(args) > nArgs {
+			// NOTE: extra args are ignored as well as Pangaea funcs
+			args = args[:nArgs]
+		}
+
+		in := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			var t reflect.Type
+			if ft.IsVariadic() && i >= nArgs {
+				t = ft.In(nArgs).Elem()
+			} else {
+				t = ft.In(i)
+			}
+
+			v := reflect.New(t).Elem()
+			if err := decode(arg, v); err != nil {
+				return object.NewTypeErr(fmt.Sprintf("\\%d: %s", i+1, err.Error()))
+			}
+			in[i] = v
+		}
+
+		out := fv.Call(in)
+		return fromReturnValues(ft, out)
+	}
+
+	return fn, nil
+}
+
+func fromReturnValues(ft reflect.Type, out []reflect.Value) object.PanObject {
+	if len(out) == 0 {
+		return object.BuiltInNil
+	}
+
+	if ft.Out(len(out)-1) == errorType {
+		if err, ok := out[len(out)-1].Interface().(error); ok && err != nil {
+			var e *Error
+			if errors.As(err, &e) {
+				return e.Err
+			}
+			return object.NewPanErr(err.Error())
+		}
+		if len(out) == 1 {
+			return object.BuiltInNil
+		}
+	}
+
+	ret, err := toPanObject(out[0])
+	if err != nil {
+		return object.NewTypeErr(err.Error())
+	}
+	return ret
+}
@@ -0,0 +1,443 @@
+package embed
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// TagName is a struct tag key to specify a key name of obj.
+//
+//	type Person struct {
+//		Name string `pangaea:"name"`
+//		Age  int    `pangaea:"-"` // ignored
+//	}
+const TagName = "pangaea"
+
+var (
+	panObjectType = reflect.TypeOf((*object.PanObject)(nil)).Elem()
+	errorType     = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// ToPanObject converts Go value v to PanObject.
+//   - nil, bool, integers, floats and string are converted to corresponding literals
+//   - []byte is converted to bytes
+//   - other slices and arrays are converted to arr
+//   - maps with string keys and structs are converted to obj
+//   - other maps are converted to map
+//   - funcs are converted to built-in funcs, whose args are decoded by Decode
+//   - PanObject is returned as it is
+func ToPanObject(v interface{}) (object.PanObject, error) {
+	if v == nil {
+		return object.BuiltInNil, nil
+	}
+	return toPanObject(reflect.ValueOf(v))
+}
+
+func toPanObject(rv reflect.Value) (object.PanObject, error) {
+	if rv.Type().Implements(panObjectType) {
+		if rv.IsNil() {
+			return object.BuiltInNil, nil
+		}
+		return rv.Interface().(object.PanObject), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return object.BuiltInTrue, nil
+		}
+		return object.BuiltInFalse, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return object.NewPanInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%d overflows int", rv.Uint())
+		}
+		return object.NewPanInt(int64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return object.NewPanFloat(rv.Float()), nil
+	case reflect.String:
+		return object.NewPanStr(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return object.BuiltInNil, nil
+		}
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return object.NewPanBytes(append([]byte{}, rv.Bytes()...)), nil
+		}
+		elems := []object.PanObject{}
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := toPanObject(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		}
+		return object.NewPanArr(elems...), nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return object.BuiltInNil, nil
+		}
+		return mapToPanObject(rv)
+	case reflect.Struct:
+		return structToPanObject(rv)
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return object.BuiltInNil, nil
+		}
+		return toPanObject(rv.Elem())
+	case reflect.Func:
+		if rv.IsNil() {
+			return object.BuiltInNil, nil
+		}
+		fn, err := wrapFunc(rv)
+		if err != nil {
+			return nil, err
+		}
+		return object.NewPanBuiltInFunc(fn), nil
+	}
+
+	return nil, fmt.Errorf("%s cannot be converted to PanObject", rv.Type())
+}
+
+func mapToPanObject(rv reflect.Value) (object.PanObject, error) {
+	if rv.Type().Key().Kind() == reflect.String {
+		pairs := map[object.SymHash]object.Pair{}
+		iter := rv.MapRange()
+		for iter.Next() {
+			v, err := toPanObject(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			key := iter.Key().String()
+			pairs[object.GetSymHash(key)] = object.Pair{Key: object.NewPanStr(key), Value: v}
+		}
+		return object.PanObjInstancePtr(&pairs), nil
+	}
+
+	pairs := []object.Pair{}
+	iter := rv.MapRange()
+	for iter.Next() {
+		k, err := toPanObject(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		v, err := toPanObject(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, object.Pair{Key: k, Value: v})
+	}
+	return object.NewPanMap(pairs...), nil
+}
+
+func structToPanObject(rv reflect.Value) (object.PanObject, error) {
+	pairs := map[object.SymHash]object.Pair{}
+	for _, field := range structFields(rv.Type()) {
+		v, err := toPanObject(rv.FieldByIndex(field.index))
+		if err != nil {
+			return nil, err
+		}
+		pairs[object.GetSymHash(field.name)] = object.Pair{Key: object.NewPanStr(field.name), Value: v}
+	}
+	return object.PanObjInstancePtr(&pairs), nil
+}
+
+type structField struct {
+	name  string
+	index []int
+}
+
+// structFields returns exported fields of struct type t.
+func structFields(t reflect.Type) []structField {
+	fields := []structField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Name
+		if tag, ok := f.Tag.Lookup(TagName); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, structField{name: name, index: f.Index})
+	}
+	return fields
+}
+
+// FromPanObject converts PanObject o to Go value.
+//   - nil, bool, int, float and str are converted to nil, bool, int64, float64 and string
+//   - arr is converted to []interface{}
+//   - bytes is converted to []byte
+//   - obj is converted to map[string]interface{}
+//   - map is converted to map[interface{}]interface{}
+//   - other objects (funcs, ranges, etc.) are returned as they are
+func FromPanObject(o object.PanObject) (interface{}, error) {
+	switch o := o.(type) {
+	case *object.PanNil:
+		return nil, nil
+	case *object.PanBool:
+		return o.Value, nil
+	case *object.PanInt:
+		return o.Value, nil
+	case *object.PanFloat:
+		return o.Value, nil
+	case *object.PanStr:
+		return o.Value, nil
+	case *object.PanArr:
+		elems := make([]interface{}, 0, len(o.Elems))
+		for _, elem := range o.Elems {
+			v, err := FromPanObject(elem)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, v)
+		}
+		return elems, nil
+	case *object.PanObj:
+		m := map[string]interface{}{}
+		for _, pair := range *o.Pairs {
+			v, err := FromPanObject(pair.Value)
+			if err != nil {
+				return nil, err
+			}
+			m[pair.Key.(*object.PanStr).Value] = v
+		}
+		return m, nil
+	case *object.PanMap:
+		m := map[interface{}]interface{}{}
+		for _, pair := range *o.Pairs {
+			k, err := FromPanObject(pair.Key)
+			if err != nil {
+				return nil, err
+			}
+			v, err := FromPanObject(pair.Value)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = v
+		}
+		if len(*o.NonHashablePairs) > 0 {
+			return nil, errors.New("map with non-hashable keys cannot be converted")
+		}
+		return m, nil
+	case *object.PanSet:
+		elems := make([]interface{}, 0, o.Len())
+		for _, elem := range o.Items() {
+			v, err := FromPanObject(elem)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, v)
+		}
+		return elems, nil
+	case *object.PanBytes:
+		return append([]byte{}, o.Value...), nil
+	case *object.PanRef:
+		// NOTE: current value is converted because Go values cannot follow its updates
+		return FromPanObject(o.Get())
+	}
+
+	return o, nil
+}
+
+// Decode converts PanObject o and stores it in the value pointed to by out.
+// Obj keys are mapped to struct fields in the same way as ToPanObject.
+func Decode(o object.PanObject, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("out must be a non-nil pointer. got=%T", out)
+	}
+	return decode(o, rv.Elem())
+}
+
+func decode(o object.PanObject, rv reflect.Value) error {
+	if rv.Type() == panObjectType {
+		rv.Set(reflect.ValueOf(o))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		v, err := FromPanObject(o)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		if !reflect.TypeOf(v).AssignableTo(rv.Type()) {
+			return decodeErr(o, rv)
+		}
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	case reflect.Ptr:
+		if _, ok := o.(*object.PanNil); ok {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		p := reflect.New(rv.Type().Elem())
+		if err := decode(o, p.Elem()); err != nil {
+			return err
+		}
+		rv.Set(p)
+		return nil
+	case reflect.Bool:
+		b, ok := object.TraceProtoOfBool(o)
+		if !ok {
+			return decodeErr(o, rv)
+		}
+		rv.SetBool(b.Value)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := object.TraceProtoOfInt(o)
+		if !ok {
+			return decodeErr(o, rv)
+		}
+		if rv.OverflowInt(i.Value) {
+			return fmt.Errorf("%d overflows %s", i.Value, rv.Type())
+		}
+		rv.SetInt(i.Value)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := object.TraceProtoOfInt(o)
+		if !ok {
+			return decodeErr(o, rv)
+		}
+		if i.Value < 0 || rv.OverflowUint(uint64(i.Value)) {
+			return fmt.Errorf("%d overflows %s", i.Value, rv.Type())
+		}
+		rv.SetUint(uint64(i.Value))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if f, ok := object.TraceProtoOfFloat(o); ok {
+			rv.SetFloat(f.Value)
+			return nil
+		}
+		if i, ok := object.TraceProtoOfInt(o); ok {
+			rv.SetFloat(float64(i.Value))
+			return nil
+		}
+		return decodeErr(o, rv)
+	case reflect.String:
+		s, ok := object.TraceProtoOfStr(o)
+		if !ok {
+			return decodeErr(o, rv)
+		}
+		rv.SetString(s.Value)
+		return nil
+	case reflect.Slice:
+		if b, ok := object.TraceProtoOfBytes(o); ok && rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes(append([]byte{}, b.Value...))
+			return nil
+		}
+		arr, ok := object.TraceProtoOfArr(o)
+		if !ok {
+			return decodeErr(o, rv)
+		}
+		s := reflect.MakeSlice(rv.Type(), len(arr.Elems), len(arr.Elems))
+		for i, elem := range arr.Elems {
+			if err := decode(elem, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(s)
+		return nil
+	case reflect.Array:
+		arr, ok := object.TraceProtoOfArr(o)
+		if !ok {
+			return decodeErr(o, rv)
+		}
+		if len(arr.Elems) != rv.Len() {
+			return fmt.Errorf("length of %s must be %d", o.Repr(), rv.Len())
+		}
+		for i, elem := range arr.Elems {
+			if err := decode(elem, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		return decodeMap(o, rv)
+	case reflect.Struct:
+		obj, ok := object.TraceProtoOfObj(o)
+		if !ok {
+			return decodeErr(o, rv)
+		}
+		for _, field := range structFields(rv.Type()) {
+			pair, ok := (*obj.Pairs)[object.GetSymHash(field.name)]
+			if !ok {
+				continue
+			}
+			if err := decode(pair.Value, rv.FieldByIndex(field.index)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return decodeErr(o, rv)
+}
+
+func decodeMap(o object.PanObject, rv reflect.Value) error {
+	m := reflect.MakeMap(rv.Type())
+
+	if obj, ok := o.(*object.PanObj); ok {
+		if rv.Type().Key().Kind() != reflect.String {
+			return decodeErr(o, rv)
+		}
+		for _, pair := range *obj.Pairs {
+			v := reflect.New(rv.Type().Elem()).Elem()
+			if err := decode(pair.Value, v); err != nil {
+				return err
+			}
+			k := reflect.New(rv.Type().Key()).Elem()
+			k.SetString(pair.Key.(*object.PanStr).Value)
+			m.SetMapIndex(k, v)
+		}
+		rv.Set(m)
+		return nil
+	}
+
+	panMap, ok := object.TraceProtoOfMap(o)
+	if !ok {
+		return decodeErr(o, rv)
+	}
+	pairs := []object.Pair{}
+	for _, pair := range *panMap.Pairs {
+		pairs = append(pairs, pair)
+	}
+	pairs = append(pairs, *panMap.NonHashablePairs...)
+
+	for _, pair := range pairs {
+		k := reflect.New(rv.Type().Key()).Elem()
+		if err := decode(pair.Key, k); err != nil {
+			return err
+		}
+		if !k.Comparable() {
+			return fmt.Errorf("key %s cannot be decoded to map key", pair.Key.Repr())
+		}
+		v := reflect.New(rv.Type().Elem()).Elem()
+		if err := decode(pair.Value, v); err != nil {
+			return err
+		}
+		m.SetMapIndex(k, v)
+	}
+	rv.Set(m)
+	return nil
+}
+
+func decodeErr(o object.PanObject, rv reflect.Value) error {
+	return fmt.Errorf("%s cannot be decoded to %s", o.Repr(), rv.Type())
+}
@@ -0,0 +1,175 @@
+package embed
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func testEqual(t *testing.T, actual, expected interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("wrong value. expected=%#v, got=%#v", expected, actual)
+	}
+}
+
+type person struct {
+	Name    string `pangaea:"name"`
+	Age     int    `pangaea:"age"`
+	Ignored string `pangaea:"-"`
+	Nick    *string
+	private int
+}
+
+func TestToPanObject(t *testing.T) {
+	nick := "Taro"
+
+	tests := []struct {
+		input    interface{}
+		expected string
+	}{
+		{nil, "nil"},
+		{true, "true"},
+		{uint8(3), "3"},
+		{-5, "-5"},
+		{1.5, "1.500000"},
+		{"a", `"a"`},
+		{[]int{1, 2}, "[1, 2]"},
+		{[2]string{"a", "b"}, `["a", "b"]`},
+		{[]int(nil), "nil"},
+		{[]byte{1, 255}, "Bytes.new([1, 255])"},
+		{[2]byte{1, 2}, "[1, 2]"},
+		{map[string]int{"a": 1}, `{"a": 1}`},
+		{map[int]string{1: "a"}, `%{1: "a"}`},
+		{person{Name: "Taro", Age: 20, Ignored: "x", private: 1}, `{"Nick": nil, "age": 20, "name": "Taro"}`},
+		{&person{Nick: &nick}, `{"Nick": "Taro", "age": 0, "name": ""}`},
+		{object.NewPanStr("raw"), `"raw"`},
+	}
+
+	for _, tt := range tests {
+		actual, err := ToPanObject(tt.input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		testEqual(t, actual.Repr(), tt.expected)
+	}
+}
+
+func TestToPanObjectError(t *testing.T) {
+	tests := []interface{}{
+		uint64(1 << 63),
+		make(chan int),
+		[]interface{}{complex(1, 2)},
+		func() (int, int) { return 0, 0 },
+		func() (int, error, error) { return 0, nil, nil },
+	}
+
+	for _, tt := range tests {
+		if _, err := ToPanObject(tt); err == nil {
+			t.Errorf("error must be raised (%T)", tt)
+		}
+	}
+}
+
+func TestFromPanObject(t *testing.T) {
+	f := object.NewPanBuiltInFunc(nil)
+
+	tests := []struct {
+		input    object.PanObject
+		expected interface{}
+	}{
+		{object.BuiltInNil, nil},
+		{object.BuiltInFalse, false},
+		{object.NewPanInt(1), int64(1)},
+		{object.NewPanFloat(0.5), 0.5},
+		{object.NewPanStr("a"), "a"},
+		{object.NewPanArr(object.NewPanInt(1), object.NewPanStr("a")), []interface{}{int64(1), "a"}},
+		{
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+			}),
+			map[string]interface{}{"a": int64(1)},
+		},
+		{
+			object.NewPanMap(object.Pair{Key: object.NewPanInt(1), Value: object.NewPanStr("a")}),
+			map[interface{}]interface{}{int64(1): "a"},
+		},
+		{object.NewPanSet(object.NewPanInt(1), object.NewPanStr("a")), []interface{}{int64(1), "a"}},
+		{object.NewPanRef(object.NewPanArr(object.NewPanInt(1))), []interface{}{int64(1)}},
+		{object.NewPanBytes([]byte{1, 2}), []byte{1, 2}},
+		{f, f},
+	}
+
+	for _, tt := range tests {
+		actual, err := FromPanObject(tt.input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		testEqual(t, actual, tt.expected)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	o, _ := ToPanObject(map[string]interface{}{
+		"name":    "Taro",
+		"age":     20,
+		"Nick":    "T",
+		"Ignored": "x",
+	})
+
+	var p person
+	if err := Decode(o, &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	nick := "T"
+	testEqual(t, p, person{Name: "Taro", Age: 20, Nick: &nick})
+
+	var floats []float64
+	if err := Decode(object.NewPanArr(object.NewPanInt(1), object.NewPanFloat(0.5)), &floats); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testEqual(t, floats, []float64{1, 0.5})
+
+	var m map[int]string
+	if err := Decode(object.NewPanMap(object.Pair{Key: object.NewPanInt(1), Value: object.NewPanStr("a")}), &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testEqual(t, m, map[int]string{1: "a"})
+
+	var b []byte
+	if err := Decode(object.NewPanBytes([]byte{1, 2}), &b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testEqual(t, b, []byte{1, 2})
+
+	var raw object.PanObject
+	if err := Decode(o, &raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testEqual(t, raw, o)
+}
+
+func TestDecodeError(t *testing.T) {
+	var i int8
+	var u uint
+	var s string
+	var arr [2]int
+
+	tests := []struct {
+		input object.PanObject
+		out   interface{}
+	}{
+		{object.NewPanInt(1000), &i},
+		{object.NewPanInt(-1), &u},
+		{object.NewPanInt(1), &s},
+		{object.NewPanArr(object.NewPanInt(1)), &arr},
+		{object.NewPanInt(1), s},
+	}
+
+	for _, tt := range tests {
+		if err := Decode(tt.input, tt.out); err == nil {
+			t.Errorf("error must be raised (%s -> %T)", tt.input.Repr(), tt.out)
+		}
+	}
+}
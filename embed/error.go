@@ -0,0 +1,30 @@
+package embed
+
+import (
+	"github.com/Syuparn/pangaea/object"
+)
+
+// Error is an error raised in Pangaea source code.
+type Error struct {
+	Err *object.PanErr
+}
+
+// Error returns the kind and message of err like "ValueErr: invalid value".
+func (e *Error) Error() string {
+	return e.Err.Inspect()
+}
+
+// Kind returns the kind of err like "ValueErr".
+func (e *Error) Kind() string {
+	return e.Err.Kind()
+}
+
+// Message returns the message of err.
+func (e *Error) Message() string {
+	return e.Err.Message()
+}
+
+// StackTrace returns the stack trace where err was raised.
+func (e *Error) StackTrace() string {
+	return e.Err.StackTrace
+}
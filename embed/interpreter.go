@@ -0,0 +1,183 @@
+// Package embed provides API to run Pangaea scripts inside Go programs.
+//
+//	interp := embed.New(embed.WithIO(strings.NewReader(""), os.Stdout))
+//	interp.Define("limit", 10)
+//	ret, err := interp.Eval(context.Background(), "(1:limit).sum")
+package embed
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Syuparn/pangaea/di"
+	"github.com/Syuparn/pangaea/evaluator"
+	"github.com/Syuparn/pangaea/object"
+	"github.com/Syuparn/pangaea/parser"
+)
+
+var (
+	preparedEnv *object.Env
+	prepareOnce sync.Once
+)
+
+// prepare returns the global env with built-in props.
+// NOTE: built-in props are injected only once because they are shared by all envs
+// and injecting them takes time
+func prepare() *object.Env {
+	prepareOnce.Do(func() {
+		env := object.NewEnvWithConsts()
+
+		// necessary to setup built-in object props
+		di.InjectBuiltInProps(env)
+
+		// enable to use Kernel props directly in top-level
+		// NOTE: InjectFrom must be called after BuiltInKernelObj is set up
+		env.InjectFrom(object.BuiltInKernelObj)
+
+		preparedEnv = env
+	})
+	return preparedEnv
+}
+
+// Interpreter evaluates Pangaea source codes.
+// Variables defined by Define are shared by all evaluations
+// while variables assigned in source codes are discarded after each evaluation.
+type Interpreter struct {
+	base   *object.Env
+	in     io.Reader
+	out    io.Writer
+	limits object.Limits
+}
+
+// Option is an option of Interpreter.
+type Option func(*Interpreter)
+
+// WithIO sets reader and writer used by `IO` object (and therefore `<>`, `p` and so on).
+// Stdin and stdout are used by default.
+func WithIO(in io.Reader, out io.Writer) Option {
+	return func(i *Interpreter) {
+		i.in = in
+		i.out = out
+	}
+}
+
+// WithLimits sets resource limits and denied capabilities of each evaluation.
+// The limits are used to run untrusted scripts.
+func WithLimits(limits object.Limits) Option {
+	return func(i *Interpreter) {
+		i.limits = limits
+	}
+}
+
+// New makes new Interpreter.
+// The first call takes time because built-in props are set up.
+func New(options ...Option) *Interpreter {
+	base := object.NewCopiedEnv(prepare())
+	// modules imported in this interpreter are not shared with others
+	base.SetModules(object.NewModuleCache())
+
+	i := &Interpreter{
+		base: base,
+		in:   os.Stdin,
+		out:  os.Stdout,
+	}
+
+	for _, op := range options {
+		op(i)
+	}
+
+	return i
+}
+
+// Clone returns a copy of the interpreter.
+// Variables defined in the copy do not affect the original one.
+func (i *Interpreter) Clone(options ...Option) *Interpreter {
+	c := &Interpreter{
+		base:   object.NewCopiedEnv(i.base),
+		in:     i.in,
+		out:    i.out,
+		limits: i.limits,
+	}
+
+	for _, op := range options {
+		op(c)
+	}
+
+	return c
+}
+
+// Define sets Go value v to variable name.
+// v is converted by ToPanObject.
+func (i *Interpreter) Define(name string, v interface{}) error {
+	o, err := ToPanObject(v)
+	if err != nil {
+		return err
+	}
+
+	i.base.Set(object.GetSymHash(name), o)
+	return nil
+}
+
+// DefineBuiltIn sets built-in func fn to variable name.
+func (i *Interpreter) DefineBuiltIn(name string, fn object.BuiltInFunc) {
+	i.base.Set(object.GetSymHash(name), object.NewPanBuiltInFunc(fn))
+}
+
+// Get fetches the value of variable name defined by Define.
+func (i *Interpreter) Get(name string) (object.PanObject, bool) {
+	return i.base.Get(object.GetSymHash(name))
+}
+
+// Eval evaluates src and returns the evaluated value.
+// If src raises an err, it is returned as *Error.
+func (i *Interpreter) Eval(ctx context.Context, src string) (object.PanObject, error) {
+	return i.eval(ctx, strings.NewReader(src), object.StrFileName)
+}
+
+// EvalFile evaluates the source file.
+// Relative paths in `import` are resolved from the file.
+func (i *Interpreter) EvalFile(ctx context.Context, fileName string) (object.PanObject, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return i.eval(ctx, f, fileName)
+}
+
+func (i *Interpreter) eval(ctx context.Context, src io.Reader, fileName string) (object.PanObject, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	node, err := parser.Parse(parser.NewReader(src, fileName))
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: copy env so that variables assigned in src do not remain in the next evaluation
+	env := object.NewCopiedEnv(i.base)
+	env.InjectIO(i.in, i.out)
+	env.SetSourceFilePath(fileName)
+	// NOTE: evaluation stops when ctx is done
+	env.SetSandbox(object.NewSandbox(ctx, i.limits))
+
+	ch := make(chan object.PanObject, 1)
+	go func() {
+		ch <- evaluator.Eval(node, env)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case ret := <-ch:
+		if e, ok := ret.(*object.PanErr); ok {
+			return nil, &Error{Err: e}
+		}
+		return ret, nil
+	}
+}
@@ -0,0 +1,262 @@
+package embed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Syuparn/pangaea/evaluator"
+	"github.com/Syuparn/pangaea/object"
+)
+
+func TestInterpreterEval(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1 + 2`, int64(3)},
+		{`"a" * 3`, "aaa"},
+		{`[1, 2].map {|i| i * 2}`, []interface{}{int64(2), int64(4)}},
+		{`{a: 1, b: nil}`, map[string]interface{}{"a": int64(1), "b": nil}},
+		// defined variables
+		{`(1:limit).sum`, int64(45)},
+		{`double(3) + half(3.0)`, 7.5},
+		{`join("-", 'a, 'b)`, "a-b"},
+		{`user.name + user.tags[0]`, "Taroadmin"},
+	}
+
+	i := New()
+	i.Define("limit", 10)
+	i.Define("double", func(x int) int { return x * 2 })
+	i.Define("half", func(x float64) float64 { return x / 2 })
+	i.Define("join", func(sep string, elems ...string) string { return strings.Join(elems, sep) })
+	i.Define("user", struct {
+		Name string   `pangaea:"name"`
+		Tags []string `pangaea:"tags"`
+	}{"Taro", []string{"admin"}})
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ret, err := i.Eval(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			actual, err := FromPanObject(ret)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			testEqual(t, actual, tt.expected)
+		})
+	}
+}
+
+func TestInterpreterEvalError(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  string
+		msg   string
+	}{
+		{`1 / 0`, "ZeroDivisionErr", "cannot be divided by 0"},
+		{`double()`, "TypeErr", "func(int) int requires at least 1 arg(s)"},
+		{`double("a")`, "TypeErr", `\1: "a" cannot be decoded to int`},
+		{`fail("oops")`, "Err", "oops"},
+		{`failWithValueErr()`, "ValueErr", "invalid"},
+	}
+
+	i := New()
+	i.Define("double", func(x int) int { return x * 2 })
+	i.Define("fail", func(msg string) error { return errors.New(msg) })
+	i.Define("failWithValueErr", func() (int, error) {
+		return 0, &Error{Err: object.NewValueErr("invalid")}
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := i.Eval(context.Background(), tt.input)
+
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("err must be *Error. got=%T (%v)", err, err)
+			}
+			if e.Kind() != tt.kind || e.Message() != tt.msg {
+				t.Errorf("wrong err. expected=%s: %s, got=%s", tt.kind, tt.msg, e.Error())
+			}
+		})
+	}
+}
+
+func TestInterpreterEvalSyntaxError(t *testing.T) {
+	_, err := New().Eval(context.Background(), `1 +`)
+	if err == nil {
+		t.Fatalf("error must be raised")
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		t.Errorf("syntax error must not be *Error. got=%v", e)
+	}
+}
+
+func TestInterpreterAssignedVariablesAreDiscarded(t *testing.T) {
+	i := New()
+
+	if _, err := i.Eval(context.Background(), `x := 1`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := i.Eval(context.Background(), `x`)
+	var e *Error
+	if !errors.As(err, &e) || e.Kind() != "NameErr" {
+		t.Errorf("x must not be defined. got=%v", err)
+	}
+}
+
+func TestInterpreterClone(t *testing.T) {
+	i := New()
+	i.Define("a", 1)
+
+	c := i.Clone()
+	c.Define("a", 2)
+	c.Define("b", 3)
+
+	if v, _ := i.Get("a"); v.(*object.PanInt).Value != 1 {
+		t.Errorf("original must not be changed. got=%s", v.Repr())
+	}
+	if _, ok := i.Get("b"); ok {
+		t.Errorf("b must not be defined in original")
+	}
+	if v, _ := c.Get("a"); v.(*object.PanInt).Value != 2 {
+		t.Errorf("clone must be changed. got=%s", v.Repr())
+	}
+}
+
+func TestInterpreterIO(t *testing.T) {
+	out1 := &bytes.Buffer{}
+	out2 := &bytes.Buffer{}
+	i1 := New(WithIO(strings.NewReader("input\n"), out1))
+	i2 := i1.Clone(WithIO(strings.NewReader(""), out2))
+
+	if _, err := i1.Eval(context.Background(), `<>.uc.puts; 'a.print`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := i2.Eval(context.Background(), `'b.p`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if out1.String() != "INPUT\na" {
+		t.Errorf("wrong output: %q", out1.String())
+	}
+	if out2.String() != "b\n" {
+		t.Errorf("wrong output: %q", out2.String())
+	}
+}
+
+func TestInterpreterDefineBuiltIn(t *testing.T) {
+	i := New()
+	i.DefineBuiltIn("first", func(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+		return args[0]
+	})
+
+	ret, err := i.Eval(context.Background(), `first('a, 'b)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testEqual(t, ret.Repr(), `"a"`)
+}
+
+func TestInterpreterDefineChainContext(t *testing.T) {
+	names := []string{}
+	i := New()
+	i.DefineBuiltIn("logged", evaluator.NewChainContext(func(
+		env *object.Env,
+		recv object.PanObject,
+		name string,
+		next func(recv object.PanObject) object.PanObject,
+	) object.PanObject {
+		names = append(names, name)
+		return next(recv)
+	}))
+
+	ret, err := i.Eval(context.Background(), `(1:4)^logged@{|i| i ** 2}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testEqual(t, ret.Repr(), `[1, 4, 9]`)
+	testEqual(t, len(names), 3)
+}
+
+func TestInterpreterEvalFile(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "lib.pangaea"), []byte(`greeting := "Hello"`), 0644)
+	os.WriteFile(filepath.Join(dir, "main.pangaea"), []byte(`"#{import("./lib").greeting}, #{name}!"`), 0644)
+
+	i := New()
+	i.Define("name", "Pangaea")
+
+	ret, err := i.EvalFile(context.Background(), filepath.Join(dir, "main.pangaea"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testEqual(t, ret.Repr(), `"Hello, Pangaea!"`)
+}
+
+func TestInterpreterEvalCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New().Eval(ctx, `1`)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err must be context.Canceled. got=%v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	i := New()
+	i.Define("sleep", func() { time.Sleep(time.Second) })
+
+	_, err = i.Eval(ctx, `sleep()`)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err must be context.DeadlineExceeded. got=%v", err)
+	}
+}
+
+func TestInterpreterLimits(t *testing.T) {
+	tests := []struct {
+		input  string
+		limits object.Limits
+		kind   string
+	}{
+		{`(1:1000).map {|i| i * 2}`, object.Limits{MaxSteps: 100}, "LimitErr"},
+		{`f := {|n| f(n + 1)}; f(0)`, object.Limits{MaxDepth: 100}, "RecursionErr"},
+		{`read("interpreter.go")`, object.Limits{Denied: object.CapRead}, "PermissionErr"},
+		{`import("dummy")`, object.Limits{Denied: object.CapImport}, "PermissionErr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := New(WithLimits(tt.limits)).Eval(context.Background(), tt.input)
+
+			var e *Error
+			if !errors.As(err, &e) || e.Kind() != tt.kind {
+				t.Errorf("%s must be raised. got=%v", tt.kind, err)
+			}
+		})
+	}
+}
+
+func TestInterpreterLimitsAreReset(t *testing.T) {
+	i := New(WithLimits(object.Limits{MaxSteps: 1000}))
+
+	// steps are counted in each evaluation
+	for n := 0; n < 3; n++ {
+		if _, err := i.Eval(context.Background(), `(1:100).map {|i| i}`); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
@@ -3,12 +3,17 @@ package envs
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 )
 
 // environment variable names
 const (
 	// JargonFile is a file path of the jargon script file.
 	JargonFileKey = "PANGAEA_JARGON_FILE"
+	// PathKey is a list of directories where third-party modules are searched.
+	PathKey = "PANGAEA_PATH"
+	// MaxDepthKey is the maximum depth of func calls in the pangaea command.
+	MaxDepthKey = "PANGAEA_MAX_DEPTH"
 )
 
 // DefaultJargonFile is a default file path ot jargon script file.
@@ -16,3 +21,27 @@ func DefaultJargonFile() string {
 	dir, _ := os.UserHomeDir()
 	return filepath.Join(dir, ".jargon.pangaea")
 }
+
+// DefaultHistoryFile is a file path of REPL history, which is next to the default jargon script file.
+func DefaultHistoryFile() string {
+	return filepath.Join(filepath.Dir(DefaultJargonFile()), ".pangaea_history")
+}
+
+// ModulePaths returns directories listed in $PANGAEA_PATH.
+func ModulePaths() []string {
+	paths, ok := os.LookupEnv(PathKey)
+	if !ok || paths == "" {
+		return []string{}
+	}
+	return filepath.SplitList(paths)
+}
+
+// MaxDepth returns the maximum depth of func calls set in $PANGAEA_MAX_DEPTH.
+// It returns 0 (no limits) if the variable is not set or is not a positive int.
+func MaxDepth() int64 {
+	depth, err := strconv.ParseInt(os.Getenv(MaxDepthKey), 10, 64)
+	if err != nil || depth < 0 {
+		return 0
+	}
+	return depth
+}
@@ -7,9 +7,23 @@ import (
 	"strings"
 )
 
+// maxStackTraceLines is the maximum number of lines in stack trace.
+// NOTE: without this limit, appending stack trace of deep recursion takes quadratic time
+const maxStackTraceLines = 200
+
+// omittedStackTrace is appended to stack trace instead of omitted lines.
+const omittedStackTrace = "..."
+
 func appendStackTrace(e *object.PanErr, src *ast.Source) *object.PanErr {
 	var out bytes.Buffer
 
+	if strings.Count(e.StackTrace, "\n") >= maxStackTraceLines {
+		if !strings.HasSuffix(e.StackTrace, omittedStackTrace) {
+			e.StackTrace += "\n" + omittedStackTrace
+		}
+		return e
+	}
+
 	stackTrace := parseSrc(src)
 	// NOTE: if stackTrace is same as previous one, just ignore it
 	if strings.HasSuffix(e.StackTrace, stackTrace) {
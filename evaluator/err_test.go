@@ -40,3 +40,21 @@ func TestStackTrace(t *testing.T) {
 
 	}
 }
+
+func TestStackTraceOmitted(t *testing.T) {
+	actual := testEval(t, `f := {|n| 1 / 0 if n == 0 else f(n - 1) + 1}; f(500)`)
+
+	e, ok := actual.(*object.PanErr)
+	if !ok {
+		t.Fatalf("must be evaluated to Err. got=%T(%v)", actual, actual)
+	}
+
+	lines := strings.Split(e.StackTrace, "\n")
+	// NOTE: each frame has 2 lines and the last line is omittedStackTrace
+	if len(lines) > maxStackTraceLines+3 {
+		t.Errorf("stacktrace must have at most %d lines. got=%d", maxStackTraceLines+3, len(lines))
+	}
+	if lines[len(lines)-1] != omittedStackTrace {
+		t.Errorf("last line must be %s. got=%s", omittedStackTrace, lines[len(lines)-1])
+	}
+}
@@ -7,6 +7,12 @@ import (
 
 // Eval evaluates ast recursively.
 func Eval(node ast.Node, env *object.Env) object.PanObject {
+	if s := env.Sandbox(); s != nil {
+		if err := s.Step(); err != nil {
+			return err
+		}
+	}
+
 	switch node := node.(type) {
 	// Program
 	case *ast.Program:
@@ -39,6 +45,8 @@ func Eval(node ast.Node, env *object.Env) object.PanObject {
 		return evalFunc(node, env)
 	case *ast.IterLiteral:
 		return evalIter(node, env)
+	case *ast.MatchLiteral:
+		return evalMatch(node, env)
 	case *ast.DiamondLiteral:
 		return evalDiamond(node, env)
 	case *ast.Ident:
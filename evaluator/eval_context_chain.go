@@ -0,0 +1,110 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/Syuparn/pangaea/ast"
+	"github.com/Syuparn/pangaea/object"
+)
+
+// ChainContext is a user-defined chain context written in Go.
+// It is called with the receiver of the call (each element in list chains) and the name of the call,
+// and returns the result of the call. next evaluates the call with the receiver.
+type ChainContext func(
+	env *object.Env,
+	recv object.PanObject,
+	name string,
+	next func(recv object.PanObject) object.PanObject,
+) object.PanObject
+
+// NewChainContext returns a built-in func which can be used as chain context `^name`
+// if it is set to the variable name.
+func NewChainContext(ctx ChainContext) object.BuiltInFunc {
+	return func(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+		if len(args) < 3 {
+			return object.NewTypeErr("chain context requires at least 3 args")
+		}
+
+		name, ok := object.TraceProtoOfStr(args[1])
+		if !ok {
+			return object.NewTypeErr("\\2 must be str")
+		}
+
+		next := func(recv object.PanObject) object.PanObject {
+			return evalFuncCall(env, object.EmptyPanObjPtr(), args[2], recv)
+		}
+		return ctx(env, args[0], name.Value, next)
+	}
+}
+
+// evalChainContext returns the chain context `^name` in chain (nil if chain does not have it).
+// Chain contexts are funcs like `{|recv, name, next| next(recv)}`.
+func evalChainContext(chain *ast.Chain, env *object.Env) (object.PanObject, *object.PanErr) {
+	if chain.Additional != ast.UserDefined {
+		return nil, nil
+	}
+
+	ctx, ok := env.Get(object.GetSymHash(chain.Context))
+	if !ok {
+		return nil, object.NewNameErr(
+			fmt.Sprintf("chain context `%s` is not defined", chain.Context))
+	}
+	return ctx, nil
+}
+
+func callChainContext(
+	env *object.Env,
+	ctx object.PanObject,
+	recv object.PanObject,
+	name string,
+	next func(recv object.PanObject) object.PanObject,
+) object.PanObject {
+	nextFunc := object.NewPanBuiltInFunc(func(
+		env *object.Env,
+		kwargs *object.PanObj,
+		args ...object.PanObject,
+	) object.PanObject {
+		if len(args) < 1 {
+			return object.NewTypeErr("next requires at least 1 arg")
+		}
+		return next(args[0])
+	})
+
+	return evalFuncCall(env, object.EmptyPanObjPtr(), ctx, recv, object.NewPanStr(name), nextFunc)
+}
+
+func newPropCallContextChainMiddleware(ctx object.PanObject) _PropCallMiddleware {
+	return func(next _PropCallMiddlewareHandler) _PropCallMiddlewareHandler {
+		return func(
+			env *object.Env,
+			recv object.PanObject,
+			propName string,
+			_ object.PanObject,
+			chainArg object.PanObject,
+			args []object.PanObject,
+			kwargs *object.PanObj,
+		) object.PanObject {
+			return callChainContext(env, ctx, recv, propName, func(recv object.PanObject) object.PanObject {
+				return next(env, recv, propName, nil, chainArg, args, kwargs)
+			})
+		}
+	}
+}
+
+func newLiteralCallContextChainMiddleware(ctx object.PanObject) _LiteralCallMiddleware {
+	return func(next _LiteralCallMiddlewareHandler) _LiteralCallMiddlewareHandler {
+		return func(
+			env *object.Env,
+			recv object.PanObject,
+			chainArg object.PanObject,
+			args []object.PanObject,
+			kwargs *object.PanObj,
+		) object.PanObject {
+			// NOTE: literal is used as the name of the call
+			name := args[0].Repr()
+			return callChainContext(env, ctx, recv, name, func(recv object.PanObject) object.PanObject {
+				return next(env, recv, chainArg, args, kwargs)
+			})
+		}
+	}
+}
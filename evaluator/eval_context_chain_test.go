@@ -0,0 +1,161 @@
+package evaluator
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func TestEvalContextChain(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		// propcall
+		{
+			`ctx := {|recv, name, next| [name, next(recv)]}; "a"^ctx.uc`,
+			object.NewPanArr(object.NewPanStr("uc"), object.NewPanStr("A")),
+		},
+		{
+			`ctx := {|recv, name, next| next(recv) * 10}; [1, 2]^ctx@+(1)`,
+			object.NewPanArr(object.NewPanInt(20), object.NewPanInt(30)),
+		},
+		{
+			`ctx := {|recv, name, next| next(recv) * 10}; [1, 2]^ctx$(0)+`,
+			object.NewPanInt(120),
+		},
+		// literalcall
+		{
+			`ctx := {|recv, name, next| [name, next(recv)]}; 2^ctx.{|x| x * 3}`,
+			object.NewPanArr(object.NewPanStr("{|x| (x * 3)}"), object.NewPanInt(6)),
+		},
+		{
+			`ctx := {|recv, name, next| next(recv) + 1}; [1, 2]^ctx@{|x| x * 3}`,
+			object.NewPanArr(object.NewPanInt(4), object.NewPanInt(7)),
+		},
+		// varcall
+		{
+			`ctx := {|recv, name, next| next(recv) + 1}; f := {|x| x * 3}; [1, 2]^ctx@^f`,
+			object.NewPanArr(object.NewPanInt(4), object.NewPanInt(7)),
+		},
+		// next can be called with another receiver
+		{
+			`ctx := {|recv, name, next| next(recv + 1)}; [1, 2]^ctx@{|x| x * 3}`,
+			object.NewPanArr(object.NewPanInt(6), object.NewPanInt(9)),
+		},
+		// call can be skipped
+		{
+			`skipNil := {|recv, name, next| return 0 if recv == nil; next(recv)}; [1, nil]^skipNil@+(1)`,
+			object.NewPanArr(object.NewPanInt(2), object.NewPanInt(0)),
+		},
+		// list chain ignores nil returned by the context
+		{
+			`ctx := {|recv, name, next| nil}; [1, 2]^ctx@+(1)`,
+			object.NewPanArr(),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalContextChainErr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *object.PanErr
+	}{
+		{
+			`1^ctx.p`,
+			object.NewNameErr("chain context `ctx` is not defined"),
+		},
+		{
+			`ctx := 1; 1^ctx.p`,
+			object.NewTypeErr("1 is not callable."),
+		},
+		{
+			`ctx := {|recv, name, next| next()}; 1^ctx.p`,
+			object.NewTypeErr("next requires at least 1 arg"),
+		},
+		{
+			`ctx := {|recv, name, next| raise ValueErr.new("oops")}; 1^ctx.p`,
+			object.NewValueErr("oops"),
+		},
+		// errors in the call are propagated
+		{
+			`ctx := {|recv, name, next| next(recv)}; 1^ctx.undefinedProp`,
+			object.NewNoPropErr("property `undefinedProp` is not defined."),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testPanErr(t, actual, tt.expected)
+	}
+}
+
+func TestEvalContextChainWrittenInGo(t *testing.T) {
+	// timed records names of calls (instead of durations)
+	timed := []string{}
+	timedCtx := NewChainContext(func(
+		env *object.Env,
+		recv object.PanObject,
+		name string,
+		next func(recv object.PanObject) object.PanObject,
+	) object.PanObject {
+		ret := next(recv)
+		timed = append(timed, name)
+		return ret
+	})
+
+	// parallel evaluates the call with each element concurrently
+	parallelCtx := NewChainContext(func(
+		env *object.Env,
+		recv object.PanObject,
+		name string,
+		next func(recv object.PanObject) object.PanObject,
+	) object.PanObject {
+		arr, ok := object.TraceProtoOfArr(recv)
+		if !ok {
+			return next(recv)
+		}
+
+		elems := make([]object.PanObject, len(arr.Elems))
+		var wg sync.WaitGroup
+		for i, elem := range arr.Elems {
+			wg.Add(1)
+			go func(i int, elem object.PanObject) {
+				defer wg.Done()
+				elems[i] = next(elem)
+			}(i, elem)
+		}
+		wg.Wait()
+
+		for _, elem := range elems {
+			if err, ok := elem.(*object.PanErr); ok {
+				return err
+			}
+		}
+		return object.NewPanArr(elems...)
+	})
+
+	env := object.NewEnvWithConsts()
+	env.InjectFrom(object.BuiltInKernelObj)
+	env.Set(object.GetSymHash("timed"), object.NewPanBuiltInFunc(timedCtx))
+	env.Set(object.GetSymHash("parallel"), object.NewPanBuiltInFunc(parallelCtx))
+
+	actual := testEvalInEnv(t, `[1, 2, 3]^parallel.{|x| x * 2}`, env)
+	testValue(t, actual, object.NewPanArr(
+		object.NewPanInt(2), object.NewPanInt(4), object.NewPanInt(6)))
+
+	actual = testEvalInEnv(t, `[1, 2]^timed@+(1)`, env)
+	testValue(t, actual, object.NewPanArr(object.NewPanInt(2), object.NewPanInt(3)))
+	if len(timed) != 2 || timed[0] != "+" || timed[1] != "+" {
+		t.Errorf("wrong timed calls: %v", timed)
+	}
+
+	actual = testEvalInEnv(t, `[1, 'a]^parallel.{|x| x + 1}`, env)
+	testPanErr(t, actual, object.NewTypeErr("1 cannot be treated as str"))
+}
@@ -6,7 +6,11 @@ import (
 )
 
 func evalFunc(node *ast.FuncLiteral, env *object.Env) object.PanObject {
-	return evalCallable(node.FuncComponent, env, object.FuncFunc)
+	f := evalCallable(node.FuncComponent, env, object.FuncFunc)
+	if fn, ok := f.(*object.PanFunc); ok {
+		fn.Doc = node.Doc
+	}
+	return f
 }
 
 func evalCallable(
@@ -14,23 +18,39 @@ func evalCallable(
 	env *object.Env,
 	funcKind object.FuncKind,
 ) object.PanObject {
+	wrapper, err := newFuncWrapper(component, component.Kwargs, env)
+	if err != nil {
+		return err
+	}
+
+	if funcKind == object.FuncFunc {
+		return object.NewPanFunc(wrapper, object.NewEnclosedEnv(env))
+	}
+
+	return object.NewPanIter(wrapper, object.NewEnclosedEnv(env))
+}
+
+func newFuncWrapper(
+	component ast.FuncComponent,
+	kwargNodes map[*ast.Ident]ast.Expr,
+	env *object.Env,
+) (*FuncWrapperImpl, *object.PanErr) {
 	args := []object.PanObject{}
+	hasPattern := false
 	for _, argNode := range component.Args {
-		var arg object.PanObject
 		if ident, ok := argNode.(*ast.Ident); ok {
-			arg = object.NewPanStr(ident.String())
-		} else {
-			// TODO: error handling for pattern match exprs
-			arg = Eval(argNode, env)
+			args = append(args, object.NewPanStr(ident.String()))
+			continue
 		}
 
-		args = append(args, arg)
+		// destructuring pattern like `[a, b]` (bound when the func is called)
+		args = append(args, object.NewPanStr(argNode.String()))
+		hasPattern = true
 	}
 
-	kwargs, err := evalKwargs(component.Kwargs, env)
-
+	kwargs, err := evalKwargs(kwargNodes, env)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	wrapper := &FuncWrapperImpl{
@@ -39,10 +59,9 @@ func evalCallable(
 		kwargs:  kwargs,
 		body:    &component.Body,
 	}
-
-	if funcKind == object.FuncFunc {
-		return object.NewPanFunc(wrapper, object.NewEnclosedEnv(env))
+	if hasPattern {
+		wrapper.patterns = component.Args
 	}
 
-	return object.NewPanIter(wrapper, object.NewEnclosedEnv(env))
+	return wrapper, nil
 }
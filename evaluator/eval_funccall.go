@@ -3,6 +3,7 @@ package evaluator
 import (
 	"fmt"
 
+	"github.com/Syuparn/pangaea/ast"
 	"github.com/Syuparn/pangaea/object"
 )
 
@@ -40,33 +41,84 @@ func evalPanFuncCall(
 ) object.PanObject {
 	// NOTE: copy is necessary otherwise recurred call breaks outer env! (see TestEvalRecurredFuncCall)
 	e := object.NewCopiedEnv(f.Env)
-	assignArgsToEnv(e, f.Args().Elems, f.Kwargs(), args, kwargs)
+
+	// NOTE: sandbox of the caller is used because f may be defined outside of the sandbox (e.g. native code)
+	if s := env.Sandbox(); s != nil {
+		e.SetSandbox(s)
+	}
+	if s := e.Sandbox(); s != nil {
+		if err := s.Enter(); err != nil {
+			return err
+		}
+		defer s.Leave()
+	}
+
+	if f.Sig != nil {
+		if err := checkArgsSig(env, f, args, kwargs); err != nil {
+			return err
+		}
+	}
+
+	if err := assignArgsToEnv(e, f.FuncWrapper, args, kwargs); err != nil {
+		return err
+	}
 	retVal := evalStmts(*f.Body(), e)
 
 	if err, ok := retVal.(*object.PanErr); ok {
 		return appendStackTrace(err, (*f.Body())[0].Source())
 	}
 
+	if f.Sig != nil {
+		if err := checkRetSig(env, f, retVal); err != nil {
+			return err
+		}
+	}
+
 	return retVal
 }
 
 func assignArgsToEnv(
 	env *object.Env,
-	params []object.PanObject,
-	kwargParams *object.PanObj,
+	f object.FuncWrapper,
 	args []object.PanObject,
 	kwargs *object.PanObj,
-) {
-	// nil padding if arity of args is fewer than that of params
-	args = paddedArgs(args, params)
+) *object.PanErr {
+	matched, err := assignArgsToEnvIfMatched(env, f, args, kwargs)
+	if err != nil {
+		return err
+	}
 
-	for i, param := range params {
-		ident, ok := param.(*object.PanStr)
+	if !matched {
+		return object.NewMatchErr(fmt.Sprintf("%s does not match params %s",
+			object.NewPanArr(args...).Repr(), paramsStr(paramPatterns(f))))
+	}
+	return nil
+}
 
-		if ok {
-			env.Set(object.GetSymHash(ident.Value), args[i])
-		} else {
-			// TODO: pattern matching
+// assignArgsToEnvIfMatched assigns args to env only if args match destructuring patterns of params.
+func assignArgsToEnvIfMatched(
+	env *object.Env,
+	f object.FuncWrapper,
+	args []object.PanObject,
+	kwargs *object.PanObj,
+) (bool, *object.PanErr) {
+	params := f.Args().Elems
+	kwargParams := f.Kwargs()
+
+	if patterns := paramPatterns(f); patterns != nil {
+		// destructure args
+		ok, err := matchParams(patterns, args, env)
+		if !ok || err != nil {
+			return ok, err
+		}
+	} else {
+		// nil padding if arity of args is fewer than that of params
+		args = paddedArgs(args, params)
+
+		for i, param := range params {
+			if ident, ok := param.(*object.PanStr); ok {
+				env.Set(object.GetSymHash(ident.Value), args[i])
+			}
 		}
 	}
 
@@ -99,6 +151,15 @@ func assignArgsToEnv(
 	}
 	// `\_`
 	env.Set(object.GetSymHash("\\_"), kwargs)
+	return true, nil
+}
+
+// paramPatterns returns destructuring patterns of params (or nil if all params are idents).
+func paramPatterns(f object.FuncWrapper) []ast.Expr {
+	if w, ok := f.(*FuncWrapperImpl); ok {
+		return w.patterns
+	}
+	return nil
 }
 
 func paddedArgs(args []object.PanObject, params []object.PanObject) []object.PanObject {
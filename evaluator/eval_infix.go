@@ -27,6 +27,10 @@ func evalInfix(node *ast.InfixExpr, env *object.Env) object.PanObject {
 	ret := builtInCallProp(env, object.EmptyPanObjPtr(),
 		object.EmptyPanObjPtr(), left, propSym, right)
 
+	if reflected, ok := evalReflectedInfix(env, node.Operator, left, right, ret); ok {
+		ret = reflected
+	}
+
 	if err, ok := ret.(*object.PanErr); ok {
 		return appendStackTrace(err, node.Source())
 	}
@@ -34,6 +38,61 @@ func evalInfix(node *ast.InfixExpr, env *object.Env) object.PanObject {
 	return ret
 }
 
+// reflectedOps are names of reflected operator props.
+// If left cannot handle the operator, reflected prop of right is called instead
+// (e.g. `2 * v` is evaluated to `v._rmul(2)` if v has `_rmul`).
+var reflectedOps = map[string]string{
+	"+":  "_radd",
+	"-":  "_rsub",
+	"*":  "_rmul",
+	"/":  "_rdiv",
+	"//": "_rfloorDiv",
+	"%":  "_rmod",
+	"**": "_rpow",
+	"<<": "_rlshift",
+	">>": "_rrshift",
+	"/&": "_rbitAnd",
+	"/|": "_rbitOr",
+	"/^": "_rbitXor",
+}
+
+func evalReflectedInfix(
+	env *object.Env,
+	op string,
+	left object.PanObject,
+	right object.PanObject,
+	ret object.PanObject,
+) (object.PanObject, bool) {
+	name, ok := reflectedOps[op]
+	if !ok {
+		return nil, false
+	}
+
+	if !isUnsupportedOperand(left, op, ret) {
+		return nil, false
+	}
+
+	if _, ok := object.FindPropAlongProtos(right, object.GetSymHash(name)); !ok {
+		return nil, false
+	}
+
+	return builtInCallProp(env, object.EmptyPanObjPtr(),
+		object.EmptyPanObjPtr(), right, object.NewPanStr(name), left), true
+}
+
+// isUnsupportedOperand returns whether left cannot handle the operator with right.
+func isUnsupportedOperand(left object.PanObject, op string, ret object.PanObject) bool {
+	if _, ok := object.FindPropAlongProtos(left, object.GetSymHash(op)); !ok {
+		return true
+	}
+
+	err, ok := ret.(*object.PanErr)
+	if !ok {
+		return false
+	}
+	return err.ErrKind == object.TypeErr || err.ErrKind == object.NoPropErr
+}
+
 func isShortCutOperator(op string) bool {
 	switch op {
 	case "||":
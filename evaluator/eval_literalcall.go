@@ -24,7 +24,12 @@ func evalLiteralCall(node *ast.LiteralCallExpr, env *object.Env) object.PanObjec
 		return appendStackTrace(err, node.Source())
 	}
 
-	chainMiddleware := newLiteralCallChainMiddleware(*node.Chain)
+	ctx, err := evalChainContext(node.Chain, env)
+	if err != nil {
+		return appendStackTrace(err, node.Source())
+	}
+
+	chainMiddleware := newLiteralCallChainMiddleware(*node.Chain, ctx)
 	ret := _evalLiteralCall(env, recv, chainArg, f,
 		chainMiddleware, literalProxyMiddleware)
 	if err, ok := ret.(*object.PanErr); ok {
@@ -64,6 +69,10 @@ func literalCallHandler(
 		return evalFuncCall(env, kwargs, argsToPass...)
 	}
 
+	if _, ok := object.TraceProtoOfMatch(args[0]); ok {
+		return evalMatchCall(env, kwargs, args[0], recv)
+	}
+
 	builtIn, ok := object.TraceProtoOfBuiltInFunc(args[0])
 	if ok {
 		// TODO: extract elems if there are more than 1 params and recv is arr
@@ -32,6 +32,7 @@ func mergeLiteralCallMiddlewares(middlewares ..._LiteralCallMiddleware) _Literal
 
 func newLiteralCallChainMiddleware(
 	chain ast.Chain,
+	ctx object.PanObject,
 ) _LiteralCallMiddleware {
 	// `=@` chain keeps evaluated nil elements
 	if chain.Main == ast.List && chain.Additional == ast.Strict {
@@ -51,7 +52,7 @@ func newLiteralCallChainMiddleware(
 
 	// the other chain middlewares simply consists of two chain middlewares
 	mainMiddleware := newLiteralCallMainChainMiddleware(chain.Main)
-	additionalMiddleware := newLiteralCallAdditionalChainMiddleware(chain.Additional)
+	additionalMiddleware := newLiteralCallAdditionalChainMiddleware(chain.Additional, ctx)
 	return mergeLiteralCallMiddlewares(mainMiddleware, additionalMiddleware)
 }
 
@@ -235,7 +236,7 @@ func literalCallThoughtfulReduceChainMiddleware(next _LiteralCallMiddlewareHandl
 	}
 }
 
-func newLiteralCallAdditionalChainMiddleware(c ast.AdditionalChain) _LiteralCallMiddleware {
+func newLiteralCallAdditionalChainMiddleware(c ast.AdditionalChain, ctx object.PanObject) _LiteralCallMiddleware {
 	switch c {
 	case ast.Lonely:
 		return literalCallLonelyChainMiddleware
@@ -244,6 +245,8 @@ func newLiteralCallAdditionalChainMiddleware(c ast.AdditionalChain) _LiteralCall
 	// NOTE: newChainMiddleware deals with combination `=@`
 	case ast.Strict:
 		return literalCallNothingMiddleware
+	case ast.UserDefined:
+		return newLiteralCallContextChainMiddleware(ctx)
 	default:
 		return literalCallNothingMiddleware
 	}
@@ -0,0 +1,126 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/Syuparn/pangaea/ast"
+	"github.com/Syuparn/pangaea/object"
+)
+
+// guardKey is a kwarg key to designate guard of the pattern (like `|x, when: x > 0|`).
+const guardKey = "when"
+
+func evalMatch(node *ast.MatchLiteral, env *object.Env) object.PanObject {
+	patterns := []object.FuncWrapper{}
+
+	for _, component := range node.Patterns {
+		// NOTE: guard is evaluated only when the pattern is matched
+		kwargNodes := map[*ast.Ident]ast.Expr{}
+		var guard ast.Expr
+		for ident, expr := range component.Kwargs {
+			if ident.Value == guardKey {
+				guard = expr
+				continue
+			}
+			kwargNodes[ident] = expr
+		}
+
+		wrapper, err := newFuncWrapper(*component, kwargNodes, env)
+		if err != nil {
+			return appendStackTrace(err, node.Source())
+		}
+		wrapper.guard = guard
+		// NOTE: patterns are always used even if all params are idents
+		wrapper.patterns = component.Args
+
+		patterns = append(patterns, wrapper)
+	}
+
+	wrapper := &MatchWrapperImpl{
+		codeStr:  node.String(),
+		patterns: patterns,
+	}
+
+	return object.NewPanMatch(wrapper, object.NewEnclosedEnv(env))
+}
+
+// used for Match#call
+func evalMatchCall(
+	env *object.Env,
+	kwargs *object.PanObj,
+	args ...object.PanObject,
+) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("Match#call requires at least 1 arg")
+	}
+
+	self, ok := object.TraceProtoOfMatch(args[0])
+	if !ok {
+		return object.NewTypeErr(
+			fmt.Sprintf("%s is not callable.", args[0].Inspect()))
+	}
+	// unshift args to ignore match itself
+	args = args[1:]
+
+	if s := env.Sandbox(); s != nil {
+		if err := s.Enter(); err != nil {
+			return err
+		}
+		defer s.Leave()
+	}
+
+	for _, pattern := range self.Patterns() {
+		e := object.NewCopiedEnv(self.Env)
+		if s := env.Sandbox(); s != nil {
+			e.SetSandbox(s)
+		}
+
+		matched, err := matchArgs(e, pattern, args, kwargs)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
+		retVal := evalStmts(*pattern.Body(), e)
+		if err, ok := retVal.(*object.PanErr); ok && len(*pattern.Body()) > 0 {
+			return appendStackTrace(err, (*pattern.Body())[0].Source())
+		}
+		return retVal
+	}
+
+	return object.NewMatchErr(
+		fmt.Sprintf("%s does not match any pattern", matchedValueRepr(args)))
+}
+
+// matchArgs assigns args to env and checks the guard if args match the pattern.
+func matchArgs(
+	env *object.Env,
+	pattern object.FuncWrapper,
+	args []object.PanObject,
+	kwargs *object.PanObj,
+) (bool, *object.PanErr) {
+	matched, err := assignArgsToEnvIfMatched(env, pattern, args, kwargs)
+	if !matched || err != nil {
+		return false, err
+	}
+
+	w, ok := pattern.(*FuncWrapperImpl)
+	if !ok || w.guard == nil {
+		return true, nil
+	}
+
+	cond := Eval(w.guard, env)
+	if err, ok := cond.(*object.PanErr); ok {
+		return false, appendStackTrace(err, w.guard.Source())
+	}
+	return isTruthy(cond, env), nil
+}
+
+func matchedValueRepr(args []object.PanObject) string {
+	if len(args) == 1 {
+		return args[0].Repr()
+	}
+	return object.NewPanArr(args...).Repr()
+}
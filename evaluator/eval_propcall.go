@@ -26,7 +26,12 @@ func evalPropCall(node *ast.PropCallExpr, env *object.Env) object.PanObject {
 		return appendStackTrace(err, node.Source())
 	}
 
-	chainMiddleware := newChainMiddleware(*node.Chain)
+	ctx, err := evalChainContext(node.Chain, env)
+	if err != nil {
+		return appendStackTrace(err, node.Source())
+	}
+
+	chainMiddleware := newChainMiddleware(*node.Chain, ctx)
 	ret := _evalPropCall(env, recv, chainArg, node.Prop.Value, args, kwargs,
 		chainMiddleware)
 	if err, ok := ret.(*object.PanErr); ok {
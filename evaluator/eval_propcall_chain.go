@@ -29,6 +29,7 @@ func mergePropCallMiddlewares(middlewares ..._PropCallMiddleware) _PropCallMiddl
 
 func newChainMiddleware(
 	chain ast.Chain,
+	ctx object.PanObject,
 ) _PropCallMiddleware {
 	// `=@` chain keeps evaluated nil elements
 	if chain.Main == ast.List && chain.Additional == ast.Strict {
@@ -48,7 +49,7 @@ func newChainMiddleware(
 
 	// the other chain middlewares simply consists of two chain middlewares
 	mainMiddleware := newPropCallMainChainMiddleware(chain.Main)
-	additionalMiddleware := newPropCallAdditionalChainMiddleware(chain.Additional)
+	additionalMiddleware := newPropCallAdditionalChainMiddleware(chain.Additional, ctx)
 	return mergePropCallMiddlewares(
 		mainMiddleware,
 		additionalMiddleware,
@@ -234,7 +235,7 @@ func propCallReduceChainMiddleware(next _PropCallMiddlewareHandler) _PropCallMid
 	}
 }
 
-func newPropCallAdditionalChainMiddleware(c ast.AdditionalChain) _PropCallMiddleware {
+func newPropCallAdditionalChainMiddleware(c ast.AdditionalChain, ctx object.PanObject) _PropCallMiddleware {
 	switch c {
 	case ast.Lonely:
 		return propCallLonelyChainMiddleware
@@ -243,6 +244,8 @@ func newPropCallAdditionalChainMiddleware(c ast.AdditionalChain) _PropCallMiddle
 	// NOTE: newChainMiddleware deals with combination `=@`
 	case ast.Strict:
 		return propCallNothingMiddleware
+	case ast.UserDefined:
+		return newPropCallContextChainMiddleware(ctx)
 	default:
 		return propCallNothingMiddleware
 	}
@@ -7,8 +7,10 @@ package evaluator
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -25,6 +27,7 @@ func TestMain(m *testing.M) {
 	ctn["Str_eval"] = object.NewNotImplementedErr("not implemented in evaluator")
 	ctn["Str_evalEnv"] = object.NewNotImplementedErr("not implemented in evaluator")
 	ctn["Kernel_import"] = object.NewNotImplementedErr("not implemented in evaluator")
+	ctn["Kernel_reload"] = object.NewNotImplementedErr("not implemented in evaluator")
 	injectBuiltInProps(ctn)
 	ret := m.Run()
 	os.Exit(ret)
@@ -34,6 +37,7 @@ func injectBuiltInProps(ctn map[string]object.PanObject) {
 	injectProps(object.BuiltInAssertionErr, props.AssertionErrProps, ctn)
 	injectProps(object.BuiltInArrObj, props.ArrProps, ctn)
 	injectProps(object.BuiltInBaseObj, props.BaseObjProps, ctn)
+	injectProps(object.BuiltInBytesObj, props.BytesProps, ctn)
 	injectProps(object.BuiltInComparableObj, props.ComparableProps, ctn)
 	injectProps(object.BuiltInDiamondObj, props.DiamondProps, ctn)
 	injectProps(object.BuiltInEitherObj, props.EitherProps, ctn)
@@ -49,13 +53,20 @@ func injectBuiltInProps(ctn map[string]object.PanObject) {
 	injectProps(object.BuiltInKernelObj, props.KernelProps, ctn)
 	injectProps(object.BuiltInMatchObj, props.MatchProps, ctn)
 	injectProps(object.BuiltInMapObj, props.MapProps, ctn)
+	injectProps(object.BuiltInImportErr, props.ImportErrProps, ctn)
+	injectProps(object.BuiltInLimitErr, props.LimitErrProps, ctn)
+	injectProps(object.BuiltInMatchErr, props.MatchErrProps, ctn)
 	injectProps(object.BuiltInNameErr, props.NameErrProps, ctn)
 	injectProps(object.BuiltInNilObj, props.NilProps, ctn)
 	injectProps(object.BuiltInNoPropErr, props.NoPropErrProps, ctn)
 	injectProps(object.BuiltInNotImplementedErr, props.NotImplementedErrProps, ctn)
+	injectProps(object.BuiltInPermissionErr, props.PermissionErrProps, ctn)
+	injectProps(object.BuiltInRecursionErr, props.RecursionErrProps, ctn)
 	injectProps(object.BuiltInNumObj, props.NumProps, ctn)
 	injectProps(object.BuiltInObjObj, props.ObjProps, ctn)
 	injectProps(object.BuiltInRangeObj, props.RangeProps, ctn)
+	injectProps(object.BuiltInRefObj, props.RefProps, ctn)
+	injectProps(object.BuiltInSetObj, props.SetProps, ctn)
 	injectProps(object.BuiltInStopIterErr, props.StopIterErrProps, ctn)
 	injectProps(object.BuiltInStrObj, props.StrProps, ctn)
 	injectProps(object.BuiltInSyntaxErr, props.SyntaxErrProps, ctn)
@@ -467,6 +478,286 @@ func TestEvalNumRound(t *testing.T) {
 			`Num['round]("a")`,
 			object.NewTypeErr("\"a\" cannot be treated as num"),
 		},
+		// digits
+		{
+			`3.14159.round(digits: 2)`,
+			object.NewPanFloat(3.14),
+		},
+		{
+			`1234.5.round(digits: -2)`,
+			object.NewPanInt(1200),
+		},
+		{
+			`1250.round(digits: -2)`,
+			object.NewPanInt(1300),
+		},
+		{
+			`-1250.round(digits: -2)`,
+			object.NewPanInt(-1300),
+		},
+		{
+			`1249.round(digits: -2)`,
+			object.NewPanInt(1200),
+		},
+		{
+			`12.round(digits: 2)`,
+			object.NewPanInt(12),
+		},
+		{
+			`12.round(digits: -20)`,
+			object.NewPanInt(0),
+		},
+		// digits exceeding the precision of float do not change self
+		{
+			`1.5.round(digits: 400)`,
+			object.NewPanFloat(1.5),
+		},
+		{
+			`1.5.round(digits: 17)`,
+			object.NewPanFloat(1.5),
+		},
+		{
+			`1.25e-20.round(digits: 21)`,
+			object.NewPanFloat(1.3e-20),
+		},
+		{
+			`1234.5.round(digits: -400)`,
+			object.NewPanInt(0),
+		},
+		{
+			`1.0e19.round`,
+			object.NewValueErr("1e+19 cannot be rounded into int"),
+		},
+		{
+			`-9.3e18.round(digits: -2)`,
+			object.NewValueErr("-9.3e+18 cannot be rounded into int"),
+		},
+		// half
+		{
+			`2.5.round(half: "even")`,
+			object.NewPanInt(2),
+		},
+		{
+			`3.5.round(half: "even")`,
+			object.NewPanInt(4),
+		},
+		{
+			`2.5.round(half: "down")`,
+			object.NewPanInt(2),
+		},
+		{
+			`-2.5.round(half: "down")`,
+			object.NewPanInt(-2),
+		},
+		{
+			`2.6.round(half: "down")`,
+			object.NewPanInt(3),
+		},
+		{
+			`2.5.round(half: "up")`,
+			object.NewPanInt(3),
+		},
+		{
+			`1250.round(digits: -2, half: "even")`,
+			object.NewPanInt(1200),
+		},
+		{
+			`1350.round(digits: -2, half: "even")`,
+			object.NewPanInt(1400),
+		},
+		{
+			`1250.round(digits: -2, half: "down")`,
+			object.NewPanInt(1200),
+		},
+		{
+			`1.5.round(digits: "a")`,
+			object.NewTypeErr("\"a\" cannot be treated as int"),
+		},
+		{
+			`1.5.round(half: "odd")`,
+			object.NewValueErr(`half "odd" must be "up", "even" or "down"`),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIntDigits(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`1203.digits`,
+			object.NewPanArr(object.NewPanInt(3), object.NewPanInt(0), object.NewPanInt(2), object.NewPanInt(1)),
+		},
+		{
+			`0.digits`,
+			object.NewPanArr(object.NewPanInt(0)),
+		},
+		{
+			`0xabc.digits(base: 16)`,
+			object.NewPanArr(object.NewPanInt(12), object.NewPanInt(11), object.NewPanInt(10)),
+		},
+		{
+			`6.digits(base: 2)`,
+			object.NewPanArr(object.NewPanInt(0), object.NewPanInt(1), object.NewPanInt(1)),
+		},
+		{
+			`Int['digits]()`,
+			object.NewTypeErr("Int#digits requires at least 1 arg"),
+		},
+		{
+			`Int['digits]("a")`,
+			object.NewTypeErr(`\1 must be int`),
+		},
+		{
+			`10.digits(base: "a")`,
+			object.NewTypeErr(`"a" cannot be treated as int`),
+		},
+		{
+			`10.digits(base: 1)`,
+			object.NewValueErr("base 1 must be greater than 1"),
+		},
+		{
+			`-10.digits`,
+			object.NewValueErr("digits of negative int -10 are not defined"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIntGcd(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{`12.gcd(18)`, object.NewPanInt(6)},
+		{`-12.gcd(18)`, object.NewPanInt(6)},
+		{`7.gcd(5)`, object.NewPanInt(1)},
+		{`0.gcd(5)`, object.NewPanInt(5)},
+		{`0.gcd(0)`, object.NewPanInt(0)},
+		{`(-9223372036854775807 - 1).gcd(6)`, object.NewPanInt(2)},
+		{`(-9223372036854775807 - 1).gcd(-9223372036854775807)`, object.NewPanInt(1)},
+		{
+			`(-9223372036854775807 - 1).gcd(0)`,
+			object.NewValueErr("gcd of -9223372036854775808 and 0 overflows int"),
+		},
+		{
+			`Int['gcd](1)`,
+			object.NewTypeErr("Int#gcd requires at least 2 args"),
+		},
+		{
+			`1.gcd("a")`,
+			object.NewTypeErr(`"a" cannot be treated as int`),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIntLcm(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{`4.lcm(6)`, object.NewPanInt(12)},
+		{`-4.lcm(6)`, object.NewPanInt(12)},
+		{`0.lcm(6)`, object.NewPanInt(0)},
+		{`4611686018427387904.lcm(3)`, object.NewValueErr("lcm of 4611686018427387904 and 3 overflows int")},
+		{`4611686018427387904.lcm(2)`, object.NewPanInt(4611686018427387904)},
+		{`(-9223372036854775807 - 1).lcm(2)`, object.NewValueErr("lcm of -9223372036854775808 and 2 overflows int")},
+		{`3037000500.lcm(3037000501)`, object.NewValueErr("lcm of 3037000500 and 3037000501 overflows int")},
+		{
+			`Int['lcm](1)`,
+			object.NewTypeErr("Int#lcm requires at least 2 args"),
+		},
+		{
+			`1.lcm("a")`,
+			object.NewTypeErr(`"a" cannot be treated as int`),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIntPopcount(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{`0.popcount`, object.NewPanInt(0)},
+		{`255.popcount`, object.NewPanInt(8)},
+		{`0b1011.popcount`, object.NewPanInt(3)},
+		// absolute value is used
+		{`-3.popcount`, object.NewPanInt(2)},
+		{
+			`Int['popcount]()`,
+			object.NewTypeErr("Int#popcount requires at least 1 arg"),
+		},
+		{
+			`Int['popcount]("a")`,
+			object.NewTypeErr(`\1 must be int`),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIntPowMod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{`3.powMod(4, 5)`, object.NewPanInt(1)},
+		{`2.powMod(100, 1000000007)`, object.NewPanInt(976371285)},
+		{`5.powMod(0, 3)`, object.NewPanInt(1)},
+		// result is not negative
+		{`-3.powMod(3, 5)`, object.NewPanInt(3)},
+		{
+			`Int['powMod](1, 2)`,
+			object.NewTypeErr("Int#powMod requires at least 3 args"),
+		},
+		{
+			`Int['powMod]("a", 1, 2)`,
+			object.NewTypeErr(`\1 must be int`),
+		},
+		{
+			`1.powMod("a", 2)`,
+			object.NewTypeErr(`"a" cannot be treated as int`),
+		},
+		{
+			`1.powMod(2, "a")`,
+			object.NewTypeErr(`"a" cannot be treated as int`),
+		},
+		{
+			`2.powMod(-1, 5)`,
+			object.NewValueErr("exponent -1 must not be negative"),
+		},
+		{
+			`2.powMod(3, 0)`,
+			object.NewZeroDivisionErr("cannot be divided by 0"),
+		},
+		{
+			`2.powMod(3, -5)`,
+			object.NewValueErr("modulus -5 must be positive"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -984,6 +1275,35 @@ func TestEvalStrSymp(t *testing.T) {
 	}
 }
 
+func TestEvalStrHasp(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{`"abc".has?("bc")`, object.BuiltInTrue},
+		{`"abc".has?("")`, object.BuiltInTrue},
+		{`"abc".has?("ac")`, object.BuiltInFalse},
+		{`"にほんご".has?("ほん")`, object.BuiltInTrue},
+		{
+			`Str['has?]("a")`,
+			object.NewTypeErr("Str#has? requires at least 2 args"),
+		},
+		{
+			`Str['has?](1, "a")`,
+			object.NewTypeErr(`\1 must be str`),
+		},
+		{
+			`"a".has?(1)`,
+			object.NewTypeErr("1 cannot be treated as str"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
 func TestEvalStrUc(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1582,205 +1902,204 @@ func TestEvalArrLen(t *testing.T) {
 	}
 }
 
-func TestEvalObjLiteral(t *testing.T) {
+func TestEvalArrSort(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected *object.PanObj
+		expected object.PanObject
 	}{
 		{
-			`{}`,
-			toPanObj([]object.Pair{}),
+			`[3, 1, 2].sort`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
+				object.NewPanInt(3),
+			),
 		},
 		{
-			`{a: 1}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanInt(1),
-				},
-			}),
+			`[3, 1, 2].sort(rev: true)`,
+			object.NewPanArr(
+				object.NewPanInt(3),
+				object.NewPanInt(2),
+				object.NewPanInt(1),
+			),
 		},
 		{
-			`{a: 1, b: 2}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanInt(1),
-				},
-				{
-					Key:   object.NewPanStr("b"),
-					Value: object.NewPanInt(2),
-				},
-			}),
+			`[3, 1, 2].sort(cmp: {|a, b| b <=> a})`,
+			object.NewPanArr(
+				object.NewPanInt(3),
+				object.NewPanInt(2),
+				object.NewPanInt(1),
+			),
 		},
-		// dangling keys are ignored (in this example, `a: 3` is ignored)
+		// equal elements keep their order
 		{
-			`{a: 1, b: 2, a: 3}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanInt(1),
-				},
-				{
-					Key:   object.NewPanStr("b"),
-					Value: object.NewPanInt(2),
-				},
-			}),
+			`[[1, 'a], [0, 'b], [1, 'c]].sort(cmp: {|a, b| a[0] <=> b[0]})`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(0), object.NewPanStr("b")),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanStr("a")),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanStr("c")),
+			),
 		},
-		// Obj can contain multiple types
+		// use descendant of arr for recv
 		{
-			`{a: 1, b: "B"}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanInt(1),
-				},
-				{
-					Key:   object.NewPanStr("b"),
-					Value: object.NewPanStr("B"),
-				},
-			}),
-		},
-		// nested
-		{
-			`{a: {b: 10}}`,
-			toPanObj([]object.Pair{
-				{
-					Key: object.NewPanStr("a"),
-					Value: toPanObj([]object.Pair{
-						{
-							Key:   object.NewPanStr("b"),
-							Value: object.NewPanInt(10),
-						},
-					}),
-				},
-			}),
+			`[2, 1].bear.sort`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
+			),
 		},
-		// embedded
-		// NOTE: embedded elems must be after normal pairs (`{**a, b: 2}` is syntax error)
+		// err raised in cmp is propagated
 		{
-			`{a: 1, **{b: 2}}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanInt(1),
-				},
-				{
-					Key:   object.NewPanStr("b"),
-					Value: object.NewPanInt(2),
-				},
-			}),
+			`[1, 2].sort(cmp: {|a, b| a + 'x})`,
+			object.NewTypeErr(`"x" cannot be treated as int`),
 		},
 		{
-			`{**{a: 1}, **{b: 2}}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanInt(1),
-				},
-				{
-					Key:   object.NewPanStr("b"),
-					Value: object.NewPanInt(2),
-				},
-			}),
+			`[1, 2].sort(cmp: {|a, b| 'x})`,
+			object.NewValueErr(`cmp returned non-int value "x"`),
 		},
-		// dangling keys in embedded obj are also ignored
 		{
-			`{x: 1, **{x: 2}}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("x"),
-					Value: object.NewPanInt(1),
-				},
-			}),
+			`[{}, {}].sort`,
+			object.NewValueErr(`<=> returned non-int value`),
 		},
+		// if no args are passed, raise an error
 		{
-			`{**{y: 1}, **{y: 2}}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("y"),
-					Value: object.NewPanInt(1),
-				},
-			}),
+			`Arr['sort]()`,
+			object.NewTypeErr("Arr#sort requires at least 1 arg"),
 		},
-		// enable to use descendant of str for key (str proto is set)
+		// if \1 is not arr, raise an error
 		{
-			`{"hoge".bear: 1}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("hoge"),
-					Value: object.NewPanInt(1),
-				},
-			}),
+			`Arr['sort](1)`,
+			object.NewTypeErr("\\1 must be arr"),
 		},
 	}
 
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
-		testPanObj(t, actual, tt.expected)
+		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalPinnedObjKey(t *testing.T) {
+func TestEvalArrSortBy(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected *object.PanObj
+		expected object.PanObject
 	}{
 		{
-			`a := "A"; {^a: 1}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("A"),
-					Value: object.NewPanInt(1),
-				},
-			}),
+			`['bb, 'a, 'dd, 'c].sortBy {|s| s.len}`,
+			object.NewPanArr(
+				object.NewPanStr("a"),
+				object.NewPanStr("c"),
+				object.NewPanStr("bb"),
+				object.NewPanStr("dd"),
+			),
 		},
-		// enable to use descendant of str for key (str proto is set)
 		{
-			`b := "B".bear; {^b: 1}`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("B"),
-					Value: object.NewPanInt(1),
-				},
-			}),
+			`['bb, 'a, 'dd, 'c].sortBy({|s| s.len}, rev: true)`,
+			object.NewPanArr(
+				object.NewPanStr("bb"),
+				object.NewPanStr("dd"),
+				object.NewPanStr("a"),
+				object.NewPanStr("c"),
+			),
+		},
+		{
+			`[{s: 2}, {s: 1}].sortBy {|o| o.s}`,
+			object.NewPanArr(
+				toPanObj([]object.Pair{
+					{Key: object.NewPanStr("s"), Value: object.NewPanInt(1)},
+				}),
+				toPanObj([]object.Pair{
+					{Key: object.NewPanStr("s"), Value: object.NewPanInt(2)},
+				}),
+			),
+		},
+		// err raised in f is propagated
+		{
+			`[1, 2].sortBy {|i| i + 'x}`,
+			object.NewTypeErr(`"x" cannot be treated as int`),
+		},
+		// if arity is insufficient, raise an error
+		{
+			`[].sortBy`,
+			object.NewTypeErr("Arr#sortBy requires at least 2 args"),
+		},
+		// if \1 is not arr, raise an error
+		{
+			`Arr['sortBy](1, 'a)`,
+			object.NewTypeErr("\\1 must be arr"),
 		},
 	}
 
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
-		testPanObj(t, actual, tt.expected)
+		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalBuiltInCallProp(t *testing.T) {
+func TestEvalArrTopN(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
-		// non-callable
 		{
-			`{}.callProp({a: 1}, 'a)`,
-			object.NewPanInt(1),
+			`[3, 1, 4, 1, 5].topN(2)`,
+			object.NewPanArr(
+				object.NewPanInt(5),
+				object.NewPanInt(4),
+			),
 		},
-		// callable
 		{
-			`{}.callProp({a: {|| 2}}, 'a)`,
-			object.NewPanInt(2),
+			`[3, 1, 2].topN(5)`,
+			object.NewPanArr(
+				object.NewPanInt(3),
+				object.NewPanInt(2),
+				object.NewPanInt(1),
+			),
 		},
-		// NOTE: first arg (`self`) is receiver itself! (`{a: m{|x| x}}`)
 		{
-			`{}.callProp({a: m{|x| x}}, 'a, 3)`,
-			object.NewPanInt(3),
+			`[3, 1, 2].topN(0)`,
+			object.NewPanArr(),
 		},
+		// equal elements keep their order
 		{
-			`{}.callProp({a: m{|x, y, z: 1| [x, y, z]}}, 'a, 4, 5, z: 6)`,
+			`['bb, 'a, 'dd, 'c].topN(2, by: {|s| s.len})`,
 			object.NewPanArr(
-				object.NewPanInt(4),
-				object.NewPanInt(5),
-				object.NewPanInt(6),
+				object.NewPanStr("bb"),
+				object.NewPanStr("dd"),
+			),
+		},
+		{
+			`[3, 1, 2].topN(2, cmp: {|a, b| b <=> a})`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
 			),
 		},
+		// err raised in cmp is propagated
+		{
+			`[1, 2, 3].topN(2, cmp: {|a, b| a + 'x})`,
+			object.NewTypeErr(`"x" cannot be treated as int`),
+		},
+		// if arity is insufficient, raise an error
+		{
+			`[].topN`,
+			object.NewTypeErr("Arr#topN requires at least 2 args"),
+		},
+		// if \1 is not arr, raise an error
+		{
+			`Arr['topN](1, 2)`,
+			object.NewTypeErr("\\1 must be arr"),
+		},
+		// if \2 is not int, raise an error
+		{
+			`[1].topN('a)`,
+			object.NewTypeErr("\\2 must be int"),
+		},
+		// if \2 is negative, raise an error
+		{
+			`[1].topN(-1)`,
+			object.NewValueErr("\\2 must not be negative"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1789,285 +2108,179 @@ func TestEvalBuiltInCallProp(t *testing.T) {
 	}
 }
 
-func TestEvalObjLiteralInvalidKey(t *testing.T) {
+func TestEvalArrBottomN(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected *object.PanErr
+		expected object.PanObject
 	}{
 		{
-			`{1: 2}`,
-			object.NewTypeErr("cannot use `1` as Obj key."),
+			`[3, 1, 4, 1, 5].bottomN(3)`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(1),
+				object.NewPanInt(3),
+			),
 		},
+		// equal elements keep their order
 		{
-			`{a: 1, 2: 3}`,
-			object.NewTypeErr("cannot use `2` as Obj key."),
+			`['bb, 'a, 'dd, 'c].bottomN(3, by: {|s| s.len})`,
+			object.NewPanArr(
+				object.NewPanStr("a"),
+				object.NewPanStr("c"),
+				object.NewPanStr("bb"),
+			),
+		},
+		// if arity is insufficient, raise an error
+		{
+			`[].bottomN`,
+			object.NewTypeErr("Arr#bottomN requires at least 2 args"),
+		},
+		// if \2 is negative, raise an error
+		{
+			`[1].bottomN(-1)`,
+			object.NewValueErr("\\2 must not be negative"),
 		},
 	}
 
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
-		testPanErr(t, actual, tt.expected)
+		testValue(t, actual, tt.expected)
 	}
 }
 
-func toPanObj(pairs []object.Pair) *object.PanObj {
-	pairMap := map[object.SymHash]object.Pair{}
-
-	for _, pair := range pairs {
-		panStr, _ := pair.Key.(*object.PanStr)
-		symHash := object.GetSymHash(panStr.Value)
-		pairMap[symHash] = pair
+func TestEvalArrUniq(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`[1, 2, 1, 3, 2].uniq`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
+				object.NewPanInt(3),
+			),
+		},
+		// non-hashable elements are compared by ==
+		{
+			`[[1], [2], [1]].uniq`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(1)),
+				object.NewPanArr(object.NewPanInt(2)),
+			),
+		},
+		// if no args are passed, raise an error
+		{
+			`Arr['uniq]()`,
+			object.NewTypeErr("Arr#uniq requires at least 1 arg"),
+		},
+		// if \1 is not arr, raise an error
+		{
+			`Arr['uniq](1)`,
+			object.NewTypeErr("\\1 must be arr"),
+		},
 	}
 
-	obj := object.PanObjInstance(&pairMap)
-	return &obj
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
 }
 
-func TestEvalMapLiteral(t *testing.T) {
+func TestEvalArrUniqBy(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected *object.PanMap
+		expected object.PanObject
 	}{
 		{
-			`%{}`,
-			toPanMap([]object.Pair{}, []object.Pair{}),
-		},
-		{
-			`%{'a: 1}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("a"),
-						Value: object.NewPanInt(1),
-					},
-				},
-				[]object.Pair{},
+			`['a, 'bb, 'cc, 'd].uniqBy {|s| s.len}`,
+			object.NewPanArr(
+				object.NewPanStr("a"),
+				object.NewPanStr("bb"),
 			),
 		},
-		// map can contain keys other than str
+		// err raised in f is propagated
 		{
-			`%{'a: 1, 2: 3, true: 5}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("a"),
-						Value: object.NewPanInt(1),
-					},
-					{
-						Key:   object.NewPanInt(2),
-						Value: object.NewPanInt(3),
-					},
-					{
-						Key:   object.BuiltInTrue,
-						Value: object.NewPanInt(5),
-					},
-				},
-				[]object.Pair{},
-			),
+			`[1].uniqBy {|i| i + 'x}`,
+			object.NewTypeErr(`"x" cannot be treated as int`),
 		},
-		// dangling keys are ignored (in this example, `a: 3` is ignored)
+		// if arity is insufficient, raise an error
 		{
-			`%{'a: 1, 'b: 2, 'a: 3}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("a"),
-						Value: object.NewPanInt(1),
-					},
-					{
-						Key:   object.NewPanStr("b"),
-						Value: object.NewPanInt(2),
-					},
-				},
-				[]object.Pair{},
-			),
-		},
-		// Obj can contain multiple types
-		{
-			`%{"a": 1, "b": "B"}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("a"),
-						Value: object.NewPanInt(1),
-					},
-					{
-						Key:   object.NewPanStr("b"),
-						Value: object.NewPanStr("B"),
-					},
-				},
-				[]object.Pair{},
-			),
-		},
-		// nested
-		{
-			`%{'a: %{'b: 10}}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key: object.NewPanStr("a"),
-						Value: toPanMap(
-							[]object.Pair{
-								{
-									Key:   object.NewPanStr("b"),
-									Value: object.NewPanInt(10),
-								},
-							},
-							[]object.Pair{},
-						),
-					},
-				},
-				[]object.Pair{},
-			),
-		},
-		// embedded
-		// NOTE: embedded elems must be after normal pairs
-		// (`%{**a, b: 2}` is syntax error)
-		{
-			`%{'a: 1, **%{'b: 2}}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("a"),
-						Value: object.NewPanInt(1),
-					},
-					{
-						Key:   object.NewPanStr("b"),
-						Value: object.NewPanInt(2),
-					},
-				},
-				[]object.Pair{},
-			),
+			`[].uniqBy`,
+			object.NewTypeErr("Arr#uniqBy requires at least 2 args"),
 		},
-		// embedded non-scalar
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalArrGroupBy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
 		{
-			`%{'a: 1, **%{[]: 2}}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("a"),
-						Value: object.NewPanInt(1),
-					},
-				},
-				[]object.Pair{
-					{
-						Key:   object.NewPanArr(),
-						Value: object.NewPanInt(2),
-					},
+			`[1, 2, 3, 4, 5].groupBy {|i| i % 2}`,
+			object.NewPanMap(
+				object.Pair{
+					Key: object.NewPanInt(1),
+					Value: object.NewPanArr(
+						object.NewPanInt(1),
+						object.NewPanInt(3),
+						object.NewPanInt(5),
+					),
 				},
-			),
-		},
-		{
-			`%{**%{'a: 1}, **%{'b: 2}}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("a"),
-						Value: object.NewPanInt(1),
-					},
-					{
-						Key:   object.NewPanStr("b"),
-						Value: object.NewPanInt(2),
-					},
+				object.Pair{
+					Key: object.NewPanInt(0),
+					Value: object.NewPanArr(
+						object.NewPanInt(2),
+						object.NewPanInt(4),
+					),
 				},
-				[]object.Pair{},
 			),
 		},
-		// obj can be embedded to map (but map cannot be embedded to obj)
 		{
-			`%{**%{'a: 1}, **{b: 2}}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("a"),
-						Value: object.NewPanInt(1),
-					},
-					{
-						Key:   object.NewPanStr("b"),
-						Value: object.NewPanInt(2),
-					},
-				},
-				[]object.Pair{},
-			),
+			`[].groupBy {|i| i}`,
+			object.NewPanMap(),
 		},
-		// map can contain non-scalar keys
+		// err raised in f is propagated
 		{
-			`%{'a: 1, [1, 2]: 3, %{4: 5}: 6}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("a"),
-						Value: object.NewPanInt(1),
-					},
-				},
-				[]object.Pair{
-					{
-						Key: object.NewPanArr(
-							object.NewPanInt(1),
-							object.NewPanInt(2),
-						),
-						Value: object.NewPanInt(3),
-					},
-					{
-						Key: toPanMap(
-							[]object.Pair{
-								{
-									Key:   object.NewPanInt(4),
-									Value: object.NewPanInt(5),
-								},
-							},
-							[]object.Pair{},
-						),
-						Value: object.NewPanInt(6),
-					},
-				},
-			),
+			`[1].groupBy {|i| i + 'x}`,
+			object.NewTypeErr(`"x" cannot be treated as int`),
 		},
-		// non-hashable dangling keys are also ignored ([1,2]: 4 is ignored)
+		// if arity is insufficient, raise an error
 		{
-			`%{[1, 2]: 3, [1, 2]: 4}`,
-			toPanMap(
-				[]object.Pair{},
-				[]object.Pair{
-					{
-						Key: object.NewPanArr(
-							object.NewPanInt(1),
-							object.NewPanInt(2),
-						),
-						Value: object.NewPanInt(3),
-					},
-				},
-			),
+			`[].groupBy`,
+			object.NewTypeErr("Arr#groupBy requires at least 2 args"),
 		},
 	}
 
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
-		testPanMap(t, actual, tt.expected)
+		testValue(t, actual, tt.expected)
 	}
 }
 
-func toPanMap(pairs []object.Pair, nonHashablePairs []object.Pair) *object.PanMap {
-	return object.NewPanMap(append(pairs, nonHashablePairs...)...)
-}
-
-func TestEvalPinnedMapKey(t *testing.T) {
+func TestEvalArrCountBy(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected *object.PanMap
+		expected object.PanObject
 	}{
 		{
-			`a := true; %{^a: 1}`,
-			toPanMap(
-				[]object.Pair{
-					{
-						Key:   object.BuiltInTrue,
-						Value: object.NewPanInt(1),
-					},
-				},
-				[]object.Pair{},
+			`[1, 2, 3, 4, 5].countBy {|i| i % 2}`,
+			object.NewPanMap(
+				object.Pair{Key: object.NewPanInt(1), Value: object.NewPanInt(3)},
+				object.Pair{Key: object.NewPanInt(0), Value: object.NewPanInt(2)},
 			),
 		},
+		// if arity is insufficient, raise an error
+		{
+			`[].countBy`,
+			object.NewTypeErr("Arr#countBy requires at least 2 args"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -2076,161 +2289,2613 @@ func TestEvalPinnedMapKey(t *testing.T) {
 	}
 }
 
-func TestEvalUnpackingErr(t *testing.T) {
+func TestEvalArrPartition(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected *object.PanErr
+		expected object.PanObject
 	}{
 		{
-			`*[1]`,
-			object.NewSyntaxErr("cannot use `*` unpacking outside of Arr."),
-		},
-		{
-			`[*1]`,
-			object.NewTypeErr("cannot use `*` unpacking for `1`"),
+			`[1, 2, 3, 0].partition {|i| i % 2}`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanInt(1),
+					object.NewPanInt(3),
+				),
+				object.NewPanArr(
+					object.NewPanInt(2),
+					object.NewPanInt(0),
+				),
+			),
 		},
+		// err raised in f is propagated
 		{
-			`{a: 1, **[2]}`,
-			object.NewTypeErr("cannot use `**` unpacking for `[2]`"),
+			`[1].partition {|i| i + 'x}`,
+			object.NewTypeErr(`"x" cannot be treated as int`),
 		},
+		// if arity is insufficient, raise an error
 		{
-			`%{'a: 1, **[3]}`,
-			object.NewTypeErr("cannot use `**` unpacking for `[3]`"),
+			`[].partition`,
+			object.NewTypeErr("Arr#partition requires at least 2 args"),
 		},
 	}
 
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
-		testPanErr(t, actual, tt.expected)
+		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalFuncLiteral(t *testing.T) {
-	outerEnv := object.NewEnv()
-
+func TestEvalArrFlatten(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected *object.PanFunc
+		expected object.PanObject
 	}{
 		{
-			`{||}`,
-			toPanFunc(
-				[]string{},
-				[]object.Pair{},
-				`|| `,
-				outerEnv,
+			`[1, [2, [3]]].flatten`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
+				object.NewPanInt(3),
 			),
 		},
 		{
-			`{|a|}`,
-			toPanFunc(
-				[]string{"a"},
-				[]object.Pair{},
-				`|a| `,
-				outerEnv,
+			`[1, [2, [3]]].flatten(nil)`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
+				object.NewPanInt(3),
 			),
 		},
 		{
-			`{|a, b|}`,
-			toPanFunc(
-				[]string{"a", "b"},
-				[]object.Pair{},
-				`|a, b| `,
-				outerEnv,
+			`[1, [2, [3]]].flatten(1)`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
+				object.NewPanArr(object.NewPanInt(3)),
 			),
 		},
+		// if no args are passed, raise an error
 		{
-			`{|a, b, c:10|}`,
-			toPanFunc(
-				[]string{"a", "b"},
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("c"),
-						Value: object.NewPanInt(10),
-					},
-				},
-				`|a, b, c: 10| `,
-				outerEnv,
+			`Arr['flatten]()`,
+			object.NewTypeErr("Arr#flatten requires at least 1 arg"),
+		},
+		// if \2 is not int, raise an error
+		{
+			`[1].flatten('a)`,
+			object.NewTypeErr("\\2 must be int"),
+		},
+		// if \2 is negative, raise an error
+		{
+			`[1].flatten(-1)`,
+			object.NewValueErr("\\2 must not be negative"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalArrSetOperations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`[1, 2, 1].union([2, 3], [4])`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
+				object.NewPanInt(3),
+				object.NewPanInt(4),
 			),
 		},
 		{
-			`{|a, b, c: 10, d:'e|}`,
-			toPanFunc(
-				[]string{"a", "b"},
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("c"),
-						Value: object.NewPanInt(10),
-					},
-					{
-						Key:   object.NewPanStr("d"),
-						Value: object.NewPanStr("e"),
-					},
-				},
-				`|a, b, c: 10, d: 'e| `,
-				outerEnv,
+			`[1, 2, 2, 3].intersect([2, 3, 4], [3, 2])`,
+			object.NewPanArr(
+				object.NewPanInt(2),
+				object.NewPanInt(3),
+			),
+		},
+		// duplicated elements remain
+		{
+			`[1, 2, 2, 3].diff([3])`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
+				object.NewPanInt(2),
+			),
+		},
+		// set can be passed
+		{
+			`[1, 2].diff(Set.new([1]))`,
+			object.NewPanArr(
+				object.NewPanInt(2),
 			),
 		},
+		// if no args are passed, raise an error
+		{
+			`Arr['union]()`,
+			object.NewTypeErr("Arr#union requires at least 1 arg"),
+		},
+		// if \1 is not arr, raise an error
+		{
+			`Arr['intersect](1)`,
+			object.NewTypeErr("\\1 must be arr"),
+		},
 	}
 
 	for _, tt := range tests {
-		actual := testEvalInEnv(t, tt.input, outerEnv)
-		testPanFunc(t, actual, tt.expected)
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
 	}
 }
 
-func toPanFunc(
-	args []string,
-	kwargs []object.Pair,
-	str string,
-	env *object.Env,
-) *object.PanFunc {
-	argArr := []object.PanObject{}
-	for _, arg := range args {
-		argArr = append(argArr, object.NewPanStr(arg))
+func TestEvalArrCombinations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`[1, 2, 3].combinations(2)@{|c| c}`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2)),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(3)),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(3)),
+			),
+		},
+		{
+			`[1, 2].combinations(0)@{|c| c}`,
+			object.NewPanArr(object.NewPanArr()),
+		},
+		{
+			`[1, 2].combinations(3)@{|c| c}`,
+			object.NewPanArr(),
+		},
+		// if arity is insufficient, raise an error
+		{
+			`[].combinations`,
+			object.NewTypeErr("Arr#combinations requires at least 2 args"),
+		},
+		// if \2 is not int, raise an error
+		{
+			`[1].combinations('a)`,
+			object.NewTypeErr("\\2 must be int"),
+		},
+		// if \2 is negative, raise an error
+		{
+			`[1].combinations(-1)`,
+			object.NewValueErr("\\2 must not be negative"),
+		},
 	}
 
-	funcWrapper := &FuncWrapperImpl{
-		codeStr: str,
-		args:    object.NewPanArr(argArr...),
-		kwargs:  toPanObj(kwargs),
-		// empty stmt (body is not tested)
-		body: &[]ast.Stmt{},
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
 	}
+}
 
-	return object.NewPanFunc(
-		funcWrapper,
-		object.NewEnclosedEnv(env),
-	)
+func TestEvalArrPermutations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`[1, 2, 3].permutations@{|c| c}`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2), object.NewPanInt(3)),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(3), object.NewPanInt(2)),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(1), object.NewPanInt(3)),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(3), object.NewPanInt(1)),
+				object.NewPanArr(object.NewPanInt(3), object.NewPanInt(1), object.NewPanInt(2)),
+				object.NewPanArr(object.NewPanInt(3), object.NewPanInt(2), object.NewPanInt(1)),
+			),
+		},
+		{
+			`[1, 2, 3].permutations(2)@{|c| c}`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2)),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(3)),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(1)),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(3)),
+				object.NewPanArr(object.NewPanInt(3), object.NewPanInt(1)),
+				object.NewPanArr(object.NewPanInt(3), object.NewPanInt(2)),
+			),
+		},
+		{
+			`[1, 2].permutations(3)@{|c| c}`,
+			object.NewPanArr(),
+		},
+		// if no args are passed, raise an error
+		{
+			`Arr['permutations]()`,
+			object.NewTypeErr("Arr#permutations requires at least 1 arg"),
+		},
+		// if \2 is negative, raise an error
+		{
+			`[1].permutations(-1)`,
+			object.NewValueErr("\\2 must not be negative"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
 }
 
-func TestEvalFuncCall(t *testing.T) {
+func TestEvalArrProduct(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{10}()`,
-			object.NewPanInt(10),
+			`[1, 2].product(['a, 'b])@{|c| c}`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(1), object.NewPanStr("a")),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanStr("b")),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanStr("a")),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanStr("b")),
+			),
 		},
 		{
-			`{|x| x}(5)`,
-			object.NewPanInt(5),
+			`[1, 2].product([])@{|c| c}`,
+			object.NewPanArr(),
 		},
+		// iters in the args are read lazily
 		{
-			`{|x, y| [x, y]}("x", "y")`,
+			`it := [1].product(<{|i| yield i; recur(i + 1)}>.new(0)); [it.next, it.next]`,
 			object.NewPanArr(
-				object.NewPanStr("x"),
-				object.NewPanStr("y"),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(0)),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(1)),
+			),
+		},
+		// if no args are passed, raise an error
+		{
+			`Arr['product]()`,
+			object.NewTypeErr("Arr#product requires at least 1 arg"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIterCombinations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`[0, 1, 2]._iter.combinations(2)@{|c| c}`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(1)),
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(2)),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2)),
+			),
+		},
+		// elements are read lazily
+		{
+			`it := <{|i| yield i; recur(i + 1)}>.new(0).combinations(2); [it.next, it.next, it.next]`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(1)),
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(2)),
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(3)),
+			),
+		},
+		{
+			`[0, 1]._iter.combinations(3)@{|c| c}`,
+			object.NewPanArr(),
+		},
+		// errors raised in the iter are propagated
+		{
+			`<{1 / 0}>.combinations(2).next`,
+			object.NewZeroDivisionErr("cannot be divided by 0"),
+		},
+		// if arity is insufficient, raise an error
+		{
+			`<{1}>.combinations`,
+			object.NewTypeErr("Iter#combinations requires at least 2 args"),
+		},
+		// if \2 is not int, raise an error
+		{
+			`<{1}>.combinations('a)`,
+			object.NewTypeErr("\\2 must be int"),
+		},
+		// if \2 is negative, raise an error
+		{
+			`<{1}>.combinations(-1)`,
+			object.NewValueErr("\\2 must not be negative"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIterPermutations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`[0, 1, 2]._iter.permutations@{|c| c}`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(1), object.NewPanInt(2)),
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(2), object.NewPanInt(1)),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(0), object.NewPanInt(2)),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2), object.NewPanInt(0)),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(0), object.NewPanInt(1)),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(1), object.NewPanInt(0)),
+			),
+		},
+		// elements are read lazily if k is specified
+		{
+			`it := <{|i| yield i; recur(i + 1)}>.new(0).permutations(2); [it.next, it.next, it.next]`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(1)),
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(2)),
+				object.NewPanArr(object.NewPanInt(0), object.NewPanInt(3)),
+			),
+		},
+		{
+			`[0, 1]._iter.permutations(3)@{|c| c}`,
+			object.NewPanArr(),
+		},
+		// if no args are passed, raise an error
+		{
+			`Iter['permutations]()`,
+			object.NewTypeErr("Iter#permutations requires at least 1 arg"),
+		},
+		// if \2 is negative, raise an error
+		{
+			`<{1}>.permutations(-1)`,
+			object.NewValueErr("\\2 must not be negative"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIterProduct(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`[0, 1]._iter.product(['a, 'b])@{|c| c}`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(0), object.NewPanStr("a")),
+				object.NewPanArr(object.NewPanInt(0), object.NewPanStr("b")),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanStr("a")),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanStr("b")),
+			),
+		},
+		// elements are read lazily
+		{
+			`it := <{|i| yield i; recur(i + 1)}>.new(0).product(['a]); [it.next, it.next]`,
+			object.NewPanArr(
+				object.NewPanArr(object.NewPanInt(0), object.NewPanStr("a")),
+				object.NewPanArr(object.NewPanInt(1), object.NewPanStr("a")),
 			),
 		},
 		{
-			`{|foo, bar: "bar", baz| [foo, bar, baz]}("FOO", "BAZ")`,
-			object.NewPanArr(
-				object.NewPanStr("FOO"),
-				object.NewPanStr("bar"),
-				object.NewPanStr("BAZ"),
-			),
+			`[0, 1]._iter.product([])@{|c| c}`,
+			object.NewPanArr(),
+		},
+		// if no args are passed, raise an error
+		{
+			`Iter['product]()`,
+			object.NewTypeErr("Iter#product requires at least 1 arg"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalObjLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *object.PanObj
+	}{
+		{
+			`{}`,
+			toPanObj([]object.Pair{}),
+		},
+		{
+			`{a: 1}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanInt(1),
+				},
+			}),
+		},
+		{
+			`{a: 1, b: 2}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanInt(1),
+				},
+				{
+					Key:   object.NewPanStr("b"),
+					Value: object.NewPanInt(2),
+				},
+			}),
+		},
+		// dangling keys are ignored (in this example, `a: 3` is ignored)
+		{
+			`{a: 1, b: 2, a: 3}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanInt(1),
+				},
+				{
+					Key:   object.NewPanStr("b"),
+					Value: object.NewPanInt(2),
+				},
+			}),
+		},
+		// Obj can contain multiple types
+		{
+			`{a: 1, b: "B"}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanInt(1),
+				},
+				{
+					Key:   object.NewPanStr("b"),
+					Value: object.NewPanStr("B"),
+				},
+			}),
+		},
+		// nested
+		{
+			`{a: {b: 10}}`,
+			toPanObj([]object.Pair{
+				{
+					Key: object.NewPanStr("a"),
+					Value: toPanObj([]object.Pair{
+						{
+							Key:   object.NewPanStr("b"),
+							Value: object.NewPanInt(10),
+						},
+					}),
+				},
+			}),
+		},
+		// embedded
+		// NOTE: embedded elems must be after normal pairs (`{**a, b: 2}` is syntax error)
+		{
+			`{a: 1, **{b: 2}}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanInt(1),
+				},
+				{
+					Key:   object.NewPanStr("b"),
+					Value: object.NewPanInt(2),
+				},
+			}),
+		},
+		{
+			`{**{a: 1}, **{b: 2}}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanInt(1),
+				},
+				{
+					Key:   object.NewPanStr("b"),
+					Value: object.NewPanInt(2),
+				},
+			}),
+		},
+		// dangling keys in embedded obj are also ignored
+		{
+			`{x: 1, **{x: 2}}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("x"),
+					Value: object.NewPanInt(1),
+				},
+			}),
+		},
+		{
+			`{**{y: 1}, **{y: 2}}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("y"),
+					Value: object.NewPanInt(1),
+				},
+			}),
+		},
+		// enable to use descendant of str for key (str proto is set)
+		{
+			`{"hoge".bear: 1}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("hoge"),
+					Value: object.NewPanInt(1),
+				},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testPanObj(t, actual, tt.expected)
+	}
+}
+
+func TestEvalPinnedObjKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *object.PanObj
+	}{
+		{
+			`a := "A"; {^a: 1}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("A"),
+					Value: object.NewPanInt(1),
+				},
+			}),
+		},
+		// enable to use descendant of str for key (str proto is set)
+		{
+			`b := "B".bear; {^b: 1}`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("B"),
+					Value: object.NewPanInt(1),
+				},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testPanObj(t, actual, tt.expected)
+	}
+}
+
+func TestEvalBuiltInCallProp(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		// non-callable
+		{
+			`{}.callProp({a: 1}, 'a)`,
+			object.NewPanInt(1),
+		},
+		// callable
+		{
+			`{}.callProp({a: {|| 2}}, 'a)`,
+			object.NewPanInt(2),
+		},
+		// NOTE: first arg (`self`) is receiver itself! (`{a: m{|x| x}}`)
+		{
+			`{}.callProp({a: m{|x| x}}, 'a, 3)`,
+			object.NewPanInt(3),
+		},
+		{
+			`{}.callProp({a: m{|x, y, z: 1| [x, y, z]}}, 'a, 4, 5, z: 6)`,
+			object.NewPanArr(
+				object.NewPanInt(4),
+				object.NewPanInt(5),
+				object.NewPanInt(6),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalObjLiteralInvalidKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *object.PanErr
+	}{
+		{
+			`{1: 2}`,
+			object.NewTypeErr("cannot use `1` as Obj key."),
+		},
+		{
+			`{a: 1, 2: 3}`,
+			object.NewTypeErr("cannot use `2` as Obj key."),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testPanErr(t, actual, tt.expected)
+	}
+}
+
+func toPanObj(pairs []object.Pair) *object.PanObj {
+	pairMap := map[object.SymHash]object.Pair{}
+
+	for _, pair := range pairs {
+		panStr, _ := pair.Key.(*object.PanStr)
+		symHash := object.GetSymHash(panStr.Value)
+		pairMap[symHash] = pair
+	}
+
+	obj := object.PanObjInstance(&pairMap)
+	return &obj
+}
+
+func TestEvalMapLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *object.PanMap
+	}{
+		{
+			`%{}`,
+			toPanMap([]object.Pair{}, []object.Pair{}),
+		},
+		{
+			`%{'a: 1}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("a"),
+						Value: object.NewPanInt(1),
+					},
+				},
+				[]object.Pair{},
+			),
+		},
+		// map can contain keys other than str
+		{
+			`%{'a: 1, 2: 3, true: 5}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("a"),
+						Value: object.NewPanInt(1),
+					},
+					{
+						Key:   object.NewPanInt(2),
+						Value: object.NewPanInt(3),
+					},
+					{
+						Key:   object.BuiltInTrue,
+						Value: object.NewPanInt(5),
+					},
+				},
+				[]object.Pair{},
+			),
+		},
+		// dangling keys are ignored (in this example, `a: 3` is ignored)
+		{
+			`%{'a: 1, 'b: 2, 'a: 3}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("a"),
+						Value: object.NewPanInt(1),
+					},
+					{
+						Key:   object.NewPanStr("b"),
+						Value: object.NewPanInt(2),
+					},
+				},
+				[]object.Pair{},
+			),
+		},
+		// Obj can contain multiple types
+		{
+			`%{"a": 1, "b": "B"}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("a"),
+						Value: object.NewPanInt(1),
+					},
+					{
+						Key:   object.NewPanStr("b"),
+						Value: object.NewPanStr("B"),
+					},
+				},
+				[]object.Pair{},
+			),
+		},
+		// nested
+		{
+			`%{'a: %{'b: 10}}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key: object.NewPanStr("a"),
+						Value: toPanMap(
+							[]object.Pair{
+								{
+									Key:   object.NewPanStr("b"),
+									Value: object.NewPanInt(10),
+								},
+							},
+							[]object.Pair{},
+						),
+					},
+				},
+				[]object.Pair{},
+			),
+		},
+		// embedded
+		// NOTE: embedded elems must be after normal pairs
+		// (`%{**a, b: 2}` is syntax error)
+		{
+			`%{'a: 1, **%{'b: 2}}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("a"),
+						Value: object.NewPanInt(1),
+					},
+					{
+						Key:   object.NewPanStr("b"),
+						Value: object.NewPanInt(2),
+					},
+				},
+				[]object.Pair{},
+			),
+		},
+		// embedded non-scalar
+		{
+			`%{'a: 1, **%{[]: 2}}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("a"),
+						Value: object.NewPanInt(1),
+					},
+				},
+				[]object.Pair{
+					{
+						Key:   object.NewPanArr(),
+						Value: object.NewPanInt(2),
+					},
+				},
+			),
+		},
+		{
+			`%{**%{'a: 1}, **%{'b: 2}}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("a"),
+						Value: object.NewPanInt(1),
+					},
+					{
+						Key:   object.NewPanStr("b"),
+						Value: object.NewPanInt(2),
+					},
+				},
+				[]object.Pair{},
+			),
+		},
+		// obj can be embedded to map (but map cannot be embedded to obj)
+		{
+			`%{**%{'a: 1}, **{b: 2}}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("a"),
+						Value: object.NewPanInt(1),
+					},
+					{
+						Key:   object.NewPanStr("b"),
+						Value: object.NewPanInt(2),
+					},
+				},
+				[]object.Pair{},
+			),
+		},
+		// map can contain non-scalar keys
+		{
+			`%{'a: 1, [1, 2]: 3, %{4: 5}: 6}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("a"),
+						Value: object.NewPanInt(1),
+					},
+				},
+				[]object.Pair{
+					{
+						Key: object.NewPanArr(
+							object.NewPanInt(1),
+							object.NewPanInt(2),
+						),
+						Value: object.NewPanInt(3),
+					},
+					{
+						Key: toPanMap(
+							[]object.Pair{
+								{
+									Key:   object.NewPanInt(4),
+									Value: object.NewPanInt(5),
+								},
+							},
+							[]object.Pair{},
+						),
+						Value: object.NewPanInt(6),
+					},
+				},
+			),
+		},
+		// non-hashable dangling keys are also ignored ([1,2]: 4 is ignored)
+		{
+			`%{[1, 2]: 3, [1, 2]: 4}`,
+			toPanMap(
+				[]object.Pair{},
+				[]object.Pair{
+					{
+						Key: object.NewPanArr(
+							object.NewPanInt(1),
+							object.NewPanInt(2),
+						),
+						Value: object.NewPanInt(3),
+					},
+				},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testPanMap(t, actual, tt.expected)
+	}
+}
+
+func toPanMap(pairs []object.Pair, nonHashablePairs []object.Pair) *object.PanMap {
+	return object.NewPanMap(append(pairs, nonHashablePairs...)...)
+}
+
+func TestEvalPinnedMapKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *object.PanMap
+	}{
+		{
+			`a := true; %{^a: 1}`,
+			toPanMap(
+				[]object.Pair{
+					{
+						Key:   object.BuiltInTrue,
+						Value: object.NewPanInt(1),
+					},
+				},
+				[]object.Pair{},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalUnpackingErr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *object.PanErr
+	}{
+		{
+			`*[1]`,
+			object.NewSyntaxErr("cannot use `*` unpacking outside of Arr."),
+		},
+		{
+			`[*1]`,
+			object.NewTypeErr("cannot use `*` unpacking for `1`"),
+		},
+		{
+			`{a: 1, **[2]}`,
+			object.NewTypeErr("cannot use `**` unpacking for `[2]`"),
+		},
+		{
+			`%{'a: 1, **[3]}`,
+			object.NewTypeErr("cannot use `**` unpacking for `[3]`"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testPanErr(t, actual, tt.expected)
+	}
+}
+
+func TestEvalFuncLiteral(t *testing.T) {
+	outerEnv := object.NewEnv()
+
+	tests := []struct {
+		input    string
+		expected *object.PanFunc
+	}{
+		{
+			`{||}`,
+			toPanFunc(
+				[]string{},
+				[]object.Pair{},
+				`|| `,
+				outerEnv,
+			),
+		},
+		{
+			`{|a|}`,
+			toPanFunc(
+				[]string{"a"},
+				[]object.Pair{},
+				`|a| `,
+				outerEnv,
+			),
+		},
+		{
+			`{|a, b|}`,
+			toPanFunc(
+				[]string{"a", "b"},
+				[]object.Pair{},
+				`|a, b| `,
+				outerEnv,
+			),
+		},
+		{
+			`{|a, b, c:10|}`,
+			toPanFunc(
+				[]string{"a", "b"},
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("c"),
+						Value: object.NewPanInt(10),
+					},
+				},
+				`|a, b, c: 10| `,
+				outerEnv,
+			),
+		},
+		{
+			`{|a, b, c: 10, d:'e|}`,
+			toPanFunc(
+				[]string{"a", "b"},
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("c"),
+						Value: object.NewPanInt(10),
+					},
+					{
+						Key:   object.NewPanStr("d"),
+						Value: object.NewPanStr("e"),
+					},
+				},
+				`|a, b, c: 10, d: 'e| `,
+				outerEnv,
+			),
+		},
+		// destructuring patterns are bound when the func is called
+		{
+			`{|[a, b], {c: d}, *e|}`,
+			toPanFunc(
+				[]string{"[a, b]", "{c: d}", "(*e)"},
+				[]object.Pair{},
+				`|[a, b], {c: d}, (*e)| `,
+				outerEnv,
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEvalInEnv(t, tt.input, outerEnv)
+		testPanFunc(t, actual, tt.expected)
+	}
+}
+
+func toPanFunc(
+	args []string,
+	kwargs []object.Pair,
+	str string,
+	env *object.Env,
+) *object.PanFunc {
+	argArr := []object.PanObject{}
+	for _, arg := range args {
+		argArr = append(argArr, object.NewPanStr(arg))
+	}
+
+	funcWrapper := &FuncWrapperImpl{
+		codeStr: str,
+		args:    object.NewPanArr(argArr...),
+		kwargs:  toPanObj(kwargs),
+		// empty stmt (body is not tested)
+		body: &[]ast.Stmt{},
+	}
+
+	return object.NewPanFunc(
+		funcWrapper,
+		object.NewEnclosedEnv(env),
+	)
+}
+
+func TestEvalFuncCall(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{10}()`,
+			object.NewPanInt(10),
+		},
+		{
+			`{|x| x}(5)`,
+			object.NewPanInt(5),
+		},
+		{
+			`{|x, y| [x, y]}("x", "y")`,
+			object.NewPanArr(
+				object.NewPanStr("x"),
+				object.NewPanStr("y"),
+			),
+		},
+		{
+			`{|foo, bar: "bar", baz| [foo, bar, baz]}("FOO", "BAZ")`,
+			object.NewPanArr(
+				object.NewPanStr("FOO"),
+				object.NewPanStr("bar"),
+				object.NewPanStr("BAZ"),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalEmptyFuncCall(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{||}()`,
+			object.BuiltInNil,
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalMultiLineFuncCall(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{|a, b| a; b}(1, 2)`,
+			object.NewPanInt(2),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalRecurredFuncCall(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`
+			func := {|f| f(); f}
+			inner := {|| 1}
+			outer := {func(inner)}
+			func(outer) == outer
+			`,
+			object.BuiltInTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalFuncCallWithPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{|[a, b]| [b, a]}([1, 2])`,
+			object.NewPanArr(object.NewPanInt(2), object.NewPanInt(1)),
+		},
+		{
+			`{|[a, [b, c]], d| [a, b, c, d]}([1, [2, 3]], 4)`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(2),
+				object.NewPanInt(3),
+				object.NewPanInt(4),
+			),
+		},
+		{
+			`{|{name: n, age: a}| [n, a]}({name: "Taro", age: 20, id: 1})`,
+			object.NewPanArr(object.NewPanStr("Taro"), object.NewPanInt(20)),
+		},
+		{
+			`{|{name: {first: f}}| f}({name: {first: "Taro"}})`,
+			object.NewPanStr("Taro"),
+		},
+		{
+			`{|%{"a": a, 1: b}| [a, b]}(%{"a": 2, 1: 3})`,
+			object.NewPanArr(object.NewPanInt(2), object.NewPanInt(3)),
+		},
+		// rest
+		{
+			`{|a, *rest| [a, rest]}(1, 2, 3)`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(3)),
+			),
+		},
+		{
+			`{|a, *rest| [a, rest]}()`,
+			object.NewPanArr(object.BuiltInNil, object.NewPanArr()),
+		},
+		{
+			`{|[h, *t, l]| [h, t, l]}([1, 2, 3, 4])`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(3)),
+				object.NewPanInt(4),
+			),
+		},
+		{
+			`{|{a: a, **kw}| [a, kw]}({a: 1, b: 2})`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				toPanObj([]object.Pair{
+					{Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+				}),
+			),
+		},
+		{
+			`{|%{"a": a, **kw}| kw}(%{"a": 1, "b": 2})`,
+			toPanMap([]object.Pair{
+				{Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+			}, []object.Pair{}),
+		},
+		// wildcard
+		{
+			`{|[_, b, _]| b}([1, 2, 3])`,
+			object.NewPanInt(2),
+		},
+		// literal patterns
+		{
+			`{|[1, x], ["a", y]| [x, y]}([1, 2], ["a", 4])`,
+			object.NewPanArr(object.NewPanInt(2), object.NewPanInt(4)),
+		},
+		// argvars can be still used
+		{
+			`{|[a, b]| \1}([1, 2])`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2)),
+		},
+		// errors
+		{
+			`{|[a, b]| a}([1, 2, 3])`,
+			object.NewMatchErr("[[1, 2, 3]] does not match params |[a, b]|"),
+		},
+		{
+			`{|[a, b]| a}(1)`,
+			object.NewMatchErr("[1] does not match params |[a, b]|"),
+		},
+		{
+			`{|{a: a}| a}({b: 1})`,
+			object.NewMatchErr(`[{"b": 1}] does not match params |{a: a}|`),
+		},
+		{
+			`{|[1, x]| x}([2, 3])`,
+			object.NewMatchErr("[[2, 3]] does not match params |[1, x]|"),
+		},
+		{
+			`{|*a, *b| a}(1)`,
+			object.NewSyntaxErr("only one rest pattern can be used"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalMatchLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`%{|1| 2, |a| a * 2}`,
+			"%{\n|1| 2,\n|a| (a * 2)}",
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testPanMatch(t, actual, tt.expected)
+	}
+}
+
+func TestEvalMatchCall(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`%{|1| "one", |2| "two"}(2)`,
+			object.NewPanStr("two"),
+		},
+		// first matched pattern is used
+		{
+			`%{|x| "any", |1| "one"}(1)`,
+			object.NewPanStr("any"),
+		},
+		{
+			`%{|[a, b]| a + b, |{a: a}| a}({a: 3})`,
+			object.NewPanInt(3),
+		},
+		{
+			`%{|[a, b]| a + b, |{a: a}| a}([1, 2])`,
+			object.NewPanInt(3),
+		},
+		// str literal is compared by == (not ===)
+		{
+			`%{|"^a"| 1, |_| 2}("abc")`,
+			object.NewPanInt(2),
+		},
+		// guard
+		{
+			`%{|x, when: x == 0| "zero", |x| "other"}(1)`,
+			object.NewPanStr("other"),
+		},
+		{
+			`%{|x, when: x == 0| "zero", |x| "other"}(0)`,
+			object.NewPanStr("zero"),
+		},
+		// multiple args
+		{
+			`%{|0, y| y, |x, y| x + y}(1, 2)`,
+			object.NewPanInt(3),
+		},
+		// kwargs
+		{
+			`%{|x, y: 10| x + y}(1)`,
+			object.NewPanInt(11),
+		},
+		// closure
+		{
+			`a := 10; %{|x| x + a}(1)`,
+			object.NewPanInt(11),
+		},
+		{
+			`%{|x| x}.call(5)`,
+			object.NewPanInt(5),
+		},
+		// literal call
+		{
+			`m := %{|[a, b]| a * b}; [2, 3].^m`,
+			object.NewPanInt(6),
+		},
+		// errors
+		{
+			`%{|1| "one", |2| "two"}(3)`,
+			object.NewMatchErr("3 does not match any pattern"),
+		},
+		{
+			`%{|[x]| 1}.call`,
+			object.NewMatchErr("[] does not match any pattern"),
+		},
+		{
+			`%{|x, when: y| 1}(1)`,
+			object.NewNameErr("name `y` is not defined"),
+		},
+		{
+			`Match['call]()`,
+			object.NewTypeErr("Match#call requires at least 1 arg"),
+		},
+		{
+			`Match['call](1)`,
+			object.NewTypeErr("1 is not callable."),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalBuiltInFuncCall(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`f := {||}['at]; f({a: 10}, ['a])`,
+			object.NewPanInt(10),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalBuiltInFuncInvalidArgErr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *object.PanErr
+	}{
+		{
+			`{call: {|x| x}['call]}.call(1)`,
+			object.NewTypeErr("{\"call\": {|| [builtin]}} is not callable."),
+		},
+		{
+			`f := {||}['at]; f()`,
+			object.NewTypeErr("Obj#at requires at least 2 args"),
+		},
+		{
+			`f := {||}['at]; f(1)`,
+			object.NewTypeErr("Obj#at requires at least 2 args"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testPanErr(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIterLiteral(t *testing.T) {
+	outerEnv := object.NewEnv()
+
+	tests := []struct {
+		input    string
+		expected *object.PanFunc
+	}{
+		{
+			`<{||}>`,
+			toPanIter(
+				[]string{},
+				[]object.Pair{},
+				`|| `,
+				object.NewEnclosedEnv(outerEnv),
+			),
+		},
+		{
+			`<{|a|}>`,
+			toPanIter(
+				[]string{"a"},
+				[]object.Pair{},
+				`|a| `,
+				object.NewEnclosedEnv(outerEnv),
+			),
+		},
+		{
+			`<{|a, b|}>`,
+			toPanIter(
+				[]string{"a", "b"},
+				[]object.Pair{},
+				`|a, b| `,
+				object.NewEnclosedEnv(outerEnv),
+			),
+		},
+		{
+			`<{|a, b, c:10|}>`,
+			toPanIter(
+				[]string{"a", "b"},
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("c"),
+						Value: object.NewPanInt(10),
+					},
+				},
+				`|a, b, c: 10| `,
+				object.NewEnclosedEnv(outerEnv),
+			),
+		},
+		{
+			`<{|a, b, c: 10, d:'e|}>`,
+			toPanIter(
+				[]string{"a", "b"},
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("c"),
+						Value: object.NewPanInt(10),
+					},
+					{
+						Key:   object.NewPanStr("d"),
+						Value: object.NewPanStr("e"),
+					},
+				},
+				`|a, b, c: 10, d: 'e| `,
+				object.NewEnclosedEnv(outerEnv),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEvalInEnv(t, tt.input, outerEnv)
+		testPanFunc(t, actual, tt.expected)
+	}
+}
+
+func toPanIter(
+	args []string,
+	kwargs []object.Pair,
+	str string,
+	env *object.Env,
+) *object.PanFunc {
+	argArr := []object.PanObject{}
+	for _, arg := range args {
+		argArr = append(argArr, object.NewPanStr(arg))
+	}
+
+	funcWrapper := &FuncWrapperImpl{
+		codeStr: str,
+		args:    object.NewPanArr(argArr...),
+		kwargs:  toPanObj(kwargs),
+		// empty stmt (body is not tested)
+		body: &[]ast.Stmt{},
+	}
+
+	return object.NewPanIter(funcWrapper, env)
+}
+
+func TestEvalIterNew(t *testing.T) {
+	outerEnv := object.NewEnv()
+
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		// return new Iter with args set
+		{
+			`<{|a| yield a}>.new(10)`,
+			toPanIter(
+				[]string{"a"},
+				[]object.Pair{},
+				`|a| yield a`,
+				toEnv(
+					[]object.Pair{
+						{
+							Key:   object.NewPanStr("a"),
+							Value: object.NewPanInt(10),
+						},
+					},
+					[]object.Pair{},
+					outerEnv,
+				),
+			),
+		},
+		{
+			`<{|a, b, c: 'c| yield a}>.new('A, 'B, c: 'C)`,
+			toPanIter(
+				[]string{"a", "b"},
+				[]object.Pair{
+					{
+						Key:   object.NewPanStr("c"),
+						Value: object.NewPanStr("c"),
+					},
+				},
+				`|a, b, c: 'c| yield a`,
+				toEnv(
+					[]object.Pair{
+						{
+							Key:   object.NewPanStr("a"),
+							Value: object.NewPanStr("A"),
+						},
+						{
+							Key:   object.NewPanStr("b"),
+							Value: object.NewPanStr("B"),
+						},
+					},
+					[]object.Pair{
+						{
+							Key:   object.NewPanStr("c"),
+							Value: object.NewPanStr("C"),
+						},
+					},
+					outerEnv,
+				),
+			),
+		},
+		// builtinIter cannot handle new because it cannot hold the received args
+		{
+			`[1, 2, 3]._iter.new(2)`,
+			object.NewValueErr("Iter#new cannot handle builtinIter (use _iter instead)"),
+		},
+	}
+
+	for _, tt := range tests {
+		if outerEnv.Items().Inspect() != "{}" {
+			t.Errorf("outerEnv must be empty. got=%s", outerEnv.Items().Inspect())
+		}
+
+		actual := testEvalInEnv(t, tt.input, outerEnv)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func toEnv(
+	argPairs []object.Pair,
+	kwargPairs []object.Pair,
+	outer *object.Env,
+) *object.Env {
+	env := object.NewEnclosedEnv(outer)
+	for i, pair := range argPairs {
+		sym := object.GetSymHash(pair.Key.(*object.PanStr).Value)
+		env.Set(sym, pair.Value)
+		// \n
+		argSym := object.GetSymHash(fmt.Sprintf(`\%d`, i+1))
+		env.Set(argSym, pair.Value)
+	}
+	// \0
+	argValues := []object.PanObject{}
+	for _, pair := range argPairs {
+		argValues = append(argValues, pair.Value)
+	}
+	env.Set(object.GetSymHash(`\0`), object.NewPanArr(argValues...))
+	// \
+	if len(argValues) > 0 {
+		env.Set(object.GetSymHash("\\"), argValues[0])
+	}
+
+	for _, pair := range kwargPairs {
+		sym := object.GetSymHash(pair.Key.(*object.PanStr).Value)
+		env.Set(sym, pair.Value)
+		// \hoge
+		kwargSym := object.GetSymHash("\\" + pair.Key.(*object.PanStr).Value)
+		env.Set(kwargSym, pair.Value)
+	}
+	// \_
+	env.Set(object.GetSymHash("\\_"), toPanObj(kwargPairs))
+
+	return env
+}
+
+func TestEvalDiamondLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`<>`,
+			object.BuiltInDiamondObj,
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalDiamondProps(t *testing.T) {
+	tests := []struct {
+		stdin    string
+		input    string
+		expected object.PanObject
+	}{
+		// NOTE: <> can use all str props
+		{
+			`hoge`,
+			`<>.uc`,
+			object.NewPanStr("HOGE"),
+		},
+		{
+			"HOGE\nFUGA",
+			`<>.lc
+			 <>.lc`,
+			object.NewPanStr("fuga"),
+		},
+		// after reached to EOF, "" is read
+		{
+			"hoge\nfuga",
+			`<>.uc
+			 <>.uc
+			 <>.uc`,
+			object.NewPanStr(""),
+		},
+		// Diamond#S returns read line str
+		{
+			"hoge\nfuga",
+			`<>.S+<>.S`,
+			object.NewPanStr("hogefuga"),
+		},
+	}
+	for _, tt := range tests {
+		reader := strings.NewReader(tt.stdin)
+		// setup IO
+		env := object.NewEnvWithConsts()
+		env.InjectIO(reader, os.Stdout)
+
+		actual := testEvalInEnv(t, tt.input, env)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalDiamondPropsErr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`<>._missing`,
+			object.NewTypeErr("_missing requires at least 2 args"),
+		},
+		{
+			`<>['S]()`,
+			object.NewTypeErr("Diamond#S requires at least 1 arg"),
+		},
+		{
+			`<>._missing(1)`,
+			object.NewTypeErr("\\2 must be prop name str"),
+		},
+		{
+			`IO := 1; <>.uc`,
+			object.NewTypeErr("name `IO` must be IO obj"),
+		},
+	}
+	for _, tt := range tests {
+		// setup IO
+		env := object.NewEnvWithConsts()
+		env.InjectIO(os.Stdin, os.Stdout)
+
+		actual := testEvalInEnv(t, tt.input, env)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalDiamondPropsErrIONotFound(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`<>.lc`,
+			object.NewNameErr("`IO` is not found in env"),
+		},
+		{
+			`<>.S`,
+			object.NewNameErr("`IO` is not found in env"),
+		},
+		{
+			`<>@{\}`,
+			object.NewNameErr("`IO` is not found in env"),
+		},
+	}
+	for _, tt := range tests {
+		// without IO injection
+		env := object.NewEnvWithConsts()
+		actual := testEvalInEnv(t, tt.input, env)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalDiamondIter(t *testing.T) {
+	tests := []struct {
+		stdin    string
+		input    string
+		expected object.PanObject
+	}{
+		// <> reads all lines until EOF
+		{
+			"hoge\nfuga\npiyo",
+			`<>@{\}`,
+			object.NewPanArr(
+				object.NewPanStr("hoge"),
+				object.NewPanStr("fuga"),
+				object.NewPanStr("piyo"),
+			),
+		},
+		{
+			"hoge\nfuga\npiyo\n",
+			`<>@{\}`,
+			object.NewPanArr(
+				object.NewPanStr("hoge"),
+				object.NewPanStr("fuga"),
+				object.NewPanStr("piyo"),
+			),
+		},
+	}
+	for _, tt := range tests {
+		reader := strings.NewReader(tt.stdin)
+		// setup IO
+		env := object.NewEnvWithConsts()
+		env.InjectIO(reader, os.Stdout)
+
+		actual := testEvalInEnv(t, tt.input, env)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalYield(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`<{|a| yield a}>.new(1).next`,
+			object.NewPanInt(1),
+		},
+		{
+			`<{|a| yield a}>.new('a).next`,
+			object.NewPanStr("a"),
+		},
+		// recur
+		{
+			`it := <{|a| yield a; recur(a+1)}>.new(1)
+			 it.next
+			 it.next
+			 `,
+			object.NewPanInt(2),
+		},
+		{
+			`it := <{|a| yield a; recur(a+1)}>.new(1)
+			 it.next
+			 it.next
+			 it.next
+			`,
+			object.NewPanInt(3),
+		},
+		{
+			`it := <{|a, b| yield [a, b]; recur(a+1, b*2)}>.new(1, 2)
+			 it.next
+			 it.next
+			 it.next
+			`,
+			object.NewPanArr(
+				object.NewPanInt(3),
+				object.NewPanInt(8),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIterYieldIsIndependent(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`
+			it := <{|a| yield a; recur(a+1)}>.new(1)
+			it.next
+			it.next
+			it2 := it.new(1)
+			it2.next`,
+			object.NewPanInt(1),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalYieldStopped(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`it := <{|a| yield a if a == 1; recur(a+1)}>.new(1)
+			it.next`,
+			object.NewPanInt(1),
+		},
+		{
+			`it := <{|a| yield a if a == 1; recur(a+1)}>.new(1)
+			it.next
+			it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalJumpStmt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{|i| yield i}(1)`,
+			object.NewPanInt(1),
+		},
+		{
+			`{|i| return i}(2)`,
+			object.NewPanInt(2),
+		},
+		{
+			`{|i| raise Err.new("new error")}(2)`,
+			object.NewPanErr("new error"),
+		},
+	}
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalJumpIfStmt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{|i| yield i if true; i * 2}(1)`,
+			object.NewPanInt(1),
+		},
+		// raise StopIterErr
+		{
+			`{|i| yield i if false; i * 2}(1)`,
+			object.NewStopIterErr("iter stopped"),
+		},
+		{
+			`{|i| return i if true; i * 2}(10)`,
+			object.NewPanInt(10),
+		},
+		{
+			`{|i| return i if false; i * 2}(10)`,
+			object.NewPanInt(20),
+		},
+		{
+			`{|i| raise Err.new("new error") if true; i}(2)`,
+			object.NewPanErr("new error"),
+		},
+		{
+			`{|i| raise Err.new("new error") if false; i}(2)`,
+			object.NewPanInt(2),
+		},
+		// recursion
+		{
+			`fact := {|n| return 1 if n == 0; n * fact(n - 1)}; fact(4)`,
+			object.NewPanInt(24),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt //pin
+		t.Run(tt.input, func(t *testing.T) {
+			actual := testEval(t, tt.input)
+			testValue(t, actual, tt.expected)
+		})
+	}
+}
+
+func TestEvalJumpIfStmtWithNonBoolCond(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		// `(cond).B` is called to check cond is truthy/falsy
+		{
+			`{|i| yield i if 1; i * 2}(1)`,
+			object.NewPanInt(1),
+		},
+		{
+			`{|i| yield i if ""; i * 2}(1)`,
+			object.NewStopIterErr("iter stopped"),
+		},
+		{
+			`{|i| return i if [1,2,3]; i * 2}(10)`,
+			object.NewPanInt(10),
+		},
+		{
+			`{|i| return i if nil; i * 2}(10)`,
+			object.NewPanInt(20),
+		},
+	}
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalStmtsAfterYieldAreEvaluated(t *testing.T) {
+	tests := []struct {
+		input          string
+		expected       object.PanObject
+		expectedStdOut string
+	}{
+		{
+			`{|i| yield i; "evaluated!".p}(1)`,
+			object.NewPanInt(1),
+			"evaluated!\n",
+		},
+	}
+	for _, tt := range tests {
+		writer := &bytes.Buffer{}
+		// setup IO
+		env := object.NewEnvWithConsts()
+		env.InjectIO(os.Stdin, writer)
+
+		actual := testEvalInEnv(t, tt.input, env)
+		testValue(t, actual, tt.expected)
+
+		// check output
+		output := writer.String()
+		if output != tt.expectedStdOut {
+			t.Errorf("wrong output. expected=`%s`, got=`%s`",
+				tt.expectedStdOut, output)
+		}
+	}
+}
+
+func TestEvalJumpStmtJumpPrecedence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		// jump precedence: last line < yield < return = raise
+		{
+			`{|i| yield i; i * 2}(1)`,
+			object.NewPanInt(1),
+		},
+		{
+			`{|i| yield i; return i * 2}(1)`,
+			object.NewPanInt(2),
+		},
+		{
+			`{|i| return i; yield i * 2}(3)`,
+			object.NewPanInt(3),
+		},
+		{
+			`{|i| return i; i * 2}(4)`,
+			object.NewPanInt(4),
+		},
+		{
+			`{|i| yield i; raise i * 2}(1)`,
+			object.NewPanInt(2),
+		},
+		{
+			`{|i| raise i; yield i * 2}(3)`,
+			object.NewPanInt(3),
+		},
+		{
+			`{|i| raise i; i * 2}(4)`,
+			object.NewPanInt(4),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalDefer(t *testing.T) {
+	// defer is evaluated after jump (same as that in golang)
+	tests := []struct {
+		input          string
+		expected       object.PanObject
+		expectedStdOut string
+	}{
+		{
+			`{|i| defer "one".p; i}(1)`,
+			object.NewPanInt(1),
+			"one\n",
+		},
+		{
+			`{|i| defer "one".p; return i}(1)`,
+			object.NewPanInt(1),
+			"one\n",
+		},
+		{
+			`{|i| defer "one".p; yield i}(1)`,
+			object.NewPanInt(1),
+			"one\n",
+		},
+		{
+			`{|i| defer "one".p; defer "two".p; i}(1)`,
+			object.NewPanInt(1),
+			"one\ntwo\n",
+		},
+		// NOTE: only defers above last evaluated line are valid
+		{
+			`{|i| defer "one".p; return i; defer "two".p}(1)`,
+			object.NewPanInt(1),
+			"one\n",
+		},
+		{
+			`{|i| defer "one".p; yield i; defer "two".p}(1)`,
+			object.NewPanInt(1),
+			"one\ntwo\n",
+		},
+		{
+			`{|i| defer "one".p if true; i}(1)`,
+			object.NewPanInt(1),
+			"one\n",
+		},
+		{
+			`{|i| defer "one".p if false; i}(1)`,
+			object.NewPanInt(1),
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		writer := &bytes.Buffer{}
+		// setup IO
+		env := object.NewEnvWithConsts()
+		env.InjectIO(os.Stdin, writer)
+
+		actual := testEvalInEnv(t, tt.input, env)
+		testValue(t, actual, tt.expected)
+
+		// check output
+		output := writer.String()
+		if output != tt.expectedStdOut {
+			t.Errorf("wrong output. expected=`%s`, got=`%s`",
+				tt.expectedStdOut, output)
+		}
+	}
+}
+
+func TestEvalRaise(t *testing.T) {
+	// raise works like return but unwraps errWrappers
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{|i| raise 10; i}(5)`,
+			object.NewPanInt(10),
+		},
+		{
+			`{|i| raise Err.new("new error"); i}(5)`,
+			object.NewPanErr("new error"),
+		},
+		// err wrapped by Either is unwrapped by raise
+		{
+			`5.try.fmap {Err.new("new error")}.A.{|val, err| raise err; val}`,
+			object.NewPanErr("new error"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalRaiseIf(t *testing.T) {
+	// raise works like return but unwraps errWrappers
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{|i| raise 10 if true; i}(5)`,
+			object.NewPanInt(10),
+		},
+		{
+			`{|i| raise Err.new("new error") if true; i}(5)`,
+			object.NewPanErr("new error"),
+		},
+		// err wrapped by Either is unwrapped by raise
+		{
+			`5.try.fmap {Err.new("new error")}.A.{|val, err| raise err if true; val}`,
+			object.NewPanErr("new error"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalArrIter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`it := ['a, 'b]._iter
+			 it.next`,
+			object.NewPanStr("a"),
+		},
+		{
+			`it := ['a, 'b]._iter
+			 it.next
+			 it.next`,
+			object.NewPanStr("b"),
+		},
+		{
+			`it := ['a, 'b]._iter
+			it.next
+			it.next
+			it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIntIter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`it := 2._iter
+			 it.next`,
+			object.NewPanInt(1),
+		},
+		{
+			`it := 2._iter
+			 it.next
+			 it.next`,
+			object.NewPanInt(2),
+		},
+		{
+			`it := 2._iter
+			it.next
+			it.next
+			it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalStrIter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`it := "Hi"._iter
+			 it.next`,
+			object.NewPanStr("H"),
+		},
+		{
+			`it := "Hi"._iter
+			 it.next
+			 it.next`,
+			object.NewPanStr("i"),
+		},
+		{
+			`it := "Hi"._iter
+			it.next
+			it.next
+			it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+		// each iteration yields rune (not byte!)
+		{
+			`it := "日本語"._iter
+			 it.next`,
+			object.NewPanStr("日"),
+		},
+		{
+			`it := "日本語"._iter
+			 it.next
+			 it.next`,
+			object.NewPanStr("本"),
+		},
+		{
+			`it := "日本語"._iter
+			 it.next
+			 it.next
+			 it.next
+			 it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalObjIter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`it := {a: "A", b: "B"}._iter
+			 it.next`,
+			object.NewPanArr(
+				object.NewPanStr("a"),
+				object.NewPanStr("A"),
+			),
+		},
+		{
+			`it := {a: "A", b: "B"}._iter
+			 it.next
+			 it.next`,
+			object.NewPanArr(
+				object.NewPanStr("b"),
+				object.NewPanStr("B"),
+			),
+		},
+		// sorted in alphabetical order of keys
+		{
+			`it := {b: "B", a: "A"}._iter
+			 it.next`,
+			object.NewPanArr(
+				object.NewPanStr("a"),
+				object.NewPanStr("A"),
+			),
+		},
+		{
+			`it := {b: "B", a: "A"}._iter
+			 it.next
+			 it.next`,
+			object.NewPanArr(
+				object.NewPanStr("b"),
+				object.NewPanStr("B"),
+			),
+		},
+		{
+			`it := {a: "A", b: "B"}._iter
+			it.next
+			it.next
+			it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+		// private keys are ignored
+		{
+			`it := {
+				_secret: "invisible",
+				'+: "PLUS",
+				"include spaces": "ignored",
+			 }._iter
+			 it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalMapIter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`it := %{true: 1, false: 2}._iter
+			 it.next`,
+			object.NewPanArr(
+				object.BuiltInTrue,
+				object.NewPanInt(1),
+			),
+		},
+		{
+			`it := %{true: 1, false: 2}._iter
+			 it.next
+			 it.next`,
+			object.NewPanArr(
+				object.BuiltInFalse,
+				object.NewPanInt(2),
+			),
+		},
+		{
+			`it := %{true: 1}._iter
+			 it.next
+			 it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+		// non-scalar key
+		{
+			`it := %{[0]: 1}._iter
+			 it.next`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanInt(0),
+				),
+				object.NewPanInt(1),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalRangeIter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`it := (0:2)._iter
+			 it.next`,
+			object.NewPanInt(0),
+		},
+		{
+			`it := (0:2)._iter
+			 it.next
+			 it.next`,
+			object.NewPanInt(1),
+		},
+		{
+			`it := (0:2)._iter
+			 it.next
+			 it.next`,
+			object.NewPanInt(1),
+		},
+		{
+			`it := (0:2)._iter
+			 it.next
+			 it.next
+			 it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+		{
+			`it := (10:7:-2)._iter
+			 it.next`,
+			object.NewPanInt(10),
+		},
+		{
+			`it := (10:7:-2)._iter
+			 it.next
+			 it.next`,
+			object.NewPanInt(8),
+		},
+		{
+			`it := (10:7:-2)._iter
+			 it.next
+			 it.next
+			 it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+		// str
+		{
+			`it := ('a:'c)._iter
+			 it.next`,
+			object.NewPanStr("a"),
+		},
+		{
+			`it := ('a:'c)._iter
+			 it.next
+			 it.next`,
+			object.NewPanStr("b"),
+		},
+		{
+			`it := ('a:'c)._iter
+			 it.next
+			 it.next
+			 it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalIterIter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`it := <{|i| yield i; recur(i+1)}>.new(1)._iter
+			 it.next`,
+			object.NewPanInt(1),
+		},
+		{
+			`it := <{|i| yield i; recur(i+1)}>.new(1)._iter
+			 it.next
+			 it.next`,
+			object.NewPanInt(2),
+		},
+		{
+			`it := <{|i| yield i if i < 2; recur(i+1)}>.new(1)._iter
+			 it.next
+			 it.next`,
+			object.NewStopIterErr("iter stopped"),
+		},
+		// new iter generated by ._iter is independent of original one
+		{
+			`orig := <{|i| yield i; recur(i+1)}>.new(1)
+			 it := orig._iter
+			 orig.next
+			 orig.next
+			 it.next`,
+			object.NewPanInt(1),
+		},
+		// errors
+		{
+			`it := <{}>['_iter]()`,
+			object.NewTypeErr("Iter#_iter requires at least 1 arg"),
+		},
+		{
+			`it := <{}>['_iter](1)`,
+			object.NewTypeErr("1 cannot be treated as iter"),
+		},
+		{
+			`it := <{}>['_iter]({|i| i})`,
+			object.NewTypeErr("{|i| i} cannot be treated as iter"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalListChainPropCall(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`[1, 2]@+(1)`,
+			object.NewPanArr(
+				object.NewPanInt(2),
+				object.NewPanInt(3),
+			),
+		},
+		{
+			`3@S`,
+			object.NewPanArr(
+				object.NewPanStr("1"),
+				object.NewPanStr("2"),
+				object.NewPanStr("3"),
+			),
+		},
+		{
+			`"日本語"@S`,
+			object.NewPanArr(
+				object.NewPanStr("日"),
+				object.NewPanStr("本"),
+				object.NewPanStr("語"),
+			),
+		},
+		// NOTE: must new() to init params
+		{
+			`<{|i| yield i if i != 3; recur(i+1)}>.new(0)@S`,
+			object.NewPanArr(
+				object.NewPanStr("0"),
+				object.NewPanStr("1"),
+				object.NewPanStr("2"),
+			),
+		},
+		{
+			`(1:6:2)@S`,
+			object.NewPanArr(
+				object.NewPanStr("1"),
+				object.NewPanStr("3"),
+				object.NewPanStr("5"),
+			),
+		},
+		{
+			`{a: 1, b: 2}@at([0])`,
+			object.NewPanArr(
+				object.NewPanStr("a"),
+				object.NewPanStr("b"),
+			),
+		},
+		{
+			`%{"a": 1, "b": 2, "c": 3}@at([0])`,
+			object.NewPanArr(
+				object.NewPanStr("a"),
+				object.NewPanStr("b"),
+				object.NewPanStr("c"),
+			),
+		},
+		// digest check
+		// NOTE: Obj#digest is defined in /native, which cannot be used in eval_test
+		{
+			`
+			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
+			[['a, 1], ['b, 2]]@(MyObj.bear({}))at([0:2])
+			`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+				{Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+			}),
+		},
+		{
+			`
+			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
+			[['A, 1], ['B, 2]]=@(MyObj.bear({}))at([0:2])
+			`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("A"), Value: object.NewPanInt(1)},
+				{Key: object.NewPanStr("B"), Value: object.NewPanInt(2)},
+			}),
+		},
+		// if any element raises error, propagate it
+		{
+			`[1, 2, "str", 4]@+(1)`,
+			object.NewTypeErr("1 cannot be treated as str"),
+		},
+		// digest failed
+		{
+			`
+			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
+			['a, 'b]@(MyObj.bear({}))uc
+			`,
+			object.NewValueErr("element \"A\" cannot be treated as arr"),
+		},
+		{
+			`
+			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
+			['a, 'b]=@(MyObj.bear({}))uc
+			`,
+			object.NewValueErr("element \"A\" cannot be treated as arr"),
+		},
+		// digest not defined
+		{
+			`[['a, 1], ['b, 2]]@(BaseObj)at([0:2])`,
+			object.NewNoPropErr("property `digest` is not defined."),
+		},
+		{
+			`[['a, 1], ['b, 2]]=@(BaseObj)at([0:2])`,
+			object.NewNoPropErr("property `digest` is not defined."),
 		},
 	}
 
@@ -2240,14 +4905,17 @@ func TestEvalFuncCall(t *testing.T) {
 	}
 }
 
-func TestEvalEmptyFuncCall(t *testing.T) {
+func TestEvalListChainPropCallIgnoresNil(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{||}()`,
-			object.BuiltInNil,
+			`[{name: 'Taro}, {name: nil}, {name: 'Jiro}]@name`,
+			object.NewPanArr(
+				object.NewPanStr("Taro"),
+				object.NewPanStr("Jiro"),
+			),
 		},
 	}
 
@@ -2257,36 +4925,117 @@ func TestEvalEmptyFuncCall(t *testing.T) {
 	}
 }
 
-func TestEvalMultiLineFuncCall(t *testing.T) {
+func TestEvalListChainLiteralCall(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{|a, b| a; b}(1, 2)`,
-			object.NewPanInt(2),
+			`[1, 2]@{|i| i + 1}`,
+			object.NewPanArr(
+				object.NewPanInt(2),
+				object.NewPanInt(3),
+			),
+		},
+		{
+			`3@{|i| i.S}`,
+			object.NewPanArr(
+				object.NewPanStr("1"),
+				object.NewPanStr("2"),
+				object.NewPanStr("3"),
+			),
+		},
+		{
+			`"日本語"@{|c| c + "!"}`,
+			object.NewPanArr(
+				object.NewPanStr("日!"),
+				object.NewPanStr("本!"),
+				object.NewPanStr("語!"),
+			),
+		},
+		{
+			`{a: 1, b: 2}@{|k, v| "#{k}: #{v}"}`,
+			object.NewPanArr(
+				object.NewPanStr("a: 1"),
+				object.NewPanStr("b: 2"),
+			),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalRecurredFuncCall(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
+		{
+			`%{true: "yes", false: "no"}@{|k, v| [k, v]}`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.BuiltInTrue,
+					object.NewPanStr("yes"),
+				),
+				object.NewPanArr(
+					object.BuiltInFalse,
+					object.NewPanStr("no"),
+				),
+			),
+		},
+		{
+			`(1:6:2)@{|n| n+1}`,
+			object.NewPanArr(
+				object.NewPanInt(2),
+				object.NewPanInt(4),
+				object.NewPanInt(6),
+			),
+		},
+		// digest check
+		// NOTE: Obj#digest is defined in /native, which cannot be used in eval_test
 		{
 			`
-			func := {|f| f(); f}
-			inner := {|| 1}
-			outer := {func(inner)}
-			func(outer) == outer
+			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
+			['a, 'b]@(MyObj.bear({})){[\, .uc]}
 			`,
-			object.BuiltInTrue,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanStr("A")},
+				{Key: object.NewPanStr("b"), Value: object.NewPanStr("B")},
+			}),
+		},
+		{
+			`
+			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
+			['c, 'd]=@(MyObj.bear({})){[\, .uc]}
+			`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("c"), Value: object.NewPanStr("C")},
+				{Key: object.NewPanStr("d"), Value: object.NewPanStr("D")},
+			}),
+		},
+		// if any element raises error, propagate it
+		{
+			`[2, 0, 3]@{|n| 6/n}`,
+			object.NewZeroDivisionErr("cannot be divided by 0"),
+		},
+		// if chainarg raises error, propagate it
+		{
+			`[1]@(_){|n| n + 1}`,
+			object.BuiltInNotImplemented,
+		},
+		// digest failed
+		{
+			`
+			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
+			['a, 'b]@(MyObj.bear({})){[.uc]}
+			`,
+			object.NewValueErr("element [\"A\"] must have two elements"),
+		},
+		{
+			`
+			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
+			['a, 'b]=@(MyObj.bear({})){[.uc]}
+			`,
+			object.NewValueErr("element [\"A\"] must have two elements"),
+		},
+		// digest not defined
+		{
+			`['a, 'b]@(BaseObj){[\, .uc]}`,
+			object.NewNoPropErr("property `digest` is not defined."),
+		},
+		{
+			`['a, 'b]=@(BaseObj){[\, .uc]}`,
+			object.NewNoPropErr("property `digest` is not defined."),
 		},
 	}
 
@@ -2296,14 +5045,17 @@ func TestEvalRecurredFuncCall(t *testing.T) {
 	}
 }
 
-func TestEvalBuiltInFuncCall(t *testing.T) {
+func TestEvalListChainLiteralCallIgnoresNil(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`f := {||}['at]; f({a: 10}, ['a])`,
-			object.NewPanInt(10),
+			`[{name: 'Taro}, {name: nil}, {name: 'Jiro}]@{|o| o.name}`,
+			object.NewPanArr(
+				object.NewPanStr("Taro"),
+				object.NewPanStr("Jiro"),
+			),
 		},
 	}
 
@@ -2313,247 +5065,108 @@ func TestEvalBuiltInFuncCall(t *testing.T) {
 	}
 }
 
-func TestEvalBuiltInFuncInvalidArgErr(t *testing.T) {
+func TestEvalListChainVarCall(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected *object.PanErr
+		expected object.PanObject
 	}{
 		{
-			`{call: {|x| x}['call]}.call(1)`,
-			object.NewTypeErr("{\"call\": {|| [builtin]}} is not callable."),
-		},
-		{
-			`f := {||}['at]; f()`,
-			object.NewTypeErr("Obj#at requires at least 2 args"),
-		},
-		{
-			`f := {||}['at]; f(1)`,
-			object.NewTypeErr("Obj#at requires at least 2 args"),
+			`f := {|i| i + 1}; [1, 2]@^f`,
+			object.NewPanArr(
+				object.NewPanInt(2),
+				object.NewPanInt(3),
+			),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testPanErr(t, actual, tt.expected)
-	}
-}
-
-func TestEvalIterLiteral(t *testing.T) {
-	outerEnv := object.NewEnv()
-
-	tests := []struct {
-		input    string
-		expected *object.PanFunc
-	}{
 		{
-			`<{||}>`,
-			toPanIter(
-				[]string{},
-				[]object.Pair{},
-				`|| `,
-				object.NewEnclosedEnv(outerEnv),
+			`f := {|i| i.S}; 3@^f`,
+			object.NewPanArr(
+				object.NewPanStr("1"),
+				object.NewPanStr("2"),
+				object.NewPanStr("3"),
 			),
 		},
 		{
-			`<{|a|}>`,
-			toPanIter(
-				[]string{"a"},
-				[]object.Pair{},
-				`|a| `,
-				object.NewEnclosedEnv(outerEnv),
+			`f := {|c| c + "!"}; "日本語"@^f`,
+			object.NewPanArr(
+				object.NewPanStr("日!"),
+				object.NewPanStr("本!"),
+				object.NewPanStr("語!"),
 			),
 		},
 		{
-			`<{|a, b|}>`,
-			toPanIter(
-				[]string{"a", "b"},
-				[]object.Pair{},
-				`|a, b| `,
-				object.NewEnclosedEnv(outerEnv),
+			`f := {|k, v| "#{k}: #{v}"}; {a: 1, b: 2}@^f`,
+			object.NewPanArr(
+				object.NewPanStr("a: 1"),
+				object.NewPanStr("b: 2"),
 			),
 		},
 		{
-			`<{|a, b, c:10|}>`,
-			toPanIter(
-				[]string{"a", "b"},
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("c"),
-						Value: object.NewPanInt(10),
-					},
-				},
-				`|a, b, c: 10| `,
-				object.NewEnclosedEnv(outerEnv),
+			`f := {|k, v| [k, v]}; %{true: "yes", false: "no"}@^f`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.BuiltInTrue,
+					object.NewPanStr("yes"),
+				),
+				object.NewPanArr(
+					object.BuiltInFalse,
+					object.NewPanStr("no"),
+				),
 			),
 		},
 		{
-			`<{|a, b, c: 10, d:'e|}>`,
-			toPanIter(
-				[]string{"a", "b"},
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("c"),
-						Value: object.NewPanInt(10),
-					},
-					{
-						Key:   object.NewPanStr("d"),
-						Value: object.NewPanStr("e"),
-					},
-				},
-				`|a, b, c: 10, d: 'e| `,
-				object.NewEnclosedEnv(outerEnv),
+			`f := {|n| n+1}; (1:6:2)@^f`,
+			object.NewPanArr(
+				object.NewPanInt(2),
+				object.NewPanInt(4),
+				object.NewPanInt(6),
 			),
 		},
+		// if any element raises error, propagate it
+		{
+			`f := {|n| 6/n}; [2, 0, 3]@^f`,
+			object.NewZeroDivisionErr("cannot be divided by 0"),
+		},
 	}
 
 	for _, tt := range tests {
-		actual := testEvalInEnv(t, tt.input, outerEnv)
-		testPanFunc(t, actual, tt.expected)
-	}
-}
-
-func toPanIter(
-	args []string,
-	kwargs []object.Pair,
-	str string,
-	env *object.Env,
-) *object.PanFunc {
-	argArr := []object.PanObject{}
-	for _, arg := range args {
-		argArr = append(argArr, object.NewPanStr(arg))
-	}
-
-	funcWrapper := &FuncWrapperImpl{
-		codeStr: str,
-		args:    object.NewPanArr(argArr...),
-		kwargs:  toPanObj(kwargs),
-		// empty stmt (body is not tested)
-		body: &[]ast.Stmt{},
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
 	}
-
-	return object.NewPanIter(funcWrapper, env)
 }
 
-func TestEvalIterNew(t *testing.T) {
-	outerEnv := object.NewEnv()
-
+func TestEvalListChainVarCallIgnoresNil(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
-		// return new Iter with args set
-		{
-			`<{|a| yield a}>.new(10)`,
-			toPanIter(
-				[]string{"a"},
-				[]object.Pair{},
-				`|a| yield a`,
-				toEnv(
-					[]object.Pair{
-						{
-							Key:   object.NewPanStr("a"),
-							Value: object.NewPanInt(10),
-						},
-					},
-					[]object.Pair{},
-					outerEnv,
-				),
-			),
-		},
 		{
-			`<{|a, b, c: 'c| yield a}>.new('A, 'B, c: 'C)`,
-			toPanIter(
-				[]string{"a", "b"},
-				[]object.Pair{
-					{
-						Key:   object.NewPanStr("c"),
-						Value: object.NewPanStr("c"),
-					},
-				},
-				`|a, b, c: 'c| yield a`,
-				toEnv(
-					[]object.Pair{
-						{
-							Key:   object.NewPanStr("a"),
-							Value: object.NewPanStr("A"),
-						},
-						{
-							Key:   object.NewPanStr("b"),
-							Value: object.NewPanStr("B"),
-						},
-					},
-					[]object.Pair{
-						{
-							Key:   object.NewPanStr("c"),
-							Value: object.NewPanStr("C"),
-						},
-					},
-					outerEnv,
-				),
+			`f := {|o| o.name}; [{name: 'Taro}, {name: nil}, {name: 'Jiro}]@^f`,
+			object.NewPanArr(
+				object.NewPanStr("Taro"),
+				object.NewPanStr("Jiro"),
 			),
 		},
-		// builtinIter cannot handle new because it cannot hold the received args
-		{
-			`[1, 2, 3]._iter.new(2)`,
-			object.NewValueErr("Iter#new cannot handle builtinIter (use _iter instead)"),
-		},
 	}
 
 	for _, tt := range tests {
-		if outerEnv.Items().Inspect() != "{}" {
-			t.Errorf("outerEnv must be empty. got=%s", outerEnv.Items().Inspect())
-		}
-
-		actual := testEvalInEnv(t, tt.input, outerEnv)
+		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
 	}
 }
 
-func toEnv(
-	argPairs []object.Pair,
-	kwargPairs []object.Pair,
-	outer *object.Env,
-) *object.Env {
-	env := object.NewEnclosedEnv(outer)
-	for i, pair := range argPairs {
-		sym := object.GetSymHash(pair.Key.(*object.PanStr).Value)
-		env.Set(sym, pair.Value)
-		// \n
-		argSym := object.GetSymHash(fmt.Sprintf(`\%d`, i+1))
-		env.Set(argSym, pair.Value)
-	}
-	// \0
-	argValues := []object.PanObject{}
-	for _, pair := range argPairs {
-		argValues = append(argValues, pair.Value)
-	}
-	env.Set(object.GetSymHash(`\0`), object.NewPanArr(argValues...))
-	// \
-	if len(argValues) > 0 {
-		env.Set(object.GetSymHash("\\"), argValues[0])
-	}
-
-	for _, pair := range kwargPairs {
-		sym := object.GetSymHash(pair.Key.(*object.PanStr).Value)
-		env.Set(sym, pair.Value)
-		// \hoge
-		kwargSym := object.GetSymHash("\\" + pair.Key.(*object.PanStr).Value)
-		env.Set(kwargSym, pair.Value)
-	}
-	// \_
-	env.Set(object.GetSymHash("\\_"), toPanObj(kwargPairs))
-
-	return env
-}
-
-func TestEvalDiamondLiteral(t *testing.T) {
+func TestEvalReduceChainPropCall(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`<>`,
-			object.BuiltInDiamondObj,
+			`[1, 2, 3]$(4)+`,
+			object.NewPanInt(10),
+		},
+		// if chainarg is not set, acc is `nil`
+		{
+			`[nil]$==`,
+			object.BuiltInTrue,
 		},
 	}
 
@@ -2563,184 +5176,161 @@ func TestEvalDiamondLiteral(t *testing.T) {
 	}
 }
 
-func TestEvalDiamondProps(t *testing.T) {
+func TestEvalReduceChainPropCallRecvIsAcc(t *testing.T) {
+	// receiver of prop is resolved to acc
 	tests := []struct {
-		stdin    string
 		input    string
 		expected object.PanObject
 	}{
-		// NOTE: <> can use all str props
-		{
-			`hoge`,
-			`<>.uc`,
-			object.NewPanStr("HOGE"),
-		},
-		{
-			"HOGE\nFUGA",
-			`<>.lc
-			 <>.lc`,
-			object.NewPanStr("fuga"),
-		},
-		// after reached to EOF, "" is read
-		{
-			"hoge\nfuga",
-			`<>.uc
-			 <>.uc
-			 <>.uc`,
-			object.NewPanStr(""),
-		},
-		// Diamond#S returns read line str
+		// "a"['+] is called
 		{
-			"hoge\nfuga",
-			`<>.S+<>.S`,
-			object.NewPanStr("hogefuga"),
+			`[1]$("a")+`,
+			object.NewTypeErr("1 cannot be treated as str"),
 		},
 	}
-	for _, tt := range tests {
-		reader := strings.NewReader(tt.stdin)
-		// setup IO
-		env := object.NewEnvWithConsts()
-		env.InjectIO(reader, os.Stdout)
 
-		actual := testEvalInEnv(t, tt.input, env)
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalDiamondPropsErr(t *testing.T) {
+func TestEvalReduceChainLiteralCall(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`<>._missing`,
-			object.NewTypeErr("_missing requires at least 2 args"),
-		},
-		{
-			`<>['S]()`,
-			object.NewTypeErr("Diamond#S requires at least 1 arg"),
+			`[1, 2, 3]$(0){|i, j| i + j}`,
+			object.NewPanInt(6),
 		},
 		{
-			`<>._missing(1)`,
-			object.NewTypeErr("\\2 must be prop name str"),
+			`[1, 2, 3]$(4){|i, j| i + j}`,
+			object.NewPanInt(10),
 		},
+		// if chainarg is not set, acc is `nil`
 		{
-			`IO := 1; <>.uc`,
-			object.NewTypeErr("name `IO` must be IO obj"),
+			`[nil]${|i, j| i == j}`,
+			object.BuiltInTrue,
 		},
 	}
-	for _, tt := range tests {
-		// setup IO
-		env := object.NewEnvWithConsts()
-		env.InjectIO(os.Stdin, os.Stdout)
 
-		actual := testEvalInEnv(t, tt.input, env)
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalDiamondPropsErrIONotFound(t *testing.T) {
+func TestEvalReduceChainVarCall(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`<>.lc`,
-			object.NewNameErr("`IO` is not found in env"),
+			`f := {|i, j| i + j}; [1, 2, 3]$(0)^f`,
+			object.NewPanInt(6),
 		},
 		{
-			`<>.S`,
-			object.NewNameErr("`IO` is not found in env"),
+			`f := {|i, j| i + j}; [1, 2, 3]$(4)^f`,
+			object.NewPanInt(10),
 		},
+		// if chainarg is not set, acc is `nil`
 		{
-			`<>@{\}`,
-			object.NewNameErr("`IO` is not found in env"),
+			`f := {|i, j| i == j}; [nil]$^f`,
+			object.BuiltInTrue,
 		},
 	}
+
 	for _, tt := range tests {
-		// without IO injection
-		env := object.NewEnvWithConsts()
-		actual := testEvalInEnv(t, tt.input, env)
+		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalDiamondIter(t *testing.T) {
+func TestEvalIfExpr(t *testing.T) {
 	tests := []struct {
-		stdin    string
 		input    string
 		expected object.PanObject
 	}{
-		// <> reads all lines until EOF
 		{
-			"hoge\nfuga\npiyo",
-			`<>@{\}`,
-			object.NewPanArr(
-				object.NewPanStr("hoge"),
-				object.NewPanStr("fuga"),
-				object.NewPanStr("piyo"),
-			),
+			`1 if true`,
+			object.NewPanInt(1),
+		},
+		{
+			`1 if false`,
+			object.BuiltInNil,
+		},
+		{
+			`10 if true else 5`,
+			object.NewPanInt(10),
+		},
+		{
+			`10 if false else 5`,
+			object.NewPanInt(5),
 		},
+		// cond other than bool
 		{
-			"hoge\nfuga\npiyo\n",
-			`<>@{\}`,
-			object.NewPanArr(
-				object.NewPanStr("hoge"),
-				object.NewPanStr("fuga"),
-				object.NewPanStr("piyo"),
-			),
+			`'t if 100 else 'f`,
+			object.NewPanStr("t"),
+		},
+		{
+			`'t if [] else 'f`,
+			object.NewPanStr("f"),
 		},
 	}
-	for _, tt := range tests {
-		reader := strings.NewReader(tt.stdin)
-		// setup IO
-		env := object.NewEnvWithConsts()
-		env.InjectIO(reader, os.Stdout)
 
-		actual := testEvalInEnv(t, tt.input, env)
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalYield(t *testing.T) {
+func TestEvalInfixOr(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`<{|a| yield a}>.new(1).next`,
-			object.NewPanInt(1),
+			`true || false`,
+			object.BuiltInTrue,
 		},
 		{
-			`<{|a| yield a}>.new('a).next`,
+			`false || true`,
+			object.BuiltInTrue,
+		},
+		{
+			`false || false`,
+			object.BuiltInFalse,
+		},
+		{
+			`true || true`,
+			object.BuiltInTrue,
+		},
+		{
+			`'a || 10`,
 			object.NewPanStr("a"),
 		},
-		// recur
 		{
-			`it := <{|a| yield a; recur(a+1)}>.new(1)
-			 it.next
-			 it.next
-			 `,
-			object.NewPanInt(2),
+			`false || 10`,
+			object.NewPanInt(10),
 		},
+		// evaluated to right if left is falsy
 		{
-			`it := <{|a| yield a; recur(a+1)}>.new(1)
-			 it.next
-			 it.next
-			 it.next
-			`,
-			object.NewPanInt(3),
+			`[] || 1`,
+			object.NewPanInt(1),
 		},
 		{
-			`it := <{|a, b| yield [a, b]; recur(a+1, b*2)}>.new(1, 2)
-			 it.next
-			 it.next
-			 it.next
-			`,
-			object.NewPanArr(
-				object.NewPanInt(3),
-				object.NewPanInt(8),
-			),
+			`"" || "b"`,
+			object.NewPanStr("b"),
+		},
+		// shortcut
+		{
+			`5 || _`,
+			object.NewPanInt(5),
+		},
+		{
+			`_ || 5`,
+			object.BuiltInNotImplemented,
 		},
 	}
 
@@ -2750,20 +5340,49 @@ func TestEvalYield(t *testing.T) {
 	}
 }
 
-func TestEvalIterYieldIsIndependent(t *testing.T) {
+func TestEvalInfixAnd(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`
-			it := <{|a| yield a; recur(a+1)}>.new(1)
-			it.next
-			it.next
-			it2 := it.new(1)
-			it2.next`,
+			`true && false`,
+			object.BuiltInFalse,
+		},
+		{
+			`false && true`,
+			object.BuiltInFalse,
+		},
+		{
+			`false && false`,
+			object.BuiltInFalse,
+		},
+		{
+			`true && true`,
+			object.BuiltInTrue,
+		},
+		{
+			`false && 10`,
+			object.BuiltInFalse,
+		},
+		// evaluated to right if left is truthy
+		{
+			`['a] && 1`,
 			object.NewPanInt(1),
 		},
+		{
+			`"abc" && "b"`,
+			object.NewPanStr("b"),
+		},
+		// shortcut
+		{
+			`"" && _`,
+			object.NewPanStr(""),
+		},
+		{
+			`_ && ""`,
+			object.BuiltInNotImplemented,
+		},
 	}
 
 	for _, tt := range tests {
@@ -2772,286 +5391,295 @@ func TestEvalIterYieldIsIndependent(t *testing.T) {
 	}
 }
 
-func TestEvalYieldStopped(t *testing.T) {
+func TestEvalAssign(t *testing.T) {
 	tests := []struct {
-		input    string
-		expected object.PanObject
+		input       string
+		expected    object.PanObject
+		expectedEnv *object.PanObj
 	}{
 		{
-			`it := <{|a| yield a if a == 1; recur(a+1)}>.new(1)
-			it.next`,
-			object.NewPanInt(1),
+			`a := 'A`,
+			object.NewPanStr("A"),
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanStr("A"),
+				},
+			}),
 		},
 		{
-			`it := <{|a| yield a if a == 1; recur(a+1)}>.new(1)
-			it.next
-			it.next`,
-			object.NewStopIterErr("iter stopped"),
+			`'A => a`,
+			object.NewPanStr("A"),
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanStr("A"),
+				},
+			}),
+		},
+		{
+			`a := 'A; a`,
+			object.NewPanStr("A"),
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanStr("A"),
+				},
+			}),
+		},
+		{
+			`a := 5; b := 10; [a, b]`,
+			object.NewPanArr(
+				object.NewPanInt(5),
+				object.NewPanInt(10),
+			),
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanInt(5),
+				},
+				{
+					Key:   object.NewPanStr("b"),
+					Value: object.NewPanInt(10),
+				},
+			}),
+		},
+		{
+			`a := b := 2; [a, b]`,
+			object.NewPanArr(
+				object.NewPanInt(2),
+				object.NewPanInt(2),
+			),
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanInt(2),
+				},
+				{
+					Key:   object.NewPanStr("b"),
+					Value: object.NewPanInt(2),
+				},
+			}),
+		},
+		{
+			`"hi" => a; a`,
+			object.NewPanStr("hi"),
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanStr("hi"),
+				},
+			}),
+		},
+		{
+			`3 => c => d; [c, d]`,
+			object.NewPanArr(
+				object.NewPanInt(3),
+				object.NewPanInt(3),
+			),
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("c"),
+					Value: object.NewPanInt(3),
+				},
+				{
+					Key:   object.NewPanStr("d"),
+					Value: object.NewPanInt(3),
+				},
+			}),
+		},
+		// right value is not assigned if error raises
+		{
+			`a := 0 / 0`,
+			object.NewZeroDivisionErr("cannot be divided by 0"),
+			toPanObj([]object.Pair{}),
+		},
+		{
+			`0 / 0 => b`,
+			object.NewZeroDivisionErr("cannot be divided by 0"),
+			toPanObj([]object.Pair{}),
 		},
 	}
 
 	for _, tt := range tests {
-		actual := testEval(t, tt.input)
+		// NOTE: use empty env without consts to make tt simple
+		env := object.NewEnv()
+		actual := testEvalInEnv(t, tt.input, env)
 		testValue(t, actual, tt.expected)
+		testValue(t, env.Items(), tt.expectedEnv)
 	}
 }
 
-func TestEvalJumpStmt(t *testing.T) {
+func TestEvalAssignShadowingConsts(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{|i| yield i}(1)`,
-			object.NewPanInt(1),
-		},
-		{
-			`{|i| return i}(2)`,
-			object.NewPanInt(2),
-		},
-		{
-			`{|i| raise Err.new("new error")}(2)`,
-			object.NewPanErr("new error"),
+			`true := 100; true`,
+			object.NewPanInt(100),
 		},
 	}
+
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalJumpIfStmt(t *testing.T) {
+func TestEvalArgVars(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{|i| yield i if true; i * 2}(1)`,
-			object.NewPanInt(1),
-		},
-		// raise StopIterErr
-		{
-			`{|i| yield i if false; i * 2}(1)`,
-			object.NewStopIterErr("iter stopped"),
+			`{\1}("a", "b")`,
+			object.NewPanStr("a"),
 		},
 		{
-			`{|i| return i if true; i * 2}(10)`,
-			object.NewPanInt(10),
+			`{\2}("a", "b")`,
+			object.NewPanStr("b"),
 		},
+		// NameErr if number exceeds arity
 		{
-			`{|i| return i if false; i * 2}(10)`,
-			object.NewPanInt(20),
+			`{\3}("a", "b")`,
+			object.NewNameErr("name `\\3` is not defined"),
 		},
+		// `\` is syntax sugar of `\1`
 		{
-			`{|i| raise Err.new("new error") if true; i}(2)`,
-			object.NewPanErr("new error"),
+			`{\}("one", "two")`,
+			object.NewPanStr("one"),
 		},
+		// `\0` is arr of all args
 		{
-			`{|i| raise Err.new("new error") if false; i}(2)`,
-			object.NewPanInt(2),
+			`{\0}("one", "two")`,
+			object.NewPanArr(
+				object.NewPanStr("one"),
+				object.NewPanStr("two"),
+			),
 		},
-		// recursion
+		// NOTE: \0 does not contain kwargs
 		{
-			`fact := {|n| return 1 if n == 0; n * fact(n - 1)}; fact(4)`,
-			object.NewPanInt(24),
+			`{\0}("arg", kwarg: "kwarg")`,
+			object.NewPanArr(
+				object.NewPanStr("arg"),
+			),
 		},
 	}
+
 	for _, tt := range tests {
-		tt := tt //pin
-		t.Run(tt.input, func(t *testing.T) {
-			actual := testEval(t, tt.input)
-			testValue(t, actual, tt.expected)
-		})
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalJumpIfStmtWithNonBoolCond(t *testing.T) {
+func TestEvalKwargVars(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
-		// `(cond).B` is called to check cond is truthy/falsy
 		{
-			`{|i| yield i if 1; i * 2}(1)`,
-			object.NewPanInt(1),
+			`{\a}(a: "A", b: "B")`,
+			object.NewPanStr("A"),
 		},
 		{
-			`{|i| yield i if ""; i * 2}(1)`,
-			object.NewStopIterErr("iter stopped"),
+			`{\b}(a: "A", b: "B")`,
+			object.NewPanStr("B"),
 		},
+		// NameErr if kwarg is not found
 		{
-			`{|i| return i if [1,2,3]; i * 2}(10)`,
-			object.NewPanInt(10),
+			`{\c}(a: "A", b: "B")`,
+			object.NewNameErr("name `\\c` is not defined"),
 		},
+		// `\_` is obj of all kwargs
 		{
-			`{|i| return i if nil; i * 2}(10)`,
-			object.NewPanInt(20),
+			`{\_}(one: 1, two: 2)`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("one"),
+					Value: object.NewPanInt(1),
+				},
+				{
+					Key:   object.NewPanStr("two"),
+					Value: object.NewPanInt(2),
+				},
+			}),
 		},
-	}
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalStmtsAfterYieldAreEvaluated(t *testing.T) {
-	tests := []struct {
-		input          string
-		expected       object.PanObject
-		expectedStdOut string
-	}{
+		// NOTE: \0 does not contain kwargs
 		{
-			`{|i| yield i; "evaluated!".p}(1)`,
-			object.NewPanInt(1),
-			"evaluated!\n",
+			`{\0}("arg", kwarg: "kwarg")`,
+			object.NewPanArr(
+				object.NewPanStr("arg"),
+			),
 		},
 	}
-	for _, tt := range tests {
-		writer := &bytes.Buffer{}
-		// setup IO
-		env := object.NewEnvWithConsts()
-		env.InjectIO(os.Stdin, writer)
 
-		actual := testEvalInEnv(t, tt.input, env)
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
-
-		// check output
-		output := writer.String()
-		if output != tt.expectedStdOut {
-			t.Errorf("wrong output. expected=`%s`, got=`%s`",
-				tt.expectedStdOut, output)
-		}
 	}
 }
 
-func TestEvalJumpStmtJumpPrecedence(t *testing.T) {
+func TestEvalProto(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
-		// jump precedence: last line < yield < return = raise
-		{
-			`{|i| yield i; i * 2}(1)`,
-			object.NewPanInt(1),
-		},
-		{
-			`{|i| yield i; return i * 2}(1)`,
-			object.NewPanInt(2),
-		},
-		{
-			`{|i| return i; yield i * 2}(3)`,
-			object.NewPanInt(3),
-		},
-		{
-			`{|i| return i; i * 2}(4)`,
-			object.NewPanInt(4),
-		},
 		{
-			`{|i| yield i; raise i * 2}(1)`,
-			object.NewPanInt(2),
+			`Obj.proto`,
+			object.BuiltInBaseObj,
 		},
 		{
-			`{|i| raise i; yield i * 2}(3)`,
-			object.NewPanInt(3),
+			`[].proto`,
+			object.BuiltInArrObj,
 		},
 		{
-			`{|i| raise i; i * 2}(4)`,
-			object.NewPanInt(4),
+			`1.0.proto`,
+			object.BuiltInFloatObj,
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalDefer(t *testing.T) {
-	// defer is evaluated after jump (same as that in golang)
-	tests := []struct {
-		input          string
-		expected       object.PanObject
-		expectedStdOut string
-	}{
 		{
-			`{|i| defer "one".p; i}(1)`,
-			object.NewPanInt(1),
-			"one\n",
+			`{||}.proto`,
+			object.BuiltInFuncObj,
 		},
 		{
-			`{|i| defer "one".p; return i}(1)`,
-			object.NewPanInt(1),
-			"one\n",
+			`<{||}>.proto`,
+			object.BuiltInIterObj,
 		},
 		{
-			`{|i| defer "one".p; yield i}(1)`,
-			object.NewPanInt(1),
-			"one\n",
+			`1.proto`,
+			object.BuiltInIntObj,
 		},
 		{
-			`{|i| defer "one".p; defer "two".p; i}(1)`,
-			object.NewPanInt(1),
-			"one\ntwo\n",
+			`%{}.proto`,
+			object.BuiltInMapObj,
 		},
-		// NOTE: only defers above last evaluated line are valid
 		{
-			`{|i| defer "one".p; return i; defer "two".p}(1)`,
-			object.NewPanInt(1),
-			"one\n",
+			`Set.new([1]).proto`,
+			object.BuiltInSetObj,
 		},
 		{
-			`{|i| defer "one".p; yield i; defer "two".p}(1)`,
-			object.NewPanInt(1),
-			"one\ntwo\n",
+			`Ref.new(1).proto`,
+			object.BuiltInRefObj,
 		},
 		{
-			`{|i| defer "one".p if true; i}(1)`,
-			object.NewPanInt(1),
-			"one\n",
+			`nil.proto`,
+			object.BuiltInNilObj,
 		},
 		{
-			`{|i| defer "one".p if false; i}(1)`,
-			object.NewPanInt(1),
-			"",
+			`{}.proto`,
+			object.BuiltInObjObj,
 		},
-	}
-
-	for _, tt := range tests {
-		writer := &bytes.Buffer{}
-		// setup IO
-		env := object.NewEnvWithConsts()
-		env.InjectIO(os.Stdin, writer)
-
-		actual := testEvalInEnv(t, tt.input, env)
-		testValue(t, actual, tt.expected)
-
-		// check output
-		output := writer.String()
-		if output != tt.expectedStdOut {
-			t.Errorf("wrong output. expected=`%s`, got=`%s`",
-				tt.expectedStdOut, output)
-		}
-	}
-}
-
-func TestEvalRaise(t *testing.T) {
-	// raise works like return but unwraps errWrappers
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
 		{
-			`{|i| raise 10; i}(5)`,
-			object.NewPanInt(10),
+			`'a.proto`,
+			object.BuiltInStrObj,
 		},
 		{
-			`{|i| raise Err.new("new error"); i}(5)`,
-			object.NewPanErr("new error"),
+			`true.proto`,
+			object.BuiltInOneInt,
 		},
-		// err wrapped by Either is unwrapped by raise
 		{
-			`5.try.fmap {Err.new("new error")}.A.{|val, err| raise err; val}`,
-			object.NewPanErr("new error"),
+			`false.proto`,
+			object.BuiltInZeroInt,
 		},
 	}
 
@@ -3061,248 +5689,123 @@ func TestEvalRaise(t *testing.T) {
 	}
 }
 
-func TestEvalRaiseIf(t *testing.T) {
-	// raise works like return but unwraps errWrappers
+func TestEvalBearProto(t *testing.T) {
 	tests := []struct {
-		input    string
-		expected object.PanObject
+		input string
 	}{
-		{
-			`{|i| raise 10 if true; i}(5)`,
-			object.NewPanInt(10),
-		},
-		{
-			`{|i| raise Err.new("new error") if true; i}(5)`,
-			object.NewPanErr("new error"),
-		},
-		// err wrapped by Either is unwrapped by raise
-		{
-			`5.try.fmap {Err.new("new error")}.A.{|val, err| raise err if true; val}`,
-			object.NewPanErr("new error"),
-		},
+		{`obj := {a: 1};  obj.bear({x: 2}).proto == obj`},
+		{`arr := [1, 2];  arr.bear({x: 2}).proto == arr`},
+		{`num := 2;       num.bear({x: 2}).proto == num`},
+		{`map := %{1: 2}; map.bear({x: 2}).proto == map`},
 	}
-
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
+		testValue(t, actual, object.BuiltInTrue)
 	}
 }
 
-func TestEvalArrIter(t *testing.T) {
+// NOTE: since these objects must be singletons and used for equality check,
+// they bear itself and does not allow descendants.
+func TestEvalBearSingleton(t *testing.T) {
 	tests := []struct {
-		input    string
-		expected object.PanObject
+		input string
 	}{
-		{
-			`it := ['a, 'b]._iter
-			 it.next`,
-			object.NewPanStr("a"),
-		},
-		{
-			`it := ['a, 'b]._iter
-			 it.next
-			 it.next`,
-			object.NewPanStr("b"),
-		},
-		{
-			`it := ['a, 'b]._iter
-			it.next
-			it.next
-			it.next`,
-			object.NewStopIterErr("iter stopped"),
-		},
+		{`true.bear == true`},
+		{`false.bear == false`},
+		// ignore args
+		{`true.bear({a: 1}) == true`},
+		{`false.bear({a: 1}) == false`},
 	}
-
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
+		testValue(t, actual, object.BuiltInTrue)
 	}
 }
 
-func TestEvalIntIter(t *testing.T) {
+func TestEvalBearErr(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`it := 2._iter
-			 it.next`,
-			object.NewPanInt(1),
-		},
-		{
-			`it := 2._iter
-			 it.next
-			 it.next`,
-			object.NewPanInt(2),
+			`{}.bear(1)`,
+			object.NewTypeErr("BaseObj#bear requires obj literal src"),
 		},
 		{
-			`it := 2._iter
-			it.next
-			it.next
-			it.next`,
-			object.NewStopIterErr("iter stopped"),
+			`f := {}['bear]; f()`,
+			object.NewTypeErr("BaseObj#bear requires at least 1 arg"),
 		},
 	}
-
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalStrIter(t *testing.T) {
+func TestEvalBearContents(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`it := "Hi"._iter
-			 it.next`,
-			object.NewPanStr("H"),
-		},
-		{
-			`it := "Hi"._iter
-			 it.next
-			 it.next`,
-			object.NewPanStr("i"),
-		},
-		{
-			`it := "Hi"._iter
-			it.next
-			it.next
-			it.next`,
-			object.NewStopIterErr("iter stopped"),
-		},
-		// each iteration yields rune (not byte!)
-		{
-			`it := "日本語"._iter
-			 it.next`,
-			object.NewPanStr("日"),
-		},
-		{
-			`it := "日本語"._iter
-			 it.next
-			 it.next`,
-			object.NewPanStr("本"),
+			`Obj.bear({a: 1})`,
+			toPanObj([]object.Pair{
+				{
+					Key:   object.NewPanStr("a"),
+					Value: object.NewPanInt(1),
+				},
+			}),
 		},
+		// if no arg, use empty obj
 		{
-			`it := "日本語"._iter
-			 it.next
-			 it.next
-			 it.next
-			 it.next`,
-			object.NewStopIterErr("iter stopped"),
+			`Obj.bear`,
+			toPanObj([]object.Pair{}),
 		},
 	}
-
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalObjIter(t *testing.T) {
+func TestEvalObjKeys(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`it := {a: "A", b: "B"}._iter
-			 it.next`,
-			object.NewPanArr(
-				object.NewPanStr("a"),
-				object.NewPanStr("A"),
-			),
-		},
-		{
-			`it := {a: "A", b: "B"}._iter
-			 it.next
-			 it.next`,
-			object.NewPanArr(
-				object.NewPanStr("b"),
-				object.NewPanStr("B"),
-			),
+			`{}.keys`,
+			object.NewPanArr(),
 		},
-		// sorted in alphabetical order of keys
 		{
-			`it := {b: "B", a: "A"}._iter
-			 it.next`,
+			`{a: 1, b: 2}.keys`,
 			object.NewPanArr(
 				object.NewPanStr("a"),
-				object.NewPanStr("A"),
-			),
-		},
-		{
-			`it := {b: "B", a: "A"}._iter
-			 it.next
-			 it.next`,
-			object.NewPanArr(
 				object.NewPanStr("b"),
-				object.NewPanStr("B"),
 			),
 		},
-		{
-			`it := {a: "A", b: "B"}._iter
-			it.next
-			it.next
-			it.next`,
-			object.NewStopIterErr("iter stopped"),
-		},
 		// private keys are ignored
 		{
-			`it := {
-				_secret: "invisible",
-				'+: "PLUS",
-				"include spaces": "ignored",
-			 }._iter
-			 it.next`,
-			object.NewStopIterErr("iter stopped"),
-		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalMapIter(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
-		{
-			`it := %{true: 1, false: 2}._iter
-			 it.next`,
+			`{a: 1, _b: 2, '+: 3, "with space": 4}.keys`,
 			object.NewPanArr(
-				object.BuiltInTrue,
-				object.NewPanInt(1),
+				object.NewPanStr("a"),
 			),
 		},
+		// with private keys (private keys follow public keys)
 		{
-			`it := %{true: 1, false: 2}._iter
-			 it.next
-			 it.next`,
+			`{a: 1, _b: 2, '+: 3, "with space": 4}.keys(private?: true)`,
 			object.NewPanArr(
-				object.BuiltInFalse,
-				object.NewPanInt(2),
+				object.NewPanStr("a"),
+				object.NewPanStr("+"),
+				object.NewPanStr("_b"),
+				object.NewPanStr("with space"),
 			),
 		},
+		// child of obj
 		{
-			`it := %{true: 1}._iter
-			 it.next
-			 it.next`,
-			object.NewStopIterErr("iter stopped"),
-		},
-		// non-scalar key
-		{
-			`it := %{[0]: 1}._iter
-			 it.next`,
+			`{a: 1}.bear({b: 2}).keys`,
 			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanInt(0),
-				),
-				object.NewPanInt(1),
+				object.NewPanStr("b"),
 			),
 		},
 	}
@@ -3313,71 +5816,55 @@ func TestEvalMapIter(t *testing.T) {
 	}
 }
 
-func TestEvalRangeIter(t *testing.T) {
+func TestEvalObjValues(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`it := (0:2)._iter
-			 it.next`,
-			object.NewPanInt(0),
-		},
-		{
-			`it := (0:2)._iter
-			 it.next
-			 it.next`,
-			object.NewPanInt(1),
-		},
-		{
-			`it := (0:2)._iter
-			 it.next
-			 it.next`,
-			object.NewPanInt(1),
-		},
-		{
-			`it := (0:2)._iter
-			 it.next
-			 it.next
-			 it.next`,
-			object.NewStopIterErr("iter stopped"),
-		},
-		{
-			`it := (10:7:-2)._iter
-			 it.next`,
-			object.NewPanInt(10),
-		},
-		{
-			`it := (10:7:-2)._iter
-			 it.next
-			 it.next`,
-			object.NewPanInt(8),
+			`{}.values`,
+			object.NewPanArr(),
 		},
 		{
-			`it := (10:7:-2)._iter
-			 it.next
-			 it.next
-			 it.next`,
-			object.NewStopIterErr("iter stopped"),
+			`{a: "A", b: "B"}.values`,
+			object.NewPanArr(
+				object.NewPanStr("A"),
+				object.NewPanStr("B"),
+			),
 		},
-		// str
+		// private keys are ignored
 		{
-			`it := ('a:'c)._iter
-			 it.next`,
-			object.NewPanStr("a"),
+			`{
+			   a: "A",
+			   _b: "_B",
+			   '+: "PLUS",
+			   "with space": "WITH SPACE",
+			 }.values`,
+			object.NewPanArr(
+				object.NewPanStr("A"),
+			),
 		},
+		// with private keys (private keys follow public keys)
 		{
-			`it := ('a:'c)._iter
-			 it.next
-			 it.next`,
-			object.NewPanStr("b"),
+			`{
+			   a: "A",
+			   _b: "_B",
+			   '+: "PLUS",
+			   "with space": "WITH SPACE",
+			 }.values(private?: true)`,
+			object.NewPanArr(
+				object.NewPanStr("A"),
+				object.NewPanStr("PLUS"),
+				object.NewPanStr("_B"),
+				object.NewPanStr("WITH SPACE"),
+			),
 		},
+		// child of obj
 		{
-			`it := ('a:'c)._iter
-			 it.next
-			 it.next
-			 it.next`,
-			object.NewStopIterErr("iter stopped"),
+			`{a: 1}.bear({b: 2}).values`,
+			object.NewPanArr(
+				object.NewPanInt(2),
+			),
 		},
 	}
 
@@ -3387,49 +5874,79 @@ func TestEvalRangeIter(t *testing.T) {
 	}
 }
 
-func TestEvalIterIter(t *testing.T) {
+func TestEvalObjItems(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`it := <{|i| yield i; recur(i+1)}>.new(1)._iter
-			 it.next`,
-			object.NewPanInt(1),
-		},
-		{
-			`it := <{|i| yield i; recur(i+1)}>.new(1)._iter
-			 it.next
-			 it.next`,
-			object.NewPanInt(2),
-		},
-		{
-			`it := <{|i| yield i if i < 2; recur(i+1)}>.new(1)._iter
-			 it.next
-			 it.next`,
-			object.NewStopIterErr("iter stopped"),
+			`{}.items`,
+			object.NewPanArr(),
 		},
-		// new iter generated by ._iter is independent of original one
 		{
-			`orig := <{|i| yield i; recur(i+1)}>.new(1)
-			 it := orig._iter
-			 orig.next
-			 orig.next
-			 it.next`,
-			object.NewPanInt(1),
+			`{a: "A", b: "B"}.items`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanStr("a"),
+					object.NewPanStr("A"),
+				),
+				object.NewPanArr(
+					object.NewPanStr("b"),
+					object.NewPanStr("B"),
+				),
+			),
 		},
-		// errors
+		// private keys are ignored
 		{
-			`it := <{}>['_iter]()`,
-			object.NewTypeErr("Iter#_iter requires at least 1 arg"),
+			`{
+			   a: "A",
+			   _b: "_B",
+			   '+: "PLUS",
+			   "with space": "WITH SPACE",
+			 }.items`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanStr("a"),
+					object.NewPanStr("A"),
+				),
+			),
 		},
+		// with private keys (private keys follow public keys)
 		{
-			`it := <{}>['_iter](1)`,
-			object.NewTypeErr("1 cannot be treated as iter"),
+			`{
+			   a: "A",
+			   _b: "_B",
+			   '+: "PLUS",
+			   "with space": "WITH SPACE",
+			 }.items(private?: true)`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanStr("a"),
+					object.NewPanStr("A"),
+				),
+				object.NewPanArr(
+					object.NewPanStr("+"),
+					object.NewPanStr("PLUS"),
+				),
+				object.NewPanArr(
+					object.NewPanStr("_b"),
+					object.NewPanStr("_B"),
+				),
+				object.NewPanArr(
+					object.NewPanStr("with space"),
+					object.NewPanStr("WITH SPACE"),
+				),
+			),
 		},
+		// child of obj
 		{
-			`it := <{}>['_iter]({|i| i})`,
-			object.NewTypeErr("{|i| i} cannot be treated as iter"),
+			`{a: 1}.bear({b: 2}).items`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanStr("b"),
+					object.NewPanInt(2),
+				),
+			),
 		},
 	}
 
@@ -3439,135 +5956,112 @@ func TestEvalIterIter(t *testing.T) {
 	}
 }
 
-func TestEvalListChainPropCall(t *testing.T) {
+func TestEvalMapKeys(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`[1, 2]@+(1)`,
-			object.NewPanArr(
-				object.NewPanInt(2),
-				object.NewPanInt(3),
-			),
-		},
-		{
-			`3@S`,
-			object.NewPanArr(
-				object.NewPanStr("1"),
-				object.NewPanStr("2"),
-				object.NewPanStr("3"),
-			),
+			`%{}.keys`,
+			object.NewPanArr(),
 		},
 		{
-			`"日本語"@S`,
+			`%{true: 1}.keys`,
 			object.NewPanArr(
-				object.NewPanStr("日"),
-				object.NewPanStr("本"),
-				object.NewPanStr("語"),
+				object.BuiltInTrue,
 			),
 		},
-		// NOTE: must new() to init params
 		{
-			`<{|i| yield i if i != 3; recur(i+1)}>.new(0)@S`,
+			`%{[0]: "zero"}.keys`,
 			object.NewPanArr(
-				object.NewPanStr("0"),
-				object.NewPanStr("1"),
-				object.NewPanStr("2"),
+				object.NewPanArr(
+					object.NewPanInt(0),
+				),
 			),
 		},
 		{
-			`(1:6:2)@S`,
+			`%{true: 1, "false": 2}.keys`,
 			object.NewPanArr(
-				object.NewPanStr("1"),
-				object.NewPanStr("3"),
-				object.NewPanStr("5"),
+				object.BuiltInTrue,
+				object.NewPanStr("false"),
 			),
 		},
 		{
-			`{a: 1, b: 2}@at([0])`,
+			`%{[0]: "zero", [1]: "one"}.keys`,
 			object.NewPanArr(
-				object.NewPanStr("a"),
-				object.NewPanStr("b"),
+				object.NewPanArr(
+					object.NewPanInt(0),
+				),
+				object.NewPanArr(
+					object.NewPanInt(1),
+				),
 			),
 		},
+		// non-hashables follow hashables
 		{
-			`%{"a": 1, "b": 2, "c": 3}@at([0])`,
+			`%{[0]: "zero", 1: "one", "2": "two", [3]: "three"}.keys`,
 			object.NewPanArr(
-				object.NewPanStr("a"),
-				object.NewPanStr("b"),
-				object.NewPanStr("c"),
+				object.NewPanInt(1),
+				object.NewPanStr("2"),
+				object.NewPanArr(
+					object.NewPanInt(0),
+				),
+				object.NewPanArr(
+					object.NewPanInt(3),
+				),
 			),
 		},
-		// digest check
-		// NOTE: Obj#digest is defined in /native, which cannot be used in eval_test
-		{
-			`
-			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
-			[['a, 1], ['b, 2]]@(MyObj.bear({}))at([0:2])
-			`,
-			toPanObj([]object.Pair{
-				{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
-				{Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
-			}),
-		},
-		{
-			`
-			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
-			[['A, 1], ['B, 2]]=@(MyObj.bear({}))at([0:2])
-			`,
-			toPanObj([]object.Pair{
-				{Key: object.NewPanStr("A"), Value: object.NewPanInt(1)},
-				{Key: object.NewPanStr("B"), Value: object.NewPanInt(2)},
-			}),
-		},
-		// if any element raises error, propagate it
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalMapValues(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
 		{
-			`[1, 2, "str", 4]@+(1)`,
-			object.NewTypeErr("1 cannot be treated as str"),
+			`%{}.values`,
+			object.NewPanArr(),
 		},
-		// digest failed
 		{
-			`
-			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
-			['a, 'b]@(MyObj.bear({}))uc
-			`,
-			object.NewValueErr("element \"A\" cannot be treated as arr"),
+			`%{true: 1}.values`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+			),
 		},
 		{
-			`
-			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
-			['a, 'b]=@(MyObj.bear({}))uc
-			`,
-			object.NewValueErr("element \"A\" cannot be treated as arr"),
+			`%{true: 1, false: 0}.values`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanInt(0),
+			),
 		},
-		// digest not defined
 		{
-			`[['a, 1], ['b, 2]]@(BaseObj)at([0:2])`,
-			object.NewNoPropErr("property `digest` is not defined."),
+			`%{[0]: "zero"}.values`,
+			object.NewPanArr(
+				object.NewPanStr("zero"),
+			),
 		},
 		{
-			`[['a, 1], ['b, 2]]=@(BaseObj)at([0:2])`,
-			object.NewNoPropErr("property `digest` is not defined."),
+			`%{[0]: "zero", [1]: "one"}.values`,
+			object.NewPanArr(
+				object.NewPanStr("zero"),
+				object.NewPanStr("one"),
+			),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalListChainPropCallIgnoresNil(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
+		// non-hashables follow hashables
 		{
-			`[{name: 'Taro}, {name: nil}, {name: 'Jiro}]@name`,
+			`%{[0]: "zero", 1: "one", "2": "two", [3]: "three"}.values`,
 			object.NewPanArr(
-				object.NewPanStr("Taro"),
-				object.NewPanStr("Jiro"),
+				object.NewPanStr("one"),
+				object.NewPanStr("two"),
+				object.NewPanStr("zero"),
+				object.NewPanStr("three"),
 			),
 		},
 	}
@@ -3578,118 +6072,91 @@ func TestEvalListChainPropCallIgnoresNil(t *testing.T) {
 	}
 }
 
-func TestEvalListChainLiteralCall(t *testing.T) {
+func TestEvalMapItems(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`[1, 2]@{|i| i + 1}`,
-			object.NewPanArr(
-				object.NewPanInt(2),
-				object.NewPanInt(3),
-			),
+			`%{}.items`,
+			object.NewPanArr(),
 		},
 		{
-			`3@{|i| i.S}`,
+			`%{true: 1}.items`,
 			object.NewPanArr(
-				object.NewPanStr("1"),
-				object.NewPanStr("2"),
-				object.NewPanStr("3"),
+				object.NewPanArr(
+					object.BuiltInTrue,
+					object.NewPanInt(1),
+				),
 			),
 		},
 		{
-			`"日本語"@{|c| c + "!"}`,
+			`%{true: 1, false: 0}.items`,
 			object.NewPanArr(
-				object.NewPanStr("日!"),
-				object.NewPanStr("本!"),
-				object.NewPanStr("語!"),
+				object.NewPanArr(
+					object.BuiltInTrue,
+					object.NewPanInt(1),
+				),
+				object.NewPanArr(
+					object.BuiltInFalse,
+					object.NewPanInt(0),
+				),
 			),
 		},
 		{
-			`{a: 1, b: 2}@{|k, v| "#{k}: #{v}"}`,
+			`%{[0]: "zero"}.items`,
 			object.NewPanArr(
-				object.NewPanStr("a: 1"),
-				object.NewPanStr("b: 2"),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(0),
+					),
+					object.NewPanStr("zero"),
+				),
 			),
 		},
 		{
-			`%{true: "yes", false: "no"}@{|k, v| [k, v]}`,
+			`%{[0]: "zero", [1]: "one"}.items`,
 			object.NewPanArr(
 				object.NewPanArr(
-					object.BuiltInTrue,
-					object.NewPanStr("yes"),
+					object.NewPanArr(
+						object.NewPanInt(0),
+					),
+					object.NewPanStr("zero"),
 				),
 				object.NewPanArr(
-					object.BuiltInFalse,
-					object.NewPanStr("no"),
+					object.NewPanArr(
+						object.NewPanInt(1),
+					),
+					object.NewPanStr("one"),
 				),
 			),
 		},
+		// non-hashables follow hashables
 		{
-			`(1:6:2)@{|n| n+1}`,
+			`%{[0]: "zero", 1: "one", "2": "two", [3]: "three"}.items`,
 			object.NewPanArr(
-				object.NewPanInt(2),
-				object.NewPanInt(4),
-				object.NewPanInt(6),
+				object.NewPanArr(
+					object.NewPanInt(1),
+					object.NewPanStr("one"),
+				),
+				object.NewPanArr(
+					object.NewPanStr("2"),
+					object.NewPanStr("two"),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(0),
+					),
+					object.NewPanStr("zero"),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(3),
+					),
+					object.NewPanStr("three"),
+				),
 			),
 		},
-		// digest check
-		// NOTE: Obj#digest is defined in /native, which cannot be used in eval_test
-		{
-			`
-			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
-			['a, 'b]@(MyObj.bear({})){[\, .uc]}
-			`,
-			toPanObj([]object.Pair{
-				{Key: object.NewPanStr("a"), Value: object.NewPanStr("A")},
-				{Key: object.NewPanStr("b"), Value: object.NewPanStr("B")},
-			}),
-		},
-		{
-			`
-			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
-			['c, 'd]=@(MyObj.bear({})){[\, .uc]}
-			`,
-			toPanObj([]object.Pair{
-				{Key: object.NewPanStr("c"), Value: object.NewPanStr("C")},
-				{Key: object.NewPanStr("d"), Value: object.NewPanStr("D")},
-			}),
-		},
-		// if any element raises error, propagate it
-		{
-			`[2, 0, 3]@{|n| 6/n}`,
-			object.NewZeroDivisionErr("cannot be divided by 0"),
-		},
-		// if chainarg raises error, propagate it
-		{
-			`[1]@(_){|n| n + 1}`,
-			object.BuiltInNotImplemented,
-		},
-		// digest failed
-		{
-			`
-			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
-			['a, 'b]@(MyObj.bear({})){[.uc]}
-			`,
-			object.NewValueErr("element [\"A\"] must have two elements"),
-		},
-		{
-			`
-			MyObj := {digest: m{|pairs| {**self, **pairs.O}}}
-			['a, 'b]=@(MyObj.bear({})){[.uc]}
-			`,
-			object.NewValueErr("element [\"A\"] must have two elements"),
-		},
-		// digest not defined
-		{
-			`['a, 'b]@(BaseObj){[\, .uc]}`,
-			object.NewNoPropErr("property `digest` is not defined."),
-		},
-		{
-			`['a, 'b]=@(BaseObj){[\, .uc]}`,
-			object.NewNoPropErr("property `digest` is not defined."),
-		},
 	}
 
 	for _, tt := range tests {
@@ -3698,17 +6165,28 @@ func TestEvalListChainLiteralCall(t *testing.T) {
 	}
 }
 
-func TestEvalListChainLiteralCallIgnoresNil(t *testing.T) {
+func TestEvalSetNew(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`[{name: 'Taro}, {name: nil}, {name: 'Jiro}]@{|o| o.name}`,
-			object.NewPanArr(
-				object.NewPanStr("Taro"),
-				object.NewPanStr("Jiro"),
-			),
+			`Set.new([1, 2, 1])`,
+			object.NewPanSet(object.NewPanInt(1), object.NewPanInt(2)),
+		},
+		// non-hashable elements are compared by ==
+		{
+			`Set.new([[1], [1]])`,
+			object.NewPanSet(object.NewPanArr(object.NewPanInt(1))),
+		},
+		{
+			`Set.new`,
+			object.NewPanSet(),
+		},
+		// if no args are passed, raise an error
+		{
+			`Set['new]()`,
+			object.NewTypeErr("Set#new requires at least 1 arg"),
 		},
 	}
 
@@ -3718,108 +6196,79 @@ func TestEvalListChainLiteralCallIgnoresNil(t *testing.T) {
 	}
 }
 
-func TestEvalListChainVarCall(t *testing.T) {
+func TestEvalSetProps(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`f := {|i| i + 1}; [1, 2]@^f`,
-			object.NewPanArr(
-				object.NewPanInt(2),
-				object.NewPanInt(3),
-			),
+			`Set.new([1, [2]]).len`,
+			object.NewPanInt(2),
 		},
 		{
-			`f := {|i| i.S}; 3@^f`,
-			object.NewPanArr(
-				object.NewPanStr("1"),
-				object.NewPanStr("2"),
-				object.NewPanStr("3"),
-			),
+			`Set.new([1, [2]]).has?(1)`,
+			object.BuiltInTrue,
 		},
 		{
-			`f := {|c| c + "!"}; "日本語"@^f`,
-			object.NewPanArr(
-				object.NewPanStr("日!"),
-				object.NewPanStr("本!"),
-				object.NewPanStr("語!"),
-			),
+			`Set.new([1, [2]]).has?([2])`,
+			object.BuiltInTrue,
 		},
 		{
-			`f := {|k, v| "#{k}: #{v}"}; {a: 1, b: 2}@^f`,
-			object.NewPanArr(
-				object.NewPanStr("a: 1"),
-				object.NewPanStr("b: 2"),
-			),
+			`Set.new([1, [2]]).has?(2)`,
+			object.BuiltInFalse,
 		},
 		{
-			`f := {|k, v| [k, v]}; %{true: "yes", false: "no"}@^f`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.BuiltInTrue,
-					object.NewPanStr("yes"),
-				),
-				object.NewPanArr(
-					object.BuiltInFalse,
-					object.NewPanStr("no"),
-				),
-			),
+			`Set.new([1]).add(2, 1)`,
+			object.NewPanSet(object.NewPanInt(1), object.NewPanInt(2)),
 		},
 		{
-			`f := {|n| n+1}; (1:6:2)@^f`,
-			object.NewPanArr(
-				object.NewPanInt(2),
-				object.NewPanInt(4),
-				object.NewPanInt(6),
-			),
+			`Set.new([1, 2]).del(1)`,
+			object.NewPanSet(object.NewPanInt(2)),
 		},
-		// if any element raises error, propagate it
 		{
-			`f := {|n| 6/n}; [2, 0, 3]@^f`,
-			object.NewZeroDivisionErr("cannot be divided by 0"),
+			`Set.new([1]).sub?([1, 2])`,
+			object.BuiltInTrue,
+		},
+		{
+			`Set.new([1]).super?([1, 2])`,
+			object.BuiltInFalse,
+		},
+		{
+			`Set.new([1, 2]) == Set.new([2, 1])`,
+			object.BuiltInTrue,
+		},
+		{
+			`Set.new([1, 2]) == Set.new([1])`,
+			object.BuiltInFalse,
+		},
+		{
+			`Set.new.B`,
+			object.BuiltInFalse,
+		},
+		{
+			`Set.new([1, 2]) /| [2, 3]`,
+			object.NewPanSet(object.NewPanInt(1), object.NewPanInt(2), object.NewPanInt(3)),
+		},
+		{
+			`Set.new([1, 2]) /& [2, 3]`,
+			object.NewPanSet(object.NewPanInt(2)),
+		},
+		{
+			`Set.new([1, 2]) - [2, 3]`,
+			object.NewPanSet(object.NewPanInt(1)),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalListChainVarCallIgnoresNil(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
 		{
-			`f := {|o| o.name}; [{name: 'Taro}, {name: nil}, {name: 'Jiro}]@^f`,
-			object.NewPanArr(
-				object.NewPanStr("Taro"),
-				object.NewPanStr("Jiro"),
-			),
+			`Set.new([1, 2]) /^ [2, 3]`,
+			object.NewPanSet(object.NewPanInt(1), object.NewPanInt(3)),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalReduceChainPropCall(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
+		// if \1 is not set, raise an error
 		{
-			`[1, 2, 3]$(4)+`,
-			object.NewPanInt(10),
+			`Set['has?](1, 2)`,
+			object.NewTypeErr("\\1 must be set"),
 		},
-		// if chainarg is not set, acc is `nil`
 		{
-			`[nil]$==`,
-			object.BuiltInTrue,
+			`Set['-](1, 2)`,
+			object.NewTypeErr("\\1 must be set"),
 		},
 	}
 
@@ -3829,16 +6278,23 @@ func TestEvalReduceChainPropCall(t *testing.T) {
 	}
 }
 
-func TestEvalReduceChainPropCallRecvIsAcc(t *testing.T) {
-	// receiver of prop is resolved to acc
+func TestEvalRefNew(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
-		// "a"['+] is called
 		{
-			`[1]$("a")+`,
-			object.NewTypeErr("1 cannot be treated as str"),
+			`Ref.new(1)`,
+			object.NewPanRef(object.NewPanInt(1)),
+		},
+		{
+			`Ref.new`,
+			object.NewPanRef(object.BuiltInNil),
+		},
+		// if no args are passed, raise an error
+		{
+			`Ref['new]()`,
+			object.NewTypeErr("Ref#new requires at least 1 arg"),
 		},
 	}
 
@@ -3848,24 +6304,76 @@ func TestEvalReduceChainPropCallRecvIsAcc(t *testing.T) {
 	}
 }
 
-func TestEvalReduceChainLiteralCall(t *testing.T) {
+func TestEvalRefProps(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`[1, 2, 3]$(0){|i, j| i + j}`,
-			object.NewPanInt(6),
+			`Ref.new(1).get`,
+			object.NewPanInt(1),
 		},
 		{
-			`[1, 2, 3]$(4){|i, j| i + j}`,
-			object.NewPanInt(10),
+			`r := Ref.new(1); r.set(2); r.get`,
+			object.NewPanInt(2),
 		},
-		// if chainarg is not set, acc is `nil`
+		// set returns the new value
 		{
-			`[nil]${|i, j| i == j}`,
+			`Ref.new(1).set(2)`,
+			object.NewPanInt(2),
+		},
+		// ref can be updated in funcs
+		{
+			`r := Ref.new(0); f := {r.set(5)}; f(); r.get`,
+			object.NewPanInt(5),
+		},
+		{
+			`r := Ref.new([1]); r.swap {|a| [*a, 2]}`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2)),
+		},
+		// extra args are passed to the func
+		{
+			`r := Ref.new([1]); r.swap({|a, b, c| [*a, b, c]}, 2, 3); r.get`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2), object.NewPanInt(3)),
+		},
+		// error raised in the func is returned
+		{
+			`Ref.new(1).swap {|a| a.foo}`,
+			object.NewNoPropErr("property `foo` is not defined."),
+		},
+		{
+			`r := Ref.new([1]); [r.cas([1], [2]), r.get]`,
+			object.NewPanArr(object.BuiltInTrue, object.NewPanArr(object.NewPanInt(2))),
+		},
+		{
+			`r := Ref.new([1]); [r.cas([3], [2]), r.get]`,
+			object.NewPanArr(object.BuiltInFalse, object.NewPanArr(object.NewPanInt(1))),
+		},
+		// refs are compared by identity
+		{
+			`r := Ref.new(1); r == r`,
 			object.BuiltInTrue,
 		},
+		{
+			`Ref.new(1) == Ref.new(1)`,
+			object.BuiltInFalse,
+		},
+		{
+			`Ref.new([1]).repr`,
+			object.NewPanStr("Ref.new([1])"),
+		},
+		{
+			`Ref.get`,
+			object.NewTypeErr(`\1 must be ref`),
+		},
+		{
+			`Ref.new(1)['cas](1)`,
+			object.NewTypeErr("Ref#cas requires at least 3 args"),
+		},
+		{
+			`Ref.new(1)['swap]()`,
+			object.NewTypeErr("Ref#swap requires at least 2 args"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -3874,23 +6382,35 @@ func TestEvalReduceChainLiteralCall(t *testing.T) {
 	}
 }
 
-func TestEvalReduceChainVarCall(t *testing.T) {
+func TestEvalBytesNew(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`f := {|i, j| i + j}; [1, 2, 3]$(0)^f`,
-			object.NewPanInt(6),
+			`Bytes.new([0, 1, 255])`,
+			object.NewPanBytes([]byte{0, 1, 255}),
 		},
 		{
-			`f := {|i, j| i + j}; [1, 2, 3]$(4)^f`,
-			object.NewPanInt(10),
+			`Bytes.new`,
+			object.NewPanBytes([]byte{}),
 		},
-		// if chainarg is not set, acc is `nil`
 		{
-			`f := {|i, j| i == j}; [nil]$^f`,
-			object.BuiltInTrue,
+			`Bytes.new(Bytes.new([1]))`,
+			object.NewPanBytes([]byte{1}),
+		},
+		{
+			`Bytes.new([256])`,
+			object.NewValueErr("256 is out of range 0..255"),
+		},
+		{
+			`Bytes.new(["a"])`,
+			object.NewTypeErr(`"a" cannot be treated as int`),
+		},
+		// if no args are passed, raise an error
+		{
+			`Bytes['new]()`,
+			object.NewTypeErr("Bytes#new requires at least 1 arg"),
 		},
 	}
 
@@ -3900,35 +6420,39 @@ func TestEvalReduceChainVarCall(t *testing.T) {
 	}
 }
 
-func TestEvalIfExpr(t *testing.T) {
+func TestEvalBytesAt(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`1 if true`,
+			`Bytes.new([1, 2, 3])[0]`,
 			object.NewPanInt(1),
 		},
 		{
-			`1 if false`,
+			`Bytes.new([1, 2, 3])[-1]`,
+			object.NewPanInt(3),
+		},
+		{
+			`Bytes.new([1, 2, 3])[3]`,
 			object.BuiltInNil,
 		},
 		{
-			`10 if true else 5`,
-			object.NewPanInt(10),
+			`Bytes.new([1, 2, 3])[1:]`,
+			object.NewPanBytes([]byte{2, 3}),
 		},
 		{
-			`10 if false else 5`,
-			object.NewPanInt(5),
+			`Bytes.new([1, 2, 3])[::-1]`,
+			object.NewPanBytes([]byte{3, 2, 1}),
 		},
-		// cond other than bool
 		{
-			`'t if 100 else 'f`,
-			object.NewPanStr("t"),
+			`Bytes.new([1, 2, 3])[::0]`,
+			object.NewValueErr("cannot use 0 for range step"),
 		},
+		// props are also found
 		{
-			`'t if [] else 'f`,
-			object.NewPanStr("f"),
+			`Bytes.new([1, 2, 3])['_name]`,
+			object.NewPanStr("Bytes"),
 		},
 	}
 
@@ -3938,239 +6462,147 @@ func TestEvalIfExpr(t *testing.T) {
 	}
 }
 
-func TestEvalInfixOr(t *testing.T) {
+func TestEvalBytesProps(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`true || false`,
-			object.BuiltInTrue,
+			`Bytes.new([1, 2]).len`,
+			object.NewPanInt(2),
 		},
 		{
-			`false || true`,
+			`Bytes.new([1, 2]) == Bytes.new([1, 2])`,
 			object.BuiltInTrue,
 		},
 		{
-			`false || false`,
+			`Bytes.new([1, 2]) == Bytes.new([2, 1])`,
 			object.BuiltInFalse,
 		},
 		{
-			`true || true`,
-			object.BuiltInTrue,
-		},
-		{
-			`'a || 10`,
-			object.NewPanStr("a"),
+			`Bytes.new([1, 2]) == [1, 2]`,
+			object.BuiltInFalse,
 		},
 		{
-			`false || 10`,
-			object.NewPanInt(10),
+			`Bytes == Bytes`,
+			object.BuiltInTrue,
 		},
-		// evaluated to right if left is falsy
 		{
-			`[] || 1`,
-			object.NewPanInt(1),
+			`Bytes.new([1]) + Bytes.new([2])`,
+			object.NewPanBytes([]byte{1, 2}),
 		},
 		{
-			`"" || "b"`,
-			object.NewPanStr("b"),
+			`Bytes.new([1]) + "a"`,
+			object.NewTypeErr(`"a" cannot be treated as bytes`),
 		},
-		// shortcut
 		{
-			`5 || _`,
-			object.NewPanInt(5),
+			`Bytes.new([1]).B`,
+			object.BuiltInTrue,
 		},
 		{
-			`_ || 5`,
-			object.BuiltInNotImplemented,
+			`Bytes.new.B`,
+			object.BuiltInFalse,
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalInfixAnd(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
+		// bytes can be used as map keys
 		{
-			`true && false`,
-			object.BuiltInFalse,
+			`%{Bytes.new([1]): "a"}[Bytes.new([1])]`,
+			object.NewPanStr("a"),
 		},
 		{
-			`false && true`,
-			object.BuiltInFalse,
+			`Bytes.new([0, 171, 255]).hex`,
+			object.NewPanStr("00abff"),
 		},
 		{
-			`false && false`,
-			object.BuiltInFalse,
+			`Bytes.fromHex("00ABff")`,
+			object.NewPanBytes([]byte{0, 171, 255}),
 		},
 		{
-			`true && true`,
-			object.BuiltInTrue,
+			`Bytes.fromHex("0")`,
+			object.NewValueErr(`"0" is invalid hex: encoding/hex: odd length hex string`),
 		},
 		{
-			`false && 10`,
-			object.BuiltInFalse,
+			`Bytes.new([251, 255]).base64`,
+			object.NewPanStr("+/8="),
 		},
-		// evaluated to right if left is truthy
 		{
-			`['a] && 1`,
-			object.NewPanInt(1),
+			`Bytes.new([251, 255]).base64(url?: true)`,
+			object.NewPanStr("-_8="),
 		},
 		{
-			`"abc" && "b"`,
-			object.NewPanStr("b"),
+			`Bytes.fromBase64("+/8=")`,
+			object.NewPanBytes([]byte{251, 255}),
 		},
-		// shortcut
 		{
-			`"" && _`,
-			object.NewPanStr(""),
+			`Bytes.fromBase64("-_8=", url?: true)`,
+			object.NewPanBytes([]byte{251, 255}),
 		},
 		{
-			`_ && ""`,
-			object.BuiltInNotImplemented,
+			`"foo".enc.base32`,
+			object.NewPanStr("MZXW6==="),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalAssign(t *testing.T) {
-	tests := []struct {
-		input       string
-		expected    object.PanObject
-		expectedEnv *object.PanObj
-	}{
 		{
-			`a := 'A`,
-			object.NewPanStr("A"),
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanStr("A"),
-				},
-			}),
+			`Bytes.fromBase32("MZXW6===").dec`,
+			object.NewPanStr("foo"),
 		},
 		{
-			`'A => a`,
-			object.NewPanStr("A"),
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanStr("A"),
-				},
-			}),
+			`Bytes.fromBase64(1)`,
+			object.NewTypeErr("1 cannot be treated as str"),
 		},
 		{
-			`a := 'A; a`,
-			object.NewPanStr("A"),
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanStr("A"),
-				},
-			}),
+			`Bytes.new([1, 2]).unpack`,
+			object.NewPanInt(258),
 		},
 		{
-			`a := 5; b := 10; [a, b]`,
-			object.NewPanArr(
-				object.NewPanInt(5),
-				object.NewPanInt(10),
-			),
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanInt(5),
-				},
-				{
-					Key:   object.NewPanStr("b"),
-					Value: object.NewPanInt(10),
-				},
-			}),
+			`Bytes.new([1, 2]).unpack(endian: "little")`,
+			object.NewPanInt(513),
 		},
 		{
-			`a := b := 2; [a, b]`,
-			object.NewPanArr(
-				object.NewPanInt(2),
-				object.NewPanInt(2),
-			),
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanInt(2),
-				},
-				{
-					Key:   object.NewPanStr("b"),
-					Value: object.NewPanInt(2),
-				},
-			}),
+			`Bytes.new([255, 254]).unpack(signed?: true)`,
+			object.NewPanInt(-2),
 		},
 		{
-			`"hi" => a; a`,
-			object.NewPanStr("hi"),
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanStr("hi"),
-				},
-			}),
+			`Bytes.new([255, 254]).unpack`,
+			object.NewPanInt(65534),
 		},
 		{
-			`3 => c => d; [c, d]`,
-			object.NewPanArr(
-				object.NewPanInt(3),
-				object.NewPanInt(3),
-			),
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("c"),
-					Value: object.NewPanInt(3),
-				},
-				{
-					Key:   object.NewPanStr("d"),
-					Value: object.NewPanInt(3),
-				},
-			}),
+			`Bytes.new([128, 0, 0, 0, 0, 0, 0, 0]).unpack`,
+			object.NewValueErr("9223372036854775808 overflows Int"),
 		},
-		// right value is not assigned if error raises
 		{
-			`a := 0 / 0`,
-			object.NewZeroDivisionErr("cannot be divided by 0"),
-			toPanObj([]object.Pair{}),
+			`Bytes.new.unpack`,
+			object.NewValueErr("length must be 1..8 but got 0"),
 		},
 		{
-			`0 / 0 => b`,
-			object.NewZeroDivisionErr("cannot be divided by 0"),
-			toPanObj([]object.Pair{}),
+			`Bytes.new([1]).unpack(endian: "middle")`,
+			object.NewValueErr(`endian must be "big" or "little" but got "middle"`),
 		},
-	}
-
-	for _, tt := range tests {
-		// NOTE: use empty env without consts to make tt simple
-		env := object.NewEnv()
-		actual := testEvalInEnv(t, tt.input, env)
-		testValue(t, actual, tt.expected)
-		testValue(t, env.Items(), tt.expectedEnv)
-	}
-}
-
-func TestEvalAssignShadowingConsts(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
 		{
-			`true := 100; true`,
-			object.NewPanInt(100),
+			`"abc".enc.md5.hex`,
+			object.NewPanStr("900150983cd24fb0d6963f7d28e17f72"),
+		},
+		{
+			`"abc".enc.sha1.hex`,
+			object.NewPanStr("a9993e364706816aba3e25717850c26c9cd0d89d"),
+		},
+		{
+			`"abc".enc.sha256.hex`,
+			object.NewPanStr("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"),
+		},
+		{
+			`"abc".enc.crc32`,
+			object.NewPanInt(891568578),
+		},
+		{
+			`"The quick brown fox jumps over the lazy dog".enc.hmac("key").hex`,
+			object.NewPanStr("f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"),
+		},
+		{
+			`"The quick brown fox jumps over the lazy dog".enc.hmac("key".enc, algo: "md5").hex`,
+			object.NewPanStr("80070713463e7749b90c2dc24911e275"),
+		},
+		{
+			`"a".enc.hmac("key", algo: "sha512")`,
+			object.NewValueErr(`unknown algorithm "sha512"`),
 		},
 	}
 
@@ -4180,43 +6612,58 @@ func TestEvalAssignShadowingConsts(t *testing.T) {
 	}
 }
 
-func TestEvalArgVars(t *testing.T) {
+func TestEvalStrEnc(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{\1}("a", "b")`,
-			object.NewPanStr("a"),
+			`"aé".enc`,
+			object.NewPanBytes([]byte{97, 195, 169}),
 		},
 		{
-			`{\2}("a", "b")`,
-			object.NewPanStr("b"),
+			`"aé".enc("UTF-16BE")`,
+			object.NewPanBytes([]byte{0, 97, 0, 233}),
 		},
-		// NameErr if number exceeds arity
 		{
-			`{\3}("a", "b")`,
-			object.NewNameErr("name `\\3` is not defined"),
+			`"aé".enc("utf-16le")`,
+			object.NewPanBytes([]byte{97, 0, 233, 0}),
 		},
-		// `\` is syntax sugar of `\1`
 		{
-			`{\}("one", "two")`,
-			object.NewPanStr("one"),
+			`"aé".enc("latin1")`,
+			object.NewPanBytes([]byte{97, 233}),
 		},
-		// `\0` is arr of all args
 		{
-			`{\0}("one", "two")`,
-			object.NewPanArr(
-				object.NewPanStr("one"),
-				object.NewPanStr("two"),
-			),
+			`"aé".enc("ascii")`,
+			object.NewValueErr(`failed to encode ascii: 'é' cannot be encoded`),
 		},
-		// NOTE: \0 does not contain kwargs
 		{
-			`{\0}("arg", kwarg: "kwarg")`,
-			object.NewPanArr(
-				object.NewPanStr("arg"),
-			),
+			`"a".enc("ebcdic")`,
+			object.NewValueErr(`unknown encoding "ebcdic"`),
+		},
+		{
+			`Bytes.new([97, 195, 169]).dec`,
+			object.NewPanStr("aé"),
+		},
+		{
+			`Bytes.new([97, 233]).dec("iso-8859-1")`,
+			object.NewPanStr("aé"),
+		},
+		{
+			`Bytes.new([61, 216, 0, 222]).dec("utf-16le")`,
+			object.NewPanStr("😀"),
+		},
+		{
+			`Bytes.new([97, 233]).dec`,
+			object.NewValueErr(`failed to decode utf-8: invalid byte 0xe9 at 1`),
+		},
+		{
+			`Bytes.new([0, 216]).dec("utf-16le")`,
+			object.NewValueErr(`failed to decode utf-16le: unpaired surrogate 0xd800 at 0`),
+		},
+		{
+			`Bytes.new([0]).dec("utf-16be")`,
+			object.NewValueErr(`failed to decode utf-16be: length 1 is not a multiple of 2`),
 		},
 	}
 
@@ -4226,44 +6673,38 @@ func TestEvalArgVars(t *testing.T) {
 	}
 }
 
-func TestEvalKwargVars(t *testing.T) {
+func TestEvalIntPack(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{\a}(a: "A", b: "B")`,
-			object.NewPanStr("A"),
+			`258.pack`,
+			object.NewPanBytes([]byte{0, 0, 0, 0, 0, 0, 1, 2}),
 		},
 		{
-			`{\b}(a: "A", b: "B")`,
-			object.NewPanStr("B"),
+			`258.pack(size: 2, endian: "little")`,
+			object.NewPanBytes([]byte{2, 1}),
 		},
-		// NameErr if kwarg is not found
 		{
-			`{\c}(a: "A", b: "B")`,
-			object.NewNameErr("name `\\c` is not defined"),
+			`-2.pack(size: 2)`,
+			object.NewPanBytes([]byte{255, 254}),
 		},
-		// `\_` is obj of all kwargs
 		{
-			`{\_}(one: 1, two: 2)`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("one"),
-					Value: object.NewPanInt(1),
-				},
-				{
-					Key:   object.NewPanStr("two"),
-					Value: object.NewPanInt(2),
-				},
-			}),
+			`255.pack(size: 1)`,
+			object.NewPanBytes([]byte{255}),
 		},
-		// NOTE: \0 does not contain kwargs
 		{
-			`{\0}("arg", kwarg: "kwarg")`,
-			object.NewPanArr(
-				object.NewPanStr("arg"),
-			),
+			`256.pack(size: 1)`,
+			object.NewValueErr("256 cannot be packed into 1 bytes"),
+		},
+		{
+			`1.pack(size: 9)`,
+			object.NewValueErr("size must be 1..8 but got 9"),
+		},
+		{
+			`-300.pack(size: 2).unpack(signed?: true)`,
+			object.NewPanInt(-300),
 		},
 	}
 
@@ -4273,185 +6714,111 @@ func TestEvalKwargVars(t *testing.T) {
 	}
 }
 
-func TestEvalProto(t *testing.T) {
+func TestEvalStringify(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`Obj.proto`,
-			object.BuiltInBaseObj,
+			`[1].S`,
+			object.NewPanStr("[1]"),
 		},
+		// unnecessary zeros are omitted
 		{
-			`[].proto`,
-			object.BuiltInArrObj,
+			`1.0.S`,
+			object.NewPanStr("1.0"),
 		},
+		// infinities and NaN are shown as they are
 		{
-			`1.0.proto`,
-			object.BuiltInFloatObj,
+			`(1.0e308 * 10.0).S`,
+			object.NewPanStr("+Inf"),
 		},
 		{
-			`{||}.proto`,
-			object.BuiltInFuncObj,
+			`(0.0 - 1.0e308 * 10.0).S`,
+			object.NewPanStr("-Inf"),
 		},
 		{
-			`<{||}>.proto`,
-			object.BuiltInIterObj,
+			`(1.0e308 * 10.0 - 1.0e308 * 10.0).S`,
+			object.NewPanStr("NaN"),
 		},
 		{
-			`1.proto`,
-			object.BuiltInIntObj,
+			`{|x| x}.S`,
+			object.NewPanStr("{|x| x}"),
 		},
 		{
-			`%{}.proto`,
-			object.BuiltInMapObj,
+			`%{'a: 1}.S`,
+			object.NewPanStr(`%{"a": 1}`),
 		},
 		{
-			`nil.proto`,
-			object.BuiltInNilObj,
+			`nil.S`,
+			object.NewPanStr(`nil`),
 		},
 		{
-			`{}.proto`,
-			object.BuiltInObjObj,
+			`{a: 1}.S`,
+			object.NewPanStr(`{"a": 1}`),
 		},
 		{
-			`'a.proto`,
-			object.BuiltInStrObj,
+			`(1:2).S`,
+			object.NewPanStr("(1:2:nil)"),
 		},
+		// str is not quoted
 		{
-			`true.proto`,
-			object.BuiltInOneInt,
+			`'a.S`,
+			object.NewPanStr("a"),
 		},
 		{
-			`false.proto`,
-			object.BuiltInZeroInt,
+			`true.S`,
+			object.NewPanStr("true"),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalBearProto(t *testing.T) {
-	tests := []struct {
-		input string
-	}{
-		{`obj := {a: 1};  obj.bear({x: 2}).proto == obj`},
-		{`arr := [1, 2];  arr.bear({x: 2}).proto == arr`},
-		{`num := 2;       num.bear({x: 2}).proto == num`},
-		{`map := %{1: 2}; map.bear({x: 2}).proto == map`},
-	}
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, object.BuiltInTrue)
-	}
-}
-
-// NOTE: since these objects must be singletons and used for equality check,
-// they bear itself and does not allow descendants.
-func TestEvalBearSingleton(t *testing.T) {
-	tests := []struct {
-		input string
-	}{
-		{`true.bear == true`},
-		{`false.bear == false`},
-		// ignore args
-		{`true.bear({a: 1}) == true`},
-		{`false.bear({a: 1}) == false`},
-	}
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, object.BuiltInTrue)
-	}
-}
-
-func TestEvalBearErr(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
 		{
-			`{}.bear(1)`,
-			object.NewTypeErr("BaseObj#bear requires obj literal src"),
+			`false.S`,
+			object.NewPanStr("false"),
 		},
 		{
-			`f := {}['bear]; f()`,
-			object.NewTypeErr("BaseObj#bear requires at least 1 arg"),
+			`Obj['S]()`,
+			object.NewTypeErr("Obj#S requires at least 1 arg"),
 		},
 	}
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
 
-func TestEvalBearContents(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
-		{
-			`Obj.bear({a: 1})`,
-			toPanObj([]object.Pair{
-				{
-					Key:   object.NewPanStr("a"),
-					Value: object.NewPanInt(1),
-				},
-			}),
-		},
-		// if no arg, use empty obj
-		{
-			`Obj.bear`,
-			toPanObj([]object.Pair{}),
-		},
-	}
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
 		testValue(t, actual, tt.expected)
 	}
 }
 
-func TestEvalObjKeys(t *testing.T) {
+func TestEvalStringifyInt(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{}.keys`,
-			object.NewPanArr(),
+			`10.S`,
+			object.NewPanStr("10"),
 		},
+		// specify base
 		{
-			`{a: 1, b: 2}.keys`,
-			object.NewPanArr(
-				object.NewPanStr("a"),
-				object.NewPanStr("b"),
-			),
+			`10.S(base: 16)`,
+			object.NewPanStr("a"),
 		},
-		// private keys are ignored
 		{
-			`{a: 1, _b: 2, '+: 3, "with space": 4}.keys`,
-			object.NewPanArr(
-				object.NewPanStr("a"),
-			),
+			`-10.S(base: 2)`,
+			object.NewPanStr("-1010"),
 		},
-		// with private keys (private keys follow public keys)
 		{
-			`{a: 1, _b: 2, '+: 3, "with space": 4}.keys(private?: true)`,
-			object.NewPanArr(
-				object.NewPanStr("a"),
-				object.NewPanStr("+"),
-				object.NewPanStr("_b"),
-				object.NewPanStr("with space"),
-			),
+			`35.S(base: 36)`,
+			object.NewPanStr("z"),
 		},
-		// child of obj
 		{
-			`{a: 1}.bear({b: 2}).keys`,
-			object.NewPanArr(
-				object.NewPanStr("b"),
-			),
+			`10.S(base: 'a)`,
+			object.NewTypeErr("\"a\" cannot be treated as int"),
+		},
+		{
+			`10.S(base: 0)`,
+			object.NewValueErr("base 0 must be within (2:37)"),
+		},
+		{
+			`10.S(base: 63)`,
+			object.NewValueErr("base 63 must be within (2:37)"),
 		},
 	}
 
@@ -4461,55 +6828,100 @@ func TestEvalObjKeys(t *testing.T) {
 	}
 }
 
-func TestEvalObjValues(t *testing.T) {
+func TestEvalBoolify(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
+		// arr
 		{
-			`{}.values`,
-			object.NewPanArr(),
+			`[1].B`,
+			object.BuiltInTrue,
 		},
 		{
-			`{a: "A", b: "B"}.values`,
-			object.NewPanArr(
-				object.NewPanStr("A"),
-				object.NewPanStr("B"),
-			),
+			`[].B`,
+			object.BuiltInFalse,
 		},
-		// private keys are ignored
+		// float
 		{
-			`{
-			   a: "A",
-			   _b: "_B",
-			   '+: "PLUS",
-			   "with space": "WITH SPACE",
-			 }.values`,
-			object.NewPanArr(
-				object.NewPanStr("A"),
-			),
+			`1.0.B`,
+			object.BuiltInTrue,
 		},
-		// with private keys (private keys follow public keys)
 		{
-			`{
-			   a: "A",
-			   _b: "_B",
-			   '+: "PLUS",
-			   "with space": "WITH SPACE",
-			 }.values(private?: true)`,
-			object.NewPanArr(
-				object.NewPanStr("A"),
-				object.NewPanStr("PLUS"),
-				object.NewPanStr("_B"),
-				object.NewPanStr("WITH SPACE"),
-			),
+			`0.0.B`,
+			object.BuiltInFalse,
 		},
-		// child of obj
+		// func (always true)
 		{
-			`{a: 1}.bear({b: 2}).values`,
-			object.NewPanArr(
-				object.NewPanInt(2),
-			),
+			`{|x| x}.B`,
+			object.BuiltInTrue,
+		},
+		{
+			`{}['keys].B`,
+			object.BuiltInTrue,
+		},
+		// iter (always true)
+		{
+			`<{|x| x}>.B`,
+			object.BuiltInTrue,
+		},
+		// int
+		{
+			`10.B`,
+			object.BuiltInTrue,
+		},
+		{
+			`0.B`,
+			object.BuiltInFalse,
+		},
+		{
+			`true.B`,
+			object.BuiltInTrue,
+		},
+		{
+			`false.B`,
+			object.BuiltInFalse,
+		},
+		// map
+		{
+			`%{'a: 1}.B`,
+			object.BuiltInTrue,
+		},
+		{
+			`%{[1]: 1}.B`,
+			object.BuiltInTrue,
+		},
+		{
+			`%{}.B`,
+			object.BuiltInFalse,
+		},
+		// nil
+		{
+			`nil.B`,
+			object.BuiltInFalse,
+		},
+		// obj
+		{
+			`{a: 1}.B`,
+			object.BuiltInTrue,
+		},
+		{
+			`{}.B`,
+			object.BuiltInFalse,
+		},
+		// range (always true)
+		{
+			`(1:2).B`,
+			object.BuiltInTrue,
+		},
+		// str
+		{
+			`'a.B`,
+			object.BuiltInTrue,
+		},
+		{
+			`"".B`,
+			object.BuiltInFalse,
 		},
 	}
 
@@ -4519,79 +6931,27 @@ func TestEvalObjValues(t *testing.T) {
 	}
 }
 
-func TestEvalObjItems(t *testing.T) {
+func TestEvalIntify(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{}.items`,
-			object.NewPanArr(),
-		},
-		{
-			`{a: "A", b: "B"}.items`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanStr("a"),
-					object.NewPanStr("A"),
-				),
-				object.NewPanArr(
-					object.NewPanStr("b"),
-					object.NewPanStr("B"),
-				),
-			),
+			`"1".I`,
+			object.NewPanInt(1),
 		},
-		// private keys are ignored
 		{
-			`{
-			   a: "A",
-			   _b: "_B",
-			   '+: "PLUS",
-			   "with space": "WITH SPACE",
-			 }.items`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanStr("a"),
-					object.NewPanStr("A"),
-				),
-			),
+			`"-5".I`,
+			object.NewPanInt(-5),
 		},
-		// with private keys (private keys follow public keys)
+		// specify base
 		{
-			`{
-			   a: "A",
-			   _b: "_B",
-			   '+: "PLUS",
-			   "with space": "WITH SPACE",
-			 }.items(private?: true)`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanStr("a"),
-					object.NewPanStr("A"),
-				),
-				object.NewPanArr(
-					object.NewPanStr("+"),
-					object.NewPanStr("PLUS"),
-				),
-				object.NewPanArr(
-					object.NewPanStr("_b"),
-					object.NewPanStr("_B"),
-				),
-				object.NewPanArr(
-					object.NewPanStr("with space"),
-					object.NewPanStr("WITH SPACE"),
-				),
-			),
+			`"1010".I(base: 2)`,
+			object.NewPanInt(10),
 		},
-		// child of obj
 		{
-			`{a: 1}.bear({b: 2}).items`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanStr("b"),
-					object.NewPanInt(2),
-				),
-			),
+			`"-5a".I(base: 16)`,
+			object.NewPanInt(-90),
 		},
 	}
 
@@ -4601,60 +6961,34 @@ func TestEvalObjItems(t *testing.T) {
 	}
 }
 
-func TestEvalMapKeys(t *testing.T) {
+func TestEvalIntifyErr(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`%{}.keys`,
-			object.NewPanArr(),
-		},
-		{
-			`%{true: 1}.keys`,
-			object.NewPanArr(
-				object.BuiltInTrue,
-			),
+			`Str['I]()`,
+			object.NewTypeErr("Str#I requires at least 1 arg"),
 		},
 		{
-			`%{[0]: "zero"}.keys`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanInt(0),
-				),
-			),
+			`"1"['I](1)`,
+			object.NewTypeErr("1 cannot be treated as str"),
 		},
 		{
-			`%{true: 1, "false": 2}.keys`,
-			object.NewPanArr(
-				object.BuiltInTrue,
-				object.NewPanStr("false"),
-			),
+			`"-5a".I`,
+			object.NewValueErr("\"-5a\" cannot be converted into int"),
 		},
 		{
-			`%{[0]: "zero", [1]: "one"}.keys`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanInt(0),
-				),
-				object.NewPanArr(
-					object.NewPanInt(1),
-				),
-			),
+			`"10".I(base: 'a)`,
+			object.NewTypeErr("\"a\" cannot be treated as int"),
 		},
-		// non-hashables follow hashables
 		{
-			`%{[0]: "zero", 1: "one", "2": "two", [3]: "three"}.keys`,
-			object.NewPanArr(
-				object.NewPanInt(1),
-				object.NewPanStr("2"),
-				object.NewPanArr(
-					object.NewPanInt(0),
-				),
-				object.NewPanArr(
-					object.NewPanInt(3),
-				),
-			),
+			`"10".I(base: 0)`,
+			object.NewValueErr("base 0 must be within (2:37)"),
+		},
+		{
+			`"10".I(base: 63)`,
+			object.NewValueErr("base 63 must be within (2:37)"),
 		},
 	}
 
@@ -4664,50 +6998,29 @@ func TestEvalMapKeys(t *testing.T) {
 	}
 }
 
-func TestEvalMapValues(t *testing.T) {
+func TestEvalFloatify(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
+		// int
 		{
-			`%{}.values`,
-			object.NewPanArr(),
-		},
-		{
-			`%{true: 1}.values`,
-			object.NewPanArr(
-				object.NewPanInt(1),
-			),
-		},
-		{
-			`%{true: 1, false: 0}.values`,
-			object.NewPanArr(
-				object.NewPanInt(1),
-				object.NewPanInt(0),
-			),
+			`1.F`,
+			object.NewPanFloat(1.0),
 		},
+		// float
 		{
-			`%{[0]: "zero"}.values`,
-			object.NewPanArr(
-				object.NewPanStr("zero"),
-			),
+			`4.0.F`,
+			object.NewPanFloat(4.0),
 		},
+		// str
 		{
-			`%{[0]: "zero", [1]: "one"}.values`,
-			object.NewPanArr(
-				object.NewPanStr("zero"),
-				object.NewPanStr("one"),
-			),
+			`"1.0".F`,
+			object.NewPanFloat(1.0),
 		},
-		// non-hashables follow hashables
 		{
-			`%{[0]: "zero", 1: "one", "2": "two", [3]: "three"}.values`,
-			object.NewPanArr(
-				object.NewPanStr("one"),
-				object.NewPanStr("two"),
-				object.NewPanStr("zero"),
-				object.NewPanStr("three"),
-			),
+			`"-5.25".F`,
+			object.NewPanFloat(-5.25),
 		},
 	}
 
@@ -4717,90 +7030,30 @@ func TestEvalMapValues(t *testing.T) {
 	}
 }
 
-func TestEvalMapItems(t *testing.T) {
+func TestEvalFloatifyErr(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`%{}.items`,
-			object.NewPanArr(),
-		},
-		{
-			`%{true: 1}.items`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.BuiltInTrue,
-					object.NewPanInt(1),
-				),
-			),
+			`1['F]()`,
+			object.NewTypeErr("Num#F requires at least 1 arg"),
 		},
 		{
-			`%{true: 1, false: 0}.items`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.BuiltInTrue,
-					object.NewPanInt(1),
-				),
-				object.NewPanArr(
-					object.BuiltInFalse,
-					object.NewPanInt(0),
-				),
-			),
+			`1['F]("a")`,
+			object.NewTypeErr("\"a\" cannot be treated as num"),
 		},
 		{
-			`%{[0]: "zero"}.items`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(0),
-					),
-					object.NewPanStr("zero"),
-				),
-			),
+			`Str['F]()`,
+			object.NewTypeErr("Str#F requires at least 1 arg"),
 		},
 		{
-			`%{[0]: "zero", [1]: "one"}.items`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(0),
-					),
-					object.NewPanStr("zero"),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(1),
-					),
-					object.NewPanStr("one"),
-				),
-			),
+			`"1"['F](2)`,
+			object.NewTypeErr("2 cannot be treated as str"),
 		},
-		// non-hashables follow hashables
 		{
-			`%{[0]: "zero", 1: "one", "2": "two", [3]: "three"}.items`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanInt(1),
-					object.NewPanStr("one"),
-				),
-				object.NewPanArr(
-					object.NewPanStr("2"),
-					object.NewPanStr("two"),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(0),
-					),
-					object.NewPanStr("zero"),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(3),
-					),
-					object.NewPanStr("three"),
-				),
-			),
+			`"-5.a".F`,
+			object.NewValueErr("\"-5.a\" cannot be converted into float"),
 		},
 	}
 
@@ -4810,56 +7063,112 @@ func TestEvalMapItems(t *testing.T) {
 	}
 }
 
-func TestEvalStringify(t *testing.T) {
+func TestEvalObjectify(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`[1].S`,
-			object.NewPanStr("[1]"),
+			`[].O`,
+			toPanObj([]object.Pair{}),
 		},
-		// unnecessary zeros are omitted
 		{
-			`1.0.S`,
-			object.NewPanStr("1.0"),
+			`[['a, 1]].O`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+			}),
 		},
 		{
-			`{|x| x}.S`,
-			object.NewPanStr("{|x| x}"),
+			`[['a, 1], ['b, "2"]].O`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+				{Key: object.NewPanStr("b"), Value: object.NewPanStr("2")},
+			}),
 		},
+		// if keys are duplicated, first value is set
 		{
-			`%{'a: 1}.S`,
-			object.NewPanStr(`%{"a": 1}`),
+			`[['a, 1], ['a, 2]].O`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+			}),
 		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalArrM(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
 		{
-			`nil.S`,
-			object.NewPanStr(`nil`),
+			`[].M`,
+			toPanMap(
+				[]object.Pair{},
+				[]object.Pair{},
+			),
 		},
 		{
-			`{a: 1}.S`,
-			object.NewPanStr(`{"a": 1}`),
+			`[['a, 1]].M`,
+			toPanMap(
+				[]object.Pair{
+					{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+				},
+				[]object.Pair{},
+			),
 		},
 		{
-			`(1:2).S`,
-			object.NewPanStr("(1:2:nil)"),
+			`[["hi", 1], [4, "2"]].M`,
+			toPanMap(
+				[]object.Pair{
+					{Key: object.NewPanStr("hi"), Value: object.NewPanInt(1)},
+					{Key: object.NewPanInt(4), Value: object.NewPanStr("2")},
+				},
+				[]object.Pair{},
+			),
 		},
-		// str is not quoted
+		// if keys are duplicated, first value is set
 		{
-			`'a.S`,
-			object.NewPanStr("a"),
+			`[['a, 1], ['a, 2]].M`,
+			toPanMap(
+				[]object.Pair{
+					{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+				},
+				[]object.Pair{},
+			),
 		},
 		{
-			`true.S`,
-			object.NewPanStr("true"),
+			`[["hi", 'str], [[], 'arr], [4, 'int], [{}, 'obj]].M`,
+			toPanMap(
+				[]object.Pair{
+					{Key: object.NewPanStr("hi"), Value: object.NewPanStr("str")},
+					{Key: object.NewPanInt(4), Value: object.NewPanStr("int")},
+				},
+				[]object.Pair{
+					{Key: object.NewPanArr(), Value: object.NewPanStr("arr")},
+					{Key: object.EmptyPanObjPtr(), Value: object.NewPanStr("obj")},
+				},
+			),
 		},
 		{
-			`false.S`,
-			object.NewPanStr("false"),
+			`Arr['M]()`,
+			object.NewTypeErr("Arr#M requires at least 1 arg"),
 		},
 		{
-			`Obj['S]()`,
-			object.NewTypeErr("Obj#S requires at least 1 arg"),
+			`Arr['M](1)`,
+			object.NewTypeErr("1 cannot be treated as arr"),
+		},
+		{
+			`[2].M`,
+			object.NewValueErr("element 2 cannot be treated as arr"),
+		},
+		{
+			`[[3]].M`,
+			object.NewValueErr("element [3] must have two elements"),
 		},
 	}
 
@@ -4869,39 +7178,30 @@ func TestEvalStringify(t *testing.T) {
 	}
 }
 
-func TestEvalStringifyInt(t *testing.T) {
+func TestEvalObjectifyErr(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`10.S`,
-			object.NewPanStr("10"),
-		},
-		// specify base
-		{
-			`10.S(base: 16)`,
-			object.NewPanStr("a"),
-		},
-		{
-			`-10.S(base: 2)`,
-			object.NewPanStr("-1010"),
+			`[]['O]()`,
+			object.NewTypeErr("Arr#O requires at least 1 arg"),
 		},
 		{
-			`35.S(base: 36)`,
-			object.NewPanStr("z"),
+			`[]['O]("a")`,
+			object.NewTypeErr("\"a\" cannot be treated as arr"),
 		},
 		{
-			`10.S(base: 'a)`,
-			object.NewTypeErr("\"a\" cannot be treated as int"),
+			`[['a, 1], 2].O`,
+			object.NewValueErr("element 2 cannot be treated as arr"),
 		},
 		{
-			`10.S(base: 0)`,
-			object.NewValueErr("base 0 must be within (2:37)"),
+			`[['a, 1], ['b]].O`,
+			object.NewValueErr("element [\"b\"] must have two elements"),
 		},
 		{
-			`10.S(base: 63)`,
-			object.NewValueErr("base 63 must be within (2:37)"),
+			`[['a, 1], [2, 3]].O`,
+			object.NewValueErr("element key 2 cannot be treated as str"),
 		},
 	}
 
@@ -4911,100 +7211,83 @@ func TestEvalStringifyInt(t *testing.T) {
 	}
 }
 
-func TestEvalBoolify(t *testing.T) {
+func TestEvalRepr(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
-		// arr
-		{
-			`[1].B`,
-			object.BuiltInTrue,
-		},
-		{
-			`[].B`,
-			object.BuiltInFalse,
-		},
-		// float
 		{
-			`1.0.B`,
-			object.BuiltInTrue,
-		},
-		{
-			`0.0.B`,
-			object.BuiltInFalse,
+			`[1].repr`,
+			object.NewPanStr("[1]"),
 		},
-		// func (always true)
+		// precise value is shown
 		{
-			`{|x| x}.B`,
-			object.BuiltInTrue,
+			`1.0.repr`,
+			object.NewPanStr("1.000000"),
 		},
 		{
-			`{}['keys].B`,
-			object.BuiltInTrue,
+			`{|x| x}.repr`,
+			object.NewPanStr("{|x| x}"),
 		},
-		// iter (always true)
 		{
-			`<{|x| x}>.B`,
-			object.BuiltInTrue,
+			`10.repr`,
+			object.NewPanStr("10"),
 		},
-		// int
 		{
-			`10.B`,
-			object.BuiltInTrue,
+			`%{'a: 1}.repr`,
+			object.NewPanStr(`%{"a": 1}`),
 		},
 		{
-			`0.B`,
-			object.BuiltInFalse,
+			`nil.repr`,
+			object.NewPanStr(`nil`),
 		},
 		{
-			`true.B`,
-			object.BuiltInTrue,
+			`{a: 1}.repr`,
+			object.NewPanStr(`{"a": 1}`),
 		},
 		{
-			`false.B`,
-			object.BuiltInFalse,
+			`(1:2).repr`,
+			object.NewPanStr("(1:2:nil)"),
 		},
-		// map
+		// str is quoted
 		{
-			`%{'a: 1}.B`,
-			object.BuiltInTrue,
+			`'a.repr`,
+			object.NewPanStr(`"a"`),
 		},
 		{
-			`%{[1]: 1}.B`,
-			object.BuiltInTrue,
+			`true.repr`,
+			object.NewPanStr("true"),
 		},
 		{
-			`%{}.B`,
-			object.BuiltInFalse,
+			`false.repr`,
+			object.NewPanStr("false"),
 		},
-		// nil
+		// return name instead if self has '_name and it is str
 		{
-			`nil.B`,
-			object.BuiltInFalse,
+			`{_name: "myObj"}.repr`,
+			object.NewPanStr("myObj"),
 		},
-		// obj
 		{
-			`{a: 1}.B`,
-			object.BuiltInTrue,
+			`{_name: 1}.repr`,
+			object.NewPanStr(`{"_name": 1}`),
 		},
+		// proto's _name is not referred
 		{
-			`{}.B`,
-			object.BuiltInFalse,
+			`{_name: "myObj"}.bear.repr`,
+			object.NewPanStr(`{}`),
 		},
-		// range (always true)
+		// child elements have _name
 		{
-			`(1:2).B`,
-			object.BuiltInTrue,
+			`[{_name: "myObj"}].repr`,
+			object.NewPanStr("[myObj]"),
 		},
-		// str
 		{
-			`'a.B`,
-			object.BuiltInTrue,
+			`{a: {_name: "value"}}.repr`,
+			object.NewPanStr(`{"a": value}`),
 		},
 		{
-			`"".B`,
-			object.BuiltInFalse,
+			`[Obj].repr`,
+			object.NewPanStr("[Obj]"),
 		},
 	}
 
@@ -5014,64 +7297,179 @@ func TestEvalBoolify(t *testing.T) {
 	}
 }
 
-func TestEvalIntify(t *testing.T) {
+func TestEvalTraverse(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`"1".I`,
-			object.NewPanInt(1),
+			`{}.traverse`,
+			object.NewPanArr(),
 		},
 		{
-			`"-5".I`,
-			object.NewPanInt(-5),
+			`{a: 1, b: 2}.traverse`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanStr("a"),
+					),
+					object.NewPanInt(1),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanStr("b"),
+					),
+					object.NewPanInt(2),
+				),
+			),
 		},
-		// specify base
 		{
-			`"1010".I(base: 2)`,
-			object.NewPanInt(10),
+			`{a: 1, b: {c: 2, d: 3}}.traverse`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanStr("a"),
+					),
+					object.NewPanInt(1),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanStr("b"),
+						object.NewPanStr("c"),
+					),
+					object.NewPanInt(2),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanStr("b"),
+						object.NewPanStr("d"),
+					),
+					object.NewPanInt(3),
+				),
+			),
 		},
+		// array
 		{
-			`"-5a".I(base: 16)`,
-			object.NewPanInt(-90),
+			`[1, 2].traverse`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(0),
+					),
+					object.NewPanInt(1),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(1),
+					),
+					object.NewPanInt(2),
+				),
+			),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalIntifyErr(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
 		{
-			`Str['I]()`,
-			object.NewTypeErr("Str#I requires at least 1 arg"),
+			`['a, ['b, 'c]].traverse`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(0),
+					),
+					object.NewPanStr("a"),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(1),
+						object.NewPanInt(0),
+					),
+					object.NewPanStr("b"),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(1),
+						object.NewPanInt(1),
+					),
+					object.NewPanStr("c"),
+				),
+			),
 		},
+		// obj in arr
 		{
-			`"1"['I](1)`,
-			object.NewTypeErr("1 cannot be treated as str"),
+			`['a, {b: 1, c: 2}].traverse`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(0),
+					),
+					object.NewPanStr("a"),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(1),
+						object.NewPanStr("b"),
+					),
+					object.NewPanInt(1),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanInt(1),
+						object.NewPanStr("c"),
+					),
+					object.NewPanInt(2),
+				),
+			),
+		},
+		// arr in obj
+		{
+			`{a: ['b, 'c]}.traverse`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanStr("a"),
+						object.NewPanInt(0),
+					),
+					object.NewPanStr("b"),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanStr("a"),
+						object.NewPanInt(1),
+					),
+					object.NewPanStr("c"),
+				),
+			),
 		},
+		// TODO: map
+		// kwarg "key" selects only pairs containing provided key
 		{
-			`"-5a".I`,
-			object.NewValueErr("\"-5a\" cannot be converted into int"),
+			`{a: 1, b: {a: 2, c: 3}}.traverse(key: 'a)`,
+			object.NewPanArr(
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanStr("a"),
+					),
+					object.NewPanInt(1),
+				),
+				object.NewPanArr(
+					object.NewPanArr(
+						object.NewPanStr("b"),
+						object.NewPanStr("a"),
+					),
+					object.NewPanInt(2),
+				),
+			),
 		},
+		// object other than PanObj
 		{
-			`"10".I(base: 'a)`,
-			object.NewTypeErr("\"a\" cannot be treated as int"),
+			`1.traverse`,
+			object.NewPanArr(),
 		},
+		// error
 		{
-			`"10".I(base: 0)`,
-			object.NewValueErr("base 0 must be within (2:37)"),
+			`Obj['traverse]()`,
+			object.NewTypeErr("Obj#traverse requires at least 1 arg"),
 		},
 		{
-			`"10".I(base: 63)`,
-			object.NewValueErr("base 63 must be within (2:37)"),
+			`{a:1}.traverse(key: 2)`,
+			object.NewTypeErr("2 cannot be treated as str"),
 		},
 	}
 
@@ -5081,62 +7479,51 @@ func TestEvalIntifyErr(t *testing.T) {
 	}
 }
 
-func TestEvalFloatify(t *testing.T) {
+func TestEvalObjGetIn(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
-		// int
 		{
-			`1.F`,
-			object.NewPanFloat(1.0),
+			`{a: [1, {b: 2}]}.getIn(['a, 1, 'b])`,
+			object.NewPanInt(2),
 		},
-		// float
+		// negative index counts from the last
 		{
-			`4.0.F`,
-			object.NewPanFloat(4.0),
+			`{a: [1, {b: 2}]}.getIn(['a, -2])`,
+			object.NewPanInt(1),
 		},
-		// str
 		{
-			`"1.0".F`,
-			object.NewPanFloat(1.0),
+			`[%{[1]: {a: 3}}].getIn([0, [1], 'a])`,
+			object.NewPanInt(3),
 		},
 		{
-			`"-5.25".F`,
-			object.NewPanFloat(-5.25),
+			`{a: 1}.getIn([])`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+			}),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalFloatifyErr(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
+		// not found
 		{
-			`1['F]()`,
-			object.NewTypeErr("Num#F requires at least 1 arg"),
+			`{a: [1]}.getIn(['a, 1])`,
+			object.BuiltInNil,
 		},
 		{
-			`1['F]("a")`,
-			object.NewTypeErr("\"a\" cannot be treated as num"),
+			`{a: 1}.getIn(['a, 'b])`,
+			object.BuiltInNil,
 		},
 		{
-			`Str['F]()`,
-			object.NewTypeErr("Str#F requires at least 1 arg"),
+			`{a: 1}.getIn(['b], default: 2)`,
+			object.NewPanInt(2),
 		},
+		// error
 		{
-			`"1"['F](2)`,
-			object.NewTypeErr("2 cannot be treated as str"),
+			`{a: 1}.getIn('a)`,
+			object.NewTypeErr(`path "a" cannot be treated as arr`),
 		},
 		{
-			`"-5.a".F`,
-			object.NewValueErr("\"-5.a\" cannot be converted into float"),
+			`Obj['getIn]({})`,
+			object.NewTypeErr("Obj#getIn requires at least 2 args"),
 		},
 	}
 
@@ -5146,112 +7533,89 @@ func TestEvalFloatifyErr(t *testing.T) {
 	}
 }
 
-func TestEvalObjectify(t *testing.T) {
+func TestEvalObjSetIn(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`[].O`,
-			toPanObj([]object.Pair{}),
-		},
-		{
-			`[['a, 1]].O`,
+			`{a: {b: 1, c: 2}}.setIn(['a, 'b], 3)`,
 			toPanObj([]object.Pair{
-				{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+				{Key: object.NewPanStr("a"), Value: toPanObj([]object.Pair{
+					{Key: object.NewPanStr("b"), Value: object.NewPanInt(3)},
+					{Key: object.NewPanStr("c"), Value: object.NewPanInt(2)},
+				})},
 			}),
 		},
+		// self is not changed
 		{
-			`[['a, 1], ['b, "2"]].O`,
+			`o := {a: 1}; o.setIn(['a], 2); o`,
 			toPanObj([]object.Pair{
 				{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
-				{Key: object.NewPanStr("b"), Value: object.NewPanStr("2")},
 			}),
 		},
-		// if keys are duplicated, first value is set
 		{
-			`[['a, 1], ['a, 2]].O`,
-			toPanObj([]object.Pair{
-				{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
-			}),
+			`[1, [2, 3]].setIn([1, -1], 4)`,
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanArr(object.NewPanInt(2), object.NewPanInt(4)),
+			),
 		},
-	}
-
-	for _, tt := range tests {
-		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
-	}
-}
-
-func TestEvalArrM(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected object.PanObject
-	}{
+		// index len appends the value
 		{
-			`[].M`,
-			toPanMap(
-				[]object.Pair{},
-				[]object.Pair{},
-			),
+			`[1].setIn([1], 2)`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2)),
 		},
 		{
-			`[['a, 1]].M`,
-			toPanMap(
-				[]object.Pair{
-					{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
-				},
-				[]object.Pair{},
+			`%{1: 'a, 2: 'b}.setIn([1], 'c)`,
+			object.NewPanMap(
+				object.Pair{Key: object.NewPanInt(1), Value: object.NewPanStr("c")},
+				object.Pair{Key: object.NewPanInt(2), Value: object.NewPanStr("b")},
 			),
 		},
+		// missing collections are created by the next key
 		{
-			`[["hi", 1], [4, "2"]].M`,
-			toPanMap(
-				[]object.Pair{
-					{Key: object.NewPanStr("hi"), Value: object.NewPanInt(1)},
-					{Key: object.NewPanInt(4), Value: object.NewPanStr("2")},
-				},
-				[]object.Pair{},
-			),
+			`{}.setIn(['a, 0, 'b], 1)`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanArr(
+					toPanObj([]object.Pair{
+						{Key: object.NewPanStr("b"), Value: object.NewPanInt(1)},
+					}),
+				)},
+			}),
 		},
-		// if keys are duplicated, first value is set
 		{
-			`[['a, 1], ['a, 2]].M`,
-			toPanMap(
-				[]object.Pair{
-					{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
-				},
-				[]object.Pair{},
-			),
+			`{}.setIn(['a, [1]], 2)`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanMap(
+					object.Pair{Key: object.NewPanArr(object.NewPanInt(1)), Value: object.NewPanInt(2)},
+				)},
+			}),
 		},
 		{
-			`[["hi", 'str], [[], 'arr], [4, 'int], [{}, 'obj]].M`,
-			toPanMap(
-				[]object.Pair{
-					{Key: object.NewPanStr("hi"), Value: object.NewPanStr("str")},
-					{Key: object.NewPanInt(4), Value: object.NewPanStr("int")},
-				},
-				[]object.Pair{
-					{Key: object.NewPanArr(), Value: object.NewPanStr("arr")},
-					{Key: object.EmptyPanObjPtr(), Value: object.NewPanStr("obj")},
-				},
-			),
+			`1.setIn([], 2)`,
+			object.NewPanInt(2),
 		},
+		// error
 		{
-			`Arr['M]()`,
-			object.NewTypeErr("Arr#M requires at least 1 arg"),
+			`[1].setIn([2], 2)`,
+			object.NewValueErr("index 2 out of range of [1]"),
 		},
 		{
-			`Arr['M](1)`,
-			object.NewTypeErr("1 cannot be treated as arr"),
+			`{}.setIn(['a, 1], 2)`,
+			object.NewValueErr("index 1 out of range of []"),
 		},
 		{
-			`[2].M`,
-			object.NewValueErr("element 2 cannot be treated as arr"),
+			`{}.setIn([1], 2)`,
+			object.NewTypeErr("cannot use `1` as Obj key."),
 		},
 		{
-			`[[3]].M`,
-			object.NewValueErr("element [3] must have two elements"),
+			`{a: 1}.setIn(['a, 'b], 2)`,
+			object.NewTypeErr("cannot set `\"b\"` to 1"),
+		},
+		{
+			`Obj['setIn]({}, [])`,
+			object.NewTypeErr("Obj#setIn requires at least 3 args"),
 		},
 	}
 
@@ -5261,30 +7625,37 @@ func TestEvalArrM(t *testing.T) {
 	}
 }
 
-func TestEvalObjectifyErr(t *testing.T) {
+func TestEvalObjUpdateIn(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`[]['O]()`,
-			object.NewTypeErr("Arr#O requires at least 1 arg"),
+			`{a: [1, 2]}.updateIn(['a], {|a| a.len})`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanInt(2)},
+			}),
 		},
 		{
-			`[]['O]("a")`,
-			object.NewTypeErr("\"a\" cannot be treated as arr"),
+			`{}.updateIn(['a], {|a| [a]})`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanArr(object.BuiltInNil)},
+			}),
 		},
 		{
-			`[['a, 1], 2].O`,
-			object.NewValueErr("element 2 cannot be treated as arr"),
+			`{}.updateIn(['a], {|a| [a]}, default: 0)`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanArr(object.NewPanInt(0))},
+			}),
 		},
+		// error
 		{
-			`[['a, 1], ['b]].O`,
-			object.NewValueErr("element [\"b\"] must have two elements"),
+			`{a: 1}.updateIn(['a], {|a| a.foo})`,
+			object.NewNoPropErr("property `foo` is not defined."),
 		},
 		{
-			`[['a, 1], [2, 3]].O`,
-			object.NewValueErr("element key 2 cannot be treated as str"),
+			`Obj['updateIn]({}, [])`,
+			object.NewTypeErr("Obj#updateIn requires at least 3 args"),
 		},
 	}
 
@@ -5294,83 +7665,124 @@ func TestEvalObjectifyErr(t *testing.T) {
 	}
 }
 
-func TestEvalRepr(t *testing.T) {
+func TestEvalObjDeepMerge(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`[1].repr`,
-			object.NewPanStr("[1]"),
+			`{a: {b: 1, c: [1]}, d: 2}.deepMerge({a: {c: [2], e: 3}})`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: toPanObj([]object.Pair{
+					{Key: object.NewPanStr("b"), Value: object.NewPanInt(1)},
+					{Key: object.NewPanStr("c"), Value: object.NewPanArr(object.NewPanInt(2))},
+					{Key: object.NewPanStr("e"), Value: object.NewPanInt(3)},
+				})},
+				{Key: object.NewPanStr("d"), Value: object.NewPanInt(2)},
+			}),
 		},
-		// precise value is shown
+		// multiple args
 		{
-			`1.0.repr`,
-			object.NewPanStr("1.000000"),
+			`{a: 1}.deepMerge({b: 2}, {a: 3})`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanInt(3)},
+				{Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+			}),
 		},
 		{
-			`{|x| x}.repr`,
-			object.NewPanStr("{|x| x}"),
+			`%{1: %{2: 3}}.deepMerge(%{1: %{4: 5}})`,
+			object.NewPanMap(
+				object.Pair{Key: object.NewPanInt(1), Value: object.NewPanMap(
+					object.Pair{Key: object.NewPanInt(2), Value: object.NewPanInt(3)},
+					object.Pair{Key: object.NewPanInt(4), Value: object.NewPanInt(5)},
+				)},
+			),
 		},
+		// different kinds are replaced
 		{
-			`10.repr`,
-			object.NewPanStr("10"),
+			`{a: {b: 1}}.deepMerge({a: [1]})`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanArr(object.NewPanInt(1))},
+			}),
 		},
+		// arr strategies
 		{
-			`%{'a: 1}.repr`,
-			object.NewPanStr(`%{"a": 1}`),
+			`[1, 2].deepMerge([3], arr: 'concat)`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2), object.NewPanInt(3)),
 		},
 		{
-			`nil.repr`,
-			object.NewPanStr(`nil`),
+			`[1, 2].deepMerge([2, 3], arr: 'union)`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2), object.NewPanInt(3)),
 		},
 		{
-			`{a: 1}.repr`,
-			object.NewPanStr(`{"a": 1}`),
+			`[{a: 1}, 2].deepMerge([{b: 2}], arr: 'merge)`,
+			object.NewPanArr(
+				toPanObj([]object.Pair{
+					{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+					{Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+				}),
+				object.NewPanInt(2),
+			),
 		},
 		{
-			`(1:2).repr`,
-			object.NewPanStr("(1:2:nil)"),
+			`{a: [1]}.deepMerge({a: [2]}, arr: {|x, y| [x, y]})`,
+			toPanObj([]object.Pair{
+				{Key: object.NewPanStr("a"), Value: object.NewPanArr(
+					object.NewPanArr(object.NewPanInt(1)),
+					object.NewPanArr(object.NewPanInt(2)),
+				)},
+			}),
 		},
-		// str is quoted
+		// error
 		{
-			`'a.repr`,
-			object.NewPanStr(`"a"`),
+			`[1].deepMerge([2], arr: 'foo)`,
+			object.NewValueErr(`unknown arr strategy "foo" (must be 'replace, 'concat, 'merge, 'union or func)`),
 		},
 		{
-			`true.repr`,
-			object.NewPanStr("true"),
+			`Obj['deepMerge]()`,
+			object.NewTypeErr("Obj#deepMerge requires at least 1 arg"),
 		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalObjDeepEq(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
 		{
-			`false.repr`,
-			object.NewPanStr("false"),
+			`{a: [1, {b: "c"}]}.deepEq({a: [1, {b: "c"}]})`,
+			object.BuiltInTrue,
 		},
-		// return name instead if self has '_name and it is str
+		// numbers are compared by values
 		{
-			`{_name: "myObj"}.repr`,
-			object.NewPanStr("myObj"),
+			`{a: [1, 2.5]}.deepEq({a: [1.0, 2.5]})`,
+			object.BuiltInTrue,
 		},
 		{
-			`{_name: 1}.repr`,
-			object.NewPanStr(`{"_name": 1}`),
+			`%{[1]: 2}.deepEq(%{[1]: 2.0})`,
+			object.BuiltInTrue,
 		},
-		// proto's _name is not referred
 		{
-			`{_name: "myObj"}.bear.repr`,
-			object.NewPanStr(`{}`),
+			`{a: [1]}.deepEq({a: [1, 2]})`,
+			object.BuiltInFalse,
 		},
-		// child elements have _name
 		{
-			`[{_name: "myObj"}].repr`,
-			object.NewPanStr("[myObj]"),
+			`{a: 1}.deepEq({a: 1, b: 2})`,
+			object.BuiltInFalse,
 		},
 		{
-			`{a: {_name: "value"}}.repr`,
-			object.NewPanStr(`{"a": value}`),
+			`{a: 1}.deepEq(%{"a": 1})`,
+			object.BuiltInFalse,
 		},
 		{
-			`[Obj].repr`,
-			object.NewPanStr("[Obj]"),
+			`Obj['deepEq]({})`,
+			object.NewTypeErr("Obj#deepEq requires at least 2 args"),
 		},
 	}
 
@@ -5380,179 +7792,98 @@ func TestEvalRepr(t *testing.T) {
 	}
 }
 
-func TestEvalTraverse(t *testing.T) {
+func TestEvalObjDeepDiff(t *testing.T) {
+	op := func(name string, path object.PanObject, v object.PanObject) object.PanObject {
+		pairs := []object.Pair{
+			{Key: object.NewPanStr("op"), Value: object.NewPanStr(name)},
+			{Key: object.NewPanStr("path"), Value: path},
+		}
+		if v != nil {
+			pairs = append(pairs, object.Pair{Key: object.NewPanStr("value"), Value: v})
+		}
+		return toPanObj(pairs)
+	}
+
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
 		{
-			`{}.traverse`,
+			`{a: 1, b: 2}.deepDiff({a: 1.0, b: 2})`,
 			object.NewPanArr(),
 		},
 		{
-			`{a: 1, b: 2}.traverse`,
+			`{a: 1, b: 2}.deepDiff({a: 3, c: 4})`,
 			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanStr("a"),
-					),
-					object.NewPanInt(1),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanStr("b"),
-					),
-					object.NewPanInt(2),
-				),
+				op("replace", object.NewPanArr(object.NewPanStr("a")), object.NewPanInt(3)),
+				op("remove", object.NewPanArr(object.NewPanStr("b")), nil),
+				op("add", object.NewPanArr(object.NewPanStr("c")), object.NewPanInt(4)),
 			),
 		},
+		// arr elements are removed from the last
 		{
-			`{a: 1, b: {c: 2, d: 3}}.traverse`,
+			`{a: [1, 2, 3]}.deepDiff({a: [0]})`,
 			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanStr("a"),
-					),
-					object.NewPanInt(1),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanStr("b"),
-						object.NewPanStr("c"),
-					),
-					object.NewPanInt(2),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanStr("b"),
-						object.NewPanStr("d"),
-					),
-					object.NewPanInt(3),
-				),
+				op("replace", object.NewPanArr(object.NewPanStr("a"), object.NewPanInt(0)), object.NewPanInt(0)),
+				op("remove", object.NewPanArr(object.NewPanStr("a"), object.NewPanInt(2)), nil),
+				op("remove", object.NewPanArr(object.NewPanStr("a"), object.NewPanInt(1)), nil),
 			),
 		},
-		// array
 		{
-			`[1, 2].traverse`,
+			`[1].deepDiff([1, [2]])`,
 			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(0),
-					),
-					object.NewPanInt(1),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(1),
-					),
-					object.NewPanInt(2),
-				),
+				op("add", object.NewPanArr(object.NewPanInt(1)), object.NewPanArr(object.NewPanInt(2))),
 			),
 		},
 		{
-			`['a, ['b, 'c]].traverse`,
+			`%{1: %{2: 3}}.deepDiff(%{1: %{2: 4}})`,
 			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(0),
-					),
-					object.NewPanStr("a"),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(1),
-						object.NewPanInt(0),
-					),
-					object.NewPanStr("b"),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(1),
-						object.NewPanInt(1),
-					),
-					object.NewPanStr("c"),
-				),
+				op("replace", object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2)), object.NewPanInt(4)),
 			),
 		},
-		// obj in arr
+		// different kinds are replaced
 		{
-			`['a, {b: 1, c: 2}].traverse`,
+			`{a: [1]}.deepDiff({a: {b: 1}})`,
 			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(0),
-					),
-					object.NewPanStr("a"),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(1),
-						object.NewPanStr("b"),
-					),
-					object.NewPanInt(1),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanInt(1),
-						object.NewPanStr("c"),
-					),
-					object.NewPanInt(2),
-				),
+				op("replace", object.NewPanArr(object.NewPanStr("a")), toPanObj([]object.Pair{
+					{Key: object.NewPanStr("b"), Value: object.NewPanInt(1)},
+				})),
 			),
 		},
-		// arr in obj
 		{
-			`{a: ['b, 'c]}.traverse`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanStr("a"),
-						object.NewPanInt(0),
-					),
-					object.NewPanStr("b"),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanStr("a"),
-						object.NewPanInt(1),
-					),
-					object.NewPanStr("c"),
-				),
-			),
+			`Obj['deepDiff]({})`,
+			object.NewTypeErr("Obj#deepDiff requires at least 2 args"),
 		},
-		// TODO: map
-		// kwarg "key" selects only pairs containing provided key
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalObjQ(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
 		{
-			`{a: 1, b: {a: 2, c: 3}}.traverse(key: 'a)`,
-			object.NewPanArr(
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanStr("a"),
-					),
-					object.NewPanInt(1),
-				),
-				object.NewPanArr(
-					object.NewPanArr(
-						object.NewPanStr("b"),
-						object.NewPanStr("a"),
-					),
-					object.NewPanInt(2),
-				),
-			),
+			`{a: [{b: 1}, {b: 2}, {c: 3}]}.q("a[*].b")`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2)),
 		},
-		// object other than PanObj
 		{
-			`1.traverse`,
-			object.NewPanArr(),
+			`[{b: 1}, {b: 2}].q("$[?(@.b > 1)]")`,
+			object.NewPanArr(toPanObj([]object.Pair{
+				{Key: object.NewPanStr("b"), Value: object.NewPanInt(2)},
+			})),
 		},
-		// error
 		{
-			`Obj['traverse]()`,
-			object.NewTypeErr("Obj#traverse requires at least 1 arg"),
+			`{a: 1}.q("$.b")`,
+			object.NewPanArr(),
 		},
 		{
-			`{a:1}.traverse(key: 2)`,
-			object.NewTypeErr("2 cannot be treated as str"),
+			`Obj['q]({})`,
+			object.NewTypeErr("Obj#q requires at least 2 args"),
 		},
 	}
 
@@ -5669,6 +8000,14 @@ func TestEvalNamesOfConsts(t *testing.T) {
 			`Map._name`,
 			object.NewPanStr("Map"),
 		},
+		{
+			`Set._name`,
+			object.NewPanStr("Set"),
+		},
+		{
+			`Ref._name`,
+			object.NewPanStr("Ref"),
+		},
 		{
 			`Diamond._name`,
 			object.NewPanStr("Diamond"),
@@ -5697,6 +8036,18 @@ func TestEvalNamesOfConsts(t *testing.T) {
 			`AssertionErr._name`,
 			object.NewPanStr("AssertionErr"),
 		},
+		{
+			`ImportErr._name`,
+			object.NewPanStr("ImportErr"),
+		},
+		{
+			`LimitErr._name`,
+			object.NewPanStr("LimitErr"),
+		},
+		{
+			`MatchErr._name`,
+			object.NewPanStr("MatchErr"),
+		},
 		{
 			`NameErr._name`,
 			object.NewPanStr("NameErr"),
@@ -5709,6 +8060,14 @@ func TestEvalNamesOfConsts(t *testing.T) {
 			`NotImplementedErr._name`,
 			object.NewPanStr("NotImplementedErr"),
 		},
+		{
+			`PermissionErr._name`,
+			object.NewPanStr("PermissionErr"),
+		},
+		{
+			`RecursionErr._name`,
+			object.NewPanStr("RecursionErr"),
+		},
 		{
 			`StopIterErr._name`,
 			object.NewPanStr("StopIterErr"),
@@ -5878,16 +8237,170 @@ func TestEvalJSONDec(t *testing.T) {
 			}),
 		},
 		{
-			`JSON.dec`,
-			object.NewTypeErr("JSON.dec requires at least 2 args"),
+			`JSON.dec`,
+			object.NewTypeErr("JSON.dec requires at least 2 args"),
+		},
+		{
+			`JSON.dec(1)`,
+			object.NewTypeErr("1 cannot be treated as str"),
+		},
+		{
+			`JSON.dec("{")`,
+			object.NewValueErr("failed to decode JSON: unexpected end of JSON input (input `{`)"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalJSONQuery(t *testing.T) {
+	data := `{
+		users: [
+			{name: "a", age: 18, address: {city: "X"}},
+			{name: "b", age: 25, address: {city: "Y"}},
+			{name: "c", age: 32},
+		],
+		owner: {name: "d", age: 40},
+	}`
+
+	strs := func(strs ...string) object.PanObject {
+		elems := []object.PanObject{}
+		for _, s := range strs {
+			elems = append(elems, object.NewPanStr(s))
+		}
+		return object.NewPanArr(elems...)
+	}
+
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`JSON.query(` + data + `, "users[*].address.city")`,
+			strs("X", "Y"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[*].address.city")`,
+			strs("X", "Y"),
+		},
+		{
+			`JSON.query(` + data + `, "$['users'][0]['name']")`,
+			strs("a"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[-1].name")`,
+			strs("c"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[0,2].name")`,
+			strs("a", "c"),
+		},
+		// wildcard of obj follows order of keys
+		{
+			`JSON.query(` + data + `, "$.owner.*")`,
+			object.NewPanArr(object.NewPanInt(40), object.NewPanStr("d")),
+		},
+		// recursive descent
+		{
+			`JSON.query(` + data + `, "$..name")`,
+			strs("d", "a", "b", "c"),
+		},
+		{
+			`JSON.query(` + data + `, "..city")`,
+			strs("X", "Y"),
+		},
+		// slices
+		{
+			`JSON.query(` + data + `, "$.users[1:].name")`,
+			strs("b", "c"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[:2].name")`,
+			strs("a", "b"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[::-1].name")`,
+			strs("c", "b", "a"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[-2:10:1].name")`,
+			strs("b", "c"),
+		},
+		// filters (arithmetic is not supported)
+		{
+			`JSON.query(` + data + `, "$.users[?(@.age > 20)].name")`,
+			strs("b", "c"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[?(@.age >= 18 && @.age < 30)].name")`,
+			strs("a", "b"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[?(@.name == 'a' || @.name == \"c\")].age")`,
+			object.NewPanArr(object.NewPanInt(18), object.NewPanInt(32)),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[?(@.address)].name")`,
+			strs("a", "b"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[?(!@.address)].name")`,
+			strs("c"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[?(@.age < $.owner.age - 10)].name")`,
+			object.NewValueErr(`invalid query "$.users[?(@.age < $.owner.age - 10)].name": ` +
+				"`)` is expected at 31"),
+		},
+		{
+			`JSON.query(` + data + `, "$.users[?(@.age > $.users[1].age)].name")`,
+			strs("c"),
+		},
+		{
+			`JSON.query(` + data + `, "$..[?(@.city == 'Y')].city")`,
+			strs("Y"),
+		},
+		// numbers are compared by values
+		{
+			`JSON.query([1, 2.0, 3], "$[?(@ == 2)]")`,
+			object.NewPanArr(object.NewPanFloat(2.0)),
+		},
+		// map
+		{
+			`JSON.query(%{"a": 1, 2: 3}, "$.a")`,
+			object.NewPanArr(object.NewPanInt(1)),
+		},
+		// nothing matched
+		{
+			`JSON.query(` + data + `, "$.users[5].name")`,
+			object.NewPanArr(),
+		},
+		{
+			`JSON.query(1, "$.a")`,
+			object.NewPanArr(),
+		},
+		{
+			`JSON.query(1, "$")`,
+			object.NewPanArr(object.NewPanInt(1)),
+		},
+		{
+			`JSON.query({}, "$.a[")`,
+			object.NewValueErr(`invalid query "$.a[": selector is expected at 5`),
 		},
 		{
-			`JSON.dec(1)`,
+			`JSON.query({}, "$[::0]")`,
+			object.NewValueErr(`invalid query "$[::0]": slice step cannot be 0 at 6`),
+		},
+		{
+			`JSON.query({}, 1)`,
 			object.NewTypeErr("1 cannot be treated as str"),
 		},
 		{
-			`JSON.dec("{")`,
-			object.NewValueErr("failed to decode JSON: unexpected end of JSON input (input `{`)"),
+			`JSON.query({})`,
+			object.NewTypeErr("JSON.query requires at least 3 args"),
 		},
 	}
 
@@ -5897,6 +8410,17 @@ func TestEvalJSONDec(t *testing.T) {
 	}
 }
 
+func TestEvalJSONQueryGeneratedDynamically(t *testing.T) {
+	// queries more than the cache size are compiled correctly
+	input := `(0:300)@{|i| JSON.query({a: (0:300)@{\}}, "a[#{i}]")[0]}$(0)+`
+	actual := testEval(t, input)
+	testValue(t, actual, object.NewPanInt(44850))
+
+	// evicted queries are compiled again
+	actual = testEval(t, `JSON.query({a: [1, 2]}, "a[0]")`)
+	testValue(t, actual, object.NewPanArr(object.NewPanInt(1)))
+}
+
 func TestEvalArgv(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -5991,6 +8515,16 @@ func TestEvalPrint(t *testing.T) {
 			`"abc".p(end: ",")`,
 			"abc,",
 		},
+		// print does not break line
+		{
+			`"abc".print`,
+			"abc",
+		},
+		// puts is an alias of p
+		{
+			`[1].puts`,
+			"[1]\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -6025,6 +8559,14 @@ func TestEvalPrintErr(t *testing.T) {
 			`BaseObj.p`,
 			object.NewNoPropErr("property `p` is not defined."),
 		},
+		{
+			`f := {}['print]; f()`,
+			object.NewTypeErr("Obj#print requires at least 1 arg"),
+		},
+		{
+			`f := {}['puts]; f()`,
+			object.NewTypeErr("Obj#puts requires at least 1 arg"),
+		},
 		{
 			`IO := 1; 'a.p`,
 			object.NewTypeErr("name `IO` is not io object"),
@@ -6056,6 +8598,197 @@ func TestEvalPrintErr(t *testing.T) {
 	}
 }
 
+func TestEvalPrettyPrint(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`[1, "a", nil].pp`,
+			"[1, \"a\", nil]\n",
+		},
+		{
+			`{b: 2, a: [1, 2]}.pp`,
+			"{\"a\": [1, 2], \"b\": 2}\n",
+		},
+		{
+			`[1, 2, 3].pp(width: 5)`,
+			"[\n  1,\n  2,\n  3\n]\n",
+		},
+		{
+			`{a: [10, 20], b: 1}.pp(width: 18)`,
+			"{\n  \"a\": [10, 20],\n  \"b\": 1\n}\n",
+		},
+		{
+			`[1, 2, 3, 4, 5].pp(max: 3)`,
+			"[1, 2, 3, ... (2 more)]\n",
+		},
+		{
+			`%{1: "a"}.pp(max: 0)`,
+			"%{1: \"a\"}\n",
+		},
+		{
+			`[1, "a"].pp(color?: true)`,
+			"[\x1b[36m1\x1b[0m, \x1b[32m\"a\"\x1b[0m]\n",
+		},
+		// self reference
+		{
+			`r := Ref.new(nil); r.set([r]); r.pp`,
+			"Ref.new([Ref.new(...)])\n",
+		},
+	}
+
+	for _, tt := range tests {
+		writer := &bytes.Buffer{}
+		// setup IO
+		env := object.NewEnvWithConsts()
+		env.InjectIO(os.Stdin, writer)
+
+		actual := testEvalInEnv(t, tt.input, env)
+		// pp returns nil
+		testValue(t, actual, object.BuiltInNil)
+
+		// check output
+		output := writer.String()
+		if output != tt.expected {
+			t.Errorf("wrong output. expected=%q, got=%q",
+				tt.expected, output)
+		}
+	}
+}
+
+func TestEvalPrettyPrintErr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`f := {}['pp]; f()`,
+			object.NewTypeErr("Obj#pp requires at least 1 arg"),
+		},
+		{
+			`IO := 1; 'a.pp`,
+			object.NewTypeErr("name `IO` is not io object"),
+		},
+		{
+			`[1].pp(width: "a")`,
+			object.NewTypeErr(`"a" cannot be treated as int`),
+		},
+		{
+			`[1].pp(max: -1)`,
+			object.NewValueErr("max must not be negative but got -1"),
+		},
+	}
+
+	for _, tt := range tests {
+		writer := &bytes.Buffer{}
+		// setup IO
+		env := object.NewEnvWithConsts()
+		env.InjectIO(os.Stdin, writer)
+
+		actual := testEvalInEnv(t, tt.input, env)
+		testValue(t, actual, tt.expected)
+
+		// check output is empty
+		output := writer.String()
+		if output != "" {
+			t.Errorf("output must be empty. got=`%s`", output)
+		}
+	}
+}
+
+func TestEvalDoc(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		// doc comment of assigned func
+		{
+			"# f returns 1.\nf := {|| 1}\nf.doc",
+			object.NewPanStr("f returns 1."),
+		},
+		{
+			"# f returns 1.\n# it has no args.\nf := m{1}\nf.doc",
+			object.NewPanStr("f returns 1.\nit has no args."),
+		},
+		// comment separated by a blank line is not a doc
+		{
+			"# f returns 1.\n\nf := {|| 1}\nf.doc",
+			object.BuiltInNil,
+		},
+		{
+			"f := {|| 1}\nf.doc",
+			object.BuiltInNil,
+		},
+		// doc comment of prop
+		{
+			"o := {\n  # a returns 1.\n  a: m{1},\n  b: m{2},\n}\no.doc('a)",
+			object.NewPanStr("a returns 1."),
+		},
+		{
+			"o := {\n  # a returns 1.\n  a: m{1},\n  b: m{2},\n}\no.doc('b)",
+			object.BuiltInNil,
+		},
+		{
+			"o := {\n  # a returns 1.\n  a: m{1},\n}\no['a].doc",
+			object.NewPanStr("a returns 1."),
+		},
+		// prop which is not a func
+		{
+			"o := {\n  # a is 1.\n  a: 1,\n}\no.doc('a)",
+			object.BuiltInNil,
+		},
+		// prop in proto
+		{
+			"o := {\n  # a returns 1.\n  a: m{1},\n}\no.bear.doc('a)",
+			object.NewPanStr("a returns 1."),
+		},
+		// Func#doc with arg works as Obj#doc
+		{
+			"f := {|| 1}\nf.doc('doc) == Func['doc].doc",
+			object.BuiltInTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalDocErr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{}.doc`,
+			object.NewTypeErr("Obj#doc requires at least 2 args"),
+		},
+		{
+			`{a: 1}.doc(1)`,
+			object.NewTypeErr("1 cannot be treated as str"),
+		},
+		{
+			`{a: 1}.doc('b)`,
+			object.NewNoPropErr("property `b` is not defined."),
+		},
+		{
+			`f := Func['doc]; f()`,
+			object.NewTypeErr("Func#doc requires at least 1 arg"),
+		},
+		{
+			`Func['doc].call(1)`,
+			object.NewTypeErr("1 cannot be treated as func"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
 func TestEvalPrintErrIfNoIO(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -6415,6 +9148,13 @@ func TestEvalFuncArgs(t *testing.T) {
 				object.NewPanStr("b"),
 			),
 		},
+		{
+			`{|[a, b], c| a + c}.args`,
+			object.NewPanArr(
+				object.NewPanStr("[a, b]"),
+				object.NewPanStr("c"),
+			),
+		},
 		// errors
 		{
 			`Func['args]()`,
@@ -6475,6 +9215,87 @@ func TestEvalFuncKwargs(t *testing.T) {
 	}
 }
 
+func TestEvalFuncSig(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`{|a, b| [a, b]}.sig(Int, Str)(1, "a")`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanStr("a")),
+		},
+		// descendant of the type is accepted
+		{
+			`{|a| a}.sig(Num)(1.5)`,
+			object.NewPanFloat(1.5),
+		},
+		{
+			`{|a| a}.sig(Int)(true)`,
+			object.BuiltInTrue,
+		},
+		// nil accepts any value
+		{
+			`{|a, b| b}.sig(nil, Int)("a", 2)`,
+			object.NewPanInt(2),
+		},
+		{
+			`{|a, b: 1| b}.sig(b: Int)(1)`,
+			object.NewPanInt(1),
+		},
+		{
+			`{|a| a}.sig(ret: Str)("a")`,
+			object.NewPanStr("a"),
+		},
+		// original func is not annotated
+		{
+			`f := {|a| a}; f.sig(Int); f("a")`,
+			object.NewPanStr("a"),
+		},
+		// errors
+		{
+			`{|a, b| a}.sig(Int, Str)(1, 2)`,
+			object.NewTypeErr("param `b` must be Str (got 2)"),
+		},
+		{
+			`{|a| a}.sig(Int)()`,
+			object.NewTypeErr("param `a` must be Int (got nil)"),
+		},
+		{
+			`{|a, b: 1| b}.sig(b: Int)(1, b: "x")`,
+			object.NewTypeErr("kwarg `b` must be Int (got \"x\")"),
+		},
+		{
+			`{|a| a}.sig(ret: Str)(1)`,
+			object.NewTypeErr("return value must be Str (got 1)"),
+		},
+		{
+			`{|a| a}.sig(Int, Int)`,
+			object.NewTypeErr(`too many types for params ["a"]`),
+		},
+		{
+			`{|a| a}.sig(b: Int)`,
+			object.NewTypeErr("`b` is not a kwarg param"),
+		},
+		{
+			`Func['sig]()`,
+			object.NewTypeErr("Func#sig requires at least 1 arg"),
+		},
+		{
+			`Func['sig](1)`,
+			object.NewTypeErr("1 cannot be treated as func"),
+		},
+		{
+			`Func['sig](<{|a| a}>)`,
+			object.NewTypeErr("sig cannot be used for iter"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
 func TestEvalNoPropErr(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -7096,6 +9917,14 @@ func TestEvalConsts(t *testing.T) {
 			`Map`,
 			object.BuiltInMapObj,
 		},
+		{
+			`Set`,
+			object.BuiltInSetObj,
+		},
+		{
+			`Ref`,
+			object.BuiltInRefObj,
+		},
 		{
 			`true`,
 			object.BuiltInTrue,
@@ -7116,6 +9945,18 @@ func TestEvalConsts(t *testing.T) {
 			`AssertionErr`,
 			object.BuiltInAssertionErr,
 		},
+		{
+			`ImportErr`,
+			object.BuiltInImportErr,
+		},
+		{
+			`LimitErr`,
+			object.BuiltInLimitErr,
+		},
+		{
+			`MatchErr`,
+			object.BuiltInMatchErr,
+		},
 		{
 			`NameErr`,
 			object.BuiltInNameErr,
@@ -7128,6 +9969,14 @@ func TestEvalConsts(t *testing.T) {
 			`NotImplementedErr`,
 			object.BuiltInNotImplementedErr,
 		},
+		{
+			`PermissionErr`,
+			object.BuiltInPermissionErr,
+		},
+		{
+			`RecursionErr`,
+			object.BuiltInRecursionErr,
+		},
 		{
 			`StopIterErr`,
 			object.BuiltInStopIterErr,
@@ -7149,9 +9998,70 @@ func TestEvalConsts(t *testing.T) {
 			object.BuiltInZeroDivisionErr,
 		},
 		{
-			`_`,
-			object.NewNotImplementedErr("Not implemented"),
+			`_`,
+			object.NewNotImplementedErr("Not implemented"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalInfix(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`1 == 1`,
+			object.BuiltInTrue,
+		},
+		{
+			`1 + 1`,
+			object.NewPanInt(2),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalInfixUserDefinedOps(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		// every operator can be defined as a prop
+		{`{'**: m{|o| "**"}} ** 1`, object.NewPanStr("**")},
+		{`{'%: m{|o| "%"}} % 1`, object.NewPanStr("%")},
+		{`{'<=>: m{|o| "<=>"}} <=> 1`, object.NewPanStr("<=>")},
+		{`{'<<: m{|o| "<<"}} << 1`, object.NewPanStr("<<")},
+		{`{'>>: m{|o| ">>"}} >> 1`, object.NewPanStr(">>")},
+		{`{'/&: m{|o| "/&"}} /& 1`, object.NewPanStr("/&")},
+		{`{'/|: m{|o| "/|"}} /| 1`, object.NewPanStr("/|")},
+		{`{'/^: m{|o| "/^"}} /^ 1`, object.NewPanStr("/^")},
+		{`{'in: m{|o| "in"}} in 1`, object.NewPanStr("in")},
+		{`{'<+>: m{|o| "<+>"}} <+> 1`, object.NewPanStr("<+>")},
+		{`{'<|>: m{|o| "<|>"}} <|> 1`, object.NewPanStr("<|>")},
+		{`{'⊕: m{|o| "⊕"}} ⊕ 1`, object.NewPanStr("⊕")},
+		{`{'×: m{|o| "×"}} × 1`, object.NewPanStr("×")},
+		// self and the right operand are passed
+		{
+			`V := {new: m{|x| .bear({x: x})}, '⊗: m{|o| [.x, o.x]}}; V.new(1) ⊗ V.new(2)`,
+			object.NewPanArr(object.NewPanInt(1), object.NewPanInt(2)),
 		},
+		// user-defined operators can be called as methods
+		{`{'<*>: m{|o| o * 2}}.<*>(3)`, object.NewPanInt(6)},
+		// operator symbols are syms
+		{`'<$>.sym?`, object.BuiltInTrue},
+		{`'∘.sym?`, object.BuiltInTrue},
+		{`{'·: 1}`, toPanObj([]object.Pair{
+			{Key: object.NewPanStr("·"), Value: object.NewPanInt(1)},
+		})},
 	}
 
 	for _, tt := range tests {
@@ -7160,24 +10070,72 @@ func TestEvalConsts(t *testing.T) {
 	}
 }
 
-func TestEvalInfix(t *testing.T) {
+func TestEvalInfixReflectedOps(t *testing.T) {
+	vec := `V := {new: m{|x| .bear({x: x})}, ` +
+		`_radd: m{|o| ["radd", o]}, _rsub: m{|o| ["rsub", o]}, ` +
+		`_rmul: m{|o| ["rmul", o]}, _rdiv: m{|o| ["rdiv", o]}, ` +
+		`_rfloorDiv: m{|o| ["rfloorDiv", o]}, _rmod: m{|o| ["rmod", o]}, ` +
+		`_rpow: m{|o| ["rpow", o]}, _rlshift: m{|o| ["rlshift", o]}, ` +
+		`_rrshift: m{|o| ["rrshift", o]}, _rbitAnd: m{|o| ["rbitAnd", o]}, ` +
+		`_rbitOr: m{|o| ["rbitOr", o]}, _rbitXor: m{|o| ["rbitXor", o]}}; `
+
 	tests := []struct {
 		input    string
 		expected object.PanObject
 	}{
+		{vec + `2 + V.new(1)`, object.NewPanArr(object.NewPanStr("radd"), object.NewPanInt(2))},
+		{vec + `2 - V.new(1)`, object.NewPanArr(object.NewPanStr("rsub"), object.NewPanInt(2))},
+		{vec + `2 * V.new(1)`, object.NewPanArr(object.NewPanStr("rmul"), object.NewPanInt(2))},
+		{vec + `2 / V.new(1)`, object.NewPanArr(object.NewPanStr("rdiv"), object.NewPanInt(2))},
+		{vec + `2 // V.new(1)`, object.NewPanArr(object.NewPanStr("rfloorDiv"), object.NewPanInt(2))},
+		{vec + `2 % V.new(1)`, object.NewPanArr(object.NewPanStr("rmod"), object.NewPanInt(2))},
+		{vec + `2 ** V.new(1)`, object.NewPanArr(object.NewPanStr("rpow"), object.NewPanInt(2))},
+		{vec + `2 << V.new(1)`, object.NewPanArr(object.NewPanStr("rlshift"), object.NewPanInt(2))},
+		{vec + `2 >> V.new(1)`, object.NewPanArr(object.NewPanStr("rrshift"), object.NewPanInt(2))},
+		{vec + `2 /& V.new(1)`, object.NewPanArr(object.NewPanStr("rbitAnd"), object.NewPanInt(2))},
+		{vec + `2 /| V.new(1)`, object.NewPanArr(object.NewPanStr("rbitOr"), object.NewPanInt(2))},
+		{vec + `2 /^ V.new(1)`, object.NewPanArr(object.NewPanStr("rbitXor"), object.NewPanInt(2))},
+		// other types of the left operand
+		{vec + `2.5 * V.new(1)`, object.NewPanArr(object.NewPanStr("rmul"), object.NewPanFloat(2.5))},
+		{vec + `"a" * V.new(1)`, object.NewPanArr(object.NewPanStr("rmul"), object.NewPanStr("a"))},
+		{vec + `[1] + V.new(1)`, object.NewPanArr(object.NewPanStr("radd"), object.NewPanArr(object.NewPanInt(1)))},
+		// left operand which does not have the operator
+		{vec + `{a: 1} % V.new(1)`, object.NewPanArr(object.NewPanStr("rmod"), toPanObj([]object.Pair{
+			{Key: object.NewPanStr("a"), Value: object.NewPanInt(1)},
+		}))},
+		// reflected operator is not called if the left operand can handle the operator
+		{vec + `"a" * 3`, object.NewPanStr("aaa")},
 		{
-			`1 == 1`,
-			object.BuiltInTrue,
+			`V := {_radd: m{|o| "radd"}}; {'+: m{|o| "add"}} + V`,
+			object.NewPanStr("add"),
 		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalInfixReflectedOpsErr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *object.PanErr
+	}{
+		// error is raised if the right operand does not have the reflected operator
+		{`2 * {a: 1}`, object.NewTypeErr(`{"a": 1} cannot be treated as int`)},
+		// comparison operators are not reflected
+		{`2 <=> {_rlt: 1}`, object.NewTypeErr(`{"_rlt": 1} cannot be treated as int`)},
+		// error in the reflected operator is raised
 		{
-			`1 + 1`,
-			object.NewPanInt(2),
+			`2 * {_rmul: m{|o| raise ValueErr.new("oops")}}`,
+			object.NewValueErr("oops"),
 		},
 	}
 
 	for _, tt := range tests {
 		actual := testEval(t, tt.input)
-		testValue(t, actual, tt.expected)
+		testPanErr(t, actual, tt.expected)
 	}
 }
 
@@ -9355,6 +12313,74 @@ func TestEvalInfixIntModErr(t *testing.T) {
 	}
 }
 
+func TestEvalInfixIntBitwise(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{`6 /& 3`, object.NewPanInt(2)},
+		{`6 /| 3`, object.NewPanInt(7)},
+		{`6 /^ 3`, object.NewPanInt(5)},
+		{`-1 /& 0xff`, object.NewPanInt(255)},
+		{`1 << 10`, object.NewPanInt(1024)},
+		{`1024 >> 3`, object.NewPanInt(128)},
+		{`1 << 64`, object.NewPanInt(0)},
+		// sign bit is kept
+		{`-16 >> 2`, object.NewPanInt(-4)},
+		// nil is treated as 0
+		{`6 /| nil`, object.NewPanInt(6)},
+		// descendant of int can be used
+		{`3 /& true`, object.NewPanInt(1)},
+		// child /& child == brother
+		{
+			`child := Int.bear; (child.new(6) /& child.new(3)).proto == child`,
+			object.BuiltInTrue,
+		},
+		{
+			`child := Int.bear; (child.new(6) << child.new(3)).proto == child`,
+			object.BuiltInTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalInfixIntBitwiseErr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`1 /& "a"`,
+			object.NewTypeErr(`"a" cannot be treated as int`),
+		},
+		{
+			`1['/|]({}, 2)`,
+			object.NewTypeErr("{} cannot be treated as int"),
+		},
+		{
+			`1./^`,
+			object.NewTypeErr("/^ requires at least 2 args"),
+		},
+		{
+			`1 << -1`,
+			object.NewValueErr("negative shift count -1"),
+		},
+		{
+			`1 >> -2`,
+			object.NewValueErr("negative shift count -2"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
 func TestEvalPrefix(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -9572,6 +12598,84 @@ func TestEvalAssertionErrConstructor(t *testing.T) {
 	}
 }
 
+func TestEvalImportErrConstructor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`ImportErr.new("new error")`,
+			object.NewImportErr("new error"),
+		},
+		// args are converted to str by .S
+		{
+			`ImportErr.new(1)`,
+			object.NewImportErr("1"),
+		},
+		{
+			`ImportErr.new()`,
+			object.NewImportErr("nil"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalLimitErrConstructor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`LimitErr.new("new error")`,
+			object.NewLimitErr("new error"),
+		},
+		// args are converted to str by .S
+		{
+			`LimitErr.new(1)`,
+			object.NewLimitErr("1"),
+		},
+		{
+			`LimitErr.new()`,
+			object.NewLimitErr("nil"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalMatchErrConstructor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`MatchErr.new("new error")`,
+			object.NewMatchErr("new error"),
+		},
+		// args are converted to str by .S
+		{
+			`MatchErr.new(1)`,
+			object.NewMatchErr("1"),
+		},
+		{
+			`MatchErr.new()`,
+			object.NewMatchErr("nil"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
 func TestEvalNameErrConstructor(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -9662,6 +12766,58 @@ func TestEvalNotImplementedErrConstructor(t *testing.T) {
 	}
 }
 
+func TestEvalPermissionErrConstructor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`PermissionErr.new("new error")`,
+			object.NewPermissionErr("new error"),
+		},
+		// args are converted to str by .S
+		{
+			`PermissionErr.new(1)`,
+			object.NewPermissionErr("1"),
+		},
+		{
+			`PermissionErr.new()`,
+			object.NewPermissionErr("nil"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
+func TestEvalRecursionErrConstructor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.PanObject
+	}{
+		{
+			`RecursionErr.new("new error")`,
+			object.NewRecursionErr("new error"),
+		},
+		// args are converted to str by .S
+		{
+			`RecursionErr.new(1)`,
+			object.NewRecursionErr("1"),
+		},
+		{
+			`RecursionErr.new()`,
+			object.NewRecursionErr("nil"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual := testEval(t, tt.input)
+		testValue(t, actual, tt.expected)
+	}
+}
+
 func TestEvalStopIterErrConstructor(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -10400,7 +13556,18 @@ func TestEvalRead(t *testing.T) {
 			`read("testdata/notfound.txt")`,
 			object.NewFileNotFoundErr(`"testdata/notfound.txt" cannot be opened: open testdata/notfound.txt: no such file or directory`),
 		},
-		// TODO: add non-text options
+		{
+			`read("testdata/sample.txt", mode: "bytes")`,
+			object.NewPanBytes([]byte("dummy\n")),
+		},
+		{
+			`read("testdata/sample.txt", mode: "str")`,
+			object.NewPanStr("dummy\n"),
+		},
+		{
+			`read("testdata/sample.txt", mode: "rb")`,
+			object.NewValueErr(`mode must be "str" or "bytes" but got "rb"`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -10934,6 +14101,73 @@ func testPanObj(t *testing.T, actual object.PanObject, expected *object.PanObj)
 	}
 }
 
+func testPanSet(t *testing.T, actual object.PanObject, expected *object.PanSet) {
+	if actual == nil {
+		t.Fatalf("actual must not be nil. expected=%v(%T)", expected, expected)
+	}
+
+	if actual.Type() != object.SetType {
+		t.Fatalf("Type must be SetType(%s). got=%s(%s)",
+			expected.Inspect(), actual.Type(), actual.Inspect())
+		return
+	}
+
+	// NOTE: elements are compared by Inspect because the order of elements is kept
+	if actual.Inspect() != expected.Inspect() {
+		t.Errorf("wrong value. expected=%s, got=%s",
+			expected.Inspect(), actual.Inspect())
+	}
+}
+
+func testPanRef(t *testing.T, actual object.PanObject, expected *object.PanRef) {
+	if actual == nil {
+		t.Fatalf("actual must not be nil. expected=%v(%T)", expected, expected)
+	}
+
+	if actual.Type() != object.RefType {
+		t.Fatalf("Type must be RefType(%s). got=%s(%s)",
+			expected.Inspect(), actual.Type(), actual.Inspect())
+		return
+	}
+
+	// NOTE: refs are compared by their values (not by identity)
+	testValue(t, actual.(*object.PanRef).Get(), expected.Get())
+}
+
+func testPanBytes(t *testing.T, actual object.PanObject, expected *object.PanBytes) {
+	if actual == nil {
+		t.Fatalf("actual must not be nil. expected=%v(%T)", expected, expected)
+	}
+
+	if actual.Type() != object.BytesType {
+		t.Fatalf("Type must be BytesType(%s). got=%s(%s)",
+			expected.Inspect(), actual.Type(), actual.Inspect())
+		return
+	}
+
+	if actual.Inspect() != expected.Inspect() {
+		t.Errorf("wrong value. expected=%s, got=%s",
+			expected.Inspect(), actual.Inspect())
+	}
+}
+
+func testPanMatch(t *testing.T, actual object.PanObject, expected string) {
+	if actual == nil {
+		t.Fatalf("actual must not be nil. expected=%s", expected)
+	}
+
+	if actual.Type() != object.MatchType {
+		t.Fatalf("Type must be MatchType(%s). got=%s(%s)",
+			expected, actual.Type(), actual.Inspect())
+		return
+	}
+
+	if actual.Inspect() != expected {
+		t.Errorf("wrong value. expected=%s, got=%s",
+			expected, actual.Inspect())
+	}
+}
+
 func testPanMap(t *testing.T, actual object.PanObject, expected *object.PanMap) {
 	if actual == nil {
 		t.Fatalf("actual must not be nil. expected=%v(%T)", expected, expected)
@@ -11164,6 +14398,12 @@ func testValue(t *testing.T, actual object.PanObject, expected object.PanObject)
 		testPanObj(t, actual, expected)
 	case *object.PanMap:
 		testPanMap(t, actual, expected)
+	case *object.PanSet:
+		testPanSet(t, actual, expected)
+	case *object.PanRef:
+		testPanRef(t, actual, expected)
+	case *object.PanBytes:
+		testPanBytes(t, actual, expected)
 	case *object.PanErr:
 		testPanErr(t, actual, expected)
 	case *object.PanErrWrapper:
@@ -11210,3 +14450,108 @@ func testParse(t *testing.T, input string) *ast.Program {
 
 	return node
 }
+
+func TestEvalSandbox(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		input    string
+		ctx      context.Context
+		limits   object.Limits
+		expected object.PanObject
+	}{
+		{
+			`(1:1000)@{|i| i * 2}`,
+			context.Background(),
+			object.Limits{MaxSteps: 100},
+			object.NewLimitErr("exceeded maximum steps 100"),
+		},
+		{
+			`(1:10000)@{|i| i * 2}`,
+			canceled,
+			object.Limits{},
+			object.NewLimitErr("evaluation stopped: context canceled"),
+		},
+		{
+			`f := {|n| f(n + 1)}; f(0)`,
+			context.Background(),
+			object.Limits{MaxDepth: 10},
+			object.NewRecursionErr("exceeded maximum recursion depth 10"),
+		},
+		{
+			`(1:100000)@{|i| [i, "abc" * 10]}`,
+			context.Background(),
+			object.Limits{MaxMemory: 1024},
+			object.NewLimitErr("exceeded maximum memory 1024 bytes"),
+		},
+		// depth is uncounted after calls
+		{
+			`f := {|n| n}; ((1:100)@{|i| f(i)}).len`,
+			context.Background(),
+			object.Limits{MaxDepth: 10},
+			object.NewPanInt(99),
+		},
+		{
+			`read("notfound.txt")`,
+			context.Background(),
+			object.Limits{Denied: object.CapRead},
+			object.NewPermissionErr("read is not permitted"),
+		},
+		{
+			`Kernel.argv`,
+			context.Background(),
+			object.Limits{Denied: object.CapOS},
+			object.NewPermissionErr("argv is not permitted"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// NOTE: collect garbage so that memory usage is estimated stably
+			runtime.GC()
+
+			env := object.NewEnvWithConsts()
+			env.InjectFrom(object.BuiltInKernelObj)
+			env.SetSandbox(object.NewSandbox(tt.ctx, tt.limits))
+
+			actual := testEvalInEnv(t, tt.input, env)
+			testValue(t, actual, tt.expected)
+		})
+	}
+}
+
+func TestEvalSandboxOfCaller(t *testing.T) {
+	// f is defined outside of the sandbox (like native code)
+	outer := object.NewEnvWithConsts()
+	f := testEvalInEnv(t, `{|n| (1:n)@{|i| i}}`, outer)
+
+	env := object.NewEnclosedEnv(outer)
+	env.Set(object.GetSymHash("f"), f)
+	env.SetSandbox(object.NewSandbox(context.Background(), object.Limits{MaxSteps: 100}))
+
+	actual := testEvalInEnv(t, `f(1000)`, env)
+	testValue(t, actual, object.NewLimitErr("exceeded maximum steps 100"))
+}
+
+func TestEvalSandboxDetachedFromIter(t *testing.T) {
+	// it is defined outside of the sandbox (like native code)
+	outer := object.NewEnvWithConsts()
+	outer.InjectFrom(object.BuiltInKernelObj)
+	it := testEvalInEnv(t, `<{|i| yield i}>.new(1)`, outer)
+
+	env := object.NewEnclosedEnv(outer)
+	env.Set(object.GetSymHash("it"), it)
+	env.SetSandbox(object.NewSandbox(context.Background(), object.Limits{MaxSteps: 100}))
+
+	actual := testEvalInEnv(t, `it.next`, env)
+	testValue(t, actual, object.NewPanInt(1))
+
+	f, ok := it.(*object.PanFunc)
+	if !ok {
+		t.Fatalf("it must be *object.PanFunc. got=%T", it)
+	}
+	if f.Env.Sandbox() != nil {
+		t.Errorf("sandbox of the caller must be detached from the iter after the call")
+	}
+}
@@ -24,7 +24,12 @@ func evalVarCall(node *ast.VarCallExpr, env *object.Env) object.PanObject {
 		return appendStackTrace(err, node.Source())
 	}
 
-	chainMiddleware := newLiteralCallChainMiddleware(*node.Chain)
+	ctx, err := evalChainContext(node.Chain, env)
+	if err != nil {
+		return appendStackTrace(err, node.Source())
+	}
+
+	chainMiddleware := newLiteralCallChainMiddleware(*node.Chain, ctx)
 	ret := _evalLiteralCall(env, recv, chainArg, f,
 		chainMiddleware, literalProxyMiddleware)
 	if err, ok := ret.(*object.PanErr); ok {
@@ -12,6 +12,10 @@ type FuncWrapperImpl struct {
 	args    *object.PanArr
 	kwargs  *object.PanObj
 	body    *[]ast.Stmt
+	// patterns is ast of params, which is used only if params contain destructuring patterns
+	patterns []ast.Expr
+	// guard is condition of each pattern in match literal (`|x, when: x > 0|`)
+	guard ast.Expr
 }
 
 // String returns func code.
@@ -45,6 +45,43 @@ func findElemInArr(
 	return findElemInObj(env, kwargs, args...)
 }
 
+func findElemInBytes(
+	env *object.Env,
+	kwargs *object.PanObj,
+	args ...object.PanObject,
+) object.PanObject {
+	// NOTE: if index is not found, Obj#at is called
+
+	if len(args) < 2 {
+		return object.NewTypeErr("Bytes#at requires at least 2 args")
+	}
+	// allow child of bytes
+	self, ok := object.TraceProtoOfBytes(args[0])
+	if !ok {
+		return findElemInObj(env, kwargs, args...)
+	}
+
+	// allow child of arr
+	indexArr, ok := object.TraceProtoOfArr(args[1])
+	if !ok {
+		return findElemInObj(env, kwargs, args...)
+	}
+
+	if len(indexArr.Elems) < 1 {
+		return object.BuiltInNil
+	}
+
+	if index, ok := object.TraceProtoOfInt(indexArr.Elems[0]); ok {
+		return bytesIndex(index.Value, self.Value)
+	}
+
+	if index, ok := object.TraceProtoOfRange(indexArr.Elems[0]); ok {
+		return bytesRange(index, self.Value)
+	}
+
+	return findElemInObj(env, kwargs, args...)
+}
+
 func findBitInInt(
 	env *object.Env,
 	kwargs *object.PanObj,
@@ -196,6 +233,19 @@ func strIndex(index int64, runes []rune) object.PanObject {
 	return object.NewPanStr(string(runes[index]))
 }
 
+func bytesIndex(index int64, b []byte) object.PanObject {
+	length := int64(len(b))
+	if index >= length || index < -length {
+		return object.BuiltInNil
+	}
+
+	if index < 0 {
+		return object.NewPanInt(int64(b[index+length]))
+	}
+
+	return object.NewPanInt(int64(b[index]))
+}
+
 func arrRange(r *object.PanRange, arr *object.PanArr) object.PanObject {
 	return valRange(r, len(arr.Elems), func(i int64) object.PanObject {
 		return arrIndex(i, arr)
@@ -221,6 +271,22 @@ func strRange(r *object.PanRange, runes []rune) object.PanObject {
 	return object.NewPanStr(out.String())
 }
 
+func bytesRange(r *object.PanRange, b []byte) object.PanObject {
+	intArr := valRange(r, len(b), func(i int64) object.PanObject {
+		return bytesIndex(i, b)
+	})
+	if intArr.Type() == object.ErrType {
+		return intArr
+	}
+
+	elems := intArr.(*object.PanArr).Elems
+	sliced := make([]byte, 0, len(elems))
+	for _, elem := range elems {
+		sliced = append(sliced, byte(elem.(*object.PanInt).Value))
+	}
+	return object.NewPanBytes(sliced)
+}
+
 func valRange(
 	r *object.PanRange,
 	size int,
@@ -11,11 +11,13 @@ func NewPropContainer() map[string]object.PanObject {
 		// name format: "ObjName_propName"
 		"Arr_at":       object.NewPanBuiltInFunc(findElemInArr),
 		"BaseObj_at":   object.NewPanBuiltInFunc(findElemInObj),
+		"Bytes_at":     object.NewPanBuiltInFunc(findElemInBytes),
 		"Func_call":    object.NewPanBuiltInFunc(evalFuncCall),
 		"Int_at":       object.NewPanBuiltInFunc(findBitInInt),
 		"Iter_new":     object.NewPanBuiltInFunc(iterNew),
 		"Iter_next":    object.NewPanBuiltInFunc(iterNext),
 		"Map_at":       object.NewPanBuiltInFunc(findElemInMap),
+		"Match_call":   object.NewPanBuiltInFunc(evalMatchCall),
 		"Obj_callProp": object.NewPanBuiltInFunc(builtInCallProp),
 		"Str_at":       object.NewPanBuiltInFunc(findElemInStr),
 	}
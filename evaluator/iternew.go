@@ -26,7 +26,9 @@ func iterNew(
 
 	// locate env in same closure as self.Env
 	newEnv := object.NewEnclosedEnv(self.Env.Outer())
-	assignArgsToEnv(newEnv, self.Args().Elems, self.Kwargs(), args[1:], kwargs)
+	if err := assignArgsToEnv(newEnv, self.FuncWrapper, args[1:], kwargs); err != nil {
+		return err
+	}
 
 	return object.NewPanIter(self.FuncWrapper, newEnv)
 }
@@ -16,15 +16,21 @@ func iterNext(
 	}
 
 	// ignore args (args are set in env by Iter#new method or recur in last loop)
-	return evalIterCall(args[0])
+	return evalIterCall(env, args[0])
 }
 
-func evalIterCall(self object.PanObject) object.PanObject {
+func evalIterCall(env *object.Env, self object.PanObject) object.PanObject {
 	switch f := self.(type) {
 	case *object.PanFunc:
+		// NOTE: sandbox of the caller is used because iter may be defined outside of the sandbox (e.g. native code)
+		// it is set to a new env for this call so that the iter shared by other callers is not modified
+		e := object.NewEnclosedEnv(f.Env)
+		if s := env.Sandbox(); s != nil {
+			e.SetSandbox(s)
+		}
 		// inject var `recur`
-		f.Env.InjectRecur(recur(f))
-		retVal := evalStmts(*f.Body(), f.Env)
+		e.InjectRecur(recur(f))
+		retVal := evalStmts(*f.Body(), e)
 
 		if err, ok := retVal.(*object.PanErr); ok {
 			return appendStackTrace(err, (*f.Body())[0].Source())
@@ -50,7 +56,9 @@ func recur(iter *object.PanFunc) object.BuiltInFunc {
 		// replace iter.Env
 		// newEnv is in same closure as old env
 		newEnv := object.NewEnclosedEnv(iter.Env.Outer())
-		assignArgsToEnv(newEnv, iter.Args().Elems, iter.Kwargs(), args, kwargs)
+		if err := assignArgsToEnv(newEnv, iter.FuncWrapper, args, kwargs); err != nil {
+			return err
+		}
 		iter.Env = newEnv
 
 		return object.BuiltInNil
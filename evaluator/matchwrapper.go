@@ -0,0 +1,22 @@
+package evaluator
+
+import (
+	"github.com/Syuparn/pangaea/object"
+)
+
+// MatchWrapperImpl is wrapper for ast match code.
+// This implements object.MatchWrapper.
+type MatchWrapperImpl struct {
+	codeStr  string
+	patterns []object.FuncWrapper
+}
+
+// String returns match code.
+func (mw *MatchWrapperImpl) String() string {
+	return mw.codeStr
+}
+
+// Patterns returns each pattern of match.
+func (mw *MatchWrapperImpl) Patterns() []object.FuncWrapper {
+	return mw.patterns
+}
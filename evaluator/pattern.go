@@ -0,0 +1,410 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Syuparn/pangaea/ast"
+	"github.com/Syuparn/pangaea/object"
+)
+
+// matchPattern binds idents in pattern to the corresponding parts of v.
+// It returns false if v does not match pattern.
+// NOTE: bound variables are set to env even if the whole pattern does not match
+func matchPattern(
+	pattern ast.Expr,
+	v object.PanObject,
+	env *object.Env,
+) (bool, *object.PanErr) {
+	switch p := pattern.(type) {
+	case *ast.Ident:
+		return matchIdentPattern(p, v, env)
+	case *ast.IntLiteral, *ast.FloatLiteral, *ast.StrLiteral, *ast.SymLiteral:
+		return matchEqPattern(pattern, v, env)
+	case *ast.ArrLiteral:
+		arr, ok := object.TraceProtoOfArr(v)
+		if !ok {
+			return false, nil
+		}
+		return matchElemPatterns(p.Elems, arr.Elems, env)
+	case *ast.ObjLiteral:
+		return matchObjPattern(p, v, env)
+	case *ast.MapLiteral:
+		return matchMapPattern(p, v, env)
+	case *ast.PrefixExpr:
+		if isRestPattern(p) {
+			err := object.NewSyntaxErr(
+				fmt.Sprintf("`%s` can be used only in params or arr pattern", p.String()))
+			return false, appendStackTrace(err, p.Source())
+		}
+	}
+
+	return matchValuePattern(pattern, v, env)
+}
+
+// matchIdentPattern binds v to ident.
+// `_` matches any value without binding, and constant-like idents (`Int`, `Foo`, etc.)
+// are treated as values.
+func matchIdentPattern(
+	ident *ast.Ident,
+	v object.PanObject,
+	env *object.Env,
+) (bool, *object.PanErr) {
+	switch ident.Value {
+	case "_":
+		return true, nil
+	case "nil", "true", "false":
+		return matchEqPattern(ident, v, env)
+	}
+
+	if isConstIdent(ident.Value) {
+		return matchValuePattern(ident, v, env)
+	}
+
+	env.Set(object.GetSymHash(ident.Value), v)
+	return true, nil
+}
+
+func isConstIdent(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// matchValuePattern evaluates pattern and checks `v === pattern`.
+func matchValuePattern(
+	pattern ast.Expr,
+	v object.PanObject,
+	env *object.Env,
+) (bool, *object.PanErr) {
+	return compareWithPattern(pattern, v, env, object.NewPanStr("==="))
+}
+
+// matchEqPattern evaluates literal pattern and checks `v == pattern`.
+// NOTE: `===` is not used because it is too loose for literals (e.g. `"abc" === "^a"` is true)
+func matchEqPattern(
+	pattern ast.Expr,
+	v object.PanObject,
+	env *object.Env,
+) (bool, *object.PanErr) {
+	return compareWithPattern(pattern, v, env, object.NewPanStr("=="))
+}
+
+func compareWithPattern(
+	pattern ast.Expr,
+	v object.PanObject,
+	env *object.Env,
+	opSym *object.PanStr,
+) (bool, *object.PanErr) {
+	expected := Eval(pattern, env)
+	if err, ok := expected.(*object.PanErr); ok {
+		return false, appendStackTrace(err, pattern.Source())
+	}
+
+	ret := builtInCallProp(env, object.EmptyPanObjPtr(),
+		object.EmptyPanObjPtr(), v, opSym, expected)
+	if err, ok := ret.(*object.PanErr); ok {
+		return false, appendStackTrace(err, pattern.Source())
+	}
+
+	return ret == object.BuiltInTrue, nil
+}
+
+// matchElemPatterns matches elems to patterns one by one.
+// At most one rest pattern (like `*rest`) can be used, which is bound to arr of the remaining elems.
+func matchElemPatterns(
+	patterns []ast.Expr,
+	elems []object.PanObject,
+	env *object.Env,
+) (bool, *object.PanErr) {
+	restIndex, err := findRestPattern(patterns)
+	if err != nil {
+		return false, err
+	}
+
+	if restIndex < 0 {
+		if len(patterns) != len(elems) {
+			return false, nil
+		}
+		return matchEach(patterns, elems, env)
+	}
+
+	head := patterns[:restIndex]
+	tail := patterns[restIndex+1:]
+	if len(elems) < len(head)+len(tail) {
+		return false, nil
+	}
+
+	if ok, err := matchEach(head, elems[:len(head)], env); !ok || err != nil {
+		return ok, err
+	}
+	if ok, err := matchEach(tail, elems[len(elems)-len(tail):], env); !ok || err != nil {
+		return ok, err
+	}
+
+	restElems := make([]object.PanObject, len(elems)-len(head)-len(tail))
+	copy(restElems, elems[len(head):len(elems)-len(tail)])
+	rest := patterns[restIndex].(*ast.PrefixExpr).Right.(*ast.Ident)
+	if rest.Value != "_" {
+		env.Set(object.GetSymHash(rest.Value), object.NewPanArr(restElems...))
+	}
+
+	return true, nil
+}
+
+func matchEach(
+	patterns []ast.Expr,
+	elems []object.PanObject,
+	env *object.Env,
+) (bool, *object.PanErr) {
+	for i, pattern := range patterns {
+		ok, err := matchPattern(pattern, elems[i], env)
+		if !ok || err != nil {
+			return ok, err
+		}
+	}
+	return true, nil
+}
+
+func findRestPattern(patterns []ast.Expr) (int, *object.PanErr) {
+	restIndex := -1
+	for i, pattern := range patterns {
+		p, ok := pattern.(*ast.PrefixExpr)
+		if !ok || !isRestPattern(p) {
+			continue
+		}
+
+		if _, ok := p.Right.(*ast.Ident); !ok {
+			err := object.NewSyntaxErr(
+				fmt.Sprintf("rest pattern `%s` must be ident", p.String()))
+			return -1, appendStackTrace(err, p.Source())
+		}
+		if restIndex >= 0 {
+			err := object.NewSyntaxErr("only one rest pattern can be used")
+			return -1, appendStackTrace(err, p.Source())
+		}
+		restIndex = i
+	}
+	return restIndex, nil
+}
+
+func isRestPattern(p *ast.PrefixExpr) bool {
+	return p.Operator == "*"
+}
+
+// matchObjPattern matches props of obj v.
+// v can have props which are not in pattern (which can be bound by `**rest`).
+func matchObjPattern(
+	pattern *ast.ObjLiteral,
+	v object.PanObject,
+	env *object.Env,
+) (bool, *object.PanErr) {
+	obj, ok := v.(*object.PanObj)
+	if !ok {
+		return false, nil
+	}
+
+	matched := map[object.SymHash]bool{}
+	for _, pair := range pattern.Pairs {
+		key, err := objPatternKey(pair.Key, env)
+		if err != nil {
+			return false, err
+		}
+
+		symHash := object.GetSymHash(key.Value)
+		elem, ok := (*obj.Pairs)[symHash]
+		if !ok {
+			return false, nil
+		}
+
+		if ok, err := matchPattern(pair.Val, elem.Value, env); !ok || err != nil {
+			return ok, err
+		}
+		matched[symHash] = true
+	}
+
+	rest, err := findKwargRestPattern(pattern.EmbeddedExprs)
+	if err != nil {
+		return false, err
+	}
+	if rest != nil && rest.Value != "_" {
+		restPairs := map[object.SymHash]object.Pair{}
+		for symHash, pair := range *obj.Pairs {
+			if !matched[symHash] {
+				restPairs[symHash] = pair
+			}
+		}
+		env.Set(object.GetSymHash(rest.Value), object.PanObjInstancePtr(&restPairs))
+	}
+
+	return true, nil
+}
+
+func objPatternKey(key ast.Expr, env *object.Env) (*object.PanStr, *object.PanErr) {
+	// NOTE: same as obj literal, ident key is treated as sym
+	if ident, ok := key.(*ast.Ident); ok {
+		return object.NewPanStr(ident.String()), nil
+	}
+
+	var k object.PanObject
+	if pinned, ok := key.(*ast.PinnedIdent); ok {
+		pinnedKey, err := searchPinnedKey(pinned, env)
+		if err != nil {
+			return nil, err
+		}
+		k = pinnedKey
+	} else {
+		k = Eval(key, env)
+		if err, ok := k.(*object.PanErr); ok {
+			return nil, appendStackTrace(err, key.Source())
+		}
+	}
+
+	str, ok := object.TraceProtoOfStr(k)
+	if !ok {
+		err := object.NewTypeErr(
+			fmt.Sprintf("cannot use `%s` as Obj key.", k.Inspect()))
+		return nil, appendStackTrace(err, key.Source())
+	}
+	return str, nil
+}
+
+// matchMapPattern matches values of map v.
+// v can have keys which are not in pattern (which can be bound by `**rest`).
+func matchMapPattern(
+	pattern *ast.MapLiteral,
+	v object.PanObject,
+	env *object.Env,
+) (bool, *object.PanErr) {
+	m, ok := object.TraceProtoOfMap(v)
+	if !ok {
+		return false, nil
+	}
+
+	matchedKeys := map[object.HashKey]bool{}
+	matchedNonHashables := map[int]bool{}
+	for _, pair := range pattern.Pairs {
+		key := Eval(pair.Key, env)
+		if err, ok := key.(*object.PanErr); ok {
+			return false, appendStackTrace(err, pair.Key.Source())
+		}
+
+		var elem object.PanObject
+		if scalar, ok := key.(object.PanScalar); ok {
+			p, ok := (*m.Pairs)[scalar.Hash()]
+			if !ok {
+				return false, nil
+			}
+			elem = p.Value
+			matchedKeys[scalar.Hash()] = true
+		} else {
+			i, found := findNonHashableKey(env, *m.NonHashablePairs, key)
+			if !found {
+				return false, nil
+			}
+			elem = (*m.NonHashablePairs)[i].Value
+			matchedNonHashables[i] = true
+		}
+
+		if ok, err := matchPattern(pair.Val, elem, env); !ok || err != nil {
+			return ok, err
+		}
+	}
+
+	rest, err := findKwargRestPattern(pattern.EmbeddedExprs)
+	if err != nil {
+		return false, err
+	}
+	if rest != nil && rest.Value != "_" {
+		restPairs := []object.Pair{}
+		for _, hashKey := range *m.HashKeys {
+			if !matchedKeys[hashKey] {
+				restPairs = append(restPairs, (*m.Pairs)[hashKey])
+			}
+		}
+		for i, pair := range *m.NonHashablePairs {
+			if !matchedNonHashables[i] {
+				restPairs = append(restPairs, pair)
+			}
+		}
+		env.Set(object.GetSymHash(rest.Value), object.NewPanMap(restPairs...))
+	}
+
+	return true, nil
+}
+
+func findNonHashableKey(
+	env *object.Env,
+	pairs []object.Pair,
+	key object.PanObject,
+) (int, bool) {
+	eqSym := object.NewPanStr("==")
+
+	for i, pair := range pairs {
+		ret := builtInCallProp(env, object.EmptyPanObjPtr(),
+			object.EmptyPanObjPtr(), key, eqSym, pair.Key)
+		if ret == object.BuiltInTrue {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// findKwargRestPattern returns ident of `**rest` (or nil if it is not used).
+func findKwargRestPattern(exprs []ast.Expr) (*ast.Ident, *object.PanErr) {
+	if len(exprs) == 0 {
+		return nil, nil
+	}
+
+	if len(exprs) > 1 {
+		err := object.NewSyntaxErr("only one rest pattern can be used")
+		return nil, appendStackTrace(err, exprs[1].Source())
+	}
+
+	ident, ok := exprs[0].(*ast.Ident)
+	if !ok {
+		err := object.NewSyntaxErr(
+			fmt.Sprintf("rest pattern `**%s` must be ident", exprs[0].String()))
+		return nil, appendStackTrace(err, exprs[0].Source())
+	}
+	return ident, nil
+}
+
+// matchParams matches args to params of func (or pattern of match).
+// Unlike arr pattern, lacked args are regarded as nil and extra args are ignored.
+func matchParams(
+	params []ast.Expr,
+	args []object.PanObject,
+	env *object.Env,
+) (bool, *object.PanErr) {
+	restIndex, err := findRestPattern(params)
+	if err != nil {
+		return false, err
+	}
+
+	arity := len(params)
+	if restIndex >= 0 {
+		arity--
+	}
+
+	padded := make([]object.PanObject, len(args))
+	copy(padded, args)
+	for len(padded) < arity {
+		padded = append(padded, object.BuiltInNil)
+	}
+	if restIndex < 0 {
+		padded = padded[:arity]
+	}
+
+	return matchElemPatterns(params, padded, env)
+}
+
+func paramsStr(params []ast.Expr) string {
+	strs := []string{}
+	for _, param := range params {
+		strs = append(strs, param.String())
+	}
+	return "|" + strings.Join(strs, ", ") + "|"
+}
@@ -0,0 +1,100 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// checkArgsSig checks whether args and kwargs match type annotations of f.
+func checkArgsSig(
+	env *object.Env,
+	f *object.PanFunc,
+	args []object.PanObject,
+	kwargs *object.PanObj,
+) *object.PanErr {
+	params := f.Args().Elems
+	for i, typ := range f.Sig.Params {
+		if typ == object.BuiltInNil {
+			continue
+		}
+
+		// NOTE: lacked args are regarded as nil
+		var arg object.PanObject = object.BuiltInNil
+		if i < len(args) {
+			arg = args[i]
+		}
+
+		name := fmt.Sprintf(`\%d`, i+1)
+		if i < len(params) {
+			if str, ok := params[i].(*object.PanStr); ok {
+				name = str.Value
+			}
+		}
+
+		if err := checkKindOf(env, arg, typ, fmt.Sprintf("param `%s`", name)); err != nil {
+			return err
+		}
+	}
+
+	for symHash, typePair := range *f.Sig.Kwargs.Pairs {
+		if typePair.Value == object.BuiltInNil {
+			continue
+		}
+
+		var kwarg object.PanObject = object.BuiltInNil
+		if pair, ok := (*kwargs.Pairs)[symHash]; ok {
+			kwarg = pair.Value
+		} else if pair, ok := (*f.Kwargs().Pairs)[symHash]; ok {
+			kwarg = pair.Value
+		}
+
+		name := typePair.Key.(*object.PanStr).Value
+		if err := checkKindOf(env, kwarg, typePair.Value, fmt.Sprintf("kwarg `%s`", name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkRetSig checks whether the returned value matches type annotation of f.
+func checkRetSig(env *object.Env, f *object.PanFunc, ret object.PanObject) *object.PanErr {
+	if f.Sig.Ret == object.BuiltInNil {
+		return nil
+	}
+	return checkKindOf(env, ret, f.Sig.Ret, "return value")
+}
+
+func checkKindOf(
+	env *object.Env,
+	o object.PanObject,
+	typ object.PanObject,
+	name string,
+) *object.PanErr {
+	if !isKindOf(env, o, typ) {
+		return object.NewTypeErr(
+			fmt.Sprintf("%s must be %s (got %s)", name, typ.Repr(), o.Repr()))
+	}
+	return nil
+}
+
+// isKindOf returns whether typ appears in o's proto chain (same as Obj#kindOf?).
+func isKindOf(env *object.Env, o object.PanObject, typ object.PanObject) bool {
+	eqSym := object.NewPanStr("==")
+
+	for ancestor := o; ancestor != nil; ancestor = ancestor.Proto() {
+		if ancestor == typ {
+			return true
+		}
+
+		// NOTE: err is ignored because it means ancestor cannot be compared with typ
+		ret := builtInCallProp(env, object.EmptyPanObjPtr(),
+			object.EmptyPanObjPtr(), ancestor, eqSym, typ)
+		if ret == object.BuiltInTrue {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,10 @@
+package example
+
+import (
+	"embed"
+)
+
+// FS exports example scripts.
+//
+//go:embed *.pangaea
+var FS embed.FS
@@ -5,6 +5,9 @@ package main
 // make parser y.go from yacc file parser.go.y
 //go:generate go run golang.org/x/tools/cmd/goyacc -o ./parser/y.go -v ./parser/y.output ./parser/parser.go.y
 
+// make docs of builtin props from comments in props/*_props.go
+//go:generate go run ./props/internal/docgen -o ./props/docs_gen.go ./props
+
 // make credits file which includes licenses of the dependent libraries
 // HACK: use bash to use redirect `>`
 //go:generate sh -c "go run github.com/Songmu/gocredits/cmd/gocredits . > CREDITS"
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Syuparn/pangaea/check"
 	"github.com/Syuparn/pangaea/envs"
+	"github.com/Syuparn/pangaea/mod"
 	"github.com/Syuparn/pangaea/object"
+	"github.com/Syuparn/pangaea/refdoc"
 	"github.com/Syuparn/pangaea/runscript"
 )
 
@@ -30,6 +33,24 @@ func main() {
 		}
 	}
 
+	// mod mode
+	if len(os.Args) >= 2 && os.Args[1] == "mod" {
+		exitCode := runMod(os.Args[2:])
+		os.Exit(exitCode)
+	}
+
+	// check mode
+	if len(os.Args) >= 2 && os.Args[1] == "check" {
+		exitCode := runCheck(os.Args[2:])
+		os.Exit(exitCode)
+	}
+
+	// doc mode
+	if len(os.Args) >= 2 && os.Args[1] == "doc" {
+		exitCode := runDoc(os.Args[2:])
+		os.Exit(exitCode)
+	}
+
 	// normal mode
 	flag.Parse()
 
@@ -70,6 +91,21 @@ func runTest(path string) int {
 	return exitCode
 }
 
+func runMod(args []string) int {
+	exitCode := mod.Run(".", args, os.Stdout, os.Stderr)
+	return exitCode
+}
+
+func runCheck(args []string) int {
+	exitCode := check.Run(args, os.Stdout, os.Stderr)
+	return exitCode
+}
+
+func runDoc(args []string) int {
+	exitCode := refdoc.Run(args, os.Stdout, os.Stderr)
+	return exitCode
+}
+
 func run(src string, fileName string) int {
 	exitCode := runscript.RunSource(src, fileName, os.Stdin, os.Stdout)
 	return exitCode
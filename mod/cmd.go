@@ -0,0 +1,116 @@
+package mod
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+const usage = `usage: pangaea mod <command> [arguments]
+
+commands:
+  init [name]                                 create pangaea.json in the current directory
+  add [-name name] [-git] [-ref ref] source   add a dependency and vendor it
+  tidy                                        vendor all dependencies and update pangaea.lock
+`
+
+// Run runs `pangaea mod` subcommand in dir and returns exit code.
+func Run(dir string, args []string, out io.Writer, errOut io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprint(errOut, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "init":
+		return runInit(dir, args[1:], out, errOut)
+	case "add":
+		return runAdd(dir, args[1:], out, errOut)
+	case "tidy":
+		return runTidy(dir, out, errOut)
+	default:
+		fmt.Fprintf(errOut, "unknown command %q\n", args[0])
+		fmt.Fprint(errOut, usage)
+		return 2
+	}
+}
+
+func runInit(dir string, args []string, out io.Writer, errOut io.Writer) int {
+	name := ""
+	if len(args) >= 1 {
+		name = args[0]
+	}
+
+	m, err := Init(dir, name)
+	if err != nil {
+		fmt.Fprintln(errOut, err.Error())
+		return 1
+	}
+
+	fmt.Fprintf(out, "created %s for %s\n", ManifestFileName, m.Name)
+	return 0
+}
+
+func runAdd(dir string, args []string, out io.Writer, errOut io.Writer) int {
+	cmdSet := flag.NewFlagSet("add", flag.ContinueOnError)
+	cmdSet.SetOutput(errOut)
+	name := cmdSet.String("name", "", "dependency name (guessed by source by default)")
+	isGit := cmdSet.Bool("git", false, "treat source as a git repository")
+	ref := cmdSet.String("ref", "", "branch, tag or commit to checkout (only with -git)")
+	if err := cmdSet.Parse(args); err != nil {
+		return 2
+	}
+
+	src := cmdSet.Arg(0)
+	if src == "" {
+		fmt.Fprintln(errOut, "source must be specified")
+		return 2
+	}
+
+	dep := Dependency{Path: src}
+	if *isGit {
+		dep = Dependency{Git: src, Ref: *ref}
+	}
+
+	root, err := FindRoot(dir)
+	if err != nil {
+		fmt.Fprintln(errOut, err.Error())
+		return 1
+	}
+
+	lock, err := Add(root, *name, dep)
+	if err != nil {
+		fmt.Fprintln(errOut, err.Error())
+		return 1
+	}
+
+	printLock(out, lock)
+	return 0
+}
+
+func runTidy(dir string, out io.Writer, errOut io.Writer) int {
+	root, err := FindRoot(dir)
+	if err != nil {
+		fmt.Fprintln(errOut, err.Error())
+		return 1
+	}
+
+	lock, err := Tidy(root)
+	if err != nil {
+		fmt.Fprintln(errOut, err.Error())
+		return 1
+	}
+
+	printLock(out, lock)
+	return 0
+}
+
+func printLock(out io.Writer, lock *Lock) {
+	for _, m := range lock.Modules {
+		if m.Version == "" {
+			fmt.Fprintf(out, "%s (%s)\n", m.Name, m.Source)
+			continue
+		}
+		fmt.Fprintf(out, "%s %s (%s)\n", m.Name, m.Version, m.Source)
+	}
+}
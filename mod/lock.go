@@ -0,0 +1,69 @@
+package mod
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// LockFileName is a file name of the lock file.
+const LockFileName = "pangaea.lock"
+
+// Lock is a list of resolved dependencies written in pangaea.lock.
+type Lock struct {
+	Modules []LockedModule `json:"modules"`
+}
+
+// LockedModule is a resolved dependency.
+type LockedModule struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Source  string `json:"source"`
+	Ref     string `json:"ref,omitempty"`
+	// Commit is a checked out commit hash (only for git sources).
+	Commit string `json:"commit,omitempty"`
+	// Checksum is a hash of all vendored files.
+	Checksum string `json:"checksum"`
+}
+
+// Find returns the locked module named name.
+func (l *Lock) Find(name string) (LockedModule, bool) {
+	for _, m := range l.Modules {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return LockedModule{}, false
+}
+
+// ReadLock reads pangaea.lock in dir.
+// If the lock file does not exist, an empty lock is returned.
+func ReadLock(dir string) (*Lock, error) {
+	b, err := os.ReadFile(filepath.Join(dir, LockFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Lock{Modules: []LockedModule{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var l Lock
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, LockFileName), err)
+	}
+
+	return &l, nil
+}
+
+// WriteLock writes l to pangaea.lock in dir.
+func WriteLock(dir string, l *Lock) error {
+	// NOTE: sort modules otherwise diffs of the lock file get noisy
+	sort.Slice(l.Modules, func(i, j int) bool {
+		return l.Modules[i].Name < l.Modules[j].Name
+	})
+	return writeJSON(filepath.Join(dir, LockFileName), l)
+}
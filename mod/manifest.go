@@ -0,0 +1,139 @@
+package mod
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ManifestFileName is a file name of the package manifest.
+	ManifestFileName = "pangaea.json"
+	// DefaultMain is an entry file of the package if manifest does not specify it.
+	DefaultMain = "main.pangaea"
+)
+
+// Manifest is a package manifest written in pangaea.json.
+type Manifest struct {
+	Name         string                `json:"name"`
+	Version      string                `json:"version"`
+	Main         string                `json:"main,omitempty"`
+	Dependencies map[string]Dependency `json:"dependencies,omitempty"`
+}
+
+// Dependency is a source of the dependent package.
+// Either Path or Git must be set.
+type Dependency struct {
+	// Path is a local directory of the package (relative to the manifest).
+	Path string `json:"path,omitempty"`
+	// Git is a repository url (or a local repository path) of the package.
+	Git string `json:"git,omitempty"`
+	// Ref is a branch, tag or commit to checkout (only for Git).
+	Ref string `json:"ref,omitempty"`
+}
+
+// Source returns a human-readable source of the dependency.
+func (d Dependency) Source() string {
+	if d.Git != "" {
+		return "git:" + d.Git
+	}
+	return "path:" + d.Path
+}
+
+func (d Dependency) validate(name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	if d.Path == "" && d.Git == "" {
+		return fmt.Errorf("dependency %q must have either path or git", name)
+	}
+	if d.Path != "" && d.Git != "" {
+		return fmt.Errorf("dependency %q cannot have both path and git", name)
+	}
+	// NOTE: otherwise they are parsed as options of git commands
+	if strings.HasPrefix(d.Git, "-") {
+		return fmt.Errorf("git of dependency %q must not start with \"-\": %s", name, d.Git)
+	}
+	if strings.HasPrefix(d.Ref, "-") {
+		return fmt.Errorf("ref of dependency %q must not start with \"-\": %s", name, d.Ref)
+	}
+	return nil
+}
+
+// validateName checks name can be used as a directory name in the vendor directory.
+// NOTE: otherwise the vendored package may overwrite (or remove!) files out of the vendor directory
+func validateName(name string) error {
+	if name == "" || name == "." || name == ".." {
+		return fmt.Errorf("invalid dependency name %q", name)
+	}
+	if filepath.IsAbs(name) || strings.ContainsAny(name, `/\`) || filepath.VolumeName(name) != "" {
+		return fmt.Errorf("dependency name %q must not be a path", name)
+	}
+	return nil
+}
+
+// EntryFile returns the file name evaluated when the package is imported.
+func (m *Manifest) EntryFile() string {
+	if m.Main == "" {
+		return DefaultMain
+	}
+	return m.Main
+}
+
+// ReadManifest reads pangaea.json in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	b, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, ManifestFileName), err)
+	}
+
+	for name, dep := range m.Dependencies {
+		if err := dep.validate(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &m, nil
+}
+
+// WriteManifest writes m to pangaea.json in dir.
+func WriteManifest(dir string, m *Manifest) error {
+	return writeJSON(filepath.Join(dir, ManifestFileName), m)
+}
+
+// FindRoot returns the nearest ancestor directory of dir (including dir itself)
+// which contains pangaea.json.
+func FindRoot(dir string) (string, error) {
+	d, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(d, ManifestFileName)); err == nil {
+			return d, nil
+		}
+
+		parent := filepath.Dir(d)
+		if parent == d {
+			return "", fmt.Errorf("%s not found in %s or any parent directory", ManifestFileName, dir)
+		}
+		d = parent
+	}
+}
+
+func writeJSON(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0644)
+}
@@ -0,0 +1,210 @@
+// Package mod manages third-party Pangaea packages.
+// Dependencies declared in pangaea.json are vendored into pangaea_modules
+// and their resolved sources are recorded in pangaea.lock.
+package mod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultVersion is a version of the package created by Init.
+const DefaultVersion = "0.1.0"
+
+// Init creates new pangaea.json in dir.
+// If name is empty, the directory name is used instead.
+func Init(dir string, name string) (*Manifest, error) {
+	if _, err := os.Stat(filepath.Join(dir, ManifestFileName)); err == nil {
+		return nil, fmt.Errorf("%s already exists", filepath.Join(dir, ManifestFileName))
+	}
+
+	if name == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.Base(abs)
+	}
+
+	m := &Manifest{
+		Name:         name,
+		Version:      DefaultVersion,
+		Dependencies: map[string]Dependency{},
+	}
+
+	if err := WriteManifest(dir, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Add adds dependency dep named name to the manifest in root and vendors all dependencies.
+// If name is empty, it is guessed by the source.
+func Add(root string, name string, dep Dependency) (*Lock, error) {
+	m, err := ReadManifest(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = guessName(dep)
+	}
+	if err := dep.validate(name); err != nil {
+		return nil, err
+	}
+
+	if m.Dependencies == nil {
+		m.Dependencies = map[string]Dependency{}
+	}
+	m.Dependencies[name] = dep
+
+	if err := WriteManifest(root, m); err != nil {
+		return nil, err
+	}
+
+	return Tidy(root)
+}
+
+func guessName(dep Dependency) string {
+	src := dep.Path
+	if dep.Git != "" {
+		src = dep.Git
+	}
+	// NOTE: handle both url and local path
+	src = strings.TrimRight(filepath.ToSlash(src), "/")
+	return strings.TrimSuffix(src[strings.LastIndex(src, "/")+1:], ".git")
+}
+
+// Tidy vendors all dependencies (including transitive ones) declared in the manifest in root,
+// removes unused vendored packages and updates the lock file.
+// Git dependencies already locked are checked out to the locked commits.
+func Tidy(root string) (*Lock, error) {
+	m, err := ReadManifest(root)
+	if err != nil {
+		return nil, err
+	}
+
+	oldLock, err := ReadLock(root)
+	if err != nil {
+		return nil, err
+	}
+
+	vendor := filepath.Join(root, VendorDir)
+	if err := os.MkdirAll(vendor, 0755); err != nil {
+		return nil, err
+	}
+
+	type request struct {
+		name    string
+		dep     Dependency
+		baseDir string
+		from    string
+	}
+
+	queue := []request{}
+	for _, name := range sortedNames(m.Dependencies) {
+		queue = append(queue, request{name, m.Dependencies[name], root, m.Name})
+	}
+
+	resolved := map[string]Dependency{}
+	newLock := &Lock{Modules: []LockedModule{}}
+
+	for len(queue) > 0 {
+		req := queue[0]
+		queue = queue[1:]
+
+		if prev, ok := resolved[req.name]; ok {
+			if prev.Source() != req.dep.Source() || prev.Ref != req.dep.Ref {
+				return nil, fmt.Errorf("conflicting dependency %q: %s (required by %s) and %s",
+					req.name, req.dep.Source(), req.from, prev.Source())
+			}
+			continue
+		}
+		resolved[req.name] = req.dep
+
+		commit := ""
+		lockedSum := ""
+		if locked, ok := oldLock.Find(req.name); ok && locked.Source == req.dep.Source() && locked.Ref == req.dep.Ref {
+			commit = locked.Commit
+			lockedSum = locked.Checksum
+		}
+
+		f, err := fetch(req.dep, req.baseDir, vendor, req.name, commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %q: %w", req.name, err)
+		}
+
+		sum, err := checksum(f.dir)
+		if err != nil {
+			return nil, err
+		}
+		// NOTE: files of the locked commit must not be changed
+		// (path dependencies are not verified because they are edited locally)
+		if commit != "" && lockedSum != "" && sum != lockedSum {
+			return nil, fmt.Errorf("checksum of %q does not match the lock file: expected %s, got %s",
+				req.name, lockedSum, sum)
+		}
+
+		locked := LockedModule{
+			Name:     req.name,
+			Source:   req.dep.Source(),
+			Ref:      req.dep.Ref,
+			Commit:   f.commit,
+			Checksum: sum,
+		}
+
+		// resolve transitive dependencies
+		depManifest, err := ReadManifest(f.dir)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if depManifest != nil {
+			locked.Version = depManifest.Version
+			for _, name := range sortedNames(depManifest.Dependencies) {
+				queue = append(queue, request{name, depManifest.Dependencies[name], f.srcDir, req.name})
+			}
+		}
+
+		newLock.Modules = append(newLock.Modules, locked)
+	}
+
+	if err := removeUnused(vendor, resolved); err != nil {
+		return nil, err
+	}
+
+	if err := WriteLock(root, newLock); err != nil {
+		return nil, err
+	}
+	return newLock, nil
+}
+
+func sortedNames(deps map[string]Dependency) []string {
+	names := []string{}
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func removeUnused(vendor string, resolved map[string]Dependency) error {
+	entries, err := os.ReadDir(vendor)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if _, ok := resolved[e.Name()]; ok {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(vendor, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,409 @@
+package mod
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestInit(t *testing.T) {
+	dir := t.TempDir()
+
+	m, err := Init(dir, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &Manifest{Name: filepath.Base(dir), Version: DefaultVersion, Dependencies: map[string]Dependency{}}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("wrong manifest: expected=%+v, got=%+v", expected, m)
+	}
+
+	read, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if read.Name != expected.Name || read.Version != expected.Version {
+		t.Errorf("wrong manifest file: expected=%+v, got=%+v", expected, read)
+	}
+
+	if _, err := Init(dir, "foo"); err == nil {
+		t.Errorf("error must be raised if manifest already exists")
+	}
+}
+
+func TestAddPath(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/pangaea.json":       `{"name": "app", "version": "1.0.0"}`,
+		"libs/foo/pangaea.json":  `{"name": "foo", "version": "0.2.0", "dependencies": {"bar": {"path": "../bar"}}}`,
+		"libs/foo/main.pangaea":  `greet := {"foo"}`,
+		"libs/bar/bar.pangaea":   `x := 1`,
+		"libs/bar/.git/HEAD":     `ignored`,
+		"libs/bar/sub/a.pangaea": `a := 1`,
+	})
+	app := filepath.Join(root, "app")
+
+	lock, err := Add(app, "", Dependency{Path: "../libs/foo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(lock.Modules) != 2 {
+		t.Fatalf("2 modules must be locked. got=%+v", lock.Modules)
+	}
+	if lock.Modules[0].Name != "bar" || lock.Modules[0].Source != "path:../bar" {
+		t.Errorf("wrong locked module: %+v", lock.Modules[0])
+	}
+	if lock.Modules[1].Name != "foo" || lock.Modules[1].Version != "0.2.0" {
+		t.Errorf("wrong locked module: %+v", lock.Modules[1])
+	}
+
+	// transitive dependencies are vendored flatly
+	for _, f := range []string{"foo/main.pangaea", "bar/bar.pangaea", "bar/sub/a.pangaea"} {
+		if !isFile(filepath.Join(app, VendorDir, filepath.FromSlash(f))) {
+			t.Errorf("%s must be vendored", f)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(app, VendorDir, "bar", ".git")); err == nil {
+		t.Errorf(".git must not be vendored")
+	}
+
+	m, err := ReadManifest(app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Dependencies["foo"] != (Dependency{Path: "../libs/foo"}) {
+		t.Errorf("dependency must be added to manifest. got=%+v", m.Dependencies)
+	}
+
+	read, err := ReadLock(app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(read, lock) {
+		t.Errorf("wrong lock file: expected=%+v, got=%+v", lock, read)
+	}
+}
+
+func TestTidyRemovesUnused(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/pangaea.json":                     `{"name": "app", "version": "1.0.0", "dependencies": {"foo": {"path": "../foo"}}}`,
+		"app/pangaea_modules/old/main.pangaea": `a := 1`,
+		"foo/main.pangaea":                     `a := 1`,
+	})
+	app := filepath.Join(root, "app")
+
+	if _, err := Tidy(app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(app, VendorDir, "old")); err == nil {
+		t.Errorf("unused module must be removed")
+	}
+	if !isFile(filepath.Join(app, VendorDir, "foo", "main.pangaea")) {
+		t.Errorf("foo must be vendored")
+	}
+}
+
+func TestTidyChecksum(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/pangaea.json": `{"name": "app", "version": "1.0.0", "dependencies": {"foo": {"path": "../foo"}}}`,
+		"foo/main.pangaea": `a := 1`,
+	})
+	app := filepath.Join(root, "app")
+
+	first, err := Tidy(app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := Tidy(app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Modules[0].Checksum != second.Modules[0].Checksum {
+		t.Errorf("checksum must be stable: %s != %s", first.Modules[0].Checksum, second.Modules[0].Checksum)
+	}
+
+	writeFiles(t, root, map[string]string{"foo/main.pangaea": `a := 2`})
+	third, err := Tidy(app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Modules[0].Checksum == third.Modules[0].Checksum {
+		t.Errorf("checksum must be changed")
+	}
+}
+
+func TestTidyConflict(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/pangaea.json":  `{"name": "app", "version": "1.0.0", "dependencies": {"foo": {"path": "../foo"}, "bar": {"path": "../bar1"}}}`,
+		"foo/pangaea.json":  `{"name": "foo", "version": "1.0.0", "dependencies": {"bar": {"path": "../bar2"}}}`,
+		"foo/main.pangaea":  `a := 1`,
+		"bar1/main.pangaea": `a := 1`,
+		"bar2/main.pangaea": `a := 2`,
+	})
+
+	_, err := Tidy(filepath.Join(root, "app"))
+	if err == nil {
+		t.Fatalf("error must be raised")
+	}
+}
+
+func TestTidyGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/pangaea.json":  `{"name": "app", "version": "1.0.0"}`,
+		"repo/main.pangaea": `v := 1`,
+	})
+	repo := filepath.Join(root, "repo")
+	runGit(t, repo, "init", "--quiet")
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "--quiet", "-m", "first")
+	runGit(t, repo, "tag", "v1")
+	first := runGit(t, repo, "rev-parse", "HEAD")
+	writeFiles(t, root, map[string]string{"repo/main.pangaea": `v := 2`})
+	runGit(t, repo, "commit", "--quiet", "-am", "second")
+
+	app := filepath.Join(root, "app")
+	lock, err := Add(app, "foo", Dependency{Git: "../repo", Ref: "v1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if lock.Modules[0].Commit != first {
+		t.Errorf("wrong commit: expected=%s, got=%s", first, lock.Modules[0].Commit)
+	}
+	b, _ := os.ReadFile(filepath.Join(app, VendorDir, "foo", "main.pangaea"))
+	if string(b) != `v := 1` {
+		t.Errorf("wrong vendored file: %s", string(b))
+	}
+
+	// locked commit is used even if the tag is moved
+	runGit(t, repo, "tag", "-f", "v1")
+	lock, err = Tidy(app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lock.Modules[0].Commit != first {
+		t.Errorf("locked commit must be used: expected=%s, got=%s", first, lock.Modules[0].Commit)
+	}
+}
+
+func TestTidyGitChecksumMismatch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/pangaea.json":  `{"name": "app", "version": "1.0.0"}`,
+		"repo/main.pangaea": `v := 1`,
+	})
+	repo := filepath.Join(root, "repo")
+	runGit(t, repo, "init", "--quiet")
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "--quiet", "-m", "first")
+
+	app := filepath.Join(root, "app")
+	lock, err := Add(app, "foo", Dependency{Git: "../repo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lock.Modules[0].Checksum = "sha256:0000"
+	if err := WriteLock(app, lock); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Tidy(app); err == nil {
+		t.Errorf("error must be raised if the checksum does not match")
+	}
+}
+
+func TestTidyGitOptionLikeArgs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	tests := []struct {
+		manifest string
+		lock     string
+	}{
+		{
+			`{"name": "app", "version": "1.0.0", "dependencies": {"foo": {"git": "--upload-pack=touch pwned"}}}`,
+			``,
+		},
+		{
+			`{"name": "app", "version": "1.0.0", "dependencies": {"foo": {"git": "../repo", "ref": "--orphan=pwned"}}}`,
+			``,
+		},
+		{
+			`{"name": "app", "version": "1.0.0", "dependencies": {"foo": {"git": "../repo"}}}`,
+			`{"modules": [{"name": "foo", "source": "git:../repo", "commit": "--orphan=pwned", "checksum": ""}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		root := t.TempDir()
+		files := map[string]string{
+			"app/pangaea.json":  tt.manifest,
+			"repo/main.pangaea": `v := 1`,
+		}
+		if tt.lock != "" {
+			files["app/"+LockFileName] = tt.lock
+		}
+		writeFiles(t, root, files)
+		repo := filepath.Join(root, "repo")
+		runGit(t, repo, "init", "--quiet")
+		runGit(t, repo, "add", "-A")
+		runGit(t, repo, "commit", "--quiet", "-m", "first")
+
+		if _, err := Tidy(filepath.Join(root, "app")); err == nil {
+			t.Errorf("error must be raised (manifest=%s, lock=%s)", tt.manifest, tt.lock)
+		}
+		if isFile(filepath.Join(root, "app", "pwned")) {
+			t.Errorf("option must not be run (manifest=%s, lock=%s)", tt.manifest, tt.lock)
+		}
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	out, err := git(dir, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestAddInvalidName(t *testing.T) {
+	tests := []struct {
+		name string
+		dep  Dependency
+	}{
+		// guessed name is ".."
+		{"", Dependency{Path: ".."}},
+		{"", Dependency{Path: "."}},
+		{"..", Dependency{Path: "../foo"}},
+		{"a/b", Dependency{Path: "../foo"}},
+		{"/tmp/foo", Dependency{Path: "../foo"}},
+	}
+
+	for _, tt := range tests {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"app/pangaea.json":  `{"name": "app", "version": "1.0.0"}`,
+			"app/keep.pangaea":  `a := 1`,
+			"app/foo/a.pangaea": `a := 1`,
+		})
+		app := filepath.Join(root, "app")
+
+		if _, err := Add(app, tt.name, tt.dep); err == nil {
+			t.Errorf("error must be raised (name=%q, dep=%+v)", tt.name, tt.dep)
+		}
+
+		// files out of the vendor directory must not be removed
+		for _, f := range []string{"pangaea.json", "keep.pangaea"} {
+			if !isFile(filepath.Join(app, f)) {
+				t.Errorf("%s must not be removed (name=%q, dep=%+v)", f, tt.name, tt.dep)
+			}
+		}
+	}
+}
+
+func TestTidyInvalidTransitiveName(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/pangaea.json": `{"name": "app", "version": "1.0.0", "dependencies": {"foo": {"path": "../foo"}}}`,
+		"foo/pangaea.json": `{"name": "foo", "version": "1.0.0", "dependencies": {"..": {"path": "../bar"}}}`,
+		"bar/main.pangaea": `a := 1`,
+	})
+	app := filepath.Join(root, "app")
+
+	if _, err := Tidy(app); err == nil {
+		t.Errorf("error must be raised")
+	}
+	if !isFile(filepath.Join(app, ManifestFileName)) {
+		t.Errorf("manifest must not be removed")
+	}
+}
+
+func TestVendoredDir(t *testing.T) {
+	vendor := filepath.Join("root", VendorDir)
+
+	if dir, err := vendoredDir(vendor, "foo"); err != nil || dir != filepath.Join(vendor, "foo") {
+		t.Errorf("wrong dir: %s (err=%v)", dir, err)
+	}
+
+	for _, name := range []string{"", ".", "..", "../foo", "foo/../.."} {
+		if dir, err := vendoredDir(vendor, name); err == nil {
+			t.Errorf("error must be raised (name=%q, dir=%s)", name, dir)
+		}
+	}
+}
+
+func TestGuessName(t *testing.T) {
+	tests := []struct {
+		dep      Dependency
+		expected string
+	}{
+		{Dependency{Path: "../libs/foo"}, "foo"},
+		{Dependency{Path: "../libs/foo/"}, "foo"},
+		{Dependency{Git: "https://example.com/user/bar.git"}, "bar"},
+		{Dependency{Git: "../repos/baz"}, "baz"},
+	}
+
+	for _, tt := range tests {
+		actual := guessName(tt.dep)
+		if actual != tt.expected {
+			t.Errorf("wrong name: expected=%s, got=%s", tt.expected, actual)
+		}
+	}
+}
+
+func TestReadManifestError(t *testing.T) {
+	tests := []struct {
+		content string
+	}{
+		{`{"name": `},
+		{`{"name": "a", "dependencies": {"foo": {}}}`},
+		{`{"name": "a", "dependencies": {"foo": {"path": "a", "git": "b"}}}`},
+		{`{"name": "a", "dependencies": {"..": {"path": "a"}}}`},
+		{`{"name": "a", "dependencies": {"../foo": {"path": "a"}}}`},
+		{`{"name": "a", "dependencies": {"/tmp/foo": {"path": "a"}}}`},
+		{`{"name": "a", "dependencies": {"foo": {"git": "-a"}}}`},
+		{`{"name": "a", "dependencies": {"foo": {"git": "a", "ref": "-b"}}}`},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{ManifestFileName: tt.content})
+		if _, err := ReadManifest(dir); err == nil {
+			t.Errorf("error must be raised (content=%s)", tt.content)
+		}
+	}
+}
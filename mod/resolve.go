@@ -0,0 +1,81 @@
+package mod
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Resolve finds the source file of third-party package importPath (like "foo" or "foo/bar").
+// Packages are searched in the following order:
+//  1. pangaea_modules in fromDir and its ancestors (nearest first)
+//  2. each directory in searchPaths
+func Resolve(importPath string, fromDir string, searchPaths []string) (string, bool) {
+	for _, dir := range vendorDirs(fromDir) {
+		if p, ok := resolveIn(dir, importPath); ok {
+			return p, true
+		}
+	}
+
+	for _, dir := range searchPaths {
+		if dir == "" {
+			continue
+		}
+		if p, ok := resolveIn(dir, importPath); ok {
+			return p, true
+		}
+	}
+
+	return "", false
+}
+
+func vendorDirs(fromDir string) []string {
+	if fromDir == "" {
+		return []string{}
+	}
+
+	d, err := filepath.Abs(fromDir)
+	if err != nil {
+		return []string{}
+	}
+
+	dirs := []string{}
+	for {
+		dirs = append(dirs, filepath.Join(d, VendorDir))
+
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dirs
+		}
+		d = parent
+	}
+}
+
+func resolveIn(dir string, importPath string) (string, bool) {
+	p := filepath.Join(dir, filepath.FromSlash(importPath))
+
+	// file module like `foo.pangaea`
+	if isFile(p + ".pangaea") {
+		p, _ = filepath.Abs(p + ".pangaea")
+		return p, true
+	}
+
+	// package directory like `foo/main.pangaea`
+	if info, err := os.Stat(p); err == nil && info.IsDir() {
+		entry := DefaultMain
+		if m, err := ReadManifest(p); err == nil {
+			entry = m.EntryFile()
+		}
+
+		if isFile(filepath.Join(p, entry)) {
+			p, _ = filepath.Abs(filepath.Join(p, entry))
+			return p, true
+		}
+	}
+
+	return "", false
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
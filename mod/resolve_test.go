@@ -0,0 +1,62 @@
+package mod
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/pangaea_modules/foo/main.pangaea":    `a := 1`,
+		"app/pangaea_modules/foo/util.pangaea":    `a := 1`,
+		"app/pangaea_modules/bar/pangaea.json":    `{"name": "bar", "version": "1.0.0", "main": "src/bar.pangaea"}`,
+		"app/pangaea_modules/bar/src/bar.pangaea": `a := 1`,
+		"app/src/main.pangaea":                    `a := 1`,
+		"path1/baz.pangaea":                       `a := 1`,
+		"path2/baz.pangaea":                       `a := 1`,
+		"path2/foo/main.pangaea":                  `a := 1`,
+	})
+	from := filepath.Join(root, "app", "src")
+	searchPaths := []string{filepath.Join(root, "path1"), "", filepath.Join(root, "path2")}
+
+	tests := []struct {
+		importPath string
+		expected   string
+	}{
+		// vendored modules in ancestors are found
+		{"foo", "app/pangaea_modules/foo/main.pangaea"},
+		{"foo/util", "app/pangaea_modules/foo/util.pangaea"},
+		// entry file in manifest
+		{"bar", "app/pangaea_modules/bar/src/bar.pangaea"},
+		// the first path in searchPaths takes precedence
+		{"baz", "path1/baz.pangaea"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.importPath, func(t *testing.T) {
+			actual, ok := Resolve(tt.importPath, from, searchPaths)
+			if !ok {
+				t.Fatalf("%s must be resolved", tt.importPath)
+			}
+			expected := filepath.Join(root, filepath.FromSlash(tt.expected))
+			if actual != expected {
+				t.Errorf("wrong path: expected=%s, got=%s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestResolveNotFound(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		// directory without entry file
+		"pangaea_modules/foo/util.pangaea": `a := 1`,
+	})
+
+	for _, importPath := range []string{"foo", "notfound", "foo/notfound"} {
+		if p, ok := Resolve(importPath, root, []string{}); ok {
+			t.Errorf("%s must not be resolved. got=%s", importPath, p)
+		}
+	}
+}
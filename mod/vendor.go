@@ -0,0 +1,227 @@
+package mod
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// VendorDir is a directory name where dependencies are vendored.
+const VendorDir = "pangaea_modules"
+
+// fetched is a dependency copied into the vendor directory.
+type fetched struct {
+	dir    string
+	commit string
+	// srcDir is a directory where relative paths in the dependency's manifest are based on
+	srcDir string
+}
+
+// fetch copies dep into the directory name in vendor. baseDir is used to resolve relative paths in dep.
+// If commit is not empty, the git dependency is checked out to the commit instead of dep.Ref.
+func fetch(dep Dependency, baseDir string, vendor string, name string, commit string) (*fetched, error) {
+	dst, err := vendoredDir(vendor, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.RemoveAll(dst); err != nil {
+		return nil, err
+	}
+
+	if dep.Git != "" {
+		return fetchGit(dep, baseDir, dst, commit)
+	}
+	return fetchPath(dep, baseDir, dst)
+}
+
+// vendoredDir returns the directory name in vendor.
+// It raises an error if the directory is not inside vendor.
+func vendoredDir(vendor string, name string) (string, error) {
+	dst := filepath.Join(vendor, name)
+	rel, err := filepath.Rel(vendor, dst)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return "", fmt.Errorf("%s is not inside %s", dst, vendor)
+	}
+	return dst, nil
+}
+
+func fetchPath(dep Dependency, baseDir string, dst string) (*fetched, error) {
+	src := dep.Path
+	if !filepath.IsAbs(src) {
+		src = filepath.Join(baseDir, src)
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s: %w", dep.Source(), err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dep.Source())
+	}
+
+	if err := copyDir(src, dst); err != nil {
+		return nil, err
+	}
+
+	return &fetched{dir: dst, srcDir: src}, nil
+}
+
+func fetchGit(dep Dependency, baseDir string, dst string, commit string) (*fetched, error) {
+	url := dep.Git
+	// NOTE: local repository path is relative to the manifest
+	if isLocalPath(url) && !filepath.IsAbs(url) {
+		url = filepath.Join(baseDir, url)
+	}
+
+	tmp, err := os.MkdirTemp("", "pangaea-mod-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	// NOTE: `--` prevents url and ref from being parsed as options
+	if _, err := git("", "clone", "--quiet", "--", url, tmp); err != nil {
+		return nil, err
+	}
+
+	ref := dep.Ref
+	if commit != "" {
+		ref = commit
+	}
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("ref must not start with \"-\": %s", ref)
+	}
+	if ref != "" {
+		if _, err := git(tmp, "checkout", "--quiet", ref, "--"); err != nil {
+			return nil, err
+		}
+	}
+
+	head, err := git(tmp, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := copyDir(tmp, dst); err != nil {
+		return nil, err
+	}
+
+	return &fetched{dir: dst, commit: head, srcDir: dst}, nil
+}
+
+func isLocalPath(url string) bool {
+	return !strings.Contains(url, "://") && !strings.Contains(url, "@")
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s",
+			strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ignoredEntry reports whether the file should not be vendored.
+func ignoredEntry(name string) bool {
+	return name == ".git" || name == VendorDir
+}
+
+func copyDir(src string, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		if rel != "." && ignoredEntry(info.Name()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// checksum calculates a hash of all files in dir.
+// The hash does not depend on file modes or timestamps.
+func checksum(dir string) (string, error) {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, f := range files {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f)))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", f, len(b))
+		h.Write(b)
+	}
+
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
@@ -9,6 +9,8 @@ const BuiltInType = "BuiltInType"
 // PanBuiltIn is object of built-in func literal.
 type PanBuiltIn struct {
 	Fn BuiltInFunc
+	// Doc is the document of the func (written as comments in props/*_props.go)
+	Doc string
 }
 
 // Type returns type of this PanObject.
@@ -38,5 +40,5 @@ func (b *PanBuiltIn) Zero() PanObject {
 
 // NewPanBuiltInFunc returns new BuiltInFunc object.
 func NewPanBuiltInFunc(f BuiltInFunc) *PanBuiltIn {
-	return &PanBuiltIn{f}
+	return &PanBuiltIn{Fn: f}
 }
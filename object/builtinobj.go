@@ -11,6 +11,7 @@ func init() {
 	//    because other objects may refer the pointer
 	*BuiltInArrObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj, WithZero(zeroArr))
 	*BuiltInBaseObj = *NewPanObj(&map[SymHash]Pair{}, nil)
+	*BuiltInBytesObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj, WithZero(zeroBytes))
 	*BuiltInComparableObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj)
 	*BuiltInDiamondObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj)
 	*BuiltInEitherObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj)
@@ -30,14 +31,21 @@ func init() {
 	*BuiltInNumObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj)
 	*BuiltInObjObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInBaseObj)
 	*BuiltInRangeObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj, WithZero(zeroRange))
+	*BuiltInRefObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj)
+	*BuiltInSetObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj, WithZero(zeroSet))
 	*BuiltInStrObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj, WithZero(zeroStr))
 	*BuiltInWrappableObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj)
 
 	*BuiltInErrObj = *NewPanObj(&map[SymHash]Pair{}, BuiltInObjObj)
 	*BuiltInAssertionErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
+	*BuiltInImportErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
+	*BuiltInLimitErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
+	*BuiltInMatchErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
 	*BuiltInNameErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
 	*BuiltInNoPropErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
 	*BuiltInNotImplementedErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
+	*BuiltInPermissionErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
+	*BuiltInRecursionErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
 	*BuiltInStopIterErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
 	*BuiltInSyntaxErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
 	*BuiltInTypeErr = *NewPanObj(&map[SymHash]Pair{}, BuiltInErrObj)
@@ -50,6 +58,9 @@ func init() {
 // zeroArr is a zero value of Arr []
 var zeroArr = NewPanArr()
 
+// zeroBytes is a zero value of Bytes Bytes.new([])
+var zeroBytes = NewPanBytes([]byte{})
+
 // zeroFloat is a zero value of Float 0.0
 var zeroFloat = NewPanFloat(0.0)
 
@@ -69,6 +80,9 @@ var zeroObj = &PanObj{
 // zeroRange is a zero value of Range (nil:nil:nil)
 var zeroRange = NewPanRange(BuiltInNil, BuiltInNil, BuiltInNil)
 
+// zeroSet is a zero value of Set Set.new([])
+var zeroSet = NewPanSet()
+
 // zeroStr is a zero value of Str ""
 var zeroStr = NewPanStr("")
 
@@ -133,6 +147,15 @@ var BuiltInBaseObj = &PanObj{}
 // BuiltInMapObj is an object of Map (proto of each map).
 var BuiltInMapObj = &PanObj{}
 
+// BuiltInSetObj is an object of Set (proto of each set).
+var BuiltInSetObj = &PanObj{}
+
+// BuiltInBytesObj is an object of Bytes (proto of each bytes).
+var BuiltInBytesObj = &PanObj{}
+
+// BuiltInRefObj is an object of Ref (proto of each ref).
+var BuiltInRefObj = &PanObj{}
+
 // BuiltInIOObj is an object of IO (proto of each io).
 var BuiltInIOObj = &PanObj{}
 
@@ -172,6 +195,15 @@ var BuiltInAssertionErr = &PanObj{}
 // BuiltInFileNotFoundErr is an object of FileNotFoundErr (proto of each fileNotFoundErr).
 var BuiltInFileNotFoundErr = &PanObj{}
 
+// BuiltInImportErr is an object of ImportErr (proto of each importErr).
+var BuiltInImportErr = &PanObj{}
+
+// BuiltInLimitErr is an object of LimitErr (proto of each limitErr).
+var BuiltInLimitErr = &PanObj{}
+
+// BuiltInMatchErr is an object of MatchErr (proto of each matchErr).
+var BuiltInMatchErr = &PanObj{}
+
 // BuiltInNameErr is an object of NameErr (proto of each nameErr).
 var BuiltInNameErr = &PanObj{}
 
@@ -181,6 +213,12 @@ var BuiltInNoPropErr = &PanObj{}
 // BuiltInNotImplementedErr is an object of NotImplemented (proto of each notImplementdErr).
 var BuiltInNotImplementedErr = &PanObj{}
 
+// BuiltInPermissionErr is an object of PermissionErr (proto of each permissionErr).
+var BuiltInPermissionErr = &PanObj{}
+
+// BuiltInRecursionErr is an object of RecursionErr (proto of each recursionErr).
+var BuiltInRecursionErr = &PanObj{}
+
 // BuiltInStopIterErr is an object of StopIterErr (proto of each stopIterErr).
 var BuiltInStopIterErr = &PanObj{}
 
@@ -25,6 +25,9 @@ func TestBuiltInObjZeroValue(t *testing.T) {
 		{"BuiltInObjObj", BuiltInObjObj, zeroObj},
 		{"BuiltInBaseObj", BuiltInBaseObj, zeroObj},
 		{"BuiltInMapObj", BuiltInMapObj, zeroMap},
+		{"BuiltInSetObj", BuiltInSetObj, zeroSet},
+		{"BuiltInRefObj", BuiltInRefObj, zeroObj},
+		{"BuiltInBytesObj", BuiltInBytesObj, zeroBytes},
 		{"BuiltInDiamondObj", BuiltInDiamondObj, zeroObj},
 		{"BuiltInKernelObj", BuiltInKernelObj, zeroObj},
 		{"BuiltInJSONObj", BuiltInJSONObj, zeroObj},
@@ -0,0 +1,73 @@
+package object
+
+import (
+	"bytes"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// BytesType is a type of PanBytes.
+const BytesType = "BytesType"
+
+// PanBytes is object of immutable byte sequence.
+type PanBytes struct {
+	Value []byte
+	proto PanObject
+}
+
+// Type returns type of this PanObject.
+func (b *PanBytes) Type() PanObjType {
+	return BytesType
+}
+
+// Inspect returns formatted source code of this object.
+func (b *PanBytes) Inspect() string {
+	return bytesString(b.Value)
+}
+
+// Repr returns pritty-printed string of this object.
+func (b *PanBytes) Repr() string {
+	return b.Inspect()
+}
+
+func bytesString(value []byte) string {
+	elems := make([]string, 0, len(value))
+	for _, c := range value {
+		elems = append(elems, strconv.Itoa(int(c)))
+	}
+
+	var out bytes.Buffer
+	out.WriteString("Bytes.new([")
+	out.WriteString(strings.Join(elems, ", "))
+	out.WriteString("])")
+	return out.String()
+}
+
+// Proto returns proto of this object.
+func (b *PanBytes) Proto() PanObject {
+	return b.proto
+}
+
+// Zero returns zero value of this object.
+func (b *PanBytes) Zero() PanObject {
+	return b
+}
+
+// Hash returns hashkey of this object.
+func (b *PanBytes) Hash() HashKey {
+	h := fnv.New64a()
+	h.Write(b.Value)
+	return HashKey{BytesType, h.Sum64()}
+}
+
+// NewPanBytes returns new bytes object.
+// NOTE: value must not be modified after that because bytes are immutable
+func NewPanBytes(value []byte) *PanBytes {
+	return NewInheritedBytes(BuiltInBytesObj, value)
+}
+
+// NewInheritedBytes returns new bytes object born of proto.
+func NewInheritedBytes(proto PanObject, value []byte) *PanBytes {
+	return &PanBytes{Value: value, proto: proto}
+}
@@ -0,0 +1,113 @@
+package object
+
+import (
+	"testing"
+)
+
+func TestBytesType(t *testing.T) {
+	obj := NewPanBytes([]byte{})
+	if obj.Type() != BytesType {
+		t.Fatalf("wrong type: expected=%s, got=%s", BytesType, obj.Type())
+	}
+}
+
+func TestBytesInspect(t *testing.T) {
+	tests := []struct {
+		obj      *PanBytes
+		expected string
+	}{
+		{
+			NewPanBytes([]byte{}),
+			`Bytes.new([])`,
+		},
+		{
+			NewPanBytes([]byte{0, 1, 255}),
+			`Bytes.new([0, 1, 255])`,
+		},
+		{
+			NewPanBytes([]byte("ab")),
+			`Bytes.new([97, 98])`,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.obj.Inspect() != tt.expected {
+			t.Errorf("wrong output: expected=%s, got=%s",
+				tt.expected, tt.obj.Inspect())
+		}
+	}
+}
+
+func TestBytesRepr(t *testing.T) {
+	tests := []struct {
+		obj      *PanBytes
+		expected string
+	}{
+		{
+			NewPanBytes([]byte{10, 20}),
+			`Bytes.new([10, 20])`,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.obj.Repr() != tt.expected {
+			t.Errorf("wrong output: expected=%s, got=%s",
+				tt.expected, tt.obj.Repr())
+		}
+	}
+}
+
+func TestBytesProto(t *testing.T) {
+	b := NewPanBytes([]byte{})
+	if b.Proto() != BuiltInBytesObj {
+		t.Fatalf("Proto is not BuiltInBytesObj. got=%T (%+v)",
+			b.Proto(), b.Proto())
+	}
+}
+
+func TestInheritedBytesProto(t *testing.T) {
+	bytesChild := ChildPanObjPtr(BuiltInBytesObj, EmptyPanObjPtr())
+	o := NewInheritedBytes(bytesChild, []byte{1})
+	if o.Proto() != bytesChild {
+		t.Fatalf("Proto is not bytesChild. got=%T (%s)",
+			o.Proto(), o.Proto().Inspect())
+	}
+}
+
+func TestBytesHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		b1       *PanBytes
+		b2       *PanBytes
+		expected bool
+	}{
+		{"same values", NewPanBytes([]byte{1, 2}), NewPanBytes([]byte{1, 2}), true},
+		{"different values", NewPanBytes([]byte{1, 2}), NewPanBytes([]byte{2, 1}), false},
+		{"empty", NewPanBytes([]byte{}), NewPanBytes(nil), true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			h1, h2 := tt.b1.Hash(), tt.b2.Hash()
+
+			if h1.Type != BytesType {
+				t.Fatalf("hash type must be BytesType. got=%s", h1.Type)
+			}
+
+			if (h1 == h2) != tt.expected {
+				t.Errorf("wrong result: expected=%t, got=%t", tt.expected, h1 == h2)
+			}
+		})
+	}
+}
+
+// checked by compiler (this function works nothing)
+func testBytesIsPanObject() {
+	var _ PanObject = NewPanBytes([]byte{})
+}
+
+func testBytesIsPanScalar() {
+	var _ PanScalar = NewPanBytes([]byte{})
+}
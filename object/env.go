@@ -7,20 +7,23 @@ package object
 
 import (
 	"io"
+	"io/fs"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
 // NewEnv makes new environment of variables.
 func NewEnv() *Env {
 	s := make(map[SymHash]PanObject)
-	return &Env{s, nil}
+	return &Env{Store: s, modules: NewModuleCache()}
 }
 
 // NewEnclosedEnv makes new environment of variables inside e.
 // It is used to make closure.
 func NewEnclosedEnv(e *Env) *Env {
 	s := make(map[SymHash]PanObject)
-	return &Env{s, e}
+	return &Env{Store: s, outer: e, sandbox: e.Sandbox()}
 }
 
 // NewEnvWithConsts makes new global environment, which includes all standart objects.
@@ -42,6 +45,9 @@ func NewEnvWithConsts() *Env {
 	env.Set(GetSymHash("Obj"), BuiltInObjObj)
 	env.Set(GetSymHash("BaseObj"), BuiltInBaseObj)
 	env.Set(GetSymHash("Map"), BuiltInMapObj)
+	env.Set(GetSymHash("Set"), BuiltInSetObj)
+	env.Set(GetSymHash("Ref"), BuiltInRefObj)
+	env.Set(GetSymHash("Bytes"), BuiltInBytesObj)
 	env.Set(GetSymHash("Diamond"), BuiltInDiamondObj)
 	env.Set(GetSymHash("Kernel"), BuiltInKernelObj)
 	env.Set(GetSymHash("JSON"), BuiltInJSONObj)
@@ -54,9 +60,14 @@ func NewEnvWithConsts() *Env {
 	env.Set(GetSymHash("Err"), BuiltInErrObj)
 	env.Set(GetSymHash("AssertionErr"), BuiltInAssertionErr)
 	env.Set(GetSymHash("FileNotFoundErr"), BuiltInFileNotFoundErr)
+	env.Set(GetSymHash("ImportErr"), BuiltInImportErr)
+	env.Set(GetSymHash("LimitErr"), BuiltInLimitErr)
+	env.Set(GetSymHash("MatchErr"), BuiltInMatchErr)
 	env.Set(GetSymHash("NameErr"), BuiltInNameErr)
 	env.Set(GetSymHash("NoPropErr"), BuiltInNoPropErr)
 	env.Set(GetSymHash("NotImplementedErr"), BuiltInNotImplementedErr)
+	env.Set(GetSymHash("PermissionErr"), BuiltInPermissionErr)
+	env.Set(GetSymHash("RecursionErr"), BuiltInRecursionErr)
 	env.Set(GetSymHash("StopIterErr"), BuiltInStopIterErr)
 	env.Set(GetSymHash("SyntaxErr"), BuiltInSyntaxErr)
 	env.Set(GetSymHash("TypeErr"), BuiltInTypeErr)
@@ -76,8 +87,12 @@ func NewCopiedEnv(env *Env) *Env {
 	}
 
 	return &Env{
-		Store: newStore,
-		outer: env.outer,
+		Store:       newStore,
+		outer:       env.outer,
+		modules:     env.modules,
+		importChain: env.importChain,
+		sandbox:     env.sandbox,
+		fsys:        env.fsys,
 	}
 }
 
@@ -85,6 +100,16 @@ func NewCopiedEnv(env *Env) *Env {
 type Env struct {
 	Store map[SymHash]PanObject
 	outer *Env
+	// modules is a cache of imported modules (only set in the global environment)
+	modules *ModuleCache
+	// importChain is a list of paths of the modules being imported
+	// (only set in the top-level environment of a module)
+	importChain []string
+	// sandbox restricts evaluation in the environment (nil if not restricted)
+	sandbox *Sandbox
+	// fsys is a file system where source files of modules are read
+	// (only set in the global environment, nil if the OS file system is used)
+	fsys fs.FS
 }
 
 // Get fetches variable value from the environment.
@@ -138,6 +163,70 @@ func (e *Env) Global() *Env {
 	}
 }
 
+// Modules returns the cache of imported modules shared in the global environment.
+func (e *Env) Modules() *ModuleCache {
+	global := e.Global()
+	// NOTE: global env copied by NewCopiedEnv has the same cache
+	if global.modules == nil {
+		global.modules = NewModuleCache()
+	}
+	return global.modules
+}
+
+// SetModules replaces the cache of imported modules in the environment.
+// It is used to separate modules of the copied environment from the original one.
+func (e *Env) SetModules(c *ModuleCache) {
+	e.modules = c
+}
+
+// ImportChain returns paths of the modules being imported when this environment is created.
+func (e *Env) ImportChain() []string {
+	for env := e; env != nil; env = env.outer {
+		if env.importChain != nil {
+			return env.importChain
+		}
+	}
+	return []string{}
+}
+
+// SetImportChain sets paths of the modules being imported.
+// It is used to detect cyclic imports.
+func (e *Env) SetImportChain(paths []string) {
+	e.importChain = paths
+}
+
+// Sandbox returns the sandbox of the environment.
+// Enclosed environments inherit the sandbox of the outer one.
+func (e *Env) Sandbox() *Sandbox {
+	if e == nil {
+		return nil
+	}
+	return e.sandbox
+}
+
+// SetSandbox sets the sandbox of the environment.
+func (e *Env) SetSandbox(s *Sandbox) {
+	e.sandbox = s
+}
+
+// FS returns the file system where source files of imported modules are read.
+// It returns nil if the OS file system is used.
+func (e *Env) FS() fs.FS {
+	return e.Global().fsys
+}
+
+// SetFS replaces the file system where source files of imported modules are read.
+// Paths of source files are relative to the root of fsys.
+func (e *Env) SetFS(fsys fs.FS) {
+	e.Global().fsys = fsys
+}
+
+// Permits reports whether operations of c are permitted in the environment.
+func (e *Env) Permits(c Capability) bool {
+	s := e.Sandbox()
+	return s == nil || s.Permits(c)
+}
+
 // InjectIO injects reader and writer for `IO` object
 func (e *Env) InjectIO(in io.Reader, out io.Writer) {
 	// define const `IO` containing io of args
@@ -166,6 +255,17 @@ func (e *Env) SetSourceFilePath(path string) {
 		return
 	}
 
+	// NOTE: paths in FS are relative to its root
+	if e.FS() != nil {
+		e.Set(GetSymHash(SourcePathVar), NewPanStr(FSPath(path)))
+		return
+	}
+
 	abspath, _ := filepath.Abs(path)
 	e.Set(GetSymHash(SourcePathVar), NewPanStr(abspath))
 }
+
+// FSPath converts the file path to a path in fs.FS, which is slash-separated and unrooted.
+func FSPath(p string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(p)), "/")
+}
@@ -1,6 +1,7 @@
 package object
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 )
@@ -104,6 +105,9 @@ func TestEnvWithConsts(t *testing.T) {
 		{"Obj", BuiltInObjObj},
 		{"BaseObj", BuiltInBaseObj},
 		{"Map", BuiltInMapObj},
+		{"Set", BuiltInSetObj},
+		{"Ref", BuiltInRefObj},
+		{"Bytes", BuiltInBytesObj},
 		{"Diamond", BuiltInDiamondObj},
 		{"Kernel", BuiltInKernelObj},
 		{"JSON", BuiltInJSONObj},
@@ -115,10 +119,15 @@ func TestEnvWithConsts(t *testing.T) {
 		{"nil", BuiltInNil},
 		{"Err", BuiltInErrObj},
 		{"AssertionErr", BuiltInAssertionErr},
+		{"ImportErr", BuiltInImportErr},
+		{"LimitErr", BuiltInLimitErr},
+		{"MatchErr", BuiltInMatchErr},
 		{"NameErr", BuiltInNameErr},
 		{"FileNotFoundErr", BuiltInFileNotFoundErr},
 		{"NoPropErr", BuiltInNoPropErr},
 		{"NotImplementedErr", BuiltInNotImplementedErr},
+		{"PermissionErr", BuiltInPermissionErr},
+		{"RecursionErr", BuiltInRecursionErr},
 		{"StopIterErr", BuiltInStopIterErr},
 		{"SyntaxErr", BuiltInSyntaxErr},
 		{"TypeErr", BuiltInTypeErr},
@@ -257,3 +266,62 @@ func TestSetSourceFilePathIgnored(t *testing.T) {
 		}
 	}
 }
+
+func TestEnvModules(t *testing.T) {
+	global := NewEnv()
+	child := NewEnclosedEnv(NewEnclosedEnv(global))
+
+	if child.Modules() != global.Modules() {
+		t.Errorf("module cache must be shared in the global env")
+	}
+
+	if NewCopiedEnv(global).Modules() != global.Modules() {
+		t.Errorf("module cache must be shared with the copied env")
+	}
+
+	if NewEnv().Modules() == global.Modules() {
+		t.Errorf("module cache must not be shared with another global env")
+	}
+
+	copied := NewCopiedEnv(global)
+	copied.SetModules(NewModuleCache())
+	if copied.Modules() == global.Modules() {
+		t.Errorf("module cache must be replaced")
+	}
+}
+
+func TestEnvImportChain(t *testing.T) {
+	global := NewEnv()
+	module := NewEnclosedEnv(global)
+	module.SetImportChain([]string{"a", "b"})
+	inner := NewEnclosedEnv(module)
+
+	if len(global.ImportChain()) != 0 {
+		t.Errorf("global env must not have import chain. got=%v", global.ImportChain())
+	}
+
+	actual := inner.ImportChain()
+	if len(actual) != 2 || actual[0] != "a" || actual[1] != "b" {
+		t.Errorf("import chain of outer env must be found. got=%v", actual)
+	}
+}
+
+func TestEnvSandbox(t *testing.T) {
+	global := NewEnv()
+	if global.Sandbox() != nil || !global.Permits(CapAll) {
+		t.Errorf("env without sandbox must permit everything")
+	}
+
+	s := NewSandbox(context.Background(), Limits{Denied: CapRead})
+	global.SetSandbox(s)
+
+	if NewEnclosedEnv(global).Sandbox() != s {
+		t.Errorf("sandbox must be inherited to the enclosed env")
+	}
+	if NewCopiedEnv(global).Sandbox() != s {
+		t.Errorf("sandbox must be inherited to the copied env")
+	}
+	if global.Permits(CapRead) {
+		t.Errorf("denied capability must not be permitted")
+	}
+}
@@ -78,6 +78,33 @@ func NewFileNotFoundErr(msg string) *PanErr {
 	}
 }
 
+// NewImportErr returns new importErr object.
+func NewImportErr(msg string) *PanErr {
+	return &PanErr{
+		ErrKind: ImportErr,
+		Msg:     msg,
+		proto:   BuiltInImportErr,
+	}
+}
+
+// NewLimitErr returns new limitErr object.
+func NewLimitErr(msg string) *PanErr {
+	return &PanErr{
+		ErrKind: LimitErr,
+		Msg:     msg,
+		proto:   BuiltInLimitErr,
+	}
+}
+
+// NewMatchErr returns new matchErr object.
+func NewMatchErr(msg string) *PanErr {
+	return &PanErr{
+		ErrKind: MatchErr,
+		Msg:     msg,
+		proto:   BuiltInMatchErr,
+	}
+}
+
 // NewNameErr returns new nameErr object.
 func NewNameErr(msg string) *PanErr {
 	return &PanErr{
@@ -105,6 +132,24 @@ func NewNotImplementedErr(msg string) *PanErr {
 	}
 }
 
+// NewPermissionErr returns new permissionErr object.
+func NewPermissionErr(msg string) *PanErr {
+	return &PanErr{
+		ErrKind: PermissionErr,
+		Msg:     msg,
+		proto:   BuiltInPermissionErr,
+	}
+}
+
+// NewRecursionErr returns new recursionErr object.
+func NewRecursionErr(msg string) *PanErr {
+	return &PanErr{
+		ErrKind: RecursionErr,
+		Msg:     msg,
+		proto:   BuiltInRecursionErr,
+	}
+}
+
 // NewStopIterErr returns new stopIterErr object.
 // NOTE: This error is prepared to make iter simpler, even though
 // stopIter is not an error actually.
@@ -161,9 +206,14 @@ const (
 	Err             = "Err"
 	AssertionErr    = "AssertionErr"
 	FileNotFoundErr = "FileNotFoundErr"
+	ImportErr       = "ImportErr"
+	LimitErr        = "LimitErr"
+	MatchErr        = "MatchErr"
 	NameErr         = "NameErr"
 	NoPropErr       = "NoPropErr"
 	NotImplementErr = "NotImplementedErr"
+	PermissionErr   = "PermissionErr"
+	RecursionErr    = "RecursionErr"
 	StopIterErr     = "StopIterErr"
 	SyntaxErr       = "SyntaxErr"
 	TypeErr         = "TypeErr"
@@ -84,6 +84,21 @@ func TestErrProto(t *testing.T) {
 			BuiltInFileNotFoundErr,
 			"BuiltInFileNotFoundErr",
 		},
+		{
+			NewImportErr("err"),
+			BuiltInImportErr,
+			"BuiltInImportErr",
+		},
+		{
+			NewLimitErr("err"),
+			BuiltInLimitErr,
+			"BuiltInLimitErr",
+		},
+		{
+			NewMatchErr("err"),
+			BuiltInMatchErr,
+			"BuiltInMatchErr",
+		},
 		{
 			NewNameErr("err"),
 			BuiltInNameErr,
@@ -99,6 +114,16 @@ func TestErrProto(t *testing.T) {
 			BuiltInNotImplementedErr,
 			"BuiltInNotImplementedErr",
 		},
+		{
+			NewPermissionErr("err"),
+			BuiltInPermissionErr,
+			"BuiltInPermissionErr",
+		},
+		{
+			NewRecursionErr("err"),
+			BuiltInRecursionErr,
+			"BuiltInRecursionErr",
+		},
 		{
 			NewStopIterErr("err"),
 			BuiltInStopIterErr,
@@ -172,6 +197,18 @@ func TestErrKindMethod(t *testing.T) {
 			NewFileNotFoundErr("err"),
 			"FileNotFoundErr",
 		},
+		{
+			NewImportErr("err"),
+			"ImportErr",
+		},
+		{
+			NewLimitErr("err"),
+			"LimitErr",
+		},
+		{
+			NewMatchErr("err"),
+			"MatchErr",
+		},
 		{
 			NewNameErr("err"),
 			"NameErr",
@@ -184,6 +221,14 @@ func TestErrKindMethod(t *testing.T) {
 			NewNotImplementedErr("err"),
 			"NotImplementedErr",
 		},
+		{
+			NewPermissionErr("err"),
+			"PermissionErr",
+		},
+		{
+			NewRecursionErr("err"),
+			"RecursionErr",
+		},
 		{
 			NewStopIterErr("err"),
 			"StopIterErr",
@@ -14,6 +14,10 @@ type PanFunc struct {
 	FuncWrapper
 	FuncKind FuncKind
 	Env      *Env
+	// Sig is type annotations checked when the func is called (nil if not annotated)
+	Sig *FuncSig
+	// Doc is the doc comment written just above the func literal
+	Doc string
 	// TODO: add proto field to fix inheritance
 }
 
@@ -89,10 +93,23 @@ type FuncWrapper interface {
 
 // NewPanFunc returns new func object.
 func NewPanFunc(f FuncWrapper, env *Env) *PanFunc {
-	return &PanFunc{f, FuncFunc, env}
+	return &PanFunc{FuncWrapper: f, FuncKind: FuncFunc, Env: env}
 }
 
 // NewPanIter returns new func object.
 func NewPanIter(f FuncWrapper, env *Env) *PanFunc {
-	return &PanFunc{f, IterFunc, env}
+	return &PanFunc{FuncWrapper: f, FuncKind: IterFunc, Env: env}
+}
+
+// FuncSig is type annotations of params, kwargs and the return value of func.
+// nil annotation means any value is accepted.
+type FuncSig struct {
+	Params []PanObject
+	Kwargs *PanObj
+	Ret    PanObject
+}
+
+// WithSig returns copied func annotated by sig.
+func (f *PanFunc) WithSig(sig *FuncSig) *PanFunc {
+	return &PanFunc{FuncWrapper: f.FuncWrapper, FuncKind: f.FuncKind, Env: f.Env, Sig: sig, Doc: f.Doc}
 }
@@ -137,6 +137,29 @@ func TestNewPanIter(t *testing.T) {
 	}
 }
 
+func TestPanFuncWithSig(t *testing.T) {
+	f := NewPanFunc(newMockFuncWrapper(), NewEnv())
+	sig := &FuncSig{Params: []PanObject{BuiltInIntObj}, Kwargs: EmptyPanObjPtr(), Ret: BuiltInNil}
+
+	actual := f.WithSig(sig)
+
+	if actual == f {
+		t.Fatalf("WithSig must return a copy")
+	}
+	if actual.Sig != sig {
+		t.Errorf("wrong sig. expected=%#v, got=%#v", sig, actual.Sig)
+	}
+	if f.Sig != nil {
+		t.Errorf("original func must not be changed. got=%#v", f.Sig)
+	}
+	if actual.FuncKind != f.FuncKind {
+		t.Errorf("wrong kind. expected=%#v, got=%#v", f.FuncKind, actual.FuncKind)
+	}
+	if actual.Env != f.Env {
+		t.Errorf("wrong env. expected=%#v, got=%#v", f.Env, actual.Env)
+	}
+}
+
 func newMockFuncWrapper() *mockFuncWrapper {
 	return &mockFuncWrapper{}
 }
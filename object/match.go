@@ -6,6 +6,7 @@ const MatchType = "MatchType"
 // PanMatch is object of match literal.
 type PanMatch struct {
 	MatchWrapper
+	Env *Env
 }
 
 // Type returns type of this PanObject.
@@ -37,7 +38,13 @@ func (m *PanMatch) Zero() PanObject {
 
 // MatchWrapper is a wrapper for match literal ast node.
 // NOTE: keep loose coupling to ast.MatchLiteral and PanMatch
-// ast.MatchLiteral implements MatchWrapper
 type MatchWrapper interface {
 	String() string
+	// Patterns returns each pattern in order (pattern is same as func literal).
+	Patterns() []FuncWrapper
+}
+
+// NewPanMatch returns new match object.
+func NewPanMatch(m MatchWrapper, env *Env) *PanMatch {
+	return &PanMatch{m, env}
 }
@@ -19,13 +19,17 @@ func (m *MockMatchWrapper) String() string {
 	return m.str
 }
 
+func (m *MockMatchWrapper) Patterns() []FuncWrapper {
+	return []FuncWrapper{}
+}
+
 func TestMatchInspect(t *testing.T) {
 	tests := []struct {
 		obj      PanMatch
 		expected string
 	}{
 		// AstFuncWrapper delegates to FuncComponent.String(), which works same as below
-		{PanMatch{&MockMatchWrapper{"%{|1| 2 |a| a * 2}"}}, "%{|1| 2 |a| a * 2}"},
+		{PanMatch{MatchWrapper: &MockMatchWrapper{"%{|1| 2 |a| a * 2}"}}, "%{|1| 2 |a| a * 2}"},
 	}
 
 	for _, tt := range tests {
@@ -42,7 +46,7 @@ func TestMatchRepr(t *testing.T) {
 		expected string
 	}{
 		// AstFuncWrapper delegates to FuncComponent.String(), which works same as below
-		{PanMatch{&MockMatchWrapper{"%{|1| 2 |a| a * 2}"}}, "%{|1| 2 |a| a * 2}"},
+		{PanMatch{MatchWrapper: &MockMatchWrapper{"%{|1| 2 |a| a * 2}"}}, "%{|1| 2 |a| a * 2}"},
 	}
 
 	for _, tt := range tests {
@@ -54,7 +58,7 @@ func TestMatchRepr(t *testing.T) {
 }
 
 func TestMatchProto(t *testing.T) {
-	m := PanMatch{&MockMatchWrapper{"%{}"}}
+	m := PanMatch{MatchWrapper: &MockMatchWrapper{"%{}"}}
 	if m.Proto() != BuiltInMatchObj {
 		t.Fatalf("Proto is not BuiltInMatchObj. got=%T (%+v)",
 			m.Proto(), m.Proto())
@@ -63,5 +67,17 @@ func TestMatchProto(t *testing.T) {
 
 // checked by compiler (this function works nothing)
 func testMatchIsPanObject() {
-	var _ PanObject = &PanMatch{&MockMatchWrapper{"%{|1| 2 |foo| foo}"}}
+	var _ PanObject = &PanMatch{MatchWrapper: &MockMatchWrapper{"%{|1| 2 |foo| foo}"}}
+}
+
+func TestNewPanMatch(t *testing.T) {
+	env := NewEnv()
+	m := NewPanMatch(&MockMatchWrapper{"%{|1| 2}"}, env)
+
+	if m.Env != env {
+		t.Errorf("wrong env: expected=%v, got=%v", env, m.Env)
+	}
+	if m.Inspect() != "%{|1| 2}" {
+		t.Errorf("wrong output: expected=%s, got=%s", "%{|1| 2}", m.Inspect())
+	}
 }
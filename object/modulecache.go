@@ -0,0 +1,45 @@
+package object
+
+import "sync"
+
+// NewModuleCache makes new empty cache of imported modules.
+func NewModuleCache() *ModuleCache {
+	return &ModuleCache{modules: map[string]PanObject{}}
+}
+
+// ModuleCache is a cache of imported modules keyed by their absolute paths
+// (or names of standard modules).
+// NOTE: it is safe for concurrent use because http handlers may import modules concurrently
+type ModuleCache struct {
+	mu      sync.RWMutex
+	modules map[string]PanObject
+}
+
+// Get fetches the module imported from path.
+func (c *ModuleCache) Get(path string) (PanObject, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	m, ok := c.modules[path]
+	return m, ok
+}
+
+// Set caches the module imported from path.
+func (c *ModuleCache) Set(path string, m PanObject) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.modules[path] = m
+}
+
+// Delete removes the module imported from path so that it is evaluated again in the next import.
+func (c *ModuleCache) Delete(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.modules, path)
+}
+
+// Clear removes all cached modules.
+func (c *ModuleCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.modules = map[string]PanObject{}
+}
@@ -0,0 +1,29 @@
+package object
+
+import "testing"
+
+func TestModuleCache(t *testing.T) {
+	c := NewModuleCache()
+	m := NewPanStr("module")
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("empty cache must not have modules")
+	}
+
+	c.Set("a", m)
+	if actual, ok := c.Get("a"); !ok || actual != m {
+		t.Errorf("wrong module: expected=%v, got=%v", m, actual)
+	}
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("deleted module must not be found")
+	}
+
+	c.Set("a", m)
+	c.Set("b", m)
+	c.Clear()
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("cleared module must not be found")
+	}
+}
@@ -0,0 +1,269 @@
+package object
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultPrettyWidth is the default max width of lines written by PrettyRepr.
+	DefaultPrettyWidth = 80
+	// DefaultPrettyMaxElems is the default max number of elements shown in each collection.
+	DefaultPrettyMaxElems = 100
+)
+
+// ANSI escape codes of colors by type
+const (
+	colorReset  = "\x1b[0m"
+	colorBold   = "\x1b[1m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorBlue   = "\x1b[34m"
+	colorPurple = "\x1b[35m"
+	colorCyan   = "\x1b[36m"
+)
+
+// PrettyOptions are options of PrettyRepr.
+type PrettyOptions struct {
+	// Width is the max width of lines
+	Width int
+	// MaxElems is the max number of elements shown in each collection (unlimited if 0)
+	MaxElems int
+	// Colored colors values by their types with ANSI escape codes
+	Colored bool
+}
+
+// PrettyRepr returns Repr of o, in which collections are broken into indented lines
+// if they do not fit in the width.
+// Refs referring themselves are shown as `Ref.new(...)`.
+func PrettyRepr(o PanObject, opts PrettyOptions) string {
+	if opts.Width <= 0 {
+		opts.Width = DefaultPrettyWidth
+	}
+
+	p := &prettyPrinter{opts: opts, visiting: map[PanObject]bool{}}
+	var out strings.Builder
+	p.write(&out, p.doc(o), 0, 0)
+	return out.String()
+}
+
+type prettyDocKind int
+
+const (
+	prettyLeaf prettyDocKind = iota
+	prettyColl
+	prettyPair
+)
+
+// prettyDoc is a layout of an object.
+type prettyDoc struct {
+	kind prettyDocKind
+	// text and color are used only in leaf
+	text  string
+	color string
+	// open and close are brackets of collection
+	open  string
+	close string
+	// elems are elements of collection (or key and value of pair)
+	elems []*prettyDoc
+	// width is the length in one line (-1 if it contains line breaks)
+	width int
+}
+
+type prettyPrinter struct {
+	opts PrettyOptions
+	// visiting are collections in the path from the root (used to detect cycles)
+	visiting map[PanObject]bool
+}
+
+func (p *prettyPrinter) doc(o PanObject) *prettyDoc {
+	switch o := o.(type) {
+	case *PanArr:
+		return p.collDoc(o, "[", "]", o.Elems)
+	case *PanSet:
+		return p.collDoc(o, "Set.new([", "])", o.Items())
+	case *PanObj:
+		if name, ok := o.extractName(); ok {
+			return leafDoc(name.Value, colorBold)
+		}
+		pairs := []Pair{}
+		for _, pair := range *o.Pairs {
+			pairs = append(pairs, pair)
+		}
+		return p.pairsDoc(o, "{", "}", sortPairsByKeyRepr(pairs))
+	case *PanMap:
+		pairs := []Pair{}
+		for _, pair := range *o.Pairs {
+			pairs = append(pairs, pair)
+		}
+		pairs = append(sortPairsByKeyRepr(pairs), *o.NonHashablePairs...)
+		return p.pairsDoc(o, "%{", "}", pairs)
+	case *PanRef:
+		if p.visiting[o] {
+			return leafDoc("Ref.new(...)", "")
+		}
+		p.visiting[o] = true
+		defer delete(p.visiting, o)
+		return newCollDoc("Ref.new(", ")", []*prettyDoc{p.doc(o.Get())})
+	}
+
+	return leafDoc(o.Repr(), typeColor(o))
+}
+
+func (p *prettyPrinter) collDoc(o PanObject, open, close string, elems []PanObject) *prettyDoc {
+	if p.visiting[o] {
+		return leafDoc(open+"..."+close, "")
+	}
+	p.visiting[o] = true
+	defer delete(p.visiting, o)
+
+	shown, omitted := p.truncate(len(elems))
+	docs := []*prettyDoc{}
+	for _, e := range elems[:shown] {
+		docs = append(docs, p.doc(e))
+	}
+	if omitted > 0 {
+		docs = append(docs, leafDoc(fmt.Sprintf("... (%d more)", omitted), ""))
+	}
+	return newCollDoc(open, close, docs)
+}
+
+func (p *prettyPrinter) pairsDoc(o PanObject, open, close string, pairs []Pair) *prettyDoc {
+	if p.visiting[o] {
+		return leafDoc(open+"..."+close, "")
+	}
+	p.visiting[o] = true
+	defer delete(p.visiting, o)
+
+	shown, omitted := p.truncate(len(pairs))
+	docs := []*prettyDoc{}
+	for _, pair := range pairs[:shown] {
+		key, value := p.doc(pair.Key), p.doc(pair.Value)
+		width := -1
+		if key.width >= 0 && value.width >= 0 {
+			width = key.width + len(": ") + value.width
+		}
+		docs = append(docs, &prettyDoc{kind: prettyPair, elems: []*prettyDoc{key, value}, width: width})
+	}
+	if omitted > 0 {
+		docs = append(docs, leafDoc(fmt.Sprintf("... (%d more)", omitted), ""))
+	}
+	return newCollDoc(open, close, docs)
+}
+
+// truncate returns the numbers of shown and omitted elements.
+func (p *prettyPrinter) truncate(n int) (int, int) {
+	if p.opts.MaxElems <= 0 || n <= p.opts.MaxElems {
+		return n, 0
+	}
+	return p.opts.MaxElems, n - p.opts.MaxElems
+}
+
+// write writes doc from the column col and returns the column after writing.
+func (p *prettyPrinter) write(out *strings.Builder, d *prettyDoc, indent int, col int) int {
+	switch d.kind {
+	case prettyPair:
+		col = p.write(out, d.elems[0], indent, col)
+		out.WriteString(": ")
+		return p.write(out, d.elems[1], indent, col+len(": "))
+	case prettyColl:
+		// write in one line if it fits in the width
+		if len(d.elems) == 0 || (d.width >= 0 && col+d.width <= p.opts.Width) {
+			out.WriteString(d.open)
+			col += utf8.RuneCountInString(d.open)
+			for i, e := range d.elems {
+				if i > 0 {
+					out.WriteString(", ")
+					col += len(", ")
+				}
+				col = p.write(out, e, indent, col)
+			}
+			out.WriteString(d.close)
+			return col + utf8.RuneCountInString(d.close)
+		}
+
+		out.WriteString(d.open)
+		inner := strings.Repeat(" ", indent+2)
+		for i, e := range d.elems {
+			out.WriteString("\n" + inner)
+			p.write(out, e, indent+2, indent+2)
+			if i < len(d.elems)-1 {
+				out.WriteString(",")
+			}
+		}
+		out.WriteString("\n" + strings.Repeat(" ", indent) + d.close)
+		return indent + utf8.RuneCountInString(d.close)
+	}
+
+	if p.opts.Colored && d.color != "" {
+		out.WriteString(d.color + d.text + colorReset)
+	} else {
+		out.WriteString(d.text)
+	}
+
+	if i := strings.LastIndex(d.text, "\n"); i >= 0 {
+		return utf8.RuneCountInString(d.text[i+1:])
+	}
+	return col + d.width
+}
+
+func leafDoc(text string, color string) *prettyDoc {
+	width := utf8.RuneCountInString(text)
+	if strings.Contains(text, "\n") {
+		width = -1
+	}
+	return &prettyDoc{kind: prettyLeaf, text: text, color: color, width: width}
+}
+
+func newCollDoc(open, close string, elems []*prettyDoc) *prettyDoc {
+	width := utf8.RuneCountInString(open) + utf8.RuneCountInString(close)
+	for i, e := range elems {
+		if e.width < 0 {
+			width = -1
+			break
+		}
+		if i > 0 {
+			width += len(", ")
+		}
+		width += e.width
+	}
+	return &prettyDoc{kind: prettyColl, open: open, close: close, elems: elems, width: width}
+}
+
+func sortPairsByKeyRepr(pairs []Pair) []Pair {
+	type keyedPair struct {
+		key  string
+		pair Pair
+	}
+
+	keyed := make([]keyedPair, len(pairs))
+	for i, p := range pairs {
+		keyed[i] = keyedPair{key: p.Key.Repr(), pair: p}
+	}
+	// NOTE: same order as Repr
+	sort.Slice(keyed, func(i, j int) bool { return keyed[i].key < keyed[j].key })
+
+	sorted := make([]Pair, len(pairs))
+	for i, k := range keyed {
+		sorted[i] = k.pair
+	}
+	return sorted
+}
+
+func typeColor(o PanObject) string {
+	switch o.Type() {
+	case IntType, FloatType:
+		return colorCyan
+	case StrType:
+		return colorGreen
+	case NilType, BoolType:
+		return colorPurple
+	case FuncType, BuiltInType:
+		return colorBlue
+	case ErrType, ErrWrapperType:
+		return colorRed
+	}
+	return ""
+}
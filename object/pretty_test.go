@@ -0,0 +1,164 @@
+package object
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrettyRepr(t *testing.T) {
+	nums := func(n int) []PanObject {
+		elems := []PanObject{}
+		for i := 0; i < n; i++ {
+			elems = append(elems, NewPanInt(int64(i)))
+		}
+		return elems
+	}
+
+	tests := []struct {
+		name     string
+		obj      PanObject
+		opts     PrettyOptions
+		expected string
+	}{
+		{
+			"scalar",
+			NewPanStr("a"),
+			PrettyOptions{},
+			`"a"`,
+		},
+		{
+			"short arr is the same as repr",
+			NewPanArr(NewPanInt(1), NewPanArr(NewPanStr("a")), NewPanArr()),
+			PrettyOptions{Width: 80},
+			`[1, ["a"], []]`,
+		},
+		{
+			"long arr",
+			NewPanArr(NewPanInt(10), NewPanInt(20), NewPanInt(30)),
+			PrettyOptions{Width: 10},
+			"[\n  10,\n  20,\n  30\n]",
+		},
+		{
+			"arr just fits",
+			NewPanArr(NewPanInt(10), NewPanInt(20)),
+			PrettyOptions{Width: 8},
+			"[10, 20]",
+		},
+		{
+			"only outer collection is broken",
+			NewPanArr(NewPanArr(NewPanInt(1), NewPanInt(2)), NewPanArr(NewPanInt(3), NewPanInt(4))),
+			PrettyOptions{Width: 10},
+			"[\n  [1, 2],\n  [3, 4]\n]",
+		},
+		{
+			"obj",
+			PanObjInstancePtr(&map[SymHash]Pair{
+				GetSymHash("b"): {NewPanStr("b"), NewPanArr(NewPanInt(100), NewPanInt(200))},
+				GetSymHash("a"): {NewPanStr("a"), NewPanInt(1)},
+			}),
+			PrettyOptions{Width: 15},
+			"{\n  \"a\": 1,\n  \"b\": [\n    100,\n    200\n  ]\n}",
+		},
+		{
+			"obj with name",
+			PanObjInstancePtr(&map[SymHash]Pair{
+				GetSymHash("_name"): {NewPanStr("_name"), NewPanStr("Foo")},
+				GetSymHash("a"):     {NewPanStr("a"), NewPanInt(1)},
+			}),
+			PrettyOptions{Width: 1},
+			"Foo",
+		},
+		{
+			"map",
+			NewPanMap(
+				Pair{NewPanInt(2), NewPanStr("two")},
+				Pair{NewPanInt(1), NewPanStr("one")},
+			),
+			PrettyOptions{Width: 10},
+			"%{\n  1: \"one\",\n  2: \"two\"\n}",
+		},
+		{
+			"set",
+			NewPanSet(NewPanInt(1), NewPanInt(2)),
+			PrettyOptions{Width: 10},
+			"Set.new([\n  1,\n  2\n])",
+		},
+		{
+			"truncated",
+			NewPanArr(nums(10)...),
+			PrettyOptions{Width: 80, MaxElems: 3},
+			"[0, 1, 2, ... (7 more)]",
+		},
+		{
+			"truncated obj",
+			PanObjInstancePtr(&map[SymHash]Pair{
+				GetSymHash("a"): {NewPanStr("a"), NewPanInt(1)},
+				GetSymHash("b"): {NewPanStr("b"), NewPanInt(2)},
+			}),
+			PrettyOptions{Width: 80, MaxElems: 1},
+			`{"a": 1, ... (1 more)}`,
+		},
+		{
+			"not truncated",
+			NewPanArr(nums(3)...),
+			PrettyOptions{Width: 80, MaxElems: 3},
+			"[0, 1, 2]",
+		},
+		{
+			"default width",
+			NewPanArr(nums(40)...),
+			PrettyOptions{},
+			"[\n  " + reprLines(nums(40)) + "\n]",
+		},
+		{
+			"colored",
+			NewPanArr(NewPanInt(1), NewPanStr("a"), BuiltInNil, NewPanErr("e")),
+			PrettyOptions{Width: 80, Colored: true},
+			"[\x1b[36m1\x1b[0m, \x1b[32m\"a\"\x1b[0m, \x1b[35mnil\x1b[0m, \x1b[31mErr: e\x1b[0m]",
+		},
+		{
+			"colors are not counted in width",
+			NewPanArr(NewPanInt(1), NewPanInt(2)),
+			PrettyOptions{Width: 6, Colored: true},
+			"[\x1b[36m1\x1b[0m, \x1b[36m2\x1b[0m]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			actual := PrettyRepr(tt.obj, tt.opts)
+			if actual != tt.expected {
+				t.Errorf("wrong output:\nexpected=%q\ngot=%q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPrettyReprCycle(t *testing.T) {
+	ref := NewPanRef(BuiltInNil)
+	ref.Set(NewPanArr(NewPanInt(1), ref))
+
+	actual := PrettyRepr(ref, PrettyOptions{Width: 80})
+	expected := "Ref.new([1, Ref.new(...)])"
+	if actual != expected {
+		t.Errorf("wrong output: expected=%q, got=%q", expected, actual)
+	}
+
+	// shared (but not cyclic) values are written as they are
+	shared := NewPanArr(NewPanInt(1))
+	actual = PrettyRepr(NewPanArr(shared, shared), PrettyOptions{Width: 80})
+	expected = "[[1], [1]]"
+	if actual != expected {
+		t.Errorf("wrong output: expected=%q, got=%q", expected, actual)
+	}
+}
+
+func reprLines(elems []PanObject) string {
+	reprs := []string{}
+	for _, e := range elems {
+		reprs = append(reprs, e.Repr())
+	}
+	return strings.Join(reprs, ",\n  ")
+}
@@ -0,0 +1,161 @@
+package object
+
+import (
+	"bytes"
+	"sync"
+)
+
+// RefType is a type of PanRef.
+const RefType = "RefType"
+
+// PanRef is object of mutable reference cell, which holds one value.
+// NOTE: it is safe for concurrent use because http handlers may evaluate funcs concurrently
+type PanRef struct {
+	mu    *sync.RWMutex
+	value PanObject
+	proto PanObject
+}
+
+// Type returns type of this PanObject.
+func (r *PanRef) Type() PanObjType {
+	return RefType
+}
+
+// Inspect returns formatted source code of this object.
+func (r *PanRef) Inspect() string {
+	return r.print(func(v PanObject) string { return v.Inspect() }, map[*PanRef]bool{})
+}
+
+// Repr returns pritty-printed string of this object.
+func (r *PanRef) Repr() string {
+	return r.print(func(v PanObject) string { return v.Repr() }, map[*PanRef]bool{})
+}
+
+// print prints the value by f.
+// Refs in visited (refs in the path from the root) are printed as `Ref.new(...)`.
+func (r *PanRef) print(f func(PanObject) string, visited map[*PanRef]bool) string {
+	if visited[r] {
+		return "Ref.new(...)"
+	}
+	visited[r] = true
+	defer delete(visited, r)
+
+	return refString(f(refView(r.Get(), visited)))
+}
+
+// visitedRef is a ref printed with the visited refs of its parent.
+type visitedRef struct {
+	*PanRef
+	visited map[*PanRef]bool
+}
+
+func (r *visitedRef) Inspect() string {
+	return r.print(func(v PanObject) string { return v.Inspect() }, r.visited)
+}
+
+func (r *visitedRef) Repr() string {
+	return r.print(func(v PanObject) string { return v.Repr() }, r.visited)
+}
+
+// refView returns a copy of o, in which refs are replaced with visitedRef
+// so that visited refs are passed to them.
+// NOTE: only collections are copied because others cannot contain refs in their strings
+func refView(o PanObject, visited map[*PanRef]bool) PanObject {
+	switch o := o.(type) {
+	case *PanRef:
+		return &visitedRef{PanRef: o, visited: visited}
+	case *PanArr:
+		elems := make([]PanObject, len(o.Elems))
+		for i, e := range o.Elems {
+			elems[i] = refView(e, visited)
+		}
+		return &PanArr{Elems: elems, proto: o.proto}
+	case *PanObj:
+		pairs := make(map[SymHash]Pair, len(*o.Pairs))
+		for k, p := range *o.Pairs {
+			pairs[k] = Pair{Key: p.Key, Value: refView(p.Value, visited)}
+		}
+		v := *o
+		v.Pairs = &pairs
+		return &v
+	case *PanMap:
+		pairs := make(map[HashKey]Pair, len(*o.Pairs))
+		for k, p := range *o.Pairs {
+			pairs[k] = Pair{Key: p.Key, Value: refView(p.Value, visited)}
+		}
+		nonHashablePairs := make([]Pair, len(*o.NonHashablePairs))
+		for i, p := range *o.NonHashablePairs {
+			nonHashablePairs[i] = Pair{Key: refView(p.Key, visited), Value: refView(p.Value, visited)}
+		}
+		return &PanMap{HashKeys: o.HashKeys, Pairs: &pairs, NonHashablePairs: &nonHashablePairs, proto: o.proto}
+	case *PanSet:
+		nonHashableElems := make([]PanObject, len(*o.NonHashableElems))
+		for i, e := range *o.NonHashableElems {
+			nonHashableElems[i] = refView(e, visited)
+		}
+		return &PanSet{HashKeys: o.HashKeys, Elems: o.Elems, NonHashableElems: &nonHashableElems, proto: o.proto}
+	case *PanRange:
+		return &PanRange{
+			Start: refView(o.Start, visited),
+			Stop:  refView(o.Stop, visited),
+			Step:  refView(o.Step, visited),
+			proto: o.proto,
+		}
+	}
+	return o
+}
+
+func refString(value string) string {
+	var out bytes.Buffer
+	out.WriteString("Ref.new(")
+	out.WriteString(value)
+	out.WriteString(")")
+	return out.String()
+}
+
+// Proto returns proto of this object.
+func (r *PanRef) Proto() PanObject {
+	return r.proto
+}
+
+// Zero returns zero value of this object.
+func (r *PanRef) Zero() PanObject {
+	return r
+}
+
+// Get returns the current value.
+func (r *PanRef) Get() PanObject {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.value
+}
+
+// Set replaces the current value with v.
+func (r *PanRef) Set(v PanObject) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.value = v
+}
+
+// CompareAndSet replaces the current value with v only if the current value is old itself.
+// It returns whether the value is replaced.
+// NOTE: values are compared by identity so that user-defined `==` is not called in the lock
+func (r *PanRef) CompareAndSet(old, v PanObject) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.value != old {
+		return false
+	}
+	r.value = v
+	return true
+}
+
+// NewPanRef returns new ref object.
+func NewPanRef(v PanObject) *PanRef {
+	return NewInheritedRef(BuiltInRefObj, v)
+}
+
+// NewInheritedRef returns new ref object born of proto.
+func NewInheritedRef(proto PanObject, v PanObject) *PanRef {
+	return &PanRef{mu: &sync.RWMutex{}, value: v, proto: proto}
+}
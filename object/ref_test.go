@@ -0,0 +1,189 @@
+package object
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRefType(t *testing.T) {
+	obj := NewPanRef(BuiltInNil)
+	if obj.Type() != RefType {
+		t.Fatalf("wrong type: expected=%s, got=%s", RefType, obj.Type())
+	}
+}
+
+func TestRefInspect(t *testing.T) {
+	tests := []struct {
+		obj      *PanRef
+		expected string
+	}{
+		{
+			NewPanRef(NewPanInt(1)),
+			`Ref.new(1)`,
+		},
+		{
+			NewPanRef(NewPanStr("a")),
+			`Ref.new("a")`,
+		},
+		{
+			NewPanRef(NewPanRef(BuiltInNil)),
+			`Ref.new(Ref.new(nil))`,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.obj.Inspect() != tt.expected {
+			t.Errorf("wrong output: expected=%s, got=%s",
+				tt.expected, tt.obj.Inspect())
+		}
+	}
+}
+
+func TestRefRepr(t *testing.T) {
+	tests := []struct {
+		obj      *PanRef
+		expected string
+	}{
+		{
+			NewPanRef(NewPanStr("a")),
+			`Ref.new("a")`,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.obj.Repr() != tt.expected {
+			t.Errorf("wrong output: expected=%s, got=%s",
+				tt.expected, tt.obj.Repr())
+		}
+	}
+}
+
+func TestRefInspectCyclic(t *testing.T) {
+	r := NewPanRef(BuiltInNil)
+	r.Set(NewPanArr(r, NewPanInt(1)))
+
+	expected := `Ref.new([Ref.new(...), 1])`
+	if r.Inspect() != expected {
+		t.Errorf("wrong output: expected=%s, got=%s", expected, r.Inspect())
+	}
+	if r.Repr() != expected {
+		t.Errorf("wrong output: expected=%s, got=%s", expected, r.Repr())
+	}
+	// printed normally after the cycle is detected
+	if r.Inspect() != expected {
+		t.Errorf("wrong output: expected=%s, got=%s", expected, r.Inspect())
+	}
+
+	// cycle through another ref
+	r2 := NewPanRef(r)
+	r.Set(r2)
+	expected = `Ref.new(Ref.new(Ref.new(...)))`
+	if r.Inspect() != expected {
+		t.Errorf("wrong output: expected=%s, got=%s", expected, r.Inspect())
+	}
+}
+
+func TestRefInspectSharedInCollection(t *testing.T) {
+	// refs which are not cyclic are printed even if they appear twice
+	r := NewPanRef(NewPanInt(1))
+	arr := NewPanRef(NewPanArr(r, r, NewPanMap(Pair{Key: NewPanStr("a"), Value: r})))
+
+	expected := `Ref.new([Ref.new(1), Ref.new(1), %{"a": Ref.new(1)}])`
+	if arr.Inspect() != expected {
+		t.Errorf("wrong output: expected=%s, got=%s", expected, arr.Inspect())
+	}
+}
+
+func TestRefReprConcurrently(t *testing.T) {
+	r := NewPanRef(NewPanArr(NewPanInt(1)))
+	expected := `Ref.new([1])`
+
+	var wg sync.WaitGroup
+	outputs := make([]string, 100)
+	for i := range outputs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outputs[i] = r.Repr()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, out := range outputs {
+		if out != expected {
+			t.Errorf("wrong output: expected=%s, got=%s", expected, out)
+		}
+	}
+}
+
+func TestRefProto(t *testing.T) {
+	r := NewPanRef(BuiltInNil)
+	if r.Proto() != BuiltInRefObj {
+		t.Fatalf("Proto is not BuiltInRefObj. got=%T (%+v)",
+			r.Proto(), r.Proto())
+	}
+}
+
+func TestInheritedRefProto(t *testing.T) {
+	refChild := ChildPanObjPtr(BuiltInRefObj, EmptyPanObjPtr())
+	r := NewInheritedRef(refChild, BuiltInNil)
+	if r.Proto() != refChild {
+		t.Fatalf("Proto is not refChild. got=%T (%s)",
+			r.Proto(), r.Proto().Inspect())
+	}
+}
+
+func TestRefGetAndSet(t *testing.T) {
+	r := NewPanRef(NewPanInt(1))
+	v := NewPanInt(2)
+	r.Set(v)
+
+	if r.Get() != v {
+		t.Errorf("wrong value: expected=%s, got=%s", v.Inspect(), r.Get().Inspect())
+	}
+}
+
+func TestRefCompareAndSet(t *testing.T) {
+	old := NewPanArr(NewPanInt(1))
+	r := NewPanRef(old)
+
+	// compared by identity
+	if r.CompareAndSet(NewPanArr(NewPanInt(1)), NewPanInt(2)) {
+		t.Errorf("value must not be replaced if old is not identical")
+	}
+	if r.Get() != old {
+		t.Errorf("wrong value: expected=%s, got=%s", old.Inspect(), r.Get().Inspect())
+	}
+
+	v := NewPanArr(NewPanInt(3))
+	if !r.CompareAndSet(old, v) {
+		t.Errorf("value must be replaced if old is identical")
+	}
+	if r.Get() != v {
+		t.Errorf("wrong value: expected=%s, got=%s", v.Inspect(), r.Get().Inspect())
+	}
+}
+
+func TestRefCompareAndSetConcurrently(t *testing.T) {
+	r := NewPanRef(NewPanInt(0))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				old := r.Get()
+				v := NewPanInt(old.(*PanInt).Value + 1)
+				if r.CompareAndSet(old, v) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if r.Get().(*PanInt).Value != 100 {
+		t.Errorf("wrong value: expected=100, got=%s", r.Get().Inspect())
+	}
+}
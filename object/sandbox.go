@@ -0,0 +1,162 @@
+package object
+
+import (
+	"context"
+	"fmt"
+	"runtime/metrics"
+	"sync/atomic"
+)
+
+// DefaultMaxDepth is the default maximum depth of func calls.
+// NOTE: it prevents Go stack overflow, which cannot be recovered
+const DefaultMaxDepth = 10000
+
+// checkInterval is the number of steps between checks of ctx and memory usage.
+const checkInterval = 1024
+
+// heapMetric is a runtime metric to estimate memory usage.
+const heapMetric = "/memory/classes/heap/objects:bytes"
+
+// Capability is a kind of operations which can be denied in the sandbox.
+type Capability uint
+
+const (
+	// CapImport is a capability to import modules by `import`, `invite!` and `reload`.
+	CapImport Capability = 1 << iota
+	// CapRead is a capability to read files by `read`.
+	CapRead
+	// CapHTTP is a capability to use the `http` module.
+	CapHTTP
+	// CapOS is a capability to refer OS resources like `argv`.
+	CapOS
+	// CapWrite is a capability to write files (used by the `kv` module).
+	CapWrite
+)
+
+// CapAll is a set of all capabilities.
+const CapAll = CapImport | CapRead | CapHTTP | CapOS | CapWrite
+
+// Limits is a set of resource limits of the sandbox.
+// Zero values mean no limits (MaxDepth is set to DefaultMaxDepth instead).
+type Limits struct {
+	// MaxSteps is the maximum number of evaluated ast nodes.
+	MaxSteps int64
+	// MaxDepth is the maximum depth of func calls.
+	MaxDepth int64
+	// MaxMemory is the maximum size (bytes) of heap growth.
+	// NOTE: it is an estimate because heap is shared in the process
+	MaxMemory int64
+	// Denied is a set of capabilities which are not permitted.
+	Denied Capability
+}
+
+// NewSandbox makes new sandbox.
+// Evaluation is stopped when ctx is done.
+func NewSandbox(ctx context.Context, limits Limits) *Sandbox {
+	if limits.MaxDepth == 0 {
+		limits.MaxDepth = DefaultMaxDepth
+	}
+
+	shared := &sandboxShared{ctx: ctx, limits: limits}
+	if limits.MaxMemory > 0 {
+		shared.baseMemory = heapBytes()
+	}
+	return &Sandbox{sandboxShared: shared}
+}
+
+// Sandbox restricts resources and operations used in evaluation.
+// NOTE: steps are safe for concurrent use because http handlers may evaluate funcs concurrently,
+// but depth is counted in each evaluation (use Fork to start another evaluation concurrently)
+type Sandbox struct {
+	*sandboxShared
+	depth int64
+}
+
+// sandboxShared is a state shared with forked sandboxes.
+type sandboxShared struct {
+	ctx        context.Context
+	limits     Limits
+	steps      int64
+	baseMemory uint64
+	onCheck    func()
+}
+
+// Fork returns a sandbox for another evaluation, which shares limits and steps with s.
+// The depth of func calls is counted separately so that concurrent evaluations do not add up their depths.
+func (s *Sandbox) Fork() *Sandbox {
+	return &Sandbox{sandboxShared: s.sandboxShared}
+}
+
+// Step counts an evaluation step and returns LimitErr if any limit is exceeded.
+func (s *Sandbox) Step() *PanErr {
+	n := atomic.AddInt64(&s.steps, 1)
+	if s.limits.MaxSteps > 0 && n > s.limits.MaxSteps {
+		return NewLimitErr(fmt.Sprintf("exceeded maximum steps %d", s.limits.MaxSteps))
+	}
+
+	if n%checkInterval == 0 {
+		return s.check()
+	}
+	return nil
+}
+
+func (s *Sandbox) check() *PanErr {
+	if s.onCheck != nil {
+		s.onCheck()
+	}
+
+	if s.ctx != nil {
+		if err := s.ctx.Err(); err != nil {
+			return NewLimitErr(fmt.Sprintf("evaluation stopped: %s", err))
+		}
+	}
+
+	if s.limits.MaxMemory > 0 {
+		used := int64(heapBytes()) - int64(s.baseMemory)
+		if used > s.limits.MaxMemory {
+			return NewLimitErr(fmt.Sprintf("exceeded maximum memory %d bytes", s.limits.MaxMemory))
+		}
+	}
+	return nil
+}
+
+// OnCheck sets f called every time ctx and memory usage are checked.
+// It can be used to yield to the host (e.g. the event loop of js) so that ctx is canceled during the evaluation.
+// NOTE: it must be set before the evaluation starts
+func (s *Sandbox) OnCheck(f func()) {
+	s.onCheck = f
+}
+
+// Enter counts a func call and returns RecursionErr if the depth exceeds the limit.
+// Leave must be called after the call finishes unless err is returned.
+func (s *Sandbox) Enter() *PanErr {
+	if d := atomic.AddInt64(&s.depth, 1); d > s.limits.MaxDepth {
+		atomic.AddInt64(&s.depth, -1)
+		return NewRecursionErr(fmt.Sprintf("exceeded maximum recursion depth %d", s.limits.MaxDepth))
+	}
+	return nil
+}
+
+// Leave uncounts a func call.
+func (s *Sandbox) Leave() {
+	atomic.AddInt64(&s.depth, -1)
+}
+
+// Steps returns the number of evaluated steps.
+func (s *Sandbox) Steps() int64 {
+	return atomic.LoadInt64(&s.steps)
+}
+
+// Permits reports whether operations of c are permitted.
+func (s *Sandbox) Permits(c Capability) bool {
+	return s.limits.Denied&c == 0
+}
+
+func heapBytes() uint64 {
+	sample := []metrics.Sample{{Name: heapMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
@@ -0,0 +1,166 @@
+package object
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestSandboxStep(t *testing.T) {
+	s := NewSandbox(context.Background(), Limits{MaxSteps: 3})
+
+	for i := 0; i < 3; i++ {
+		if err := s.Step(); err != nil {
+			t.Fatalf("unexpected error: %s", err.Inspect())
+		}
+	}
+
+	err := s.Step()
+	if err == nil || err.Kind() != LimitErr {
+		t.Errorf("LimitErr must be raised. got=%v", err)
+	}
+	if s.Steps() != 4 {
+		t.Errorf("wrong steps: %d", s.Steps())
+	}
+}
+
+func TestSandboxStepCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewSandbox(ctx, Limits{})
+	cancel()
+
+	var err *PanErr
+	for i := 0; i < checkInterval && err == nil; i++ {
+		err = s.Step()
+	}
+
+	if err == nil || err.Kind() != LimitErr {
+		t.Errorf("LimitErr must be raised. got=%v", err)
+	}
+}
+
+func TestSandboxOnCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewSandbox(ctx, Limits{})
+	// cancel during the evaluation (like js event handlers)
+	s.OnCheck(cancel)
+
+	var err *PanErr
+	for i := 0; i < checkInterval && err == nil; i++ {
+		err = s.Step()
+	}
+
+	if err == nil || err.Kind() != LimitErr {
+		t.Errorf("LimitErr must be raised. got=%v", err)
+	}
+	if s.Steps() != checkInterval {
+		t.Errorf("wrong steps: %d", s.Steps())
+	}
+}
+
+func TestSandboxEnter(t *testing.T) {
+	s := NewSandbox(context.Background(), Limits{MaxDepth: 2})
+
+	if err := s.Enter(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Inspect())
+	}
+	if err := s.Enter(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Inspect())
+	}
+	if err := s.Enter(); err == nil || err.Kind() != RecursionErr {
+		t.Errorf("RecursionErr must be raised. got=%v", err)
+	}
+
+	s.Leave()
+	if err := s.Enter(); err != nil {
+		t.Errorf("depth must be uncounted. got=%s", err.Inspect())
+	}
+}
+
+func TestSandboxFork(t *testing.T) {
+	s := NewSandbox(context.Background(), Limits{MaxSteps: 2, MaxDepth: 1})
+	forked := s.Fork()
+
+	// depth is counted separately
+	if err := s.Enter(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Inspect())
+	}
+	if err := forked.Enter(); err != nil {
+		t.Errorf("depth of the forked sandbox must be counted separately. got=%s", err.Inspect())
+	}
+	if err := forked.Enter(); err == nil || err.Kind() != RecursionErr {
+		t.Errorf("RecursionErr must be raised. got=%v", err)
+	}
+
+	// steps are shared
+	if err := s.Step(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Inspect())
+	}
+	if err := forked.Step(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Inspect())
+	}
+	if err := forked.Step(); err == nil || err.Kind() != LimitErr {
+		t.Errorf("LimitErr must be raised. got=%v", err)
+	}
+	if s.Steps() != 3 {
+		t.Errorf("wrong steps: %d", s.Steps())
+	}
+}
+
+func TestSandboxForkConcurrently(t *testing.T) {
+	s := NewSandbox(context.Background(), Limits{MaxDepth: 10})
+
+	var wg sync.WaitGroup
+	errs := make([]*PanErr, 100)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			forked := s.Fork()
+			for j := 0; j < 10; j++ {
+				if err := forked.Enter(); err != nil {
+					errs[i] = err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("depths of concurrent evaluations must not be added up. got=%s", err.Inspect())
+		}
+	}
+}
+
+func TestSandboxDefaultMaxDepth(t *testing.T) {
+	s := NewSandbox(context.Background(), Limits{})
+
+	if s.limits.MaxDepth != DefaultMaxDepth {
+		t.Errorf("MaxDepth must be %d. got=%d", DefaultMaxDepth, s.limits.MaxDepth)
+	}
+}
+
+func TestSandboxPermits(t *testing.T) {
+	s := NewSandbox(context.Background(), Limits{Denied: CapImport | CapHTTP})
+
+	tests := []struct {
+		c        Capability
+		expected bool
+	}{
+		{CapImport, false},
+		{CapRead, true},
+		{CapHTTP, false},
+		{CapOS, true},
+		{CapWrite, true},
+		// all of the capabilities must be permitted
+		{CapRead | CapHTTP, false},
+	}
+
+	for _, tt := range tests {
+		if s.Permits(tt.c) != tt.expected {
+			t.Errorf("Permits(%d) must be %t", tt.c, tt.expected)
+		}
+	}
+}
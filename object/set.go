@@ -0,0 +1,107 @@
+package object
+
+import (
+	"bytes"
+	"strings"
+)
+
+// SetType is a type of PanSet.
+const SetType = "SetType"
+
+// PanSet is object of set, which contains unique elements.
+type PanSet struct {
+	// used to keep set order
+	HashKeys         *[]HashKey
+	Elems            *map[HashKey]PanObject
+	NonHashableElems *[]PanObject
+	proto            PanObject
+}
+
+// Type returns type of this PanObject.
+func (s *PanSet) Type() PanObjType {
+	return SetType
+}
+
+// Inspect returns formatted source code of this object.
+func (s *PanSet) Inspect() string {
+	elems := []string{}
+	for _, e := range s.Items() {
+		elems = append(elems, e.Inspect())
+	}
+	return setString(elems)
+}
+
+// Repr returns pritty-printed string of this object.
+func (s *PanSet) Repr() string {
+	elems := []string{}
+	for _, e := range s.Items() {
+		elems = append(elems, e.Repr())
+	}
+	return setString(elems)
+}
+
+func setString(elems []string) string {
+	var out bytes.Buffer
+	out.WriteString("Set.new([")
+	out.WriteString(strings.Join(elems, ", "))
+	out.WriteString("])")
+	return out.String()
+}
+
+// Proto returns proto of this object.
+func (s *PanSet) Proto() PanObject {
+	return s.proto
+}
+
+// Zero returns zero value of this object.
+func (s *PanSet) Zero() PanObject {
+	return s
+}
+
+// Items returns elements of the set in insertion order.
+// NOTE: non-hashable elements are placed after hashable ones
+func (s *PanSet) Items() []PanObject {
+	items := make([]PanObject, 0, s.Len())
+	for _, h := range *s.HashKeys {
+		items = append(items, (*s.Elems)[h])
+	}
+	return append(items, *s.NonHashableElems...)
+}
+
+// Len returns the number of elements.
+func (s *PanSet) Len() int {
+	return len(*s.HashKeys) + len(*s.NonHashableElems)
+}
+
+// NewPanSet returns new set object.
+func NewPanSet(elems ...PanObject) *PanSet {
+	return NewInheritedSet(BuiltInSetObj, elems...)
+}
+
+// NewInheritedSet returns new set object born of proto.
+func NewInheritedSet(proto PanObject, elems ...PanObject) *PanSet {
+	hashKeys := []HashKey{}
+	elemMap := map[HashKey]PanObject{}
+	nonHashableElems := []PanObject{}
+
+	for _, elem := range elems {
+		hashable, ok := elem.(PanScalar)
+		if ok {
+			if _, exists := elemMap[hashable.Hash()]; !exists {
+				hashKeys = append(hashKeys, hashable.Hash())
+				elemMap[hashable.Hash()] = elem
+			}
+		} else {
+			// NOTE: this method DOES NOT check duplicated nonhashable elements
+			// because they should be compared by '== method
+			nonHashableElems = append(nonHashableElems, elem)
+		}
+	}
+
+	return &PanSet{
+		HashKeys:         &hashKeys,
+		Elems:            &elemMap,
+		NonHashableElems: &nonHashableElems,
+		proto:            proto,
+	}
+}
@@ -0,0 +1,98 @@
+package object
+
+import (
+	"testing"
+)
+
+func TestSetType(t *testing.T) {
+	obj := NewPanSet()
+	if obj.Type() != SetType {
+		t.Fatalf("wrong type: expected=%s, got=%s", SetType, obj.Type())
+	}
+}
+
+func TestSetInspect(t *testing.T) {
+	tests := []struct {
+		obj      *PanSet
+		expected string
+	}{
+		{
+			NewPanSet(),
+			`Set.new([])`,
+		},
+		// elements are ordered by insertion
+		{
+			NewPanSet(NewPanStr("b"), NewPanInt(1), BuiltInTrue),
+			`Set.new(["b", 1, true])`,
+		},
+		// duplicated hashable elements are removed
+		{
+			NewPanSet(NewPanInt(1), NewPanInt(2), NewPanInt(1)),
+			`Set.new([1, 2])`,
+		},
+		// elements of different types are distinguished
+		{
+			NewPanSet(NewPanInt(1), NewPanStr("1")),
+			`Set.new([1, "1"])`,
+		},
+		// order: hashable, non-hashable
+		{
+			NewPanSet(NewPanArr(NewPanInt(1)), NewPanInt(2)),
+			`Set.new([2, [1]])`,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.obj.Inspect() != tt.expected {
+			t.Errorf("wrong output: expected=%s, got=%s",
+				tt.expected, tt.obj.Inspect())
+		}
+	}
+}
+
+func TestSetRepr(t *testing.T) {
+	tests := []struct {
+		obj      *PanSet
+		expected string
+	}{
+		{
+			NewPanSet(),
+			`Set.new([])`,
+		},
+		{
+			NewPanSet(NewPanStr("b"), NewPanArr(NewPanStr("c"))),
+			`Set.new(["b", ["c"]])`,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.obj.Repr() != tt.expected {
+			t.Errorf("wrong output: expected=%s, got=%s",
+				tt.expected, tt.obj.Repr())
+		}
+	}
+}
+
+func TestSetLen(t *testing.T) {
+	obj := NewPanSet(NewPanInt(1), NewPanInt(1), NewPanArr())
+	if obj.Len() != 2 {
+		t.Errorf("wrong len: expected=2, got=%d", obj.Len())
+	}
+}
+
+func TestSetProto(t *testing.T) {
+	s := NewPanSet()
+	if s.Proto() != BuiltInSetObj {
+		t.Fatalf("Proto is not BuiltInSetObj. got=%T (%+v)",
+			s.Proto(), s.Proto())
+	}
+}
+
+func TestInheritedSetProto(t *testing.T) {
+	setChild := ChildPanObjPtr(BuiltInSetObj, EmptyPanObjPtr())
+	s := NewInheritedSet(setChild)
+	if s.Proto() != setChild {
+		t.Fatalf("Proto is not setChild. got=%T (%s)",
+			s.Proto(), s.Proto().Inspect())
+	}
+}
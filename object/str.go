@@ -75,6 +75,9 @@ var opPattern = regexp.MustCompile(fmt.Sprintf(`^(%s)$`, strings.Join([]string{
 	`<=>`, `==`, `!=`, `>=`, `<=`, `>`, `<`, `<<`, `>>`,
 	`/&`, `/\|`, `/\^`, `/~`, `!`, `\+`, `\-`, `\*`, `\*\*`,
 	`/`, "//", `%`, `\-%`, `\+%`,
+	// user-defined operators
+	`<\+>`, `<\->`, `<\*>`, `</>`, `<\$>`, `<\|>`, `<&>`,
+	`⊕`, `⊖`, `⊗`, `⊘`, `⊙`, `∘`, `×`, `÷`, `·`,
 }, "|")))
 
 func isPublic(s string) bool {
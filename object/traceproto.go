@@ -46,6 +46,21 @@ func TraceProtoOfBuiltInIter(obj PanObject) (*PanBuiltInIter, bool) {
 	return nil, false
 }
 
+// TraceProtoOfBytes traces proto chain of obj and returns bytes proto.
+func TraceProtoOfBytes(obj PanObject) (*PanBytes, bool) {
+	for o := obj; o.Proto() != nil; o = o.Proto() {
+		// HACK: proto of Bytes is zero value Bytes.new([]) so that Bytes itself can be used as bytes object
+		if o == BuiltInBytesObj {
+			return zeroBytes, true
+		}
+
+		if v, ok := o.(*PanBytes); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
 // TraceProtoOfFloat traces proto chain of obj and returns float proto.
 func TraceProtoOfFloat(obj PanObject) (*PanFloat, bool) {
 	for o := obj; o.Proto() != nil; o = o.Proto() {
@@ -162,6 +177,32 @@ func TraceProtoOfRange(obj PanObject) (*PanRange, bool) {
 	return nil, false
 }
 
+// TraceProtoOfRef traces proto chain of obj and returns ref proto.
+// NOTE: unlike other builtin objects, Ref itself cannot be used as ref object because ref is mutable
+func TraceProtoOfRef(obj PanObject) (*PanRef, bool) {
+	for o := obj; o.Proto() != nil; o = o.Proto() {
+		if v, ok := o.(*PanRef); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// TraceProtoOfSet traces proto chain of obj and returns set proto.
+func TraceProtoOfSet(obj PanObject) (*PanSet, bool) {
+	for o := obj; o.Proto() != nil; o = o.Proto() {
+		// HACK: proto of Set is zero value Set.new([]) so that Set itself can be used as set object
+		if o == BuiltInSetObj {
+			return zeroSet, true
+		}
+
+		if v, ok := o.(*PanSet); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
 // TraceProtoOfStr traces proto chain of obj and returns str proto.
 func TraceProtoOfStr(obj PanObject) (*PanStr, bool) {
 	for o := obj; o.Proto() != nil; o = o.Proto() {
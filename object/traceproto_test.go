@@ -238,6 +238,74 @@ func TestTraceProtoOfBuiltInIterFailed(t *testing.T) {
 	}
 }
 
+func TestTraceProtoOfBytes(t *testing.T) {
+	proto := NewPanBytes([]byte{1})
+
+	tests := []struct {
+		obj      PanObject
+		expected *PanBytes
+	}{
+		// return proto
+		{
+			NewPanObj(&map[SymHash]Pair{}, proto),
+			proto,
+		},
+		// return itself
+		{
+			proto,
+			proto,
+		},
+		// Bytes returns zero value Bytes.new([]) so that Bytes itself can be used as bytes object
+		{
+			BuiltInBytesObj,
+			zeroBytes,
+		},
+		// child of Bytes
+		{
+			NewPanObj(&map[SymHash]Pair{}, BuiltInBytesObj),
+			zeroBytes,
+		},
+	}
+
+	for _, tt := range tests {
+		actual, ok := TraceProtoOfBytes(tt.obj)
+
+		if !ok {
+			t.Errorf("ok must be true (obj=%v)", tt.obj)
+		}
+
+		if actual != tt.expected {
+			t.Errorf("proto must be %+v(%T). got=%+v(%T)",
+				tt.expected, tt.expected, actual, actual)
+		}
+	}
+}
+
+func TestTraceProtoOfBytesFailed(t *testing.T) {
+	tests := []struct {
+		obj PanObject
+	}{
+		{
+			PanObjInstancePtr(&map[SymHash]Pair{}),
+		},
+		{
+			NewPanStr("a"),
+		},
+	}
+
+	for _, tt := range tests {
+		actual, ok := TraceProtoOfBytes(tt.obj)
+
+		if ok {
+			t.Errorf("ok must be false (obj=%v)", tt.obj)
+		}
+
+		if actual != nil {
+			t.Errorf("actual must be nil. got=%+v(%T)", actual, actual)
+		}
+	}
+}
+
 func TestTraceProtoOfFloat(t *testing.T) {
 	proto := NewPanFloat(0.0)
 
@@ -795,6 +863,130 @@ func TestTraceProtoOfRangeFailed(t *testing.T) {
 	}
 }
 
+func TestTraceProtoOfRef(t *testing.T) {
+	proto := NewPanRef(NewPanInt(1))
+
+	tests := []struct {
+		obj      PanObject
+		expected *PanRef
+	}{
+		// return proto
+		{
+			NewPanObj(&map[SymHash]Pair{}, proto),
+			proto,
+		},
+		// return itself
+		{
+			proto,
+			proto,
+		},
+	}
+
+	for _, tt := range tests {
+		actual, ok := TraceProtoOfRef(tt.obj)
+
+		if !ok {
+			t.Errorf("ok must be true (obj=%v)", tt.obj)
+		}
+
+		if actual != tt.expected {
+			t.Errorf("proto must be %+v(%T). got=%+v(%T)",
+				tt.expected, tt.expected, actual, actual)
+		}
+	}
+}
+
+func TestTraceProtoOfRefFailed(t *testing.T) {
+	tests := []struct {
+		obj PanObject
+	}{
+		{
+			PanObjInstancePtr(&map[SymHash]Pair{}),
+		},
+		// Ref itself is not a ref
+		{
+			BuiltInRefObj,
+		},
+	}
+
+	for _, tt := range tests {
+		actual, ok := TraceProtoOfRef(tt.obj)
+
+		if ok {
+			t.Errorf("ok must be false (obj=%v)", tt.obj)
+		}
+
+		if actual != nil {
+			t.Errorf("actual must be nil. got=%+v(%T)", actual, actual)
+		}
+	}
+}
+
+func TestTraceProtoOfSet(t *testing.T) {
+	proto := NewPanSet()
+
+	tests := []struct {
+		obj      PanObject
+		expected *PanSet
+	}{
+		// return proto
+		{
+			NewPanObj(&map[SymHash]Pair{}, proto),
+			proto,
+		},
+		// return itself
+		{
+			proto,
+			proto,
+		},
+		// Set returns zero value Set.new([]) so that Set itself can be used as set object
+		{
+			BuiltInSetObj,
+			zeroSet,
+		},
+		// child of Set
+		{
+			NewPanObj(&map[SymHash]Pair{}, BuiltInSetObj),
+			zeroSet,
+		},
+	}
+
+	for _, tt := range tests {
+		actual, ok := TraceProtoOfSet(tt.obj)
+
+		if !ok {
+			t.Errorf("ok must be true (obj=%v)", tt.obj)
+		}
+
+		if actual != tt.expected {
+			t.Errorf("proto must be %+v(%T). got=%+v(%T)",
+				tt.expected, tt.expected, actual, actual)
+		}
+	}
+}
+
+func TestTraceProtoOfSetFailed(t *testing.T) {
+	tests := []struct {
+		obj PanObject
+	}{
+		{
+			PanObjInstancePtr(&map[SymHash]Pair{}),
+		},
+	}
+
+	for _, tt := range tests {
+		actual, ok := TraceProtoOfSet(tt.obj)
+
+		if ok {
+			t.Errorf("ok must be false (obj=%v)", tt.obj)
+		}
+
+		if actual != nil {
+			t.Errorf("actual must be nil. got=%+v(%T)", actual, actual)
+		}
+	}
+}
+
 func TestTraceProtoOfStr(t *testing.T) {
 	proto := NewPanStr("")
 
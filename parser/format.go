@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/macrat/simplexer"
+)
+
+// formatIndent is an indent of a nesting level written by Format.
+const formatIndent = "  "
+
+var (
+	// openers are tokens which increase the indentation depth.
+	openers = map[int]bool{
+		LPAREN: true, LBRACE: true, LBRACKET: true, MAP_LBRACE: true,
+		METHOD_MAP_LBRACE: true, METHOD_LBRACE: true, LITER: true, METHOD_LITER: true,
+		HEAD_STR_PIECE: true,
+	}
+	// closers are tokens which decrease the indentation depth.
+	closers = map[int]bool{
+		RPAREN: true, RBRACE: true, RBRACKET: true, RITER: true, TAIL_STR_PIECE: true,
+	}
+)
+
+// Format indents each line of src by the nesting depth of brackets.
+// Only whitespaces between tokens are changed, so that comments are kept:
+// trailing whitespaces are removed and consecutive blank lines are merged into one.
+// Lines in multi-line tokens (such as raw strs) are not changed.
+func Format(src string, fileName string) (string, error) {
+	program, err := Parse(NewReader(strings.NewReader(src), fileName))
+	if err != nil {
+		return "", err
+	}
+
+	tokens, err := lexAll(src, fileName)
+	if err != nil {
+		return "", err
+	}
+
+	formatted := formatLines(strings.Split(src, "\n"), tokens)
+
+	// NOTE: check the formatted source has the same meaning just in case
+	reformatted, err := Parse(NewReader(strings.NewReader(formatted), fileName))
+	if err != nil || reformatted.String() != program.String() {
+		return "", errors.New("failed to format: the formatted source differs from the original one")
+	}
+	return formatted, nil
+}
+
+// lexAll returns all tokens in src.
+func lexAll(src string, fileName string) (tokens []*simplexer.Token, e error) {
+	l := NewLexer(NewReader(strings.NewReader(src), fileName))
+
+	// NOTE: lexer errors are raised by panic (see Lexer.Error)
+	defer func() {
+		if err := recover(); err != nil {
+			e = fmt.Errorf("failed to lex: %v", err)
+		}
+	}()
+
+	for {
+		var lval yySymType
+		if l.Lex(&lval) < 0 {
+			return tokens, nil
+		}
+		tokens = append(tokens, lval.token)
+	}
+}
+
+func formatLines(lines []string, tokens []*simplexer.Token) string {
+	indents := map[int]int{}
+	codeLines := map[int]bool{}
+	// lines whose leading (or trailing) whitespaces are in multi-line tokens
+	fixedHeads := map[int]bool{}
+	fixedTails := map[int]bool{}
+
+	// indents of lines where unclosed openers are
+	// NOTE: lines in brackets are indented one more than the line of the opener
+	// even if the line has multiple openers like `f({`
+	openerIndents := []int{}
+	innerIndent := func() int {
+		if len(openerIndents) == 0 {
+			return 0
+		}
+		return openerIndents[len(openerIndents)-1] + 1
+	}
+
+	for _, tok := range tokens {
+		id := int(tok.Type.GetID())
+		line := tok.Position.Line
+		n := strings.Count(tok.Literal, "\n")
+
+		if id == RET {
+			// NOTE: lines in RET are blank lines or comments, which are indented as the next line
+			for i := 1; i <= n; i++ {
+				if !codeLines[line+i] {
+					indents[line+i] = innerIndent()
+				}
+			}
+			continue
+		}
+
+		if !codeLines[line] {
+			codeLines[line] = true
+			switch {
+			case fixedHeads[line]:
+				indents[line] = indentOf(lines[line])
+			case closers[id] && len(openerIndents) > 0:
+				indents[line] = openerIndents[len(openerIndents)-1]
+			default:
+				indents[line] = innerIndent()
+			}
+		}
+
+		if openers[id] {
+			openerIndents = append(openerIndents, indents[line])
+		}
+		if closers[id] && len(openerIndents) > 0 {
+			openerIndents = openerIndents[:len(openerIndents)-1]
+		}
+
+		for i := 0; i < n; i++ {
+			fixedTails[line+i] = true
+			fixedHeads[line+i+1] = true
+		}
+	}
+
+	formatted := []string{}
+	blank := true // NOTE: true to remove leading blank lines
+	for i, line := range lines {
+		// NOTE: keep CRLF line breaks
+		line, cr := strings.CutSuffix(line, "\r")
+		if !fixedTails[i] {
+			line = strings.TrimRight(line, " \t")
+		}
+		if !fixedHeads[i] {
+			line = strings.TrimLeft(line, " \t")
+			if line != "" {
+				line = strings.Repeat(formatIndent, indents[i]) + line
+			}
+		}
+
+		isBlank := line == "" && !fixedHeads[i]
+		if cr {
+			line += "\r"
+		}
+		if isBlank && blank {
+			continue
+		}
+		formatted = append(formatted, line)
+		blank = isBlank
+	}
+
+	// remove trailing blank lines
+	for len(formatted) > 0 && strings.TrimSuffix(formatted[len(formatted)-1], "\r") == "" {
+		formatted = formatted[:len(formatted)-1]
+	}
+	return strings.Join(formatted, "\n") + "\n"
+}
+
+// indentOf returns the indentation depth of the line (a tab is treated as an indent).
+func indentOf(line string) int {
+	spaces := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			spaces++
+		case '\t':
+			spaces += len(formatIndent)
+		default:
+			return spaces / len(formatIndent)
+		}
+	}
+	return spaces / len(formatIndent)
+}
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			"indent by brackets",
+			"o := {\na: [\n1,\n      2,\n],\n}\n",
+			"o := {\n  a: [\n    1,\n    2,\n  ],\n}\n",
+		},
+		{
+			"multiple openers in a line are indented once",
+			"f({|x|\nx * 2\n})\n",
+			"f({|x|\n  x * 2\n})\n",
+		},
+		{
+			"comments are kept",
+			"o := {\n      # doc of a\n  a: 1, # trailing\n}\n",
+			"o := {\n  # doc of a\n  a: 1, # trailing\n}\n",
+		},
+		{
+			"trailing spaces and extra blank lines are removed",
+			"\n\na := 1   \n\n\n\nb := 2\t\n\n",
+			"a := 1\n\nb := 2\n",
+		},
+		{
+			"lines in raw strs are kept",
+			"s := `a   \n      b\n\n\n`\n",
+			"s := `a   \n      b\n\n\n`\n",
+		},
+		{
+			"multi-line chains are kept",
+			"[1, 2]\n  |@{|x|\n  x + 1\n  }\n  |.sum\n",
+			"[1, 2]\n  |@{|x|\n    x + 1\n  }\n  |.sum\n",
+		},
+		{
+			"CRLF is kept",
+			"o := {\r\na: 1,\r\n\r\n\r\n}\r\n",
+			"o := {\r\n  a: 1,\r\n\r\n}\r\n",
+		},
+		{
+			"source without newline at the end",
+			"a := 1",
+			"a := 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Format(tt.input, "<stdin>")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if actual != tt.expected {
+				t.Errorf("wrong output. expected=%q, got=%q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	_, err := Format("a := [1, 2", "<stdin>")
+	if err == nil {
+		t.Fatalf("expected parse error did not occur")
+	}
+
+	if _, ok := err.(*Error); !ok {
+		t.Errorf("err must be *Error. got=%T", err)
+	}
+}
@@ -57,7 +57,7 @@ import (
 %type<argList> argList callArgs funcParams
 %type<exprList> exprList kwargExpansionList
 %type<ident> ident
-%type<chain> chain
+%type<chain> chain contextChain
 %type<recvAndChain> recvAndChain
 %type<formerStrPiece> formerStrPiece
 %type<token> opMethod breakLine
@@ -70,7 +70,7 @@ import (
 %token<token> DOUBLE_STAR PLUS MINUS STAR SLASH BANG DOUBLE_SLASH PERCENT
 %token<token> SPACESHIP EQ NEQ TOPIC_EQ TOPIC_NEQ LT LE GT GE
 %token<token> BIT_LSHIFT BIT_RSHIFT BIT_AND BIT_OR BIT_XOR BIT_NOT
-%token<token> AND OR IADD ISUB
+%token<token> AND OR IADD ISUB IN USER_OP
 %token<token> ADD_CHAIN MAIN_CHAIN MULTILINE_ADD_CHAIN MULTILINE_MAIN_CHAIN
 %token<token> IDENT PRIVATE_IDENT ARG_IDENT KWARG_IDENT
 %token<token> LPAREN RPAREN COMMA COLON LBRACE RBRACE VERT LBRACKET RBRACKET CARET
@@ -88,7 +88,8 @@ import (
 %right ASSIGN COMPOUND_ASSIGN
 %left OR
 %left AND
-%left SPACESHIP EQ NEQ TOPIC_EQ TOPIC_NEQ LT LE GT GE
+%left SPACESHIP EQ NEQ TOPIC_EQ TOPIC_NEQ LT LE GT GE IN
+%left USER_OP
 %left BIT_OR BIT_XOR
 %left BIT_AND
 %left BIT_LSHIFT BIT_RSHIFT
@@ -96,7 +97,7 @@ import (
 %left STAR SLASH DOUBLE_SLASH PERCENT
 %left DOUBLE_STAR
 %left MULTILINE_ADD_CHAIN MULTILINE_MAIN_CHAIN
-%left ADD_CHAIN MAIN_CHAIN
+%left ADD_CHAIN MAIN_CHAIN CARET
 %left UNARY_OP
 %left CALLING
 %left GROUPING
@@ -113,6 +114,7 @@ program
 	}
 	| RET stmts
 	{
+		yylex.(*Lexer).attachStmtDoc($1, $2[0], false)
 		$$ = &ast.Program{Stmts: $2}
 		yylex.(*Lexer).program = $$
 		yylex.(*Lexer).curRule = "program -> RET stmts"
@@ -138,6 +140,7 @@ stmts
 	}
 	| stmts breakLine stmt
 	{
+		yylex.(*Lexer).attachStmtDoc($2, $3, true)
 		$$ = append($1, $3)
 		yylex.(*Lexer).curRule = "stmts -> stmts breakLine stmt"
 	}
@@ -723,6 +726,28 @@ infixExpr
 		}
 		yylex.(*Lexer).curRule = "infixExpr -> expr GE expr"
 	}
+	| expr IN expr
+	{
+		$$ = &ast.InfixExpr{
+			Token: $2.Literal,
+			Left: $1,
+			Operator: $2.Literal,
+			Right: $3,
+			Src: yylex.(*Lexer).Source,
+		}
+		yylex.(*Lexer).curRule = "infixExpr -> expr IN expr"
+	}
+	| expr USER_OP expr
+	{
+		$$ = &ast.InfixExpr{
+			Token: $2.Literal,
+			Left: $1,
+			Operator: $2.Literal,
+			Right: $3,
+			Src: yylex.(*Lexer).Source,
+		}
+		yylex.(*Lexer).curRule = "infixExpr -> expr USER_OP expr"
+	}
 	| expr AND expr
 	{
 		$$ = &ast.InfixExpr{
@@ -885,6 +910,7 @@ objLiteral
 	}
 	| lBrace pairList RBRACE
 	{
+		yylex.(*Lexer).attachPairDoc($1, $2[0])
 		$$ = &ast.ObjLiteral{
 			Token: $1.Literal,
 			Pairs: $2,
@@ -894,6 +920,7 @@ objLiteral
 	}
 	| lBrace pairList RET RBRACE
 	{
+		yylex.(*Lexer).attachPairDoc($1, $2[0])
 		$$ = &ast.ObjLiteral{
 			Token: $1.Literal,
 			Pairs: $2,
@@ -903,6 +930,7 @@ objLiteral
 	}
 	| lBrace pairList comma RBRACE
 	{
+		yylex.(*Lexer).attachPairDoc($1, $2[0])
 		$$ = &ast.ObjLiteral{
 			Token: $1.Literal,
 			Pairs: $2,
@@ -939,6 +967,7 @@ objLiteral
 	}
 	| lBrace pairList comma kwargExpansionList RBRACE
 	{
+		yylex.(*Lexer).attachPairDoc($1, $2[0])
 		$$ = &ast.ObjLiteral{
 			Token: $1.Literal,
 			Pairs: $2,
@@ -948,6 +977,7 @@ objLiteral
 	}
 	| lBrace pairList comma kwargExpansionList RET RBRACE
 	{
+		yylex.(*Lexer).attachPairDoc($1, $2[0])
 		$$ = &ast.ObjLiteral{
 			Token: $1.Literal,
 			Pairs: $2,
@@ -957,6 +987,7 @@ objLiteral
 	}
 	| lBrace pairList comma kwargExpansionList comma RBRACE
 	{
+		yylex.(*Lexer).attachPairDoc($1, $2[0])
 		$$ = &ast.ObjLiteral{
 			Token: $1.Literal,
 			Pairs: $2,
@@ -1646,6 +1677,13 @@ recvAndChain
 			Chain: $1,
 		}
 	}
+	| expr contextChain
+	{
+		$$ = &ast.RecvAndChain{
+			Recv: $1,
+			Chain: $2,
+		}
+	}
 
 callArgs
 	: lParen RPAREN %prec GROUPING
@@ -1863,6 +1901,11 @@ opMethod
 		$$ = $1
 		yylex.(*Lexer).curRule = "opMethod -> ISUB"
 	}
+	| USER_OP
+	{
+		$$ = $1
+		yylex.(*Lexer).curRule = "opMethod -> USER_OP"
+	}
 
 chain
 	: ADD_CHAIN MAIN_CHAIN
@@ -1906,6 +1949,18 @@ chain
 		$$ = ast.MakeChain(ac, $2.Literal, $4)
 	}
 
+contextChain
+	: CARET IDENT MAIN_CHAIN
+	{
+		$$ = ast.MakeContextChain($2.Literal, $3.Literal, nil)
+		yylex.(*Lexer).curRule = "contextChain -> CARET IDENT MAIN_CHAIN"
+	}
+	| CARET IDENT MAIN_CHAIN lParen expr RPAREN
+	{
+		$$ = ast.MakeContextChain($2.Literal, $3.Literal, $5)
+		yylex.(*Lexer).curRule = "contextChain -> CARET IDENT MAIN_CHAIN lParen expr RPAREN"
+	}
+
 exprList
 	: exprList comma listElem
 	{
@@ -1952,6 +2007,7 @@ listElem
 pairList
 	: pairList comma pair
 	{
+		yylex.(*Lexer).attachPairDoc($2, $3)
 		$$ = append($1, $3)
 	}
 	| pair
@@ -1995,6 +2051,7 @@ lBrace
 	}
 	| LBRACE RET
 	{
+		yylex.(*Lexer).setDoc($1, $2)
 		$$ = $1
 		yylex.(*Lexer).curRule = "lBrace -> LBRACE RET"
 	}
@@ -2097,12 +2154,35 @@ comma
 	}
 	| COMMA RET
 	{
+		yylex.(*Lexer).setDoc($1, $2)
 		$$ = $1
 		yylex.(*Lexer).curRule = "comma -> COMMA RET"
 	}
 
 %%
 
+// ErrIncomplete is wrapped by the error Parse returns
+// if the source ended before an expression was completed.
+var ErrIncomplete = errors.New("incomplete source")
+
+// Error is an error occurred in parsing.
+type Error struct {
+	Msg string
+	// Pos is the position of the token where the error occurred (nil if it occurred before lexing)
+	Pos *ast.Position
+	// incomplete is true if the error is caused by the end of the source
+	incomplete bool
+}
+
+func (e *Error) Error() string { return e.Msg }
+
+func (e *Error) Unwrap() error {
+	if e.incomplete {
+		return ErrIncomplete
+	}
+	return nil
+}
+
 func Parse(src *Reader) (*ast.Program, error) {	
 	lexer := NewLexer(src)
 	prog, err := tryParse(src, lexer)
@@ -2130,14 +2210,16 @@ func tryParse(src io.Reader, l *Lexer) (a ast.Node, e error) {
 	defer func(l *Lexer) {
 		if err := recover(); err != nil {
 			m := "error occured:"
+			var pos *ast.Position
 			if l.Source != nil {
 				m = fmt.Sprintf("%s\n%s", m,
 					l.ErrMsg()) 
+				pos = &l.Source.Pos
 			} else {
 				// NOTE: err returned by recover() is type `any` (not `error`)!
 				m = m + fmt.Sprintf(" before lexing: %v", err)
 			}
-			e = errors.New(m)
+			e = &Error{Msg: m, Pos: pos, incomplete: l.eof}
 		}
 	}(l)
 	
@@ -2152,6 +2234,12 @@ type Lexer struct {
 	program      ast.Node
 	Source		 *ast.Source
 	curRule		 string
+	// eof is true if the lexer reached the end of the source
+	eof bool
+	// docs maps tokens followed by RET to doc comments in the RET
+	docs map[*simplexer.Token]string
+	// prevID is the id of the previous token (0 if nothing is lexed yet)
+	prevID int
 }
 
 func tokenTypes() []simplexer.TokenType{
@@ -2186,6 +2274,13 @@ func tokenTypes() []simplexer.TokenType{
 		"iSub": `\-%`,
 	}
 
+	// NOTE: these ops have no built-in meanings and can be defined freely as props
+	// (e.g. `{'<+>: m{|other| ...}}`)
+	userOps := []string{
+		`<\+>`, `<\->`, `<\*>`, `</>`, `<\$>`, `<\|>`, `<&>`,
+		`⊕`, `⊖`, `⊗`, `⊘`, `⊙`, `∘`, `×`, `÷`, `·`,
+	}
+
 	// NOTE: unary and comparison ops cannot be used for compound assign
 	// `&&` and `||` are not methodops but can be used for compound assign
 	compoundAssign := `(<<|>>|/&|/\||/\^|\+|\-|\*|\*\*|/|//|%|&&|\|\|)=`
@@ -2205,6 +2300,7 @@ func tokenTypes() []simplexer.TokenType{
 	for _, op := range methodOps {
 		methodOpTokens = append(methodOpTokens, op)
 	}
+	methodOpTokens = append(methodOpTokens, userOps...)
 
 	// sort by each token length (the longer, the earlier)
 	sort.Slice(methodOpTokens, func(i, j int) bool {
@@ -2248,6 +2344,7 @@ func tokenTypes() []simplexer.TokenType{
 		t(RET, ret),
 		t(COMPOUND_ASSIGN, compoundAssign),
 		t(SYMBOL, "'"+symbolable),
+		t(USER_OP, strings.Join(userOps, "|")),
 		t(SPACESHIP, methodOps["spaceship"]),
 		t(ASSIGN, `:=`),
 		t(RIGHT_ASSIGN, `=>`),
@@ -2303,6 +2400,9 @@ func tokenTypes() []simplexer.TokenType{
 		t(YIELD, `yield`),
 		t(RAISE, `raise`),
 		t(DEFER, `defer`),
+		// NOTE: `in` is an operator only if it is not a part of an ident (e.g. `index`)
+		// and it follows an operand (otherwise it is an ident, see Lex)
+		t(IN, `in\b`),
 		t(IDENT, ident),
 		t(PRIVATE_IDENT, fmt.Sprintf(`_+(%s)?`, ident)),
 	}
@@ -2339,21 +2439,92 @@ func NewLexer(reader *Reader) *Lexer {
 	// to use it stmts separator
 	l.Whitespace = simplexer.NewPatternTokenType(
 		-1, []string{" ", "\t"})
-	return &Lexer{lexer: l, fileName: reader.fileName}
+	return &Lexer{lexer: l, fileName: reader.fileName, docs: map[*simplexer.Token]string{}}
+}
+
+// setDoc saves doc comments in ret, which follows the token tok (such as `{` or `,`).
+func (l *Lexer) setDoc(tok *simplexer.Token, ret *simplexer.Token) {
+	if doc := docComment(ret.Literal, true); doc != "" {
+		l.docs[tok] = doc
+	}
+}
+
+// attachPairDoc attaches doc comments following tok to pair.
+// If the value is a func literal, the doc is also attached to it.
+func (l *Lexer) attachPairDoc(tok *simplexer.Token, pair *ast.Pair) {
+	doc, ok := l.docs[tok]
+	if !ok {
+		return
+	}
+
+	pair.Doc = doc
+	if f, ok := pair.Val.(*ast.FuncLiteral); ok {
+		f.Doc = doc
+	}
+}
+
+// attachStmtDoc attaches doc comments in ret to the func literal assigned in stmt
+// (such as `f := {|x| x}`).
+// afterToken is false only if ret is at the head of the source.
+func (l *Lexer) attachStmtDoc(ret *simplexer.Token, stmt ast.Stmt, afterToken bool) {
+	// NOTE: stmts may be separated by semicolons
+	if ret.Type.GetID() != RET {
+		return
+	}
+
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return
+	}
+	assign, ok := exprStmt.Expr.(*ast.AssignExpr)
+	if !ok {
+		return
+	}
+	if f, ok := assign.Right.(*ast.FuncLiteral); ok {
+		f.Doc = docComment(ret.Literal, afterToken)
+	}
+}
+
+// docComment returns the comment lines just before the next token in the RET literal.
+// Leading `#` and a space are removed from each line.
+// If afterToken is true, the first line is ignored because it is a trailing comment of the previous token.
+func docComment(ret string, afterToken bool) string {
+	lines := strings.Split(strings.ReplaceAll(ret, "\r", ""), "\n")
+	// NOTE: the last line is the indent of the next token
+	lines = lines[:len(lines)-1]
+	if afterToken && len(lines) > 0 {
+		lines = lines[1:]
+	}
+
+	// find consecutive comment lines from the last one
+	start := len(lines)
+	for start > 0 && strings.HasPrefix(strings.TrimSpace(lines[start-1]), "#") {
+		start--
+	}
+
+	comments := []string{}
+	for _, line := range lines[start:] {
+		comment := strings.TrimPrefix(strings.TrimSpace(line), "#")
+		comments = append(comments, strings.TrimPrefix(comment, " "))
+	}
+	return strings.Join(comments, "\n")
 }
 
 func (l *Lexer) Lex(lval *yySymType) int {
 	token, err := l.lexer.Scan()
 
 	if terr, ok := err.(*simplexer.UnknownTokenError); ok {
+		l.eof = isUnclosedRawStr(terr)
 		l.Error(l.unknownTokenErrMsg(terr))
 	} else if terr, ok := err.(simplexer.UnknownTokenError); ok {
+		l.eof = isUnclosedRawStr(&terr)
 		l.Error(l.unknownTokenErrMsg(&terr))
 	} else if err != nil {
 		l.Error(fmt.Sprintf("%s\n(type: %T: %+v)", err.Error(), err, err))
 	}
 
 	if token == nil {
+		l.eof = true
 		return -1
 	}
 
@@ -2367,6 +2538,13 @@ func (l *Lexer) Lex(lval *yySymType) int {
 		l.removeEmbeddedStrTokenTypes()
 	}
 
+	id := int(token.Type.GetID())
+	if id == IN && !endsOperand(l.prevID) {
+		// NOTE: `in` can be used as a name of variables, kwargs and props (e.g. `in := 1`, `{in: 1}.in`)
+		id = IDENT
+	}
+	l.prevID = id
+
 	lval.token = token
 	newSource := l.convertSourceInfo(token)
 	// NOTE: fix Line string because Line refers next line
@@ -2378,7 +2556,27 @@ func (l *Lexer) Lex(lval *yySymType) int {
 	}
 
 	l.Source = newSource
-	return int(token.Type.GetID())
+	return id
+}
+
+// endsOperand reports whether the token with id can be the last token of an operand.
+// Infix operator `in` only follows these tokens.
+func endsOperand(id int) bool {
+	switch id {
+	case IDENT, PRIVATE_IDENT, ARG_IDENT, KWARG_IDENT,
+		INT, HEX_INT, OCT_INT, BIN_INT, EXP_INT, FLOAT, EXP_FLOAT,
+		CHAR_STR, BACKQUOTE_STR, DOUBLEQUOTE_STR, TAIL_STR_PIECE, SYMBOL,
+		RPAREN, RBRACKET, RBRACE, RITER:
+		return true
+	default:
+		return false
+	}
+}
+
+// isUnclosedRawStr reports whether the unknown token is a backquoted str not closed yet.
+// (closed one is lexed as a RAW_STR token)
+func isUnclosedRawStr(err *simplexer.UnknownTokenError) bool {
+	return strings.HasPrefix(err.Literal, "`")
 }
 
 func (l *Lexer) unknownTokenErrMsg(err *simplexer.UnknownTokenError) string {
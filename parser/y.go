@@ -85,48 +85,50 @@ const AND = 57383
 const OR = 57384
 const IADD = 57385
 const ISUB = 57386
-const ADD_CHAIN = 57387
-const MAIN_CHAIN = 57388
-const MULTILINE_ADD_CHAIN = 57389
-const MULTILINE_MAIN_CHAIN = 57390
-const IDENT = 57391
-const PRIVATE_IDENT = 57392
-const ARG_IDENT = 57393
-const KWARG_IDENT = 57394
-const LPAREN = 57395
-const RPAREN = 57396
-const COMMA = 57397
-const COLON = 57398
-const LBRACE = 57399
-const RBRACE = 57400
-const VERT = 57401
-const LBRACKET = 57402
-const RBRACKET = 57403
-const CARET = 57404
-const MAP_LBRACE = 57405
-const METHOD_MAP_LBRACE = 57406
-const METHOD_LBRACE = 57407
-const LITER = 57408
-const RITER = 57409
-const METHOD_LITER = 57410
-const DIAMOND = 57411
-const RET = 57412
-const SEMICOLON = 57413
-const ASSIGN = 57414
-const COMPOUND_ASSIGN = 57415
-const RIGHT_ASSIGN = 57416
-const IF = 57417
-const ELSE = 57418
-const RETURN = 57419
-const RAISE = 57420
-const YIELD = 57421
-const DEFER = 57422
-const JUMP = 57423
-const JUMPIF = 57424
-const UNARY_OP = 57425
-const CALLING = 57426
-const GROUPING = 57427
-const INDEXING = 57428
+const IN = 57387
+const USER_OP = 57388
+const ADD_CHAIN = 57389
+const MAIN_CHAIN = 57390
+const MULTILINE_ADD_CHAIN = 57391
+const MULTILINE_MAIN_CHAIN = 57392
+const IDENT = 57393
+const PRIVATE_IDENT = 57394
+const ARG_IDENT = 57395
+const KWARG_IDENT = 57396
+const LPAREN = 57397
+const RPAREN = 57398
+const COMMA = 57399
+const COLON = 57400
+const LBRACE = 57401
+const RBRACE = 57402
+const VERT = 57403
+const LBRACKET = 57404
+const RBRACKET = 57405
+const CARET = 57406
+const MAP_LBRACE = 57407
+const METHOD_MAP_LBRACE = 57408
+const METHOD_LBRACE = 57409
+const LITER = 57410
+const RITER = 57411
+const METHOD_LITER = 57412
+const DIAMOND = 57413
+const RET = 57414
+const SEMICOLON = 57415
+const ASSIGN = 57416
+const COMPOUND_ASSIGN = 57417
+const RIGHT_ASSIGN = 57418
+const IF = 57419
+const ELSE = 57420
+const RETURN = 57421
+const RAISE = 57422
+const YIELD = 57423
+const DEFER = 57424
+const JUMP = 57425
+const JUMPIF = 57426
+const UNARY_OP = 57427
+const CALLING = 57428
+const GROUPING = 57429
+const INDEXING = 57430
 
 var yyToknames = [...]string{
 	"$end",
@@ -173,6 +175,8 @@ var yyToknames = [...]string{
 	"OR",
 	"IADD",
 	"ISUB",
+	"IN",
+	"USER_OP",
 	"ADD_CHAIN",
 	"MAIN_CHAIN",
 	"MULTILINE_ADD_CHAIN",
@@ -223,7 +227,29 @@ const yyEofCode = 1
 const yyErrCode = 2
 const yyInitialStackSize = 16
 
-//line ./parser/parser.go.y:2104
+//line ./parser/parser.go.y:2162
+
+// ErrIncomplete is wrapped by the error Parse returns
+// if the source ended before an expression was completed.
+var ErrIncomplete = errors.New("incomplete source")
+
+// Error is an error occurred in parsing.
+type Error struct {
+	Msg string
+	// Pos is the position of the token where the error occurred (nil if it occurred before lexing)
+	Pos *ast.Position
+	// incomplete is true if the error is caused by the end of the source
+	incomplete bool
+}
+
+func (e *Error) Error() string { return e.Msg }
+
+func (e *Error) Unwrap() error {
+	if e.incomplete {
+		return ErrIncomplete
+	}
+	return nil
+}
 
 func Parse(src *Reader) (*ast.Program, error) {
 	lexer := NewLexer(src)
@@ -252,14 +278,16 @@ func tryParse(src io.Reader, l *Lexer) (a ast.Node, e error) {
 	defer func(l *Lexer) {
 		if err := recover(); err != nil {
 			m := "error occured:"
+			var pos *ast.Position
 			if l.Source != nil {
 				m = fmt.Sprintf("%s\n%s", m,
 					l.ErrMsg())
+				pos = &l.Source.Pos
 			} else {
 				// NOTE: err returned by recover() is type `any` (not `error`)!
 				m = m + fmt.Sprintf(" before lexing: %v", err)
 			}
-			e = errors.New(m)
+			e = &Error{Msg: m, Pos: pos, incomplete: l.eof}
 		}
 	}(l)
 
@@ -274,6 +302,12 @@ type Lexer struct {
 	program ast.Node
 	Source  *ast.Source
 	curRule string
+	// eof is true if the lexer reached the end of the source
+	eof bool
+	// docs maps tokens followed by RET to doc comments in the RET
+	docs map[*simplexer.Token]string
+	// prevID is the id of the previous token (0 if nothing is lexed yet)
+	prevID int
 }
 
 func tokenTypes() []simplexer.TokenType {
@@ -308,6 +342,13 @@ func tokenTypes() []simplexer.TokenType {
 		"iSub":        `\-%`,
 	}
 
+	// NOTE: these ops have no built-in meanings and can be defined freely as props
+	// (e.g. `{'<+>: m{|other| ...}}`)
+	userOps := []string{
+		`<\+>`, `<\->`, `<\*>`, `</>`, `<\$>`, `<\|>`, `<&>`,
+		`⊕`, `⊖`, `⊗`, `⊘`, `⊙`, `∘`, `×`, `÷`, `·`,
+	}
+
 	// NOTE: unary and comparison ops cannot be used for compound assign
 	// `&&` and `||` are not methodops but can be used for compound assign
 	compoundAssign := `(<<|>>|/&|/\||/\^|\+|\-|\*|\*\*|/|//|%|&&|\|\|)=`
@@ -327,6 +368,7 @@ func tokenTypes() []simplexer.TokenType {
 	for _, op := range methodOps {
 		methodOpTokens = append(methodOpTokens, op)
 	}
+	methodOpTokens = append(methodOpTokens, userOps...)
 
 	// sort by each token length (the longer, the earlier)
 	sort.Slice(methodOpTokens, func(i, j int) bool {
@@ -370,6 +412,7 @@ func tokenTypes() []simplexer.TokenType {
 		t(RET, ret),
 		t(COMPOUND_ASSIGN, compoundAssign),
 		t(SYMBOL, "'"+symbolable),
+		t(USER_OP, strings.Join(userOps, "|")),
 		t(SPACESHIP, methodOps["spaceship"]),
 		t(ASSIGN, `:=`),
 		t(RIGHT_ASSIGN, `=>`),
@@ -425,6 +468,9 @@ func tokenTypes() []simplexer.TokenType {
 		t(YIELD, `yield`),
 		t(RAISE, `raise`),
 		t(DEFER, `defer`),
+		// NOTE: `in` is an operator only if it is not a part of an ident (e.g. `index`)
+		// and it follows an operand (otherwise it is an ident, see Lex)
+		t(IN, `in\b`),
 		t(IDENT, ident),
 		t(PRIVATE_IDENT, fmt.Sprintf(`_+(%s)?`, ident)),
 	}
@@ -461,21 +507,92 @@ func NewLexer(reader *Reader) *Lexer {
 	// to use it stmts separator
 	l.Whitespace = simplexer.NewPatternTokenType(
 		-1, []string{" ", "\t"})
-	return &Lexer{lexer: l, fileName: reader.fileName}
+	return &Lexer{lexer: l, fileName: reader.fileName, docs: map[*simplexer.Token]string{}}
+}
+
+// setDoc saves doc comments in ret, which follows the token tok (such as `{` or `,`).
+func (l *Lexer) setDoc(tok *simplexer.Token, ret *simplexer.Token) {
+	if doc := docComment(ret.Literal, true); doc != "" {
+		l.docs[tok] = doc
+	}
+}
+
+// attachPairDoc attaches doc comments following tok to pair.
+// If the value is a func literal, the doc is also attached to it.
+func (l *Lexer) attachPairDoc(tok *simplexer.Token, pair *ast.Pair) {
+	doc, ok := l.docs[tok]
+	if !ok {
+		return
+	}
+
+	pair.Doc = doc
+	if f, ok := pair.Val.(*ast.FuncLiteral); ok {
+		f.Doc = doc
+	}
+}
+
+// attachStmtDoc attaches doc comments in ret to the func literal assigned in stmt
+// (such as `f := {|x| x}`).
+// afterToken is false only if ret is at the head of the source.
+func (l *Lexer) attachStmtDoc(ret *simplexer.Token, stmt ast.Stmt, afterToken bool) {
+	// NOTE: stmts may be separated by semicolons
+	if ret.Type.GetID() != RET {
+		return
+	}
+
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return
+	}
+	assign, ok := exprStmt.Expr.(*ast.AssignExpr)
+	if !ok {
+		return
+	}
+	if f, ok := assign.Right.(*ast.FuncLiteral); ok {
+		f.Doc = docComment(ret.Literal, afterToken)
+	}
+}
+
+// docComment returns the comment lines just before the next token in the RET literal.
+// Leading `#` and a space are removed from each line.
+// If afterToken is true, the first line is ignored because it is a trailing comment of the previous token.
+func docComment(ret string, afterToken bool) string {
+	lines := strings.Split(strings.ReplaceAll(ret, "\r", ""), "\n")
+	// NOTE: the last line is the indent of the next token
+	lines = lines[:len(lines)-1]
+	if afterToken && len(lines) > 0 {
+		lines = lines[1:]
+	}
+
+	// find consecutive comment lines from the last one
+	start := len(lines)
+	for start > 0 && strings.HasPrefix(strings.TrimSpace(lines[start-1]), "#") {
+		start--
+	}
+
+	comments := []string{}
+	for _, line := range lines[start:] {
+		comment := strings.TrimPrefix(strings.TrimSpace(line), "#")
+		comments = append(comments, strings.TrimPrefix(comment, " "))
+	}
+	return strings.Join(comments, "\n")
 }
 
 func (l *Lexer) Lex(lval *yySymType) int {
 	token, err := l.lexer.Scan()
 
 	if terr, ok := err.(*simplexer.UnknownTokenError); ok {
+		l.eof = isUnclosedRawStr(terr)
 		l.Error(l.unknownTokenErrMsg(terr))
 	} else if terr, ok := err.(simplexer.UnknownTokenError); ok {
+		l.eof = isUnclosedRawStr(&terr)
 		l.Error(l.unknownTokenErrMsg(&terr))
 	} else if err != nil {
 		l.Error(fmt.Sprintf("%s\n(type: %T: %+v)", err.Error(), err, err))
 	}
 
 	if token == nil {
+		l.eof = true
 		return -1
 	}
 
@@ -489,6 +606,13 @@ func (l *Lexer) Lex(lval *yySymType) int {
 		l.removeEmbeddedStrTokenTypes()
 	}
 
+	id := int(token.Type.GetID())
+	if id == IN && !endsOperand(l.prevID) {
+		// NOTE: `in` can be used as a name of variables, kwargs and props (e.g. `in := 1`, `{in: 1}.in`)
+		id = IDENT
+	}
+	l.prevID = id
+
 	lval.token = token
 	newSource := l.convertSourceInfo(token)
 	// NOTE: fix Line string because Line refers next line
@@ -500,7 +624,27 @@ func (l *Lexer) Lex(lval *yySymType) int {
 	}
 
 	l.Source = newSource
-	return int(token.Type.GetID())
+	return id
+}
+
+// endsOperand reports whether the token with id can be the last token of an operand.
+// Infix operator `in` only follows these tokens.
+func endsOperand(id int) bool {
+	switch id {
+	case IDENT, PRIVATE_IDENT, ARG_IDENT, KWARG_IDENT,
+		INT, HEX_INT, OCT_INT, BIN_INT, EXP_INT, FLOAT, EXP_FLOAT,
+		CHAR_STR, BACKQUOTE_STR, DOUBLEQUOTE_STR, TAIL_STR_PIECE, SYMBOL,
+		RPAREN, RBRACKET, RBRACE, RITER:
+		return true
+	default:
+		return false
+	}
+}
+
+// isUnclosedRawStr reports whether the unknown token is a backquoted str not closed yet.
+// (closed one is lexed as a RAW_STR token)
+func isUnclosedRawStr(err *simplexer.UnknownTokenError) bool {
+	return strings.HasPrefix(err.Literal, "`")
 }
 
 func (l *Lexer) unknownTokenErrMsg(err *simplexer.UnknownTokenError) string {
@@ -573,103 +717,107 @@ var yyExca = [...]int8{
 
 const yyPrivate = 57344
 
-const yyLast = 2531
+const yyLast = 2908
 
 var yyAct = [...]int16{
-	67, 188, 235, 160, 162, 164, 23, 232, 293, 109,
-	166, 115, 83, 165, 2, 121, 122, 82, 54, 4,
-	254, 31, 277, 344, 121, 122, 81, 80, 354, 342,
-	116, 309, 349, 254, 303, 345, 47, 52, 48, 50,
-	49, 53, 51, 65, 62, 63, 64, 66, 350, 130,
-	163, 24, 25, 26, 254, 27, 265, 337, 237, 198,
-	175, 157, 179, 179, 132, 197, 196, 177, 177, 338,
-	195, 254, 28, 194, 170, 310, 193, 75, 76, 77,
-	78, 43, 44, 45, 46, 22, 311, 192, 274, 68,
-	173, 169, 74, 276, 168, 70, 71, 69, 72, 203,
-	73, 60, 290, 254, 161, 287, 254, 200, 319, 202,
-	117, 109, 109, 109, 109, 229, 254, 74, 291, 109,
-	233, 288, 279, 236, 109, 109, 109, 109, 109, 254,
-	254, 280, 270, 267, 157, 343, 254, 230, 254, 255,
-	250, 275, 246, 248, 271, 268, 306, 306, 68, 157,
-	157, 256, 247, 249, 254, 117, 69, 251, 306, 68,
-	172, 306, 355, 182, 184, 170, 352, 69, 109, 252,
-	254, 316, 313, 273, 304, 262, 236, 264, 260, 109,
-	299, 176, 169, 266, 258, 357, 356, 353, 308, 332,
-	109, 327, 109, 260, 159, 240, 201, 117, 317, 199,
-	306, 305, 129, 128, 59, 109, 109, 109, 109, 109,
-	109, 109, 109, 109, 109, 109, 109, 109, 109, 109,
-	109, 109, 109, 109, 109, 109, 109, 109, 109, 109,
-	282, 58, 284, 190, 55, 109, 35, 57, 56, 180,
-	61, 79, 109, 131, 109, 109, 109, 41, 253, 257,
-	114, 174, 43, 44, 45, 46, 119, 301, 302, 42,
-	109, 187, 298, 269, 272, 157, 40, 157, 230, 39,
-	230, 38, 37, 315, 302, 36, 281, 34, 8, 32,
-	179, 33, 85, 321, 109, 318, 109, 30, 110, 111,
-	112, 113, 331, 329, 29, 109, 236, 13, 109, 18,
-	17, 118, 21, 123, 124, 125, 126, 127, 109, 75,
-	76, 77, 78, 19, 20, 331, 16, 157, 15, 236,
-	230, 289, 292, 109, 14, 109, 7, 109, 6, 5,
-	1, 109, 0, 167, 109, 178, 109, 109, 0, 0,
-	189, 109, 109, 0, 0, 191, 75, 76, 77, 78,
-	0, 0, 109, 312, 0, 0, 0, 0, 0, 0,
-	0, 0, 204, 205, 206, 207, 208, 209, 210, 211,
-	212, 213, 214, 215, 216, 217, 218, 219, 220, 221,
-	222, 223, 224, 225, 226, 227, 228, 0, 0, 0,
-	339, 0, 0, 0, 0, 234, 0, 0, 0, 241,
-	243, 244, 0, 0, 346, 0, 0, 0, 245, 85,
-	90, 91, 86, 87, 0, 88, 89, 85, 292, 0,
-	86, 87, 0, 88, 89, 0, 92, 93, 94, 95,
-	96, 0, 0, 0, 0, 0, 75, 76, 77, 78,
-	0, 0, 259, 0, 75, 76, 77, 78, 234, 0,
-	0, 0, 47, 52, 48, 50, 49, 53, 51, 65,
-	62, 63, 64, 66, 0, 241, 163, 24, 25, 26,
-	0, 27, 0, 0, 0, 0, 0, 0, 0, 283,
-	0, 285, 0, 0, 0, 0, 0, 0, 28, 0,
-	170, 0, 0, 75, 76, 77, 78, 43, 44, 45,
-	46, 22, 0, 0, 0, 68, 158, 169, 74, 0,
-	168, 70, 71, 69, 72, 0, 73, 60, 294, 0,
-	0, 297, 0, 0, 0, 9, 10, 11, 12, 0,
-	0, 0, 178, 0, 0, 0, 0, 0, 0, 0,
-	307, 0, 0, 0, 0, 0, 0, 0, 178, 0,
-	0, 0, 85, 90, 91, 86, 87, 0, 88, 89,
-	189, 322, 0, 324, 0, 326, 0, 0, 330, 92,
-	93, 94, 333, 0, 335, 336, 0, 0, 0, 75,
-	76, 77, 78, 0, 0, 340, 0, 341, 0, 0,
-	0, 330, 47, 52, 48, 50, 49, 53, 51, 65,
-	62, 63, 64, 66, 0, 0, 0, 24, 25, 26,
-	0, 27, 0, 351, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 28, 0,
-	170, 0, 0, 75, 76, 77, 78, 43, 44, 45,
-	46, 22, 0, 0, 0, 68, 0, 169, 74, 0,
-	0, 70, 71, 69, 72, 183, 73, 60, 0, 0,
-	0, 0, 0, 0, 0, 9, 10, 11, 12, 47,
-	52, 48, 50, 49, 53, 51, 65, 62, 63, 64,
-	66, 0, 0, 0, 24, 25, 26, 0, 27, 0,
+	67, 23, 120, 165, 169, 282, 54, 243, 171, 111,
+	167, 193, 119, 240, 4, 302, 83, 170, 2, 125,
+	126, 82, 360, 262, 262, 31, 262, 354, 365, 347,
+	262, 125, 126, 352, 319, 81, 80, 318, 361, 355,
+	262, 348, 299, 262, 134, 320, 287, 296, 262, 162,
+	312, 273, 245, 203, 262, 288, 262, 278, 300, 275,
+	180, 184, 184, 297, 202, 182, 182, 262, 136, 279,
+	263, 276, 262, 201, 200, 259, 199, 198, 197, 205,
+	285, 207, 264, 284, 121, 68, 328, 260, 68, 353,
+	175, 366, 363, 69, 208, 121, 69, 75, 76, 77,
+	78, 315, 74, 325, 85, 90, 91, 86, 87, 174,
+	88, 89, 236, 315, 113, 111, 111, 111, 111, 322,
+	313, 308, 244, 111, 241, 315, 162, 274, 111, 111,
+	111, 111, 111, 75, 76, 77, 78, 266, 317, 258,
+	166, 162, 162, 367, 369, 238, 262, 254, 256, 283,
+	113, 262, 342, 337, 281, 364, 248, 121, 295, 315,
+	255, 257, 85, 90, 91, 86, 87, 326, 88, 89,
+	261, 265, 237, 111, 206, 270, 244, 204, 315, 92,
+	93, 94, 95, 96, 111, 277, 280, 268, 272, 59,
+	107, 75, 76, 77, 78, 111, 177, 111, 289, 187,
+	189, 314, 268, 164, 181, 133, 132, 290, 113, 292,
+	111, 111, 111, 111, 111, 111, 111, 111, 111, 111,
+	111, 111, 111, 111, 111, 111, 111, 111, 111, 111,
+	111, 111, 111, 111, 111, 111, 111, 43, 44, 45,
+	46, 195, 35, 111, 85, 58, 298, 301, 55, 57,
+	111, 56, 111, 111, 111, 61, 118, 79, 135, 41,
+	179, 162, 185, 162, 123, 310, 42, 112, 111, 192,
+	40, 307, 311, 75, 76, 77, 78, 39, 321, 38,
+	238, 324, 238, 37, 36, 34, 32, 184, 311, 33,
+	113, 327, 111, 30, 111, 29, 13, 18, 336, 8,
+	244, 330, 339, 17, 111, 21, 341, 111, 19, 114,
+	115, 116, 117, 20, 162, 16, 349, 111, 15, 14,
+	7, 6, 122, 244, 127, 128, 129, 130, 131, 341,
+	356, 5, 111, 238, 111, 1, 111, 0, 0, 0,
+	0, 111, 0, 0, 111, 301, 111, 111, 0, 0,
+	0, 111, 111, 0, 172, 0, 183, 0, 0, 0,
+	111, 194, 0, 111, 0, 0, 196, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 28, 0, 170, 0, 0,
-	75, 76, 77, 78, 43, 44, 45, 46, 22, 0,
-	0, 0, 68, 0, 169, 74, 0, 0, 70, 71,
-	69, 72, 181, 73, 60, 0, 0, 0, 0, 0,
-	0, 0, 9, 10, 11, 12, 47, 52, 48, 50,
-	49, 53, 51, 65, 62, 63, 64, 66, 0, 0,
-	0, 24, 25, 26, 0, 27, 0, 0, 0, 0,
+	0, 0, 0, 209, 210, 211, 212, 213, 214, 215,
+	216, 217, 218, 219, 220, 221, 222, 223, 224, 225,
+	226, 227, 228, 229, 230, 231, 232, 233, 234, 235,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 28, 0, 170, 0, 0, 75, 76, 77,
-	78, 43, 44, 45, 46, 22, 0, 0, 0, 68,
-	171, 169, 74, 0, 0, 70, 71, 69, 72, 0,
-	73, 60, 0, 0, 0, 0, 0, 0, 0, 9,
-	10, 11, 12, 47, 52, 48, 50, 49, 53, 51,
-	65, 62, 63, 64, 66, 0, 0, 0, 24, 25,
-	26, 0, 27, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 28,
-	0, 170, 0, 0, 75, 76, 77, 78, 43, 44,
-	45, 46, 22, 0, 0, 0, 68, 0, 169, 74,
-	0, 0, 70, 71, 69, 72, 0, 73, 60, 0,
+	242, 0, 0, 0, 249, 251, 252, 0, 0, 0,
+	0, 0, 0, 253, 0, 0, 0, 47, 52, 48,
+	50, 49, 53, 51, 65, 62, 63, 64, 66, 0,
+	0, 168, 24, 25, 26, 0, 27, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 267, 0,
+	0, 0, 0, 28, 242, 175, 0, 0, 0, 0,
+	75, 76, 77, 78, 43, 44, 45, 46, 22, 0,
+	0, 249, 68, 163, 174, 74, 0, 173, 70, 71,
+	69, 72, 0, 73, 60, 291, 0, 293, 0, 0,
+	0, 0, 9, 10, 11, 12, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 85, 90, 91, 86, 87,
+	0, 88, 89, 97, 98, 99, 100, 101, 102, 104,
+	103, 105, 92, 93, 94, 95, 96, 303, 108, 109,
+	306, 0, 106, 107, 75, 76, 77, 78, 0, 0,
+	0, 183, 0, 246, 0, 247, 0, 0, 0, 316,
+	0, 113, 0, 0, 0, 0, 0, 183, 0, 0,
+	0, 0, 0, 110, 84, 0, 0, 0, 0, 194,
+	331, 0, 333, 0, 335, 0, 0, 0, 340, 0,
+	0, 0, 343, 0, 345, 346, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 350, 0, 351, 0, 0,
+	0, 340, 47, 52, 48, 50, 49, 53, 51, 65,
+	62, 63, 64, 66, 0, 0, 359, 24, 25, 26,
+	0, 27, 0, 0, 362, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 28, 0,
+	175, 0, 0, 0, 0, 75, 76, 77, 78, 43,
+	44, 45, 46, 22, 0, 0, 0, 68, 0, 174,
+	74, 0, 0, 70, 71, 69, 72, 188, 73, 60,
+	0, 0, 0, 0, 0, 0, 0, 9, 10, 11,
+	12, 47, 52, 48, 50, 49, 53, 51, 65, 62,
+	63, 64, 66, 0, 0, 0, 24, 25, 26, 0,
+	27, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 28, 0, 175,
+	0, 0, 0, 0, 75, 76, 77, 78, 43, 44,
+	45, 46, 22, 0, 0, 0, 68, 0, 174, 74,
+	0, 0, 70, 71, 69, 72, 186, 73, 60, 0,
 	0, 0, 0, 0, 0, 0, 9, 10, 11, 12,
 	47, 52, 48, 50, 49, 53, 51, 65, 62, 63,
 	64, 66, 0, 0, 0, 24, 25, 26, 0, 27,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 28, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 28, 0, 175, 0,
+	0, 0, 0, 75, 76, 77, 78, 43, 44, 45,
+	46, 22, 0, 0, 0, 68, 176, 174, 74, 0,
+	0, 70, 71, 69, 72, 0, 73, 60, 0, 0,
+	0, 0, 0, 0, 0, 9, 10, 11, 12, 47,
+	52, 48, 50, 49, 53, 51, 65, 62, 63, 64,
+	66, 0, 0, 0, 24, 25, 26, 0, 27, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 28, 0, 175, 0, 0,
+	0, 0, 75, 76, 77, 78, 43, 44, 45, 46,
+	22, 0, 0, 0, 68, 0, 174, 74, 0, 0,
+	70, 71, 69, 72, 0, 73, 60, 0, 0, 0,
+	0, 0, 0, 0, 9, 10, 11, 12, 47, 52,
+	48, 50, 49, 53, 51, 65, 62, 63, 64, 66,
+	0, 0, 0, 24, 25, 26, 0, 27, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 28, 0, 0, 0, 0, 0,
 	0, 75, 76, 77, 78, 43, 44, 45, 46, 22,
 	0, 0, 0, 68, 0, 0, 74, 0, 0, 70,
 	71, 69, 72, 0, 73, 60, 3, 0, 0, 0,
@@ -677,207 +825,241 @@ var yyAct = [...]int16{
 	50, 49, 53, 51, 65, 62, 63, 64, 66, 0,
 	0, 0, 24, 25, 26, 0, 27, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 28, 0, 0, 0, 0, 75, 76,
-	77, 78, 43, 44, 45, 46, 22, 0, 0, 0,
-	68, 0, 0, 74, 0, 0, 70, 71, 69, 72,
-	0, 73, 60, 0, 0, 0, 0, 0, 0, 0,
-	9, 10, 11, 12, 47, 52, 48, 50, 49, 53,
-	51, 65, 62, 63, 64, 66, 0, 0, 163, 24,
-	25, 26, 0, 27, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	28, 0, 0, 0, 0, 75, 76, 77, 78, 43,
-	44, 45, 46, 22, 0, 0, 0, 68, 314, 0,
-	74, 0, 168, 70, 71, 69, 72, 0, 73, 60,
-	47, 52, 48, 50, 49, 53, 51, 65, 62, 63,
-	64, 66, 0, 0, 163, 24, 25, 26, 0, 27,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 28, 0, 0, 0,
-	0, 75, 76, 77, 78, 43, 44, 45, 46, 22,
-	0, 0, 0, 68, 300, 0, 74, 0, 168, 70,
-	71, 69, 72, 0, 73, 60, 85, 90, 91, 86,
+	0, 0, 0, 28, 0, 0, 0, 0, 0, 0,
+	75, 76, 77, 78, 43, 44, 45, 46, 22, 0,
+	0, 0, 68, 0, 0, 74, 0, 0, 70, 71,
+	69, 72, 0, 73, 60, 0, 0, 0, 0, 0,
+	0, 0, 9, 10, 11, 12, 85, 90, 91, 86,
 	87, 0, 88, 89, 97, 98, 99, 100, 101, 102,
-	104, 103, 105, 92, 93, 94, 95, 96, 0, 106,
-	107, 0, 0, 75, 76, 77, 78, 0, 0, 0,
-	0, 0, 238, 0, 239, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 108, 84, 85, 90, 91, 86, 87, 0,
-	88, 89, 97, 98, 99, 100, 101, 102, 104, 103,
-	105, 92, 93, 94, 95, 96, 0, 106, 107, 0,
-	0, 75, 76, 77, 78, 85, 90, 91, 86, 87,
-	348, 88, 89, 97, 98, 99, 100, 101, 102, 104,
-	103, 105, 92, 93, 94, 95, 96, 0, 106, 107,
-	108, 84, 75, 76, 77, 78, 85, 90, 91, 86,
-	87, 347, 88, 89, 97, 98, 99, 100, 101, 102,
-	104, 103, 105, 92, 93, 94, 95, 96, 0, 106,
-	107, 108, 84, 75, 76, 77, 78, 0, 0, 0,
+	104, 103, 105, 92, 93, 94, 95, 96, 0, 108,
+	109, 0, 0, 106, 107, 75, 76, 77, 78, 0,
+	0, 0, 0, 0, 368, 0, 0, 0, 0, 0,
+	0, 0, 113, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 110, 84, 85, 90, 91, 86,
+	87, 0, 88, 89, 97, 98, 99, 100, 101, 102,
+	104, 103, 105, 92, 93, 94, 95, 96, 0, 108,
+	109, 0, 0, 106, 107, 75, 76, 77, 78, 0,
+	0, 0, 0, 0, 358, 0, 0, 0, 0, 0,
+	0, 0, 113, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 110, 84, 85, 90, 91, 86,
+	87, 0, 88, 89, 97, 98, 99, 100, 101, 102,
+	104, 103, 105, 92, 93, 94, 95, 96, 0, 108,
+	109, 0, 0, 106, 107, 75, 76, 77, 78, 0,
+	0, 0, 0, 0, 357, 0, 0, 0, 0, 0,
+	0, 0, 113, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 110, 84, 85, 90, 91, 86,
+	87, 0, 88, 89, 97, 98, 99, 100, 101, 102,
+	104, 103, 105, 92, 93, 94, 95, 96, 0, 108,
+	109, 0, 0, 106, 107, 75, 76, 77, 78, 0,
+	0, 0, 0, 0, 0, 0, 344, 0, 0, 0,
+	0, 0, 113, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 110, 84, 85, 90, 91, 86,
+	87, 0, 88, 89, 97, 98, 99, 100, 101, 102,
+	104, 103, 105, 92, 93, 94, 95, 96, 0, 108,
+	109, 0, 0, 106, 107, 75, 76, 77, 78, 0,
 	0, 0, 0, 0, 334, 0, 0, 0, 0, 0,
-	47, 52, 48, 50, 49, 53, 51, 65, 62, 63,
-	64, 66, 108, 84, 163, 24, 25, 26, 0, 27,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 28, 0, 0, 0,
-	0, 75, 76, 77, 78, 43, 44, 45, 46, 22,
-	328, 0, 0, 68, 0, 0, 74, 0, 0, 70,
-	71, 69, 72, 0, 73, 60, 85, 90, 91, 86,
+	0, 0, 113, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 110, 84, 85, 90, 91, 86,
+	87, 0, 88, 89, 97, 98, 99, 100, 101, 102,
+	104, 103, 105, 92, 93, 94, 95, 96, 0, 108,
+	109, 0, 0, 106, 107, 75, 76, 77, 78, 0,
+	0, 0, 0, 0, 332, 0, 0, 0, 0, 0,
+	0, 0, 113, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 110, 84, 85, 90, 91, 86,
+	87, 0, 88, 89, 97, 98, 99, 100, 101, 102,
+	104, 103, 105, 92, 93, 94, 95, 96, 0, 108,
+	109, 0, 0, 106, 107, 75, 76, 77, 78, 0,
+	0, 0, 0, 0, 0, 0, 305, 0, 0, 0,
+	0, 0, 113, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 110, 84, 85, 90, 91, 86,
 	87, 0, 88, 89, 97, 98, 99, 100, 101, 102,
-	104, 103, 105, 92, 93, 94, 95, 96, 0, 106,
-	107, 0, 0, 75, 76, 77, 78, 85, 90, 91,
-	86, 87, 325, 88, 89, 97, 98, 99, 100, 101,
+	104, 103, 105, 92, 93, 94, 95, 96, 0, 108,
+	109, 0, 0, 106, 107, 75, 76, 77, 78, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 113, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 110, 0, 294, 85, 90, 91,
+	86, 87, 0, 88, 89, 97, 98, 99, 100, 101,
 	102, 104, 103, 105, 92, 93, 94, 95, 96, 0,
-	106, 107, 108, 84, 75, 76, 77, 78, 0, 0,
-	0, 0, 0, 323, 0, 0, 0, 0, 0, 0,
-	47, 52, 48, 50, 49, 53, 51, 65, 62, 63,
-	64, 66, 0, 108, 84, 24, 25, 26, 0, 27,
+	108, 109, 0, 0, 106, 107, 75, 76, 77, 78,
+	0, 0, 0, 0, 0, 0, 0, 247, 0, 0,
+	0, 0, 0, 113, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 110, 84, 85, 90, 91,
+	86, 87, 0, 88, 89, 97, 98, 99, 100, 101,
+	102, 104, 103, 105, 92, 93, 94, 95, 96, 0,
+	108, 109, 0, 0, 106, 107, 75, 76, 77, 78,
+	0, 0, 0, 0, 0, 0, 0, 269, 0, 0,
+	0, 0, 0, 113, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 110, 84, 47, 52, 48,
+	50, 49, 53, 51, 65, 62, 63, 64, 66, 0,
+	0, 168, 24, 25, 26, 0, 27, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 28, 0, 0, 0,
+	0, 0, 0, 28, 0, 175, 0, 0, 0, 0,
+	75, 76, 77, 78, 43, 44, 45, 46, 22, 0,
+	0, 0, 68, 178, 174, 74, 0, 173, 70, 71,
+	69, 72, 0, 73, 60, 85, 90, 91, 86, 87,
+	0, 88, 89, 97, 98, 99, 100, 101, 102, 104,
+	103, 105, 92, 93, 94, 95, 96, 0, 108, 109,
+	0, 0, 106, 107, 75, 76, 77, 78, 47, 52,
+	48, 50, 49, 53, 51, 65, 62, 63, 64, 66,
+	0, 113, 168, 24, 25, 26, 0, 27, 0, 0,
+	0, 0, 0, 110, 84, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 28, 0, 0, 0, 0, 0,
 	0, 75, 76, 77, 78, 43, 44, 45, 46, 22,
-	0, 0, 120, 68, 0, 0, 74, 320, 0, 70,
-	71, 69, 72, 0, 73, 60, 85, 90, 91, 86,
-	87, 0, 88, 89, 97, 98, 99, 100, 101, 102,
-	104, 103, 105, 92, 93, 94, 95, 96, 0, 106,
-	107, 0, 0, 75, 76, 77, 78, 0, 0, 0,
-	0, 0, 0, 0, 296, 0, 0, 0, 0, 0,
+	0, 0, 0, 68, 323, 0, 74, 0, 173, 70,
+	71, 69, 72, 0, 73, 60, 47, 52, 48, 50,
+	49, 53, 51, 65, 62, 63, 64, 66, 0, 0,
+	168, 24, 25, 26, 0, 27, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 108, 84, 85, 90, 91, 86, 87, 0,
+	0, 0, 28, 0, 0, 0, 0, 0, 0, 75,
+	76, 77, 78, 43, 44, 45, 46, 22, 0, 0,
+	0, 68, 309, 0, 74, 0, 173, 70, 71, 69,
+	72, 0, 73, 60, 85, 90, 91, 86, 87, 0,
 	88, 89, 97, 98, 99, 100, 101, 102, 104, 103,
-	105, 92, 93, 94, 95, 96, 0, 106, 107, 0,
-	0, 75, 76, 77, 78, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	108, 0, 286, 85, 90, 91, 86, 87, 0, 88,
-	89, 97, 98, 99, 100, 101, 102, 104, 103, 105,
-	92, 93, 94, 95, 96, 0, 106, 107, 0, 0,
-	75, 76, 77, 78, 0, 0, 0, 0, 0, 0,
-	0, 239, 0, 0, 0, 0, 47, 52, 48, 50,
-	49, 53, 51, 65, 62, 63, 64, 66, 0, 108,
-	84, 24, 25, 26, 0, 27, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 28, 0, 0, 0, 0, 75, 76, 77,
-	78, 43, 44, 45, 46, 22, 0, 0, 242, 68,
-	0, 0, 74, 278, 0, 70, 71, 69, 72, 0,
-	73, 60, 85, 90, 91, 86, 87, 0, 88, 89,
-	97, 98, 99, 100, 101, 102, 104, 103, 105, 92,
-	93, 94, 95, 96, 0, 106, 107, 0, 0, 75,
-	76, 77, 78, 0, 0, 0, 0, 0, 0, 0,
-	261, 0, 0, 0, 0, 0, 47, 52, 48, 50,
-	49, 53, 51, 65, 62, 63, 64, 66, 108, 84,
-	163, 24, 25, 26, 0, 27, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 28, 0, 0, 0, 0, 75, 76, 77,
-	78, 43, 44, 45, 46, 22, 231, 0, 0, 68,
-	0, 0, 74, 0, 0, 70, 71, 69, 72, 0,
-	73, 60, 47, 52, 48, 50, 49, 53, 51, 65,
-	62, 63, 64, 66, 0, 0, 0, 24, 25, 26,
-	0, 27, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 28, 0,
-	0, 0, 0, 75, 76, 77, 78, 43, 44, 45,
-	46, 22, 0, 0, 186, 68, 0, 0, 74, 185,
-	0, 70, 71, 69, 72, 0, 73, 60, 85, 90,
-	91, 86, 87, 0, 88, 89, 97, 98, 99, 100,
-	101, 102, 104, 103, 105, 92, 93, 94, 95, 96,
-	0, 106, 107, 0, 0, 75, 76, 77, 78, 47,
-	52, 48, 50, 49, 53, 51, 65, 62, 63, 64,
-	66, 0, 0, 0, 24, 25, 26, 0, 27, 0,
-	0, 0, 0, 0, 108, 84, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 28, 0, 0, 0, 0,
-	75, 76, 77, 78, 43, 44, 45, 46, 22, 0,
-	0, 295, 68, 0, 0, 74, 0, 0, 70, 71,
+	105, 92, 93, 94, 95, 96, 0, 108, 109, 0,
+	0, 106, 107, 75, 76, 77, 78, 47, 52, 48,
+	50, 49, 53, 51, 65, 62, 63, 64, 66, 0,
+	113, 168, 24, 25, 26, 0, 27, 0, 0, 0,
+	0, 0, 110, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 28, 0, 0, 0, 0, 0, 0,
+	75, 76, 77, 78, 43, 44, 45, 46, 22, 338,
+	0, 0, 68, 0, 0, 74, 0, 0, 70, 71,
 	69, 72, 0, 73, 60, 47, 52, 48, 50, 49,
 	53, 51, 65, 62, 63, 64, 66, 0, 0, 0,
 	24, 25, 26, 0, 27, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 28, 0, 0, 0, 0, 75, 76, 77, 78,
-	43, 44, 45, 46, 22, 0, 0, 0, 68, 0,
-	263, 74, 0, 0, 70, 71, 69, 72, 0, 73,
+	0, 28, 0, 0, 0, 0, 0, 0, 75, 76,
+	77, 78, 43, 44, 45, 46, 22, 0, 0, 124,
+	68, 0, 0, 74, 329, 0, 70, 71, 69, 72,
+	0, 73, 60, 47, 52, 48, 50, 49, 53, 51,
+	65, 62, 63, 64, 66, 0, 0, 0, 24, 25,
+	26, 0, 27, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 28,
+	0, 0, 0, 0, 0, 0, 75, 76, 77, 78,
+	43, 44, 45, 46, 22, 0, 0, 250, 68, 0,
+	0, 74, 286, 0, 70, 71, 69, 72, 0, 73,
 	60, 47, 52, 48, 50, 49, 53, 51, 65, 62,
-	63, 64, 66, 0, 0, 0, 24, 25, 26, 0,
+	63, 64, 66, 0, 0, 168, 24, 25, 26, 0,
 	27, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 28, 0, 0,
+	0, 0, 0, 0, 75, 76, 77, 78, 43, 44,
+	45, 46, 22, 239, 0, 0, 68, 0, 0, 74,
+	0, 0, 70, 71, 69, 72, 0, 73, 60, 47,
+	52, 48, 50, 49, 53, 51, 65, 62, 63, 64,
+	66, 0, 0, 0, 24, 25, 26, 0, 27, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 28, 0, 0, 0, 0,
 	0, 0, 75, 76, 77, 78, 43, 44, 45, 46,
-	22, 0, 0, 242, 68, 0, 0, 74, 0, 0,
+	22, 0, 0, 191, 68, 0, 0, 74, 190, 0,
 	70, 71, 69, 72, 0, 73, 60, 47, 52, 48,
 	50, 49, 53, 51, 65, 62, 63, 64, 66, 0,
 	0, 0, 24, 25, 26, 0, 27, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 28, 0, 0, 0, 0, 75, 76,
-	77, 78, 43, 44, 45, 46, 22, 0, 0, 120,
-	68, 0, 0, 74, 0, 0, 70, 71, 69, 72,
+	0, 0, 0, 28, 0, 0, 0, 0, 0, 0,
+	75, 76, 77, 78, 43, 44, 45, 46, 22, 0,
+	0, 304, 68, 0, 0, 74, 0, 0, 70, 71,
+	69, 72, 0, 73, 60, 47, 52, 48, 50, 49,
+	53, 51, 65, 62, 63, 64, 66, 0, 0, 0,
+	24, 25, 26, 0, 27, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 28, 0, 0, 0, 0, 0, 0, 75, 76,
+	77, 78, 43, 44, 45, 46, 22, 0, 0, 0,
+	68, 0, 271, 74, 0, 0, 70, 71, 69, 72,
 	0, 73, 60, 47, 52, 48, 50, 49, 53, 51,
 	65, 62, 63, 64, 66, 0, 0, 0, 24, 25,
 	26, 0, 27, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 28,
+	0, 0, 0, 0, 0, 0, 75, 76, 77, 78,
+	43, 44, 45, 46, 22, 0, 0, 250, 68, 0,
+	0, 74, 0, 0, 70, 71, 69, 72, 0, 73,
+	60, 47, 52, 48, 50, 49, 53, 51, 65, 62,
+	63, 64, 66, 0, 0, 0, 24, 25, 26, 0,
+	27, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 28, 0, 0,
 	0, 0, 0, 0, 75, 76, 77, 78, 43, 44,
-	45, 46, 22, 0, 0, 0, 68, 0, 0, 74,
-	0, 0, 70, 71, 69, 72, 0, 73, 60, 85,
-	90, 91, 86, 87, 0, 88, 89, 97, 98, 99,
-	100, 101, 102, 104, 103, 105, 92, 93, 94, 95,
-	96, 0, 106, 107, 0, 0, 75, 76, 77, 78,
-	0, 140, 134, 135, 136, 137, 154, 138, 139, 141,
-	142, 143, 0, 0, 147, 145, 146, 144, 148, 149,
-	150, 151, 152, 153, 0, 108, 155, 156, 0, 0,
-	0, 0, 43, 44, 45, 46, 0, 0, 0, 0,
-	68, 0, 0, 0, 0, 133, 0, 0, 69, 85,
-	90, 91, 86, 87, 0, 88, 89, 97, 98, 99,
+	45, 46, 22, 0, 0, 124, 68, 0, 0, 74,
+	0, 0, 70, 71, 69, 72, 0, 73, 60, 47,
+	52, 48, 50, 49, 53, 51, 65, 62, 63, 64,
+	66, 0, 0, 0, 24, 25, 26, 0, 27, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 28, 0, 0, 0, 0,
+	0, 0, 75, 76, 77, 78, 43, 44, 45, 46,
+	22, 0, 0, 0, 68, 0, 0, 74, 0, 0,
+	70, 71, 69, 72, 0, 73, 60, 144, 138, 139,
+	140, 141, 158, 142, 143, 145, 146, 147, 0, 0,
+	151, 149, 150, 148, 152, 153, 154, 155, 156, 157,
+	0, 0, 159, 160, 0, 161, 0, 0, 0, 0,
+	43, 44, 45, 46, 0, 0, 0, 0, 68, 0,
+	0, 0, 0, 137, 0, 0, 69, 85, 90, 91,
+	86, 87, 0, 88, 89, 97, 98, 99, 100, 101,
+	102, 104, 103, 105, 92, 93, 94, 95, 96, 0,
+	108, 109, 0, 0, 106, 107, 75, 76, 77, 78,
+	0, 0, 0, 0, 0, 0, 0, 0, 85, 90,
+	91, 86, 87, 113, 88, 89, 97, 98, 99, 100,
+	101, 102, 104, 103, 105, 92, 93, 94, 95, 96,
+	0, 108, 0, 0, 0, 106, 107, 75, 76, 77,
+	78, 0, 0, 0, 0, 0, 0, 0, 0, 85,
+	90, 91, 86, 87, 113, 88, 89, 97, 98, 99,
 	100, 101, 102, 104, 103, 105, 92, 93, 94, 95,
-	96, 0, 106, 107, 0, 0, 75, 76, 77, 78,
-	85, 90, 91, 86, 87, 0, 88, 89, 97, 98,
-	99, 100, 101, 102, 104, 103, 105, 92, 93, 94,
-	95, 96, 0, 106, 0, 0, 0, 75, 76, 77,
-	78, 85, 90, 91, 86, 87, 0, 88, 89, 97,
-	98, 99, 100, 101, 102, 104, 103, 105, 92, 93,
-	94, 95, 96, 0, 0, 0, 0, 0, 75, 76,
+	96, 0, 0, 0, 0, 0, 106, 107, 75, 76,
 	77, 78, 85, 90, 91, 86, 87, 0, 88, 89,
-	85, 90, 91, 86, 87, 0, 88, 89, 0, 92,
-	93, 0, 0, 0, 0, 0, 0, 0, 0, 75,
-	76, 77, 78, 0, 0, 0, 0, 75, 76, 77,
-	78,
+	85, 90, 91, 86, 87, 113, 88, 89, 0, 92,
+	93, 94, 95, 96, 0, 0, 0, 92, 93, 94,
+	0, 75, 76, 77, 78, 0, 0, 0, 0, 75,
+	76, 77, 78, 85, 90, 91, 86, 87, 113, 88,
+	89, 85, 0, 0, 86, 87, 113, 88, 89, 0,
+	92, 93, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 75, 76, 77, 78, 0, 0, 0, 0,
+	75, 76, 77, 78, 0, 0, 0, 0, 0, 113,
+	0, 0, 0, 0, 0, 0, 0, 113,
 }
 
 var yyPact = [...]int16{
-	896, -1000, -44, 973, -1000, -1000, -63, -1000, 1940, 2249,
-	2249, 2249, 2249, 57, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, 2183, -57, 2249, 2249, 2249, 2249, 2249, -1000,
+	934, -1000, -37, 1013, -1000, -1000, -61, -1000, 1747, 2575,
+	2575, 2575, 2575, 40, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, 2507, -55, 2575, 2575, 2575, 2575, 2575, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 189, 2629, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, 433, 776, 1693, 48, 697, 618,
+	-1000, 2235, -1000, -1000, -1000, -1000, 2575, -1000, 6, 5,
+	4, 2, 1, -8, -19, 129, 102, 126, 102, 1013,
+	-1000, -1000, -37, 2575, 2575, 2575, 2575, 2575, 2575, 2575,
+	2575, 2575, 2575, 2575, 2575, 2575, 2575, 2575, 2575, 2575,
+	2575, 2575, 2575, 2575, 2575, 2575, 2575, 2575, 2575, 2575,
+	186, -1000, -1000, 121, 1916, 1916, 1916, 1916, -1000, 26,
+	2167, -20, 507, 100, 2439, 2575, 2575, -1000, -1000, -1000,
+	-1000, -1000, -1000, 2575, 29, 29, -1000, 186, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, 186, 2333, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 448, 742, 32, 123, 665, 588,
-	-1000, 1888, -1000, -1000, -1000, -1000, 2249, -1000, 17, 6,
-	3, 0, -4, -5, -11, 153, 144, 150, 144, 973,
-	-1000, -1000, -44, 2249, 2249, 2249, 2249, 2249, 2249, 2249,
-	2249, 2249, 2249, 2249, 2249, 2249, 2249, 2249, 2249, 2249,
-	2249, 2249, 2249, 2249, 2249, 2249, 2249, 2249, 203, -1000,
-	2301, 2301, 2301, 2301, -1000, 91, 1822, -12, 1168, 141,
-	2117, 2249, 2249, -1000, -1000, -1000, -1000, -1000, -1000, 2249,
-	102, 102, -1000, 203, -1000, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 819, -1000, 99,
-	81, 126, -1000, 2249, 973, -44, -1000, 1764, 203, 2051,
-	-14, -1000, 125, -1000, 75, 74, 115, -1000, 1764, 973,
-	83, -1000, 26, -1000, -45, -1000, 1712, 61, -1000, 1655,
-	-1000, 1940, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 144,
-	2249, 144, 2249, -1000, 1940, 1596, 301, 264, 264, 264,
-	264, 399, 399, 2482, 2482, 2474, 534, 534, 391, 391,
-	391, 391, 391, 391, 391, 391, 391, 2443, 2412, -1000,
-	-1000, -1000, 51, 48, 1940, -1000, -48, -1000, -1000, 1985,
-	-1000, 1538, 2249, 2381, 2381, 1940, 91, -1000, 91, -1000,
-	144, -1000, 122, 1116, -36, -1000, 116, 143, -1000, 1940,
-	-44, 2249, 132, -39, 16, -1000, -1000, -1000, 114, 1050,
-	-1000, 113, 140, -1000, 123, -1000, -1000, -1000, -1000, -1000,
-	47, 1486, 2249, 1429, 2249, 1398, 2249, -1000, 137, 1346,
-	-1000, 135, 182, 2249, 1288, 2249, 2249, 1940, 91, -1000,
-	-1000, -1, -1000, -1000, -1000, -1000, 2249, 1940, 2249, -1000,
-	-41, 76, 2249, -1000, -1000, -35, -1000, -1000, -1000, -1000,
-	-1000, -1000, 1257, -1000, 1226, -1000, 2301, -1000, -1000, -22,
-	1940, -1000, -1000, 1940, 2249, 1940, 1940, -1000, 108, 129,
-	1940, 1940, -1000, -42, -1000, 104, 128, -1000, -1000, -1000,
-	131, 1940, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, 855, -1000, 15, 10, 77, -1000, 2575, 1013,
+	-37, -1000, 1619, 186, 2371, -21, -1000, 67, -1000, -1,
+	-3, 94, -1000, 1619, 1013, 89, -1000, 14, -1000, 11,
+	-1000, 2099, -17, -1000, 1559, -1000, 1747, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, 102, 2575, 102, 2575, -1000, 1747,
+	1498, 50, 226, 226, 226, 226, 2843, 2843, 86, 86,
+	2835, 2802, 2802, 144, 144, 144, 144, 144, 144, 144,
+	144, 144, 144, 2794, 2761, 2720, -1000, 110, -1000, -1000,
+	-9, -14, 1747, -1000, -43, -1000, -1000, 2303, -1000, 1438,
+	2575, 2679, 2679, 1747, 26, -1000, 26, -1000, 102, -1000,
+	61, 1862, -22, -1000, 60, 141, -1000, 1747, -37, 2575,
+	80, -35, -27, -1000, -1000, -1000, 59, 1794, -1000, 43,
+	107, -1000, 48, -1000, -1000, -1000, -1000, -1000, 23, 2031,
+	2575, 1378, 2575, 1318, 2575, 102, -1000, 97, 1963, -1000,
+	96, 160, 2575, 1258, 2575, 2575, 1747, 26, -1000, -1000,
+	-31, -1000, -1000, -1000, -1000, 2575, 1747, 2575, -1000, -39,
+	28, 2575, -1000, -1000, -33, -1000, -1000, -1000, -1000, -1000,
+	-1000, 1198, -1000, 1138, -1000, 1916, 2575, -1000, -1000, -34,
+	1747, -1000, -1000, 1747, 2575, 1747, 1747, -1000, 32, 95,
+	1747, 1747, -1000, -44, -1000, 31, 83, -1000, -1000, 1078,
+	-1000, 88, 1747, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
 }
 
 var yyPgo = [...]int16{
-	0, 330, 13, 19, 329, 328, 326, 278, 324, 318,
-	316, 314, 313, 302, 300, 299, 297, 294, 287, 21,
-	281, 279, 277, 236, 275, 272, 271, 269, 266, 233,
-	1, 104, 10, 181, 2, 4, 194, 7, 11, 5,
-	261, 3, 6, 0, 259, 247, 243, 241, 88, 18,
-	30, 240, 238, 237, 234, 231, 204,
+	0, 335, 17, 14, 331, 321, 320, 299, 319, 318,
+	315, 313, 308, 305, 303, 297, 296, 295, 293, 25,
+	289, 286, 285, 242, 284, 283, 279, 277, 270, 241,
+	11, 140, 8, 204, 7, 10, 203, 13, 12, 4,
+	269, 3, 1, 0, 267, 266, 259, 258, 257, 5,
+	6, 2, 255, 251, 249, 248, 245, 189,
 }
 
 var yyR1 = [...]int8{
@@ -888,23 +1070,24 @@ var yyR1 = [...]int8{
 	15, 15, 15, 15, 17, 17, 17, 17, 17, 18,
 	18, 14, 14, 8, 8, 8, 8, 8, 8, 8,
 	8, 8, 8, 8, 8, 8, 8, 8, 8, 8,
-	8, 8, 8, 8, 8, 8, 9, 9, 9, 9,
-	9, 10, 10, 10, 13, 24, 24, 24, 24, 24,
-	24, 24, 24, 24, 24, 25, 25, 25, 25, 25,
-	25, 25, 25, 25, 25, 23, 23, 23, 23, 23,
-	26, 26, 26, 27, 28, 29, 29, 29, 29, 29,
-	29, 29, 12, 45, 45, 19, 19, 19, 20, 20,
-	20, 20, 21, 21, 33, 33, 31, 31, 31, 32,
-	22, 39, 39, 39, 39, 39, 39, 39, 39, 11,
-	11, 11, 11, 11, 11, 11, 11, 11, 11, 44,
-	44, 38, 38, 38, 38, 38, 38, 38, 38, 38,
-	46, 46, 46, 46, 46, 46, 46, 46, 46, 46,
-	46, 46, 46, 46, 46, 46, 46, 46, 46, 46,
-	46, 46, 46, 43, 43, 43, 43, 43, 43, 43,
-	43, 40, 40, 37, 37, 37, 37, 30, 30, 36,
-	36, 41, 41, 34, 35, 35, 49, 49, 50, 50,
-	51, 51, 53, 53, 52, 52, 54, 54, 55, 55,
-	56, 56, 47, 47, 48, 48,
+	8, 8, 8, 8, 8, 8, 8, 8, 9, 9,
+	9, 9, 9, 10, 10, 10, 13, 24, 24, 24,
+	24, 24, 24, 24, 24, 24, 24, 25, 25, 25,
+	25, 25, 25, 25, 25, 25, 25, 23, 23, 23,
+	23, 23, 26, 26, 26, 27, 28, 29, 29, 29,
+	29, 29, 29, 29, 12, 46, 46, 19, 19, 19,
+	20, 20, 20, 20, 21, 21, 33, 33, 31, 31,
+	31, 32, 22, 39, 39, 39, 39, 39, 39, 39,
+	39, 11, 11, 11, 11, 11, 11, 11, 11, 11,
+	11, 45, 45, 45, 38, 38, 38, 38, 38, 38,
+	38, 38, 38, 47, 47, 47, 47, 47, 47, 47,
+	47, 47, 47, 47, 47, 47, 47, 47, 47, 47,
+	47, 47, 47, 47, 47, 47, 47, 43, 43, 43,
+	43, 43, 43, 43, 43, 44, 44, 40, 40, 37,
+	37, 37, 37, 30, 30, 36, 36, 41, 41, 34,
+	35, 35, 50, 50, 51, 51, 52, 52, 54, 54,
+	53, 53, 55, 55, 56, 56, 57, 57, 48, 48,
+	49, 49,
 }
 
 var yyR2 = [...]int8{
@@ -915,62 +1098,64 @@ var yyR2 = [...]int8{
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 3, 5, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 2, 2, 2, 2,
-	2, 3, 3, 3, 2, 2, 3, 4, 4, 3,
-	4, 4, 5, 6, 6, 2, 3, 4, 4, 3,
-	4, 4, 5, 6, 6, 2, 3, 3, 4, 4,
-	1, 1, 1, 1, 3, 5, 3, 4, 4, 2,
-	2, 3, 2, 3, 2, 3, 2, 3, 2, 3,
-	2, 3, 3, 3, 3, 1, 1, 1, 1, 2,
-	1, 2, 1, 3, 4, 3, 2, 4, 5, 2,
-	3, 3, 2, 3, 3, 2, 3, 4, 2, 2,
-	1, 2, 3, 4, 4, 3, 4, 5, 6, 2,
+	3, 3, 3, 3, 3, 3, 3, 3, 2, 2,
+	2, 2, 2, 3, 3, 3, 2, 2, 3, 4,
+	4, 3, 4, 4, 5, 6, 6, 2, 3, 4,
+	4, 3, 4, 4, 5, 6, 6, 2, 3, 3,
+	4, 4, 1, 1, 1, 1, 3, 5, 3, 4,
+	4, 2, 2, 3, 2, 3, 2, 3, 2, 3,
+	2, 3, 2, 3, 3, 3, 3, 1, 1, 1,
+	1, 2, 1, 2, 1, 3, 4, 3, 2, 4,
+	5, 2, 3, 3, 2, 3, 3, 2, 3, 4,
+	2, 2, 1, 2, 2, 3, 4, 4, 3, 4,
+	5, 6, 2, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 2, 1, 4, 5, 2, 1, 4,
-	5, 3, 1, 3, 3, 1, 1, 1, 1, 3,
-	1, 4, 2, 3, 3, 4, 1, 2, 1, 2,
-	1, 2, 1, 2, 1, 2, 1, 2, 1, 2,
-	1, 2, 1, 1, 1, 2,
+	1, 1, 1, 1, 1, 1, 1, 2, 1, 4,
+	5, 2, 1, 4, 5, 3, 6, 3, 1, 3,
+	3, 1, 1, 1, 1, 3, 1, 4, 2, 3,
+	3, 4, 1, 2, 1, 2, 1, 2, 1, 2,
+	1, 2, 1, 2, 1, 2, 1, 2, 1, 1,
+	1, 2,
 }
 
 var yyChk = [...]int16{
-	-1000, -1, -2, 70, -3, -4, -5, -6, -7, 77,
-	78, 79, 80, -16, -8, -9, -10, -14, -15, -12,
-	-11, -13, 53, -42, 19, 20, 21, 23, 40, -17,
+	-1000, -1, -2, 72, -3, -4, -5, -6, -7, 79,
+	80, 81, 82, -16, -8, -9, -10, -14, -15, -12,
+	-11, -13, 55, -42, 19, 20, 21, 23, 40, -17,
 	-18, -19, -21, -20, -22, -23, -24, -25, -26, -27,
-	-28, -45, -44, 49, 50, 51, 52, 4, 6, 8,
-	7, 10, 5, 9, -49, -54, -52, -53, -55, -56,
-	69, -51, 12, 13, 14, 11, 15, -43, 57, 65,
-	63, 64, 66, 68, 60, 45, 46, 47, 48, -47,
-	71, 70, -2, 75, 75, 18, 21, 22, 24, 25,
+	-28, -46, -45, 51, 52, 53, 54, 4, 6, 8,
+	7, 10, 5, 9, -50, -55, -53, -54, -56, -57,
+	71, -52, 12, 13, 14, 11, 15, -43, 59, 67,
+	65, 66, 68, 70, 62, 47, 48, 49, 50, -48,
+	73, 72, -2, 77, 77, 18, 21, 22, 24, 25,
 	19, 20, 35, 36, 37, 38, 39, 26, 27, 28,
-	29, 30, 31, 33, 32, 34, 41, 42, 74, -43,
-	-7, -7, -7, -7, -23, -38, -50, 53, -7, -29,
-	56, 72, 73, -7, -7, -7, -7, -7, 17, 16,
-	-42, -46, -19, 62, 19, 20, 21, 22, 24, 25,
-	18, 26, 27, 28, 34, 32, 33, 31, 35, 36,
-	37, 38, 39, 40, 23, 43, 44, -49, 58, -36,
-	-41, -31, -35, 18, -39, -2, -32, -7, 62, 59,
-	42, 58, -31, 58, -36, -41, -33, -32, -7, -39,
-	-33, 67, -31, 67, -31, 61, 56, -40, -30, -7,
-	-29, -7, 70, 70, 70, 70, 70, 70, 70, 46,
-	-50, 46, -50, -3, -7, -7, -7, -7, -7, -7,
+	29, 30, 31, 33, 32, 34, 45, 46, 41, 42,
+	76, -43, -44, 64, -7, -7, -7, -7, -23, -38,
+	-51, 55, -7, -29, 58, 74, 75, -7, -7, -7,
+	-7, -7, 17, 16, -42, -47, -19, 64, 19, 20,
+	21, 22, 24, 25, 18, 26, 27, 28, 34, 32,
+	33, 31, 35, 36, 37, 38, 39, 40, 23, 43,
+	44, 46, -50, 60, -36, -41, -31, -35, 18, -39,
+	-2, -32, -7, 64, 61, 42, 60, -31, 60, -36,
+	-41, -33, -32, -7, -39, -33, 69, -31, 69, -31,
+	63, 58, -40, -30, -7, -29, -7, 72, 72, 72,
+	72, 72, 72, 72, 48, -51, 48, -51, -3, -7,
+	-7, -7, -7, -7, -7, -7, -7, -7, -7, -7,
 	-7, -7, -7, -7, -7, -7, -7, -7, -7, -7,
-	-7, -7, -7, -7, -7, -7, -7, -7, -7, -42,
-	-19, 54, -37, -41, -7, -34, -42, 70, 54, 56,
-	54, -7, 56, -7, -7, -7, -38, -19, -38, -19,
-	-42, 58, 70, -48, 55, 58, 70, -48, 58, -7,
-	-2, 56, -42, 59, -37, 70, 58, 58, 70, -48,
-	58, 70, -48, 58, -48, 58, 67, 67, 61, 61,
-	70, -48, -50, -7, -50, -7, 76, 54, 70, -48,
-	54, 70, -48, 56, -7, 56, 56, -7, -38, 58,
-	58, -41, -35, 70, 58, 58, 18, -7, 56, 70,
-	59, 70, -48, 58, 58, -41, 58, 58, -32, 61,
-	61, -30, -7, 54, -7, 54, -7, 54, 54, -41,
-	-7, -34, 54, -7, 56, -7, -7, 58, 70, -48,
-	-7, -7, 70, 59, 58, 70, -48, 54, 54, 54,
-	70, -7, 58, 58, 70, 58, 58, 54,
+	-7, -7, -7, -7, -7, -7, -42, 51, -19, 56,
+	-37, -41, -7, -34, -42, 72, 56, 58, 56, -7,
+	58, -7, -7, -7, -38, -19, -38, -19, -42, 60,
+	72, -49, 57, 60, 72, -49, 60, -7, -2, 58,
+	-42, 61, -37, 72, 60, 60, 72, -49, 60, 72,
+	-49, 60, -49, 60, 69, 69, 63, 63, 72, -49,
+	-51, -7, -51, -7, 78, 48, 56, 72, -49, 56,
+	72, -49, 58, -7, 58, 58, -7, -38, 60, 60,
+	-41, -35, 72, 60, 60, 18, -7, 58, 72, 61,
+	72, -49, 60, 60, -41, 60, 60, -32, 63, 63,
+	-30, -7, 56, -7, 56, -7, -51, 56, 56, -41,
+	-7, -34, 56, -7, 58, -7, -7, 60, 72, -49,
+	-7, -7, 72, 61, 60, 72, -49, 56, 56, -7,
+	56, 72, -7, 60, 60, 72, 60, 60, 56, 56,
 }
 
 var yyDef = [...]int16{
@@ -980,36 +1165,37 @@ var yyDef = [...]int16{
 	33, 34, 35, 36, 37, 38, 39, 40, 41, 42,
 	43, 0, 0, 28, 29, 30, 31, 44, 45, 46,
 	47, 48, 49, 50, 0, 0, 0, 0, 0, 0,
-	140, 0, 110, 111, 112, 113, 0, 160, 216, 226,
-	224, 222, 228, 230, 220, 0, 194, 0, 198, 7,
-	232, 233, 2, 0, 0, 0, 0, 0, 0, 0,
+	142, 0, 112, 113, 114, 115, 0, 162, 222, 232,
+	230, 228, 234, 236, 226, 0, 198, 0, 202, 7,
+	238, 239, 2, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 159,
-	13, 14, 15, 16, 84, 158, 0, 218, 0, 0,
-	0, 0, 0, 76, 77, 78, 79, 80, 122, 0,
-	149, 152, 155, 0, 170, 171, 172, 173, 174, 175,
-	176, 177, 178, 179, 180, 181, 182, 183, 184, 185,
-	186, 187, 188, 189, 190, 191, 192, 0, 85, 0,
-	0, 0, 210, 0, 136, 137, 138, 11, 0, 0,
-	142, 126, 0, 95, 0, 0, 0, 135, 0, 0,
-	0, 128, 0, 130, 0, 105, 0, 0, 202, 207,
-	208, 124, 217, 227, 225, 223, 229, 231, 221, 193,
-	0, 197, 0, 6, 12, 51, 53, 54, 55, 56,
-	57, 58, 59, 60, 61, 62, 63, 64, 65, 66,
-	67, 68, 69, 70, 71, 72, 73, 74, 75, 83,
-	169, 161, 0, 0, 205, 206, 27, 219, 26, 119,
-	114, 120, 0, 81, 82, 123, 150, 151, 153, 154,
-	156, 86, 0, 0, 234, 89, 0, 0, 125, 212,
-	139, 0, 0, 141, 0, 146, 127, 96, 0, 0,
-	99, 0, 0, 132, 0, 133, 129, 131, 106, 107,
-	0, 0, 0, 0, 0, 0, 0, 162, 0, 0,
-	165, 0, 0, 0, 116, 0, 0, 121, 157, 87,
-	88, 0, 209, 235, 90, 91, 0, 214, 0, 145,
-	143, 0, 0, 97, 98, 0, 100, 101, 134, 108,
-	109, 201, 0, 195, 0, 199, 52, 163, 164, 0,
-	203, 204, 166, 213, 0, 117, 118, 92, 0, 0,
-	211, 215, 147, 144, 102, 0, 0, 196, 200, 167,
-	0, 115, 93, 94, 148, 103, 104, 168,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 161, 163, 0, 13, 14, 15, 16, 86, 160,
+	0, 224, 0, 0, 0, 0, 0, 78, 79, 80,
+	81, 82, 124, 0, 151, 154, 157, 0, 173, 174,
+	175, 176, 177, 178, 179, 180, 181, 182, 183, 184,
+	185, 186, 187, 188, 189, 190, 191, 192, 193, 194,
+	195, 196, 0, 87, 0, 0, 0, 216, 0, 138,
+	139, 140, 11, 0, 0, 144, 128, 0, 97, 0,
+	0, 0, 137, 0, 0, 0, 130, 0, 132, 0,
+	107, 0, 0, 208, 213, 214, 126, 223, 233, 231,
+	229, 235, 237, 227, 197, 0, 201, 0, 6, 12,
+	51, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
+	72, 73, 74, 75, 76, 77, 85, 0, 172, 164,
+	0, 0, 211, 212, 27, 225, 26, 121, 116, 122,
+	0, 83, 84, 125, 152, 153, 155, 156, 158, 88,
+	0, 0, 240, 91, 0, 0, 127, 218, 141, 0,
+	0, 143, 0, 148, 129, 98, 0, 0, 101, 0,
+	0, 134, 0, 135, 131, 133, 108, 109, 0, 0,
+	0, 0, 0, 0, 0, 205, 165, 0, 0, 168,
+	0, 0, 0, 118, 0, 0, 123, 159, 89, 90,
+	0, 215, 241, 92, 93, 0, 220, 0, 147, 145,
+	0, 0, 99, 100, 0, 102, 103, 136, 110, 111,
+	207, 0, 199, 0, 203, 52, 0, 166, 167, 0,
+	209, 210, 169, 219, 0, 119, 120, 94, 0, 0,
+	217, 221, 149, 146, 104, 0, 0, 200, 204, 0,
+	170, 0, 117, 95, 96, 150, 105, 106, 206, 171,
 }
 
 var yyTok1 = [...]int8{
@@ -1025,7 +1211,7 @@ var yyTok2 = [...]int8{
 	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
 	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
 	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
-	82, 83, 84, 85, 86,
+	82, 83, 84, 85, 86, 87, 88,
 }
 
 var yyTok3 = [...]int8{
@@ -1371,7 +1557,7 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:109
+//line ./parser/parser.go.y:110
 		{
 			yyVAL.program = &ast.Program{Stmts: yyDollar[1].stmts}
 			yylex.(*Lexer).program = yyVAL.program
@@ -1379,15 +1565,16 @@ yydefault:
 		}
 	case 2:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:115
+//line ./parser/parser.go.y:116
 		{
+			yylex.(*Lexer).attachStmtDoc(yyDollar[1].token, yyDollar[2].stmts[0], false)
 			yyVAL.program = &ast.Program{Stmts: yyDollar[2].stmts}
 			yylex.(*Lexer).program = yyVAL.program
 			yylex.(*Lexer).curRule = "program -> RET stmts"
 		}
 	case 3:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:121
+//line ./parser/parser.go.y:123
 		{
 			yyVAL.program = &ast.Program{Stmts: []ast.Stmt{}}
 			yylex.(*Lexer).program = yyVAL.program
@@ -1395,7 +1582,7 @@ yydefault:
 		}
 	case 4:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line ./parser/parser.go.y:127
+//line ./parser/parser.go.y:129
 		{
 			yyVAL.program = &ast.Program{Stmts: []ast.Stmt{}}
 			yylex.(*Lexer).program = yyVAL.program
@@ -1403,49 +1590,50 @@ yydefault:
 		}
 	case 5:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:135
+//line ./parser/parser.go.y:137
 		{
 			yyVAL.stmts = []ast.Stmt{yyDollar[1].stmt}
 			yylex.(*Lexer).curRule = "stmts -> stmt"
 		}
 	case 6:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:140
+//line ./parser/parser.go.y:142
 		{
+			yylex.(*Lexer).attachStmtDoc(yyDollar[2].token, yyDollar[3].stmt, true)
 			yyVAL.stmts = append(yyDollar[1].stmts, yyDollar[3].stmt)
 			yylex.(*Lexer).curRule = "stmts -> stmts breakLine stmt"
 		}
 	case 7:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:145
+//line ./parser/parser.go.y:148
 		{
 			yyVAL.stmts = yyDollar[1].stmts
 			yylex.(*Lexer).curRule = "stmts -> stmts breakLine"
 		}
 	case 8:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:152
+//line ./parser/parser.go.y:155
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 			yylex.(*Lexer).curRule = "stmt -> exprStmt"
 		}
 	case 9:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:157
+//line ./parser/parser.go.y:160
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 			yylex.(*Lexer).curRule = "stmt -> jumpStmt"
 		}
 	case 10:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:162
+//line ./parser/parser.go.y:165
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 			yylex.(*Lexer).curRule = "stmt -> jumpIfStmt"
 		}
 	case 11:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:169
+//line ./parser/parser.go.y:172
 		{
 			yyVAL.stmt = &ast.ExprStmt{
 				Token: "(exprStmt)",
@@ -1456,7 +1644,7 @@ yydefault:
 		}
 	case 12:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:180
+//line ./parser/parser.go.y:183
 		{
 			yyVAL.stmt = &ast.JumpIfStmt{
 				JumpStmt: yyDollar[1].stmt.(*ast.JumpStmt),
@@ -1466,7 +1654,7 @@ yydefault:
 		}
 	case 13:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:190
+//line ./parser/parser.go.y:193
 		{
 			yyVAL.stmt = &ast.JumpStmt{
 				Token:    yyDollar[1].token.Literal,
@@ -1477,7 +1665,7 @@ yydefault:
 		}
 	case 14:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:199
+//line ./parser/parser.go.y:202
 		{
 			yyVAL.stmt = &ast.JumpStmt{
 				Token:    yyDollar[1].token.Literal,
@@ -1488,7 +1676,7 @@ yydefault:
 		}
 	case 15:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:208
+//line ./parser/parser.go.y:211
 		{
 			yyVAL.stmt = &ast.JumpStmt{
 				Token:    yyDollar[1].token.Literal,
@@ -1499,7 +1687,7 @@ yydefault:
 		}
 	case 16:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:217
+//line ./parser/parser.go.y:220
 		{
 			yyVAL.stmt = &ast.JumpStmt{
 				Token:    yyDollar[1].token.Literal,
@@ -1510,78 +1698,78 @@ yydefault:
 		}
 	case 17:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:228
+//line ./parser/parser.go.y:231
 		{
 			yyVAL.expr = yyDollar[1].expr
 			yylex.(*Lexer).curRule = "expr -> unitExpr"
 		}
 	case 18:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:233
+//line ./parser/parser.go.y:236
 		{
 			yyVAL.expr = yyDollar[1].expr
 			yylex.(*Lexer).curRule = "expr -> infixExpr"
 		}
 	case 19:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:238
+//line ./parser/parser.go.y:241
 		{
 			yyVAL.expr = yyDollar[1].expr
 			yylex.(*Lexer).curRule = "expr -> prefixExpr"
 		}
 	case 20:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:243
+//line ./parser/parser.go.y:246
 		{
 			yyVAL.expr = yyDollar[1].expr
 			yylex.(*Lexer).curRule = "expr -> assignExpr"
 		}
 	case 21:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:248
+//line ./parser/parser.go.y:251
 		{
 			yyVAL.expr = yyDollar[1].expr
 			yylex.(*Lexer).curRule = "expr -> ifExpr"
 		}
 	case 22:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:255
+//line ./parser/parser.go.y:258
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 23:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:259
+//line ./parser/parser.go.y:262
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 24:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:263
+//line ./parser/parser.go.y:266
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 25:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:267
+//line ./parser/parser.go.y:270
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 26:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:271
+//line ./parser/parser.go.y:274
 		{
 			yyVAL.expr = yyDollar[2].expr
 		}
 	case 27:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:275
+//line ./parser/parser.go.y:278
 		{
 			yyVAL.expr = yyDollar[1].ident
 		}
 	case 28:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:281
+//line ./parser/parser.go.y:284
 		{
 			yyVAL.ident = &ast.Ident{
 				Token:     yyDollar[1].token.Literal,
@@ -1594,7 +1782,7 @@ yydefault:
 		}
 	case 29:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:292
+//line ./parser/parser.go.y:295
 		{
 			yyVAL.ident = &ast.Ident{
 				Token:     yyDollar[1].token.Literal,
@@ -1607,7 +1795,7 @@ yydefault:
 		}
 	case 30:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:303
+//line ./parser/parser.go.y:306
 		{
 			yyVAL.ident = &ast.Ident{
 				Token:     yyDollar[1].token.Literal,
@@ -1620,7 +1808,7 @@ yydefault:
 		}
 	case 31:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:314
+//line ./parser/parser.go.y:317
 		{
 			yyVAL.ident = &ast.Ident{
 				Token:     yyDollar[1].token.Literal,
@@ -1633,79 +1821,79 @@ yydefault:
 		}
 	case 32:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:327
+//line ./parser/parser.go.y:330
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 33:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:331
+//line ./parser/parser.go.y:334
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 34:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:335
+//line ./parser/parser.go.y:338
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 35:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:339
+//line ./parser/parser.go.y:342
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 36:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:343
+//line ./parser/parser.go.y:346
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 37:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:347
+//line ./parser/parser.go.y:350
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 38:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:351
+//line ./parser/parser.go.y:354
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 39:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:355
+//line ./parser/parser.go.y:358
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 40:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:359
+//line ./parser/parser.go.y:362
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 41:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:363
+//line ./parser/parser.go.y:366
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 42:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:367
+//line ./parser/parser.go.y:370
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 43:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:371
+//line ./parser/parser.go.y:374
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
 	case 44:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:377
+//line ./parser/parser.go.y:380
 		{
 			// remove separator "_"s
 			intStr := strings.Replace(yyDollar[1].token.Literal, "_", "", -1)
@@ -1718,7 +1906,7 @@ yydefault:
 		}
 	case 45:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:388
+//line ./parser/parser.go.y:391
 		{
 			// remove separator "_"s
 			lit := strings.Replace(yyDollar[1].token.Literal, "_", "", -1)
@@ -1733,7 +1921,7 @@ yydefault:
 		}
 	case 46:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:401
+//line ./parser/parser.go.y:404
 		{
 			// remove separator "_"s
 			lit := strings.Replace(yyDollar[1].token.Literal, "_", "", -1)
@@ -1748,7 +1936,7 @@ yydefault:
 		}
 	case 47:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:414
+//line ./parser/parser.go.y:417
 		{
 			// remove separator "_"s
 			lit := strings.Replace(yyDollar[1].token.Literal, "_", "", -1)
@@ -1763,7 +1951,7 @@ yydefault:
 		}
 	case 48:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:427
+//line ./parser/parser.go.y:430
 		{
 			// remove separator "_"s
 			lit := strings.Replace(yyDollar[1].token.Literal, "_", "", -1)
@@ -1781,7 +1969,7 @@ yydefault:
 		}
 	case 49:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:445
+//line ./parser/parser.go.y:448
 		{
 			// remove separator "_"s
 			floatStr := strings.Replace(yyDollar[1].token.Literal, "_", "", -1)
@@ -1794,7 +1982,7 @@ yydefault:
 		}
 	case 50:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:456
+//line ./parser/parser.go.y:459
 		{
 			// remove separator "_"s
 			lit := strings.Replace(yyDollar[1].token.Literal, "_", "", -1)
@@ -1810,7 +1998,7 @@ yydefault:
 		}
 	case 51:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:472
+//line ./parser/parser.go.y:475
 		{
 			yyVAL.expr = &ast.IfExpr{
 				Token: yyDollar[2].token.Literal,
@@ -1822,7 +2010,7 @@ yydefault:
 		}
 	case 52:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line ./parser/parser.go.y:482
+//line ./parser/parser.go.y:485
 		{
 			// NOTE: to refrain shift/reduce conflict, else has higher prec than if
 			// `a if b if c else d` means `((a if b) if c else d)`
@@ -1836,7 +2024,7 @@ yydefault:
 		}
 	case 53:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:496
+//line ./parser/parser.go.y:499
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1849,7 +2037,7 @@ yydefault:
 		}
 	case 54:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:507
+//line ./parser/parser.go.y:510
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1862,7 +2050,7 @@ yydefault:
 		}
 	case 55:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:518
+//line ./parser/parser.go.y:521
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1875,7 +2063,7 @@ yydefault:
 		}
 	case 56:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:529
+//line ./parser/parser.go.y:532
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1888,7 +2076,7 @@ yydefault:
 		}
 	case 57:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:540
+//line ./parser/parser.go.y:543
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1901,7 +2089,7 @@ yydefault:
 		}
 	case 58:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:551
+//line ./parser/parser.go.y:554
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1914,7 +2102,7 @@ yydefault:
 		}
 	case 59:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:562
+//line ./parser/parser.go.y:565
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1927,7 +2115,7 @@ yydefault:
 		}
 	case 60:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:573
+//line ./parser/parser.go.y:576
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1940,7 +2128,7 @@ yydefault:
 		}
 	case 61:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:584
+//line ./parser/parser.go.y:587
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1953,7 +2141,7 @@ yydefault:
 		}
 	case 62:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:595
+//line ./parser/parser.go.y:598
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1966,7 +2154,7 @@ yydefault:
 		}
 	case 63:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:606
+//line ./parser/parser.go.y:609
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1979,7 +2167,7 @@ yydefault:
 		}
 	case 64:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:617
+//line ./parser/parser.go.y:620
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -1992,7 +2180,7 @@ yydefault:
 		}
 	case 65:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:628
+//line ./parser/parser.go.y:631
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2005,7 +2193,7 @@ yydefault:
 		}
 	case 66:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:639
+//line ./parser/parser.go.y:642
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2018,7 +2206,7 @@ yydefault:
 		}
 	case 67:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:650
+//line ./parser/parser.go.y:653
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2031,7 +2219,7 @@ yydefault:
 		}
 	case 68:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:661
+//line ./parser/parser.go.y:664
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2044,7 +2232,7 @@ yydefault:
 		}
 	case 69:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:672
+//line ./parser/parser.go.y:675
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2057,7 +2245,7 @@ yydefault:
 		}
 	case 70:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:683
+//line ./parser/parser.go.y:686
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2070,7 +2258,7 @@ yydefault:
 		}
 	case 71:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:694
+//line ./parser/parser.go.y:697
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2083,7 +2271,7 @@ yydefault:
 		}
 	case 72:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:705
+//line ./parser/parser.go.y:708
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2096,7 +2284,7 @@ yydefault:
 		}
 	case 73:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:716
+//line ./parser/parser.go.y:719
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2109,7 +2297,7 @@ yydefault:
 		}
 	case 74:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:727
+//line ./parser/parser.go.y:730
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2118,11 +2306,11 @@ yydefault:
 				Right:    yyDollar[3].expr,
 				Src:      yylex.(*Lexer).Source,
 			}
-			yylex.(*Lexer).curRule = "infixExpr -> expr AND expr"
+			yylex.(*Lexer).curRule = "infixExpr -> expr IN expr"
 		}
 	case 75:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:738
+//line ./parser/parser.go.y:741
 		{
 			yyVAL.expr = &ast.InfixExpr{
 				Token:    yyDollar[2].token.Literal,
@@ -2131,11 +2319,37 @@ yydefault:
 				Right:    yyDollar[3].expr,
 				Src:      yylex.(*Lexer).Source,
 			}
-			yylex.(*Lexer).curRule = "infixExpr -> expr OR expr"
+			yylex.(*Lexer).curRule = "infixExpr -> expr USER_OP expr"
 		}
 	case 76:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line ./parser/parser.go.y:752
+		{
+			yyVAL.expr = &ast.InfixExpr{
+				Token:    yyDollar[2].token.Literal,
+				Left:     yyDollar[1].expr,
+				Operator: yyDollar[2].token.Literal,
+				Right:    yyDollar[3].expr,
+				Src:      yylex.(*Lexer).Source,
+			}
+			yylex.(*Lexer).curRule = "infixExpr -> expr AND expr"
+		}
+	case 77:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line ./parser/parser.go.y:763
+		{
+			yyVAL.expr = &ast.InfixExpr{
+				Token:    yyDollar[2].token.Literal,
+				Left:     yyDollar[1].expr,
+				Operator: yyDollar[2].token.Literal,
+				Right:    yyDollar[3].expr,
+				Src:      yylex.(*Lexer).Source,
+			}
+			yylex.(*Lexer).curRule = "infixExpr -> expr OR expr"
+		}
+	case 78:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:751
+//line ./parser/parser.go.y:776
 		{
 			yyVAL.expr = &ast.PrefixExpr{
 				Token:    yyDollar[1].token.Literal,
@@ -2145,9 +2359,9 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "prefixExpr -> PLUS expr"
 		}
-	case 77:
+	case 79:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:761
+//line ./parser/parser.go.y:786
 		{
 			// HACK: convert -(number) to literal
 			// TOFIX: deal with this process in lexer
@@ -2173,9 +2387,9 @@ yydefault:
 				}
 			}
 		}
-	case 78:
+	case 80:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:787
+//line ./parser/parser.go.y:812
 		{
 			yyVAL.expr = &ast.PrefixExpr{
 				Token:    yyDollar[1].token.Literal,
@@ -2185,9 +2399,9 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "prefixExpr -> STAR expr"
 		}
-	case 79:
+	case 81:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:797
+//line ./parser/parser.go.y:822
 		{
 			yyVAL.expr = &ast.PrefixExpr{
 				Token:    yyDollar[1].token.Literal,
@@ -2197,9 +2411,9 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "prefixExpr -> BANG expr"
 		}
-	case 80:
+	case 82:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:807
+//line ./parser/parser.go.y:832
 		{
 			yyVAL.expr = &ast.PrefixExpr{
 				Token:    yyDollar[1].token.Literal,
@@ -2209,9 +2423,9 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "prefixExpr -> BIT_NOT expr"
 		}
-	case 81:
+	case 83:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:819
+//line ./parser/parser.go.y:844
 		{
 			yyVAL.expr = &ast.AssignExpr{
 				Token: yyDollar[2].token.Literal,
@@ -2220,9 +2434,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 82:
+	case 84:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:828
+//line ./parser/parser.go.y:853
 		{
 			op := yyDollar[2].token.Literal[:len(yyDollar[2].token.Literal)-1]
 			ie := &ast.InfixExpr{
@@ -2239,9 +2453,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 83:
+	case 85:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:845
+//line ./parser/parser.go.y:870
 		{
 			// NOTE: "Left" and "Right" are reversed!
 			yyVAL.expr = &ast.AssignExpr{
@@ -2251,9 +2465,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 84:
+	case 86:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:857
+//line ./parser/parser.go.y:882
 		{
 			atIdent := &ast.Ident{
 				Token:     "at",
@@ -2272,9 +2486,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 85:
+	case 87:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:878
+//line ./parser/parser.go.y:903
 		{
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2283,10 +2497,11 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 86:
+	case 88:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:887
+//line ./parser/parser.go.y:912
 		{
+			yylex.(*Lexer).attachPairDoc(yyDollar[1].token, yyDollar[2].pairList[0])
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
 				Pairs:         yyDollar[2].pairList,
@@ -2294,10 +2509,11 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 87:
+	case 89:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:896
+//line ./parser/parser.go.y:922
 		{
+			yylex.(*Lexer).attachPairDoc(yyDollar[1].token, yyDollar[2].pairList[0])
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
 				Pairs:         yyDollar[2].pairList,
@@ -2305,10 +2521,11 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 88:
+	case 90:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:905
+//line ./parser/parser.go.y:932
 		{
+			yylex.(*Lexer).attachPairDoc(yyDollar[1].token, yyDollar[2].pairList[0])
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
 				Pairs:         yyDollar[2].pairList,
@@ -2316,9 +2533,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 89:
+	case 91:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:914
+//line ./parser/parser.go.y:942
 		{
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2327,9 +2544,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 90:
+	case 92:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:923
+//line ./parser/parser.go.y:951
 		{
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2338,9 +2555,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 91:
+	case 93:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:932
+//line ./parser/parser.go.y:960
 		{
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2349,10 +2566,11 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 92:
+	case 94:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line ./parser/parser.go.y:941
+//line ./parser/parser.go.y:969
 		{
+			yylex.(*Lexer).attachPairDoc(yyDollar[1].token, yyDollar[2].pairList[0])
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
 				Pairs:         yyDollar[2].pairList,
@@ -2360,10 +2578,11 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 93:
+	case 95:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line ./parser/parser.go.y:950
+//line ./parser/parser.go.y:979
 		{
+			yylex.(*Lexer).attachPairDoc(yyDollar[1].token, yyDollar[2].pairList[0])
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
 				Pairs:         yyDollar[2].pairList,
@@ -2371,10 +2590,11 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 94:
+	case 96:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line ./parser/parser.go.y:959
+//line ./parser/parser.go.y:989
 		{
+			yylex.(*Lexer).attachPairDoc(yyDollar[1].token, yyDollar[2].pairList[0])
 			yyVAL.expr = &ast.ObjLiteral{
 				Token:         yyDollar[1].token.Literal,
 				Pairs:         yyDollar[2].pairList,
@@ -2382,9 +2602,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 95:
+	case 97:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:970
+//line ./parser/parser.go.y:1001
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2393,9 +2613,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 96:
+	case 98:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:979
+//line ./parser/parser.go.y:1010
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2404,9 +2624,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 97:
+	case 99:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:988
+//line ./parser/parser.go.y:1019
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2415,9 +2635,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 98:
+	case 100:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:997
+//line ./parser/parser.go.y:1028
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2426,9 +2646,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 99:
+	case 101:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1006
+//line ./parser/parser.go.y:1037
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2437,9 +2657,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 100:
+	case 102:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1015
+//line ./parser/parser.go.y:1046
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2448,9 +2668,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 101:
+	case 103:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1024
+//line ./parser/parser.go.y:1055
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2459,9 +2679,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 102:
+	case 104:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line ./parser/parser.go.y:1033
+//line ./parser/parser.go.y:1064
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2470,9 +2690,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 103:
+	case 105:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line ./parser/parser.go.y:1042
+//line ./parser/parser.go.y:1073
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2481,9 +2701,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 104:
+	case 106:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line ./parser/parser.go.y:1051
+//line ./parser/parser.go.y:1082
 		{
 			yyVAL.expr = &ast.MapLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2492,9 +2712,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 105:
+	case 107:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1062
+//line ./parser/parser.go.y:1093
 		{
 			yyVAL.expr = &ast.ArrLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2503,9 +2723,9 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "arrLiteral -> lBracket RBRACKET"
 		}
-	case 106:
+	case 108:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1072
+//line ./parser/parser.go.y:1103
 		{
 			emptyRange := &ast.RangeLiteral{
 				Token: yyDollar[2].token.Literal,
@@ -2522,9 +2742,9 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "arrLiteral -> lBracket RBRACKET"
 		}
-	case 107:
+	case 109:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1090
+//line ./parser/parser.go.y:1121
 		{
 			yyVAL.expr = &ast.ArrLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2533,9 +2753,9 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "arrLiteral -> lBracket exprList RBRACKET"
 		}
-	case 108:
+	case 110:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1100
+//line ./parser/parser.go.y:1131
 		{
 			yyVAL.expr = &ast.ArrLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2544,9 +2764,9 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "arrLiteral -> lBracket exprList RBRACKET"
 		}
-	case 109:
+	case 111:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1110
+//line ./parser/parser.go.y:1141
 		{
 			yyVAL.expr = &ast.ArrLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2555,9 +2775,9 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "arrLiteral -> lBracket exprList RBRACKET"
 		}
-	case 110:
+	case 112:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1122
+//line ./parser/parser.go.y:1153
 		{
 			yyVAL.expr = &ast.StrLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2566,9 +2786,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 111:
+	case 113:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1131
+//line ./parser/parser.go.y:1162
 		{
 			str := yyDollar[1].token.Literal[1 : len(yyDollar[1].token.Literal)-1]
 			// replace escaped backquotes with backquotes
@@ -2581,9 +2801,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 112:
+	case 114:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1144
+//line ./parser/parser.go.y:1175
 		{
 			// unquote escape sequences here
 			// NOTE: backquotes are unwraped in Unquote
@@ -2595,9 +2815,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 113:
+	case 115:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1158
+//line ./parser/parser.go.y:1189
 		{
 			yyVAL.expr = &ast.SymLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2605,15 +2825,15 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 114:
+	case 116:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1168
+//line ./parser/parser.go.y:1199
 		{
 			yyVAL.expr = yyDollar[2].expr
 		}
-	case 115:
+	case 117:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line ./parser/parser.go.y:1174
+//line ./parser/parser.go.y:1205
 		{
 			yyVAL.expr = &ast.RangeLiteral{
 				Token: yyDollar[2].token.Literal,
@@ -2623,9 +2843,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 116:
+	case 118:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1184
+//line ./parser/parser.go.y:1215
 		{
 			yyVAL.expr = &ast.RangeLiteral{
 				Token: yyDollar[2].token.Literal,
@@ -2635,9 +2855,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 117:
+	case 119:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1194
+//line ./parser/parser.go.y:1225
 		{
 			yyVAL.expr = &ast.RangeLiteral{
 				Token: yyDollar[2].token.Literal,
@@ -2647,9 +2867,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 118:
+	case 120:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1204
+//line ./parser/parser.go.y:1235
 		{
 			yyVAL.expr = &ast.RangeLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2659,9 +2879,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 119:
+	case 121:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1214
+//line ./parser/parser.go.y:1245
 		{
 			yyVAL.expr = &ast.RangeLiteral{
 				Token: yyDollar[2].token.Literal,
@@ -2671,9 +2891,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 120:
+	case 122:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1224
+//line ./parser/parser.go.y:1255
 		{
 			yyVAL.expr = &ast.RangeLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2683,9 +2903,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 121:
+	case 123:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1234
+//line ./parser/parser.go.y:1265
 		{
 			yyVAL.expr = &ast.RangeLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2695,9 +2915,9 @@ yydefault:
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 122:
+	case 124:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1246
+//line ./parser/parser.go.y:1277
 		{
 			// unquote escape sequences here
 			// NOTE: doublequotes are unwraped in Unquote
@@ -2709,9 +2929,9 @@ yydefault:
 				Src:    yylex.(*Lexer).Source,
 			}
 		}
-	case 123:
+	case 125:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1260
+//line ./parser/parser.go.y:1291
 		{
 			// unquote escape sequences here
 			// NOTE: doublequotes are unwraped in Unquote
@@ -2723,9 +2943,9 @@ yydefault:
 				Expr:   yyDollar[3].expr,
 			}
 		}
-	case 124:
+	case 126:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1272
+//line ./parser/parser.go.y:1303
 		{
 			// unquote escape sequences here
 			// NOTE: doublequotes are unwraped in Unquote
@@ -2737,9 +2957,9 @@ yydefault:
 				Expr:   yyDollar[2].expr,
 			}
 		}
-	case 125:
+	case 127:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1286
+//line ./parser/parser.go.y:1317
 		{
 			yyVAL.expr = &ast.FuncLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2747,9 +2967,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 126:
+	case 128:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1294
+//line ./parser/parser.go.y:1325
 		{
 			yyVAL.expr = &ast.FuncLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2762,9 +2982,9 @@ yydefault:
 				},
 			}
 		}
-	case 127:
+	case 129:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1307
+//line ./parser/parser.go.y:1338
 		{
 			yyVAL.expr = &ast.FuncLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2772,9 +2992,9 @@ yydefault:
 				Src:           yylex.(*Lexer).Source,
 			}
 		}
-	case 128:
+	case 130:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1317
+//line ./parser/parser.go.y:1348
 		{
 			yyVAL.expr = &ast.IterLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2787,9 +3007,9 @@ yydefault:
 				},
 			}
 		}
-	case 129:
+	case 131:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1330
+//line ./parser/parser.go.y:1361
 		{
 			yyVAL.expr = &ast.IterLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2797,9 +3017,9 @@ yydefault:
 				FuncComponent: yyDollar[2].funcComponent,
 			}
 		}
-	case 130:
+	case 132:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1338
+//line ./parser/parser.go.y:1369
 		{
 			yyVAL.expr = &ast.IterLiteral{
 				Token: yyDollar[1].token.Literal,
@@ -2812,9 +3032,9 @@ yydefault:
 				},
 			}
 		}
-	case 131:
+	case 133:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1351
+//line ./parser/parser.go.y:1382
 		{
 			yyVAL.expr = &ast.IterLiteral{
 				Token:         yyDollar[1].token.Literal,
@@ -2822,9 +3042,9 @@ yydefault:
 				FuncComponent: *yyDollar[2].funcComponent.PrependSelf(yylex.(*Lexer).Source),
 			}
 		}
-	case 132:
+	case 134:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1361
+//line ./parser/parser.go.y:1392
 		{
 			yyVAL.expr = &ast.MatchLiteral{
 				Token:    yyDollar[1].token.Literal,
@@ -2832,9 +3052,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 133:
+	case 135:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1369
+//line ./parser/parser.go.y:1400
 		{
 			patterns := []*ast.FuncComponent{}
 			for _, p := range yyDollar[2].funcComponentList {
@@ -2847,9 +3067,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 134:
+	case 136:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1384
+//line ./parser/parser.go.y:1415
 		{
 			// NOTE: assigning is nesessary because $3 is passed by reference
 			// which means address of $3 is the last match of funcComponentList
@@ -2857,16 +3077,16 @@ yydefault:
 			comp := yyDollar[3].funcComponent
 			yyVAL.funcComponentList = append(yyDollar[1].funcComponentList, &comp)
 		}
-	case 135:
+	case 137:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1392
+//line ./parser/parser.go.y:1423
 		{
 			comp := yyDollar[1].funcComponent
 			yyVAL.funcComponentList = []*ast.FuncComponent{&comp}
 		}
-	case 136:
+	case 138:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1399
+//line ./parser/parser.go.y:1430
 		{
 			yyVAL.funcComponent = ast.FuncComponent{
 				Args:   yyDollar[1].argList.Args,
@@ -2875,9 +3095,9 @@ yydefault:
 				Src:    yylex.(*Lexer).Source,
 			}
 		}
-	case 137:
+	case 139:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1408
+//line ./parser/parser.go.y:1439
 		{
 			yyVAL.funcComponent = ast.FuncComponent{
 				Args:   []ast.Expr{},
@@ -2886,15 +3106,15 @@ yydefault:
 				Src:    yylex.(*Lexer).Source,
 			}
 		}
-	case 138:
+	case 140:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1417
+//line ./parser/parser.go.y:1448
 		{
 			yyVAL.funcComponent = yyDollar[1].funcComponent
 		}
-	case 139:
+	case 141:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1423
+//line ./parser/parser.go.y:1454
 		{
 			yyVAL.funcComponent = ast.FuncComponent{
 				Args:   yyDollar[1].argList.Args,
@@ -2903,78 +3123,78 @@ yydefault:
 				Src:    yylex.(*Lexer).Source,
 			}
 		}
-	case 140:
+	case 142:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1434
+//line ./parser/parser.go.y:1465
 		{
 			yyVAL.expr = &ast.DiamondLiteral{
 				Token: yyDollar[1].token.Literal,
 				Src:   yylex.(*Lexer).Source,
 			}
 		}
-	case 141:
+	case 143:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1443
+//line ./parser/parser.go.y:1474
 		{
 			yyVAL.argList = &ast.ArgList{
 				Args:   []ast.Expr{},
 				Kwargs: map[*ast.Ident]ast.Expr{},
 			}
 		}
-	case 142:
+	case 144:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1450
+//line ./parser/parser.go.y:1481
 		{
 			yyVAL.argList = &ast.ArgList{
 				Args:   []ast.Expr{},
 				Kwargs: map[*ast.Ident]ast.Expr{},
 			}
 		}
-	case 143:
+	case 145:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1457
+//line ./parser/parser.go.y:1488
 		{
 			yyVAL.argList = yyDollar[2].argList
 		}
-	case 144:
+	case 146:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1461
+//line ./parser/parser.go.y:1492
 		{
 			yyVAL.argList = yyDollar[2].argList
 		}
-	case 145:
+	case 147:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1465
+//line ./parser/parser.go.y:1496
 		{
 			yyVAL.argList = &ast.ArgList{
 				Args:   []ast.Expr{},
 				Kwargs: map[*ast.Ident]ast.Expr{},
 			}
 		}
-	case 146:
+	case 148:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1472
+//line ./parser/parser.go.y:1503
 		{
 			yyVAL.argList = &ast.ArgList{
 				Args:   []ast.Expr{},
 				Kwargs: map[*ast.Ident]ast.Expr{},
 			}
 		}
-	case 147:
+	case 149:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1479
+//line ./parser/parser.go.y:1510
 		{
 			yyVAL.argList = yyDollar[2].argList
 		}
-	case 148:
+	case 150:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line ./parser/parser.go.y:1483
+//line ./parser/parser.go.y:1514
 		{
 			yyVAL.argList = yyDollar[2].argList
 		}
-	case 149:
+	case 151:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1489
+//line ./parser/parser.go.y:1520
 		{
 			yyVAL.expr = &ast.PropCallExpr{
 				Token:    "(propCall)",
@@ -2986,9 +3206,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 150:
+	case 152:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1501
+//line ./parser/parser.go.y:1532
 		{
 			yyVAL.expr = &ast.PropCallExpr{
 				Token:    "(propCall)",
@@ -3000,9 +3220,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 151:
+	case 153:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1513
+//line ./parser/parser.go.y:1544
 		{
 			yyVAL.expr = &ast.PropCallExpr{
 				Token:    "(propCall)",
@@ -3014,9 +3234,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 152:
+	case 154:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1525
+//line ./parser/parser.go.y:1556
 		{
 			opIdent := &ast.Ident{
 				Token:     yyDollar[2].token.Literal,
@@ -3034,9 +3254,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 153:
+	case 155:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1543
+//line ./parser/parser.go.y:1574
 		{
 			opIdent := &ast.Ident{
 				Token:     yyDollar[2].token.Literal,
@@ -3054,9 +3274,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 154:
+	case 156:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1561
+//line ./parser/parser.go.y:1592
 		{
 			opIdent := &ast.Ident{
 				Token:     yyDollar[2].token.Literal,
@@ -3074,9 +3294,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 155:
+	case 157:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1579
+//line ./parser/parser.go.y:1610
 		{
 			yyVAL.expr = &ast.LiteralCallExpr{
 				Token:    "(literalCall)",
@@ -3088,9 +3308,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 156:
+	case 158:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1591
+//line ./parser/parser.go.y:1622
 		{
 			yyVAL.expr = &ast.VarCallExpr{
 				Token:    "(varCall)",
@@ -3102,9 +3322,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 157:
+	case 159:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1603
+//line ./parser/parser.go.y:1634
 		{
 			yyVAL.expr = &ast.VarCallExpr{
 				Token:    "(varCall)",
@@ -3116,9 +3336,9 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 158:
+	case 160:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1615
+//line ./parser/parser.go.y:1646
 		{
 			callIdent := &ast.Ident{
 				Token:     "call",
@@ -3137,27 +3357,36 @@ yydefault:
 				Src:      yylex.(*Lexer).Source,
 			}
 		}
-	case 159:
+	case 161:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1636
+//line ./parser/parser.go.y:1667
 		{
 			yyVAL.recvAndChain = &ast.RecvAndChain{
 				Recv:  yyDollar[1].expr,
 				Chain: yyDollar[2].chain,
 			}
 		}
-	case 160:
+	case 162:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1643
+//line ./parser/parser.go.y:1674
 		{
 			yyVAL.recvAndChain = &ast.RecvAndChain{
 				Recv:  nil,
 				Chain: yyDollar[1].chain,
 			}
 		}
-	case 161:
+	case 163:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1652
+//line ./parser/parser.go.y:1681
+		{
+			yyVAL.recvAndChain = &ast.RecvAndChain{
+				Recv:  yyDollar[1].expr,
+				Chain: yyDollar[2].chain,
+			}
+		}
+	case 164:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line ./parser/parser.go.y:1690
 		{
 			yyVAL.argList = &ast.ArgList{
 				Args:   []ast.Expr{},
@@ -3165,30 +3394,30 @@ yydefault:
 			}
 			yylex.(*Lexer).curRule = "callArgs -> lParen RPAREN"
 		}
-	case 162:
+	case 165:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1660
+//line ./parser/parser.go.y:1698
 		{
 			yyVAL.argList = yyDollar[2].argList
 			yylex.(*Lexer).curRule = "callArgs -> lParen argList RPAREN"
 		}
-	case 163:
+	case 166:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1665
+//line ./parser/parser.go.y:1703
 		{
 			yyVAL.argList = yyDollar[2].argList
 			yylex.(*Lexer).curRule = "callArgs -> lParen argList RET RPAREN"
 		}
-	case 164:
+	case 167:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1670
+//line ./parser/parser.go.y:1708
 		{
 			yyVAL.argList = yyDollar[2].argList
 			yylex.(*Lexer).curRule = "callArgs -> lParen argList comma RPAREN"
 		}
-	case 165:
+	case 168:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1675
+//line ./parser/parser.go.y:1713
 		{
 			expansionList := []ast.Expr{}
 			for _, exp := range yyDollar[2].exprList {
@@ -3208,9 +3437,9 @@ yydefault:
 			yyVAL.argList = argList
 			yylex.(*Lexer).curRule = "callArgs -> lParen kwargExpansionList RPAREN"
 		}
-	case 166:
+	case 169:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1695
+//line ./parser/parser.go.y:1733
 		{
 			expansionList := []ast.Expr{}
 			for _, exp := range yyDollar[2].exprList {
@@ -3230,9 +3459,9 @@ yydefault:
 			yyVAL.argList = argList
 			yylex.(*Lexer).curRule = "callArgs -> lParen kwargExpansionList RET RPAREN"
 		}
-	case 167:
+	case 170:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line ./parser/parser.go.y:1715
+//line ./parser/parser.go.y:1753
 		{
 			argList := yyDollar[2].argList
 			for _, exp := range yyDollar[4].exprList {
@@ -3247,9 +3476,9 @@ yydefault:
 			yyVAL.argList = argList
 			yylex.(*Lexer).curRule = "callArgs -> lParen kwargExpansionList RPAREN"
 		}
-	case 168:
+	case 171:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line ./parser/parser.go.y:1730
+//line ./parser/parser.go.y:1768
 		{
 			argList := yyDollar[2].argList
 			for _, exp := range yyDollar[4].exprList {
@@ -3264,458 +3493,482 @@ yydefault:
 			yyVAL.argList = argList
 			yylex.(*Lexer).curRule = "callArgs -> lParen argList kwargExpansionList RET RPAREN"
 		}
-	case 169:
+	case 172:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1745
+//line ./parser/parser.go.y:1783
 		{
 			yyVAL.argList = yyDollar[1].argList.AppendArg(yyDollar[2].expr)
 			yylex.(*Lexer).curRule = "callArgs -> callArgs funcLiteral"
 		}
-	case 170:
+	case 173:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1752
+//line ./parser/parser.go.y:1790
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> PLUS"
 		}
-	case 171:
+	case 174:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1757
+//line ./parser/parser.go.y:1795
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> MINUS"
 		}
-	case 172:
+	case 175:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1762
+//line ./parser/parser.go.y:1800
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> STAR"
 		}
-	case 173:
+	case 176:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1767
+//line ./parser/parser.go.y:1805
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> SLASH"
 		}
-	case 174:
+	case 177:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1772
+//line ./parser/parser.go.y:1810
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> DOUBLE_SLASH"
 		}
-	case 175:
+	case 178:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1777
+//line ./parser/parser.go.y:1815
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> PERCENT"
 		}
-	case 176:
+	case 179:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1782
+//line ./parser/parser.go.y:1820
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> DOUBLE_STAR"
 		}
-	case 177:
+	case 180:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1787
+//line ./parser/parser.go.y:1825
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> SPACESHIP"
 		}
-	case 178:
+	case 181:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1792
+//line ./parser/parser.go.y:1830
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> EQ"
 		}
-	case 179:
+	case 182:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1797
+//line ./parser/parser.go.y:1835
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> NEQ"
 		}
-	case 180:
+	case 183:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1802
+//line ./parser/parser.go.y:1840
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> GE"
 		}
-	case 181:
+	case 184:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1807
+//line ./parser/parser.go.y:1845
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> LE"
 		}
-	case 182:
+	case 185:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1812
+//line ./parser/parser.go.y:1850
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> GT"
 		}
-	case 183:
+	case 186:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1817
+//line ./parser/parser.go.y:1855
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> LT"
 		}
-	case 184:
+	case 187:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1822
+//line ./parser/parser.go.y:1860
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> BIT_LSHIFT"
 		}
-	case 185:
+	case 188:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1827
+//line ./parser/parser.go.y:1865
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> BIT_RSHIFT"
 		}
-	case 186:
+	case 189:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1832
+//line ./parser/parser.go.y:1870
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> BIT_AND"
 		}
-	case 187:
+	case 190:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1837
+//line ./parser/parser.go.y:1875
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> BIT_OR"
 		}
-	case 188:
+	case 191:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1842
+//line ./parser/parser.go.y:1880
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> BIT_XOR"
 		}
-	case 189:
+	case 192:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1847
+//line ./parser/parser.go.y:1885
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> BIT_NOT"
 		}
-	case 190:
+	case 193:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1852
+//line ./parser/parser.go.y:1890
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> BANG"
 		}
-	case 191:
+	case 194:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1857
+//line ./parser/parser.go.y:1895
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> IADD"
 		}
-	case 192:
+	case 195:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1862
+//line ./parser/parser.go.y:1900
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "opMethod -> ISUB"
 		}
-	case 193:
+	case 196:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line ./parser/parser.go.y:1905
+		{
+			yyVAL.token = yyDollar[1].token
+			yylex.(*Lexer).curRule = "opMethod -> USER_OP"
+		}
+	case 197:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1869
+//line ./parser/parser.go.y:1912
 		{
 			yyVAL.chain = ast.MakeChain(yyDollar[1].token.Literal, yyDollar[2].token.Literal, nil)
 			yylex.(*Lexer).curRule = "chain -> ADD_CHAIN MAIN_CHAIN"
 		}
-	case 194:
+	case 198:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1874
+//line ./parser/parser.go.y:1917
 		{
 			yyVAL.chain = ast.MakeChain("", yyDollar[1].token.Literal, nil)
 			yylex.(*Lexer).curRule = "chain -> MAIN_CHAIN"
 		}
-	case 195:
+	case 199:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1879
+//line ./parser/parser.go.y:1922
 		{
 			yyVAL.chain = ast.MakeChain("", yyDollar[1].token.Literal, yyDollar[3].expr)
 			yylex.(*Lexer).curRule = "chain -> MAIN_CHAIN lParen expr RPAREN"
 		}
-	case 196:
+	case 200:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line ./parser/parser.go.y:1884
+//line ./parser/parser.go.y:1927
 		{
 			yyVAL.chain = ast.MakeChain(yyDollar[1].token.Literal, yyDollar[2].token.Literal, yyDollar[4].expr)
 			yylex.(*Lexer).curRule = "chain -> ADD_CHAIN MAIN_CHAIN lParen expr RPAREN"
 		}
-	case 197:
+	case 201:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1889
+//line ./parser/parser.go.y:1932
 		{
 			ac := string(yyDollar[1].token.Literal[len(yyDollar[1].token.Literal)-1])
 			yyVAL.chain = ast.MakeChain(ac, yyDollar[2].token.Literal, nil)
 		}
-	case 198:
+	case 202:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1894
+//line ./parser/parser.go.y:1937
 		{
 			mc := string(yyDollar[1].token.Literal[len(yyDollar[1].token.Literal)-1])
 			yyVAL.chain = ast.MakeChain("", mc, nil)
 		}
-	case 199:
+	case 203:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1899
+//line ./parser/parser.go.y:1942
 		{
 			mc := string(yyDollar[1].token.Literal[len(yyDollar[1].token.Literal)-1])
 			yyVAL.chain = ast.MakeChain("", mc, yyDollar[3].expr)
 		}
-	case 200:
+	case 204:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line ./parser/parser.go.y:1904
+//line ./parser/parser.go.y:1947
 		{
 			ac := string(yyDollar[1].token.Literal[len(yyDollar[1].token.Literal)-1])
 			yyVAL.chain = ast.MakeChain(ac, yyDollar[2].token.Literal, yyDollar[4].expr)
 		}
-	case 201:
+	case 205:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1911
+//line ./parser/parser.go.y:1954
+		{
+			yyVAL.chain = ast.MakeContextChain(yyDollar[2].token.Literal, yyDollar[3].token.Literal, nil)
+			yylex.(*Lexer).curRule = "contextChain -> CARET IDENT MAIN_CHAIN"
+		}
+	case 206:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line ./parser/parser.go.y:1959
+		{
+			yyVAL.chain = ast.MakeContextChain(yyDollar[2].token.Literal, yyDollar[3].token.Literal, yyDollar[5].expr)
+			yylex.(*Lexer).curRule = "contextChain -> CARET IDENT MAIN_CHAIN lParen expr RPAREN"
+		}
+	case 207:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line ./parser/parser.go.y:1966
 		{
 			yyVAL.exprList = append(yyDollar[1].exprList, yyDollar[3].expr)
 		}
-	case 202:
+	case 208:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1915
+//line ./parser/parser.go.y:1970
 		{
 			yyVAL.exprList = []ast.Expr{yyDollar[1].expr}
 			yylex.(*Lexer).curRule = "exprList -> expr"
 		}
-	case 203:
+	case 209:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1922
+//line ./parser/parser.go.y:1977
 		{
 			yyVAL.argList = yyDollar[1].argList.AppendArg(yyDollar[3].expr)
 			yylex.(*Lexer).curRule = "argList -> argList comma expr"
 		}
-	case 204:
+	case 210:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1927
+//line ./parser/parser.go.y:1982
 		{
 			yyVAL.argList = yyDollar[1].argList.AppendKwarg(yyDollar[3].kwargPair.Key, yyDollar[3].kwargPair.Val)
 			yylex.(*Lexer).curRule = "argList -> argList comma pair"
 		}
-	case 205:
+	case 211:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1932
+//line ./parser/parser.go.y:1987
 		{
 			yyVAL.argList = ast.ExprToArgList(yyDollar[1].expr)
 			yylex.(*Lexer).curRule = "argList -> expr"
 		}
-	case 206:
+	case 212:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1937
+//line ./parser/parser.go.y:1992
 		{
 			yyVAL.argList = ast.KwargPairToArgList(yyDollar[1].kwargPair)
 			yylex.(*Lexer).curRule = "argList -> pair"
 		}
-	case 207:
+	case 213:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1944
+//line ./parser/parser.go.y:1999
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 208:
+	case 214:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1948
+//line ./parser/parser.go.y:2003
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 209:
+	case 215:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1954
+//line ./parser/parser.go.y:2009
 		{
+			yylex.(*Lexer).attachPairDoc(yyDollar[2].token, yyDollar[3].pair)
 			yyVAL.pairList = append(yyDollar[1].pairList, yyDollar[3].pair)
 		}
-	case 210:
+	case 216:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1958
+//line ./parser/parser.go.y:2014
 		{
 			yyVAL.pairList = []*ast.Pair{yyDollar[1].pair}
 		}
-	case 211:
+	case 217:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1964
+//line ./parser/parser.go.y:2020
 		{
 			yyVAL.exprList = append(yyDollar[1].exprList, yyDollar[4].expr)
 		}
-	case 212:
+	case 218:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1968
+//line ./parser/parser.go.y:2024
 		{
 			yyVAL.exprList = []ast.Expr{yyDollar[2].expr}
 		}
-	case 213:
+	case 219:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1974
+//line ./parser/parser.go.y:2030
 		{
 			yyVAL.kwargPair = &ast.KwargPair{Key: yyDollar[1].ident, Val: yyDollar[3].expr}
 			yylex.(*Lexer).curRule = "kwargPair -> ident COLON expr"
 		}
-	case 214:
+	case 220:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line ./parser/parser.go.y:1981
+//line ./parser/parser.go.y:2037
 		{
 			yyVAL.pair = &ast.Pair{Key: yyDollar[1].expr, Val: yyDollar[3].expr}
 		}
-	case 215:
+	case 221:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line ./parser/parser.go.y:1985
+//line ./parser/parser.go.y:2041
 		{
 			pinned := &ast.PinnedIdent{Ident: *yyDollar[2].ident}
 			yyVAL.pair = &ast.Pair{Key: pinned, Val: yyDollar[4].expr}
 		}
-	case 216:
+	case 222:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:1992
+//line ./parser/parser.go.y:2048
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "lBrace -> LBRACE RET"
 		}
-	case 217:
+	case 223:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:1997
+//line ./parser/parser.go.y:2053
 		{
+			yylex.(*Lexer).setDoc(yyDollar[1].token, yyDollar[2].token)
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "lBrace -> LBRACE RET"
 		}
-	case 218:
+	case 224:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2004
+//line ./parser/parser.go.y:2061
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "lParen -> LPAREN"
 		}
-	case 219:
+	case 225:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:2009
+//line ./parser/parser.go.y:2066
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "lParen -> LPAREN RET"
 		}
-	case 220:
+	case 226:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2016
+//line ./parser/parser.go.y:2073
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "lBracket -> LBRACKET"
 		}
-	case 221:
+	case 227:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:2021
+//line ./parser/parser.go.y:2078
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "lBracket -> LBRACKET RET"
 		}
-	case 222:
+	case 228:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2028
+//line ./parser/parser.go.y:2085
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 223:
+	case 229:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:2032
+//line ./parser/parser.go.y:2089
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 224:
+	case 230:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2038
+//line ./parser/parser.go.y:2095
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "mapLBrace -> MAP_LBRACE"
 		}
-	case 225:
+	case 231:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:2043
+//line ./parser/parser.go.y:2100
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "mapLBrace -> MAP_LBRACE RET"
 		}
-	case 226:
+	case 232:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2050
+//line ./parser/parser.go.y:2107
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "mapLBrace -> METHOD_LBRACE"
 		}
-	case 227:
+	case 233:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:2055
+//line ./parser/parser.go.y:2112
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "mapLBrace -> METHOD_LBRACE RET"
 		}
-	case 228:
+	case 234:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2062
+//line ./parser/parser.go.y:2119
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 229:
+	case 235:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:2066
+//line ./parser/parser.go.y:2123
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 230:
+	case 236:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2072
+//line ./parser/parser.go.y:2129
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 231:
+	case 237:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:2076
+//line ./parser/parser.go.y:2133
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 232:
+	case 238:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2082
+//line ./parser/parser.go.y:2139
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "breakLine -> SEMICOLON"
 		}
-	case 233:
+	case 239:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2087
+//line ./parser/parser.go.y:2144
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "breakLine -> RET"
 		}
-	case 234:
+	case 240:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line ./parser/parser.go.y:2094
+//line ./parser/parser.go.y:2151
 		{
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "comma -> COMMA"
 		}
-	case 235:
+	case 241:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line ./parser/parser.go.y:2099
+//line ./parser/parser.go.y:2156
 		{
+			yylex.(*Lexer).setDoc(yyDollar[1].token, yyDollar[2].token)
 			yyVAL.token = yyDollar[1].token
 			yylex.(*Lexer).curRule = "comma -> COMMA RET"
 		}
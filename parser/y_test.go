@@ -6,6 +6,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
@@ -173,6 +174,23 @@ func TestInfixExpr(t *testing.T) {
 		{`5 /^ 2`, 5, "/^", 2},
 		{`5 && 2`, 5, "&&", 2},
 		{`5 || 2`, 5, "||", 2},
+		{`5 in 2`, 5, "in", 2},
+		{`5 <+> 2`, 5, "<+>", 2},
+		{`5 <-> 2`, 5, "<->", 2},
+		{`5 <*> 2`, 5, "<*>", 2},
+		{`5 </> 2`, 5, "</>", 2},
+		{`5 <$> 2`, 5, "<$>", 2},
+		{`5 <|> 2`, 5, "<|>", 2},
+		{`5 <&> 2`, 5, "<&>", 2},
+		{`5 ⊕ 2`, 5, "⊕", 2},
+		{`5 ⊖ 2`, 5, "⊖", 2},
+		{`5 ⊗ 2`, 5, "⊗", 2},
+		{`5 ⊘ 2`, 5, "⊘", 2},
+		{`5 ⊙ 2`, 5, "⊙", 2},
+		{`5 ∘ 2`, 5, "∘", 2},
+		{`5 × 2`, 5, "×", 2},
+		{`5 ÷ 2`, 5, "÷", 2},
+		{`5 · 2`, 5, "·", 2},
 		{`5+2`, 5, "+", 2}, // without space
 	}
 
@@ -242,6 +260,16 @@ func TestInfixPrecedence(t *testing.T) {
 		{`3 /^ 2 /& 1`, `(3 /^ (2 /& 1))`},
 		{`3 /| 2 /^ 1`, `((3 /| 2) /^ 1)`},
 		{`3 /^ 2 /| 1`, `((3 /^ 2) /| 1)`},
+		{`3 + 2 in 1 + 1`, `((3 + 2) in (1 + 1))`},
+		{`3 in 4 && 5 in 6`, `((3 in 4) && (5 in 6))`},
+		{`3 in 4 == true`, `((3 in 4) == true)`},
+		// NOTE: user-defined operators have lower precedence than bitwise operators
+		{`3 <+> 2 + 1`, `(3 <+> (2 + 1))`},
+		{`3 ⊗ 2 /| 1`, `(3 ⊗ (2 /| 1))`},
+		{`3 ⊕ 2 == 1`, `((3 ⊕ 2) == 1)`},
+		{`3 ⊕ 2 ⊗ 1`, `((3 ⊕ 2) ⊗ 1)`},
+		// `in` is a keyword only if it is not a part of an ident
+		{`index in inner`, `(index in inner)`},
 		// test for parens
 		{`2 - (3 + 4)`, `(2 - (3 + 4))`},
 		{`(3 + 4) * 2`, `((3 + 4) * 2)`},
@@ -267,6 +295,32 @@ func TestInfixPrecedence(t *testing.T) {
 	}
 }
 
+func TestInAsIdent(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`in`, `in`},
+		{`{in: 1}.in`, `{in: 1}.in()`},
+		{`f(in: 1)`, `f.call(in: 1)`},
+		{`[in, 1]`, `[in, 1]`},
+		{`{|in| in}`, `{|in| in}`},
+		{`a in in`, `(a in in)`},
+		{`in in in`, `(in in in)`},
+		{`x.in in [in]`, `(x.in() in [in])`},
+	}
+
+	for _, tt := range tests {
+		program := testParse(t, tt.input)
+		expr := extractExprStmt(t, program)
+
+		actual := expr.String()
+		if actual != tt.expected {
+			t.Errorf("wrong output. expected=%s, got=%s", tt.expected, actual)
+		}
+	}
+}
+
 func TestPrefixExpression(t *testing.T) {
 	tests := []struct {
 		input string
@@ -355,6 +409,18 @@ func TestChainPrecedence(t *testing.T) {
 			`(4-3).even?`,
 			`(4 - 3).even?()`,
 		},
+		{
+			`a + b^trace.c`,
+			`(a + b^trace.c())`,
+		},
+		{
+			`-a^trace@b.c`,
+			`(-a)^trace@b().c()`,
+		},
+		{
+			`a.^b.c`,
+			`a.^b().c()`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -473,6 +539,11 @@ func TestSymLiteral(t *testing.T) {
 		{`'/^`, "/^"},
 		{`'<<`, "<<"},
 		{`'>>`, ">>"},
+		{`'in`, "in"},
+		{`'<+>`, "<+>"},
+		{`'<|>`, "<|>"},
+		{`'⊕`, "⊕"},
+		{`'·`, "·"},
 	}
 
 	for _, tt := range tests {
@@ -2072,6 +2143,10 @@ func TestPropCall(t *testing.T) {
 		{`5&$foo`, 5, "&$", nil, "foo"},
 		{`5~$foo`, 5, "~$", nil, "foo"},
 		{`5=$foo`, 5, "=$", nil, "foo"},
+		{`5^trace.foo`, 5, "^trace.", nil, "foo"},
+		{`5^trace@foo`, 5, "^trace@", nil, "foo"},
+		{`5^trace@(10)foo`, 5, "^trace@", 10, "foo"},
+		{`5^trace$(0)+`, 5, "^trace$", 0, "+"},
 	}
 
 	for _, tt := range tests {
@@ -2090,6 +2165,48 @@ func TestPropCall(t *testing.T) {
 	}
 }
 
+func TestContextChain(t *testing.T) {
+	tests := []struct {
+		input    string
+		context  string
+		mainType ast.MainChain
+	}{
+		{`5^trace.foo`, "trace", ast.Scalar},
+		{`5^timed@{|a| 1}`, "timed", ast.List},
+		{`5^my_ctx2$(0)^foo`, "my_ctx2", ast.Reduce},
+	}
+
+	for _, tt := range tests {
+		program := testParse(t, tt.input)
+		expr := extractExprStmt(t, program)
+
+		callExpr, ok := expr.(ast.CallExpr)
+		if !ok {
+			t.Fatalf("expr is not ast.CallExpr. got=%T", expr)
+		}
+
+		var chain *ast.Chain
+		switch c := callExpr.(type) {
+		case *ast.PropCallExpr:
+			chain = c.Chain
+		case *ast.LiteralCallExpr:
+			chain = c.Chain
+		case *ast.VarCallExpr:
+			chain = c.Chain
+		}
+
+		if chain.Additional != ast.UserDefined {
+			t.Errorf("wrong additional chain. expected=%v, got=%v", ast.UserDefined, chain.Additional)
+		}
+		if chain.Context != tt.context {
+			t.Errorf("wrong context. expected=%s, got=%s", tt.context, chain.Context)
+		}
+		if chain.Main != tt.mainType {
+			t.Errorf("wrong main chain. expected=%v, got=%v", tt.mainType, chain.Main)
+		}
+	}
+}
+
 func TestRecursiveChain(t *testing.T) {
 	input := `foo.bar()@(10)hoge$piyo$(1)fuga().puts`
 	expectedVals := []struct {
@@ -2156,6 +2273,9 @@ func TestOpMethods(t *testing.T) {
 		{`10.!(1)`, `!`},
 		{`10.+%(1)`, `+%`},
 		{`10.-%(1)`, `-%`},
+		{`10.in(1)`, `in`},
+		{`10.<+>(1)`, `<+>`},
+		{`10.⊕(1)`, `⊕`},
 	}
 
 	for _, tt := range tests {
@@ -2556,6 +2676,8 @@ func TestLiteralCall(t *testing.T) {
 		{`5&${|a| 1}`, 5, "&$", nil},
 		{`5~${|a| 1}`, 5, "~$", nil},
 		{`5=${|a| 1}`, 5, "=$", nil},
+		{`5^trace@{|a| 1}`, 5, "^trace@", nil},
+		{`5^trace$(0){|a| 1}`, 5, "^trace$", 0},
 	}
 
 	for _, tt := range tests {
@@ -2736,6 +2858,7 @@ func TestVarCall(t *testing.T) {
 		{`5&$^foo`, 5, "&$", nil, "foo"},
 		{`5~$^foo`, 5, "~$", nil, "foo"},
 		{`5=$^foo`, 5, "=$", nil, "foo"},
+		{`5^trace.^foo`, 5, "^trace.", nil, "foo"},
 	}
 
 	for _, tt := range tests {
@@ -3312,6 +3435,9 @@ func TestAssignExpr(t *testing.T) {
 		{`a := 10`, "a", "Int", 10},
 		{`hello := "Hello, world!"`, "hello", "Str", "Hello, world!"},
 		{`newVar := myVar`, "newVar", "Ident", "myVar"},
+		// `in` is an ident unless it follows an operand
+		{`in := 3`, "in", "Int", 3},
+		{`a := in`, "a", "Ident", "in"},
 	}
 
 	for _, tt := range tests {
@@ -5335,6 +5461,149 @@ func TestComment(t *testing.T) {
 	}
 }
 
+func TestPairDocComment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			`{a: 1, b: 2}`,
+			[]string{"", ""},
+		},
+		{
+			`{
+			  # a is one.
+			  a: 1,
+			  # b is two.
+			  b: 2,
+			}`,
+			[]string{"a is one.", "b is two."},
+		},
+		// multiple lines
+		{
+			`{
+			  # a is
+			  #one.
+			  a: 1,
+			}`,
+			[]string{"a is\none."},
+		},
+		// comments separated by a blank line are not doc
+		{
+			`{
+			  # section
+
+			  a: 1, # trailing
+			  b: 2,
+			}`,
+			[]string{"", ""},
+		},
+		// trailing comment of the brace is not doc
+		{
+			`{ # trailing
+			  a: 1,
+			}`,
+			[]string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		program := testParse(t, tt.input)
+		obj, ok := extractExprStmt(t, program).(*ast.ObjLiteral)
+		if !ok {
+			t.Fatalf("expr is not *ast.ObjLiteral. got=%T", extractExprStmt(t, program))
+		}
+
+		if len(obj.Pairs) != len(tt.expected) {
+			t.Fatalf("wrong length of pairs. expected=%d, got=%d", len(tt.expected), len(obj.Pairs))
+		}
+
+		for i, pair := range obj.Pairs {
+			if pair.Doc != tt.expected[i] {
+				t.Errorf("wrong doc of pairs[%d] in `%s`. expected=%q, got=%q",
+					i, tt.input, tt.expected[i], pair.Doc)
+			}
+		}
+	}
+}
+
+func TestFuncDocComment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`o := {
+			  # f does nothing.
+			  f: m{},
+			}`,
+			"f does nothing.",
+		},
+		{
+			`# f does nothing.
+			f := {|x| x}`,
+			"f does nothing.",
+		},
+		{
+			`1
+			# f does nothing.
+			f := {|x| x}`,
+			"f does nothing.",
+		},
+		{
+			`1 # trailing
+			f := {|x| x}`,
+			"",
+		},
+		{
+			`1; f := {|x| x}`,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		program := testParse(t, tt.input)
+
+		var found *ast.FuncLiteral
+		walkFuncLiterals(program, func(f *ast.FuncLiteral) {
+			found = f
+		})
+		if found == nil {
+			t.Fatalf("func literal is not found in `%s`", tt.input)
+		}
+
+		if found.Doc != tt.expected {
+			t.Errorf("wrong doc in `%s`. expected=%q, got=%q", tt.input, tt.expected, found.Doc)
+		}
+	}
+}
+
+// walkFuncLiterals calls f with func literals which are pair values or assigned values.
+func walkFuncLiterals(program *ast.Program, f func(*ast.FuncLiteral)) {
+	for _, stmt := range program.Stmts {
+		exprStmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+
+		var expr ast.Expr = exprStmt.Expr
+		if assign, ok := expr.(*ast.AssignExpr); ok {
+			expr = assign.Right
+		}
+
+		switch e := expr.(type) {
+		case *ast.FuncLiteral:
+			f(e)
+		case *ast.ObjLiteral:
+			for _, pair := range e.Pairs {
+				if fl, ok := pair.Val.(*ast.FuncLiteral); ok {
+					f(fl)
+				}
+			}
+		}
+	}
+}
+
 func TestUnmatchedParenParseErr(t *testing.T) {
 	tests := []string{
 		`(`,
@@ -5408,6 +5677,72 @@ func TestImcompleteEmbeddedStrParseErr(t *testing.T) {
 	}
 }
 
+func TestIncompleteSourceParseErr(t *testing.T) {
+	tests := []struct {
+		input      string
+		incomplete bool
+	}{
+		{`(`, true},
+		{`[2,3,4,`, true},
+		{`{'a: 1`, true},
+		{`a.foo(1`, true},
+		{`a.`, true},
+		{`1 +`, true},
+		{`a :=`, true},
+		{"{|a|\n\ta", true},
+		{"`a", true},
+		{"`a\nb", true},
+		{`"aa#{`, true},
+		{`)`, false},
+		{`a ]`, false},
+		{`1 2`, false},
+		{`"a`, false},
+		{`'1`, false},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(NewReader(strings.NewReader(tt.input), "<stdin>"))
+		if err == nil {
+			t.Fatalf("expected parse error did not occur in `%s`", tt.input)
+		}
+
+		if errors.Is(err, ErrIncomplete) != tt.incomplete {
+			t.Errorf("wrong incompleteness in `%s`: expected=%v, got=%v",
+				tt.input, tt.incomplete, !tt.incomplete)
+		}
+	}
+}
+
+func TestParseErrPosition(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *ast.Position
+	}{
+		{`)`, &ast.Position{Line: 0, Column: 0, FileName: "<stdin>"}},
+		{`1 2`, &ast.Position{Line: 0, Column: 2, FileName: "<stdin>"}},
+		{"a := 1\nb := ]", &ast.Position{Line: 1, Column: 5, FileName: "<stdin>"}},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(NewReader(strings.NewReader(tt.input), "<stdin>"))
+		if err == nil {
+			t.Fatalf("expected parse error did not occur in `%s`", tt.input)
+		}
+
+		var perr *Error
+		if !errors.As(err, &perr) {
+			t.Fatalf("err must be *Error. got=%T", err)
+		}
+		if perr.Pos == nil {
+			t.Fatalf("position must be set in `%s`", tt.input)
+		}
+		if *perr.Pos != *tt.expected {
+			t.Errorf("wrong position in `%s`: expected=%+v, got=%+v",
+				tt.input, *tt.expected, *perr.Pos)
+		}
+	}
+}
+
 func TestInvalidSym(t *testing.T) {
 	tests := []string{
 		`'1`,
@@ -0,0 +1,317 @@
+package props
+
+import (
+	"github.com/Syuparn/pangaea/object"
+)
+
+// elemPool is a sequence of elements picked by combinatorics iters.
+type elemPool interface {
+	// has returns whether the pool has the i-th element.
+	has(env *object.Env, i int) (bool, *object.PanErr)
+	// at returns the i-th element (it must be checked by has beforehand).
+	at(i int) object.PanObject
+}
+
+// arrPool is a pool whose elements are already known.
+type arrPool []object.PanObject
+
+func (p arrPool) has(env *object.Env, i int) (bool, *object.PanErr) {
+	return i < len(p), nil
+}
+
+func (p arrPool) at(i int) object.PanObject {
+	return p[i]
+}
+
+// iterPool is a pool which reads elements from the iter only when they are required.
+// NOTE: this enables combinatorics iters to treat infinite iters
+type iterPool struct {
+	propContainer map[string]object.PanObject
+	it            object.PanObject
+	elems         []object.PanObject
+	stopped       bool
+}
+
+func (p *iterPool) has(env *object.Env, i int) (bool, *object.PanErr) {
+	for len(p.elems) <= i && !p.stopped {
+		ret := p.propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+			env, object.EmptyPanObjPtr(),
+			object.EmptyPanObjPtr(), p.it, nextSym,
+		)
+		if err, ok := ret.(*object.PanErr); ok {
+			if err.ErrKind != object.StopIterErr {
+				return false, err
+			}
+			p.stopped = true
+			break
+		}
+		p.elems = append(p.elems, ret)
+	}
+	return i < len(p.elems), nil
+}
+
+func (p *iterPool) at(i int) object.PanObject {
+	return p.elems[i]
+}
+
+// newElemPool returns a pool of elements in o.
+// Elements of arrs and sets are used as they are, and others are read lazily by `_iter`.
+func newElemPool(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	o object.PanObject,
+) (elemPool, *object.PanErr) {
+	if arr, ok := o.(*object.PanArr); ok {
+		return arrPool(arr.Elems), nil
+	}
+	if set, ok := o.(*object.PanSet); ok {
+		return arrPool(set.Items()), nil
+	}
+
+	it := propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+		env, object.EmptyPanObjPtr(),
+		object.EmptyPanObjPtr(), o, iterSym,
+	)
+	if err, ok := it.(*object.PanErr); ok {
+		return nil, err
+	}
+	return &iterPool{propContainer: propContainer, it: it}, nil
+}
+
+// poolLen reads all elements in p and returns the number of them.
+func poolLen(env *object.Env, p elemPool) (int, *object.PanErr) {
+	n := 0
+	for {
+		ok, err := p.has(env, n)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return n, nil
+		}
+		n++
+	}
+}
+
+// combinationsIter yields k-length combinations of elements in p in lexicographic order of indices.
+func combinationsIter(p elemPool, k int) object.BuiltInFunc {
+	var indices []int
+	done := false
+
+	return func(
+		env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+	) object.PanObject {
+		if done {
+			return object.NewStopIterErr("iter stopped")
+		}
+
+		var ok bool
+		var err *object.PanErr
+		if indices == nil {
+			indices = make([]int, k)
+			for i := range indices {
+				indices[i] = i
+			}
+			ok, err = p.has(env, k-1)
+		} else {
+			ok, err = nextCombination(env, p, indices)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			done = true
+			return object.NewStopIterErr("iter stopped")
+		}
+
+		return pickElems(p, indices)
+	}
+}
+
+// nextCombination increments indices to the next combination.
+// It returns false if indices is the last combination.
+func nextCombination(env *object.Env, p elemPool, indices []int) (bool, *object.PanErr) {
+	k := len(indices)
+	// find the rightmost index which can be incremented
+	// NOTE: the following indices are filled with consecutive ones
+	for i := k - 1; i >= 0; i-- {
+		ok, err := p.has(env, indices[i]+k-i)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			continue
+		}
+
+		indices[i]++
+		for j := i + 1; j < k; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// permutationsIter yields k-length permutations of elements in p in lexicographic order of indices.
+// If k is negative, all elements are permuted.
+func permutationsIter(p elemPool, k int) object.BuiltInFunc {
+	var indices []int
+	used := map[int]bool{}
+	done := false
+
+	return func(
+		env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+	) object.PanObject {
+		if done {
+			return object.NewStopIterErr("iter stopped")
+		}
+
+		var ok bool
+		var err *object.PanErr
+		if indices == nil {
+			if k < 0 {
+				k, err = poolLen(env, p)
+				if err != nil {
+					return err
+				}
+			}
+
+			indices = make([]int, k)
+			for i := range indices {
+				indices[i] = i
+				used[i] = true
+			}
+			ok, err = p.has(env, k-1)
+		} else {
+			ok, err = nextPermutation(env, p, indices, used)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			done = true
+			return object.NewStopIterErr("iter stopped")
+		}
+
+		return pickElems(p, indices)
+	}
+}
+
+// nextPermutation replaces indices with the next permutation.
+// It returns false if indices is the last permutation.
+func nextPermutation(env *object.Env, p elemPool, indices []int, used map[int]bool) (bool, *object.PanErr) {
+	// replace the rightmost index by a larger unused one
+	// and fill the following indices with the smallest unused ones
+	for i := len(indices) - 1; i >= 0; i-- {
+		delete(used, indices[i])
+		next, err := nextUnused(env, p, used, indices[i]+1)
+		if err != nil {
+			return false, err
+		}
+		if next < 0 {
+			continue
+		}
+
+		indices[i] = next
+		used[next] = true
+		for j := i + 1; j < len(indices); j++ {
+			// NOTE: unused ones must be found because the pool has at least len(indices) elements
+			indices[j], err = nextUnused(env, p, used, 0)
+			if err != nil {
+				return false, err
+			}
+			used[indices[j]] = true
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// productIter yields cartesian product of pools (the last pool changes fastest).
+func productIter(pools []elemPool) object.BuiltInFunc {
+	var indices []int
+	done := false
+
+	return func(
+		env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+	) object.PanObject {
+		if done {
+			return object.NewStopIterErr("iter stopped")
+		}
+
+		var ok bool
+		var err *object.PanErr
+		if indices == nil {
+			indices = make([]int, len(pools))
+			ok, err = hasFirstElems(env, pools)
+		} else {
+			ok, err = nextProduct(env, pools, indices)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			done = true
+			return object.NewStopIterErr("iter stopped")
+		}
+
+		elems := make([]object.PanObject, len(pools))
+		for i, pool := range pools {
+			elems[i] = pool.at(indices[i])
+		}
+		return object.NewPanArr(elems...)
+	}
+}
+
+func hasFirstElems(env *object.Env, pools []elemPool) (bool, *object.PanErr) {
+	for _, pool := range pools {
+		ok, err := pool.has(env, 0)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// nextProduct increments indices like an odometer.
+// It returns false if indices is the last product.
+func nextProduct(env *object.Env, pools []elemPool, indices []int) (bool, *object.PanErr) {
+	for i := len(pools) - 1; i >= 0; i-- {
+		ok, err := pools[i].has(env, indices[i]+1)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			indices[i]++
+			return true, nil
+		}
+		indices[i] = 0
+	}
+	return false, nil
+}
+
+func pickElems(p elemPool, indices []int) *object.PanArr {
+	picked := make([]object.PanObject, len(indices))
+	for i, idx := range indices {
+		picked[i] = p.at(idx)
+	}
+	return object.NewPanArr(picked...)
+}
+
+// nextUnused returns the smallest unused index from from (or returns -1 if p has no such elements).
+func nextUnused(env *object.Env, p elemPool, used map[int]bool, from int) (int, *object.PanErr) {
+	for i := from; ; i++ {
+		if used[i] {
+			continue
+		}
+
+		ok, err := p.has(env, i)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return -1, nil
+		}
+		return i, nil
+	}
+}
@@ -0,0 +1,274 @@
+package props
+
+import (
+	"github.com/Syuparn/pangaea/object"
+)
+
+// groupedElems groups elems by keys generated from f.
+// Groups are ordered by the first appearance of each key.
+func groupedElems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	elems []object.PanObject,
+	f object.PanObject,
+) ([]object.PanObject, [][]object.PanObject, *object.PanErr) {
+	x := newElemIndex(propContainer, env)
+	keys := []object.PanObject{}
+	groups := [][]object.PanObject{}
+
+	for _, elem := range elems {
+		key := callFunc(propContainer, env, f, elem)
+		if err, ok := key.(*object.PanErr); ok {
+			return nil, nil, err
+		}
+
+		id, err := x.find(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if id < 0 {
+			id = len(keys)
+			x.add(key, id)
+			keys = append(keys, key)
+			groups = append(groups, []object.PanObject{})
+		}
+		groups[id] = append(groups[id], elem)
+	}
+
+	return keys, groups, nil
+}
+
+func groupBy(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	elems []object.PanObject,
+	f object.PanObject,
+) object.PanObject {
+	keys, groups, err := groupedElems(propContainer, env, elems, f)
+	if err != nil {
+		return err
+	}
+
+	pairs := []object.Pair{}
+	for i, key := range keys {
+		pairs = append(pairs, object.Pair{Key: key, Value: object.NewPanArr(groups[i]...)})
+	}
+	return object.NewPanMap(pairs...)
+}
+
+func countBy(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	elems []object.PanObject,
+	f object.PanObject,
+) object.PanObject {
+	keys, groups, err := groupedElems(propContainer, env, elems, f)
+	if err != nil {
+		return err
+	}
+
+	pairs := []object.Pair{}
+	for i, key := range keys {
+		pairs = append(pairs, object.Pair{Key: key, Value: object.NewPanInt(int64(len(groups[i])))})
+	}
+	return object.NewPanMap(pairs...)
+}
+
+func uniqBy(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	elems []object.PanObject,
+	f object.PanObject,
+) object.PanObject {
+	_, groups, err := groupedElems(propContainer, env, elems, f)
+	if err != nil {
+		return err
+	}
+
+	uniq := []object.PanObject{}
+	for _, group := range groups {
+		uniq = append(uniq, group[0])
+	}
+	return object.NewPanArr(uniq...)
+}
+
+func partition(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	elems []object.PanObject,
+	f object.PanObject,
+) object.PanObject {
+	truthy := []object.PanObject{}
+	falsy := []object.PanObject{}
+
+	for _, elem := range elems {
+		res := callFunc(propContainer, env, f, elem)
+		if err, ok := res.(*object.PanErr); ok {
+			return err
+		}
+
+		// convert res to bool
+		resBool := propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+			env, object.EmptyPanObjPtr(),
+			object.EmptyPanObjPtr(), res, bSym,
+		)
+		if err, ok := resBool.(*object.PanErr); ok {
+			return err
+		}
+
+		if resBool == object.BuiltInTrue {
+			truthy = append(truthy, elem)
+		} else {
+			falsy = append(falsy, elem)
+		}
+	}
+
+	return object.NewPanArr(object.NewPanArr(truthy...), object.NewPanArr(falsy...))
+}
+
+// flattenElems flattens nested arrs in elems up to depth (no limit if depth is negative).
+func flattenElems(elems []object.PanObject, depth int) []object.PanObject {
+	flattened := []object.PanObject{}
+	for _, elem := range elems {
+		arr, ok := elem.(*object.PanArr)
+		if !ok || depth == 0 {
+			flattened = append(flattened, elem)
+			continue
+		}
+		flattened = append(flattened, flattenElems(arr.Elems, depth-1)...)
+	}
+	return flattened
+}
+
+// setOperation is an operation to combine elements of self and others.
+type setOperation func(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	self []object.PanObject,
+	others [][]object.PanObject,
+) ([]object.PanObject, *object.PanErr)
+
+func unionElems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	self []object.PanObject,
+	others [][]object.PanObject,
+) ([]object.PanObject, *object.PanErr) {
+	elems := append([]object.PanObject{}, self...)
+	for _, other := range others {
+		elems = append(elems, other...)
+	}
+	return uniqElems(propContainer, env, elems)
+}
+
+func intersectElems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	self []object.PanObject,
+	others [][]object.PanObject,
+) ([]object.PanObject, *object.PanErr) {
+	elems, err := uniqElems(propContainer, env, self)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range others {
+		x := newElemIndex(propContainer, env)
+		for _, elem := range other {
+			x.add(elem, 0)
+		}
+
+		selected := []object.PanObject{}
+		for _, elem := range elems {
+			id, err := x.find(elem)
+			if err != nil {
+				return nil, err
+			}
+			if id >= 0 {
+				selected = append(selected, elem)
+			}
+		}
+		elems = selected
+	}
+
+	return elems, nil
+}
+
+// NOTE: duplicated elements in self remain as long as they are not in others
+func diffElems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	self []object.PanObject,
+	others [][]object.PanObject,
+) ([]object.PanObject, *object.PanErr) {
+	x := newElemIndex(propContainer, env)
+	for _, other := range others {
+		for _, elem := range other {
+			x.add(elem, 0)
+		}
+	}
+
+	elems := []object.PanObject{}
+	for _, elem := range self {
+		id, err := x.find(elem)
+		if err != nil {
+			return nil, err
+		}
+		if id < 0 {
+			elems = append(elems, elem)
+		}
+	}
+
+	return elems, nil
+}
+
+// symDiffElems returns elements which are contained in exactly one of self and others.
+func symDiffElems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	self []object.PanObject,
+	others [][]object.PanObject,
+) ([]object.PanObject, *object.PanErr) {
+	elems, err := uniqElems(propContainer, env, self)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range others {
+		other, err := uniqElems(propContainer, env, other)
+		if err != nil {
+			return nil, err
+		}
+
+		left, err := diffElems(propContainer, env, elems, [][]object.PanObject{other})
+		if err != nil {
+			return nil, err
+		}
+		right, err := diffElems(propContainer, env, other, [][]object.PanObject{elems})
+		if err != nil {
+			return nil, err
+		}
+		elems = append(left, right...)
+	}
+
+	return elems, nil
+}
+
+func applySetOperation(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	op setOperation,
+	self []object.PanObject,
+	args []object.PanObject,
+) ([]object.PanObject, *object.PanErr) {
+	others := [][]object.PanObject{}
+	for _, arg := range args {
+		elems, err := iterableElems(propContainer, env, arg)
+		if err != nil {
+			return nil, err
+		}
+		others = append(others, elems)
+	}
+
+	return op(propContainer, env, self, others)
+}
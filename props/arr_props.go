@@ -12,6 +12,7 @@ import (
 func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// == returns whether self and the arg have equal elements.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -32,6 +33,7 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return compArrs(self, other, propContainer, env)
 			},
 		),
+		// + returns a new arr concatenating self and the arg.
 		"+": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -63,6 +65,7 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanArr(elems...)
 			},
 		),
+		// * returns a new arr repeating elements of self the arg times.
 		"*": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -110,7 +113,9 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		"_name": object.NewPanStr("Arr"),
-		"at":    propContainer["Arr_at"],
+		// at returns the element at the index (or the sub arr of the range).
+		"at": propContainer["Arr_at"],
+		// B returns false if self is empty, otherwise true.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -130,6 +135,7 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		// NOTE: override bear to set arr zero values
+		// bear creates a child of self with the obj literal src.
 		"bear": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -162,6 +168,30 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 					}))
 			},
 		),
+		// bottomN returns the n smallest elements in ascending order (compared by `by:` if specified).
+		"bottomN": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Arr#bottomN requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+				n, ok := object.TraceProtoOfInt(args[1])
+				if !ok {
+					return object.NewTypeErr(`\2 must be int`)
+				}
+				if n.Value < 0 {
+					return object.NewValueErr(`\2 must not be negative`)
+				}
+
+				return selectedElems(propContainer, env, kwargs, self.Elems, int(n.Value), false)
+			},
+		),
+		// call creates a new arr whose elements are the args.
 		"call": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -174,6 +204,111 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedArr(args[0], args[1:]...)
 			},
 		),
+		// combinations returns an iter of all k-length combinations of elements.
+		"combinations": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Arr#combinations requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+				k, ok := object.TraceProtoOfInt(args[1])
+				if !ok {
+					return object.NewTypeErr(`\2 must be int`)
+				}
+				if k.Value < 0 {
+					return object.NewValueErr(`\2 must not be negative`)
+				}
+
+				return object.NewPanBuiltInIter(combinationsIter(arrPool(self.Elems), int(k.Value)), env)
+			},
+		),
+		// countBy returns a map from keys returned by f to the numbers of elements.
+		"countBy": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Arr#countBy requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				return countBy(propContainer, env, self.Elems, args[1])
+			},
+		),
+		// diff returns elements of self which are not included in any args.
+		"diff": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Arr#diff requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				elems, err := applySetOperation(propContainer, env, diffElems, self.Elems, args[1:])
+				if err != nil {
+					return err
+				}
+				return object.NewPanArr(elems...)
+			},
+		),
+		// flatten returns a new arr with nested arrs flattened up to depth (all levels if not specified).
+		"flatten": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Arr#flatten requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				// NOTE: flatten all nested arrs if depth is not specified
+				depth := -1
+				if len(args) >= 2 && args[1] != object.BuiltInNil {
+					d, ok := object.TraceProtoOfInt(args[1])
+					if !ok {
+						return object.NewTypeErr(`\2 must be int`)
+					}
+					if d.Value < 0 {
+						return object.NewValueErr(`\2 must not be negative`)
+					}
+					depth = int(d.Value)
+				}
+
+				return object.NewPanArr(flattenElems(self.Elems, depth)...)
+			},
+		),
+		// groupBy returns a map from keys returned by f to arrs of elements.
+		"groupBy": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Arr#groupBy requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				return groupBy(propContainer, env, self.Elems, args[1])
+			},
+		),
+		// has? returns whether self includes the arg.
 		"has?": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -200,6 +335,27 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInFalse
 			},
 		),
+		// intersect returns unique elements included in self and all args.
+		"intersect": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Arr#intersect requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				elems, err := applySetOperation(propContainer, env, intersectElems, self.Elems, args[1:])
+				if err != nil {
+					return err
+				}
+				return object.NewPanArr(elems...)
+			},
+		),
+		// join returns a str concatenating elements (converted by S) with the separator.
 		"join": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -239,6 +395,7 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanStr(res)
 			},
 		),
+		// len returns the number of elements.
 		"len": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -254,6 +411,7 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanInt(int64(len(self.Elems)))
 			},
 		),
+		// M converts an arr of key-value pairs to a map.
 		"M": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -290,6 +448,7 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanMap(pairs...)
 			},
 		),
+		// new converts the arg to arr by its A prop.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -307,6 +466,7 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedArr(args[0], arr.Elems...)
 			},
 		),
+		// O converts an arr of key-value pairs to an obj (first pair wins if keys are duplicated).
 		"O": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -351,6 +511,188 @@ func ArrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.PanObjInstancePtr(&pairs)
 			},
 		),
+		// partition returns an arr of elements which satisfy f and an arr of the rest.
+		"partition": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Arr#partition requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				return partition(propContainer, env, self.Elems, args[1])
+			},
+		),
+		// permutations returns an iter of all k-length permutations of elements (k is the length by default).
+		"permutations": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Arr#permutations requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				// NOTE: all elements are permuted if k is not specified
+				k := len(self.Elems)
+				if len(args) >= 2 && args[1] != object.BuiltInNil {
+					kInt, ok := object.TraceProtoOfInt(args[1])
+					if !ok {
+						return object.NewTypeErr(`\2 must be int`)
+					}
+					if kInt.Value < 0 {
+						return object.NewValueErr(`\2 must not be negative`)
+					}
+					k = int(kInt.Value)
+				}
+
+				return object.NewPanBuiltInIter(permutationsIter(arrPool(self.Elems), k), env)
+			},
+		),
+		// product returns an iter of the cartesian product of self and iterables in the args.
+		"product": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Arr#product requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				pools := []elemPool{arrPool(self.Elems)}
+				for _, arg := range args[1:] {
+					pool, err := newElemPool(propContainer, env, arg)
+					if err != nil {
+						return err
+					}
+					pools = append(pools, pool)
+				}
+
+				return object.NewPanBuiltInIter(productIter(pools), env)
+			},
+		),
+		// sort returns a new sorted arr.
+		// The order can be changed by `cmp:` (comparison func) and `rev:` (descending if true).
+		"sort": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Arr#sort requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				return sortedElems(propContainer, env, kwargs, self.Elems, nil)
+			},
+		),
+		// sortBy returns a new arr sorted by keys returned by f.
+		"sortBy": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Arr#sortBy requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				return sortedElems(propContainer, env, kwargs, self.Elems, args[1])
+			},
+		),
+		// topN returns the n largest elements in descending order (compared by `by:` if specified).
+		"topN": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Arr#topN requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+				n, ok := object.TraceProtoOfInt(args[1])
+				if !ok {
+					return object.NewTypeErr(`\2 must be int`)
+				}
+				if n.Value < 0 {
+					return object.NewValueErr(`\2 must not be negative`)
+				}
+
+				return selectedElems(propContainer, env, kwargs, self.Elems, int(n.Value), true)
+			},
+		),
+		// union returns unique elements included in self or any args.
+		"union": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Arr#union requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				elems, err := applySetOperation(propContainer, env, unionElems, self.Elems, args[1:])
+				if err != nil {
+					return err
+				}
+				return object.NewPanArr(elems...)
+			},
+		),
+		// uniq returns a new arr without duplicated elements (the first one is kept).
+		"uniq": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Arr#uniq requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				elems, err := uniqElems(propContainer, env, self.Elems)
+				if err != nil {
+					return err
+				}
+				return object.NewPanArr(elems...)
+			},
+		),
+		// uniqBy returns a new arr without elements whose keys returned by f are duplicated.
+		"uniqBy": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Arr#uniqBy requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfArr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be arr`)
+				}
+
+				return uniqBy(propContainer, env, self.Elems, args[1])
+			},
+		),
 	}
 }
 
@@ -0,0 +1,225 @@
+package props
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// comparator compares elements by `<=>` or the func passed by `cmp:`.
+// NOTE: err raised in comparison is kept in err because sort cannot be interrupted
+type comparator struct {
+	env           *object.Env
+	propContainer map[string]object.PanObject
+	cmp           object.PanObject
+	rev           bool
+	err           *object.PanErr
+}
+
+func newComparator(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	kwargs *object.PanObj,
+) *comparator {
+	c := &comparator{env: env, propContainer: propContainer}
+
+	if pair, ok := propIn(kwargs, "cmp"); ok && pair.Value != object.BuiltInNil {
+		c.cmp = pair.Value
+	}
+	if pair, ok := propIn(kwargs, "rev"); ok {
+		c.rev = (pair.Value == object.BuiltInTrue)
+	}
+
+	return c
+}
+
+// compare returns -1, 0 or 1 like `<=>` (the sign is reversed if rev is true).
+// It always returns 0 after an err is raised.
+func (c *comparator) compare(a, b object.PanObject) int {
+	if c.err != nil {
+		return 0
+	}
+
+	var res object.PanObject
+	if c.cmp == nil {
+		// a <=> b
+		res = c.propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+			c.env, object.EmptyPanObjPtr(),
+			object.EmptyPanObjPtr(), a, spaceshipSym, b,
+		)
+	} else {
+		// cmp(a, b)
+		res = callFunc(c.propContainer, c.env, c.cmp, a, b)
+	}
+
+	if err, ok := res.(*object.PanErr); ok {
+		c.err = err
+		return 0
+	}
+
+	resInt, ok := object.TraceProtoOfInt(res)
+	if !ok {
+		if c.cmp == nil {
+			c.err = object.NewValueErr(`<=> returned non-int value`)
+		} else {
+			c.err = object.NewValueErr(
+				fmt.Sprintf("cmp returned non-int value %s", res.Repr()))
+		}
+		return 0
+	}
+
+	sign := 0
+	switch {
+	case resInt.Value < 0:
+		sign = -1
+	case resInt.Value > 0:
+		sign = 1
+	}
+
+	if c.rev {
+		return -sign
+	}
+	return sign
+}
+
+// sortItem is an element of sorted arr with its sort key.
+type sortItem struct {
+	key   object.PanObject
+	elem  object.PanObject
+	index int
+}
+
+func newSortItems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	elems []object.PanObject,
+	keyFunc object.PanObject,
+) ([]sortItem, *object.PanErr) {
+	items := make([]sortItem, len(elems))
+	for i, elem := range elems {
+		items[i] = sortItem{key: elem, elem: elem, index: i}
+		if keyFunc == nil {
+			continue
+		}
+
+		// NOTE: each key is calculated only once
+		key := callFunc(propContainer, env, keyFunc, elem)
+		if err, ok := key.(*object.PanErr); ok {
+			return nil, err
+		}
+		items[i].key = key
+	}
+
+	return items, nil
+}
+
+func sortedElems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	kwargs *object.PanObj,
+	elems []object.PanObject,
+	keyFunc object.PanObject,
+) object.PanObject {
+	items, err := newSortItems(propContainer, env, elems, keyFunc)
+	if err != nil {
+		return err
+	}
+
+	c := newComparator(propContainer, env, kwargs)
+	sort.SliceStable(items, func(i, j int) bool {
+		return c.compare(items[i].key, items[j].key) < 0
+	})
+	if c.err != nil {
+		return c.err
+	}
+
+	return itemsToArr(items)
+}
+
+// selectedElems returns the first n elements of sorted elems.
+// Only n elements are kept in the heap instead of sorting all elements.
+func selectedElems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	kwargs *object.PanObj,
+	elems []object.PanObject,
+	n int,
+	rev bool,
+) object.PanObject {
+	var keyFunc object.PanObject
+	if pair, ok := propIn(kwargs, "by"); ok && pair.Value != object.BuiltInNil {
+		keyFunc = pair.Value
+	}
+
+	items, err := newSortItems(propContainer, env, elems, keyFunc)
+	if err != nil {
+		return err
+	}
+
+	c := newComparator(propContainer, env, kwargs)
+	c.rev = rev
+	// NOTE: earlier element precedes if keys are equal so that the result is stable
+	precedes := func(a, b sortItem) bool {
+		if res := c.compare(a.key, b.key); res != 0 {
+			return res < 0
+		}
+		return a.index < b.index
+	}
+
+	h := &sortItemHeap{less: func(a, b sortItem) bool { return precedes(b, a) }}
+	for _, item := range items {
+		if n == 0 {
+			break
+		}
+		if h.Len() < n {
+			heap.Push(h, item)
+		} else if precedes(item, h.items[0]) {
+			// replace the last one of selected items
+			h.items[0] = item
+			heap.Fix(h, 0)
+		}
+		if c.err != nil {
+			return c.err
+		}
+	}
+
+	selected := h.items
+	sort.SliceStable(selected, func(i, j int) bool {
+		return precedes(selected[i], selected[j])
+	})
+	if c.err != nil {
+		return c.err
+	}
+
+	return itemsToArr(selected)
+}
+
+func itemsToArr(items []sortItem) *object.PanArr {
+	elems := make([]object.PanObject, len(items))
+	for i, item := range items {
+		elems[i] = item.elem
+	}
+	return object.NewPanArr(elems...)
+}
+
+// sortItemHeap is a heap whose root is the last item in sorted order.
+type sortItemHeap struct {
+	items []sortItem
+	less  func(a, b sortItem) bool
+}
+
+func (h *sortItemHeap) Len() int           { return len(h.items) }
+func (h *sortItemHeap) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *sortItemHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *sortItemHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(sortItem))
+}
+
+func (h *sortItemHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
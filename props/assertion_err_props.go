@@ -11,6 +11,7 @@ func AssertionErrProps(propContainer map[string]object.PanObject) map[string]obj
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("AssertionErr"),
+		// new returns a new AssertionErr whose message is the arg.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -9,6 +9,7 @@ import (
 func BaseObjProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// == returns whether self and other have equal pairs (protos are not compared).
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -31,7 +32,9 @@ func BaseObjProps(propContainer map[string]object.PanObject) map[string]object.P
 			},
 		),
 		"_name": object.NewPanStr("BaseObj"),
-		"at":    propContainer["BaseObj_at"],
+		// at returns the prop of self whose key is the arg (same as `self[key]`).
+		"at": propContainer["BaseObj_at"],
+		// bear returns a new child obj of self, whose pairs are copied from the arg obj.
 		"bear": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -54,6 +57,7 @@ func BaseObjProps(propContainer map[string]object.PanObject) map[string]object.P
 				return object.ChildPanObjPtr(proto, src)
 			},
 		),
+		// proto returns the proto of self.
 		"proto": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -0,0 +1,208 @@
+package props
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// textEncoding converts str into bytes and vice versa.
+type textEncoding struct {
+	encode func(s string) ([]byte, error)
+	decode func(b []byte) (string, error)
+}
+
+var textEncodings = map[string]textEncoding{
+	"utf-8":    {encodeUTF8, decodeUTF8},
+	"utf-16be": {encodeUTF16(binary.BigEndian), decodeUTF16(binary.BigEndian)},
+	"utf-16le": {encodeUTF16(binary.LittleEndian), decodeUTF16(binary.LittleEndian)},
+	"latin1":   {encodeSingleByte(0xff), decodeSingleByte(0xff)},
+	"ascii":    {encodeSingleByte(0x7f), decodeSingleByte(0x7f)},
+}
+
+var textEncodingAliases = map[string]string{
+	"utf8":       "utf-8",
+	"iso-8859-1": "latin1",
+}
+
+// findTextEncoding returns the encoding specified by args[i] (utf-8 by default).
+func findTextEncoding(args []object.PanObject, i int) (textEncoding, string, *object.PanErr) {
+	if len(args) <= i {
+		return textEncodings["utf-8"], "utf-8", nil
+	}
+
+	nameStr, ok := object.TraceProtoOfStr(args[i])
+	if !ok {
+		return textEncoding{}, "", object.NewTypeErr(
+			fmt.Sprintf("%s cannot be treated as str", args[i].Repr()))
+	}
+
+	name := strings.ToLower(nameStr.Value)
+	if alias, ok := textEncodingAliases[name]; ok {
+		name = alias
+	}
+	enc, ok := textEncodings[name]
+	if !ok {
+		return textEncoding{}, "", object.NewValueErr(
+			fmt.Sprintf("unknown encoding %s", nameStr.Repr()))
+	}
+	return enc, name, nil
+}
+
+func encodeUTF8(s string) ([]byte, error) {
+	return []byte(s), nil
+}
+
+func decodeUTF8(b []byte) (string, error) {
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		if r == utf8.RuneError && size <= 1 {
+			return "", fmt.Errorf("invalid byte 0x%02x at %d", b[i], i)
+		}
+		i += size
+	}
+	return string(b), nil
+}
+
+func encodeUTF16(order binary.ByteOrder) func(string) ([]byte, error) {
+	return func(s string) ([]byte, error) {
+		units := utf16.Encode([]rune(s))
+		b := make([]byte, len(units)*2)
+		for i, u := range units {
+			order.PutUint16(b[i*2:], u)
+		}
+		return b, nil
+	}
+}
+
+func decodeUTF16(order binary.ByteOrder) func([]byte) (string, error) {
+	return func(b []byte) (string, error) {
+		if len(b)%2 != 0 {
+			return "", fmt.Errorf("length %d is not a multiple of 2", len(b))
+		}
+
+		units := make([]uint16, len(b)/2)
+		for i := range units {
+			units[i] = order.Uint16(b[i*2:])
+		}
+		for i := 0; i < len(units); i++ {
+			switch {
+			case utf16.IsSurrogate(rune(units[i])) && units[i] < 0xdc00 &&
+				i+1 < len(units) && 0xdc00 <= units[i+1] && units[i+1] < 0xe000:
+				i++
+			case utf16.IsSurrogate(rune(units[i])):
+				return "", fmt.Errorf("unpaired surrogate 0x%04x at %d", units[i], i*2)
+			}
+		}
+		return string(utf16.Decode(units)), nil
+	}
+}
+
+// encodeSingleByte encodes each rune into one byte. Runes larger than limit cannot be encoded.
+func encodeSingleByte(limit rune) func(string) ([]byte, error) {
+	return func(s string) ([]byte, error) {
+		b := make([]byte, 0, len(s))
+		for _, r := range s {
+			if r > limit {
+				return nil, fmt.Errorf("%q cannot be encoded", r)
+			}
+			b = append(b, byte(r))
+		}
+		return b, nil
+	}
+}
+
+func decodeSingleByte(limit rune) func([]byte) (string, error) {
+	return func(b []byte) (string, error) {
+		runes := make([]rune, 0, len(b))
+		for i, c := range b {
+			if rune(c) > limit {
+				return "", fmt.Errorf("invalid byte 0x%02x at %d", c, i)
+			}
+			runes = append(runes, rune(c))
+		}
+		return string(runes), nil
+	}
+}
+
+// packInt returns n in size bytes. Negative values are written in two's complement.
+func packInt(n int64, size int, bigEndian bool) ([]byte, error) {
+	if size < 1 || size > 8 {
+		return nil, fmt.Errorf("size must be 1..8 but got %d", size)
+	}
+
+	if size < 8 {
+		bits := uint(size * 8)
+		// NOTE: both signed and unsigned values are accepted (-128..255 for 1 byte)
+		if n < -(1<<(bits-1)) || n >= 1<<bits {
+			return nil, fmt.Errorf("%d cannot be packed into %d bytes", n, size)
+		}
+	}
+
+	b := make([]byte, size)
+	u := uint64(n)
+	for i := 0; i < size; i++ {
+		c := byte(u >> (8 * uint(i)))
+		if bigEndian {
+			b[size-1-i] = c
+		} else {
+			b[i] = c
+		}
+	}
+	return b, nil
+}
+
+// unpackInt returns int written in b.
+func unpackInt(b []byte, bigEndian bool, signed bool) (int64, error) {
+	if len(b) < 1 || len(b) > 8 {
+		return 0, fmt.Errorf("length must be 1..8 but got %d", len(b))
+	}
+
+	var u uint64
+	for i := range b {
+		c := b[i]
+		if !bigEndian {
+			c = b[len(b)-1-i]
+		}
+		u = u<<8 | uint64(c)
+	}
+
+	bits := uint(len(b) * 8)
+	if signed {
+		// sign extension
+		shift := 64 - bits
+		return int64(u<<shift) >> shift, nil
+	}
+
+	if bits == 64 && u >= 1<<63 {
+		return 0, fmt.Errorf("%d overflows Int", u)
+	}
+	return int64(u), nil
+}
+
+// isBigEndian returns whether kwarg `endian` is big (default).
+func isBigEndian(kwargs *object.PanObj) (bool, *object.PanErr) {
+	pair, ok := propIn(kwargs, "endian")
+	if !ok {
+		return true, nil
+	}
+
+	endian, ok := object.TraceProtoOfStr(pair.Value)
+	if !ok {
+		return false, object.NewTypeErr(
+			fmt.Sprintf("%s cannot be treated as str", pair.Value.Repr()))
+	}
+
+	switch endian.Value {
+	case "big":
+		return true, nil
+	case "little":
+		return false, nil
+	}
+	return false, object.NewValueErr(
+		fmt.Sprintf("endian must be \"big\" or \"little\" but got %s", endian.Repr()))
+}
@@ -0,0 +1,416 @@
+package props
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// BytesProps provides built-in props for Bytes.
+// NOTE: Some Bytes props are defind by native code (not by this function).
+func BytesProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
+	// NOTE: inject some built-in functions which relate to parser or evaluator
+	return map[string]object.PanObject{
+		// == returns whether self and the arg have the same bytes.
+		"==": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("== requires at least 2 args")
+				}
+
+				// necessary for Bytes itself! (guarantee `Bytes == Bytes`)
+				if args[0] == object.BuiltInBytesObj && args[1] == object.BuiltInBytesObj {
+					return object.BuiltInTrue
+				}
+
+				self, ok := args[0].(*object.PanBytes)
+				if !ok {
+					return object.BuiltInFalse
+				}
+				other, ok := args[1].(*object.PanBytes)
+				if !ok {
+					return object.BuiltInFalse
+				}
+
+				if bytes.Equal(self.Value, other.Value) {
+					return object.BuiltInTrue
+				}
+				return object.BuiltInFalse
+			},
+		),
+		// + returns new bytes concatenating self and the arg.
+		"+": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("+ requires at least 2 args")
+				}
+
+				self, ok := object.TraceProtoOfBytes(args[0])
+				if !ok {
+					return object.NewTypeErr(
+						fmt.Sprintf("%s cannot be treated as bytes", args[0].Repr()))
+				}
+				other, ok := object.TraceProtoOfBytes(args[1])
+				if !ok {
+					return object.NewTypeErr(
+						fmt.Sprintf("%s cannot be treated as bytes", args[1].Repr()))
+				}
+
+				res := make([]byte, 0, len(self.Value)+len(other.Value))
+				res = append(append(res, self.Value...), other.Value...)
+				// NOTE: Bytes's descendants also call this
+				return object.NewInheritedBytes(args[0].Proto(), res)
+			},
+		),
+		"_iter": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Bytes#_iter requires at least 1 arg")
+				}
+
+				self, ok := object.TraceProtoOfBytes(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be bytes`)
+				}
+
+				return object.NewPanBuiltInIter(arrIter(bytesToArr(self.Value)), env)
+			},
+		),
+		"_name": object.NewPanStr("Bytes"),
+		// at returns the byte at the index as int (or the sub bytes of the range).
+		"at": propContainer["Bytes_at"],
+		// B returns false if self is empty, otherwise true.
+		"B": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Bytes#B requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfBytes(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be bytes`)
+				}
+
+				if len(self.Value) == 0 {
+					return object.BuiltInFalse
+				}
+				return object.BuiltInTrue
+			},
+		),
+		// base32 encodes self to a base32 str.
+		"base32": bytesToStrProp("base32", base32.StdEncoding.EncodeToString),
+		// base64 encodes self to a base64 str (URL-safe if `url?` is true).
+		"base64": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Bytes#base64 requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfBytes(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be bytes`)
+				}
+
+				return object.NewPanStr(base64Encoding(kwargs).EncodeToString(self.Value))
+			},
+		),
+		// crc32 returns the CRC-32 checksum as int.
+		"crc32": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Bytes#crc32 requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfBytes(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be bytes`)
+				}
+
+				return object.NewPanInt(int64(crc32.ChecksumIEEE(self.Value)))
+			},
+		),
+		// dec decodes self to str by the encoding ("utf-8", "utf-16be", "utf-16le", "latin1" or "ascii").
+		"dec": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Bytes#dec requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfBytes(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be bytes`)
+				}
+
+				enc, name, errObj := findTextEncoding(args, 1)
+				if errObj != nil {
+					return errObj
+				}
+
+				s, err := enc.decode(self.Value)
+				if err != nil {
+					return object.NewValueErr(fmt.Sprintf("failed to decode %s: %s", name, err.Error()))
+				}
+				return object.NewPanStr(s)
+			},
+		),
+		// fromBase32 decodes the base32 str to bytes.
+		"fromBase32": strToBytesProp("fromBase32", "base32", base32.StdEncoding.DecodeString),
+		// fromBase64 decodes the base64 str to bytes (URL-safe if `url?` is true).
+		"fromBase64": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Bytes#fromBase64 requires at least 2 args")
+				}
+				return decodeStrToBytes(args[1], "base64", base64Encoding(kwargs).DecodeString)
+			},
+		),
+		// fromHex decodes the hex str to bytes.
+		"fromHex": strToBytesProp("fromHex", "hex", hex.DecodeString),
+		// hex encodes self to a hex str.
+		"hex": bytesToStrProp("hex", hex.EncodeToString),
+		// hmac returns the HMAC of self with the key (the hash is specified by `algo:`, "sha256" by default).
+		"hmac": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Bytes#hmac requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfBytes(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be bytes`)
+				}
+
+				var key []byte
+				if b, ok := object.TraceProtoOfBytes(args[1]); ok {
+					key = b.Value
+				} else if s, ok := object.TraceProtoOfStr(args[1]); ok {
+					key = []byte(s.Value)
+				} else {
+					return object.NewTypeErr(
+						fmt.Sprintf("%s cannot be treated as bytes", args[1].Repr()))
+				}
+
+				algo := "sha256"
+				if pair, ok := propIn(kwargs, "algo"); ok {
+					s, ok := object.TraceProtoOfStr(pair.Value)
+					if !ok {
+						return object.NewTypeErr(
+							fmt.Sprintf("%s cannot be treated as str", pair.Value.Repr()))
+					}
+					algo = s.Value
+				}
+
+				newHash, ok := hashAlgorithms[algo]
+				if !ok {
+					return object.NewValueErr(fmt.Sprintf("unknown algorithm %q", algo))
+				}
+
+				mac := hmac.New(newHash, key)
+				mac.Write(self.Value)
+				return object.NewPanBytes(mac.Sum(nil))
+			},
+		),
+		// len returns the number of bytes.
+		"len": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Bytes#len requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfBytes(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be bytes`)
+				}
+
+				return object.NewPanInt(int64(len(self.Value)))
+			},
+		),
+		// md5 returns the MD5 hash as bytes.
+		"md5": hashProp("md5"),
+		// new creates bytes from bytes or an iterable of ints in 0..255.
+		"new": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Bytes#new requires at least 1 arg")
+				}
+
+				// Bytes.new is same as Bytes.new([])
+				if len(args) < 2 {
+					return object.NewInheritedBytes(args[0], []byte{})
+				}
+
+				if b, ok := object.TraceProtoOfBytes(args[1]); ok {
+					return object.NewInheritedBytes(args[0], b.Value)
+				}
+
+				elems, err := iterableElems(propContainer, env, args[1])
+				if err != nil {
+					return err
+				}
+
+				b := make([]byte, 0, len(elems))
+				for _, elem := range elems {
+					i, ok := object.TraceProtoOfInt(elem)
+					if !ok {
+						return object.NewTypeErr(
+							fmt.Sprintf("%s cannot be treated as int", elem.Repr()))
+					}
+					if i.Value < 0 || i.Value > 255 {
+						return object.NewValueErr(
+							fmt.Sprintf("%s is out of range 0..255", elem.Repr()))
+					}
+					b = append(b, byte(i.Value))
+				}
+
+				// NOTE: Bytes's descendants also call this
+				return object.NewInheritedBytes(args[0], b)
+			},
+		),
+		// sha1 returns the SHA-1 hash as bytes.
+		"sha1": hashProp("sha1"),
+		// sha256 returns the SHA-256 hash as bytes.
+		"sha256": hashProp("sha256"),
+		// unpack converts self to int (the byte order is specified by `endian:` and the sign by `signed?:`).
+		"unpack": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Bytes#unpack requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfBytes(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be bytes`)
+				}
+
+				bigEndian, errObj := isBigEndian(kwargs)
+				if errObj != nil {
+					return errObj
+				}
+
+				signed := false
+				if pair, ok := propIn(kwargs, "signed?"); ok {
+					signed = pair.Value == object.BuiltInTrue
+				}
+
+				n, err := unpackInt(self.Value, bigEndian, signed)
+				if err != nil {
+					return object.NewValueErr(err.Error())
+				}
+				return object.NewPanInt(n)
+			},
+		),
+	}
+}
+
+var hashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+func hashProp(algo string) object.PanObject {
+	return f(
+		func(
+			env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+		) object.PanObject {
+			if len(args) < 1 {
+				return object.NewTypeErr(fmt.Sprintf("Bytes#%s requires at least 1 arg", algo))
+			}
+			self, ok := object.TraceProtoOfBytes(args[0])
+			if !ok {
+				return object.NewTypeErr(`\1 must be bytes`)
+			}
+
+			h := hashAlgorithms[algo]()
+			h.Write(self.Value)
+			return object.NewPanBytes(h.Sum(nil))
+		},
+	)
+}
+
+func bytesToStrProp(propName string, encode func([]byte) string) object.PanObject {
+	return f(
+		func(
+			env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+		) object.PanObject {
+			if len(args) < 1 {
+				return object.NewTypeErr(fmt.Sprintf("Bytes#%s requires at least 1 arg", propName))
+			}
+			self, ok := object.TraceProtoOfBytes(args[0])
+			if !ok {
+				return object.NewTypeErr(`\1 must be bytes`)
+			}
+
+			return object.NewPanStr(encode(self.Value))
+		},
+	)
+}
+
+func strToBytesProp(propName string, format string, decode func(string) ([]byte, error)) object.PanObject {
+	return f(
+		func(
+			env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+		) object.PanObject {
+			if len(args) < 2 {
+				return object.NewTypeErr(fmt.Sprintf("Bytes#%s requires at least 2 args", propName))
+			}
+			return decodeStrToBytes(args[1], format, decode)
+		},
+	)
+}
+
+func decodeStrToBytes(o object.PanObject, format string, decode func(string) ([]byte, error)) object.PanObject {
+	str, ok := object.TraceProtoOfStr(o)
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("%s cannot be treated as str", o.Repr()))
+	}
+
+	b, err := decode(str.Value)
+	if err != nil {
+		return object.NewValueErr(fmt.Sprintf("%s is invalid %s: %s", str.Repr(), format, err.Error()))
+	}
+	return object.NewPanBytes(b)
+}
+
+// base64Encoding returns the url-safe encoding if kwarg `url?` is true.
+func base64Encoding(kwargs *object.PanObj) *base64.Encoding {
+	if pair, ok := propIn(kwargs, "url?"); ok && pair.Value == object.BuiltInTrue {
+		return base64.URLEncoding
+	}
+	return base64.StdEncoding
+}
+
+func bytesToArr(b []byte) *object.PanArr {
+	elems := make([]object.PanObject, 0, len(b))
+	for _, c := range b {
+		elems = append(elems, object.NewPanInt(int64(c)))
+	}
+	return object.NewPanArr(elems...)
+}
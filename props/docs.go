@@ -0,0 +1,69 @@
+package props
+
+import (
+	"fmt"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// AttachDocs attaches docs of props written in *_props.go to builtin funcs of objs in env.
+func AttachDocs(env *object.Env) {
+	for objName, docs := range builtInDocs {
+		o, ok := env.Get(object.GetSymHash(objName))
+		if !ok {
+			continue
+		}
+		obj, ok := o.(*object.PanObj)
+		if !ok || obj.Pairs == nil {
+			continue
+		}
+
+		for prop, doc := range docs {
+			pair, ok := (*obj.Pairs)[object.GetSymHash(prop)]
+			if !ok {
+				continue
+			}
+			if b, ok := pair.Value.(*object.PanBuiltIn); ok {
+				b.Doc = doc
+			}
+		}
+	}
+}
+
+// funcDoc returns doc of func o (nil if o has no docs).
+// If o is not a func, it returns false.
+func funcDoc(o object.PanObject) (object.PanObject, bool) {
+	doc := ""
+	if f, ok := object.TraceProtoOfFunc(o); ok {
+		doc = f.Doc
+	} else if b, ok := object.TraceProtoOfBuiltInFunc(o); ok {
+		doc = b.Doc
+	} else {
+		return nil, false
+	}
+
+	if doc == "" {
+		return object.BuiltInNil, true
+	}
+	return object.NewPanStr(doc), true
+}
+
+// propDoc returns doc of prop in the proto chain of o (nil if the prop is not a func or has no docs).
+func propDoc(o object.PanObject, prop object.PanObject) object.PanObject {
+	propName, ok := object.TraceProtoOfStr(prop)
+	if !ok {
+		return object.NewTypeErr(
+			fmt.Sprintf("%s cannot be treated as str", prop.Repr()))
+	}
+
+	v, ok := object.FindPropAlongProtos(o, propName.SymHash())
+	if !ok {
+		return object.NewNoPropErr(
+			fmt.Sprintf("property `%s` is not defined.", propName.Value))
+	}
+
+	if doc, ok := funcDoc(v); ok {
+		return doc
+	}
+	return object.BuiltInNil
+}
@@ -0,0 +1,319 @@
+// Code generated by docgen from *_props.go. DO NOT EDIT.
+
+package props
+
+// builtInDocs maps names of builtin objs to docs of their props.
+var builtInDocs = map[string]map[string]string{
+	"Arr": {
+		"*":            "* returns a new arr repeating elements of self the arg times.",
+		"+":            "+ returns a new arr concatenating self and the arg.",
+		"==":           "== returns whether self and the arg have equal elements.",
+		"B":            "B returns false if self is empty, otherwise true.",
+		"M":            "M converts an arr of key-value pairs to a map.",
+		"O":            "O converts an arr of key-value pairs to an obj (first pair wins if keys are duplicated).",
+		"at":           "at returns the element at the index (or the sub arr of the range).",
+		"bear":         "bear creates a child of self with the obj literal src.",
+		"bottomN":      "bottomN returns the n smallest elements in ascending order (compared by `by:` if specified).",
+		"call":         "call creates a new arr whose elements are the args.",
+		"combinations": "combinations returns an iter of all k-length combinations of elements.",
+		"countBy":      "countBy returns a map from keys returned by f to the numbers of elements.",
+		"diff":         "diff returns elements of self which are not included in any args.",
+		"flatten":      "flatten returns a new arr with nested arrs flattened up to depth (all levels if not specified).",
+		"groupBy":      "groupBy returns a map from keys returned by f to arrs of elements.",
+		"has?":         "has? returns whether self includes the arg.",
+		"intersect":    "intersect returns unique elements included in self and all args.",
+		"join":         "join returns a str concatenating elements (converted by S) with the separator.",
+		"len":          "len returns the number of elements.",
+		"new":          "new converts the arg to arr by its A prop.",
+		"partition":    "partition returns an arr of elements which satisfy f and an arr of the rest.",
+		"permutations": "permutations returns an iter of all k-length permutations of elements (k is the length by default).",
+		"product":      "product returns an iter of the cartesian product of self and iterables in the args.",
+		"sort":         "sort returns a new sorted arr.\nThe order can be changed by `cmp:` (comparison func) and `rev:` (descending if true).",
+		"sortBy":       "sortBy returns a new arr sorted by keys returned by f.",
+		"topN":         "topN returns the n largest elements in descending order (compared by `by:` if specified).",
+		"union":        "union returns unique elements included in self or any args.",
+		"uniq":         "uniq returns a new arr without duplicated elements (the first one is kept).",
+		"uniqBy":       "uniqBy returns a new arr without elements whose keys returned by f are duplicated.",
+	},
+	"AssertionErr": {
+		"new": "new returns a new AssertionErr whose message is the arg.",
+	},
+	"BaseObj": {
+		"==":    "== returns whether self and other have equal pairs (protos are not compared).",
+		"at":    "at returns the prop of self whose key is the arg (same as `self[key]`).",
+		"bear":  "bear returns a new child obj of self, whose pairs are copied from the arg obj.",
+		"proto": "proto returns the proto of self.",
+	},
+	"Bytes": {
+		"+":          "+ returns new bytes concatenating self and the arg.",
+		"==":         "== returns whether self and the arg have the same bytes.",
+		"B":          "B returns false if self is empty, otherwise true.",
+		"at":         "at returns the byte at the index as int (or the sub bytes of the range).",
+		"base32":     "base32 encodes self to a base32 str.",
+		"base64":     "base64 encodes self to a base64 str (URL-safe if `url?` is true).",
+		"crc32":      "crc32 returns the CRC-32 checksum as int.",
+		"dec":        "dec decodes self to str by the encoding (\"utf-8\", \"utf-16be\", \"utf-16le\", \"latin1\" or \"ascii\").",
+		"fromBase32": "fromBase32 decodes the base32 str to bytes.",
+		"fromBase64": "fromBase64 decodes the base64 str to bytes (URL-safe if `url?` is true).",
+		"fromHex":    "fromHex decodes the hex str to bytes.",
+		"hex":        "hex encodes self to a hex str.",
+		"hmac":       "hmac returns the HMAC of self with the key (the hash is specified by `algo:`, \"sha256\" by default).",
+		"len":        "len returns the number of bytes.",
+		"md5":        "md5 returns the MD5 hash as bytes.",
+		"new":        "new creates bytes from bytes or an iterable of ints in 0..255.",
+		"sha1":       "sha1 returns the SHA-1 hash as bytes.",
+		"sha256":     "sha256 returns the SHA-256 hash as bytes.",
+		"unpack":     "unpack converts self to int (the byte order is specified by `endian:` and the sign by `signed?:`).",
+	},
+	"Diamond": {
+		"S": "S returns read line str",
+	},
+	"EitherErr": {
+		"A":    "A returns nil and the err as an arr.",
+		"err":  "err returns the captured err.",
+		"fmap": "fmap does nothing and returns self.",
+		"or":   "or returns the default arg instead of the err.",
+		"val":  "val returns nil because self has no values.",
+	},
+	"EitherVal": {
+		"A":    "A returns the value and nil as an arr.",
+		"err":  "err returns nil because self has no errors.",
+		"fmap": "fmap calls f with the value and wraps the result by Either.",
+		"or":   "or returns the value (the default arg is ignored).",
+		"val":  "val returns the value.",
+	},
+	"Err": {
+		"==":   "== returns whether self and other are errs of the same type and message.",
+		"msg":  "msg returns the message of self.",
+		"new":  "new returns a new Err whose message is the arg.",
+		"type": "type returns the err type of self (such as `ValueErr`).",
+	},
+	"Float": {
+		"*":    "* returns product of self and other.",
+		"**":   "** returns self raised to the power of other.",
+		"+":    "+ returns sum of self and other.",
+		"-":    "- returns difference of self and other.",
+		"-%":   "-% returns negated self (called by prefix `-`).",
+		"/":    "/ returns quotient of self and other.",
+		"/~":   "/~ returns bitwise-inverted self (called by prefix `~`).",
+		"<=>":  "<=> returns 1 if self is greater than other, 0 if they are equal, otherwise -1.",
+		"==":   "== returns whether self and other are equal floats.",
+		"B":    "B returns false if self is 0.0, otherwise true.",
+		"new":  "new converts the arg into float.",
+		"sqrt": "sqrt returns square root of self.",
+	},
+	"Func": {
+		"==":     "== returns whether self and other are the same funcs.",
+		"B":      "B returns true.",
+		"args":   "args returns names of parameters of self.",
+		"call":   "call calls self with the args.",
+		"doc":    "doc returns the doc comment of self (or of prop if it is passed like Obj#doc).",
+		"kwargs": "kwargs returns keyword parameters of self and their default values.",
+		"new":    "new returns the arg func.",
+		"sig":    "sig returns copied self annotated by types of params, kwargs and the return value (`ret`).",
+	},
+	"ImportErr": {
+		"new": "new returns a new ImportErr whose message is the arg.",
+	},
+	"Int": {
+		"!=":       "!= returns whether self and other are not equal.",
+		"%":        "% returns remainder of self divided by other.",
+		"*":        "* returns product of self and other (float if other is float).",
+		"**":       "** returns self raised to the power of other (float if the result is not an integer).",
+		"+":        "+ returns sum of self and other (float if other is float).",
+		"-":        "- returns difference of self and other (float if other is float).",
+		"-%":       "-% returns negated self (called by prefix `-`).",
+		"/":        "/ returns quotient of self and other as float.",
+		"/&":       "/& returns bitwise and of self and other.",
+		"//":       "// returns floor of quotient of self and other.",
+		"/^":       "/^ returns bitwise xor of self and other.",
+		"/|":       "/| returns bitwise or of self and other.",
+		"/~":       "/~ returns bitwise-inverted self (called by prefix `~`).",
+		"<<":       "<< returns self shifted left by other bits.",
+		"<=>":      "<=> returns 1 if self is greater than other, 0 if they are equal, otherwise -1.",
+		"==":       "== returns whether self and other are equal ints with the same proto.",
+		">>":       ">> returns self shifted right by other bits (the sign bit is kept).",
+		"B":        "B returns false if self is 0, otherwise true.",
+		"at":       "at returns the bit of self at the index (`self[i]`).",
+		"bear":     "bear returns a new child obj of self, whose zero value is 0.",
+		"chr":      "chr returns the character whose code point is self.",
+		"digits":   "digits returns digits of self in `base` (10 by default) from the least significant one.",
+		"gcd":      "gcd returns the greatest common divisor of self and other.",
+		"lcm":      "lcm returns the least common multiple of self and other.",
+		"new":      "new converts the arg into int (floats are truncated).",
+		"pack":     "pack converts self into bytes of `size` (8 by default) in `endian` (\"big\" by default).",
+		"popcount": "popcount returns the number of 1 bits in the absolute value of self.",
+		"powMod":   "powMod returns self ** exp % mod without overflow.",
+		"prime?":   "prime? returns whether self is a prime number.",
+		"sqrt":     "sqrt returns square root of self as float.",
+	},
+	"Iter": {
+		"==":           "== returns whether self and other are the same iters.",
+		"B":            "B returns true.",
+		"combinations": "combinations returns an iter of all k-length combinations of elements.",
+		"new":          "new returns a new iter of self whose params are bound to the args.",
+		"next":         "next returns the next value of self (raises StopIterErr if it is finished).",
+		"permutations": "permutations returns an iter of all k-length permutations of elements (k is the number of elements by default).",
+		"product":      "product returns an iter of the cartesian product of self and iterables in the args.",
+	},
+	"JSON": {
+		"dec":   "dec decodes the json str.",
+		"query": "query returns values in the decoded json obj matched by the JSONPath query.",
+	},
+	"Kernel": {
+		"argv":         "argv returns command-line args.",
+		"assert":       "assert raises AssertionErr if the arg is not truthy.",
+		"assertEq":     "assertEq raises AssertionErr if the args are not equal.",
+		"assertRaises": "assertRaises raises AssertionErr if f does not raise the err of errType with msg.",
+		"import":       "import evaluates the module and returns an obj of its exported variables.",
+		"invite!":      "invite! imports the module and sets its exported variables directly to the current scope.",
+		"read":         "read returns the content of the file as str (or bytes if `mode` is \"bytes\").",
+		"reload":       "reload discards the cached module and imports it again.",
+	},
+	"LimitErr": {
+		"new": "new returns a new LimitErr whose message is the arg.",
+	},
+	"Map": {
+		"==":     "== returns whether self and other have equal pairs.",
+		"B":      "B returns false if self is empty, otherwise true.",
+		"at":     "at returns the value whose key is the arg (`self[key]`).",
+		"items":  "items returns an arr of key-value pairs in self.",
+		"keys":   "keys returns an arr of keys in self.",
+		"len":    "len returns the number of pairs in self.",
+		"values": "values returns an arr of values in self.",
+	},
+	"Match": {
+		"call": "call calls the first pattern of self which matches the args.",
+	},
+	"MatchErr": {
+		"new": "new returns a new MatchErr whose message is the arg.",
+	},
+	"NameErr": {
+		"new": "new returns a new NameErr whose message is the arg.",
+	},
+	"Nil": {
+		"*":   "* returns other (nil works as one).",
+		"+":   "+ returns other (nil works as zero).",
+		"-":   "- returns negated other (nil works as zero).",
+		"/":   "/ returns reciprocal of other (nil works as one).",
+		"//":  "// returns floor division of one by other (nil works as one).",
+		"==":  "== returns whether other is nil.",
+		"B":   "B returns false.",
+		"new": "new returns nil.",
+	},
+	"NoPropErr": {
+		"new": "new returns a new NoPropErr whose message is the arg.",
+	},
+	"NotImplementedErr": {
+		"new": "new returns a new NotImplementedErr whose message is the arg.",
+	},
+	"Num": {
+		"F":     "F converts self into float.",
+		"ceil":  "ceil returns the least int greater than or equal to self.",
+		"floor": "floor returns the greatest int less than or equal to self.",
+		"round": "round returns the nearest int of self (halves are rounded away from zero).\nIf `digits` is specified, self is rounded to the digits after the decimal point\n(float if digits is positive). `half` changes the rounding mode of halves (\"up\", \"even\" or \"down\").",
+	},
+	"Obj": {
+		"!":         "! returns the negation of B.",
+		"B":         "B returns false if self has no props, otherwise true.",
+		"S":         "S converts self to str.",
+		"callProp":  "callProp calls the prop of the receiver with the rest args.",
+		"deepDiff":  "deepDiff returns an arr of operations (like JSON Patch) to change self into the arg.",
+		"deepEq":    "deepEq returns whether self and the arg are equal recursively.",
+		"deepMerge": "deepMerge merges objs and maps in the args into self recursively.\nHow to merge arrs is specified by `arr:` ('replace, 'concat, 'merge, 'union or func).",
+		"doc":       "doc returns the doc comment of the prop (nil if it is not documented).",
+		"getIn":     "getIn returns the value at the path of keys and indices (`default:` if not found).",
+		"items":     "items returns an arr of key-value pairs.",
+		"keys":      "keys returns an arr of keys.",
+		"new":       "new creates an obj with the props of the arg.",
+		"p":         "p prints self in Repr format with a newline.",
+		"pp":        "pp prints self in the pretty format with a newline.",
+		"print":     "print prints self (converted by S) without a newline.",
+		"puts":      "puts prints self (converted by S) with a newline.",
+		"q":         "q returns an arr of values matched by the JSONPath expression.",
+		"repr":      "repr returns the Repr of self.",
+		"setIn":     "setIn returns a copy of self whose value at the path is replaced with the arg.",
+		"traverse":  "traverse returns an iter of pairs of paths and nested values (only values of the key if `key:` is specified).",
+		"try":       "try wraps self with EitherVal.",
+		"updateIn":  "updateIn returns a copy of self whose value at the path is replaced with the result of f.",
+		"values":    "values returns an arr of values.",
+		"which":     "which returns the obj in the proto chain which has the prop (nil if not found).",
+	},
+	"PermissionErr": {
+		"new": "new returns a new PermissionErr whose message is the arg.",
+	},
+	"Range": {
+		"==":    "== returns whether self and the arg have equal start, stop and step.",
+		"B":     "B always returns true.",
+		"new":   "new creates a range with start, stop and step.",
+		"start": "start returns the start of the range.",
+		"step":  "step returns the step of the range.",
+		"stop":  "stop returns the stop of the range (which is not included).",
+	},
+	"RecursionErr": {
+		"new": "new returns a new RecursionErr whose message is the arg.",
+	},
+	"Ref": {
+		"==":   "== returns whether self and the arg are the same ref.",
+		"cas":  "cas sets new if the current value is old and returns whether it is set.",
+		"get":  "get returns the current value.",
+		"new":  "new creates a mutable reference to the arg.",
+		"set":  "set replaces the current value with the arg and returns it.",
+		"swap": "swap replaces the current value with the result of f called with it.",
+	},
+	"Set": {
+		"-":      "- returns elements of self which are not in the arg.",
+		"/&":     "/& returns elements in both self and the arg.",
+		"/^":     "/^ returns elements in either self or the arg but not both.",
+		"/|":     "/| returns elements in self or the arg.",
+		"==":     "== returns whether self and the arg have the same elements.",
+		"B":      "B returns false if self is empty, otherwise true.",
+		"add":    "add returns a new set with the args added.",
+		"del":    "del returns a new set without the args.",
+		"has?":   "has? returns whether self includes the arg.",
+		"len":    "len returns the number of elements.",
+		"new":    "new creates a set of elements of the iterable.",
+		"sub?":   "sub? returns whether all elements of self are in the arg.",
+		"super?": "super? returns whether all elements of the arg are in self.",
+	},
+	"StopIterErr": {
+		"new": "new returns a new StopIterErr whose message is the arg.",
+	},
+	"Str": {
+		"*":       "* returns a str repeating self the arg times.",
+		"+":       "+ returns a str concatenating self and the arg.",
+		"/":       "/ splits self by the regex pattern (empty strs are excluded).",
+		"/~":      "/~ returns a str whose bytes are inverted.",
+		"<=>":     "<=> compares self with the arg in lexicographic order and returns -1, 0 or 1.",
+		"==":      "== returns whether self and the arg are the same str.",
+		"B":       "B returns false if self is empty, otherwise true.",
+		"F":       "F converts self to float.",
+		"I":       "I converts self to int.",
+		"at":      "at returns the char at the index (or the sub str of the range).",
+		"dedent":  "dedent removes the common leading whitespace from each line.",
+		"enc":     "enc encodes self to bytes by the encoding (\"utf-8\", \"utf-16be\", \"utf-16le\", \"latin1\" or \"ascii\").",
+		"eval":    "eval evaluates self as pangaea source code and returns the result.",
+		"evalEnv": "evalEnv evaluates self as pangaea source code and returns the env as an obj.",
+		"has?":    "has? returns whether self contains the substring.",
+		"lc":      "lc returns a lower-case str.",
+		"len":     "len returns the number of chars.",
+		"match":   "match returns an arr of the match and its groups of the regex pattern (empty if not matched).",
+		"new":     "new converts the arg to str by its S prop.",
+		"ord":     "ord returns the code point of the single char.",
+		"sub":     "sub replaces all matches of the regex pattern with the replacement.\nThe replacement can refer groups as `$1`, and `\\U...\\E` (`\\L...\\E`) converts the enclosed text to upper (lower) case.",
+		"sym?":    "sym? returns whether self can be a symbol literal (such as `'a`).",
+		"uc":      "uc returns an upper-case str.",
+	},
+	"SyntaxErr": {
+		"new": "new returns a new SyntaxErr whose message is the arg.",
+	},
+	"TypeErr": {
+		"new": "new returns a new TypeErr whose message is the arg.",
+	},
+	"ValueErr": {
+		"new": "new returns a new ValueErr whose message is the arg.",
+	},
+	"ZeroDivisionErr": {
+		"new": "new returns a new ZeroDivisionErr whose message is the arg.",
+	},
+}
@@ -12,6 +12,7 @@ func EitherErrProps(propContainer map[string]object.PanObject) map[string]object
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("EitherErr"),
+		// A returns nil and the err as an arr.
 		"A": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -35,6 +36,7 @@ func EitherErrProps(propContainer map[string]object.PanObject) map[string]object
 				return object.NewPanArr(object.BuiltInNil, err.Value)
 			},
 		),
+		// err returns the captured err.
 		"err": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -58,6 +60,7 @@ func EitherErrProps(propContainer map[string]object.PanObject) map[string]object
 				return err.Value
 			},
 		),
+		// fmap does nothing and returns self.
 		"fmap": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -69,6 +72,7 @@ func EitherErrProps(propContainer map[string]object.PanObject) map[string]object
 				return args[0]
 			},
 		),
+		// or returns the default arg instead of the err.
 		"or": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -80,6 +84,7 @@ func EitherErrProps(propContainer map[string]object.PanObject) map[string]object
 				return args[1]
 			},
 		),
+		// val returns nil because self has no values.
 		"val": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -12,6 +12,7 @@ func EitherValProps(propContainer map[string]object.PanObject) map[string]object
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("EitherVal"),
+		// A returns the value and nil as an arr.
 		"A": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -35,6 +36,7 @@ func EitherValProps(propContainer map[string]object.PanObject) map[string]object
 				return object.NewPanArr(val.Value, object.BuiltInNil)
 			},
 		),
+		// err returns nil because self has no errors.
 		"err": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -46,6 +48,7 @@ func EitherValProps(propContainer map[string]object.PanObject) map[string]object
 				return object.BuiltInNil
 			},
 		),
+		// fmap calls f with the value and wraps the result by Either.
 		"fmap": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -82,6 +85,7 @@ func EitherValProps(propContainer map[string]object.PanObject) map[string]object
 				return toEitherVal(result)
 			},
 		),
+		// or returns the value (the default arg is ignored).
 		"or": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -105,6 +109,7 @@ func EitherValProps(propContainer map[string]object.PanObject) map[string]object
 				return val.Value
 			},
 		),
+		// val returns the value.
 		"val": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -0,0 +1,128 @@
+package props
+
+import (
+	"fmt"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// elemIndex finds registered elements in (nearly) constant time.
+// Scalar elements are found by HashKey and the others are compared by `==` one by one
+// (in the same way as map keys).
+type elemIndex struct {
+	env            *object.Env
+	propContainer  map[string]object.PanObject
+	hashed         map[object.HashKey]int
+	nonHashable    []object.PanObject
+	nonHashableIDs []int
+}
+
+func newElemIndex(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+) *elemIndex {
+	return &elemIndex{
+		env:           env,
+		propContainer: propContainer,
+		hashed:        map[object.HashKey]int{},
+	}
+}
+
+// find returns id of registered elem which equals to o (or -1 if not found).
+func (x *elemIndex) find(o object.PanObject) (int, *object.PanErr) {
+	if hashable, ok := o.(object.PanScalar); ok {
+		if id, ok := x.hashed[hashable.Hash()]; ok {
+			return id, nil
+		}
+		return -1, nil
+	}
+
+	for i, elem := range x.nonHashable {
+		res := x.propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+			x.env, object.EmptyPanObjPtr(),
+			object.EmptyPanObjPtr(), o, eqSym, elem,
+		)
+		if err, ok := res.(*object.PanErr); ok {
+			return -1, err
+		}
+		if res == object.BuiltInTrue {
+			return x.nonHashableIDs[i], nil
+		}
+	}
+	return -1, nil
+}
+
+// add registers o with id.
+func (x *elemIndex) add(o object.PanObject, id int) {
+	if hashable, ok := o.(object.PanScalar); ok {
+		x.hashed[hashable.Hash()] = id
+		return
+	}
+
+	x.nonHashable = append(x.nonHashable, o)
+	x.nonHashableIDs = append(x.nonHashableIDs, id)
+}
+
+// addIfAbsent registers o and reports whether o is newly registered.
+func (x *elemIndex) addIfAbsent(o object.PanObject, id int) (bool, *object.PanErr) {
+	found, err := x.find(o)
+	if err != nil {
+		return false, err
+	}
+	if found >= 0 {
+		return false, nil
+	}
+
+	x.add(o, id)
+	return true, nil
+}
+
+// uniqElems removes duplicated elements keeping the first ones.
+func uniqElems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	elems []object.PanObject,
+) ([]object.PanObject, *object.PanErr) {
+	x := newElemIndex(propContainer, env)
+	uniq := []object.PanObject{}
+	for _, elem := range elems {
+		added, err := x.addIfAbsent(elem, len(uniq))
+		if err != nil {
+			return nil, err
+		}
+		if added {
+			uniq = append(uniq, elem)
+		}
+	}
+	return uniq, nil
+}
+
+// iterableElems returns elements of Iterable o.
+// Objects other than arr and set are converted by `A`.
+func iterableElems(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	o object.PanObject,
+) ([]object.PanObject, *object.PanErr) {
+	if arr, ok := o.(*object.PanArr); ok {
+		return arr.Elems, nil
+	}
+	if set, ok := o.(*object.PanSet); ok {
+		return set.Items(), nil
+	}
+
+	ret := propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+		env, object.EmptyPanObjPtr(),
+		object.EmptyPanObjPtr(), o, aSym,
+	)
+	if err, ok := ret.(*object.PanErr); ok {
+		return nil, err
+	}
+
+	arr, ok := object.TraceProtoOfArr(ret)
+	if !ok {
+		return nil, object.NewTypeErr(
+			fmt.Sprintf("%s cannot be treated as arr", o.Repr()))
+	}
+	return arr.Elems, nil
+}
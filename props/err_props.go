@@ -12,6 +12,7 @@ import (
 func ErrProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// == returns whether self and other are errs of the same type and message.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -39,6 +40,7 @@ func ErrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		"_name": object.NewPanStr("Err"),
+		// msg returns the message of self.
 		"msg": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -57,6 +59,7 @@ func ErrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanStr(err.PanErr.Msg)
 			},
 		),
+		// new returns a new Err whose message is the arg.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -64,6 +67,7 @@ func ErrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return constructErr(propContainer, env, object.NewPanErr, args...)
 			},
 		),
+		// type returns the err type of self (such as `ValueErr`).
 		"type": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -12,6 +12,7 @@ import (
 func FloatProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// <=> returns 1 if self is greater than other, 0 if they are equal, otherwise -1.
 		"<=>": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -36,6 +37,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.NewPanInt(res)
 			},
 		),
+		// == returns whether self and other are equal floats.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -59,6 +61,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.BuiltInFalse
 			},
 		),
+		// -% returns negated self (called by prefix `-`).
 		"-%": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -77,6 +80,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.NewInheritedFloat(args[0], res)
 			},
 		),
+		// /~ returns bitwise-inverted self (called by prefix `~`).
 		"/~": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -97,6 +101,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.NewInheritedFloat(args[0], res)
 			},
 		),
+		// + returns sum of self and other.
 		"+": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -111,6 +116,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.NewInheritedFloat(args[0].Proto(), res)
 			},
 		),
+		// - returns difference of self and other.
 		"-": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -125,6 +131,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.NewInheritedFloat(args[0].Proto(), res)
 			},
 		),
+		// * returns product of self and other.
 		"*": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -139,6 +146,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.NewInheritedFloat(args[0].Proto(), res)
 			},
 		),
+		// ** returns self raised to the power of other.
 		"**": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -153,6 +161,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.NewInheritedFloat(args[0].Proto(), res)
 			},
 		),
+		// / returns quotient of self and other.
 		"/": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -172,6 +181,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 			},
 		),
 		"_name": object.NewPanStr("Float"),
+		// B returns false if self is 0.0, otherwise true.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -190,6 +200,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.BuiltInTrue
 			},
 		),
+		// new converts the arg into float.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -213,6 +224,7 @@ func FloatProps(propContainer map[string]object.PanObject) map[string]object.Pan
 					fmt.Sprintf("%s cannot be treated as float", args[1].Repr()))
 			},
 		),
+		// sqrt returns square root of self.
 		"sqrt": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
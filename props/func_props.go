@@ -11,6 +11,7 @@ import (
 func FuncProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// == returns whether self and other are the same funcs.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -48,6 +49,7 @@ func FuncProps(propContainer map[string]object.PanObject) map[string]object.PanO
 			},
 		),
 		"_name": object.NewPanStr("Func"),
+		// args returns names of parameters of self.
 		"args": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -69,6 +71,7 @@ func FuncProps(propContainer map[string]object.PanObject) map[string]object.PanO
 					fmt.Sprintf("%s cannot be treated as func", args[0].Repr()))
 			},
 		),
+		// B returns true.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -88,7 +91,30 @@ func FuncProps(propContainer map[string]object.PanObject) map[string]object.PanO
 					fmt.Sprintf("%s cannot be treated as func", args[0].Repr()))
 			},
 		),
+		// call calls self with the args.
 		"call": propContainer["Func_call"],
+		// doc returns the doc comment of self (or of prop if it is passed like Obj#doc).
+		"doc": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Func#doc requires at least 1 arg")
+				}
+
+				// NOTE: Func#doc overrides Obj#doc, so doc of prop must be handled as well
+				if len(args) >= 2 {
+					return propDoc(args[0], args[1])
+				}
+
+				if doc, ok := funcDoc(args[0]); ok {
+					return doc
+				}
+				return object.NewTypeErr(
+					fmt.Sprintf("%s cannot be treated as func", args[0].Repr()))
+			},
+		),
+		// kwargs returns keyword parameters of self and their default values.
 		"kwargs": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -110,6 +136,7 @@ func FuncProps(propContainer map[string]object.PanObject) map[string]object.PanO
 					fmt.Sprintf("%s cannot be treated as func", args[0].Repr()))
 			},
 		),
+		// new returns the arg func.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -126,9 +153,66 @@ func FuncProps(propContainer map[string]object.PanObject) map[string]object.PanO
 				return f
 			},
 		),
+		// sig returns copied self annotated by types of params, kwargs and the return value (`ret`).
+		"sig": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Func#sig requires at least 1 arg")
+				}
+
+				fn, ok := object.TraceProtoOfFunc(args[0])
+				if !ok {
+					return object.NewTypeErr(
+						fmt.Sprintf("%s cannot be treated as func", args[0].Repr()))
+				}
+				if fn.FuncKind != object.FuncFunc {
+					return object.NewTypeErr("sig cannot be used for iter")
+				}
+
+				sig, err := newFuncSig(fn, args[1:], kwargs)
+				if err != nil {
+					return err
+				}
+				return fn.WithSig(sig)
+			},
+		),
 	}
 }
 
+// newFuncSig makes type annotations of fn.
+// kwarg `ret` is used for the return value and the other kwargs are used for kwarg params.
+func newFuncSig(
+	fn *object.PanFunc,
+	types []object.PanObject,
+	kwargs *object.PanObj,
+) (*object.FuncSig, *object.PanErr) {
+	if len(types) > len(fn.Args().Elems) {
+		return nil, object.NewTypeErr(fmt.Sprintf("too many types for params %s",
+			fn.Args().Repr()))
+	}
+
+	sig := &object.FuncSig{Params: types, Ret: object.BuiltInNil}
+
+	kwargTypes := map[object.SymHash]object.Pair{}
+	for symHash, pair := range *kwargs.Pairs {
+		name := pair.Key.(*object.PanStr).Value
+		if name == "ret" {
+			sig.Ret = pair.Value
+			continue
+		}
+
+		if _, ok := (*fn.Kwargs().Pairs)[symHash]; !ok {
+			return nil, object.NewTypeErr(fmt.Sprintf("`%s` is not a kwarg param", name))
+		}
+		kwargTypes[symHash] = pair
+	}
+	sig.Kwargs = object.PanObjInstancePtr(&kwargTypes).(*object.PanObj)
+
+	return sig, nil
+}
+
 func compFuncs(f1 *object.PanFunc, f2 *object.PanFunc) object.PanObject {
 	// if src is equivalent, return true
 	if f1.Repr() == f2.Repr() {
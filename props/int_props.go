@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
 
 	"github.com/Syuparn/pangaea/object"
 )
@@ -13,6 +14,7 @@ import (
 func IntProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// <=> returns 1 if self is greater than other, 0 if they are equal, otherwise -1.
 		"<=>": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -39,6 +41,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		// NOTE: this cannot be removed (Comparable uses Int#== internally)
+		// == returns whether self and other are equal ints with the same proto.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -67,6 +70,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		// NOTE: this cannot be removed (Comparable uses Int#!= internally)
+		// != returns whether self and other are not equal.
 		"!=": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -95,6 +99,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInFalse
 			},
 		),
+		// -% returns negated self (called by prefix `-`).
 		"-%": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -113,6 +118,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedInt(args[0].Proto(), res)
 			},
 		),
+		// /~ returns bitwise-inverted self (called by prefix `~`).
 		"/~": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -131,6 +137,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedInt(args[0].Proto(), res)
 			},
 		),
+		// + returns sum of self and other (float if other is float).
 		"+": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -148,6 +155,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return err
 			},
 		),
+		// - returns difference of self and other (float if other is float).
 		"-": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -165,6 +173,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return err
 			},
 		),
+		// * returns product of self and other (float if other is float).
 		"*": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -182,6 +191,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return err
 			},
 		),
+		// ** returns self raised to the power of other (float if the result is not an integer).
 		"**": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -204,6 +214,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return err
 			},
 		),
+		// / returns quotient of self and other as float.
 		"/": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -227,6 +238,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return err
 			},
 		),
+		// // returns floor of quotient of self and other.
 		"//": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -253,6 +265,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedInt(args[0].Proto(), res)
 			},
 		),
+		// % returns remainder of self divided by other.
 		"%": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -271,6 +284,91 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedInt(args[0].Proto(), res)
 			},
 		),
+		// /& returns bitwise and of self and other.
+		"/&": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				self, other, err := checkIntInfixArgs(args, "/&", object.NewPanInt(0))
+				if err != nil {
+					return err
+				}
+
+				res := self.Value & other.Value
+				// NOTE: Int's descendants also call this
+				return object.NewInheritedInt(args[0].Proto(), res)
+			},
+		),
+		// /| returns bitwise or of self and other.
+		"/|": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				self, other, err := checkIntInfixArgs(args, "/|", object.NewPanInt(0))
+				if err != nil {
+					return err
+				}
+
+				res := self.Value | other.Value
+				// NOTE: Int's descendants also call this
+				return object.NewInheritedInt(args[0].Proto(), res)
+			},
+		),
+		// /^ returns bitwise xor of self and other.
+		"/^": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				self, other, err := checkIntInfixArgs(args, "/^", object.NewPanInt(0))
+				if err != nil {
+					return err
+				}
+
+				res := self.Value ^ other.Value
+				// NOTE: Int's descendants also call this
+				return object.NewInheritedInt(args[0].Proto(), res)
+			},
+		),
+		// << returns self shifted left by other bits.
+		"<<": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				self, other, err := checkIntInfixArgs(args, "<<", object.NewPanInt(0))
+				if err != nil {
+					return err
+				}
+
+				if other.Value < 0 {
+					return object.NewValueErr(
+						fmt.Sprintf("negative shift count %s", other.Repr()))
+				}
+
+				res := self.Value << uint64(other.Value)
+				// NOTE: Int's descendants also call this
+				return object.NewInheritedInt(args[0].Proto(), res)
+			},
+		),
+		// >> returns self shifted right by other bits (the sign bit is kept).
+		">>": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				self, other, err := checkIntInfixArgs(args, ">>", object.NewPanInt(0))
+				if err != nil {
+					return err
+				}
+
+				if other.Value < 0 {
+					return object.NewValueErr(
+						fmt.Sprintf("negative shift count %s", other.Repr()))
+				}
+
+				res := self.Value >> uint64(other.Value)
+				// NOTE: Int's descendants also call this
+				return object.NewInheritedInt(args[0].Proto(), res)
+			},
+		),
 		"_incBy": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -302,7 +400,9 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		"_name": object.NewPanStr("Int"),
-		"at":    propContainer["Int_at"],
+		// at returns the bit of self at the index (`self[i]`).
+		"at": propContainer["Int_at"],
+		// B returns false if self is 0, otherwise true.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -322,6 +422,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		// NOTE: override bear to set arr zero values
+		// bear returns a new child obj of self, whose zero value is 0.
 		"bear": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -359,6 +460,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 					}))
 			},
 		),
+		// chr returns the character whose code point is self.
 		"chr": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -378,6 +480,84 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanStr(string(rune(self.Value)))
 			},
 		),
+		// digits returns digits of self in `base` (10 by default) from the least significant one.
+		"digits": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Int#digits requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfInt(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be int`)
+				}
+
+				base := int64(10)
+				if pair, ok := propIn(kwargs, "base"); ok {
+					i, ok := object.TraceProtoOfInt(pair.Value)
+					if !ok {
+						return object.NewTypeErr(
+							fmt.Sprintf("%s cannot be treated as int", pair.Value.Repr()))
+					}
+					base = i.Value
+				}
+
+				if base < 2 {
+					return object.NewValueErr(
+						fmt.Sprintf("base %d must be greater than 1", base))
+				}
+				if self.Value < 0 {
+					return object.NewValueErr(
+						fmt.Sprintf("digits of negative int %s are not defined", self.Repr()))
+				}
+
+				return object.NewPanArr(intDigits(self.Value, base)...)
+			},
+		),
+		// gcd returns the greatest common divisor of self and other.
+		"gcd": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				self, other, err := checkIntInfixArgs(args, "Int#gcd", object.NewPanInt(0))
+				if err != nil {
+					return err
+				}
+
+				g := gcd(absUint64(self.Value), absUint64(other.Value))
+				// NOTE: gcd of MinInt64 and 0 (or MinInt64) is 2 ** 63, which overflows int64
+				if g > math.MaxInt64 {
+					return object.NewValueErr(
+						fmt.Sprintf("gcd of %d and %d overflows int", self.Value, other.Value))
+				}
+				return object.NewPanInt(int64(g))
+			},
+		),
+		// lcm returns the least common multiple of self and other.
+		"lcm": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				self, other, err := checkIntInfixArgs(args, "Int#lcm", object.NewPanInt(0))
+				if err != nil {
+					return err
+				}
+
+				if self.Value == 0 || other.Value == 0 {
+					return object.NewPanInt(0)
+				}
+
+				a, b := absUint64(self.Value), absUint64(other.Value)
+				hi, lo := bits.Mul64(a/gcd(a, b), b)
+				if hi != 0 || lo > math.MaxInt64 {
+					return object.NewValueErr(
+						fmt.Sprintf("lcm of %d and %d overflows int", self.Value, other.Value))
+				}
+				return object.NewPanInt(int64(lo))
+			},
+		),
+		// new converts the arg into int (floats are truncated).
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -410,6 +590,103 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 					fmt.Sprintf("%s cannot be treated as int", args[1].Repr()))
 			},
 		),
+		// pack converts self into bytes of `size` (8 by default) in `endian` ("big" by default).
+		"pack": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Int#pack requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfInt(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be int`)
+				}
+
+				size := int64(8)
+				if pair, ok := propIn(kwargs, "size"); ok {
+					i, ok := object.TraceProtoOfInt(pair.Value)
+					if !ok {
+						return object.NewTypeErr(
+							fmt.Sprintf("%s cannot be treated as int", pair.Value.Repr()))
+					}
+					size = i.Value
+				}
+
+				bigEndian, errObj := isBigEndian(kwargs)
+				if errObj != nil {
+					return errObj
+				}
+
+				b, err := packInt(self.Value, int(size), bigEndian)
+				if err != nil {
+					return object.NewValueErr(err.Error())
+				}
+				return object.NewPanBytes(b)
+			},
+		),
+		// popcount returns the number of 1 bits in the absolute value of self.
+		"popcount": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Int#popcount requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfInt(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be int`)
+				}
+
+				n := uint64(self.Value)
+				if self.Value < 0 {
+					n = uint64(-self.Value)
+				}
+				return object.NewPanInt(int64(bits.OnesCount64(n)))
+			},
+		),
+		// powMod returns self ** exp % mod without overflow.
+		"powMod": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 3 {
+					return object.NewTypeErr("Int#powMod requires at least 3 args")
+				}
+				self, ok := object.TraceProtoOfInt(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be int`)
+				}
+				exp, ok := object.TraceProtoOfInt(args[1])
+				if !ok {
+					return object.NewTypeErr(
+						fmt.Sprintf("%s cannot be treated as int", args[1].Repr()))
+				}
+				mod, ok := object.TraceProtoOfInt(args[2])
+				if !ok {
+					return object.NewTypeErr(
+						fmt.Sprintf("%s cannot be treated as int", args[2].Repr()))
+				}
+
+				if exp.Value < 0 {
+					return object.NewValueErr(
+						fmt.Sprintf("exponent %s must not be negative", exp.Repr()))
+				}
+				if mod.Value == 0 {
+					return object.NewZeroDivisionErr("cannot be divided by 0")
+				}
+				if mod.Value < 0 {
+					return object.NewValueErr(
+						fmt.Sprintf("modulus %s must be positive", mod.Repr()))
+				}
+
+				m := big.NewInt(mod.Value)
+				// NOTE: result is in [0, mod) even if self is negative
+				res := new(big.Int).Exp(new(big.Int).Mod(big.NewInt(self.Value), m), big.NewInt(exp.Value), m)
+				return object.NewPanInt(res.Int64())
+			},
+		),
+		// prime? returns whether self is a prime number.
 		"prime?": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -437,6 +714,7 @@ func IntProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInFalse
 			},
 		),
+		// sqrt returns square root of self as float.
 		"sqrt": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -513,6 +791,35 @@ func baseString(self, base *object.PanInt) object.PanObject {
 	return object.NewPanStr(big.NewInt(self.Value).Text(int(base.Value)))
 }
 
+func intDigits(n, base int64) []object.PanObject {
+	if n == 0 {
+		return []object.PanObject{object.NewPanInt(0)}
+	}
+
+	digits := []object.PanObject{}
+	for ; n > 0; n /= base {
+		digits = append(digits, object.NewPanInt(n%base))
+	}
+	return digits
+}
+
+// gcd returns the (non-negative) greatest common divisor by Euclidean algorithm.
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// absUint64 returns the absolute value of i.
+// NOTE: uint64 is used because the absolute value of MinInt64 overflows int64
+func absUint64(i int64) uint64 {
+	if i < 0 {
+		return uint64(-(i + 1)) + 1
+	}
+	return uint64(i)
+}
+
 func intIter(i *object.PanInt) object.BuiltInFunc {
 	yieldNum := int64(1)
 
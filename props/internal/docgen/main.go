@@ -0,0 +1,183 @@
+// Command docgen generates a Go file which contains doc comments of builtin props written in props/*_props.go.
+//
+// A doc comment is the comment just above a prop, which starts with the prop name:
+//
+//	// uc returns uppercased self.
+//	"uc": f(...),
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const header = `// Code generated by docgen from *_props.go. DO NOT EDIT.
+
+package props
+
+// builtInDocs maps names of builtin objs to docs of their props.
+var builtInDocs = map[string]map[string]string{
+`
+
+func main() {
+	out := flag.String("o", "docs_gen.go", "output file")
+	flag.Parse()
+
+	dir := flag.Arg(0)
+	if dir == "" {
+		dir = "."
+	}
+
+	src, err := generate(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+// generate returns source code of docs in dir.
+func generate(dir string) ([]byte, error) {
+	docs, err := collectDocs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	for _, objName := range sortedKeys(docs) {
+		fmt.Fprintf(&out, "%s: {\n", strconv.Quote(objName))
+		for _, prop := range sortedKeys(docs[objName]) {
+			fmt.Fprintf(&out, "%s: %s,\n", strconv.Quote(prop), strconv.Quote(docs[objName][prop]))
+		}
+		out.WriteString("},\n")
+	}
+	out.WriteString("}\n")
+
+	return format.Source(out.Bytes())
+}
+
+// collectDocs returns docs of props in funcs like `StrProps` (keyed by obj name like `Str`).
+func collectDocs(dir string) (map[string]map[string]string, error) {
+	fileNames, err := filepath.Glob(filepath.Join(dir, "*_props.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	docs := map[string]map[string]string{}
+	fset := token.NewFileSet()
+	for _, fileName := range fileNames {
+		f, err := parser.ParseFile(fset, fileName, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !fn.Name.IsExported() || !strings.HasSuffix(fn.Name.Name, "Props") {
+				continue
+			}
+
+			propDocs := propDocsIn(fset, f, fn)
+			if len(propDocs) > 0 {
+				docs[strings.TrimSuffix(fn.Name.Name, "Props")] = propDocs
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// propDocsIn returns docs of props in the map literal returned by fn.
+func propDocsIn(fset *token.FileSet, f *ast.File, fn *ast.FuncDecl) map[string]string {
+	// comment groups keyed by their last lines
+	comments := map[int]*ast.CommentGroup{}
+	for _, c := range f.Comments {
+		comments[fset.Position(c.End()).Line] = c
+	}
+
+	docs := map[string]string{}
+	for _, stmt := range fn.Body.List {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		lit, ok := ret.Results[0].(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.BasicLit)
+			if !ok || key.Kind != token.STRING {
+				continue
+			}
+			prop, err := strconv.Unquote(key.Value)
+			if err != nil {
+				continue
+			}
+
+			c, ok := comments[fset.Position(kv.Pos()).Line-1]
+			if !ok {
+				continue
+			}
+			if doc := docComment(c.Text(), prop); doc != "" {
+				docs[prop] = doc
+			}
+		}
+	}
+
+	return docs
+}
+
+// docComment extracts lines from the one starting with prop.
+// Lines after notes like `NOTE:` are ignored.
+func docComment(text string, prop string) string {
+	lines := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if len(lines) == 0 && !strings.HasPrefix(line, prop+" ") {
+			continue
+		}
+		if isNote(line) {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isNote(line string) bool {
+	for _, prefix := range []string{"NOTE:", "TODO:", "HACK:", "FIXME:"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
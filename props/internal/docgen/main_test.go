@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestCollectDocs(t *testing.T) {
+	expected := map[string]map[string]string{
+		"Sample": {
+			"a": "a returns 1.",
+			"b": "b returns 2.\nIt has two lines.",
+			"e": "e returns 5.",
+		},
+	}
+
+	actual, err := collectDocs("testdata")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("wrong docs. expected=%v, got=%v", expected, actual)
+	}
+}
+
+func TestGeneratedDocsAreUpToDate(t *testing.T) {
+	expected, err := generate("../..")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual, err := os.ReadFile("../../docs_gen.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(actual) != string(expected) {
+		t.Errorf("props/docs_gen.go is outdated. run `go generate`")
+	}
+}
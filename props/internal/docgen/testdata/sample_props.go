@@ -0,0 +1,28 @@
+package props
+
+func SampleProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
+	return map[string]object.PanObject{
+		// a returns 1.
+		"a": f(a),
+		// b returns 2.
+		// It has two lines.
+		"b": f(b),
+		"c": f(c),
+		// NOTE: not a doc
+		"d": f(d),
+		// NOTE: e is defined in Go
+		// e returns 5.
+		// TODO: fix it
+		"e": f(e),
+		// it does not start with the prop name
+		"f": f(f),
+	}
+}
+
+// not a prop func
+func sampleProps() map[string]object.PanObject {
+	return map[string]object.PanObject{
+		// g returns 7.
+		"g": f(g),
+	}
+}
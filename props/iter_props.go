@@ -11,6 +11,7 @@ import (
 func IterProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// == returns whether self and other are the same iters.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -60,6 +61,7 @@ func IterProps(propContainer map[string]object.PanObject) map[string]object.PanO
 			},
 		),
 		"_name": object.NewPanStr("Iter"),
+		// B returns true.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -70,8 +72,85 @@ func IterProps(propContainer map[string]object.PanObject) map[string]object.PanO
 				return object.BuiltInTrue
 			},
 		),
-		"new":  propContainer["Iter_new"],
+		// combinations returns an iter of all k-length combinations of elements.
+		// NOTE: elements are read only when they are required
+		"combinations": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Iter#combinations requires at least 2 args")
+				}
+				k, ok := object.TraceProtoOfInt(args[1])
+				if !ok {
+					return object.NewTypeErr(`\2 must be int`)
+				}
+				if k.Value < 0 {
+					return object.NewValueErr(`\2 must not be negative`)
+				}
+
+				pool, err := newElemPool(propContainer, env, args[0])
+				if err != nil {
+					return err
+				}
+				return object.NewPanBuiltInIter(combinationsIter(pool, int(k.Value)), env)
+			},
+		),
+		// new returns a new iter of self whose params are bound to the args.
+		"new": propContainer["Iter_new"],
+		// next returns the next value of self (raises StopIterErr if it is finished).
 		"next": propContainer["Iter_next"],
+		// permutations returns an iter of all k-length permutations of elements (k is the number of elements by default).
+		// NOTE: elements are read only when they are required (all elements are read if k is not specified)
+		"permutations": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Iter#permutations requires at least 1 arg")
+				}
+
+				// NOTE: all elements are permuted if k is not specified
+				k := -1
+				if len(args) >= 2 && args[1] != object.BuiltInNil {
+					kInt, ok := object.TraceProtoOfInt(args[1])
+					if !ok {
+						return object.NewTypeErr(`\2 must be int`)
+					}
+					if kInt.Value < 0 {
+						return object.NewValueErr(`\2 must not be negative`)
+					}
+					k = int(kInt.Value)
+				}
+
+				pool, err := newElemPool(propContainer, env, args[0])
+				if err != nil {
+					return err
+				}
+				return object.NewPanBuiltInIter(permutationsIter(pool, k), env)
+			},
+		),
+		// product returns an iter of the cartesian product of self and iterables in the args.
+		// NOTE: elements are read only when they are required
+		"product": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Iter#product requires at least 1 arg")
+				}
+
+				pools := []elemPool{}
+				for _, arg := range args {
+					pool, err := newElemPool(propContainer, env, arg)
+					if err != nil {
+						return err
+					}
+					pools = append(pools, pool)
+				}
+				return object.NewPanBuiltInIter(productIter(pools), env)
+			},
+		),
 	}
 }
 
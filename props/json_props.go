@@ -13,6 +13,7 @@ import (
 func JSONProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// dec decodes the json str.
 		"dec": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -30,6 +31,17 @@ func JSONProps(propContainer map[string]object.PanObject) map[string]object.PanO
 				return decodeJSON(str.Value)
 			},
 		),
+		// query returns values in the decoded json obj matched by the JSONPath query.
+		"query": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 3 {
+					return object.NewTypeErr("JSON.query requires at least 3 args")
+				}
+				return queryJSON(propContainer, env, args[1], args[2])
+			},
+		),
 	}
 }
 
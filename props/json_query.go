@@ -0,0 +1,530 @@
+package props
+
+import (
+	"container/list"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// jsonQuery is a compiled JSONPath expression like `$.users[?(@.age > 20)].name`.
+type jsonQuery struct {
+	segments []*querySegment
+}
+
+// querySegment selects children (or descendants if recursive) of each node.
+type querySegment struct {
+	recursive bool
+	selectors []querySelector
+}
+
+type querySelector interface {
+	isQuerySelector()
+}
+
+type nameSelector struct{ name string }
+type wildcardSelector struct{}
+type indexSelector struct{ index int }
+type sliceSelector struct{ start, end, step *int }
+type filterSelector struct{ expr queryExpr }
+
+func (s *nameSelector) isQuerySelector()     {}
+func (s *wildcardSelector) isQuerySelector() {}
+func (s *indexSelector) isQuerySelector()    {}
+func (s *sliceSelector) isQuerySelector()    {}
+func (s *filterSelector) isQuerySelector()   {}
+
+// queryExpr is an expression in filter selectors.
+type queryExpr interface {
+	isQueryExpr()
+}
+
+// pathExpr is a relative (`@...`) or absolute (`$...`) query in filters.
+type pathExpr struct {
+	absolute bool
+	query    *jsonQuery
+}
+
+// literalExpr is a literal value (null, true, false, number or string).
+type literalExpr struct {
+	value object.PanObject
+}
+
+type notExpr struct{ right queryExpr }
+type logicalExpr struct {
+	op          string
+	left, right queryExpr
+}
+type compareExpr struct {
+	op          string
+	left, right queryExpr
+}
+
+func (e *pathExpr) isQueryExpr()    {}
+func (e *literalExpr) isQueryExpr() {}
+func (e *notExpr) isQueryExpr()     {}
+func (e *logicalExpr) isQueryExpr() {}
+func (e *compareExpr) isQueryExpr() {}
+
+// maxCompiledQueries is the maximum number of cached compiled queries.
+const maxCompiledQueries = 256
+
+// compiledQueries caches recently used compiled queries so that each expression is not compiled every time.
+// NOTE: the size is limited because queries may be generated dynamically
+var compiledQueries = newQueryCache(maxCompiledQueries)
+
+// compileJSONQuery compiles JSONPath expression src.
+// `$` can be omitted (`users[*].name` is same as `$.users[*].name`).
+func compileJSONQuery(src string) (*jsonQuery, error) {
+	if q, ok := compiledQueries.get(src); ok {
+		return q, nil
+	}
+
+	p := &queryParser{src: []rune(src)}
+	q, err := p.parseRoot()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", src, err)
+	}
+
+	compiledQueries.add(src, q)
+	return q, nil
+}
+
+// queryCache is an LRU cache of compiled queries.
+// NOTE: it is safe for concurrent use because http handlers may evaluate queries concurrently
+type queryCache struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	elems map[string]*list.Element
+}
+
+type queryCacheEntry struct {
+	src string
+	q   *jsonQuery
+}
+
+func newQueryCache(size int) *queryCache {
+	return &queryCache{size: size, order: list.New(), elems: map[string]*list.Element{}}
+}
+
+func (c *queryCache) get(src string) (*jsonQuery, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.elems[src]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*queryCacheEntry).q, true
+}
+
+func (c *queryCache) add(src string, q *jsonQuery) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.elems[src]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.elems[src] = c.order.PushFront(&queryCacheEntry{src: src, q: q})
+
+	// remove the least recently used one
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.elems, oldest.Value.(*queryCacheEntry).src)
+	}
+}
+
+type queryParser struct {
+	src []rune
+	pos int
+}
+
+func (p *queryParser) parseRoot() (*jsonQuery, error) {
+	p.skipSpaces()
+	if p.peek() == '$' {
+		p.pos++
+	} else if isNameStart(p.peek()) {
+		// shorthand of `$.name`
+		name := p.readName()
+		q, err := p.parseSegments()
+		if err != nil {
+			return nil, err
+		}
+		q.segments = append([]*querySegment{{selectors: []querySelector{&nameSelector{name}}}}, q.segments...)
+		return q, p.expectEOF()
+	}
+
+	q, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	return q, p.expectEOF()
+}
+
+func (p *queryParser) expectEOF() error {
+	p.skipSpaces()
+	if p.pos < len(p.src) {
+		return p.errorf("unexpected %q", string(p.src[p.pos]))
+	}
+	return nil
+}
+
+// parseSegments parses segments until a token which cannot start a segment.
+func (p *queryParser) parseSegments() (*jsonQuery, error) {
+	q := &jsonQuery{segments: []*querySegment{}}
+
+	for {
+		switch {
+		case p.hasPrefix(".."):
+			p.pos += 2
+			seg, err := p.parseDotSegment()
+			if err != nil {
+				return nil, err
+			}
+			seg.recursive = true
+			q.segments = append(q.segments, seg)
+		case p.peek() == '.':
+			p.pos++
+			seg, err := p.parseDotSegment()
+			if err != nil {
+				return nil, err
+			}
+			q.segments = append(q.segments, seg)
+		case p.peek() == '[':
+			seg, err := p.parseBracketSegment()
+			if err != nil {
+				return nil, err
+			}
+			q.segments = append(q.segments, seg)
+		default:
+			return q, nil
+		}
+	}
+}
+
+// parseDotSegment parses a segment after `.` or `..`.
+func (p *queryParser) parseDotSegment() (*querySegment, error) {
+	switch {
+	case p.peek() == '*':
+		p.pos++
+		return &querySegment{selectors: []querySelector{&wildcardSelector{}}}, nil
+	case p.peek() == '[':
+		return p.parseBracketSegment()
+	case isNameStart(p.peek()):
+		return &querySegment{selectors: []querySelector{&nameSelector{p.readName()}}}, nil
+	}
+	return nil, p.errorf("name is expected")
+}
+
+func (p *queryParser) parseBracketSegment() (*querySegment, error) {
+	// skip `[`
+	p.pos++
+	seg := &querySegment{}
+
+	for {
+		p.skipSpaces()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		seg.selectors = append(seg.selectors, sel)
+
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return seg, nil
+		default:
+			return nil, p.errorf("`]` is expected")
+		}
+	}
+}
+
+func (p *queryParser) parseSelector() (querySelector, error) {
+	switch c := p.peek(); {
+	case c == '*':
+		p.pos++
+		return &wildcardSelector{}, nil
+	case c == '\'' || c == '"':
+		s, err := p.readStr()
+		if err != nil {
+			return nil, err
+		}
+		return &nameSelector{s}, nil
+	case c == '?':
+		p.pos++
+		p.skipSpaces()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return &filterSelector{expr}, nil
+	case c == ':' || c == '-' || isDigit(c):
+		return p.parseIndexOrSlice()
+	}
+	return nil, p.errorf("selector is expected")
+}
+
+func (p *queryParser) parseIndexOrSlice() (querySelector, error) {
+	nums := []*int{}
+	for {
+		p.skipSpaces()
+		var n *int
+		if p.peek() == '-' || isDigit(p.peek()) {
+			i, err := p.readInt()
+			if err != nil {
+				return nil, err
+			}
+			n = &i
+		}
+		nums = append(nums, n)
+
+		p.skipSpaces()
+		if p.peek() != ':' {
+			break
+		}
+		p.pos++
+	}
+
+	switch {
+	case len(nums) == 1 && nums[0] != nil:
+		return &indexSelector{*nums[0]}, nil
+	case len(nums) == 2:
+		return &sliceSelector{start: nums[0], end: nums[1]}, nil
+	case len(nums) == 3:
+		if nums[2] != nil && *nums[2] == 0 {
+			return nil, p.errorf("slice step cannot be 0")
+		}
+		return &sliceSelector{start: nums[0], end: nums[1], step: nums[2]}, nil
+	}
+	return nil, p.errorf("invalid index")
+}
+
+func (p *queryParser) parseOr() (queryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.skipSpaces(); p.hasPrefix("||"); p.skipSpaces() {
+		p.pos += 2
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.skipSpaces(); p.hasPrefix("&&"); p.skipSpaces() {
+		p.pos += 2
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (queryExpr, error) {
+	p.skipSpaces()
+	if p.peek() == '!' && !p.hasPrefix("!=") {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{right}, nil
+	}
+	return p.parseComparison()
+}
+
+var compareOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func (p *queryParser) parseComparison() (queryExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	for _, op := range compareOps {
+		if p.hasPrefix(op) {
+			p.pos += len(op)
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return &compareExpr{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseOperand() (queryExpr, error) {
+	p.skipSpaces()
+
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.peek() != ')' {
+			return nil, p.errorf("`)` is expected")
+		}
+		p.pos++
+		return expr, nil
+	case c == '@' || c == '$':
+		p.pos++
+		q, err := p.parseSegments()
+		if err != nil {
+			return nil, err
+		}
+		return &pathExpr{absolute: c == '$', query: q}, nil
+	case c == '\'' || c == '"':
+		s, err := p.readStr()
+		if err != nil {
+			return nil, err
+		}
+		return &literalExpr{object.NewPanStr(s)}, nil
+	case c == '-' || isDigit(c):
+		return p.readNum()
+	case isNameStart(c):
+		switch name := p.readName(); name {
+		case "true":
+			return &literalExpr{object.BuiltInTrue}, nil
+		case "false":
+			return &literalExpr{object.BuiltInFalse}, nil
+		case "null", "nil":
+			return &literalExpr{object.BuiltInNil}, nil
+		default:
+			return nil, p.errorf("unknown literal `%s`", name)
+		}
+	}
+	return nil, p.errorf("operand is expected")
+}
+
+func (p *queryParser) readName() string {
+	start := p.pos
+	for p.pos < len(p.src) && isNameChar(p.src[p.pos]) {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+func (p *queryParser) readStr() (string, error) {
+	quote := p.src[p.pos]
+	p.pos++
+
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		switch c {
+		case quote:
+			return b.String(), nil
+		case '\\':
+			if p.pos >= len(p.src) {
+				return "", p.errorf("unterminated string")
+			}
+			b.WriteRune(p.src[p.pos])
+			p.pos++
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *queryParser) readInt() (int, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+
+	i, err := strconv.Atoi(string(p.src[start:p.pos]))
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("int is expected")
+	}
+	return i, nil
+}
+
+func (p *queryParser) readNum() (queryExpr, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for isDigit(p.peek()) || p.peek() == '.' || p.peek() == 'e' || p.peek() == 'E' {
+		p.pos++
+	}
+
+	s := string(p.src[start:p.pos])
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return &literalExpr{object.NewPanInt(i)}, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("number is expected")
+	}
+	return &literalExpr{object.NewPanFloat(f)}, nil
+}
+
+func (p *queryParser) peek() rune {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *queryParser) hasPrefix(s string) bool {
+	return strings.HasPrefix(string(p.src[p.pos:]), s)
+}
+
+func (p *queryParser) skipSpaces() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *queryParser) errorf(format string, a ...interface{}) error {
+	// NOTE: add 1 otherwise first element is shown as 0
+	return fmt.Errorf("%s at %d", fmt.Sprintf(format, a...), p.pos+1)
+}
+
+func isNameStart(c rune) bool {
+	return c == '_' || unicode.IsLetter(c)
+}
+
+func isNameChar(c rune) bool {
+	return isNameStart(c) || unicode.IsDigit(c)
+}
+
+func isDigit(c rune) bool {
+	return '0' <= c && c <= '9'
+}
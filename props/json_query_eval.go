@@ -0,0 +1,317 @@
+package props
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// queryJSON returns an arr of all values in o matched with JSONPath expression src.
+func queryJSON(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	o object.PanObject,
+	src object.PanObject,
+) object.PanObject {
+	str, ok := object.TraceProtoOfStr(src)
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("%s cannot be treated as str", src.Repr()))
+	}
+
+	q, err := compileJSONQuery(str.Value)
+	if err != nil {
+		return object.NewValueErr(err.Error())
+	}
+
+	e := &queryEvaluator{d: newDeepOps(propContainer, env), root: o}
+	matched, errObj := e.eval(q, o)
+	if errObj != nil {
+		return errObj
+	}
+	return object.NewPanArr(matched...)
+}
+
+// queryEvaluator evaluates compiled queries.
+type queryEvaluator struct {
+	d    *deepOps
+	root object.PanObject
+}
+
+func (e *queryEvaluator) eval(q *jsonQuery, o object.PanObject) ([]object.PanObject, *object.PanErr) {
+	nodes := []object.PanObject{o}
+
+	for _, seg := range q.segments {
+		next := []object.PanObject{}
+		for _, node := range nodes {
+			targets := []object.PanObject{node}
+			if seg.recursive {
+				targets = descendants(node, []object.PanObject{})
+			}
+
+			for _, target := range targets {
+				for _, sel := range seg.selectors {
+					selected, err := e.selectFrom(sel, target)
+					if err != nil {
+						return nil, err
+					}
+					next = append(next, selected...)
+				}
+			}
+		}
+		nodes = next
+	}
+
+	return nodes, nil
+}
+
+// descendants returns o itself and all values nested in o (in pre-order).
+func descendants(o object.PanObject, acc []object.PanObject) []object.PanObject {
+	acc = append(acc, o)
+	for _, child := range children(o) {
+		acc = descendants(child, acc)
+	}
+	return acc
+}
+
+// children returns values of collection o (or nil if o is not a collection).
+func children(o object.PanObject) []object.PanObject {
+	switch o := o.(type) {
+	case *object.PanObj:
+		return pairValues(objPairs(o))
+	case *object.PanArr:
+		return o.Elems
+	case *object.PanMap:
+		return pairValues(mapPairs(o))
+	}
+	return nil
+}
+
+func pairValues(pairs []object.Pair) []object.PanObject {
+	values := make([]object.PanObject, 0, len(pairs))
+	for _, pair := range pairs {
+		values = append(values, pair.Value)
+	}
+	return values
+}
+
+func (e *queryEvaluator) selectFrom(sel querySelector, o object.PanObject) ([]object.PanObject, *object.PanErr) {
+	switch sel := sel.(type) {
+	case *nameSelector:
+		// NOTE: arrs do not have names
+		if _, ok := o.(*object.PanArr); ok {
+			return nil, nil
+		}
+		return e.childOf(o, object.NewPanStr(sel.name))
+	case *wildcardSelector:
+		return children(o), nil
+	case *indexSelector:
+		if _, ok := o.(*object.PanArr); !ok {
+			return nil, nil
+		}
+		return e.childOf(o, object.NewPanInt(int64(sel.index)))
+	case *sliceSelector:
+		arr, ok := o.(*object.PanArr)
+		if !ok {
+			return nil, nil
+		}
+		return sliceElems(arr.Elems, sel), nil
+	case *filterSelector:
+		return e.filter(sel.expr, children(o))
+	}
+	return nil, nil
+}
+
+func (e *queryEvaluator) childOf(o object.PanObject, key object.PanObject) ([]object.PanObject, *object.PanErr) {
+	child, ok, err := e.d.child(o, key)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return []object.PanObject{child}, nil
+}
+
+// sliceElems returns elems in [start:end:step] (same as Python).
+func sliceElems(elems []object.PanObject, sel *sliceSelector) []object.PanObject {
+	step := 1
+	if sel.step != nil {
+		step = *sel.step
+	}
+
+	// NOTE: default range is reversed if step is negative
+	start, end := 0, len(elems)
+	if step < 0 {
+		start, end = len(elems)-1, -len(elems)-1
+	}
+	if sel.start != nil {
+		start = *sel.start
+	}
+	if sel.end != nil {
+		end = *sel.end
+	}
+	start = normalizeSliceIndex(start, len(elems), step)
+	end = normalizeSliceIndex(end, len(elems), step)
+
+	selected := []object.PanObject{}
+	if step > 0 {
+		for i := start; i < end; i += step {
+			selected = append(selected, elems[i])
+		}
+		return selected
+	}
+
+	for i := start; i > end; i += step {
+		selected = append(selected, elems[i])
+	}
+	return selected
+}
+
+func normalizeSliceIndex(i int, length int, step int) int {
+	if i < 0 {
+		i += length
+	}
+
+	if step > 0 {
+		return clamp(i, 0, length)
+	}
+	return clamp(i, -1, length-1)
+}
+
+func clamp(i, min, max int) int {
+	if i < min {
+		return min
+	}
+	if i > max {
+		return max
+	}
+	return i
+}
+
+func (e *queryEvaluator) filter(expr queryExpr, elems []object.PanObject) ([]object.PanObject, *object.PanErr) {
+	filtered := []object.PanObject{}
+	for _, elem := range elems {
+		ok, err := e.test(expr, elem)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, elem)
+		}
+	}
+	return filtered, nil
+}
+
+// test returns whether current node satisfies filter expr.
+func (e *queryEvaluator) test(expr queryExpr, current object.PanObject) (bool, *object.PanErr) {
+	switch expr := expr.(type) {
+	case *notExpr:
+		ok, err := e.test(expr.right, current)
+		return !ok, err
+	case *logicalExpr:
+		left, err := e.test(expr.left, current)
+		if err != nil {
+			return false, err
+		}
+		// short-circuit
+		if (expr.op == "&&") != left {
+			return left, nil
+		}
+		return e.test(expr.right, current)
+	case *compareExpr:
+		return e.compare(expr, current)
+	case *pathExpr:
+		// existence test
+		matched, err := e.evalPath(expr, current)
+		return len(matched) > 0, err
+	case *literalExpr:
+		return expr.value != object.BuiltInFalse && expr.value != object.BuiltInNil, nil
+	}
+	return false, nil
+}
+
+func (e *queryEvaluator) evalPath(expr *pathExpr, current object.PanObject) ([]object.PanObject, *object.PanErr) {
+	if expr.absolute {
+		return e.eval(expr.query, e.root)
+	}
+	return e.eval(expr.query, current)
+}
+
+// operand returns the value of expr in comparison (or ok=false if path matches nothing).
+func (e *queryEvaluator) operand(expr queryExpr, current object.PanObject) (object.PanObject, bool, *object.PanErr) {
+	switch expr := expr.(type) {
+	case *literalExpr:
+		return expr.value, true, nil
+	case *pathExpr:
+		matched, err := e.evalPath(expr, current)
+		if err != nil || len(matched) == 0 {
+			return nil, false, err
+		}
+		return matched[0], true, nil
+	}
+
+	// logical expressions in parentheses
+	ok, err := e.test(expr, current)
+	return parseJSONBool(ok), true, err
+}
+
+func (e *queryEvaluator) compare(expr *compareExpr, current object.PanObject) (bool, *object.PanErr) {
+	left, leftOk, err := e.operand(expr.left, current)
+	if err != nil {
+		return false, err
+	}
+	right, rightOk, err := e.operand(expr.right, current)
+	if err != nil {
+		return false, err
+	}
+
+	switch expr.op {
+	case "==", "!=":
+		eq := leftOk == rightOk
+		if leftOk && rightOk {
+			eq, err = e.d.deepEq(left, right)
+			if err != nil {
+				return false, err
+			}
+		}
+		return eq == (expr.op == "=="), nil
+	}
+
+	// NOTE: only numbers or strings can be ordered
+	if !leftOk || !rightOk {
+		return false, nil
+	}
+	if x, ok := numValue(left); ok {
+		if y, ok := numValue(right); ok {
+			return ordered(expr.op, compareFloat(x, y)), nil
+		}
+	}
+	if x, ok := left.(*object.PanStr); ok {
+		if y, ok := right.(*object.PanStr); ok {
+			return ordered(expr.op, strings.Compare(x.Value, y.Value)), nil
+		}
+	}
+	return false, nil
+}
+
+func compareFloat(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func ordered(op string, cmp int) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
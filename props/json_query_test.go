@@ -0,0 +1,66 @@
+package props
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestQueryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newQueryCache(2)
+	qa, qb, qc := &jsonQuery{}, &jsonQuery{}, &jsonQuery{}
+
+	c.add("a", qa)
+	c.add("b", qb)
+	// a is used more recently than b
+	if q, ok := c.get("a"); !ok || q != qa {
+		t.Fatalf("a must be cached")
+	}
+	c.add("c", qc)
+
+	if c.order.Len() != 2 || len(c.elems) != 2 {
+		t.Errorf("wrong size: order=%d, elems=%d", c.order.Len(), len(c.elems))
+	}
+	if _, ok := c.get("b"); ok {
+		t.Errorf("b must be evicted")
+	}
+	if q, ok := c.get("a"); !ok || q != qa {
+		t.Errorf("a must be cached")
+	}
+	if q, ok := c.get("c"); !ok || q != qc {
+		t.Errorf("c must be cached")
+	}
+}
+
+func TestQueryCacheAddSameSrc(t *testing.T) {
+	c := newQueryCache(2)
+	q1, q2 := &jsonQuery{}, &jsonQuery{}
+
+	c.add("a", q1)
+	c.add("a", q2)
+
+	if c.order.Len() != 1 || len(c.elems) != 1 {
+		t.Errorf("wrong size: order=%d, elems=%d", c.order.Len(), len(c.elems))
+	}
+	// NOTE: queries compiled from the same src are equivalent
+	if q, ok := c.get("a"); !ok || q != q1 {
+		t.Errorf("a must not be duplicated")
+	}
+}
+
+func TestCompileJSONQueryCacheBounded(t *testing.T) {
+	for i := 0; i < maxCompiledQueries+10; i++ {
+		if _, err := compileJSONQuery(fmt.Sprintf("a%d", i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if n := compiledQueries.order.Len(); n != maxCompiledQueries {
+		t.Errorf("wrong size: expected=%d, got=%d", maxCompiledQueries, n)
+	}
+	if _, ok := compiledQueries.get("a0"); ok {
+		t.Errorf("the oldest query must be evicted")
+	}
+	if _, ok := compiledQueries.get(fmt.Sprintf("a%d", maxCompiledQueries+9)); !ok {
+		t.Errorf("the newest query must be cached")
+	}
+}
@@ -14,10 +14,15 @@ func KernelProps(propContainer map[string]object.PanObject) map[string]object.Pa
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("Kernel"),
+		// argv returns command-line args.
 		"argv": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
 			) object.PanObject {
+				if !env.Permits(object.CapOS) {
+					return object.NewPermissionErr("argv is not permitted")
+				}
+
 				strs := []object.PanObject{}
 
 				// NOTE: ignore command name `pangaea` itself
@@ -28,6 +33,7 @@ func KernelProps(propContainer map[string]object.PanObject) map[string]object.Pa
 				return object.NewPanArr(strs...)
 			},
 		),
+		// assert raises AssertionErr if the arg is not truthy.
 		"assert": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -51,6 +57,7 @@ func KernelProps(propContainer map[string]object.PanObject) map[string]object.Pa
 					args[0].Repr()))
 			},
 		),
+		// assertEq raises AssertionErr if the args are not equal.
 		"assertEq": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -73,6 +80,7 @@ func KernelProps(propContainer map[string]object.PanObject) map[string]object.Pa
 					args[0].Repr(), args[1].Repr()))
 			},
 		),
+		// assertRaises raises AssertionErr if f does not raise the err of errType with msg.
 		"assertRaises": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -122,8 +130,13 @@ func KernelProps(propContainer map[string]object.PanObject) map[string]object.Pa
 				return object.BuiltInNil
 			},
 		),
-		"import":  propContainer["Kernel_import"],
+		// import evaluates the module and returns an obj of its exported variables.
+		"import": propContainer["Kernel_import"],
+		// invite! imports the module and sets its exported variables directly to the current scope.
 		"invite!": propContainer["Kernel_invite!"],
+		// reload discards the cached module and imports it again.
+		"reload": propContainer["Kernel_reload"],
+		// read returns the content of the file as str (or bytes if `mode` is "bytes").
 		"read": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -132,6 +145,10 @@ func KernelProps(propContainer map[string]object.PanObject) map[string]object.Pa
 					return object.NewTypeErr("read requires at least 1 arg")
 				}
 
+				if !env.Permits(object.CapRead) {
+					return object.NewPermissionErr("read is not permitted")
+				}
+
 				pathObj, ok := object.TraceProtoOfStr(args[0])
 				if !ok {
 					return object.NewTypeErr(
@@ -147,12 +164,29 @@ func KernelProps(propContainer map[string]object.PanObject) map[string]object.Pa
 					filePath = filepath.Join(filepath.Dir(filePath), filePath)
 				}
 
+				mode := "str"
+				if pair, ok := propIn(kwargs, "mode"); ok {
+					modeStr, ok := object.TraceProtoOfStr(pair.Value)
+					if !ok {
+						return object.NewTypeErr(
+							fmt.Sprintf("%s cannot be treated as str", pair.Value.Repr()))
+					}
+					mode = modeStr.Value
+				}
+				if mode != "str" && mode != "bytes" {
+					return object.NewValueErr(
+						fmt.Sprintf("mode must be \"str\" or \"bytes\" but got %q", mode))
+				}
+
 				b, err := os.ReadFile(filePath)
 				if err != nil {
 					return object.NewFileNotFoundErr(
 						fmt.Sprintf("%s cannot be opened: %s", args[0].Repr(), err.Error()))
 				}
 
+				if mode == "bytes" {
+					return object.NewPanBytes(b)
+				}
 				return object.NewPanStr(string(b))
 			},
 		),
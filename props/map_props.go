@@ -9,6 +9,7 @@ import (
 func MapProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// == returns whether self and other have equal pairs.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -47,7 +48,9 @@ func MapProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		"_name": object.NewPanStr("Map"),
-		"at":    propContainer["Map_at"],
+		// at returns the value whose key is the arg (`self[key]`).
+		"at": propContainer["Map_at"],
+		// B returns false if self is empty, otherwise true.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -66,6 +69,7 @@ func MapProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInTrue
 			},
 		),
+		// items returns an arr of key-value pairs in self.
 		"items": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -92,6 +96,7 @@ func MapProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanArr(items...)
 			},
 		),
+		// keys returns an arr of keys in self.
 		"keys": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -117,6 +122,7 @@ func MapProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanArr(keys...)
 			},
 		),
+		// len returns the number of pairs in self.
 		"len": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -133,6 +139,7 @@ func MapProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanInt(int64(length))
 			},
 		),
+		// values returns an arr of values in self.
 		"values": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
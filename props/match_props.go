@@ -10,5 +10,7 @@ func MatchProps(propContainer map[string]object.PanObject) map[string]object.Pan
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("Match"),
+		// call calls the first pattern of self which matches the args.
+		"call": propContainer["Match_call"],
 	}
 }
@@ -47,11 +47,15 @@ func request(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) o
 
 	var body io.Reader
 	if bodyPair, ok := (*kwargs.Pairs)[object.GetSymHash("body")]; ok {
-		bodyStr, ok := object.TraceProtoOfStr(bodyPair.Value)
-		if !ok {
-			return object.NewTypeErr(fmt.Sprintf("body `%s` cannot be treated as str", bodyPair.Value.Inspect()))
+		if bodyBytes, ok := object.TraceProtoOfBytes(bodyPair.Value); ok {
+			body = bytes.NewReader(bodyBytes.Value)
+		} else {
+			bodyStr, ok := object.TraceProtoOfStr(bodyPair.Value)
+			if !ok {
+				return object.NewTypeErr(fmt.Sprintf("body `%s` cannot be treated as str or bytes", bodyPair.Value.Inspect()))
+			}
+			body = strings.NewReader(bodyStr.Value)
 		}
-		body = strings.NewReader(bodyStr.Value)
 	}
 
 	req, err := http.NewRequest(methodStr.Value, u.String(), body)
@@ -134,6 +134,24 @@ func TestRequest(t *testing.T) {
 				}
 			},
 		},
+		{
+			"post with bytes body",
+			func(endpoint string) *object.PanObj {
+				return mapToObj(map[string]object.PanObject{
+					"method": object.NewPanStr("POST"),
+					"url":    object.NewPanStr(endpoint),
+					"body":   object.NewPanBytes([]byte{0, 1, 255}),
+				})
+			},
+			func(endpoint string) *http.Request {
+				return &http.Request{
+					Method: "POST",
+					URL:    must(url.Parse("/")),
+					Host:   strings.TrimPrefix(endpoint, "http://"),
+					Body:   io.NopCloser(bytes.NewReader([]byte{0, 1, 255})),
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -292,7 +310,7 @@ func TestRequestError(t *testing.T) {
 					"body":   object.NewPanInt(1),
 				})
 			},
-			object.NewTypeErr("body `1` cannot be treated as str"),
+			object.NewTypeErr("body `1` cannot be treated as str or bytes"),
 		},
 		{
 			"failed to create request",
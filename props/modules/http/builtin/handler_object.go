@@ -82,7 +82,12 @@ func toHandler(env *object.Env, callback *object.PanFunc) echo.HandlerFunc {
 
 	handler := func(c echo.Context) error {
 		reqObj := requestToObj(c)
-		res := call.Fn(env, object.EmptyPanObjPtr(), callback, reqObj)
+		// NOTE: requests are handled concurrently, so each of them counts its own depth of func calls
+		e := object.NewEnclosedEnv(env)
+		if s := env.Sandbox(); s != nil {
+			e.SetSandbox(s.Fork())
+		}
+		res := call.Fn(e, object.EmptyPanObjPtr(), callback, reqObj)
 		if res.Type() == object.ErrType {
 			fmt.Fprintln(os.Stderr, res.Inspect())
 			return fmt.Errorf(res.Inspect())
@@ -0,0 +1,143 @@
+package builtin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// encode serializes o into JSON.
+// Int and Float are distinguished by the decimal point (e.g. `1` and `1.0`)
+// so that decoded values have the same types as stored ones.
+func encode(o object.PanObject) ([]byte, error) {
+	v, err := toJSONValue(o)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func toJSONValue(o object.PanObject) (interface{}, error) {
+	switch o := o.(type) {
+	case *object.PanNil:
+		return nil, nil
+	case *object.PanBool:
+		return o.Value, nil
+	case *object.PanInt:
+		return json.Number(strconv.FormatInt(o.Value, 10)), nil
+	case *object.PanFloat:
+		return floatNumber(o.Value)
+	case *object.PanStr:
+		return o.Value, nil
+	case *object.PanArr:
+		elems := make([]interface{}, 0, len(o.Elems))
+		for _, elem := range o.Elems {
+			v, err := toJSONValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, v)
+		}
+		return elems, nil
+	case *object.PanObj:
+		// NOTE: proto cannot be stored
+		if o.Proto() != object.BuiltInObjObj {
+			return nil, unsupportedErr(o)
+		}
+
+		m := map[string]interface{}{}
+		for _, pair := range *o.Pairs {
+			v, err := toJSONValue(pair.Value)
+			if err != nil {
+				return nil, err
+			}
+			m[pair.Key.(*object.PanStr).Value] = v
+		}
+		return m, nil
+	}
+
+	return nil, unsupportedErr(o)
+}
+
+func floatNumber(f float64) (json.Number, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%s cannot be stored", strconv.FormatFloat(f, 'g', -1, 64))
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return json.Number(s), nil
+}
+
+func unsupportedErr(o object.PanObject) error {
+	return fmt.Errorf("%s cannot be stored (only nil, true, false, Int, Float, Str, Arr and Obj literals can be stored)",
+		o.Repr())
+}
+
+// decode deserializes JSON encoded by encode.
+func decode(data []byte) (object.PanObject, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return fromJSONValue(v)
+}
+
+func fromJSONValue(v interface{}) (object.PanObject, error) {
+	switch v := v.(type) {
+	case nil:
+		return object.BuiltInNil, nil
+	case bool:
+		if v {
+			return object.BuiltInTrue, nil
+		}
+		return object.BuiltInFalse, nil
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			f, err := v.Float64()
+			if err != nil {
+				return nil, err
+			}
+			return object.NewPanFloat(f), nil
+		}
+		i, err := v.Int64()
+		if err != nil {
+			return nil, err
+		}
+		return object.NewPanInt(i), nil
+	case string:
+		return object.NewPanStr(v), nil
+	case []interface{}:
+		elems := make([]object.PanObject, 0, len(v))
+		for _, e := range v {
+			elem, err := fromJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		}
+		return object.NewPanArr(elems...), nil
+	case map[string]interface{}:
+		pairs := map[object.SymHash]object.Pair{}
+		for k, e := range v {
+			elem, err := fromJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			pairs[object.GetSymHash(k)] = object.Pair{Key: object.NewPanStr(k), Value: elem}
+		}
+		return object.PanObjInstancePtr(&pairs), nil
+	}
+
+	return nil, fmt.Errorf("unexpected value %v", v)
+}
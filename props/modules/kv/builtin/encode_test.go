@@ -0,0 +1,143 @@
+package builtin
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      object.PanObject
+		expected string
+	}{
+		{"nil", object.BuiltInNil, `null`},
+		{"true", object.BuiltInTrue, `true`},
+		{"int", object.NewPanInt(-3), `-3`},
+		{"float", object.NewPanFloat(1.5), `1.5`},
+		// float is distinguished from int
+		{"integral float", object.NewPanFloat(2.0), `2.0`},
+		{"large float", object.NewPanFloat(1e30), `1e+30`},
+		{"str", object.NewPanStr("a\"b"), `"a\"b"`},
+		{
+			"arr",
+			object.NewPanArr(object.NewPanInt(1), object.NewPanStr("a")),
+			`[1,"a"]`,
+		},
+		// keys are sorted
+		{
+			"obj",
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.NewPanArr()},
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.BuiltInFalse},
+			}),
+			`{"a":false,"b":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := encode(tt.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(actual) != tt.expected {
+				t.Errorf("wrong output: expected=%s, got=%s", tt.expected, string(actual))
+			}
+		})
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      object.PanObject
+		expected string
+	}{
+		{
+			"map",
+			object.NewPanMap(),
+			`%{} cannot be stored (only nil, true, false, Int, Float, Str, Arr and Obj literals can be stored)`,
+		},
+		{
+			"nested",
+			object.NewPanArr(object.NewPanRange(object.NewPanInt(1), object.BuiltInNil, object.BuiltInNil)),
+			`(1:nil:nil) cannot be stored (only nil, true, false, Int, Float, Str, Arr and Obj literals can be stored)`,
+		},
+		// obj whose proto is not Obj
+		{
+			"child obj",
+			object.ChildPanObjPtr(object.BuiltInArrObj, object.EmptyPanObjPtr()),
+			`{} cannot be stored (only nil, true, false, Int, Float, Str, Arr and Obj literals can be stored)`,
+		},
+		{
+			"nan",
+			object.NewPanFloat(math.NaN()),
+			`NaN cannot be stored`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encode(tt.obj)
+			if err == nil {
+				t.Fatalf("error must be raised")
+			}
+
+			if err.Error() != tt.expected {
+				t.Errorf("wrong message: expected=%s, got=%s", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		obj object.PanObject
+	}{
+		{object.BuiltInNil},
+		{object.BuiltInFalse},
+		{object.NewPanInt(10)},
+		{object.NewPanFloat(2.0)},
+		{object.NewPanFloat(-1e-10)},
+		{object.NewPanStr("日本語")},
+		{object.NewPanArr(object.NewPanArr(object.NewPanInt(1)), object.BuiltInNil)},
+		{
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.NewPanFloat(1.0)},
+				object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.NewPanInt(1)},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.obj.Inspect(), func(t *testing.T) {
+			data, err := encode(tt.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			actual, err := decode(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// NOTE: Inspect distinguishes Int and Float
+			if actual.Inspect() != tt.obj.Inspect() {
+				t.Errorf("wrong value: expected=%s, got=%s", tt.obj.Inspect(), actual.Inspect())
+			}
+			if actual.Type() != tt.obj.Type() {
+				t.Errorf("wrong type: expected=%s, got=%s", tt.obj.Type(), actual.Type())
+			}
+		})
+	}
+}
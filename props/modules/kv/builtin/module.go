@@ -0,0 +1,14 @@
+package builtin
+
+import "github.com/Syuparn/pangaea/object"
+
+func New() map[string]object.PanObject {
+	return map[string]object.PanObject{
+		"del":  object.NewPanBuiltInFunc(del),
+		"get":  object.NewPanBuiltInFunc(get),
+		"has?": object.NewPanBuiltInFunc(has),
+		"keys": object.NewPanBuiltInFunc(keys),
+		"open": object.NewPanBuiltInFunc(open),
+		"put":  object.NewPanBuiltInFunc(put),
+	}
+}
@@ -0,0 +1,133 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func open(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("open requires at least 1 arg")
+	}
+
+	path, ok := object.TraceProtoOfStr(args[0])
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("`%s` cannot be treated as str", args[0].Inspect()))
+	}
+
+	s, err := openPanStore(path.Value)
+	if err != nil {
+		return object.NewPanErr(err.Error())
+	}
+	return s
+}
+
+func get(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 3 {
+		return object.NewTypeErr("get requires at least 3 args")
+	}
+
+	s, key, errObj := storeAndKey(args)
+	if errObj != nil {
+		return errObj
+	}
+
+	v, ok, err := s.get(key)
+	if err != nil {
+		return object.NewPanErr(err.Error())
+	}
+	if !ok {
+		// default value
+		return args[2]
+	}
+	return v
+}
+
+func has(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 2 {
+		return object.NewTypeErr("has? requires at least 2 args")
+	}
+
+	s, key, errObj := storeAndKey(args)
+	if errObj != nil {
+		return errObj
+	}
+
+	if !s.has(key) {
+		return object.BuiltInFalse
+	}
+	return object.BuiltInTrue
+}
+
+func put(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 3 {
+		return object.NewTypeErr("put requires at least 3 args")
+	}
+
+	s, key, errObj := storeAndKey(args)
+	if errObj != nil {
+		return errObj
+	}
+
+	raw, err := encode(args[2])
+	if err != nil {
+		return object.NewTypeErr(err.Error())
+	}
+
+	if err := s.put(key, raw); err != nil {
+		return object.NewPanErr(err.Error())
+	}
+	return args[2]
+}
+
+func del(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 2 {
+		return object.NewTypeErr("del requires at least 2 args")
+	}
+
+	s, key, errObj := storeAndKey(args)
+	if errObj != nil {
+		return errObj
+	}
+
+	deleted, err := s.del(key)
+	if err != nil {
+		return object.NewPanErr(err.Error())
+	}
+	if !deleted {
+		return object.BuiltInFalse
+	}
+	return object.BuiltInTrue
+}
+
+func keys(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("keys requires at least 1 arg")
+	}
+
+	s, ok := args[0].(*panStore)
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("`%s` cannot be treated as store", args[0].Inspect()))
+	}
+
+	elems := []object.PanObject{}
+	for _, k := range s.keys() {
+		elems = append(elems, object.NewPanStr(k))
+	}
+	return object.NewPanArr(elems...)
+}
+
+func storeAndKey(args []object.PanObject) (*panStore, string, *object.PanErr) {
+	s, ok := args[0].(*panStore)
+	if !ok {
+		return nil, "", object.NewTypeErr(fmt.Sprintf("`%s` cannot be treated as store", args[0].Inspect()))
+	}
+
+	key, ok := object.TraceProtoOfStr(args[1])
+	if !ok {
+		return nil, "", object.NewTypeErr(fmt.Sprintf("`%s` cannot be treated as str", args[1].Inspect()))
+	}
+
+	return s, key.Value, nil
+}
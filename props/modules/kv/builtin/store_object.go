@@ -0,0 +1,216 @@
+package builtin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// storeType is a type of panStore.
+const storeType = "StoreType"
+
+// storeVersion is a version of the file format.
+const storeVersion = 1
+
+// panStore is object of key-value store backed by a file.
+// NOTE: it is safe for concurrent use because http handlers may evaluate funcs concurrently
+type panStore struct {
+	path string
+	mu   sync.RWMutex
+	// NOTE: values are kept encoded so that stored values cannot be changed in the meantime
+	items map[string]json.RawMessage
+}
+
+// storeFile is a format of the file.
+type storeFile struct {
+	Version int                        `json:"version"`
+	Items   map[string]json.RawMessage `json:"items"`
+}
+
+// Type returns type of this PanObject.
+func (s *panStore) Type() object.PanObjType {
+	return storeType
+}
+
+// Inspect returns formatted source code of this object.
+func (s *panStore) Inspect() string {
+	return fmt.Sprintf("[store %s]", s.path)
+}
+
+// Repr returns pritty-printed string of this object.
+func (s *panStore) Repr() string {
+	return s.Inspect()
+}
+
+// Proto returns proto of this object.
+func (s *panStore) Proto() object.PanObject {
+	return object.BuiltInObjObj
+}
+
+// Zero returns zero value of this object.
+func (s *panStore) Zero() object.PanObject {
+	return s
+}
+
+// get returns the value of key.
+func (s *panStore) get(key string) (object.PanObject, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	raw, ok := s.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	v, err := decode(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode %q: %w", key, err)
+	}
+	return v, true, nil
+}
+
+// has returns whether key exists.
+func (s *panStore) has(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.items[key]
+	return ok
+}
+
+// put stores encoded value raw and saves all items to the file.
+func (s *panStore) put(key string, raw json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, exists := s.items[key]
+	s.items[key] = raw
+	if err := s.save(); err != nil {
+		// rollback
+		if exists {
+			s.items[key] = old
+		} else {
+			delete(s.items, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// del removes key and saves all items to the file.
+// It returns whether key existed.
+func (s *panStore) del(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, exists := s.items[key]
+	if !exists {
+		return false, nil
+	}
+
+	delete(s.items, key)
+	if err := s.save(); err != nil {
+		// rollback
+		s.items[key] = old
+		return false, err
+	}
+	return true, nil
+}
+
+// keys returns sorted keys.
+func (s *panStore) keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.items))
+	for k := range s.items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// save writes items to the file.
+// NOTE: the file is replaced by rename so that it is not broken even if the process stops while writing
+func (s *panStore) save() error {
+	// NOTE: keys are sorted by json.Marshal
+	data, err := json.MarshalIndent(storeFile{Version: storeVersion, Items: s.items}, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// opened stores are shared by absolute paths not to overwrite each other's items.
+var (
+	storesMu sync.Mutex
+	stores   = map[string]*panStore{}
+)
+
+// openPanStore returns store object, which loads items from the file at path if exists.
+// The same object is returned if the file has already been opened.
+func openPanStore(path string) (*panStore, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	if s, ok := stores[absPath]; ok {
+		return s, nil
+	}
+
+	s, err := loadPanStore(absPath)
+	if err != nil {
+		return nil, err
+	}
+	stores[absPath] = s
+	return s, nil
+}
+
+func loadPanStore(path string) (*panStore, error) {
+	s := &panStore{path: path, items: map[string]json.RawMessage{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f storeFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	if f.Version != storeVersion {
+		return nil, fmt.Errorf("failed to load %s: unsupported version %d", path, f.Version)
+	}
+	if f.Items != nil {
+		s.items = f.Items
+	}
+	return s, nil
+}
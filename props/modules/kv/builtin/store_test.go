@@ -0,0 +1,177 @@
+package builtin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func TestStoreType(t *testing.T) {
+	obj := &panStore{path: "db.json"}
+	if obj.Type() != storeType {
+		t.Fatalf("wrong type: expected=%s, got=%s", storeType, obj.Type())
+	}
+}
+
+func TestStoreInspect(t *testing.T) {
+	obj := &panStore{path: "/tmp/db.json"}
+	expected := `[store /tmp/db.json]`
+	if obj.Inspect() != expected {
+		t.Errorf("wrong output: expected=%s, got=%s", expected, obj.Inspect())
+	}
+}
+
+func TestStoreProto(t *testing.T) {
+	obj := &panStore{path: "db.json"}
+	if obj.Proto() != object.BuiltInObjObj {
+		t.Fatalf("Proto is not BuiltInObjObj. got=%T (%+v)", obj.Proto(), obj.Proto())
+	}
+}
+
+func TestStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	env := object.NewEnv()
+
+	s := open(env, object.EmptyPanObjPtr(), object.NewPanStr(path))
+	if s.Type() == object.ErrType {
+		t.Fatalf("error raised: %s", s.Inspect())
+	}
+
+	put(env, object.EmptyPanObjPtr(), s, object.NewPanStr("a"), object.NewPanArr(object.NewPanInt(1)))
+	put(env, object.EmptyPanObjPtr(), s, object.NewPanStr("b"), object.NewPanFloat(2.0))
+	put(env, object.EmptyPanObjPtr(), s, object.NewPanStr("c"), object.BuiltInNil)
+	del(env, object.EmptyPanObjPtr(), s, object.NewPanStr("c"))
+
+	// reload from the file
+	loaded, err := loadPanStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	actual := keys(env, object.EmptyPanObjPtr(), loaded)
+	if actual.Inspect() != `["a", "b"]` {
+		t.Errorf("wrong keys: got=%s", actual.Inspect())
+	}
+
+	v := get(env, object.EmptyPanObjPtr(), loaded, object.NewPanStr("b"), object.BuiltInNil)
+	if v.Inspect() != `2.000000` {
+		t.Errorf("wrong value: got=%s", v.Inspect())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `{
+  "version": 1,
+  "items": {
+    "a": [
+      1
+    ],
+    "b": 2.0
+  }
+}
+`
+	if string(data) != expected {
+		t.Errorf("wrong file: expected=%s, got=%s", expected, string(data))
+	}
+}
+
+func TestStoreSharedByPath(t *testing.T) {
+	dir := t.TempDir()
+	env := object.NewEnv()
+
+	s1 := open(env, object.EmptyPanObjPtr(), object.NewPanStr(filepath.Join(dir, "db.json")))
+	s2 := open(env, object.EmptyPanObjPtr(), object.NewPanStr(filepath.Join(dir, ".", "db.json")))
+
+	if s1 != s2 {
+		t.Errorf("the same store must be returned")
+	}
+}
+
+func TestStoreConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	env := object.NewEnv()
+	s := open(env, object.EmptyPanObjPtr(), object.NewPanStr(path))
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			put(env, object.EmptyPanObjPtr(), s, object.NewPanStr(key), object.NewPanStr(key))
+		}(key)
+	}
+	wg.Wait()
+
+	loaded, err := loadPanStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(loaded.keys()) != 5 {
+		t.Errorf("all items must be saved. got=%v", loaded.keys())
+	}
+}
+
+func TestStoreFuncsError(t *testing.T) {
+	env := object.NewEnv()
+	s := &panStore{path: "db.json", items: map[string]json.RawMessage{}}
+
+	tests := []struct {
+		name     string
+		actual   object.PanObject
+		expected string
+	}{
+		{
+			"put unsupported value",
+			put(env, object.EmptyPanObjPtr(), s, object.NewPanStr("a"), object.NewPanMap()),
+			"TypeErr: %{} cannot be stored (only nil, true, false, Int, Float, Str, Arr and Obj literals can be stored)",
+		},
+		{
+			"key is not str",
+			get(env, object.EmptyPanObjPtr(), s, object.NewPanInt(1), object.BuiltInNil),
+			"TypeErr: `1` cannot be treated as str",
+		},
+		{
+			"not store",
+			has(env, object.EmptyPanObjPtr(), object.NewPanInt(1), object.NewPanStr("a")),
+			"TypeErr: `1` cannot be treated as store",
+		},
+		{
+			"too few args",
+			get(env, object.EmptyPanObjPtr(), s, object.NewPanStr("a")),
+			"TypeErr: get requires at least 3 args",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.actual.Inspect() != tt.expected {
+				t.Errorf("wrong error: expected=%s, got=%s", tt.expected, tt.actual.Inspect())
+			}
+		})
+	}
+}
+
+func TestLoadStoreError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	if err := os.WriteFile(path, []byte(`{"version": 2, "items": {}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := loadPanStore(path)
+	if err == nil {
+		t.Fatalf("error must be raised")
+	}
+
+	expected := "failed to load " + path + ": unsupported version 2"
+	if err.Error() != expected {
+		t.Errorf("wrong message: expected=%s, got=%s", expected, err.Error())
+	}
+}
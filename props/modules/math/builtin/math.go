@@ -0,0 +1,108 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// floatFunc wraps a float function so that it can be called with an int or a float.
+func floatFunc(name string, fn func(float64) float64) *object.PanBuiltIn {
+	return object.NewPanBuiltInFunc(func(
+		env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+	) object.PanObject {
+		if len(args) < 1 {
+			return object.NewTypeErr(fmt.Sprintf("%s requires at least 1 arg", name))
+		}
+
+		x, err := toFloat(args[0])
+		if err != nil {
+			return err
+		}
+
+		return checkDomain(name, fn(x), args[0])
+	})
+}
+
+// floatFunc2 is floatFunc for functions with 2 args.
+func floatFunc2(name string, fn func(float64, float64) float64) *object.PanBuiltIn {
+	return object.NewPanBuiltInFunc(func(
+		env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+	) object.PanObject {
+		if len(args) < 2 {
+			return object.NewTypeErr(fmt.Sprintf("%s requires at least 2 args", name))
+		}
+
+		x, err := toFloat(args[0])
+		if err != nil {
+			return err
+		}
+		y, err := toFloat(args[1])
+		if err != nil {
+			return err
+		}
+
+		return checkDomain(name, fn(x, y), args...)
+	})
+}
+
+func log(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 2 {
+		return object.NewTypeErr("log requires at least 2 args")
+	}
+
+	x, err := toFloat(args[0])
+	if err != nil {
+		return err
+	}
+
+	// natural logarithm if base is nil
+	if _, ok := object.TraceProtoOfNil(args[1]); ok {
+		return checkDomain("log", math.Log(x), args[0])
+	}
+
+	base, err := toFloat(args[1])
+	if err != nil {
+		return err
+	}
+	if base <= 0 || base == 1 {
+		return object.NewValueErr(fmt.Sprintf("base %s must be positive and not 1", args[1].Repr()))
+	}
+
+	switch base {
+	// NOTE: use exact functions to avoid rounding errors
+	case 2:
+		return checkDomain("log", math.Log2(x), args...)
+	case 10:
+		return checkDomain("log", math.Log10(x), args...)
+	}
+	return checkDomain("log", math.Log(x)/math.Log(base), args...)
+}
+
+func toFloat(o object.PanObject) (float64, *object.PanErr) {
+	if f, ok := object.TraceProtoOfFloat(o); ok {
+		return f.Value, nil
+	}
+	if i, ok := object.TraceProtoOfInt(o); ok {
+		return float64(i.Value), nil
+	}
+	return 0, object.NewTypeErr(fmt.Sprintf("%s cannot be treated as num", o.Repr()))
+}
+
+// checkDomain raises an error if the result is NaN though args are not NaN.
+func checkDomain(name string, res float64, args ...object.PanObject) object.PanObject {
+	if !math.IsNaN(res) {
+		return object.NewPanFloat(res)
+	}
+
+	reprs := []string{}
+	for _, arg := range args {
+		if f, ok := object.TraceProtoOfFloat(arg); ok && math.IsNaN(f.Value) {
+			return object.NewPanFloat(res)
+		}
+		reprs = append(reprs, arg.Repr())
+	}
+	return object.NewValueErr(fmt.Sprintf("%s(%s) is out of domain", name, strings.Join(reprs, ", ")))
+}
@@ -0,0 +1,92 @@
+package builtin
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func TestMathFuncs(t *testing.T) {
+	m := New()
+
+	tests := []struct {
+		name     string
+		args     []object.PanObject
+		expected float64
+	}{
+		{"sin", []object.PanObject{object.NewPanFloat(math.Pi / 2)}, 1.0},
+		{"cos", []object.PanObject{object.NewPanInt(0)}, 1.0},
+		{"atan2", []object.PanObject{object.NewPanInt(1), object.NewPanInt(1)}, math.Pi / 4},
+		{"hypot", []object.PanObject{object.NewPanInt(3), object.NewPanFloat(4.0)}, 5.0},
+		{"exp", []object.PanObject{object.NewPanInt(0)}, 1.0},
+		{"log2", []object.PanObject{object.NewPanInt(1024)}, 10.0},
+		{"log10", []object.PanObject{object.NewPanInt(1000)}, 3.0},
+		{"log", []object.PanObject{object.NewPanFloat(math.E), object.BuiltInNil}, 1.0},
+		{"log", []object.PanObject{object.NewPanInt(8), object.NewPanInt(2)}, 3.0},
+		{"log", []object.PanObject{object.NewPanInt(1000), object.NewPanInt(10)}, 3.0},
+		{"log", []object.PanObject{object.NewPanInt(81), object.NewPanInt(3)}, 4.0},
+		{"log", []object.PanObject{object.NewPanInt(0), object.BuiltInNil}, math.Inf(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := m[tt.name].(*object.PanBuiltIn)
+			actual := f.Fn(object.NewEnv(), object.EmptyPanObjPtr(), tt.args...)
+
+			res, ok := actual.(*object.PanFloat)
+			if !ok {
+				t.Fatalf("result must be float. got=%s", actual.Inspect())
+			}
+			if math.Abs(res.Value-tt.expected) > 1e-9 && res.Value != tt.expected {
+				t.Errorf("wrong result: expected=%v, got=%v", tt.expected, res.Value)
+			}
+		})
+	}
+}
+
+func TestMathFuncsErr(t *testing.T) {
+	m := New()
+
+	tests := []struct {
+		name     string
+		args     []object.PanObject
+		expected *object.PanErr
+	}{
+		{"sin", []object.PanObject{}, object.NewTypeErr("sin requires at least 1 arg")},
+		{"atan2", []object.PanObject{object.NewPanInt(1)}, object.NewTypeErr("atan2 requires at least 2 args")},
+		{"cos", []object.PanObject{object.NewPanStr("a")}, object.NewTypeErr(`"a" cannot be treated as num`)},
+		{"hypot", []object.PanObject{object.NewPanInt(1), object.BuiltInNil}, object.NewTypeErr(`nil cannot be treated as num`)},
+		{"acos", []object.PanObject{object.NewPanInt(2)}, object.NewValueErr("acos(2) is out of domain")},
+		{"log2", []object.PanObject{object.NewPanInt(-1)}, object.NewValueErr("log2(-1) is out of domain")},
+		{"log", []object.PanObject{object.NewPanInt(-1), object.BuiltInNil}, object.NewValueErr("log(-1) is out of domain")},
+		{"log", []object.PanObject{object.NewPanInt(2), object.NewPanInt(1)}, object.NewValueErr("base 1 must be positive and not 1")},
+		{"log", []object.PanObject{object.NewPanInt(2), object.NewPanInt(-2)}, object.NewValueErr("base -2 must be positive and not 1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := m[tt.name].(*object.PanBuiltIn)
+			actual := f.Fn(object.NewEnv(), object.EmptyPanObjPtr(), tt.args...)
+
+			err, ok := actual.(*object.PanErr)
+			if !ok {
+				t.Fatalf("result must be err. got=%s", actual.Inspect())
+			}
+			if err.Kind() != tt.expected.Kind() || err.Msg != tt.expected.Msg {
+				t.Errorf("wrong err: expected=%s, got=%s", tt.expected.Inspect(), err.Inspect())
+			}
+		})
+	}
+}
+
+func TestMathFuncsNaN(t *testing.T) {
+	f := New()["sin"].(*object.PanBuiltIn)
+	// NaN is propagated without errors
+	actual := f.Fn(object.NewEnv(), object.EmptyPanObjPtr(), object.NewPanFloat(math.NaN()))
+
+	res, ok := actual.(*object.PanFloat)
+	if !ok || !math.IsNaN(res.Value) {
+		t.Errorf("result must be NaN. got=%s", actual.Inspect())
+	}
+}
@@ -0,0 +1,42 @@
+package builtin
+
+import (
+	"math"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func New() map[string]object.PanObject {
+	return map[string]object.PanObject{
+		"E":   object.NewPanFloat(math.E),
+		"INF": object.NewPanFloat(math.Inf(1)),
+		"NAN": object.NewPanFloat(math.NaN()),
+		"PI":  object.NewPanFloat(math.Pi),
+		"TAU": object.NewPanFloat(2 * math.Pi),
+
+		"acos":  floatFunc("acos", math.Acos),
+		"acosh": floatFunc("acosh", math.Acosh),
+		"asin":  floatFunc("asin", math.Asin),
+		"asinh": floatFunc("asinh", math.Asinh),
+		"atan":  floatFunc("atan", math.Atan),
+		"atan2": floatFunc2("atan2", math.Atan2),
+		"atanh": floatFunc("atanh", math.Atanh),
+		"cbrt":  floatFunc("cbrt", math.Cbrt),
+		"cos":   floatFunc("cos", math.Cos),
+		"cosh":  floatFunc("cosh", math.Cosh),
+		"exp":   floatFunc("exp", math.Exp),
+		"hypot": floatFunc2("hypot", math.Hypot),
+		"log":   object.NewPanBuiltInFunc(log),
+		"log10": floatFunc("log10", math.Log10),
+		"log2":  floatFunc("log2", math.Log2),
+		"sin":   floatFunc("sin", math.Sin),
+		"sinh":  floatFunc("sinh", math.Sinh),
+		"tan":   floatFunc("tan", math.Tan),
+		"tanh":  floatFunc("tanh", math.Tanh),
+
+		"newRandom": object.NewPanBuiltInFunc(newRandom),
+		"perm":      object.NewPanBuiltInFunc(perm),
+		"randFloat": object.NewPanBuiltInFunc(randFloat),
+		"randInt":   object.NewPanBuiltInFunc(randInt),
+	}
+}
@@ -0,0 +1,95 @@
+package builtin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func newRandom(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("newRandom requires at least 1 arg")
+	}
+
+	// seeded by the current time if seed is nil
+	if _, ok := object.TraceProtoOfNil(args[0]); ok {
+		return newPanRandom(time.Now().UnixNano())
+	}
+
+	seed, ok := object.TraceProtoOfInt(args[0])
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("seed %s cannot be treated as int", args[0].Repr()))
+	}
+	return newPanRandom(seed.Value)
+}
+
+func randInt(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 3 {
+		return object.NewTypeErr("randInt requires at least 3 args")
+	}
+
+	r, errObj := traceRandom(args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	start, ok := object.TraceProtoOfInt(args[1])
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("%s cannot be treated as int", args[1].Repr()))
+	}
+	stop, ok := object.TraceProtoOfInt(args[2])
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("%s cannot be treated as int", args[2].Repr()))
+	}
+
+	if start.Value >= stop.Value {
+		return object.NewValueErr(fmt.Sprintf("empty range (%d:%d)", start.Value, stop.Value))
+	}
+	return object.NewPanInt(start.Value + r.int63n(stop.Value-start.Value))
+}
+
+func randFloat(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("randFloat requires at least 1 arg")
+	}
+
+	r, errObj := traceRandom(args[0])
+	if errObj != nil {
+		return errObj
+	}
+	return object.NewPanFloat(r.float64())
+}
+
+func perm(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 2 {
+		return object.NewTypeErr("perm requires at least 2 args")
+	}
+
+	r, errObj := traceRandom(args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	n, ok := object.TraceProtoOfInt(args[1])
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("%s cannot be treated as int", args[1].Repr()))
+	}
+	if n.Value < 0 {
+		return object.NewValueErr(fmt.Sprintf("%d must not be negative", n.Value))
+	}
+
+	elems := []object.PanObject{}
+	for _, i := range r.perm(int(n.Value)) {
+		elems = append(elems, object.NewPanInt(int64(i)))
+	}
+	return object.NewPanArr(elems...)
+}
+
+func traceRandom(o object.PanObject) (*panRandom, *object.PanErr) {
+	r, ok := o.(*panRandom)
+	if !ok {
+		return nil, object.NewTypeErr(fmt.Sprintf("`%s` cannot be treated as random", o.Inspect()))
+	}
+	return r, nil
+}
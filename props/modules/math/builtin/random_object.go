@@ -0,0 +1,71 @@
+package builtin
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// randomType is a type of panRandom.
+const randomType = "RandomType"
+
+// panRandom is object of pseudo-random number generator.
+// NOTE: it is safe for concurrent use because http handlers may evaluate funcs concurrently
+type panRandom struct {
+	seed int64
+	mu   sync.Mutex
+	rnd  *rand.Rand
+}
+
+// Type returns type of this PanObject.
+func (r *panRandom) Type() object.PanObjType {
+	return randomType
+}
+
+// Inspect returns formatted source code of this object.
+func (r *panRandom) Inspect() string {
+	return fmt.Sprintf("[random seed=%d]", r.seed)
+}
+
+// Repr returns pritty-printed string of this object.
+func (r *panRandom) Repr() string {
+	return r.Inspect()
+}
+
+// Proto returns proto of this object.
+func (r *panRandom) Proto() object.PanObject {
+	return object.BuiltInObjObj
+}
+
+// Zero returns zero value of this object.
+func (r *panRandom) Zero() object.PanObject {
+	return r
+}
+
+func newPanRandom(seed int64) *panRandom {
+	// NOTE: math/rand (v1) is used because it generates the same sequence in any Go versions
+	return &panRandom{seed: seed, rnd: rand.New(rand.NewSource(seed))}
+}
+
+// int63n returns a random int in [0, n).
+func (r *panRandom) int63n(n int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int63n(n)
+}
+
+// float64 returns a random float in [0.0, 1.0).
+func (r *panRandom) float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float64()
+}
+
+// perm returns a random permutation of [0, n).
+func (r *panRandom) perm(n int) []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Perm(n)
+}
@@ -0,0 +1,113 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func TestRandomType(t *testing.T) {
+	obj := newPanRandom(1)
+	if obj.Type() != randomType {
+		t.Fatalf("wrong type: expected=%s, got=%s", randomType, obj.Type())
+	}
+}
+
+func TestRandomInspect(t *testing.T) {
+	obj := newPanRandom(42)
+	expected := `[random seed=42]`
+	if obj.Inspect() != expected {
+		t.Errorf("wrong output: expected=%s, got=%s", expected, obj.Inspect())
+	}
+}
+
+func TestRandomProto(t *testing.T) {
+	obj := newPanRandom(1)
+	if obj.Proto() != object.BuiltInObjObj {
+		t.Fatalf("Proto is not BuiltInObjObj. got=%T (%+v)", obj.Proto(), obj.Proto())
+	}
+}
+
+func TestRandomIsDeterministic(t *testing.T) {
+	env := object.NewEnv()
+	kwargs := object.EmptyPanObjPtr()
+
+	gen := func() []object.PanObject {
+		r := newRandom(env, kwargs, object.NewPanInt(42))
+		return []object.PanObject{
+			randInt(env, kwargs, r, object.NewPanInt(0), object.NewPanInt(100)),
+			randFloat(env, kwargs, r),
+			perm(env, kwargs, r, object.NewPanInt(5)),
+		}
+	}
+
+	first, second := gen(), gen()
+	for i := range first {
+		if first[i].Inspect() != second[i].Inspect() {
+			t.Errorf("results of the same seed must be the same: %s, %s",
+				first[i].Inspect(), second[i].Inspect())
+		}
+	}
+}
+
+func TestRandInt(t *testing.T) {
+	env := object.NewEnv()
+	kwargs := object.EmptyPanObjPtr()
+	r := newRandom(env, kwargs, object.BuiltInNil)
+
+	for i := 0; i < 100; i++ {
+		res := randInt(env, kwargs, r, object.NewPanInt(-3), object.NewPanInt(3))
+		n, ok := res.(*object.PanInt)
+		if !ok {
+			t.Fatalf("result must be int. got=%s", res.Inspect())
+		}
+		if n.Value < -3 || n.Value >= 3 {
+			t.Fatalf("result must be in (-3:3). got=%d", n.Value)
+		}
+	}
+}
+
+func TestRandomErr(t *testing.T) {
+	env := object.NewEnv()
+	kwargs := object.EmptyPanObjPtr()
+	r := newPanRandom(1)
+
+	tests := []struct {
+		name     string
+		actual   object.PanObject
+		expected *object.PanErr
+	}{
+		{
+			"seed is not int",
+			newRandom(env, kwargs, object.NewPanStr("a")),
+			object.NewTypeErr(`seed "a" cannot be treated as int`),
+		},
+		{
+			"empty range",
+			randInt(env, kwargs, r, object.NewPanInt(3), object.NewPanInt(3)),
+			object.NewValueErr("empty range (3:3)"),
+		},
+		{
+			"not random",
+			randFloat(env, kwargs, object.NewPanInt(1)),
+			object.NewTypeErr("`1` cannot be treated as random"),
+		},
+		{
+			"negative perm",
+			perm(env, kwargs, r, object.NewPanInt(-1)),
+			object.NewValueErr("-1 must not be negative"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err, ok := tt.actual.(*object.PanErr)
+			if !ok {
+				t.Fatalf("result must be err. got=%s", tt.actual.Inspect())
+			}
+			if err.Kind() != tt.expected.Kind() || err.Msg != tt.expected.Msg {
+				t.Errorf("wrong err: expected=%s, got=%s", tt.expected.Inspect(), err.Inspect())
+			}
+		})
+	}
+}
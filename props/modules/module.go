@@ -4,6 +4,10 @@ import (
 	"github.com/Syuparn/pangaea/object"
 	"github.com/Syuparn/pangaea/props/modules/dummy"
 	"github.com/Syuparn/pangaea/props/modules/http/builtin"
+	kv "github.com/Syuparn/pangaea/props/modules/kv/builtin"
+	math "github.com/Syuparn/pangaea/props/modules/math/builtin"
+	toml "github.com/Syuparn/pangaea/props/modules/toml/builtin"
+	yaml "github.com/Syuparn/pangaea/props/modules/yaml/builtin"
 )
 
 type ModuleFactory = func() map[string]object.PanObject
@@ -12,4 +16,8 @@ var Modules = map[string]ModuleFactory{
 	"dummy": dummy.New,
 	// NOTE: package is renamed because go does not import `internal` package
 	"http/internal": builtin.New,
+	"kv/internal":   kv.New,
+	"math/internal": math.New,
+	"toml/internal": toml.New,
+	"yaml/internal": yaml.New,
 }
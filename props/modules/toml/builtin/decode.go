@@ -0,0 +1,718 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// table is a TOML table under construction.
+type table struct {
+	keys   []string
+	values map[string]interface{}
+	// header is true if the table is defined by a header (`[a]`)
+	header bool
+	// dotted is true if the table is defined by dotted keys (`a.b = 1`)
+	dotted bool
+}
+
+func newTable() *table {
+	return &table{keys: []string{}, values: map[string]interface{}{}}
+}
+
+func (t *table) set(key string, v interface{}) {
+	if _, ok := t.values[key]; !ok {
+		t.keys = append(t.keys, key)
+	}
+	t.values[key] = v
+}
+
+// tableArray is an array of tables (`[[a]]`).
+type tableArray struct {
+	tables []*table
+}
+
+// decodeError is an error with the line number.
+type decodeError struct {
+	line int
+	msg  string
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.line, e.msg)
+}
+
+// decoder parses a TOML document into Pangaea objects.
+type decoder struct {
+	src string
+	pos int
+	// ordered is true if tables are decoded into maps to keep the order of keys
+	ordered bool
+	root    *table
+	current *table
+}
+
+func newDecoder(src string, ordered bool) *decoder {
+	root := newTable()
+	return &decoder{
+		src:     strings.ReplaceAll(src, "\r\n", "\n"),
+		ordered: ordered,
+		root:    root,
+		current: root,
+	}
+}
+
+// decode parses the whole document.
+func (d *decoder) decode() (object.PanObject, error) {
+	for {
+		d.skipSpaces()
+		if d.pos >= len(d.src) {
+			break
+		}
+
+		var err error
+		switch d.peek() {
+		case '\n':
+			d.pos++
+			continue
+		case '#':
+			err = d.skipComment()
+		case '[':
+			err = d.parseHeader()
+		default:
+			err = d.parseKeyVal(d.current)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := d.expectLineEnd(); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.toPanObject(d.root), nil
+}
+
+func (d *decoder) errorf(format string, a ...interface{}) error {
+	line := strings.Count(d.src[:min(d.pos, len(d.src))], "\n") + 1
+	return &decodeError{line: line, msg: fmt.Sprintf(format, a...)}
+}
+
+func (d *decoder) peek() byte {
+	if d.pos >= len(d.src) {
+		return 0
+	}
+	return d.src[d.pos]
+}
+
+func (d *decoder) hasPrefix(s string) bool {
+	return strings.HasPrefix(d.src[d.pos:], s)
+}
+
+// skipSpaces skips spaces and tabs (not line breaks).
+func (d *decoder) skipSpaces() {
+	for d.pos < len(d.src) && (d.src[d.pos] == ' ' || d.src[d.pos] == '\t') {
+		d.pos++
+	}
+}
+
+func (d *decoder) skipComment() error {
+	for d.pos < len(d.src) && d.src[d.pos] != '\n' {
+		if isControl(rune(d.src[d.pos])) && d.src[d.pos] != '\t' {
+			return d.errorf("control characters cannot be used in comments")
+		}
+		d.pos++
+	}
+	return nil
+}
+
+// skipBlank skips spaces, line breaks and comments (in arrays).
+func (d *decoder) skipBlank() error {
+	for {
+		d.skipSpaces()
+		switch d.peek() {
+		case '\n':
+			d.pos++
+		case '#':
+			if err := d.skipComment(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+func (d *decoder) expectLineEnd() error {
+	d.skipSpaces()
+	if d.peek() == '#' {
+		if err := d.skipComment(); err != nil {
+			return err
+		}
+	}
+
+	switch d.peek() {
+	case 0:
+		return nil
+	case '\n':
+		d.pos++
+		return nil
+	}
+	return d.errorf("line break is expected but found %q", d.rest())
+}
+
+// rest returns the rest of the current line (for error messages).
+func (d *decoder) rest() string {
+	end := strings.IndexByte(d.src[d.pos:], '\n')
+	if end < 0 {
+		return d.src[d.pos:]
+	}
+	return d.src[d.pos : d.pos+end]
+}
+
+func (d *decoder) parseHeader() error {
+	isArray := d.hasPrefix("[[")
+	if isArray {
+		d.pos += 2
+	} else {
+		d.pos++
+	}
+
+	d.skipSpaces()
+	keys, err := d.parseKey()
+	if err != nil {
+		return err
+	}
+	d.skipSpaces()
+
+	closing := "]"
+	if isArray {
+		closing = "]]"
+	}
+	if !d.hasPrefix(closing) {
+		return d.errorf("`%s` is expected", closing)
+	}
+	d.pos += len(closing)
+
+	parent, err := d.walkHeader(keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+	name := strings.Join(keys, ".")
+	existing, exists := parent.values[key]
+
+	if isArray {
+		t := newTable()
+		switch existing := existing.(type) {
+		case nil:
+			parent.set(key, &tableArray{tables: []*table{t}})
+		case *tableArray:
+			existing.tables = append(existing.tables, t)
+		default:
+			return d.errorf("%s is already defined", name)
+		}
+		d.current = t
+		return nil
+	}
+
+	if !exists {
+		t := newTable()
+		t.header = true
+		parent.set(key, t)
+		d.current = t
+		return nil
+	}
+
+	t, ok := existing.(*table)
+	if !ok || t.header || t.dotted {
+		return d.errorf("%s is already defined", name)
+	}
+	t.header = true
+	d.current = t
+	return nil
+}
+
+// walkHeader returns the table at keys from the root. Missing tables are created implicitly.
+func (d *decoder) walkHeader(keys []string) (*table, error) {
+	t := d.root
+	for i, key := range keys {
+		switch v := t.values[key].(type) {
+		case nil:
+			child := newTable()
+			t.set(key, child)
+			t = child
+		case *table:
+			t = v
+		case *tableArray:
+			// NOTE: the last table in the array is used
+			t = v.tables[len(v.tables)-1]
+		default:
+			return nil, d.errorf("%s is already defined as a value", strings.Join(keys[:i+1], "."))
+		}
+	}
+	return t, nil
+}
+
+// parseKeyVal parses `key = value` and sets the value to t.
+func (d *decoder) parseKeyVal(t *table) error {
+	keys, err := d.parseKey()
+	if err != nil {
+		return err
+	}
+
+	d.skipSpaces()
+	if d.peek() != '=' {
+		return d.errorf("`=` is expected after key %s", strings.Join(keys, "."))
+	}
+	d.pos++
+	d.skipSpaces()
+
+	v, err := d.parseValue()
+	if err != nil {
+		return err
+	}
+
+	for i, key := range keys[:len(keys)-1] {
+		switch child := t.values[key].(type) {
+		case nil:
+			c := newTable()
+			c.dotted = true
+			t.set(key, c)
+			t = c
+		case *table:
+			if child.header {
+				return d.errorf("%s is already defined", strings.Join(keys[:i+1], "."))
+			}
+			t = child
+		default:
+			return d.errorf("%s is already defined", strings.Join(keys[:i+1], "."))
+		}
+	}
+
+	key := keys[len(keys)-1]
+	if _, ok := t.values[key]; ok {
+		return d.errorf("duplicated key %s", strings.Join(keys, "."))
+	}
+	t.set(key, v)
+	return nil
+}
+
+// parseKey parses a dotted key.
+func (d *decoder) parseKey() ([]string, error) {
+	keys := []string{}
+	for {
+		d.skipSpaces()
+		key, err := d.parseSimpleKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+
+		d.skipSpaces()
+		if d.peek() != '.' {
+			return keys, nil
+		}
+		d.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_' || c == '-'
+}
+
+func (d *decoder) parseSimpleKey() (string, error) {
+	switch d.peek() {
+	case '"':
+		if d.hasPrefix(`"""`) {
+			return "", d.errorf("multi-line string cannot be used as a key")
+		}
+		return d.parseBasicStr()
+	case '\'':
+		if d.hasPrefix(`'''`) {
+			return "", d.errorf("multi-line string cannot be used as a key")
+		}
+		return d.parseLiteralStr()
+	}
+
+	start := d.pos
+	for d.pos < len(d.src) && isBareKeyChar(d.src[d.pos]) {
+		d.pos++
+	}
+	if start == d.pos {
+		return "", d.errorf("key is expected but found %q", d.rest())
+	}
+	return d.src[start:d.pos], nil
+}
+
+// parseValue parses a value. Inline tables are converted into objects immediately
+// because they cannot be extended.
+func (d *decoder) parseValue() (object.PanObject, error) {
+	switch {
+	case d.hasPrefix(`"""`):
+		s, err := d.parseMultiLineBasicStr()
+		return object.NewPanStr(s), err
+	case d.hasPrefix(`'''`):
+		s, err := d.parseMultiLineLiteralStr()
+		return object.NewPanStr(s), err
+	case d.peek() == '"':
+		s, err := d.parseBasicStr()
+		return object.NewPanStr(s), err
+	case d.peek() == '\'':
+		s, err := d.parseLiteralStr()
+		return object.NewPanStr(s), err
+	case d.peek() == '[':
+		return d.parseArray()
+	case d.peek() == '{':
+		return d.parseInlineTable()
+	}
+	return d.parseScalar()
+}
+
+func (d *decoder) parseArray() (object.PanObject, error) {
+	// skip `[`
+	d.pos++
+	elems := []object.PanObject{}
+
+	for {
+		if err := d.skipBlank(); err != nil {
+			return nil, err
+		}
+		if d.peek() == ']' {
+			d.pos++
+			return object.NewPanArr(elems...), nil
+		}
+
+		elem, err := d.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+
+		if err := d.skipBlank(); err != nil {
+			return nil, err
+		}
+		switch d.peek() {
+		case ',':
+			d.pos++
+		case ']':
+		case 0:
+			return nil, d.errorf("`]` is not closed")
+		default:
+			return nil, d.errorf("`,` or `]` is expected but found %q", d.rest())
+		}
+	}
+}
+
+func (d *decoder) parseInlineTable() (object.PanObject, error) {
+	// skip `{`
+	d.pos++
+	t := newTable()
+
+	d.skipSpaces()
+	if d.peek() == '}' {
+		d.pos++
+		return d.toPanObject(t), nil
+	}
+
+	for {
+		d.skipSpaces()
+		if c := d.peek(); c == '\n' || c == 0 {
+			return nil, d.errorf("inline table must be closed in the same line")
+		}
+		if err := d.parseKeyVal(t); err != nil {
+			return nil, err
+		}
+
+		d.skipSpaces()
+		switch d.peek() {
+		case ',':
+			d.pos++
+		case '}':
+			d.pos++
+			return d.toPanObject(t), nil
+		case '\n', 0:
+			return nil, d.errorf("inline table must be closed in the same line")
+		default:
+			return nil, d.errorf("`,` or `}` is expected but found %q", d.rest())
+		}
+	}
+}
+
+var (
+	datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+	timePattern = regexp.MustCompile(`^\d{2}:\d{2}(:\d{2}(\.\d+)?)?$`)
+	// date-time with optional time and offset
+	dateTimePattern = regexp.MustCompile(
+		`^\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?([Zz]|[+-]\d{2}:\d{2})?)?$`)
+	decPattern   = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	floatPattern = regexp.MustCompile(
+		`^[+-]?(0|[1-9](_?[0-9])*)((\.[0-9](_?[0-9])*)([eE][+-]?[0-9](_?[0-9])*)?|[eE][+-]?[0-9](_?[0-9])*)$`)
+	prefixedIntPatterns = map[string]*regexp.Regexp{
+		"0x": regexp.MustCompile(`^0x[0-9A-Fa-f](_?[0-9A-Fa-f])*$`),
+		"0o": regexp.MustCompile(`^0o[0-7](_?[0-7])*$`),
+		"0b": regexp.MustCompile(`^0b[01](_?[01])*$`),
+	}
+)
+
+// parseScalar parses a bool, number or date-time.
+// NOTE: date-times are decoded into strs
+func (d *decoder) parseScalar() (object.PanObject, error) {
+	start := d.pos
+	for d.pos < len(d.src) && isScalarChar(d.src[d.pos]) {
+		d.pos++
+	}
+	// date and time can be separated by a space
+	if datePattern.MatchString(d.src[start:d.pos]) && d.pos-start == 10 &&
+		d.hasPrefix(" ") && d.pos+3 < len(d.src) && d.src[d.pos+3] == ':' {
+		d.pos++
+		for d.pos < len(d.src) && isScalarChar(d.src[d.pos]) {
+			d.pos++
+		}
+	}
+
+	token := d.src[start:d.pos]
+	if v, ok := parseScalarToken(token); ok {
+		return v, nil
+	}
+
+	d.pos = start
+	if token == "" {
+		return nil, d.errorf("value is expected but found %q", d.rest())
+	}
+	return nil, d.errorf("invalid value %q", token)
+}
+
+func isScalarChar(c byte) bool {
+	return isBareKeyChar(c) || c == '+' || c == '.' || c == ':'
+}
+
+func parseScalarToken(token string) (object.PanObject, bool) {
+	switch token {
+	case "true":
+		return object.BuiltInTrue, true
+	case "false":
+		return object.BuiltInFalse, true
+	case "inf", "+inf":
+		return object.NewPanFloat(math.Inf(1)), true
+	case "-inf":
+		return object.NewPanFloat(math.Inf(-1)), true
+	case "nan", "+nan", "-nan":
+		return object.NewPanFloat(math.NaN()), true
+	}
+
+	if dateTimePattern.MatchString(token) || timePattern.MatchString(token) {
+		return object.NewPanStr(token), true
+	}
+
+	digits := strings.ReplaceAll(token, "_", "")
+	if decPattern.MatchString(token) {
+		i, err := strconv.ParseInt(digits, 10, 64)
+		return object.NewPanInt(i), err == nil
+	}
+	for prefix, pattern := range prefixedIntPatterns {
+		if pattern.MatchString(token) {
+			i, err := strconv.ParseInt(digits[2:], map[string]int{"0x": 16, "0o": 8, "0b": 2}[prefix], 64)
+			return object.NewPanInt(i), err == nil
+		}
+	}
+	if floatPattern.MatchString(token) {
+		f, err := strconv.ParseFloat(digits, 64)
+		return object.NewPanFloat(f), err == nil
+	}
+	return nil, false
+}
+
+func (d *decoder) parseBasicStr() (string, error) {
+	// skip `"`
+	d.pos++
+
+	var b strings.Builder
+	for d.pos < len(d.src) {
+		c := d.src[d.pos]
+		switch {
+		case c == '"':
+			d.pos++
+			return b.String(), nil
+		case c == '\\':
+			if err := d.parseEscape(&b); err != nil {
+				return "", err
+			}
+		case c == '\n':
+			return "", d.errorf("string is not closed")
+		case isControl(rune(c)) && c != '\t':
+			return "", d.errorf("control characters must be escaped")
+		default:
+			b.WriteByte(c)
+			d.pos++
+		}
+	}
+	return "", d.errorf("string is not closed")
+}
+
+func (d *decoder) parseMultiLineBasicStr() (string, error) {
+	// skip `"""` and the following line break
+	d.pos += 3
+	if d.peek() == '\n' {
+		d.pos++
+	}
+
+	var b strings.Builder
+	for d.pos < len(d.src) {
+		c := d.src[d.pos]
+		switch {
+		case d.hasPrefix(`"""`):
+			// NOTE: 1 or 2 quotes can be written just before the closing quotes
+			quotes := 3
+			for quotes < 5 && d.pos+quotes < len(d.src) && d.src[d.pos+quotes] == '"' {
+				quotes++
+			}
+			b.WriteString(strings.Repeat(`"`, quotes-3))
+			d.pos += quotes
+			return b.String(), nil
+		case c == '\\' && d.isLineEndingBackslash():
+			// skip spaces and line breaks
+			d.pos++
+			for d.pos < len(d.src) && strings.IndexByte(" \t\n", d.src[d.pos]) >= 0 {
+				d.pos++
+			}
+		case c == '\\':
+			if err := d.parseEscape(&b); err != nil {
+				return "", err
+			}
+		case isControl(rune(c)) && c != '\t' && c != '\n':
+			return "", d.errorf("control characters must be escaped")
+		default:
+			b.WriteByte(c)
+			d.pos++
+		}
+	}
+	return "", d.errorf("string is not closed")
+}
+
+// isLineEndingBackslash returns whether `\` at the current position is followed only by spaces in the line.
+func (d *decoder) isLineEndingBackslash() bool {
+	i := d.pos + 1
+	for i < len(d.src) && (d.src[i] == ' ' || d.src[i] == '\t') {
+		i++
+	}
+	return i < len(d.src) && d.src[i] == '\n'
+}
+
+func (d *decoder) parseLiteralStr() (string, error) {
+	// skip `'`
+	d.pos++
+	start := d.pos
+	for d.pos < len(d.src) {
+		switch c := d.src[d.pos]; {
+		case c == '\'':
+			d.pos++
+			return d.src[start : d.pos-1], nil
+		case c == '\n':
+			return "", d.errorf("string is not closed")
+		case isControl(rune(c)) && c != '\t':
+			return "", d.errorf("control characters cannot be used in literal strings")
+		}
+		d.pos++
+	}
+	return "", d.errorf("string is not closed")
+}
+
+func (d *decoder) parseMultiLineLiteralStr() (string, error) {
+	// skip `'''` and the following line break
+	d.pos += 3
+	if d.peek() == '\n' {
+		d.pos++
+	}
+
+	start := d.pos
+	for d.pos < len(d.src) {
+		if d.hasPrefix(`'''`) {
+			// NOTE: 1 or 2 quotes can be written just before the closing quotes
+			end := d.pos
+			quotes := 3
+			for quotes < 5 && d.pos+quotes < len(d.src) && d.src[d.pos+quotes] == '\'' {
+				quotes++
+			}
+			d.pos += quotes
+			return d.src[start:end] + strings.Repeat(`'`, quotes-3), nil
+		}
+		if c := d.src[d.pos]; isControl(rune(c)) && c != '\t' && c != '\n' {
+			return "", d.errorf("control characters cannot be used in literal strings")
+		}
+		d.pos++
+	}
+	return "", d.errorf("string is not closed")
+}
+
+var escapes = map[byte]string{
+	'b': "\b", 't': "\t", 'n': "\n", 'f': "\f", 'r': "\r", 'e': "\x1b", '"': "\"", '\\': "\\",
+}
+
+func (d *decoder) parseEscape(b *strings.Builder) error {
+	// skip `\`
+	d.pos++
+	c := d.peek()
+	d.pos++
+
+	if s, ok := escapes[c]; ok {
+		b.WriteString(s)
+		return nil
+	}
+
+	digits := map[byte]int{'u': 4, 'U': 8}[c]
+	if digits == 0 || d.pos+digits > len(d.src) {
+		d.pos -= 2
+		return d.errorf("invalid escape sequence %q", d.src[d.pos:min(d.pos+2, len(d.src))])
+	}
+
+	r, err := strconv.ParseUint(d.src[d.pos:d.pos+digits], 16, 32)
+	if err != nil {
+		return d.errorf("invalid escape sequence %q", d.src[d.pos-2:d.pos+digits])
+	}
+	b.WriteRune(rune(r))
+	d.pos += digits
+	return nil
+}
+
+func isControl(r rune) bool {
+	return r < 0x20 || r == 0x7f
+}
+
+// toPanObject converts t into an Obj (or a Map if ordered).
+func (d *decoder) toPanObject(t *table) object.PanObject {
+	pairs := make([]object.Pair, 0, len(t.keys))
+	for _, key := range t.keys {
+		pairs = append(pairs, object.Pair{Key: object.NewPanStr(key), Value: d.valueToPanObject(t.values[key])})
+	}
+
+	if d.ordered {
+		return object.NewPanMap(pairs...)
+	}
+
+	objPairs := map[object.SymHash]object.Pair{}
+	for _, pair := range pairs {
+		objPairs[object.GetSymHash(pair.Key.(*object.PanStr).Value)] = pair
+	}
+	return object.PanObjInstancePtr(&objPairs)
+}
+
+func (d *decoder) valueToPanObject(v interface{}) object.PanObject {
+	switch v := v.(type) {
+	case *table:
+		return d.toPanObject(v)
+	case *tableArray:
+		elems := make([]object.PanObject, 0, len(v.tables))
+		for _, t := range v.tables {
+			elems = append(elems, d.toPanObject(t))
+		}
+		return object.NewPanArr(elems...)
+	}
+	return v.(object.PanObject)
+}
@@ -0,0 +1,130 @@
+package builtin
+
+import (
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"empty", ``, `{}`},
+		{"comments", "# a\n\n  # b\n", `{}`},
+		{"str", `a = "b\tc\u3042"`, `{"a": "b	cあ"}`},
+		{"literal str", `a = 'C:\path'`, `{"a": "C:\path"}`},
+		{
+			"multi-line str",
+			"a = \"\"\"\nb\\\n   c\n\"\"\"\nd = '''\ne\\f'''",
+			"{\"a\": \"bc\n\", \"d\": \"e\\f\"}",
+		},
+		{"quotes before closing", `a = """b"""""`, "{\"a\": `b\"\"`}"},
+		{"int", `a = -1_000`, `{"a": -1000}`},
+		{"prefixed int", "a = 0xff\nb = 0o17\nc = 0b101", `{"a": 255, "b": 15, "c": 5}`},
+		{"float", "a = 1.5\nb = -2e3\nc = 6.6E-1_0", `{"a": 1.500000, "b": -2000.000000, "c": 0.000000}`},
+		{"inf", "a = -inf\nb = nan", `{"a": -Inf, "b": NaN}`},
+		{"bool", "a = true\nb = false", `{"a": true, "b": false}`},
+		{
+			"date-times",
+			"a = 1979-05-27T07:32:00Z\nb = 1979-05-27 07:32:00.99+09:00\nc = 1979-05-27\nd = 07:32:00",
+			`{"a": "1979-05-27T07:32:00Z", "b": "1979-05-27 07:32:00.99+09:00", "c": "1979-05-27", "d": "07:32:00"}`,
+		},
+		{
+			"array",
+			"a = [\n  1, # one\n  [2, 'x'],\n]",
+			`{"a": [1, [2, "x"]]}`,
+		},
+		{"inline table", `a = { b = 1, c.d = [] }`, `{"a": {"b": 1, "c": {"d": []}}}`},
+		{"dotted keys", "a.b = 1\na . \"c d\" = 2", `{"a": {"b": 1, "c d": 2}}`},
+		{
+			"tables",
+			"a = 1\n[b]\nc = 2\n[b.d]\n[e.f]\ng = 3\n[e]\nh = 4",
+			`{"a": 1, "b": {"c": 2, "d": {}}, "e": {"f": {"g": 3}, "h": 4}}`,
+		},
+		{
+			"arrays of tables",
+			"[[a]]\nb = 1\n[a.c]\nd = 2\n[[a]]\n[[a.e]]\nf = 3",
+			`{"a": [{"b": 1, "c": {"d": 2}}, {"e": [{"f": 3}]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := newDecoder(tt.src, false).decode()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual.Inspect() != tt.expected {
+				t.Errorf("wrong value: expected=%s, got=%s", tt.expected, actual.Inspect())
+			}
+		})
+	}
+}
+
+func TestDecodeOrdered(t *testing.T) {
+	actual, err := newDecoder("b = 1\na = 2\n[d]\n[c]", true).decode()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	pairs, ok, _ := mappingPairs(actual)
+	if !ok {
+		t.Fatalf("actual must be mapping. got=%s", actual.Inspect())
+	}
+
+	keys := ""
+	for _, pair := range pairs {
+		keys += pair.Key.Inspect()
+	}
+
+	expected := `"b""a""d""c"`
+	if keys != expected {
+		t.Errorf("wrong keys: expected=%s, got=%s", expected, keys)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"no value", "a =", `line 1: value is expected but found ""`},
+		{"no equal", "a 1", "line 1: `=` is expected after key a"},
+		{"invalid value", "a = 1x", `line 1: invalid value "1x"`},
+		{"leading zero", "a = 01", `line 1: invalid value "01"`},
+		{"two values in line", "a = 1 b = 2", `line 1: line break is expected but found "b = 2"`},
+		{"duplicated key", "a = 1\n\na = 2", `line 3: duplicated key a`},
+		{"duplicated table", "[a]\n[b]\n[a]", `line 3: a is already defined`},
+		{"table defined by dotted keys", "a.b = 1\n[a]", `line 2: a is already defined`},
+		{"extend value", "a = 1\n[a.b]", `line 2: a is already defined as a value`},
+		{"extend inline table", "a = {}\na.b = 1", `line 2: a is already defined`},
+		{"array of tables after table", "[a]\n[[a]]", `line 2: a is already defined`},
+		{"unclosed str", "a = \"b\nc = 1", `line 1: string is not closed`},
+		{"unclosed multi-line str", "a = '''\nb", `line 2: string is not closed`},
+		{"unclosed array", "a = [1,\n2", "line 2: `]` is not closed"},
+		{"multi-line inline table", "a = { b = 1,\n c = 2 }", `line 1: inline table must be closed in the same line`},
+		{"invalid escape", `a = "\x41"`, `line 1: invalid escape sequence "\\x"`},
+		{"unclosed header", "[a", "line 1: `]` is expected"},
+		{"empty key", "= 1", `line 1: key is expected but found "= 1"`},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newDecoder(tt.src, false).decode()
+			if err == nil {
+				t.Fatalf("error must be raised")
+			}
+
+			if err.Error() != tt.expected {
+				t.Errorf("wrong message: expected=%s, got=%s", tt.expected, err.Error())
+			}
+		})
+	}
+}
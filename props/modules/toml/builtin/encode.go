@@ -0,0 +1,260 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// encode serializes o into a TOML document.
+// Keys are written in the order of o (sorted in Obj and inserted in Map).
+func encode(o object.PanObject) (string, error) {
+	pairs, ok, err := mappingPairs(o)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("%s cannot be encoded into TOML document (only Obj and Map can be)", o.Repr())
+	}
+
+	var b strings.Builder
+	if err := encodeTable(&b, []string{}, pairs, false); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// encodeTable writes key-values in pairs, and then sub-tables and arrays of tables.
+// path is a list of keys already formatted by keyText.
+// If isArrayElem is true, the header `[[path]]` is written.
+func encodeTable(b *strings.Builder, path []string, pairs []object.Pair, isArrayElem bool) error {
+	values, tables, arrays := []object.Pair{}, []object.Pair{}, []object.Pair{}
+	for _, pair := range pairs {
+		switch {
+		case isTable(pair.Value):
+			tables = append(tables, pair)
+		case isTableArray(pair.Value):
+			arrays = append(arrays, pair)
+		default:
+			values = append(values, pair)
+		}
+	}
+
+	// NOTE: header can be omitted if the table has only sub-tables
+	if isArrayElem || (len(path) > 0 && (len(values) > 0 || len(tables)+len(arrays) == 0)) {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		header := strings.Join(path, ".")
+		if isArrayElem {
+			b.WriteString("[[" + header + "]]\n")
+		} else {
+			b.WriteString("[" + header + "]\n")
+		}
+	}
+
+	for _, pair := range values {
+		key, err := keyText(pair.Key)
+		if err != nil {
+			return err
+		}
+		v, err := valueText(pair.Value)
+		if err != nil {
+			return err
+		}
+		b.WriteString(key + " = " + v + "\n")
+	}
+
+	for _, pair := range tables {
+		key, err := keyText(pair.Key)
+		if err != nil {
+			return err
+		}
+		childPairs, _, _ := mappingPairs(pair.Value)
+		if err := encodeTable(b, append(path[:len(path):len(path)], key), childPairs, false); err != nil {
+			return err
+		}
+	}
+
+	for _, pair := range arrays {
+		key, err := keyText(pair.Key)
+		if err != nil {
+			return err
+		}
+		for _, elem := range pair.Value.(*object.PanArr).Elems {
+			childPairs, _, _ := mappingPairs(elem)
+			if err := encodeTable(b, append(path[:len(path):len(path)], key), childPairs, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isTable(o object.PanObject) bool {
+	_, ok, err := mappingPairs(o)
+	return ok && err == nil
+}
+
+func isTableArray(o object.PanObject) bool {
+	arr, ok := o.(*object.PanArr)
+	if !ok || len(arr.Elems) == 0 {
+		return false
+	}
+	for _, elem := range arr.Elems {
+		if !isTable(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// mappingPairs returns pairs of o in order. ok is false if o is not a mapping.
+func mappingPairs(o object.PanObject) ([]object.Pair, bool, error) {
+	switch o := o.(type) {
+	case *object.PanObj:
+		// NOTE: proto cannot be encoded
+		if o.Proto() != object.BuiltInObjObj {
+			return nil, false, unsupportedErr(o)
+		}
+
+		pairs := make([]object.Pair, 0, len(*o.Pairs))
+		for _, h := range *o.Keys {
+			pairs = append(pairs, (*o.Pairs)[h])
+		}
+		for _, h := range *o.PrivateKeys {
+			pairs = append(pairs, (*o.Pairs)[h])
+		}
+		return pairs, true, nil
+	case *object.PanMap:
+		pairs := make([]object.Pair, 0, len(*o.HashKeys)+len(*o.NonHashablePairs))
+		for _, h := range *o.HashKeys {
+			pairs = append(pairs, (*o.Pairs)[h])
+		}
+		return append(pairs, *o.NonHashablePairs...), true, nil
+	}
+	return nil, false, nil
+}
+
+func unsupportedErr(o object.PanObject) error {
+	return fmt.Errorf("%s cannot be encoded into TOML", o.Repr())
+}
+
+var bareKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func keyText(key object.PanObject) (string, error) {
+	k, ok := key.(*object.PanStr)
+	if !ok {
+		return "", fmt.Errorf("%s cannot be encoded into TOML key", key.Repr())
+	}
+
+	if bareKeyPattern.MatchString(k.Value) {
+		return k.Value, nil
+	}
+	return quote(k.Value), nil
+}
+
+// valueText returns o as an inline value.
+func valueText(o object.PanObject) (string, error) {
+	switch o := o.(type) {
+	case *object.PanNil:
+		return "", fmt.Errorf("nil cannot be encoded into TOML")
+	case *object.PanBool:
+		return strconv.FormatBool(o.Value), nil
+	case *object.PanInt:
+		return strconv.FormatInt(o.Value, 10), nil
+	case *object.PanFloat:
+		return floatText(o.Value), nil
+	case *object.PanStr:
+		return quote(o.Value), nil
+	case *object.PanArr:
+		elems := make([]string, 0, len(o.Elems))
+		for _, elem := range o.Elems {
+			s, err := valueText(elem)
+			if err != nil {
+				return "", err
+			}
+			elems = append(elems, s)
+		}
+		return "[" + strings.Join(elems, ", ") + "]", nil
+	}
+
+	pairs, ok, err := mappingPairs(o)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", unsupportedErr(o)
+	}
+
+	// inline table
+	kvs := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		key, err := keyText(pair.Key)
+		if err != nil {
+			return "", err
+		}
+		v, err := valueText(pair.Value)
+		if err != nil {
+			return "", err
+		}
+		kvs = append(kvs, key+" = "+v)
+	}
+	if len(kvs) == 0 {
+		return "{}", nil
+	}
+	return "{ " + strings.Join(kvs, ", ") + " }", nil
+}
+
+func floatText(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// quote returns s as a basic string.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if isControl(r) {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,174 @@
+package builtin
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      object.PanObject
+		expected string
+	}{
+		{"empty obj", object.EmptyPanObjPtr(), ""},
+		{
+			"scalars",
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("a"):   {Key: object.NewPanStr("a"), Value: object.BuiltInTrue},
+				object.GetSymHash("b"):   {Key: object.NewPanStr("b"), Value: object.NewPanInt(-3)},
+				object.GetSymHash("c"):   {Key: object.NewPanStr("c"), Value: object.NewPanFloat(2.0)},
+				object.GetSymHash("d"):   {Key: object.NewPanStr("d"), Value: object.NewPanFloat(math.Inf(-1))},
+				object.GetSymHash("e f"): {Key: object.NewPanStr("e f"), Value: object.NewPanStr("x\t\"y\"")},
+			}),
+			"a = true\nb = -3\nc = 2.0\nd = -inf\n\"e f\" = \"x\\t\\\"y\\\"\"\n",
+		},
+		{
+			"inline values",
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.NewPanArr(
+					object.NewPanInt(1),
+					object.NewPanArr(),
+				)},
+				object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.NewPanArr(
+					object.NewPanInt(1),
+					object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+						object.GetSymHash("c"): {Key: object.NewPanStr("c"), Value: object.NewPanInt(2)},
+					}),
+				)},
+			}),
+			"a = [1, []]\nb = [1, { c = 2 }]\n",
+		},
+		{
+			"tables",
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+					object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+						object.GetSymHash("c"): {Key: object.NewPanStr("c"), Value: object.NewPanInt(1)},
+					})},
+				})},
+				object.GetSymHash("d"): {Key: object.NewPanStr("d"), Value: object.NewPanInt(2)},
+				object.GetSymHash("e"): {Key: object.NewPanStr("e"), Value: object.EmptyPanObjPtr()},
+			}),
+			"d = 2\n\n[a.b]\nc = 1\n\n[e]\n",
+		},
+		// keys are written in order of insertion in map
+		{
+			"arrays of tables",
+			object.NewPanMap(
+				object.Pair{Key: object.NewPanStr("z"), Value: object.NewPanArr(
+					object.NewPanMap(
+						object.Pair{Key: object.NewPanStr("y"), Value: object.NewPanInt(1)},
+						object.Pair{Key: object.NewPanStr("x"), Value: object.NewPanInt(2)},
+					),
+					object.EmptyPanObjPtr(),
+				)},
+				object.Pair{Key: object.NewPanStr("w"), Value: object.NewPanInt(3)},
+			),
+			"w = 3\n\n[[z]]\ny = 1\nx = 2\n\n[[z]]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := encode(tt.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != tt.expected {
+				t.Errorf("wrong output: expected=%q, got=%q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      object.PanObject
+		expected string
+	}{
+		{
+			"arr document",
+			object.NewPanArr(object.NewPanInt(1)),
+			`[1] cannot be encoded into TOML document (only Obj and Map can be)`,
+		},
+		{
+			"nil",
+			object.NewPanMap(object.Pair{Key: object.NewPanStr("a"), Value: object.BuiltInNil}),
+			`nil cannot be encoded into TOML`,
+		},
+		// obj whose proto is not Obj
+		{
+			"child obj",
+			object.ChildPanObjPtr(object.BuiltInArrObj, object.EmptyPanObjPtr()),
+			`{} cannot be encoded into TOML`,
+		},
+		{
+			"int key",
+			object.NewPanMap(object.Pair{Key: object.NewPanInt(1), Value: object.NewPanInt(1)}),
+			`1 cannot be encoded into TOML key`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encode(tt.obj)
+			if err == nil {
+				t.Fatalf("error must be raised")
+			}
+
+			if err.Error() != tt.expected {
+				t.Errorf("wrong message: expected=%s, got=%s", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestEncodeAndDecode(t *testing.T) {
+	tests := []struct {
+		obj object.PanObject
+	}{
+		{
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.NewPanFloat(1e30)},
+				object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.NewPanStr("日本語\n\x00")},
+				object.GetSymHash("c"): {Key: object.NewPanStr("c"), Value: object.NewPanArr(
+					object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+						object.GetSymHash("d.e"): {Key: object.NewPanStr("d.e"), Value: object.NewPanArr()},
+						object.GetSymHash("f"): {Key: object.NewPanStr("f"), Value: object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+							object.GetSymHash("g"): {Key: object.NewPanStr("g"), Value: object.BuiltInFalse},
+						})},
+					}),
+				)},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.obj.Inspect(), func(t *testing.T) {
+			src, err := encode(tt.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			actual, err := newDecoder(src, false).decode()
+			if err != nil {
+				t.Fatalf("unexpected error: %s (source %q)", err, src)
+			}
+
+			if actual.Inspect() != tt.obj.Inspect() {
+				t.Errorf("wrong value: expected=%s, got=%s (source %q)", tt.obj.Inspect(), actual.Inspect(), src)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package builtin
+
+import "github.com/Syuparn/pangaea/object"
+
+func New() map[string]object.PanObject {
+	return map[string]object.PanObject{
+		"dec": object.NewPanBuiltInFunc(dec),
+		"enc": object.NewPanBuiltInFunc(enc),
+	}
+}
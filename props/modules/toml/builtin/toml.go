@@ -0,0 +1,36 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func dec(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 2 {
+		return object.NewTypeErr("dec requires at least 2 args")
+	}
+
+	src, ok := object.TraceProtoOfStr(args[0])
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("`%s` cannot be treated as str", args[0].Inspect()))
+	}
+
+	v, err := newDecoder(src.Value, args[1] == object.BuiltInTrue).decode()
+	if err != nil {
+		return object.NewValueErr(fmt.Sprintf("failed to decode TOML: %s", err))
+	}
+	return v
+}
+
+func enc(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("enc requires at least 1 arg")
+	}
+
+	s, err := encode(args[0])
+	if err != nil {
+		return object.NewTypeErr(err.Error())
+	}
+	return object.NewPanStr(s)
+}
@@ -0,0 +1,1056 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// document is a YAML document in a stream.
+type document struct {
+	lines []string
+	// firstLine is the line number of lines[0] in the stream
+	firstLine int
+}
+
+// splitDocuments splits YAML stream src into documents separated by `---`.
+func splitDocuments(src string) []*document {
+	// NOTE: the last line break does not make a new line
+	src = strings.TrimSuffix(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	lines := strings.Split(src, "\n")
+
+	docs := []*document{}
+	var cur *document
+	ended := false
+
+	for i, line := range lines {
+		switch {
+		case isDocumentMarker(line, "---"):
+			// NOTE: replace the marker with spaces so that columns of the rest are kept
+			cur = &document{lines: []string{"   " + line[3:]}, firstLine: i + 1}
+			docs = append(docs, cur)
+			ended = false
+		case isDocumentMarker(line, "..."):
+			ended = true
+		case ended || (cur == nil && strings.HasPrefix(line, "%")):
+			// directives and lines after `...` are ignored
+		case cur == nil:
+			if isBlank(line) {
+				continue
+			}
+			cur = &document{lines: []string{line}, firstLine: i + 1}
+			docs = append(docs, cur)
+		default:
+			cur.lines = append(cur.lines, line)
+		}
+	}
+
+	return docs
+}
+
+func isDocumentMarker(line string, marker string) bool {
+	return line == marker || strings.HasPrefix(line, marker+" ") || strings.HasPrefix(line, marker+"\t")
+}
+
+// decodeError is an error with the line number.
+type decodeError struct {
+	line int
+	msg  string
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.line, e.msg)
+}
+
+// decoder parses a YAML document into Pangaea objects.
+type decoder struct {
+	doc *document
+	pos int
+	// ordered is true if mappings are decoded into maps to keep the order of keys
+	ordered bool
+	anchors map[string]object.PanObject
+}
+
+func newDecoder(doc *document, ordered bool) *decoder {
+	return &decoder{doc: doc, ordered: ordered, anchors: map[string]object.PanObject{}}
+}
+
+// decode parses the whole document.
+func (d *decoder) decode() (object.PanObject, error) {
+	v, err := d.parseBlock(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.skipBlank() {
+		return nil, d.errorf("unexpected %q", strings.TrimSpace(d.line()))
+	}
+	return v, nil
+}
+
+func (d *decoder) line() string {
+	return d.doc.lines[d.pos]
+}
+
+func (d *decoder) errorf(format string, a ...interface{}) error {
+	return d.errorAt(d.pos, format, a...)
+}
+
+func (d *decoder) errorAt(pos int, format string, a ...interface{}) error {
+	return &decodeError{line: d.doc.firstLine + pos, msg: fmt.Sprintf(format, a...)}
+}
+
+// skipBlank skips blank and comment lines. It returns false if no lines are left.
+func (d *decoder) skipBlank() bool {
+	for d.pos < len(d.doc.lines) && isBlank(d.line()) {
+		d.pos++
+	}
+	return d.pos < len(d.doc.lines)
+}
+
+func isBlank(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+func (d *decoder) indent() (int, error) {
+	line := d.line()
+	i := len(line) - len(strings.TrimLeft(line, " "))
+	if i < len(line) && line[i] == '\t' {
+		return 0, d.errorf("tabs cannot be used for indentation")
+	}
+	return i, nil
+}
+
+// parseBlock parses a node indented by at least minIndent.
+func (d *decoder) parseBlock(minIndent int) (object.PanObject, error) {
+	if !d.skipBlank() {
+		return object.BuiltInNil, nil
+	}
+
+	indent, err := d.indent()
+	if err != nil {
+		return nil, err
+	}
+	if indent < minIndent {
+		return object.BuiltInNil, nil
+	}
+
+	content := d.line()[indent:]
+	if isSeqEntry(content) {
+		return d.parseSeq(indent)
+	}
+
+	if _, _, ok := d.splitMapEntry(content); ok {
+		return d.parseMap(indent)
+	}
+
+	return d.parseValue(content, minIndent-1, false)
+}
+
+func isSeqEntry(content string) bool {
+	return isDocumentMarker(content, "-")
+}
+
+func (d *decoder) parseSeq(indent int) (object.PanObject, error) {
+	elems := []object.PanObject{}
+
+	for d.skipBlank() {
+		i, err := d.indent()
+		if err != nil {
+			return nil, err
+		}
+		if i < indent {
+			break
+		}
+		if i > indent {
+			return nil, d.errorf("unexpected indentation")
+		}
+
+		content := d.line()[i:]
+		if !isSeqEntry(content) {
+			break
+		}
+
+		rest := strings.TrimLeft(content[1:], " \t")
+		if rest == "" || rest[0] == '#' {
+			d.pos++
+		} else {
+			// NOTE: replace `-` with a space so that the entry can be parsed as a nested node
+			d.doc.lines[d.pos] = strings.Repeat(" ", len(d.line())-len(rest)) + rest
+		}
+
+		elem, err := d.parseBlock(indent + 1)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+
+	return object.NewPanArr(elems...), nil
+}
+
+// pair is a key-value pair of a mapping.
+type pair struct {
+	key   string
+	value object.PanObject
+}
+
+func (d *decoder) parseMap(indent int) (object.PanObject, error) {
+	pairs := []pair{}
+	seen := map[string]bool{}
+	merged := []mergedValue{}
+
+	for d.skipBlank() {
+		i, err := d.indent()
+		if err != nil {
+			return nil, err
+		}
+		if i < indent {
+			break
+		}
+		if i > indent {
+			return nil, d.errorf("unexpected indentation")
+		}
+
+		key, rest, ok := d.splitMapEntry(d.line()[i:])
+		if !ok {
+			return nil, d.errorf("mapping entry is expected but found %q", strings.TrimSpace(d.line()))
+		}
+
+		pos := d.pos
+		v, err := d.parseValue(rest, indent, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if key == mergeKey {
+			merged = append(merged, mergedValue{value: v, pos: pos})
+			continue
+		}
+		if seen[key] {
+			return nil, d.errorAt(pos, "duplicated key %q", key)
+		}
+		seen[key] = true
+		pairs = append(pairs, pair{key: key, value: v})
+	}
+
+	// NOTE: explicit keys take precedence over merged ones
+	for _, m := range merged {
+		mergedPairs, err := d.mergedPairs(m.value, m.pos)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range mergedPairs {
+			if !seen[p.key] {
+				seen[p.key] = true
+				pairs = append(pairs, p)
+			}
+		}
+	}
+
+	return d.newMapping(pairs), nil
+}
+
+// mergeKey is a key to merge other mappings.
+// NOTE: quoted `"<<"` is a normal key
+const mergeKey = "\x00<<"
+
+// mergedValue is a value of the merge key.
+type mergedValue struct {
+	value object.PanObject
+	pos   int
+}
+
+func (d *decoder) mergedPairs(o object.PanObject, pos int) ([]pair, error) {
+	switch o := o.(type) {
+	case *object.PanObj:
+		pairs := []pair{}
+		for _, h := range append(append([]object.SymHash{}, *o.Keys...), *o.PrivateKeys...) {
+			p := (*o.Pairs)[h]
+			pairs = append(pairs, pair{key: p.Key.(*object.PanStr).Value, value: p.Value})
+		}
+		return pairs, nil
+	case *object.PanMap:
+		pairs := []pair{}
+		for _, h := range *o.HashKeys {
+			p := (*o.Pairs)[h]
+			pairs = append(pairs, pair{key: p.Key.(*object.PanStr).Value, value: p.Value})
+		}
+		return pairs, nil
+	case *object.PanArr:
+		pairs := []pair{}
+		for _, elem := range o.Elems {
+			elemPairs, err := d.mergedPairs(elem, pos)
+			if err != nil {
+				return nil, err
+			}
+			pairs = append(pairs, elemPairs...)
+		}
+		return pairs, nil
+	}
+	return nil, d.errorAt(pos, "%s cannot be merged", o.Repr())
+}
+
+func (d *decoder) newMapping(pairs []pair) object.PanObject {
+	if d.ordered {
+		mapPairs := make([]object.Pair, 0, len(pairs))
+		for _, p := range pairs {
+			mapPairs = append(mapPairs, object.Pair{Key: object.NewPanStr(p.key), Value: p.value})
+		}
+		return object.NewPanMap(mapPairs...)
+	}
+
+	objPairs := map[object.SymHash]object.Pair{}
+	for _, p := range pairs {
+		objPairs[object.GetSymHash(p.key)] = object.Pair{Key: object.NewPanStr(p.key), Value: p.value}
+	}
+	return object.PanObjInstancePtr(&objPairs)
+}
+
+// splitMapEntry splits `key: value` into key and the rest (suffix of content).
+// ok is false if content is not a mapping entry.
+func (d *decoder) splitMapEntry(content string) (string, string, bool) {
+	if content == "" {
+		return "", "", false
+	}
+
+	switch content[0] {
+	case '"', '\'':
+		p := &flowParser{d: d, src: content}
+		key, err := p.parseQuoted()
+		if err != nil {
+			// NOTE: quoted scalar may continue to the next line
+			return "", "", false
+		}
+		rest := strings.TrimLeft(content[p.pos:], " \t")
+		if !isValueIndicator(rest) {
+			return "", "", false
+		}
+		return key, rest[1:], true
+	case '[', '{', '&', '*', '!', '|', '>', '#', '%', '@', '`':
+		return "", "", false
+	}
+
+	for i := 0; i < len(content); i++ {
+		switch {
+		case content[i] == '#' && i > 0 && isSpace(content[i-1]):
+			return "", "", false
+		case isValueIndicator(content[i:]):
+			key := strings.TrimSpace(content[:i])
+			if key == "<<" {
+				key = mergeKey
+			}
+			return key, content[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// isValueIndicator returns whether s starts with `:` followed by a space (or the end of line).
+func isValueIndicator(s string) bool {
+	return strings.HasPrefix(s, ":") && (len(s) == 1 || isSpace(s[1]))
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+// parseValue parses a node which starts from rest (suffix of the current line).
+// If compactSeq is true, a sequence with the same indentation as the parent is also accepted
+// (`key:\n- a\n- b`).
+func (d *decoder) parseValue(rest string, parentIndent int, compactSeq bool) (object.PanObject, error) {
+	pos := d.pos
+	anchor, tag, rest := splitProperties(strings.TrimLeft(rest, " \t"))
+
+	v, err := d.parseValueContent(rest, parentIndent, compactSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.applyProperties(v, anchor, tag, pos)
+}
+
+func (d *decoder) parseValueContent(rest string, parentIndent int, compactSeq bool) (node, error) {
+	if rest == "" || rest[0] == '#' {
+		d.pos++
+		if !d.skipBlank() {
+			return node{value: object.BuiltInNil}, nil
+		}
+
+		indent, err := d.indent()
+		if err != nil {
+			return node{}, err
+		}
+		if compactSeq && indent == parentIndent && isSeqEntry(d.line()[indent:]) {
+			v, err := d.parseSeq(indent)
+			return node{value: v}, err
+		}
+		v, err := d.parseBlock(parentIndent + 1)
+		return node{value: v}, err
+	}
+
+	switch rest[0] {
+	case '*':
+		v, err := d.parseFlow(rest)
+		return node{value: v}, err
+	case '|', '>':
+		s, err := d.parseBlockScalar(rest, parentIndent)
+		return node{scalar: s, isScalar: true, quoted: true}, err
+	case '[', '{', '"', '\'':
+		v, err := d.parseFlow(rest)
+		return node{value: v}, err
+	}
+
+	s, err := d.parsePlain(rest, parentIndent)
+	return node{scalar: s, isScalar: true}, err
+}
+
+// node is a parsed node before resolving its type.
+type node struct {
+	value    object.PanObject
+	scalar   string
+	isScalar bool
+	quoted   bool
+}
+
+// splitProperties splits anchor (`&a`) and tag (`!!str`) from s.
+func splitProperties(s string) (string, string, string) {
+	anchor, tag := "", ""
+	for len(s) > 0 && (s[0] == '&' || s[0] == '!') {
+		end := strings.IndexAny(s, " \t\n")
+		if end < 0 {
+			end = len(s)
+		}
+		if s[0] == '&' {
+			anchor = s[1:end]
+		} else {
+			tag = s[:end]
+		}
+		s = strings.TrimLeft(s[end:], " \t")
+	}
+	return anchor, tag, s
+}
+
+// applyProperties resolves the type of n and registers the anchor.
+// pos is the line where the properties are written.
+func (d *decoder) applyProperties(n node, anchor, tag string, pos int) (object.PanObject, error) {
+	v := n.value
+	if n.isScalar {
+		var err error
+		v, err = d.resolveTagged(n.scalar, tag, n.quoted, pos)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if anchor != "" {
+		d.anchors[anchor] = v
+	}
+	return v, nil
+}
+
+func (d *decoder) resolveTagged(s string, tag string, quoted bool, pos int) (object.PanObject, error) {
+	switch tag {
+	case "":
+		if quoted {
+			return object.NewPanStr(s), nil
+		}
+		return resolvePlain(s), nil
+	case "!", "!!str":
+		return object.NewPanStr(s), nil
+	case "!!null", "!!bool", "!!int", "!!float":
+		v := resolvePlain(s)
+		if !hasTag(v, tag) {
+			return nil, d.errorAt(pos, "%q cannot be treated as %s", s, tag)
+		}
+		if i, ok := v.(*object.PanInt); ok && tag == "!!float" {
+			return object.NewPanFloat(float64(i.Value)), nil
+		}
+		return v, nil
+	}
+	// NOTE: other tags are ignored
+	return d.resolveTagged(s, "", quoted, pos)
+}
+
+func hasTag(v object.PanObject, tag string) bool {
+	switch v.(type) {
+	case *object.PanNil:
+		return tag == "!!null"
+	case *object.PanBool:
+		return tag == "!!bool"
+	case *object.PanInt:
+		// NOTE: ints can also be floats
+		return tag == "!!int" || tag == "!!float"
+	case *object.PanFloat:
+		return tag == "!!float"
+	}
+	return false
+}
+
+var (
+	intPattern   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	floatPattern = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// resolvePlain resolves a plain scalar by the YAML 1.2 core schema.
+func resolvePlain(s string) object.PanObject {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return object.BuiltInNil
+	case "true", "True", "TRUE":
+		return object.BuiltInTrue
+	case "false", "False", "FALSE":
+		return object.BuiltInFalse
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return object.NewPanFloat(math.Inf(1))
+	case "-.inf", "-.Inf", "-.INF":
+		return object.NewPanFloat(math.Inf(-1))
+	case ".nan", ".NaN", ".NAN":
+		return object.NewPanFloat(math.NaN())
+	}
+
+	switch {
+	case intPattern.MatchString(s):
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return object.NewPanInt(i)
+		}
+	case strings.HasPrefix(s, "0x"):
+		if i, err := strconv.ParseInt(s[2:], 16, 64); err == nil {
+			return object.NewPanInt(i)
+		}
+	case strings.HasPrefix(s, "0o"):
+		if i, err := strconv.ParseInt(s[2:], 8, 64); err == nil {
+			return object.NewPanInt(i)
+		}
+	}
+
+	if floatPattern.MatchString(s) {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return object.NewPanFloat(f)
+		}
+	}
+	return object.NewPanStr(s)
+}
+
+// parsePlain parses a plain scalar, which may continue to more indented lines.
+func (d *decoder) parsePlain(rest string, parentIndent int) (string, error) {
+	text, commented := stripComment(rest)
+	d.pos++
+	if commented {
+		return text, nil
+	}
+
+	// NOTE: line breaks are folded into spaces (or newlines if lines are empty)
+	for d.pos < len(d.doc.lines) {
+		next := d.pos
+		breaks := 0
+		for next < len(d.doc.lines) && strings.TrimSpace(d.doc.lines[next]) == "" {
+			next++
+			breaks++
+		}
+		if next >= len(d.doc.lines) {
+			break
+		}
+
+		line := d.doc.lines[next]
+		trimmed := strings.TrimLeft(line, " \t")
+		if len(line)-len(trimmed) <= parentIndent || trimmed[0] == '#' {
+			break
+		}
+		if _, _, ok := d.splitMapEntry(trimmed); ok {
+			return "", d.errorAt(next, "mapping entry cannot be in a plain scalar")
+		}
+
+		if breaks == 0 {
+			text += " "
+		} else {
+			text += strings.Repeat("\n", breaks)
+		}
+		cont, commented := stripComment(trimmed)
+		text += cont
+		d.pos = next + 1
+
+		if commented {
+			break
+		}
+	}
+	return text, nil
+}
+
+// stripComment removes a comment from s. It also returns whether s contained a comment.
+func stripComment(s string) (string, bool) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '#' && (i == 0 || isSpace(s[i-1])) {
+			return strings.TrimSpace(s[:i]), true
+		}
+	}
+	return strings.TrimSpace(s), false
+}
+
+// parseBlockScalar parses a literal (`|`) or folded (`>`) block scalar.
+func (d *decoder) parseBlockScalar(header string, parentIndent int) (string, error) {
+	folded := header[0] == '>'
+	chomping := byte(0)
+	indicator := 0
+
+	i := 1
+	for ; i < len(header) && !isSpace(header[i]); i++ {
+		switch c := header[i]; {
+		case c == '+' || c == '-':
+			chomping = c
+		case '1' <= c && c <= '9':
+			indicator = int(c - '0')
+		default:
+			return "", d.errorf("invalid block scalar header %q", header)
+		}
+	}
+	if rest, _ := stripComment(header[i:]); rest != "" {
+		return "", d.errorf("invalid block scalar header %q", header)
+	}
+	d.pos++
+
+	contentIndent := -1
+	if indicator > 0 {
+		contentIndent = parentIndent + indicator
+	}
+
+	lines := []string{}
+	for ; d.pos < len(d.doc.lines); d.pos++ {
+		line := d.line()
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, "")
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if contentIndent < 0 {
+			if indent <= parentIndent {
+				break
+			}
+			contentIndent = indent
+		}
+		if indent < contentIndent {
+			break
+		}
+		lines = append(lines, line[contentIndent:])
+	}
+
+	trailing := 0
+	for trailing < len(lines) && lines[len(lines)-1-trailing] == "" {
+		trailing++
+	}
+	content := lines[:len(lines)-trailing]
+
+	text := strings.Join(content, "\n")
+	if folded {
+		text = foldLines(content)
+	}
+
+	switch chomping {
+	case '-':
+		return text, nil
+	case '+':
+		if len(content) == 0 {
+			return strings.Repeat("\n", trailing), nil
+		}
+		return text + strings.Repeat("\n", trailing+1), nil
+	}
+
+	if len(content) == 0 {
+		return "", nil
+	}
+	return text + "\n", nil
+}
+
+// foldLines joins lines of a folded block scalar.
+// Line breaks are folded into spaces except for empty or more indented lines.
+func foldLines(lines []string) string {
+	var b strings.Builder
+	lastMoreIndented := false
+
+	for i, line := range lines {
+		moreIndented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+
+		switch {
+		case i == 0:
+		case line == "":
+			b.WriteString("\n")
+		case lines[i-1] == "":
+			if moreIndented || lastMoreIndented {
+				b.WriteString("\n")
+			}
+		case moreIndented || lastMoreIndented:
+			b.WriteString("\n")
+		default:
+			b.WriteString(" ")
+		}
+
+		b.WriteString(line)
+		if line != "" {
+			lastMoreIndented = moreIndented
+		}
+	}
+	return b.String()
+}
+
+// parseFlow parses a flow node (or an alias), which may continue to following lines.
+func (d *decoder) parseFlow(rest string) (object.PanObject, error) {
+	src := rest
+	if d.pos+1 < len(d.doc.lines) {
+		src += "\n" + strings.Join(d.doc.lines[d.pos+1:], "\n")
+	}
+
+	p := &flowParser{d: d, src: src}
+	v, err := p.parseNode()
+	if err != nil {
+		return nil, err
+	}
+
+	// only a comment can follow the node
+	p.skipInlineSpaces()
+	if p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '#' {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:p.lineEnd()])
+	}
+
+	d.pos += p.lines() + 1
+	return v, nil
+}
+
+// flowParser parses flow collections, quoted scalars and aliases.
+type flowParser struct {
+	d   *decoder
+	src string
+	pos int
+}
+
+func (p *flowParser) lines() int {
+	return strings.Count(p.src[:p.pos], "\n")
+}
+
+func (p *flowParser) lineEnd() int {
+	if end := strings.IndexByte(p.src[p.pos:], '\n'); end >= 0 {
+		return p.pos + end
+	}
+	return len(p.src)
+}
+
+func (p *flowParser) errorf(format string, a ...interface{}) error {
+	return p.d.errorAt(p.d.pos+p.lines(), format, a...)
+}
+
+func (p *flowParser) peek() byte {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *flowParser) skipInlineSpaces() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipSpaces skips spaces, line breaks and comments.
+func (p *flowParser) skipSpaces() {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case isSpace(c):
+			p.pos++
+		case c == '#' && (p.pos == 0 || isSpace(p.src[p.pos-1])):
+			p.pos = p.lineEnd()
+		default:
+			return
+		}
+	}
+}
+
+func (p *flowParser) parseNode() (object.PanObject, error) {
+	p.skipSpaces()
+	pos := p.d.pos + p.lines()
+
+	anchor, tag, rest := splitProperties(p.src[p.pos:])
+	p.pos = len(p.src) - len(rest)
+
+	n, err := p.parseNodeContent()
+	if err != nil {
+		return nil, err
+	}
+	return p.d.applyProperties(n, anchor, tag, pos)
+}
+
+func (p *flowParser) parseNodeContent() (node, error) {
+	switch p.peek() {
+	case '[':
+		v, err := p.parseSeq()
+		return node{value: v}, err
+	case '{':
+		v, err := p.parseMap()
+		return node{value: v}, err
+	case '"', '\'':
+		s, err := p.parseQuoted()
+		return node{scalar: s, isScalar: true, quoted: true}, err
+	case '*':
+		v, err := p.parseAlias()
+		return node{value: v}, err
+	}
+	return node{scalar: p.parsePlain(), isScalar: true}, nil
+}
+
+func (p *flowParser) parseAlias() (object.PanObject, error) {
+	start := p.pos + 1
+	for p.pos < len(p.src) && !isSpace(p.src[p.pos]) && !strings.ContainsRune(",[]{}", rune(p.src[p.pos])) {
+		p.pos++
+	}
+
+	name := p.src[start:p.pos]
+	v, ok := p.d.anchors[name]
+	if !ok {
+		return nil, p.errorf("unknown alias %q", name)
+	}
+	return v, nil
+}
+
+func (p *flowParser) parseSeq() (object.PanObject, error) {
+	// skip `[`
+	p.pos++
+	elems := []object.PanObject{}
+
+	for {
+		p.skipSpaces()
+		if p.peek() == ']' {
+			p.pos++
+			return object.NewPanArr(elems...), nil
+		}
+
+		elem, err := p.parseNode()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+
+		if err := p.parseSeparator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *flowParser) parseMap() (object.PanObject, error) {
+	// skip `{`
+	p.pos++
+	pairs := []pair{}
+	seen := map[string]bool{}
+
+	for {
+		p.skipSpaces()
+		if p.peek() == '}' {
+			p.pos++
+			return p.d.newMapping(pairs), nil
+		}
+
+		line := p.lines()
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		if seen[key] {
+			return nil, p.d.errorAt(p.d.pos+line, "duplicated key %q", key)
+		}
+		seen[key] = true
+
+		var v object.PanObject = object.BuiltInNil
+		p.skipSpaces()
+		if p.peek() == ':' {
+			p.pos++
+			p.skipSpaces()
+			if c := p.peek(); c != ',' && c != '}' {
+				v, err = p.parseNode()
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		pairs = append(pairs, pair{key: key, value: v})
+
+		if err := p.parseSeparator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *flowParser) parseKey() (string, error) {
+	if c := p.peek(); c == '"' || c == '\'' {
+		return p.parseQuoted()
+	}
+	return p.parsePlain(), nil
+}
+
+// parseSeparator parses `,` or the closing bracket (which is not consumed).
+func (p *flowParser) parseSeparator(closing byte) error {
+	p.skipSpaces()
+	switch p.peek() {
+	case ',':
+		p.pos++
+		return nil
+	case closing:
+		return nil
+	case 0:
+		return p.errorf("`%s` is not closed", string(closing))
+	}
+	return p.errorf("`,` or `%s` is expected but found %q", string(closing), string(p.peek()))
+}
+
+// parsePlain parses a plain scalar in flow context.
+func (p *flowParser) parsePlain() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if strings.ContainsRune(",[]{}", rune(c)) || isValueIndicator(p.src[p.pos:]) ||
+			(c == ':' && p.pos+1 < len(p.src) && strings.ContainsRune(",[]{}", rune(p.src[p.pos+1]))) ||
+			(c == '#' && p.pos > start && isSpace(p.src[p.pos-1])) {
+			break
+		}
+		p.pos++
+	}
+
+	return foldFlowLines(p.src[start:p.pos])
+}
+
+// foldFlowLines folds line breaks in flow scalars into spaces (or newlines if lines are empty).
+func foldFlowLines(s string) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) == 1 {
+		return strings.TrimSpace(s)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(lines[0], " \t"))
+	breaks := 0
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			breaks++
+			continue
+		}
+
+		if breaks == 0 {
+			b.WriteString(" ")
+		} else {
+			b.WriteString(strings.Repeat("\n", breaks))
+		}
+		b.WriteString(line)
+		breaks = 0
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func (p *flowParser) parseQuoted() (string, error) {
+	quote := p.src[p.pos]
+	p.pos++
+
+	var b []byte
+	// length of b except trailing raw spaces, which are trimmed before line breaks
+	kept := 0
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == quote && quote == '\'' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '\'':
+			b = append(b, '\'')
+			p.pos += 2
+			kept = len(b)
+		case c == quote:
+			p.pos++
+			return string(b), nil
+		case c == '\\' && quote == '"':
+			s, err := p.parseEscape()
+			if err != nil {
+				return "", err
+			}
+			b = append(b, s...)
+			kept = len(b)
+		case c == '\n':
+			b = append(b[:kept], p.foldQuotedLines()...)
+			kept = len(b)
+		default:
+			b = append(b, c)
+			p.pos++
+			if c != ' ' && c != '\t' {
+				kept = len(b)
+			}
+		}
+	}
+	return "", p.errorf("quoted scalar is not closed")
+}
+
+// foldQuotedLines folds raw line breaks and indents in quoted scalars.
+// NOTE: escaped characters are decoded by the caller and never folded
+func (p *flowParser) foldQuotedLines() string {
+	breaks := 0
+	for ; p.pos < len(p.src); p.pos++ {
+		c := p.src[p.pos]
+		if c == '\n' {
+			breaks++
+		} else if c != ' ' && c != '\t' {
+			break
+		}
+	}
+
+	if breaks == 1 {
+		return " "
+	}
+	return strings.Repeat("\n", breaks-1)
+}
+
+var escapes = map[byte]string{
+	'0': "\x00", 'a': "\a", 'b': "\b", 't': "\t", '\t': "\t", 'n': "\n", 'v': "\v", 'f': "\f",
+	'r': "\r", 'e': "\x1b", ' ': " ", '"': "\"", '/': "/", '\\': "\\",
+	'N': "\u0085", '_': "\u00a0", 'L': "\u2028", 'P': "\u2029",
+}
+
+func (p *flowParser) parseEscape() (string, error) {
+	// skip `\`
+	p.pos++
+	if p.pos >= len(p.src) {
+		return "", p.errorf("quoted scalar is not closed")
+	}
+
+	c := p.src[p.pos]
+	p.pos++
+
+	if s, ok := escapes[c]; ok {
+		return s, nil
+	}
+
+	switch c {
+	case '\n':
+		// escaped line break is removed with following spaces
+		p.skipInlineSpaces()
+		return "", nil
+	case 'x':
+		return p.parseHexEscape(2)
+	case 'u':
+		return p.parseHexEscape(4)
+	case 'U':
+		return p.parseHexEscape(8)
+	}
+	return "", p.errorf("invalid escape sequence %q", "\\"+string(c))
+}
+
+func (p *flowParser) parseHexEscape(digits int) (string, error) {
+	if p.pos+digits > len(p.src) {
+		return "", p.errorf("invalid escape sequence")
+	}
+
+	hex := p.src[p.pos : p.pos+digits]
+	r, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", p.errorf("invalid escape sequence %q", hex)
+	}
+	p.pos += digits
+	return string(rune(r)), nil
+}
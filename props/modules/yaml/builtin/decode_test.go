@@ -0,0 +1,255 @@
+package builtin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"empty", ``, `nil`},
+		{"null", `~`, `nil`},
+		{"bool", `True`, `true`},
+		{"int", `-12`, `-12`},
+		{"hex", `0x1f`, `31`},
+		{"octal", `0o17`, `15`},
+		{"float", `1.5`, `1.500000`},
+		{"exponent", `1e3`, `1000.000000`},
+		{"inf", `-.inf`, `-Inf`},
+		{"plain str", `foo bar`, `"foo bar"`},
+		{"str with colon", `http://example.com`, `"http://example.com"`},
+		{"single-quoted", `'it''s'`, `"it's"`},
+		{"double-quoted", `"a\tb\u3042"`, `"a	bあ"`},
+		{"quoted number", `"1"`, `"1"`},
+		{"tagged str", `!!str 1`, `"1"`},
+		{"tagged float", `!!float 1`, `1.000000`},
+		{"comment", "# comment\nfoo # bar\n", `"foo"`},
+		{
+			"mapping",
+			"a: 1\nb:\n  c: x\n  d:\n",
+			`{"a": 1, "b": {"c": "x", "d": nil}}`,
+		},
+		{
+			"sequence",
+			"- 1\n-\n  - a\n  - b\n- c: 1\n  d: 2\n- - x\n",
+			`[1, ["a", "b"], {"c": 1, "d": 2}, ["x"]]`,
+		},
+		{
+			"compact sequence in mapping",
+			"a:\n- 1\n- 2\nb: 3\n",
+			`{"a": [1, 2], "b": 3}`,
+		},
+		{
+			"flow collections",
+			"{a: [1, 'x', {b: null}], c: d e,\n  f: []}",
+			`{"a": [1, "x", {"b": nil}], "c": "d e", "f": []}`,
+		},
+		{"flow mapping without values", `{a, b: 1}`, `{"a": nil, "b": 1}`},
+		{
+			"multi-line plain scalar",
+			"a: foo\n  bar\n\n  baz\n",
+			"{\"a\": \"foo bar\nbaz\"}",
+		},
+		{
+			"multi-line quoted scalar",
+			"a: \"foo\n  bar\\\n  baz\"\n",
+			`{"a": "foo barbaz"}`,
+		},
+		{
+			"escaped line break in quoted scalar",
+			"a: \"a\\nb\"",
+			"{\"a\": \"a\nb\"}",
+		},
+		{
+			"escaped tab before line break in quoted scalar",
+			"a: \"a\\t\n  b\"",
+			"{\"a\": \"a\t b\"}",
+		},
+		{
+			"escaped tab after line break in quoted scalar",
+			"a: \"a\n  \\tb\"",
+			"{\"a\": \"a \tb\"}",
+		},
+		{
+			"escaped tab in quoted scalar",
+			"a: \"a\\tb\"",
+			"{\"a\": \"a\tb\"}",
+		},
+		{
+			"escaped line continuation in quoted scalar",
+			"a: \"a \\\n  b\"",
+			`{"a": "a b"}`,
+		},
+		{
+			"empty lines in quoted scalar",
+			"a: 'a  \n\n  \n  b'",
+			"{\"a\": \"a\n\nb\"}",
+		},
+		{
+			"literal block scalar",
+			"a: |\n  x\n   y\n\nb: 1\n",
+			"{\"a\": \"x\n y\n\", \"b\": 1}",
+		},
+		{
+			"literal block scalar (strip)",
+			"a: |-\n  x\n\n",
+			`{"a": "x"}`,
+		},
+		{
+			"literal block scalar (keep)",
+			"a: |+\n  x\n\n",
+			"{\"a\": \"x\n\n\"}",
+		},
+		{
+			"folded block scalar",
+			"- >\n  a\n  b\n\n  c\n    d\n  e\n",
+			"[\"a b\nc\n  d\ne\n\"]",
+		},
+		{
+			"anchors and aliases",
+			"a: &x [1, 2]\nb: *x\n",
+			`{"a": [1, 2], "b": [1, 2]}`,
+		},
+		{
+			"merge keys",
+			"base: &base\n  a: 1\n  b: 2\nchild:\n  <<: *base\n  b: 3\n",
+			`{"base": {"a": 1, "b": 2}, "child": {"a": 1, "b": 3}}`,
+		},
+		{"quoted keys", `"a b": 1`, `{"a b": 1}`},
+		{"document marker", "--- |\n  foo\n", "\"foo\n\""},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			docs := splitDocuments(tt.src)
+			if len(docs) == 0 {
+				if tt.expected != `nil` {
+					t.Fatalf("no documents found")
+				}
+				return
+			}
+
+			actual, err := newDecoder(docs[0], false).decode()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual.Inspect() != tt.expected {
+				t.Errorf("wrong value: expected=%s, got=%s", tt.expected, actual.Inspect())
+			}
+		})
+	}
+}
+
+func TestDecodeOrdered(t *testing.T) {
+	docs := splitDocuments("b: 1\na:\n  d: 2\n  c: 3\n")
+	actual, err := newDecoder(docs[0], true).decode()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	m, ok := actual.(*object.PanMap)
+	if !ok {
+		t.Fatalf("actual must be map. got=%T", actual)
+	}
+
+	keys := []string{}
+	for _, pair := range mappingPairsOf(t, m) {
+		keys = append(keys, pair.Key.Inspect())
+	}
+	for _, pair := range mappingPairsOf(t, (*m.Pairs)[object.NewPanStr("a").Hash()].Value) {
+		keys = append(keys, pair.Key.Inspect())
+	}
+
+	expected := `["b" "a" "d" "c"]`
+	if actual := fmt.Sprint(keys); actual != expected {
+		t.Errorf("wrong keys: expected=%s, got=%s", expected, actual)
+	}
+}
+
+func mappingPairsOf(t *testing.T, o object.PanObject) []object.Pair {
+	pairs, ok, err := mappingPairs(o)
+	if !ok || err != nil {
+		t.Fatalf("%s must be mapping", o.Inspect())
+	}
+	return pairs
+}
+
+func TestDecodeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"tab indentation", "a:\n\tb: 1", `line 2: tabs cannot be used for indentation`},
+		{"unexpected indentation", "a: 1\n b: 2", `line 2: mapping entry cannot be in a plain scalar`},
+		{"unexpected indentation in mapping", "a:\n  b: 1\n   c: 2", `line 3: mapping entry cannot be in a plain scalar`},
+		{"unexpected indentation after sequence", "a:\n  - 1\n - 2", `line 3: unexpected indentation`},
+		{"no mapping entry", "a: 1\nb", `line 2: mapping entry is expected but found "b"`},
+		{"duplicated key", "a: 1\nb: 2\na: 3", `line 3: duplicated key "a"`},
+		{"duplicated key in flow", "{a: 1,\n a: 2}", `line 2: duplicated key "a"`},
+		{"unclosed flow", "a: [1, 2\nb: 3", "line 2: `,` or `]` is expected but found \":\""},
+		{"unclosed bracket", "a: {b: 1", "line 1: `}` is not closed"},
+		{"unclosed quote", "a: 'b", `line 1: quoted scalar is not closed`},
+		{"content after flow", "a: [1] 2", `line 1: unexpected "2"`},
+		{"unknown alias", "a: *b", `line 1: unknown alias "b"`},
+		{"invalid tag", "a: !!int x", `line 1: "x" cannot be treated as !!int`},
+		{"invalid escape", `a: "\q"`, `line 1: invalid escape sequence "\\q"`},
+		{"invalid block scalar", "a: |x\n  b", `line 1: invalid block scalar header "|x"`},
+		{"invalid merge", "a:\n  <<: 1", `line 2: 1 cannot be merged`},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newDecoder(splitDocuments(tt.src)[0], false).decode()
+			if err == nil {
+				t.Fatalf("error must be raised")
+			}
+
+			if err.Error() != tt.expected {
+				t.Errorf("wrong message: expected=%s, got=%s", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestSplitDocuments(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        string
+		firstLines []int
+	}{
+		{"empty", "", []int{}},
+		{"comments only", "# a\n\n", []int{}},
+		{"implicit document", "a: 1\n", []int{1}},
+		{"explicit documents", "%YAML 1.2\n---\na: 1\n---\nb: 2\n", []int{2, 4}},
+		{"document end", "a: 1\n...\n# end\n--- 2\n", []int{1, 4}},
+		{"empty document", "---\n---\n", []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			docs := splitDocuments(tt.src)
+			firstLines := []int{}
+			for _, doc := range docs {
+				firstLines = append(firstLines, doc.firstLine)
+			}
+
+			if fmt.Sprint(firstLines) != fmt.Sprint(tt.firstLines) {
+				t.Errorf("wrong documents: expected=%v, got=%v", tt.firstLines, firstLines)
+			}
+		})
+	}
+}
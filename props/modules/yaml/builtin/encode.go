@@ -0,0 +1,252 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// encode serializes o into a YAML document.
+// Keys are written in the order of o (sorted in Obj and inserted in Map).
+func encode(o object.PanObject) (string, error) {
+	var b strings.Builder
+	if err := encodeNode(&b, o, 0, false); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// encodeNode writes o indented by indent.
+// If inline is true, indentation of the first line has already been written.
+func encodeNode(b *strings.Builder, o object.PanObject, indent int, inline bool) error {
+	pairs, isMapping, err := mappingPairs(o)
+	if err != nil {
+		return err
+	}
+	if isMapping && len(pairs) > 0 {
+		return encodeMapping(b, pairs, indent, inline)
+	}
+
+	if arr, ok := o.(*object.PanArr); ok && len(arr.Elems) > 0 {
+		return encodeSeq(b, arr, indent, inline)
+	}
+
+	s, err := scalarText(o, indent)
+	if err != nil {
+		return err
+	}
+	b.WriteString(s + "\n")
+	return nil
+}
+
+func encodeSeq(b *strings.Builder, arr *object.PanArr, indent int, inline bool) error {
+	for i, elem := range arr.Elems {
+		if i > 0 || !inline {
+			b.WriteString(strings.Repeat(" ", indent))
+		}
+		b.WriteString("- ")
+
+		if err := encodeNode(b, elem, indent+2, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeMapping(b *strings.Builder, pairs []object.Pair, indent int, inline bool) error {
+	for i, pair := range pairs {
+		if i > 0 || !inline {
+			b.WriteString(strings.Repeat(" ", indent))
+		}
+
+		key, err := keyText(pair.Key)
+		if err != nil {
+			return err
+		}
+		b.WriteString(key + ":")
+
+		if isBlockCollection(pair.Value) {
+			b.WriteString("\n")
+			if err := encodeNode(b, pair.Value, indent+2, false); err != nil {
+				return err
+			}
+			continue
+		}
+
+		b.WriteString(" ")
+		if err := encodeNode(b, pair.Value, indent, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBlockCollection returns whether o is written in block style.
+func isBlockCollection(o object.PanObject) bool {
+	if arr, ok := o.(*object.PanArr); ok {
+		return len(arr.Elems) > 0
+	}
+	pairs, ok, _ := mappingPairs(o)
+	return ok && len(pairs) > 0
+}
+
+// mappingPairs returns pairs of o in order. ok is false if o is not a mapping.
+func mappingPairs(o object.PanObject) ([]object.Pair, bool, error) {
+	switch o := o.(type) {
+	case *object.PanObj:
+		// NOTE: proto cannot be encoded
+		if o.Proto() != object.BuiltInObjObj {
+			return nil, false, unsupportedErr(o)
+		}
+
+		pairs := make([]object.Pair, 0, len(*o.Pairs))
+		for _, h := range *o.Keys {
+			pairs = append(pairs, (*o.Pairs)[h])
+		}
+		for _, h := range *o.PrivateKeys {
+			pairs = append(pairs, (*o.Pairs)[h])
+		}
+		return pairs, true, nil
+	case *object.PanMap:
+		pairs := make([]object.Pair, 0, len(*o.HashKeys)+len(*o.NonHashablePairs))
+		for _, h := range *o.HashKeys {
+			pairs = append(pairs, (*o.Pairs)[h])
+		}
+		return append(pairs, *o.NonHashablePairs...), true, nil
+	}
+	return nil, false, nil
+}
+
+func unsupportedErr(o object.PanObject) error {
+	return fmt.Errorf("%s cannot be encoded into YAML", o.Repr())
+}
+
+func keyText(key object.PanObject) (string, error) {
+	switch key := key.(type) {
+	case *object.PanStr:
+		return strText(key.Value), nil
+	case *object.PanNil, *object.PanBool, *object.PanInt, *object.PanFloat:
+		return scalarText(key, 0)
+	}
+	return "", fmt.Errorf("%s cannot be encoded into YAML key", key.Repr())
+}
+
+// scalarText returns text of scalar (or empty collection) o.
+// Multi-line strings are written as literal block scalars indented by indent+2.
+func scalarText(o object.PanObject, indent int) (string, error) {
+	switch o := o.(type) {
+	case *object.PanNil:
+		return "null", nil
+	case *object.PanBool:
+		return strconv.FormatBool(o.Value), nil
+	case *object.PanInt:
+		return strconv.FormatInt(o.Value, 10), nil
+	case *object.PanFloat:
+		return floatText(o.Value), nil
+	case *object.PanStr:
+		if block, ok := literalBlock(o.Value, indent+2); ok {
+			return block, nil
+		}
+		return strText(o.Value), nil
+	case *object.PanArr:
+		return "[]", nil
+	}
+
+	if _, ok, err := mappingPairs(o); ok || err != nil {
+		return "{}", err
+	}
+	return "", unsupportedErr(o)
+}
+
+func floatText(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return ".inf"
+	case math.IsInf(f, -1):
+		return "-.inf"
+	case math.IsNaN(f):
+		return ".nan"
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// strText returns s as a plain scalar if possible, otherwise a double-quoted scalar.
+func strText(s string) string {
+	if isPlainSafe(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+func isPlainSafe(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return false
+	}
+	// NOTE: strings which look like other types must be quoted
+	if _, ok := resolvePlain(s).(*object.PanStr); !ok {
+		return false
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+	// NOTE: `...` is a document end marker
+	if strings.HasPrefix(s, "...") {
+		return false
+	}
+
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f || r == '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// literalBlock returns s as a literal block scalar (`|`) if s has multiple lines.
+func literalBlock(s string, indent int) (string, bool) {
+	body := strings.TrimRight(s, "\n")
+	if body == "" || !strings.Contains(body, "\n") || strings.HasPrefix(body, " ") {
+		return "", false
+	}
+
+	lines := strings.Split(body, "\n")
+	for _, line := range lines {
+		if strings.TrimRight(line, " \t") != line {
+			return "", false
+		}
+		for _, r := range line {
+			if r < 0x20 || r == 0x7f {
+				return "", false
+			}
+		}
+	}
+
+	header := "|"
+	switch len(s) - len(body) {
+	case 0:
+		header = "|-"
+	case 1:
+	default:
+		header = "|+"
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	for _, line := range lines {
+		b.WriteString("\n")
+		if line != "" {
+			b.WriteString(strings.Repeat(" ", indent) + line)
+		}
+	}
+	// NOTE: kept trailing line breaks (the last one is written by the caller)
+	b.WriteString(strings.Repeat("\n", max(len(s)-len(body)-1, 0)))
+	return b.String(), true
+}
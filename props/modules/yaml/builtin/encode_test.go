@@ -0,0 +1,172 @@
+package builtin
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      object.PanObject
+		expected string
+	}{
+		{"nil", object.BuiltInNil, "null\n"},
+		{"true", object.BuiltInTrue, "true\n"},
+		{"int", object.NewPanInt(-3), "-3\n"},
+		{"float", object.NewPanFloat(1.5), "1.5\n"},
+		{"integral float", object.NewPanFloat(2.0), "2.0\n"},
+		{"inf", object.NewPanFloat(math.Inf(-1)), "-.inf\n"},
+		{"nan", object.NewPanFloat(math.NaN()), ".nan\n"},
+		{"plain str", object.NewPanStr("foo bar"), "foo bar\n"},
+		{"empty str", object.NewPanStr(""), "\"\"\n"},
+		// strings which look like other types are quoted
+		{"str like int", object.NewPanStr("1"), "\"1\"\n"},
+		{"str like null", object.NewPanStr("null"), "\"null\"\n"},
+		{"str with indicator", object.NewPanStr("- a"), "\"- a\"\n"},
+		{"str with colon", object.NewPanStr("a: b"), "\"a: b\"\n"},
+		{"str with tab", object.NewPanStr("a\tb"), "\"a\\tb\"\n"},
+		{"multi-line str", object.NewPanStr("a\nb\n"), "|\n  a\n  b\n"},
+		{"multi-line str without last line break", object.NewPanStr("a\n\nb"), "|-\n  a\n\n  b\n"},
+		{"multi-line str with line breaks", object.NewPanStr("a\nb\n\n"), "|+\n  a\n  b\n\n"},
+		{"multi-line str with leading spaces", object.NewPanStr(" a\nb"), "\" a\\nb\"\n"},
+		{"empty arr", object.NewPanArr(), "[]\n"},
+		{"empty obj", object.EmptyPanObjPtr(), "{}\n"},
+		{
+			"arr",
+			object.NewPanArr(
+				object.NewPanInt(1),
+				object.NewPanArr(object.NewPanStr("a"), object.NewPanStr("b")),
+				object.NewPanArr(),
+			),
+			"- 1\n- - a\n  - b\n- []\n",
+		},
+		// keys are sorted in obj
+		{
+			"obj",
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("b"):   {Key: object.NewPanStr("b"), Value: object.NewPanArr(object.NewPanInt(1))},
+				object.GetSymHash("a"):   {Key: object.NewPanStr("a"), Value: object.NewPanStr("x\ny")},
+				object.GetSymHash("c d"): {Key: object.NewPanStr("c d"), Value: object.EmptyPanObjPtr()},
+			}),
+			"a: |-\n  x\n  y\nb:\n  - 1\nc d: {}\n",
+		},
+		// keys are written in order of insertion in map
+		{
+			"map",
+			object.NewPanMap(
+				object.Pair{Key: object.NewPanStr("z"), Value: object.NewPanArr(
+					object.NewPanMap(
+						object.Pair{Key: object.NewPanStr("y"), Value: object.NewPanInt(1)},
+						object.Pair{Key: object.NewPanStr("x"), Value: object.NewPanInt(2)},
+					),
+				)},
+				object.Pair{Key: object.NewPanInt(1), Value: object.BuiltInNil},
+			),
+			"z:\n  - y: 1\n    x: 2\n1: null\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := encode(tt.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != tt.expected {
+				t.Errorf("wrong output: expected=%q, got=%q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      object.PanObject
+		expected string
+	}{
+		{
+			"range",
+			object.NewPanArr(object.NewPanRange(object.NewPanInt(1), object.BuiltInNil, object.BuiltInNil)),
+			`(1:nil:nil) cannot be encoded into YAML`,
+		},
+		// obj whose proto is not Obj
+		{
+			"child obj",
+			object.ChildPanObjPtr(object.BuiltInArrObj, object.EmptyPanObjPtr()),
+			`{} cannot be encoded into YAML`,
+		},
+		{
+			"arr key",
+			object.NewPanMap(object.Pair{Key: object.NewPanArr(), Value: object.NewPanInt(1)}),
+			`[] cannot be encoded into YAML key`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encode(tt.obj)
+			if err == nil {
+				t.Fatalf("error must be raised")
+			}
+
+			if err.Error() != tt.expected {
+				t.Errorf("wrong message: expected=%s, got=%s", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestEncodeAndDecode(t *testing.T) {
+	tests := []struct {
+		obj object.PanObject
+	}{
+		{object.NewPanFloat(1e30)},
+		{object.NewPanStr("日本語")},
+		{object.NewPanStr("...")},
+		{object.NewPanStr("a # b")},
+		{object.NewPanStr("\x00\x1b")},
+		{object.NewPanStr("line\n  indented\n\n")},
+		{object.NewPanArr(object.NewPanArr(object.NewPanInt(1)), object.BuiltInNil, object.NewPanStr("0x10"))},
+		{
+			object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+				object.GetSymHash("a"): {Key: object.NewPanStr("a"), Value: object.NewPanArr(
+					object.NewPanStr("x\ny"),
+					object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+						object.GetSymHash("b"): {Key: object.NewPanStr("b"), Value: object.NewPanFloat(1.0)},
+						object.GetSymHash("c"): {Key: object.NewPanStr("c"), Value: object.NewPanStr("y: z")},
+					}),
+				)},
+				object.GetSymHash("true"): {Key: object.NewPanStr("true"), Value: object.NewPanInt(1)},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.obj.Inspect(), func(t *testing.T) {
+			src, err := encode(tt.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			actual, err := newDecoder(splitDocuments(src)[0], false).decode()
+			if err != nil {
+				t.Fatalf("unexpected error: %s (source %q)", err, src)
+			}
+
+			if actual.Inspect() != tt.obj.Inspect() {
+				t.Errorf("wrong value: expected=%s, got=%s (source %q)", tt.obj.Inspect(), actual.Inspect(), src)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package builtin
+
+import "github.com/Syuparn/pangaea/object"
+
+func New() map[string]object.PanObject {
+	return map[string]object.PanObject{
+		"dec":    object.NewPanBuiltInFunc(dec),
+		"decAll": object.NewPanBuiltInFunc(decAll),
+		"enc":    object.NewPanBuiltInFunc(enc),
+		"encAll": object.NewPanBuiltInFunc(encAll),
+	}
+}
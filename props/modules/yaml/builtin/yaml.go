@@ -0,0 +1,95 @@
+package builtin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+func dec(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 2 {
+		return object.NewTypeErr("dec requires at least 2 args")
+	}
+
+	src, ok := object.TraceProtoOfStr(args[0])
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("`%s` cannot be treated as str", args[0].Inspect()))
+	}
+
+	docs := splitDocuments(src.Value)
+	switch len(docs) {
+	case 0:
+		return object.BuiltInNil
+	case 1:
+		return decodeDocument(docs[0], args[1] == object.BuiltInTrue)
+	}
+	return object.NewValueErr(
+		fmt.Sprintf("failed to decode YAML: %d documents are found (use decAll instead)", len(docs)))
+}
+
+func decAll(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 2 {
+		return object.NewTypeErr("decAll requires at least 2 args")
+	}
+
+	src, ok := object.TraceProtoOfStr(args[0])
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("`%s` cannot be treated as str", args[0].Inspect()))
+	}
+
+	docs := splitDocuments(src.Value)
+	ordered := args[1] == object.BuiltInTrue
+	i := 0
+
+	// NOTE: each document is decoded lazily
+	next := func(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+		if i >= len(docs) {
+			return object.NewStopIterErr("iter stopped")
+		}
+		i++
+		return decodeDocument(docs[i-1], ordered)
+	}
+	return object.NewPanBuiltInIter(next, env)
+}
+
+func decodeDocument(doc *document, ordered bool) object.PanObject {
+	v, err := newDecoder(doc, ordered).decode()
+	if err != nil {
+		return object.NewValueErr(fmt.Sprintf("failed to decode YAML: %s", err))
+	}
+	return v
+}
+
+func enc(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("enc requires at least 1 arg")
+	}
+
+	s, err := encode(args[0])
+	if err != nil {
+		return object.NewTypeErr(err.Error())
+	}
+	return object.NewPanStr(s)
+}
+
+func encAll(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("encAll requires at least 1 arg")
+	}
+
+	arr, ok := object.TraceProtoOfArr(args[0])
+	if !ok {
+		return object.NewTypeErr(fmt.Sprintf("`%s` cannot be treated as arr", args[0].Inspect()))
+	}
+
+	var b strings.Builder
+	for _, elem := range arr.Elems {
+		s, err := encode(elem)
+		if err != nil {
+			return object.NewTypeErr(err.Error())
+		}
+		b.WriteString("---\n" + s)
+	}
+	return object.NewPanStr(b.String())
+}
@@ -11,6 +11,7 @@ func NameErrProps(propContainer map[string]object.PanObject) map[string]object.P
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("NameErr"),
+		// new returns a new NameErr whose message is the arg.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
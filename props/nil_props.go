@@ -9,6 +9,7 @@ import (
 func NilProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// == returns whether other is nil.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -27,6 +28,7 @@ func NilProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInTrue
 			},
 		),
+		// + returns other (nil works as zero).
 		"+": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -38,6 +40,7 @@ func NilProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return args[1]
 			},
 		),
+		// - returns negated other (nil works as zero).
 		"-": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -54,6 +57,7 @@ func NilProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				)
 			},
 		),
+		// * returns other (nil works as one).
 		"*": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -65,6 +69,7 @@ func NilProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return args[1]
 			},
 		),
+		// / returns reciprocal of other (nil works as one).
 		"/": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -81,6 +86,7 @@ func NilProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				)
 			},
 		),
+		// // returns floor division of one by other (nil works as one).
 		"//": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -98,6 +104,7 @@ func NilProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		"_name": object.NewPanStr("Nil"),
+		// B returns false.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -112,6 +119,7 @@ func NilProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInFalse
 			},
 		),
+		// new returns nil.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
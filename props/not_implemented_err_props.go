@@ -11,6 +11,7 @@ func NotImplementedErrProps(propContainer map[string]object.PanObject) map[strin
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("NotImplementedErr"),
+		// new returns a new NotImplementedErr whose message is the arg.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
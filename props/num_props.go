@@ -13,6 +13,7 @@ func NumProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("Num"),
+		// ceil returns the least int greater than or equal to self.
 		"ceil": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -33,6 +34,7 @@ func NumProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 					args[0].Repr()))
 			},
 		),
+		// F converts self into float.
 		"F": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -53,6 +55,7 @@ func NumProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 					args[0].Repr()))
 			},
 		),
+		// floor returns the greatest int less than or equal to self.
 		"floor": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -73,6 +76,9 @@ func NumProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 					args[0].Repr()))
 			},
 		),
+		// round returns the nearest int of self (halves are rounded away from zero).
+		// If `digits` is specified, self is rounded to the digits after the decimal point
+		// (float if digits is positive). `half` changes the rounding mode of halves ("up", "even" or "down").
 		"round": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -81,12 +87,46 @@ func NumProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 					return object.NewTypeErr("Num#round requires at least 1 arg")
 				}
 
+				digits := int64(0)
+				if pair, ok := propIn(kwargs, "digits"); ok {
+					i, ok := object.TraceProtoOfInt(pair.Value)
+					if !ok {
+						return object.NewTypeErr(
+							fmt.Sprintf("%s cannot be treated as int", pair.Value.Repr()))
+					}
+					digits = i.Value
+				}
+
+				half := "up"
+				if pair, ok := propIn(kwargs, "half"); ok {
+					s, ok := object.TraceProtoOfStr(pair.Value)
+					if !ok || (s.Value != "up" && s.Value != "even" && s.Value != "down") {
+						return object.NewValueErr(
+							fmt.Sprintf(`half %s must be "up", "even" or "down"`, pair.Value.Repr()))
+					}
+					half = s.Value
+				}
+
 				if i, ok := object.TraceProtoOfInt(args[0]); ok {
-					return i
+					if digits >= 0 {
+						return i
+					}
+					return object.NewPanInt(roundIntHalf(i.Value, -digits, half))
 				}
 
 				if f, ok := object.TraceProtoOfFloat(args[0]); ok {
-					return object.NewPanInt(int64(math.Round(f.Value)))
+					if digits > 0 {
+						return roundFloatDigits(f, digits, half)
+					}
+
+					rounded := roundFloatHalf(f.Value, -digits, half)
+					// NOTE: float out of the range of int64 cannot be converted
+					if math.IsNaN(rounded) || rounded >= math.MaxInt64 || rounded < math.MinInt64 {
+						// NOTE: %g is used because Repr of large float is too long
+						return object.NewValueErr(
+							fmt.Sprintf("%g cannot be rounded into int", f.Value))
+					}
+					return object.NewPanInt(int64(rounded))
 				}
 
 				return object.NewTypeErr(fmt.Sprintf("%s cannot be treated as num",
@@ -95,3 +135,63 @@ func NumProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 		),
 	}
 }
+
+// roundHalf rounds x to the nearest int. half decides the direction of halves.
+func roundHalf(x float64, half string) float64 {
+	switch half {
+	case "even":
+		return math.RoundToEven(x)
+	case "down":
+		if math.Abs(x-math.Trunc(x)) == 0.5 {
+			return math.Trunc(x)
+		}
+	}
+	return math.Round(x)
+}
+
+// roundFloatDigits rounds f to the digits after the decimal point.
+func roundFloatDigits(f *object.PanFloat, digits int64, half string) *object.PanFloat {
+	scale := math.Pow10(int(digits))
+	scaled := f.Value * scale
+	// NOTE: f has no digits to be rounded if digits exceed the precision of float
+	// (otherwise scale or scaled overflows)
+	if math.IsInf(scaled, 0) || math.IsNaN(scaled) || math.Abs(scaled) >= 1<<53 {
+		return f
+	}
+	return object.NewPanFloat(roundHalf(scaled, half) / scale)
+}
+
+// roundFloatHalf rounds x to a multiple of 10 ** exp. half decides the direction of halves.
+func roundFloatHalf(x float64, exp int64, half string) float64 {
+	unit := math.Pow10(int(exp))
+	// NOTE: all digits are rounded off if unit overflows
+	if math.IsInf(unit, 0) {
+		return 0
+	}
+	return roundHalf(x/unit, half) * unit
+}
+
+// roundIntHalf rounds i to a multiple of 10 ** exp. half decides the direction of halves.
+func roundIntHalf(i, exp int64, half string) int64 {
+	// NOTE: 10 ** 19 overflows int64
+	if exp > 18 {
+		return 0
+	}
+
+	unit := int64(math.Pow10(int(exp)))
+	q, r := i/unit, i%unit
+	sign := int64(1)
+	if i < 0 {
+		sign, r = -1, -r
+	}
+
+	switch {
+	case 2*r > unit:
+		q += sign
+	case 2*r == unit && half == "up":
+		q += sign
+	case 2*r == unit && half == "even" && q%2 != 0:
+		q += sign
+	}
+	return q * unit
+}
@@ -0,0 +1,507 @@
+package props
+
+import (
+	"fmt"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// deepOps handles nested Obj, Arr and Map (collections) along paths.
+type deepOps struct {
+	propContainer map[string]object.PanObject
+	env           *object.Env
+}
+
+func newDeepOps(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+) *deepOps {
+	return &deepOps{propContainer: propContainer, env: env}
+}
+
+func pathElems(o object.PanObject) ([]object.PanObject, *object.PanErr) {
+	path, ok := object.TraceProtoOfArr(o)
+	if !ok {
+		return nil, object.NewTypeErr(fmt.Sprintf("path %s cannot be treated as arr", o.Repr()))
+	}
+	return path.Elems, nil
+}
+
+// defaultValue returns kwarg `default` (or nil if not passed).
+func defaultValue(kwargs *object.PanObj) object.PanObject {
+	if pair, ok := propIn(kwargs, "default"); ok {
+		return pair.Value
+	}
+	return object.BuiltInNil
+}
+
+// getIn returns the value at path (or ok=false if not found).
+func (d *deepOps) getIn(o object.PanObject, path []object.PanObject) (object.PanObject, bool, *object.PanErr) {
+	for _, key := range path {
+		child, ok, err := d.child(o, key)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		o = child
+	}
+	return o, true, nil
+}
+
+// setIn returns a copy of o whose value at path is replaced with v.
+// Missing collections on the way are created by the next key
+// (Arr for Int, Obj for Str and Map for the others).
+func (d *deepOps) setIn(
+	o object.PanObject,
+	path []object.PanObject,
+	v object.PanObject,
+) (object.PanObject, *object.PanErr) {
+	if len(path) == 0 {
+		return v, nil
+	}
+
+	child, ok, err := d.child(o, path[0])
+	if err != nil {
+		return nil, err
+	}
+	if !ok && len(path) > 1 {
+		child = emptyCollection(path[1])
+	}
+
+	newChild, err := d.setIn(child, path[1:], v)
+	if err != nil {
+		return nil, err
+	}
+	return d.withChild(o, path[0], newChild)
+}
+
+func emptyCollection(key object.PanObject) object.PanObject {
+	switch key.(type) {
+	case *object.PanInt:
+		return object.NewPanArr()
+	case *object.PanStr:
+		return object.EmptyPanObjPtr()
+	}
+	return object.NewPanMap()
+}
+
+// child returns the element of collection o specified by key.
+func (d *deepOps) child(o object.PanObject, key object.PanObject) (object.PanObject, bool, *object.PanErr) {
+	switch o := o.(type) {
+	case *object.PanObj:
+		str, ok := key.(*object.PanStr)
+		if !ok {
+			return nil, false, nil
+		}
+		pair, ok := (*o.Pairs)[object.GetSymHash(str.Value)]
+		if !ok {
+			return nil, false, nil
+		}
+		return pair.Value, true, nil
+	case *object.PanArr:
+		i, ok := arrIndex(o, key)
+		if !ok || i >= len(o.Elems) {
+			return nil, false, nil
+		}
+		return o.Elems[i], true, nil
+	case *object.PanMap:
+		i, err := d.findMapKey(o, key)
+		if err != nil || i < 0 {
+			return nil, false, err
+		}
+		return mapPairs(o)[i].Value, true, nil
+	}
+	return nil, false, nil
+}
+
+// withChild returns a copy of collection o whose element specified by key is replaced with v.
+func (d *deepOps) withChild(
+	o object.PanObject,
+	key object.PanObject,
+	v object.PanObject,
+) (object.PanObject, *object.PanErr) {
+	switch o := o.(type) {
+	case *object.PanObj:
+		str, ok := key.(*object.PanStr)
+		if !ok {
+			return nil, object.NewTypeErr(fmt.Sprintf("cannot use `%s` as Obj key.", key.Inspect()))
+		}
+		pairs := map[object.SymHash]object.Pair{}
+		for k, pair := range *o.Pairs {
+			pairs[k] = pair
+		}
+		pairs[object.GetSymHash(str.Value)] = object.Pair{Key: str, Value: v}
+		return object.NewPanObj(&pairs, o.Proto()), nil
+	case *object.PanArr:
+		i, ok := arrIndex(o, key)
+		// NOTE: index len appends v
+		if !ok || i > len(o.Elems) {
+			return nil, object.NewValueErr(fmt.Sprintf("index %s out of range of %s", key.Repr(), o.Repr()))
+		}
+		elems := make([]object.PanObject, len(o.Elems), len(o.Elems)+1)
+		copy(elems, o.Elems)
+		if i == len(elems) {
+			elems = append(elems, v)
+		} else {
+			elems[i] = v
+		}
+		return object.NewInheritedArr(o.Proto(), elems...), nil
+	case *object.PanMap:
+		i, err := d.findMapKey(o, key)
+		if err != nil {
+			return nil, err
+		}
+		pairs := mapPairs(o)
+		if i < 0 {
+			pairs = append(pairs, object.Pair{Key: key, Value: v})
+		} else {
+			pairs[i] = object.Pair{Key: pairs[i].Key, Value: v}
+		}
+		return object.NewInheritedMap(o.Proto(), pairs...), nil
+	}
+
+	return nil, object.NewTypeErr(fmt.Sprintf("cannot set `%s` to %s", key.Inspect(), o.Repr()))
+}
+
+// arrIndex returns index of arr (negative index counts from the last).
+func arrIndex(arr *object.PanArr, key object.PanObject) (int, bool) {
+	i, ok := key.(*object.PanInt)
+	if !ok {
+		return 0, false
+	}
+	idx := int(i.Value)
+	if idx < 0 {
+		idx += len(arr.Elems)
+	}
+	return idx, idx >= 0
+}
+
+// mapPairs returns copied pairs of m in order.
+func mapPairs(m *object.PanMap) []object.Pair {
+	pairs := make([]object.Pair, 0, len(*m.HashKeys)+len(*m.NonHashablePairs))
+	for _, h := range *m.HashKeys {
+		pairs = append(pairs, (*m.Pairs)[h])
+	}
+	return append(pairs, *m.NonHashablePairs...)
+}
+
+// findMapKey returns index of key in mapPairs(m) (or -1 if not found).
+// NOTE: same as map keys, only non-hashable keys are compared by `==`
+func (d *deepOps) findMapKey(m *object.PanMap, key object.PanObject) (int, *object.PanErr) {
+	if hashable, ok := key.(object.PanScalar); ok {
+		h := hashable.Hash()
+		for i, hashKey := range *m.HashKeys {
+			if hashKey == h {
+				return i, nil
+			}
+		}
+		return -1, nil
+	}
+
+	for i, pair := range *m.NonHashablePairs {
+		eq, err := d.eq(pair.Key, key)
+		if err != nil {
+			return -1, err
+		}
+		if eq {
+			return len(*m.HashKeys) + i, nil
+		}
+	}
+	return -1, nil
+}
+
+// objPairs returns pairs of o in order (private pairs follow public ones).
+func objPairs(o *object.PanObj) []object.Pair {
+	pairs := make([]object.Pair, 0, len(*o.Pairs))
+	for _, h := range *o.Keys {
+		pairs = append(pairs, (*o.Pairs)[h])
+	}
+	for _, h := range *o.PrivateKeys {
+		pairs = append(pairs, (*o.Pairs)[h])
+	}
+	return pairs
+}
+
+func (d *deepOps) eq(a, b object.PanObject) (bool, *object.PanErr) {
+	res := d.propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+		d.env, object.EmptyPanObjPtr(),
+		object.EmptyPanObjPtr(), a, eqSym, b,
+	)
+	if err, ok := res.(*object.PanErr); ok {
+		return false, err
+	}
+	return res == object.BuiltInTrue, nil
+}
+
+// deepEq compares collections recursively.
+// Unlike `==`, numbers are compared by values (`1` equals to `1.0`) as in JSON.
+func (d *deepOps) deepEq(a, b object.PanObject) (bool, *object.PanErr) {
+	switch a := a.(type) {
+	case *object.PanObj:
+		b, ok := b.(*object.PanObj)
+		if !ok || len(*a.Pairs) != len(*b.Pairs) {
+			return false, nil
+		}
+		for _, pair := range objPairs(a) {
+			other, ok, _ := d.child(b, pair.Key)
+			if !ok {
+				return false, nil
+			}
+			if eq, err := d.deepEq(pair.Value, other); !eq || err != nil {
+				return eq, err
+			}
+		}
+		return true, nil
+	case *object.PanArr:
+		b, ok := b.(*object.PanArr)
+		if !ok || len(a.Elems) != len(b.Elems) {
+			return false, nil
+		}
+		for i, elem := range a.Elems {
+			if eq, err := d.deepEq(elem, b.Elems[i]); !eq || err != nil {
+				return eq, err
+			}
+		}
+		return true, nil
+	case *object.PanMap:
+		b, ok := b.(*object.PanMap)
+		if !ok || len(mapPairs(a)) != len(mapPairs(b)) {
+			return false, nil
+		}
+		for _, pair := range mapPairs(a) {
+			other, ok, err := d.child(b, pair.Key)
+			if err != nil || !ok {
+				return false, err
+			}
+			if eq, err := d.deepEq(pair.Value, other); !eq || err != nil {
+				return eq, err
+			}
+		}
+		return true, nil
+	}
+
+	if x, ok := numValue(a); ok {
+		y, ok := numValue(b)
+		return ok && x == y, nil
+	}
+	return d.eq(a, b)
+}
+
+func numValue(o object.PanObject) (float64, bool) {
+	switch o := o.(type) {
+	case *object.PanInt:
+		return float64(o.Value), true
+	case *object.PanFloat:
+		return o.Value, true
+	}
+	return 0, false
+}
+
+// arrMerger merges arrs in deepMerge.
+type arrMerger func(d *deepOps, a, b *object.PanArr) (object.PanObject, *object.PanErr)
+
+var arrMergers = map[string]arrMerger{
+	"replace": func(d *deepOps, a, b *object.PanArr) (object.PanObject, *object.PanErr) {
+		return b, nil
+	},
+	"concat": func(d *deepOps, a, b *object.PanArr) (object.PanObject, *object.PanErr) {
+		return object.NewPanArr(append(append([]object.PanObject{}, a.Elems...), b.Elems...)...), nil
+	},
+	"merge": mergeArrByIndex,
+	"union": func(d *deepOps, a, b *object.PanArr) (object.PanObject, *object.PanErr) {
+		elems, err := unionElems(d.propContainer, d.env, a.Elems, [][]object.PanObject{b.Elems})
+		if err != nil {
+			return nil, err
+		}
+		return object.NewPanArr(elems...), nil
+	},
+}
+
+// mergeArrByIndex merges elements at the same index recursively.
+func mergeArrByIndex(d *deepOps, a, b *object.PanArr) (object.PanObject, *object.PanErr) {
+	elems := append([]object.PanObject{}, a.Elems...)
+	for i, elem := range b.Elems {
+		if i >= len(elems) {
+			elems = append(elems, elem)
+			continue
+		}
+		merged, err := d.deepMerge(elems[i], elem, mergeArrByIndex)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = merged
+	}
+	return object.NewPanArr(elems...), nil
+}
+
+// newArrMerger returns arrMerger specified by strategy name or func.
+func (d *deepOps) newArrMerger(strategy object.PanObject) (arrMerger, *object.PanErr) {
+	if str, ok := strategy.(*object.PanStr); ok {
+		if m, ok := arrMergers[str.Value]; ok {
+			return m, nil
+		}
+		return nil, object.NewValueErr(fmt.Sprintf(
+			"unknown arr strategy %s (must be 'replace, 'concat, 'merge, 'union or func)", str.Repr()))
+	}
+
+	return func(d *deepOps, a, b *object.PanArr) (object.PanObject, *object.PanErr) {
+		res := callFunc(d.propContainer, d.env, strategy, a, b)
+		if err, ok := res.(*object.PanErr); ok {
+			return nil, err
+		}
+		return res, nil
+	}, nil
+}
+
+// deepMerge merges b into a recursively. Values of b take precedence unless both are collections.
+func (d *deepOps) deepMerge(a, b object.PanObject, mergeArr arrMerger) (object.PanObject, *object.PanErr) {
+	switch a := a.(type) {
+	case *object.PanObj:
+		b, ok := b.(*object.PanObj)
+		if !ok {
+			break
+		}
+		return d.mergePairs(a, objPairs(b), mergeArr)
+	case *object.PanMap:
+		b, ok := b.(*object.PanMap)
+		if !ok {
+			break
+		}
+		return d.mergePairs(a, mapPairs(b), mergeArr)
+	case *object.PanArr:
+		b, ok := b.(*object.PanArr)
+		if !ok {
+			break
+		}
+		return mergeArr(d, a, b)
+	}
+	return b, nil
+}
+
+func (d *deepOps) mergePairs(
+	o object.PanObject,
+	pairs []object.Pair,
+	mergeArr arrMerger,
+) (object.PanObject, *object.PanErr) {
+	for _, pair := range pairs {
+		v := pair.Value
+		child, ok, err := d.child(o, pair.Key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			v, err = d.deepMerge(child, pair.Value, mergeArr)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		o, err = d.withChild(o, pair.Key, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// diff lists operations to change a into b, which are similar to JSON Patch.
+// Each operation is `{op: "add"|"remove"|"replace", path: [...], value: v}`.
+func (d *deepOps) diff(a, b object.PanObject, path []object.PanObject) ([]object.PanObject, *object.PanErr) {
+	switch a := a.(type) {
+	case *object.PanObj:
+		if b, ok := b.(*object.PanObj); ok {
+			return d.diffPairs(a, b, objPairs(a), objPairs(b), path)
+		}
+	case *object.PanMap:
+		if b, ok := b.(*object.PanMap); ok {
+			return d.diffPairs(a, b, mapPairs(a), mapPairs(b), path)
+		}
+	case *object.PanArr:
+		if b, ok := b.(*object.PanArr); ok {
+			return d.diffArr(a, b, path)
+		}
+	}
+
+	eq, err := d.deepEq(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if eq {
+		return []object.PanObject{}, nil
+	}
+	return []object.PanObject{patchOp("replace", path, b)}, nil
+}
+
+func (d *deepOps) diffPairs(
+	a, b object.PanObject,
+	aPairs, bPairs []object.Pair,
+	path []object.PanObject,
+) ([]object.PanObject, *object.PanErr) {
+	ops := []object.PanObject{}
+
+	for _, pair := range aPairs {
+		other, ok, err := d.child(b, pair.Key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			ops = append(ops, patchOp("remove", appendPath(path, pair.Key), nil))
+			continue
+		}
+
+		childOps, err := d.diff(pair.Value, other, appendPath(path, pair.Key))
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, childOps...)
+	}
+
+	for _, pair := range bPairs {
+		_, ok, err := d.child(a, pair.Key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			ops = append(ops, patchOp("add", appendPath(path, pair.Key), pair.Value))
+		}
+	}
+
+	return ops, nil
+}
+
+func (d *deepOps) diffArr(a, b *object.PanArr, path []object.PanObject) ([]object.PanObject, *object.PanErr) {
+	ops := []object.PanObject{}
+
+	for i := 0; i < len(a.Elems) && i < len(b.Elems); i++ {
+		childOps, err := d.diff(a.Elems[i], b.Elems[i], appendPath(path, object.NewPanInt(int64(i))))
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, childOps...)
+	}
+
+	for i := len(a.Elems); i < len(b.Elems); i++ {
+		ops = append(ops, patchOp("add", appendPath(path, object.NewPanInt(int64(i))), b.Elems[i]))
+	}
+
+	// NOTE: remove from the last so that operations can be applied in order
+	for i := len(a.Elems) - 1; i >= len(b.Elems); i-- {
+		ops = append(ops, patchOp("remove", appendPath(path, object.NewPanInt(int64(i))), nil))
+	}
+
+	return ops, nil
+}
+
+func appendPath(path []object.PanObject, key object.PanObject) []object.PanObject {
+	return append(append([]object.PanObject{}, path...), key)
+}
+
+func patchOp(op string, path []object.PanObject, v object.PanObject) object.PanObject {
+	pairs := map[object.SymHash]object.Pair{
+		object.GetSymHash("op"):   {Key: object.NewPanStr("op"), Value: object.NewPanStr(op)},
+		object.GetSymHash("path"): {Key: object.NewPanStr("path"), Value: object.NewPanArr(path...)},
+	}
+	if v != nil {
+		pairs[object.GetSymHash("value")] = object.Pair{Key: object.NewPanStr("value"), Value: v}
+	}
+	return object.PanObjInstancePtr(&pairs)
+}
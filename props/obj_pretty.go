@@ -0,0 +1,81 @@
+package props
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// prettyPrintObj prints args[0] by object.PrettyRepr.
+func prettyPrintObj(env *object.Env, kwargs *object.PanObj, args ...object.PanObject) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr("Obj#pp requires at least 1 arg")
+	}
+
+	ioObj, ok := env.Get(object.GetSymHash("IO"))
+	if !ok {
+		return object.NewNameErr("name `IO` is not defined.")
+	}
+	panIO, ok := ioObj.(*object.PanIO)
+	if !ok {
+		return object.NewTypeErr("name `IO` is not io object")
+	}
+
+	opts, err := prettyOptions(kwargs, panIO.Out)
+	if err != nil {
+		return err
+	}
+
+	io.WriteString(panIO.Out, object.PrettyRepr(args[0], opts)+"\n")
+	return object.BuiltInNil
+}
+
+// prettyOptions reads kwargs `width`, `max` and `color?`.
+// Values are colored by default only if out is a terminal.
+func prettyOptions(kwargs *object.PanObj, out io.Writer) (object.PrettyOptions, *object.PanErr) {
+	opts := object.PrettyOptions{
+		Width:    object.DefaultPrettyWidth,
+		MaxElems: object.DefaultPrettyMaxElems,
+		Colored:  isTerminal(out),
+	}
+
+	intOpts := []struct {
+		name string
+		dst  *int
+	}{
+		{"width", &opts.Width},
+		{"max", &opts.MaxElems},
+	}
+
+	for _, o := range intOpts {
+		pair, ok := propIn(kwargs, o.name)
+		if !ok {
+			continue
+		}
+		i, ok := object.TraceProtoOfInt(pair.Value)
+		if !ok {
+			return opts, object.NewTypeErr(
+				fmt.Sprintf("%s cannot be treated as int", pair.Value.Repr()))
+		}
+		if i.Value < 0 {
+			return opts, object.NewValueErr(
+				fmt.Sprintf("%s must not be negative but got %d", o.name, i.Value))
+		}
+		*o.dst = int(i.Value)
+	}
+
+	if pair, ok := propIn(kwargs, "color?"); ok {
+		opts.Colored = pair.Value == object.BuiltInTrue
+	}
+
+	return opts, nil
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && isatty.IsTerminal(f.Fd())
+}
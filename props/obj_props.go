@@ -3,6 +3,7 @@ package props
 import (
 	"fmt"
 	"io"
+	"math"
 
 	"github.com/Syuparn/pangaea/object"
 	"github.com/tanaton/dtoa"
@@ -13,6 +14,7 @@ import (
 func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// ! returns the negation of B.
 		"!": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -51,6 +53,7 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		"_name": object.NewPanStr("Obj"),
+		// B returns false if self has no props, otherwise true.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -69,7 +72,110 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInTrue
 			},
 		),
+		// callProp calls the prop of the receiver with the rest args.
 		"callProp": propContainer["Obj_callProp"],
+		// deepDiff returns an arr of operations (like JSON Patch) to change self into the arg.
+		"deepDiff": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Obj#deepDiff requires at least 2 args")
+				}
+
+				ops, err := newDeepOps(propContainer, env).diff(args[0], args[1], []object.PanObject{})
+				if err != nil {
+					return err
+				}
+				return object.NewPanArr(ops...)
+			},
+		),
+		// deepEq returns whether self and the arg are equal recursively.
+		"deepEq": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Obj#deepEq requires at least 2 args")
+				}
+
+				eq, err := newDeepOps(propContainer, env).deepEq(args[0], args[1])
+				if err != nil {
+					return err
+				}
+				if !eq {
+					return object.BuiltInFalse
+				}
+				return object.BuiltInTrue
+			},
+		),
+		// deepMerge merges objs and maps in the args into self recursively.
+		// How to merge arrs is specified by `arr:` ('replace, 'concat, 'merge, 'union or func).
+		"deepMerge": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Obj#deepMerge requires at least 1 arg")
+				}
+
+				d := newDeepOps(propContainer, env)
+
+				var strategy object.PanObject = object.NewPanStr("replace")
+				if pair, ok := propIn(kwargs, "arr"); ok && pair.Value != object.BuiltInNil {
+					strategy = pair.Value
+				}
+				mergeArr, err := d.newArrMerger(strategy)
+				if err != nil {
+					return err
+				}
+
+				merged := args[0]
+				for _, other := range args[1:] {
+					merged, err = d.deepMerge(merged, other, mergeArr)
+					if err != nil {
+						return err
+					}
+				}
+				return merged
+			},
+		),
+		// doc returns the doc comment of the prop (nil if it is not documented).
+		"doc": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Obj#doc requires at least 2 args")
+				}
+				return propDoc(args[0], args[1])
+			},
+		),
+		// getIn returns the value at the path of keys and indices (`default:` if not found).
+		"getIn": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Obj#getIn requires at least 2 args")
+				}
+
+				path, err := pathElems(args[1])
+				if err != nil {
+					return err
+				}
+
+				v, ok, err := newDeepOps(propContainer, env).getIn(args[0], path)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return defaultValue(kwargs)
+				}
+				return v
+			},
+		),
+		// items returns an arr of key-value pairs.
 		"items": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -104,6 +210,7 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanArr(items...)
 			},
 		),
+		// keys returns an arr of keys.
 		"keys": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -136,58 +243,45 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanArr(keys...)
 			},
 		),
+		// p prints self in Repr format with a newline.
 		"p": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
 			) object.PanObject {
-				if len(args) < 1 {
-					return object.NewTypeErr("Obj#p requires at least 1 arg")
-				}
-
-				// TODO: pass io by kwarg
-				// find IO object
-				ioObj, ok := env.Get(object.GetSymHash("IO"))
-				if !ok {
-					return object.NewNameErr("name `IO` is not defined.")
-				}
-				panIO, ok := ioObj.(*object.PanIO)
-				if !ok {
-					return object.NewTypeErr("name `IO` is not io object")
-				}
-
-				// get args[0].S
-				sSym := object.NewPanStr("S")
-				sRet := propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
-					env, object.EmptyPanObjPtr(),
-					object.EmptyPanObjPtr(), args[0], sSym,
-				)
-
-				str, ok := object.TraceProtoOfStr(sRet)
-				if !ok {
-					return object.NewTypeErr(`\1.S must be str`)
-				}
-
-				// get kwarg end (default: breakline)
-				endPair, ok := (*kwargs.Pairs)[object.GetSymHash("end")]
-				if !ok {
-					// print
-					io.WriteString(panIO.Out, str.Value)
-					// breakline
-					io.WriteString(panIO.Out, "\n")
-					return object.BuiltInNil
-				}
-
-				endStr, ok := object.TraceProtoOfStr(endPair.Value)
-				if !ok {
-					return object.NewTypeErr("end must be str")
-				}
-
-				// print
-				io.WriteString(panIO.Out, str.Value)
-				io.WriteString(panIO.Out, endStr.Value)
-				return object.BuiltInNil
+				return printObj(propContainer, "Obj#p", env, kwargs, args...)
+			},
+		),
+		// pp prints self in the pretty format with a newline.
+		"pp": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				return prettyPrintObj(env, kwargs, args...)
 			},
 		),
+		// NOTE: print and puts are not defined natively so that they refer to `IO` in the caller env
+		// (native methods refer to `IO` in the env where they are defined, which ignores the IO
+		// injected into embedded interpreters by embed.WithIO)
+		// print prints self (converted by S) without a newline.
+		"print": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				endKwargs := object.PanObjInstancePtr(&map[object.SymHash]object.Pair{
+					object.GetSymHash("end"): {Key: object.NewPanStr("end"), Value: object.NewPanStr("")},
+				}).(*object.PanObj)
+				return printObj(propContainer, "Obj#print", env, endKwargs, args...)
+			},
+		),
+		// puts prints self (converted by S) with a newline.
+		"puts": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				return printObj(propContainer, "Obj#puts", env, kwargs, args...)
+			},
+		),
+		// new creates an obj with the props of the arg.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -212,6 +306,18 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				})
 			},
 		),
+		// q returns an arr of values matched by the JSONPath expression.
+		"q": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Obj#q requires at least 2 args")
+				}
+				return queryJSON(propContainer, env, args[0], args[1])
+			},
+		),
+		// repr returns the Repr of self.
 		"repr": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -223,6 +329,7 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanStr(args[0].Repr())
 			},
 		),
+		// S converts self to str.
 		"S": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -233,6 +340,28 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return formattedStr(args[0], kwargs)
 			},
 		),
+		// setIn returns a copy of self whose value at the path is replaced with the arg.
+		"setIn": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 3 {
+					return object.NewTypeErr("Obj#setIn requires at least 3 args")
+				}
+
+				path, err := pathElems(args[1])
+				if err != nil {
+					return err
+				}
+
+				ret, err := newDeepOps(propContainer, env).setIn(args[0], path, args[2])
+				if err != nil {
+					return err
+				}
+				return ret
+			},
+		),
+		// traverse returns an iter of pairs of paths and nested values (only values of the key if `key:` is specified).
 		"traverse": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -254,6 +383,7 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return traverseObject(args[0])
 			},
 		),
+		// try wraps self with EitherVal.
 		"try": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -264,6 +394,42 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return toEitherVal(args[0])
 			},
 		),
+		// updateIn returns a copy of self whose value at the path is replaced with the result of f.
+		"updateIn": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 3 {
+					return object.NewTypeErr("Obj#updateIn requires at least 3 args")
+				}
+
+				path, err := pathElems(args[1])
+				if err != nil {
+					return err
+				}
+
+				d := newDeepOps(propContainer, env)
+				v, ok, err := d.getIn(args[0], path)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					v = defaultValue(kwargs)
+				}
+
+				updated := callFunc(propContainer, env, args[2], v)
+				if err, ok := updated.(*object.PanErr); ok {
+					return err
+				}
+
+				ret, err := d.setIn(args[0], path, updated)
+				if err != nil {
+					return err
+				}
+				return ret
+			},
+		),
+		// values returns an arr of values.
 		"values": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -296,6 +462,7 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanArr(values...)
 			},
 		),
+		// which returns the obj in the proto chain which has the prop (nil if not found).
 		"which": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -319,6 +486,60 @@ func ObjProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 	}
 }
 
+// printObj prints \1.S to `IO` in env.
+// name is the caller prop name shown in errors.
+func printObj(
+	propContainer map[string]object.PanObject, name string,
+	env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+) object.PanObject {
+	if len(args) < 1 {
+		return object.NewTypeErr(name + " requires at least 1 arg")
+	}
+
+	// TODO: pass io by kwarg
+	// find IO object
+	ioObj, ok := env.Get(object.GetSymHash("IO"))
+	if !ok {
+		return object.NewNameErr("name `IO` is not defined.")
+	}
+	panIO, ok := ioObj.(*object.PanIO)
+	if !ok {
+		return object.NewTypeErr("name `IO` is not io object")
+	}
+
+	// get args[0].S
+	sSym := object.NewPanStr("S")
+	sRet := propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+		env, object.EmptyPanObjPtr(),
+		object.EmptyPanObjPtr(), args[0], sSym,
+	)
+
+	str, ok := object.TraceProtoOfStr(sRet)
+	if !ok {
+		return object.NewTypeErr(`\1.S must be str`)
+	}
+
+	// get kwarg end (default: breakline)
+	endPair, ok := (*kwargs.Pairs)[object.GetSymHash("end")]
+	if !ok {
+		// print
+		io.WriteString(panIO.Out, str.Value)
+		// breakline
+		io.WriteString(panIO.Out, "\n")
+		return object.BuiltInNil
+	}
+
+	endStr, ok := object.TraceProtoOfStr(endPair.Value)
+	if !ok {
+		return object.NewTypeErr("end must be str")
+	}
+
+	// print
+	io.WriteString(panIO.Out, str.Value)
+	io.WriteString(panIO.Out, endStr.Value)
+	return object.BuiltInNil
+}
+
 func toEitherVal(o object.PanObject) object.PanObject {
 	// wrap o by EitherVal
 	pairMap := map[object.SymHash]object.Pair{
@@ -345,6 +566,11 @@ func formattedStr(o object.PanObject, kwargs *object.PanObj) object.PanObject {
 }
 
 func dtoaWrapper(f float64) string {
+	// NOTE: dtoa cannot handle infinities and NaN (printed in the same way as Repr)
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return fmt.Sprintf("%f", f)
+	}
+
 	//               buf,    val, maxDecimalPlaces
 	buf := dtoa.Dtoa([]byte{}, f, 324)
 	return string(buf)
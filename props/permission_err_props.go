@@ -0,0 +1,23 @@
+package props
+
+import (
+	"github.com/Syuparn/pangaea/object"
+)
+
+// PermissionErrProps provides built-in props for PermissionErr.
+// NOTE: internally, these props are also used for ErrWrappers
+// NOTE: Some Val props are defind by native code (not by this function).
+func PermissionErrProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
+	// NOTE: inject some built-in functions which relate to parser or evaluator
+	return map[string]object.PanObject{
+		"_name": object.NewPanStr("PermissionErr"),
+		// new returns a new PermissionErr whose message is the arg.
+		"new": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				return constructErr(propContainer, env, object.NewPermissionErr, args...)
+			},
+		),
+	}
+}
@@ -11,6 +11,7 @@ import (
 func RangeProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// == returns whether self and the arg have equal start, stop and step.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -90,6 +91,7 @@ func RangeProps(propContainer map[string]object.PanObject) map[string]object.Pan
 			},
 		),
 		"_name": object.NewPanStr("Range"),
+		// B always returns true.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -104,6 +106,7 @@ func RangeProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return object.BuiltInTrue
 			},
 		),
+		// new creates a range with start, stop and step.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -141,6 +144,7 @@ func RangeProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				}
 			},
 		),
+		// start returns the start of the range.
 		"start": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -158,6 +162,7 @@ func RangeProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return r.Start
 			},
 		),
+		// step returns the step of the range.
 		"step": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -175,6 +180,7 @@ func RangeProps(propContainer map[string]object.PanObject) map[string]object.Pan
 				return r.Step
 			},
 		),
+		// stop returns the stop of the range (which is not included).
 		"stop": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
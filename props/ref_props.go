@@ -0,0 +1,148 @@
+package props
+
+import (
+	"github.com/Syuparn/pangaea/object"
+)
+
+// RefProps provides built-in props for Ref.
+// NOTE: Some Ref props are defind by native code (not by this function).
+func RefProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
+	// NOTE: inject some built-in functions which relate to parser or evaluator
+	return map[string]object.PanObject{
+		// == returns whether self and the arg are the same ref.
+		"==": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("== requires at least 2 args")
+				}
+
+				// NOTE: refs are compared by identity because they are mutable
+				if args[0] != args[1] {
+					return object.BuiltInFalse
+				}
+				return object.BuiltInTrue
+			},
+		),
+		"_name": object.NewPanStr("Ref"),
+		// cas sets new if the current value is old and returns whether it is set.
+		"cas": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 3 {
+					return object.NewTypeErr("Ref#cas requires at least 3 args")
+				}
+
+				self, ok := object.TraceProtoOfRef(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be ref`)
+				}
+
+				for {
+					current := self.Get()
+					// NOTE: `==` must be called outside of the lock because it may refer self
+					isEq := propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+						env, object.EmptyPanObjPtr(),
+						object.EmptyPanObjPtr(), current, eqSym, args[1],
+					)
+					if err, ok := isEq.(*object.PanErr); ok {
+						return err
+					}
+					if isEq != object.BuiltInTrue {
+						return object.BuiltInFalse
+					}
+
+					// retry if the value is changed by others in the meantime
+					if self.CompareAndSet(current, args[2]) {
+						return object.BuiltInTrue
+					}
+				}
+			},
+		),
+		// get returns the current value.
+		"get": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Ref#get requires at least 1 arg")
+				}
+
+				self, ok := object.TraceProtoOfRef(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be ref`)
+				}
+
+				return self.Get()
+			},
+		),
+		// new creates a mutable reference to the arg.
+		"new": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Ref#new requires at least 1 arg")
+				}
+
+				// Ref.new is same as Ref.new(nil)
+				if len(args) < 2 {
+					return object.NewInheritedRef(args[0], object.BuiltInNil)
+				}
+
+				// NOTE: Ref's descendants also call this
+				return object.NewInheritedRef(args[0], args[1])
+			},
+		),
+		// set replaces the current value with the arg and returns it.
+		"set": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Ref#set requires at least 2 args")
+				}
+
+				self, ok := object.TraceProtoOfRef(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be ref`)
+				}
+
+				self.Set(args[1])
+				return args[1]
+			},
+		),
+		// swap replaces the current value with the result of f called with it.
+		"swap": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Ref#swap requires at least 2 args")
+				}
+
+				self, ok := object.TraceProtoOfRef(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be ref`)
+				}
+
+				for {
+					current := self.Get()
+					// NOTE: f must be called outside of the lock because it may refer self
+					ret := callFunc(propContainer, env, args[1],
+						append([]object.PanObject{current}, args[2:]...)...)
+					if err, ok := ret.(*object.PanErr); ok {
+						return err
+					}
+
+					// retry if the value is changed by others in the meantime
+					if self.CompareAndSet(current, ret) {
+						return ret
+					}
+				}
+			},
+		),
+	}
+}
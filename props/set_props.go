@@ -0,0 +1,321 @@
+package props
+
+import (
+	"fmt"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+// SetProps provides built-in props for Set.
+// NOTE: Some Set props are defind by native code (not by this function).
+func SetProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
+	// NOTE: inject some built-in functions which relate to parser or evaluator
+	return map[string]object.PanObject{
+		// == returns whether self and the arg have the same elements.
+		"==": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("== requires at least 2 args")
+				}
+
+				self, ok := object.TraceProtoOfSet(args[0])
+				if !ok {
+					return object.BuiltInFalse
+				}
+				other, ok := object.TraceProtoOfSet(args[1])
+				if !ok {
+					return object.BuiltInFalse
+				}
+
+				if self.Len() != other.Len() {
+					return object.BuiltInFalse
+				}
+				return isSubset(propContainer, env, self, other.Items())
+			},
+		),
+		// - returns elements of self which are not in the arg.
+		"-": setOperationProp(propContainer, "-", diffElems),
+		// /& returns elements in both self and the arg.
+		"/&": setOperationProp(propContainer, "/&", intersectElems),
+		// /^ returns elements in either self or the arg but not both.
+		"/^": setOperationProp(propContainer, "/^", symDiffElems),
+		// /| returns elements in self or the arg.
+		"/|": setOperationProp(propContainer, "/|", unionElems),
+		"_iter": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Set#_iter requires at least 1 arg")
+				}
+
+				self, ok := object.TraceProtoOfSet(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be set`)
+				}
+
+				return object.NewPanBuiltInIter(arrIter(object.NewPanArr(self.Items()...)), env)
+			},
+		),
+		"_name": object.NewPanStr("Set"),
+		// add returns a new set with the args added.
+		"add": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Set#add requires at least 1 arg")
+				}
+
+				self, ok := object.TraceProtoOfSet(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be set`)
+				}
+
+				elems := append(self.Items(), args[1:]...)
+				return newSet(propContainer, env, object.BuiltInSetObj, elems)
+			},
+		),
+		// B returns false if self is empty, otherwise true.
+		"B": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Set#B requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfSet(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be set`)
+				}
+
+				if self.Len() == 0 {
+					return object.BuiltInFalse
+				}
+				return object.BuiltInTrue
+			},
+		),
+		// del returns a new set without the args.
+		"del": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Set#del requires at least 1 arg")
+				}
+
+				self, ok := object.TraceProtoOfSet(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be set`)
+				}
+
+				elems, err := diffElems(propContainer, env, self.Items(),
+					[][]object.PanObject{args[1:]})
+				if err != nil {
+					return err
+				}
+				return object.NewPanSet(elems...)
+			},
+		),
+		// has? returns whether self includes the arg.
+		"has?": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Set#has? requires at least 2 args")
+				}
+
+				self, ok := object.TraceProtoOfSet(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be set`)
+				}
+
+				found, err := setHas(propContainer, env, self, args[1])
+				if err != nil {
+					return err
+				}
+				if found {
+					return object.BuiltInTrue
+				}
+				return object.BuiltInFalse
+			},
+		),
+		// len returns the number of elements.
+		"len": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Set#len requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfSet(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be set`)
+				}
+
+				return object.NewPanInt(int64(self.Len()))
+			},
+		),
+		// new creates a set of elements of the iterable.
+		"new": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Set#new requires at least 1 arg")
+				}
+
+				// Set.new is same as Set.new([])
+				if len(args) < 2 {
+					return object.NewInheritedSet(args[0])
+				}
+
+				elems, err := iterableElems(propContainer, env, args[1])
+				if err != nil {
+					return err
+				}
+
+				// NOTE: Set's descendants also call this
+				return newSet(propContainer, env, args[0], elems)
+			},
+		),
+		// sub? returns whether all elements of self are in the arg.
+		"sub?": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Set#sub? requires at least 2 args")
+				}
+
+				self, ok := object.TraceProtoOfSet(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be set`)
+				}
+				other, err := iterableElems(propContainer, env, args[1])
+				if err != nil {
+					return err
+				}
+
+				return isSubset(propContainer, env, self, other)
+			},
+		),
+		// super? returns whether all elements of the arg are in self.
+		"super?": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Set#super? requires at least 2 args")
+				}
+
+				self, ok := object.TraceProtoOfSet(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be set`)
+				}
+				other, err := iterableElems(propContainer, env, args[1])
+				if err != nil {
+					return err
+				}
+
+				for _, elem := range other {
+					found, err := setHas(propContainer, env, self, elem)
+					if err != nil {
+						return err
+					}
+					if !found {
+						return object.BuiltInFalse
+					}
+				}
+				return object.BuiltInTrue
+			},
+		),
+	}
+}
+
+func setOperationProp(
+	propContainer map[string]object.PanObject,
+	name string,
+	op setOperation,
+) object.PanObject {
+	return f(
+		func(
+			env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+		) object.PanObject {
+			if len(args) < 2 {
+				return object.NewTypeErr(fmt.Sprintf("%s requires at least 2 args", name))
+			}
+
+			self, ok := object.TraceProtoOfSet(args[0])
+			if !ok {
+				return object.NewTypeErr(`\1 must be set`)
+			}
+
+			elems, err := applySetOperation(propContainer, env, op, self.Items(), args[1:2])
+			if err != nil {
+				return err
+			}
+			return object.NewPanSet(elems...)
+		},
+	)
+}
+
+// newSet makes new set removing non-hashable duplicated elements by `==`.
+func newSet(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	proto object.PanObject,
+	elems []object.PanObject,
+) object.PanObject {
+	uniq, err := uniqElems(propContainer, env, elems)
+	if err != nil {
+		return err
+	}
+	return object.NewInheritedSet(proto, uniq...)
+}
+
+func setHas(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	set *object.PanSet,
+	o object.PanObject,
+) (bool, *object.PanErr) {
+	if hashable, ok := o.(object.PanScalar); ok {
+		_, found := (*set.Elems)[hashable.Hash()]
+		return found, nil
+	}
+
+	for _, elem := range *set.NonHashableElems {
+		res := propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+			env, object.EmptyPanObjPtr(),
+			object.EmptyPanObjPtr(), o, eqSym, elem,
+		)
+		if err, ok := res.(*object.PanErr); ok {
+			return false, err
+		}
+		if res == object.BuiltInTrue {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isSubset returns whether all elements of set are in other.
+func isSubset(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	set *object.PanSet,
+	other []object.PanObject,
+) object.PanObject {
+	rest, err := diffElems(propContainer, env, set.Items(), [][]object.PanObject{other})
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return object.BuiltInFalse
+	}
+	return object.BuiltInTrue
+}
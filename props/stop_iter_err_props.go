@@ -11,6 +11,7 @@ func StopIterErrProps(propContainer map[string]object.PanObject) map[string]obje
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("StopIterErr"),
+		// new returns a new StopIterErr whose message is the arg.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
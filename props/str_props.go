@@ -16,6 +16,7 @@ import (
 func StrProps(propContainer map[string]object.PanObject) map[string]object.PanObject {
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
+		// <=> compares self with the arg in lexicographic order and returns -1, 0 or 1.
 		"<=>": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -28,6 +29,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanInt(int64(strings.Compare(self.Value, other.Value)))
 			},
 		),
+		// == returns whether self and the arg are the same str.
 		"==": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -57,6 +59,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInFalse
 			},
 		),
+		// /~ returns a str whose bytes are inverted.
 		"/~": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -80,6 +83,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedStr(args[0].Proto(), string(negBytes))
 			},
 		),
+		// + returns a str concatenating self and the arg.
 		"+": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -94,6 +98,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedStr(args[0].Proto(), res)
 			},
 		),
+		// * returns a str repeating self the arg times.
 		"*": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -125,6 +130,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedStr(args[0].Proto(), res)
 			},
 		),
+		// / splits self by the regex pattern (empty strs are excluded).
 		"/": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -203,7 +209,9 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 			},
 		),
 		"_name": object.NewPanStr("Str"),
-		"at":    propContainer["Str_at"],
+		// at returns the char at the index (or the sub str of the range).
+		"at": propContainer["Str_at"],
+		// B returns false if self is empty, otherwise true.
 		"B": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -222,6 +230,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInTrue
 			},
 		),
+		// dedent removes the common leading whitespace from each line.
 		"dedent": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -240,8 +249,36 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedStr(args[0].Proto(), res)
 			},
 		),
-		"eval":    propContainer["Str_eval"],
+		// enc encodes self to bytes by the encoding ("utf-8", "utf-16be", "utf-16le", "latin1" or "ascii").
+		"enc": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 1 {
+					return object.NewTypeErr("Str#enc requires at least 1 arg")
+				}
+				self, ok := object.TraceProtoOfStr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be str`)
+				}
+
+				enc, name, errObj := findTextEncoding(args, 1)
+				if errObj != nil {
+					return errObj
+				}
+
+				b, err := enc.encode(self.Value)
+				if err != nil {
+					return object.NewValueErr(fmt.Sprintf("failed to encode %s: %s", name, err.Error()))
+				}
+				return object.NewPanBytes(b)
+			},
+		),
+		// eval evaluates self as pangaea source code and returns the result.
+		"eval": propContainer["Str_eval"],
+		// evalEnv evaluates self as pangaea source code and returns the env as an obj.
 		"evalEnv": propContainer["Str_evalEnv"],
+		// F converts self to float.
 		"F": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -263,6 +300,31 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanFloat(f)
 			},
 		),
+		// has? returns whether self contains the substring.
+		"has?": f(
+			func(
+				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
+			) object.PanObject {
+				if len(args) < 2 {
+					return object.NewTypeErr("Str#has? requires at least 2 args")
+				}
+				self, ok := object.TraceProtoOfStr(args[0])
+				if !ok {
+					return object.NewTypeErr(`\1 must be str`)
+				}
+				sub, ok := object.TraceProtoOfStr(args[1])
+				if !ok {
+					return object.NewTypeErr(
+						fmt.Sprintf("%s cannot be treated as str", args[1].Repr()))
+				}
+
+				if strings.Contains(self.Value, sub.Value) {
+					return object.BuiltInTrue
+				}
+				return object.BuiltInFalse
+			},
+		),
+		// I converts self to int.
 		"I": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -291,6 +353,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return parseIntInBase(self, base.Value)
 			},
 		),
+		// lc returns a lower-case str.
 		"lc": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -307,6 +370,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedStr(args[0].Proto(), strings.ToLower(self.Value))
 			},
 		),
+		// len returns the number of chars.
 		"len": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -322,6 +386,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanInt(int64(len([]rune(self.Value))))
 			},
 		),
+		// match returns an arr of the match and its groups of the regex pattern (empty if not matched).
 		"match": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -363,6 +428,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanArr(elems...)
 			},
 		),
+		// new converts the arg to str by its S prop.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -383,6 +449,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				)
 			},
 		),
+		// ord returns the code point of the single char.
 		"ord": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -405,6 +472,8 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewPanInt(int64(runes[0]))
 			},
 		),
+		// sub replaces all matches of the regex pattern with the replacement.
+		// The replacement can refer groups as `$1`, and `\U...\E` (`\L...\E`) converts the enclosed text to upper (lower) case.
 		"sub": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -465,6 +534,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.NewInheritedStr(args[0].Proto(), lcReplaced)
 			},
 		),
+		// sym? returns whether self can be a symbol literal (such as `'a`).
 		"sym?": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -485,6 +555,7 @@ func StrProps(propContainer map[string]object.PanObject) map[string]object.PanOb
 				return object.BuiltInFalse
 			},
 		),
+		// uc returns an upper-case str.
 		"uc": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -11,6 +11,7 @@ func SyntaxErrProps(propContainer map[string]object.PanObject) map[string]object
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("SyntaxErr"),
+		// new returns a new SyntaxErr whose message is the arg.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
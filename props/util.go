@@ -10,9 +10,28 @@ var valueSym = object.NewPanStr("_value")
 var prefixMinusSym = object.NewPanStr("-%")
 var divSym = object.NewPanStr("/")
 var floorDivSym = object.NewPanStr("//")
+var spaceshipSym = object.NewPanStr("<=>")
+var callSym = object.NewPanStr("call")
+var aSym = object.NewPanStr("A")
+var bSym = object.NewPanStr("B")
+var iterSym = object.NewPanStr("_iter")
+var nextSym = object.NewPanStr("next")
 
 func propIn(obj *object.PanObj, propName string) (object.Pair, bool) {
 	propSym := object.GetSymHash(propName)
 	pair, ok := (*obj.Pairs)[propSym]
 	return pair, ok
 }
+
+// callFunc calls f(args...) via prop `call`.
+func callFunc(
+	propContainer map[string]object.PanObject,
+	env *object.Env,
+	f object.PanObject,
+	args ...object.PanObject,
+) object.PanObject {
+	callArgs := append([]object.PanObject{object.EmptyPanObjPtr(), f, callSym}, args...)
+	return propContainer["Obj_callProp"].(*object.PanBuiltIn).Fn(
+		env, object.EmptyPanObjPtr(), callArgs...,
+	)
+}
@@ -11,6 +11,7 @@ func ZeroDivisionErrProps(propContainer map[string]object.PanObject) map[string]
 	// NOTE: inject some built-in functions which relate to parser or evaluator
 	return map[string]object.PanObject{
 		"_name": object.NewPanStr("ZeroDivisionErr"),
+		// new returns a new ZeroDivisionErr whose message is the arg.
 		"new": f(
 			func(
 				env *object.Env, kwargs *object.PanObj, args ...object.PanObject,
@@ -0,0 +1,52 @@
+package refdoc
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const usage = `usage: pangaea doc [-format md|html] [-o dir]
+
+generates reference pages of builtin objects from doc comments of their props.
+`
+
+// DefaultDir is the directory where pages are written by default.
+const DefaultDir = "docs/reference/builtins"
+
+// Run runs `pangaea doc` subcommand and returns exit code.
+func Run(args []string, out io.Writer, errOut io.Writer) int {
+	cmdSet := flag.NewFlagSet("doc", flag.ContinueOnError)
+	cmdSet.SetOutput(errOut)
+	cmdSet.Usage = func() {
+		fmt.Fprint(errOut, usage)
+		cmdSet.PrintDefaults()
+	}
+	format := cmdSet.String("format", "md", "format of pages (md or html)")
+	dir := cmdSet.String("o", DefaultDir, "output directory")
+	if err := cmdSet.Parse(args); err != nil {
+		return 2
+	}
+
+	pages, err := Render(Collect(), *format)
+	if err != nil {
+		fmt.Fprintln(errOut, err.Error())
+		return 2
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		fmt.Fprintln(errOut, err.Error())
+		return 1
+	}
+	for _, page := range pages {
+		if err := os.WriteFile(filepath.Join(*dir, page.FileName), page.Content, 0o644); err != nil {
+			fmt.Fprintln(errOut, err.Error())
+			return 1
+		}
+	}
+
+	fmt.Fprintf(out, "wrote %d pages to %s\n", len(pages), *dir)
+	return 0
+}
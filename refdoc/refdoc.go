@@ -0,0 +1,118 @@
+// Package refdoc generates reference pages of builtin objects from docs of their props.
+package refdoc
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Syuparn/pangaea/di"
+	"github.com/Syuparn/pangaea/object"
+)
+
+// mixins are objs whose props are copied to other objs.
+var mixins = []string{"Comparable", "Iterable", "Wrappable"}
+
+// ObjDoc is a reference of a builtin obj.
+type ObjDoc struct {
+	Name  string
+	Props []*PropDoc
+	// Mixins are names of mixins whose props the obj has (props of them are not in Props)
+	Mixins []string
+}
+
+// PropDoc is a reference of a prop.
+type PropDoc struct {
+	Name string
+	// Doc is the doc comment of the prop ("" if it is not documented)
+	Doc string
+}
+
+// Collect returns references of all builtin objs sorted by name.
+func Collect() []*ObjDoc {
+	env := object.NewEnvWithConsts()
+	di.InjectBuiltInProps(env)
+
+	objs := map[string]*object.PanObj{}
+	for h, v := range env.Store {
+		obj, ok := v.(*object.PanObj)
+		if !ok || obj.Pairs == nil {
+			continue
+		}
+		// NOTE: consts like `_` are not builtin objs
+		name, ok := varName(h)
+		if !ok || obj.Repr() != name {
+			continue
+		}
+		objs[name] = obj
+	}
+
+	docs := []*ObjDoc{}
+	for name, obj := range objs {
+		docs = append(docs, newObjDoc(name, obj, objs))
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+	return docs
+}
+
+func newObjDoc(name string, obj *object.PanObj, objs map[string]*object.PanObj) *ObjDoc {
+	d := &ObjDoc{Name: name, Props: []*PropDoc{}, Mixins: []string{}}
+
+	mixedIn := map[string]bool{}
+	for _, pair := range *obj.Pairs {
+		propName, ok := object.TraceProtoOfStr(pair.Key)
+		// NOTE: operators are not public keys but shown
+		if !ok || strings.HasPrefix(propName.Value, "_") {
+			continue
+		}
+
+		if mixin, ok := findMixin(name, propName.Value, pair.Value, objs); ok {
+			mixedIn[mixin] = true
+			continue
+		}
+		d.Props = append(d.Props, &PropDoc{Name: propName.Value, Doc: doc(pair.Value)})
+	}
+
+	sort.Slice(d.Props, func(i, j int) bool { return d.Props[i].Name < d.Props[j].Name })
+	for _, mixin := range mixins {
+		if mixedIn[mixin] {
+			d.Mixins = append(d.Mixins, mixin)
+		}
+	}
+	return d
+}
+
+// findMixin returns the mixin which has the same prop as v.
+func findMixin(objName string, prop string, v object.PanObject, objs map[string]*object.PanObj) (string, bool) {
+	for _, mixin := range mixins {
+		mixinObj, ok := objs[mixin]
+		if !ok || mixin == objName {
+			continue
+		}
+		if pair, ok := (*mixinObj.Pairs)[object.GetSymHash(prop)]; ok && pair.Value == v {
+			return mixin, true
+		}
+	}
+	return "", false
+}
+
+func varName(h object.SymHash) (string, bool) {
+	sym, ok := object.SymHash2Str(h)
+	if !ok {
+		return "", false
+	}
+	str, ok := object.TraceProtoOfStr(sym)
+	if !ok {
+		return "", false
+	}
+	return str.Value, true
+}
+
+func doc(v object.PanObject) string {
+	switch v := v.(type) {
+	case *object.PanFunc:
+		return v.Doc
+	case *object.PanBuiltIn:
+		return v.Doc
+	}
+	return ""
+}
@@ -0,0 +1,202 @@
+package refdoc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	objs := Collect()
+
+	str := findObj(t, objs, "Str")
+	if !hasMixin(str, "Iterable") || !hasMixin(str, "Comparable") {
+		t.Errorf("Str must have mixins Comparable and Iterable. got=%v", str.Mixins)
+	}
+
+	uc := findProp(t, str, "uc")
+	if uc.Doc != "uc returns an upper-case str." {
+		t.Errorf("wrong doc of Str#uc. got=%q", uc.Doc)
+	}
+	// props of mixins are not listed in Str
+	for _, prop := range str.Props {
+		if prop.Name == "map" {
+			t.Errorf("Str must not have Iterable#map in props")
+		}
+	}
+
+	iterable := findObj(t, objs, "Iterable")
+	sum := findProp(t, iterable, "sum")
+	if sum.Doc != "sum returns sum of elements in self." {
+		t.Errorf("wrong doc of Iterable#sum. got=%q", sum.Doc)
+	}
+
+	// operators are listed
+	findProp(t, findObj(t, objs, "Int"), "+")
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	objs := []*ObjDoc{
+		{
+			Name: "Foo",
+			Props: []*PropDoc{
+				{Name: "-", Doc: "- returns the diff."},
+				{Name: "a", Doc: "a returns 1.\nIt has no args."},
+				{Name: "b", Doc: ""},
+			},
+			Mixins: []string{"Iterable"},
+		},
+	}
+
+	pages, err := Render(objs, "md")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []*Page{
+		{
+			FileName: "README.md",
+			Content: []byte(`# Builtin objects
+
+<!-- generated by ` + "`pangaea doc`" + `. DO NOT EDIT. -->
+
+- [Foo](./Foo.md)
+`),
+		},
+		{
+			FileName: "Foo.md",
+			Content: []byte(`# Foo
+
+<!-- generated by ` + "`pangaea doc`" + `. DO NOT EDIT. -->
+
+Foo also has props of [Iterable](./Iterable.md).
+
+## ` + "`-`" + `
+
+\- returns the diff.
+
+## ` + "`a`" + `
+
+a returns 1.  
+It has no args.
+
+## ` + "`b`" + `
+
+(no docs)
+`),
+		},
+	}
+
+	testPages(t, pages, expected)
+}
+
+func TestRenderHTML(t *testing.T) {
+	objs := []*ObjDoc{
+		{
+			Name: "Foo",
+			Props: []*PropDoc{
+				{Name: "<", Doc: "< compares a < b.\nIt returns bool."},
+				{Name: "b", Doc: ""},
+			},
+			Mixins: []string{},
+		},
+	}
+
+	pages, err := Render(objs, "html")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pages) != 2 || pages[0].FileName != "index.html" || pages[1].FileName != "Foo.html" {
+		t.Fatalf("wrong pages. got=%v", pages)
+	}
+
+	if !strings.Contains(string(pages[0].Content), `<li><a href="./Foo.html">Foo</a></li>`) {
+		t.Errorf("index must link to Foo. got=\n%s", pages[0].Content)
+	}
+
+	contents := []string{
+		`<h2 id="&lt;"><code>&lt;</code></h2>`,
+		`<p>&lt; compares a &lt; b.<br>It returns bool.</p>`,
+		`<p>(no docs)</p>`,
+	}
+	for _, c := range contents {
+		if !strings.Contains(string(pages[1].Content), c) {
+			t.Errorf("page must contain %q. got=\n%s", c, pages[1].Content)
+		}
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	_, err := Render([]*ObjDoc{}, "pdf")
+	if err == nil {
+		t.Fatalf("error must be raised")
+	}
+
+	expected := `unknown format "pdf" (must be md or html)`
+	if err.Error() != expected {
+		t.Errorf("wrong error. expected=%q, got=%q", expected, err.Error())
+	}
+}
+
+func TestGeneratedPagesAreUpToDate(t *testing.T) {
+	pages, err := Render(Collect(), "md")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, page := range pages {
+		actual, err := os.ReadFile(filepath.Join("..", DefaultDir, page.FileName))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(actual) != string(page.Content) {
+			t.Errorf("%s is outdated. run `pangaea doc`", page.FileName)
+		}
+	}
+}
+
+func testPages(t *testing.T, actual []*Page, expected []*Page) {
+	if len(actual) != len(expected) {
+		t.Fatalf("wrong number of pages. expected=%d, got=%d", len(expected), len(actual))
+	}
+
+	for i, e := range expected {
+		if actual[i].FileName != e.FileName {
+			t.Errorf("wrong file name. expected=%s, got=%s", e.FileName, actual[i].FileName)
+		}
+		if string(actual[i].Content) != string(e.Content) {
+			t.Errorf("wrong content of %s. expected=\n%s\ngot=\n%s", e.FileName, e.Content, actual[i].Content)
+		}
+	}
+}
+
+func findObj(t *testing.T, objs []*ObjDoc, name string) *ObjDoc {
+	for _, o := range objs {
+		if o.Name == name {
+			return o
+		}
+	}
+	t.Fatalf("obj %s is not found", name)
+	return nil
+}
+
+func findProp(t *testing.T, obj *ObjDoc, name string) *PropDoc {
+	for _, p := range obj.Props {
+		if p.Name == name {
+			return p
+		}
+	}
+	t.Fatalf("prop %s is not found in %s", name, obj.Name)
+	return nil
+}
+
+func hasMixin(obj *ObjDoc, mixin string) bool {
+	for _, m := range obj.Mixins {
+		if m == mixin {
+			return true
+		}
+	}
+	return false
+}
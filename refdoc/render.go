@@ -0,0 +1,143 @@
+package refdoc
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Page is a generated file.
+type Page struct {
+	FileName string
+	Content  []byte
+}
+
+// Render returns pages of objs in format ("md" or "html").
+// The index page lists links to pages of objs.
+func Render(objs []*ObjDoc, format string) ([]*Page, error) {
+	switch format {
+	case "md":
+		return renderMarkdown(objs), nil
+	case "html":
+		return renderHTML(objs)
+	default:
+		return nil, fmt.Errorf("unknown format %q (must be md or html)", format)
+	}
+}
+
+func renderMarkdown(objs []*ObjDoc) []*Page {
+	pages := []*Page{}
+
+	var index bytes.Buffer
+	index.WriteString("# Builtin objects\n\n")
+	index.WriteString("<!-- generated by `pangaea doc`. DO NOT EDIT. -->\n\n")
+	for _, obj := range objs {
+		fmt.Fprintf(&index, "- [%s](./%s.md)\n", obj.Name, obj.Name)
+	}
+	pages = append(pages, &Page{FileName: "README.md", Content: index.Bytes()})
+
+	for _, obj := range objs {
+		var out bytes.Buffer
+		fmt.Fprintf(&out, "# %s\n\n", obj.Name)
+		out.WriteString("<!-- generated by `pangaea doc`. DO NOT EDIT. -->\n")
+
+		if len(obj.Mixins) > 0 {
+			links := []string{}
+			for _, mixin := range obj.Mixins {
+				links = append(links, fmt.Sprintf("[%s](./%s.md)", mixin, mixin))
+			}
+			fmt.Fprintf(&out, "\n%s also has props of %s.\n", obj.Name, strings.Join(links, ", "))
+		}
+
+		for _, prop := range obj.Props {
+			fmt.Fprintf(&out, "\n## `%s`\n\n", prop.Name)
+			if prop.Doc == "" {
+				out.WriteString("(no docs)\n")
+				continue
+			}
+			// NOTE: break lines explicitly
+			out.WriteString(strings.Join(escapeMarkdownLines(prop.Doc), "  \n") + "\n")
+		}
+		pages = append(pages, &Page{FileName: obj.Name + ".md", Content: out.Bytes()})
+	}
+
+	return pages
+}
+
+// escapeMarkdownLines escapes line heads which are parsed as blocks (such as `- returns ...` of prop `-`).
+func escapeMarkdownLines(doc string) []string {
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		if line != "" && strings.ContainsRune(`-+*#>=`, rune(line[0])) {
+			lines[i] = `\` + line
+		}
+	}
+	return lines
+}
+
+var htmlIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<!-- generated by pangaea doc. DO NOT EDIT. -->
+<html>
+<head>
+<meta charset="utf-8">
+<title>Builtin objects</title>
+</head>
+<body>
+<h1>Builtin objects</h1>
+<ul>
+{{- range .}}
+<li><a href="./{{.Name}}.html">{{.Name}}</a></li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+var htmlObjTemplate = template.Must(template.New("obj").Funcs(template.FuncMap{
+	"lines": func(s string) []string { return strings.Split(s, "\n") },
+}).Parse(`<!DOCTYPE html>
+<!-- generated by pangaea doc. DO NOT EDIT. -->
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+<p><a href="./index.html">Builtin objects</a></p>
+{{- if .Mixins}}
+<p>{{.Name}} also has props of
+{{- range $i, $m := .Mixins}}{{if $i}},{{end}} <a href="./{{$m}}.html">{{$m}}</a>{{end}}.</p>
+{{- end}}
+{{- range .Props}}
+<h2 id="{{.Name}}"><code>{{.Name}}</code></h2>
+{{- if .Doc}}
+<p>{{range $i, $l := lines .Doc}}{{if $i}}<br>{{end}}{{$l}}{{end}}</p>
+{{- else}}
+<p>(no docs)</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+func renderHTML(objs []*ObjDoc) ([]*Page, error) {
+	pages := []*Page{}
+
+	var index bytes.Buffer
+	if err := htmlIndexTemplate.Execute(&index, objs); err != nil {
+		return nil, err
+	}
+	pages = append(pages, &Page{FileName: "index.html", Content: index.Bytes()})
+
+	for _, obj := range objs {
+		var out bytes.Buffer
+		if err := htmlObjTemplate.Execute(&out, obj); err != nil {
+			return nil, err
+		}
+		pages = append(pages, &Page{FileName: obj.Name + ".html", Content: out.Bytes()})
+	}
+
+	return pages, nil
+}
@@ -0,0 +1,73 @@
+package runscript
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Syuparn/pangaea/ast"
+)
+
+// fields of ast nodes which are not shown in dumpAST
+var ignoredASTFields = map[string]bool{
+	"Src":   true,
+	"Token": true,
+}
+
+// dumpAST returns the tree of node with fields of each node.
+func dumpAST(node ast.Node) string {
+	var out strings.Builder
+	dumpASTValue(&out, reflect.ValueOf(node), 0)
+	return out.String()
+}
+
+func dumpASTValue(out *strings.Builder, v reflect.Value, depth int) {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		out.WriteString("nil\n")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		dumpASTValue(out, v.Elem(), depth)
+	case reflect.Struct:
+		out.WriteString(v.Type().Name() + "\n")
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() || ignoredASTFields[f.Name] {
+				continue
+			}
+			dumpASTField(out, f.Name, v.Field(i), depth+1)
+		}
+	case reflect.String:
+		out.WriteString(fmt.Sprintf("%q\n", v.String()))
+	default:
+		out.WriteString(fmt.Sprintf("%v\n", v.Interface()))
+	}
+}
+
+func dumpASTField(out *strings.Builder, name string, v reflect.Value, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			out.WriteString(fmt.Sprintf("%s%s[%d]: ", indent, name, i))
+			dumpASTValue(out, v.Index(i), depth)
+		}
+	case reflect.Map:
+		// sort keys to dump deterministically
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, k := range keys {
+			out.WriteString(fmt.Sprintf("%s%s[%v]: ", indent, name, k.Interface()))
+			dumpASTValue(out, v.MapIndex(k), depth)
+		}
+	default:
+		out.WriteString(fmt.Sprintf("%s%s: ", indent, name))
+		dumpASTValue(out, v, depth)
+	}
+}
@@ -0,0 +1,304 @@
+package runscript
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Syuparn/pangaea/object"
+	"github.com/Syuparn/pangaea/parser"
+)
+
+var commandPattern = regexp.MustCompile(`^:([a-z]+)(\s+(?s:(.*)))?$`)
+
+// replCommand is a REPL meta-command such as `:help`.
+type replCommand struct {
+	name        string
+	arg         string
+	description string
+	run         func(r *repl, arg string)
+}
+
+// NOTE: initialized in init() because `:help` refers replCommands
+var replCommands []replCommand
+
+func init() {
+	replCommands = []replCommand{
+		{"help", "", "show REPL commands", runHelpCommand},
+		{"doc", "prop", "show docs of prop (such as `map`, `Str#camel` or `[1].map`)", runDocCommand},
+		{"which", "expr.prop", "show the obj which has prop in the proto chain of expr", runWhichCommand},
+		{"time", "expr", "evaluate expr and show the elapsed time", runTimeCommand},
+		{"env", "", "show variables defined in the session", runEnvCommand},
+		{"load", "file", "evaluate the source file", runLoadCommand},
+		{"reload", "", "evaluate loaded files again", runReloadCommand},
+		{"save", "file", "save inputs evaluated without errors to the file", runSaveCommand},
+		{"reset", "", "discard all variables and loaded files", runResetCommand},
+		{"ast", "expr", "show the syntax tree of expr", runASTCommand},
+		{"width", "[n]", "show or set the max width of printed values (0: terminal width)", runWidthCommand},
+	}
+}
+
+// isCommand reports whether src is a REPL command (such as `:help`).
+func isCommand(src string) bool {
+	return commandPattern.MatchString(strings.TrimSpace(src))
+}
+
+func (r *repl) runCommand(src string) {
+	matched := commandPattern.FindStringSubmatch(strings.TrimSpace(src))
+	name, arg := matched[1], strings.TrimSpace(matched[3])
+
+	for _, c := range replCommands {
+		if c.name != name {
+			continue
+		}
+
+		// NOTE: args in brackets are optional
+		if c.arg != "" && !strings.HasPrefix(c.arg, "[") && arg == "" {
+			r.printf("usage: :%s %s\n", c.name, c.arg)
+			return
+		}
+		c.run(r, arg)
+		return
+	}
+
+	r.printf("unknown command :%s (see :help)\n", name)
+}
+
+func (r *repl) printf(format string, a ...any) {
+	fmt.Fprintf(r.out, format, a...)
+}
+
+func runHelpCommand(r *repl, _ string) {
+	usages := make([]string, len(replCommands))
+	width := 0
+	for i, c := range replCommands {
+		usages[i] = strings.TrimSpace(":" + c.name + " " + c.arg)
+		width = max(width, len(usages[i]))
+	}
+
+	for i, c := range replCommands {
+		r.printf("%-*s  %s\n", width, usages[i], c.description)
+	}
+	r.printf("%-*s  %s\n", width, lastResultName, "the last evaluated value")
+}
+
+func runDocCommand(r *repl, arg string) {
+	docs, err := loadNativeDocs()
+	if err != nil {
+		r.printf("failed to load docs: %s\n", err)
+		return
+	}
+
+	// only prop name is specified
+	if !strings.ContainsAny(arg, "#.") {
+		found := docs.find(arg)
+		if len(found) == 0 {
+			r.printf("no docs found for `%s`\n", arg)
+			return
+		}
+		for _, d := range found {
+			r.printf("%s\n", d)
+		}
+		return
+	}
+
+	recvSrc, prop := splitProp(arg)
+	if doc, ok := docs.get(recvSrc, prop); ok {
+		r.printf("%s\n", doc)
+		return
+	}
+
+	recv, ok := r.evalReceiver(recvSrc)
+	if !ok {
+		return
+	}
+	propHash := object.GetSymHash(prop)
+	v, ok := object.FindPropAlongProtos(recv, propHash)
+	if !ok {
+		r.printf("%s does not have prop `%s`\n", recv.Repr(), prop)
+		return
+	}
+
+	// find the obj where the prop is written (such as `Iterable` for `Str#map`)
+	// NOTE: props of mixins are copied to each obj, so FindPropOwner cannot be used
+	for _, objName := range docs.objNames(prop) {
+		obj, ok := r.env.Get(object.GetSymHash(objName))
+		if !ok {
+			continue
+		}
+		if written, ok := object.FindPropAlongProtos(obj, propHash); ok && written == v {
+			doc, _ := docs.get(objName, prop)
+			r.printf("%s\n", doc)
+			return
+		}
+	}
+
+	// docs of Go props and user-defined funcs are attached to funcs themselves
+	if doc := funcDoc(v); doc != "" {
+		owner, _ := object.FindPropOwner(recv, propHash)
+		r.printf("%s#%s\n  %s\n", owner.Repr(), prop, strings.ReplaceAll(doc, "\n", "\n  "))
+		return
+	}
+	r.printf("no docs found for `%s`\n", arg)
+}
+
+// funcDoc returns the doc comment of f ("" if f is not a func).
+func funcDoc(f object.PanObject) string {
+	switch f := f.(type) {
+	case *object.PanFunc:
+		return f.Doc
+	case *object.PanBuiltIn:
+		return f.Doc
+	}
+	return ""
+}
+
+func runWhichCommand(r *repl, arg string) {
+	recvSrc, prop := splitProp(arg)
+	if recvSrc == "" || prop == "" {
+		r.printf("usage: :which expr.prop\n")
+		return
+	}
+
+	recv, ok := r.evalReceiver(recvSrc)
+	if !ok {
+		return
+	}
+
+	owner, ok := object.FindPropOwner(recv, object.GetSymHash(prop))
+	if !ok {
+		r.printf("%s does not have prop `%s`\n", recv.Repr(), prop)
+		return
+	}
+	r.printf("%s\n", owner.Repr())
+}
+
+func runTimeCommand(r *repl, arg string) {
+	start := time.Now()
+	ok := r.eval(arg)
+	elapsed := time.Since(start)
+
+	if ok {
+		r.session = append(r.session, arg)
+	}
+	r.printf("time: %s\n", elapsed.Round(time.Microsecond))
+}
+
+func runEnvCommand(r *repl, _ string) {
+	names := []string{}
+	for h := range r.env.Store {
+		if name := symHash2Name(h); !r.builtInNames[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		v, _ := r.env.Get(object.GetSymHash(name))
+		r.printf("%s: %s\n", name, v.Repr())
+	}
+}
+
+func runLoadCommand(r *repl, arg string) {
+	if r.load(arg) {
+		r.loadedFiles = append(r.loadedFiles, arg)
+	}
+}
+
+func runReloadCommand(r *repl, _ string) {
+	if len(r.loadedFiles) == 0 {
+		r.printf("no files are loaded\n")
+		return
+	}
+
+	for _, fileName := range r.loadedFiles {
+		r.load(fileName)
+	}
+}
+
+func runSaveCommand(r *repl, arg string) {
+	src := strings.Join(r.session, "\n") + "\n"
+	if err := os.WriteFile(arg, []byte(src), 0o644); err != nil {
+		r.printf("%s\n", err)
+		return
+	}
+	r.printf("saved %d inputs to %s\n", len(r.session), arg)
+}
+
+func runResetCommand(r *repl, _ string) {
+	r.reset()
+	r.printf("environment is reset\n")
+}
+
+func runASTCommand(r *repl, arg string) {
+	program, err := parser.Parse(parser.NewReader(strings.NewReader(arg), object.StdinFileName))
+	if err != nil {
+		io.WriteString(r.out, err.Error())
+		return
+	}
+	io.WriteString(r.out, dumpAST(program))
+}
+
+func runWidthCommand(r *repl, arg string) {
+	if arg == "" {
+		r.printf("%d\n", r.width)
+		return
+	}
+
+	width, err := strconv.Atoi(arg)
+	if err != nil || width < 0 {
+		r.printf("width must be a non-negative int but got %s\n", arg)
+		return
+	}
+	r.width = width
+}
+
+// load evaluates the file and prints the result.
+func (r *repl) load(fileName string) bool {
+	src, err := os.ReadFile(fileName)
+	if err != nil {
+		r.printf("%s\n", err)
+		return false
+	}
+
+	evaluated, err := r.parseAndEval(string(src), fileName)
+	if err != nil {
+		io.WriteString(r.out, err.Error())
+		return false
+	}
+	if err, ok := evaluated.(*object.PanErr); ok {
+		r.printf("%s\n%s\n", err.Inspect(), err.StackTrace)
+		return false
+	}
+	r.printf("loaded %s\n", fileName)
+	return true
+}
+
+// evalReceiver evaluates src without printing the result.
+func (r *repl) evalReceiver(src string) (object.PanObject, bool) {
+	evaluated, err := r.parseAndEval(src, object.StdinFileName)
+	if err != nil {
+		io.WriteString(r.out, err.Error())
+		return nil, false
+	}
+
+	if err, ok := evaluated.(*object.PanErr); ok {
+		r.printf("%s\n", err.Repr())
+		return nil, false
+	}
+	return evaluated, true
+}
+
+// splitProp splits `expr.prop` (or `expr#prop`) into expr and prop.
+func splitProp(src string) (string, string) {
+	i := strings.LastIndexAny(src, "#.")
+	if i < 0 {
+		return "", src
+	}
+	return strings.TrimSpace(src[:i]), strings.TrimSpace(src[i+1:])
+}
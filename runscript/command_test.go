@@ -0,0 +1,236 @@
+package runscript
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// runREPLCommands runs REPL and returns output without the header.
+func runREPLCommands(t *testing.T, lines ...string) string {
+	t.Helper()
+
+	in := strings.NewReader(strings.Join(lines, "\n") + "\n")
+	out := &bytes.Buffer{}
+	StartREPL("", in, out)
+
+	header := fmt.Sprintf("Pangaea %s\nmulti : multi-line mode\nsingle: single-line mode (default)\n:help : show REPL commands\n\n", Version)
+	return strings.TrimPrefix(out.String(), header)
+}
+
+func TestREPLCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected string
+	}{
+		{
+			"last result",
+			[]string{"1 + 2", "_ * 2", "_"},
+			">>> 3\n>>> 6\n>>> 6\n>>> ",
+		},
+		{
+			"errors are not assigned to last result",
+			[]string{"1", "1 / 0", "_"},
+			">>> 1\n>>> ZeroDivisionErr: cannot be divided by 0\n>>> 1\n>>> ",
+		},
+		{
+			"doc with obj",
+			[]string{":doc Str#camel"},
+			">>> Str#camel\n  camel makes self camelCase.\n>>> ",
+		},
+		{
+			"doc with receiver",
+			[]string{`:doc "a".camel`},
+			">>> Str#camel\n  camel makes self camelCase.\n>>> ",
+		},
+		{
+			"doc of mixin prop",
+			[]string{":doc [1].sum"},
+			">>> Iterable#sum\n  sum returns sum of elements in self.\n>>> ",
+		},
+		{
+			"doc with prop name",
+			[]string{":doc camel?"},
+			">>> Str#camel?\n  camel? returns whether self is camelCased.\n>>> ",
+		},
+		{
+			"doc of builtin prop written in Go",
+			[]string{":doc Str.uc"},
+			">>> Str#uc\n  uc returns an upper-case str.\n>>> ",
+		},
+		{
+			"doc not found",
+			[]string{"o := {a: m{1}}", ":doc o.a", ":doc unknown", ":doc 1.unknown"},
+			">>> {\"a\": {|self| 1}}\n>>> no docs found for `o.a`\n>>> no docs found for `unknown`\n>>> 1 does not have prop `unknown`\n>>> ",
+		},
+		{
+			"which",
+			[]string{":which 1.map", ":which {a: 1}.a", ":which 1.unknown"},
+			">>> Int\n>>> {\"a\": 1}\n>>> 1 does not have prop `unknown`\n>>> ",
+		},
+		{
+			"which with invalid receiver",
+			[]string{":which undefined.a"},
+			">>> NameErr: name `undefined` is not defined\n>>> ",
+		},
+		{
+			"env",
+			[]string{"b := 2", "a := [1]", ":env"},
+			">>> 2\n>>> [1]\n>>> a: [1]\nb: 2\n>>> ",
+		},
+		{
+			"reset",
+			[]string{"a := 1", ":reset", ":env", "a"},
+			">>> 1\n>>> environment is reset\n>>> >>> NameErr: name `a` is not defined\n>>> ",
+		},
+		{
+			"ast",
+			[]string{":ast -a + 1"},
+			">>> Program\n" +
+				"  Stmts[0]: ExprStmt\n" +
+				"    Expr: InfixExpr\n" +
+				"      Left: PrefixExpr\n" +
+				"        Operator: \"-\"\n" +
+				"        Right: Ident\n" +
+				"          Value: \"a\"\n" +
+				"          IsPrivate: false\n" +
+				"          IdentAttr: 0\n" +
+				"      Operator: \"+\"\n" +
+				"      Right: IntLiteral\n" +
+				"        Value: 1\n" +
+				">>> ",
+		},
+		{
+			"usage",
+			[]string{":ast", ":which a"},
+			">>> usage: :ast expr\n>>> usage: :which expr.prop\n>>> ",
+		},
+		{
+			"width",
+			[]string{":width", ":width 10", ":width", "[1, 2, 3, 4]", ":width -1"},
+			">>> 0\n>>> >>> 10\n>>> [\n  1,\n  2,\n  3,\n  4\n]\n>>> width must be a non-negative int but got -1\n>>> ",
+		},
+		{
+			"unknown command",
+			[]string{":foo 1"},
+			">>> unknown command :foo (see :help)\n>>> ",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			actual := runREPLCommands(t, tt.lines...)
+			if actual != tt.expected {
+				t.Errorf("wrong output:\nexpected=%q\ngot=%q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestREPLTruncatesLongValues(t *testing.T) {
+	actual := runREPLCommands(t, "(0:150).A")
+
+	if !strings.HasSuffix(actual, "  99,\n  ... (50 more)\n]\n>>> ") {
+		t.Errorf("elements must be truncated. got=%q", actual)
+	}
+}
+
+func TestREPLHelpCommand(t *testing.T) {
+	actual := runREPLCommands(t, ":help")
+
+	for _, c := range replCommands {
+		if !strings.Contains(actual, ":"+c.name) {
+			t.Errorf("help must contain :%s. got=%q", c.name, actual)
+		}
+	}
+}
+
+func TestREPLTimeCommand(t *testing.T) {
+	actual := runREPLCommands(t, ":time 1 + 2", "_")
+
+	expected := regexp.MustCompile(`^>>> 3\ntime: [0-9.]+[µm]?s\n>>> 3\n>>> $`)
+	if !expected.MatchString(actual) {
+		t.Errorf("wrong output: got=%q", actual)
+	}
+}
+
+func TestREPLSaveAndLoadCommand(t *testing.T) {
+	dir := t.TempDir()
+	session := filepath.Join(dir, "session.pangaea")
+
+	actual := runREPLCommands(t, "a := 1", "1 / 0", "b := a + 1", ":save "+session)
+	expected := ">>> 1\n>>> ZeroDivisionErr: cannot be divided by 0\n>>> 2\n>>> saved 2 inputs to " + session + "\n>>> "
+	if actual != expected {
+		t.Errorf("wrong output:\nexpected=%q\ngot=%q", expected, actual)
+	}
+
+	saved, err := os.ReadFile(session)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(saved) != "a := 1\nb := a + 1\n" {
+		t.Errorf("wrong session: got=%q", string(saved))
+	}
+
+	actual = runREPLCommands(t, ":load "+session, "b")
+	expected = ">>> loaded " + session + "\n>>> 2\n>>> "
+	if actual != expected {
+		t.Errorf("wrong output:\nexpected=%q\ngot=%q", expected, actual)
+	}
+}
+
+func TestREPLDocCommandOfUserDefinedProp(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.pangaea")
+
+	if err := os.WriteFile(src, []byte("o := {\n  # a returns 1.\n  # it has no args.\n  a: m{1},\n}\n"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	actual := runREPLCommands(t, ":load "+src, ":doc o.a")
+	expected := ">>> loaded " + src + "\n>>> {\"a\": {|self| 1}}#a\n  a returns 1.\n  it has no args.\n>>> "
+	if actual != expected {
+		t.Errorf("wrong output:\nexpected=%q\ngot=%q", expected, actual)
+	}
+}
+
+func TestREPLReloadCommand(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.pangaea")
+
+	if err := os.WriteFile(src, []byte("a := 1"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	in := strings.NewReader("")
+	out := &bytes.Buffer{}
+	r := newREPL("", in, out)
+	r.runCommand(":load " + src)
+
+	if err := os.WriteFile(src, []byte("a := 2"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	r.runCommand(":reload")
+	r.eval("a")
+
+	expected := "loaded " + src + "\nloaded " + src + "\n2\n"
+	if out.String() != expected {
+		t.Errorf("wrong output:\nexpected=%q\ngot=%q", expected, out.String())
+	}
+}
+
+func TestREPLLoadCommandError(t *testing.T) {
+	actual := runREPLCommands(t, ":load /no/such/file.pangaea", ":reload")
+
+	expected := ">>> open /no/such/file.pangaea: no such file or directory\n>>> no files are loaded\n>>> "
+	if actual != expected {
+		t.Errorf("wrong output:\nexpected=%q\ngot=%q", expected, actual)
+	}
+}
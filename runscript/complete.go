@@ -0,0 +1,161 @@
+package runscript
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Syuparn/pangaea/object"
+)
+
+var (
+	identPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*[?!]?$`)
+	// receivers whose props can be completed without evaluation
+	receiverPatterns = []struct {
+		pattern *regexp.Regexp
+		obj     object.PanObject
+	}{
+		{regexp.MustCompile(`(^|[^a-zA-Z0-9_.])[0-9][0-9_]*\.[0-9][0-9_]*$`), object.BuiltInFloatObj},
+		{regexp.MustCompile(`(^|[^a-zA-Z0-9_.])[0-9][0-9_]*$`), object.BuiltInIntObj},
+		{regexp.MustCompile(`("[^"]*"|'[a-zA-Z_][a-zA-Z0-9_]*[?!]?)$`), object.BuiltInStrObj},
+	}
+	varChainPattern = regexp.MustCompile(`[a-zA-Z_][a-zA-Z0-9_]*[?!]?(\.[a-zA-Z_][a-zA-Z0-9_]*[?!]?)*$`)
+)
+
+// completer completes names of variables and props in REPL.
+type completer struct {
+	// env returns the current environment (which is replaced by `:reset`)
+	env func() *object.Env
+}
+
+// complete returns variables (or props if the word follows `.`) which start with the word before pos.
+func (c *completer) complete(line []rune, pos int) ([]string, int) {
+	start := pos
+	for start > 0 && isIdentRune(line[start-1]) {
+		start--
+	}
+	word := string(line[start:pos])
+
+	if start > 0 && line[start-1] == '.' {
+		recv, ok := c.receiver(string(line[:start-1]))
+		if !ok {
+			return []string{}, start
+		}
+		return propNames(recv, word), start
+	}
+
+	return c.varNames(word), start
+}
+
+// receiver returns the receiver before `.` if it can be found without evaluation.
+func (c *completer) receiver(src string) (object.PanObject, bool) {
+	// props of `a&.`, `a~.` and `a=.` are the same as those of `a.`
+	src = strings.TrimRight(src, "&~=")
+
+	for _, r := range receiverPatterns {
+		if r.pattern.MatchString(src) {
+			return r.obj, true
+		}
+	}
+
+	chain := varChainPattern.FindString(src)
+	if chain == "" {
+		return nil, false
+	}
+	names := strings.Split(chain, ".")
+
+	recv, ok := c.env().Get(object.GetSymHash(names[0]))
+	if !ok {
+		return nil, false
+	}
+
+	for _, name := range names[1:] {
+		// NOTE: return values of funcs are unknown until they are called
+		if isFunc(recv) {
+			return nil, false
+		}
+		recv, ok = object.FindPropAlongProtos(recv, object.GetSymHash(name))
+		if !ok {
+			return nil, false
+		}
+	}
+
+	if isFunc(recv) && len(names) > 1 {
+		return nil, false
+	}
+	return recv, true
+}
+
+func (c *completer) varNames(prefix string) []string {
+	names := []string{}
+	for env := c.env(); env != nil; env = env.Outer() {
+		for h := range env.Store {
+			names = append(names, symHash2Name(h))
+		}
+	}
+	return filterNames(names, prefix)
+}
+
+// propNames returns names of props recv has, which are keys of recv and its protos.
+func propNames(recv object.PanObject, prefix string) []string {
+	names := []string{}
+	for o := recv; o != nil; o = o.Proto() {
+		obj, ok := o.(*object.PanObj)
+		if !ok {
+			continue
+		}
+
+		keys := *obj.Keys
+		// private props are shown only if the prefix is private
+		if strings.HasPrefix(prefix, "_") {
+			keys = append(append([]object.SymHash{}, keys...), *obj.PrivateKeys...)
+		}
+
+		for _, h := range keys {
+			if _, ok := object.FindPropAlongProtos(recv, h); ok {
+				names = append(names, symHash2Name(h))
+			}
+		}
+	}
+	return filterNames(names, prefix)
+}
+
+// filterNames returns sorted unique identifiers which start with prefix.
+func filterNames(names []string, prefix string) []string {
+	found := map[string]bool{}
+	filtered := []string{}
+	for _, name := range names {
+		if found[name] || !strings.HasPrefix(name, prefix) || !identPattern.MatchString(name) {
+			continue
+		}
+		found[name] = true
+		filtered = append(filtered, name)
+	}
+	sort.Strings(filtered)
+	return filtered
+}
+
+func symHash2Name(h object.SymHash) string {
+	str, ok := object.SymHash2Str(h)
+	if !ok {
+		return ""
+	}
+	s, ok := object.TraceProtoOfStr(str)
+	if !ok {
+		return ""
+	}
+	return s.Value
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || r == '?' || r == '!' ||
+		('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')
+}
+
+func isFunc(o object.PanObject) bool {
+	switch o.Type() {
+	case object.FuncType, object.BuiltInType:
+		return true
+	}
+	return false
+}
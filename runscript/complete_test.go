@@ -0,0 +1,67 @@
+package runscript
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Syuparn/pangaea/evaluator"
+	"github.com/Syuparn/pangaea/object"
+	"github.com/Syuparn/pangaea/parser"
+)
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		line          string
+		expected      []string
+		expectedStart int
+	}{
+		{"foo", []string{"foo", "foo2"}, 0},
+		{"1 + fo", []string{"foo", "foo2"}, 4},
+		{"Ran", []string{"Range"}, 0},
+		{"foo.b", []string{"bar", "baz", "bear", "bottomN", "bro"}, 4},
+		{"foo.ba", []string{"bar", "baz"}, 4},
+		{"foo&.ba", []string{"bar", "baz"}, 5},
+		// private props are completed only if the prefix is private
+		{"foo._q", []string{"_qux"}, 4},
+		{"foo.qu", []string{}, 4},
+		{"foo.bar.ev", []string{"even?"}, 8},
+		{"1.ev", []string{"even?"}, 2},
+		{"1.5.fl", []string{"flatten", "flipflop", "floor"}, 4},
+		{`"a".uc`, []string{"uc", "uc?"}, 4},
+		{"'a.uc", []string{"uc", "uc?"}, 3},
+		{"Int.ev", []string{"even?"}, 4},
+		// return values of funcs are unknown
+		{"foo.baz.", []string{}, 8},
+		{"[1].le", []string{}, 4},
+		{"undefined.a", []string{}, 10},
+	}
+
+	env := setup(strings.NewReader(""), io.Discard, object.StdinFileName)
+	src := "foo := {bar: 1, baz: {|x| x}, _qux: 2}; foo2 := 2"
+	program, err := parser.Parse(parser.NewReader(strings.NewReader(src), object.StdinFileName))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	evaluator.Eval(program, env)
+
+	c := &completer{env: func() *object.Env { return env }}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.line, func(t *testing.T) {
+			line := []rune(tt.line)
+			actual, start := c.complete(line, len(line))
+
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("wrong candidates: expected=%v, got=%v", tt.expected, actual)
+			}
+
+			if start != tt.expectedStart {
+				t.Errorf("wrong start: expected=%d, got=%d", tt.expectedStart, start)
+			}
+		})
+	}
+}
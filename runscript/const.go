@@ -11,4 +11,8 @@ const (
 	// ReadStdinLinesAndWritesTemplate is a template src for one-liner option
 	// similar to ReadStdinLinesTemplate but also prints evaluated values to stdout
 	ReadStdinLinesAndWritesTemplate = "<>@{%s}@p"
+	// continuationPrompt is a REPL prompt to read the rest of an incomplete expression
+	continuationPrompt = "... "
+	// lastResultName is a variable name of the last evaluated value in REPL
+	lastResultName = "_"
 )
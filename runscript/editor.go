@@ -0,0 +1,340 @@
+package runscript
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// key codes read in raw mode
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyCtrlJ     = 10
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEsc       = 27
+	keyBackspace = 127
+)
+
+// completeFunc returns candidates which replace line[start:pos].
+type completeFunc func(line []rune, pos int) (candidates []string, start int)
+
+// lineEditor is a readline-style lineReader with cursor editing, history and completion.
+type lineEditor struct {
+	term     terminal
+	keys     *bufio.Reader
+	history  *history
+	complete completeFunc
+}
+
+func newLineEditor(term terminal, h *history, complete completeFunc) *lineEditor {
+	return &lineEditor{
+		term:     term,
+		keys:     bufio.NewReader(term),
+		history:  h,
+		complete: complete,
+	}
+}
+
+// editState is a state of the line being edited.
+type editState struct {
+	prompt string
+	buf    []rune
+	pos    int
+	// histIndex is the index of history shown (len(history.lines) means the new line)
+	histIndex int
+	// edited is the new line saved while history is shown
+	edited []rune
+}
+
+func (e *lineEditor) ReadLine(prompt string) (string, error) {
+	// lines of prompt except the last one are not redrawn
+	if i := strings.LastIndex(prompt, "\n"); i >= 0 {
+		io.WriteString(e.term, prompt[:i+1])
+		prompt = prompt[i+1:]
+	}
+
+	restore, err := e.term.MakeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	s := &editState{prompt: prompt, buf: []rune{}, histIndex: len(e.history.lines)}
+	e.refresh(s)
+
+	for {
+		r, _, err := e.keys.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case keyEnter, keyCtrlJ:
+			io.WriteString(e.term, "\r\n")
+			line := string(s.buf)
+			e.history.add(line)
+			return line, nil
+		case keyCtrlC:
+			io.WriteString(e.term, "^C\r\n")
+			return "", errInterrupted
+		case keyCtrlD:
+			if len(s.buf) == 0 {
+				io.WriteString(e.term, "\r\n")
+				return "", io.EOF
+			}
+			s.delete()
+		case keyTab:
+			e.completeWord(s)
+		case keyBackspace, keyCtrlH:
+			s.backspace()
+		case keyCtrlA:
+			s.pos = 0
+		case keyCtrlE:
+			s.pos = len(s.buf)
+		case keyCtrlB:
+			s.moveLeft()
+		case keyCtrlF:
+			s.moveRight()
+		case keyCtrlK:
+			s.buf = s.buf[:s.pos]
+		case keyCtrlU:
+			s.buf = s.buf[s.pos:]
+			s.pos = 0
+		case keyCtrlW:
+			s.deleteWord()
+		case keyCtrlP:
+			e.prevHistory(s)
+		case keyCtrlN:
+			e.nextHistory(s)
+		case keyCtrlL:
+			io.WriteString(e.term, "\x1b[H\x1b[2J")
+		case keyEsc:
+			e.escape(s)
+		default:
+			if unicode.IsPrint(r) {
+				s.insert(r)
+			}
+		}
+
+		e.refresh(s)
+	}
+}
+
+// escape handles escape sequences such as arrow keys.
+func (e *lineEditor) escape(s *editState) {
+	r, _, err := e.keys.ReadRune()
+	if err != nil || (r != '[' && r != 'O') {
+		return
+	}
+
+	// read parameters until the final byte is found
+	params := []rune{}
+	for {
+		r, _, err = e.keys.ReadRune()
+		if err != nil {
+			return
+		}
+		if r >= 0x40 && r <= 0x7e {
+			break
+		}
+		params = append(params, r)
+	}
+
+	switch r {
+	case 'A':
+		e.prevHistory(s)
+	case 'B':
+		e.nextHistory(s)
+	case 'C':
+		s.moveRight()
+	case 'D':
+		s.moveLeft()
+	case 'H':
+		s.pos = 0
+	case 'F':
+		s.pos = len(s.buf)
+	case '~':
+		switch string(params) {
+		case "1", "7":
+			s.pos = 0
+		case "4", "8":
+			s.pos = len(s.buf)
+		case "3":
+			s.delete()
+		}
+	}
+}
+
+func (e *lineEditor) prevHistory(s *editState) {
+	if s.histIndex == 0 {
+		return
+	}
+	if s.histIndex == len(e.history.lines) {
+		s.edited = s.buf
+	}
+	s.histIndex--
+	s.setLine([]rune(e.history.lines[s.histIndex]))
+}
+
+func (e *lineEditor) nextHistory(s *editState) {
+	if s.histIndex >= len(e.history.lines) {
+		return
+	}
+	s.histIndex++
+	if s.histIndex == len(e.history.lines) {
+		s.setLine(s.edited)
+		return
+	}
+	s.setLine([]rune(e.history.lines[s.histIndex]))
+}
+
+// completeWord completes the word before the cursor.
+// If candidates have no common prefix longer than the word, they are listed instead.
+func (e *lineEditor) completeWord(s *editState) {
+	if e.complete == nil {
+		return
+	}
+
+	candidates, start := e.complete(s.buf, s.pos)
+	if len(candidates) == 0 {
+		io.WriteString(e.term, "\a")
+		return
+	}
+
+	prefix := []rune(commonPrefix(candidates))
+	if len(prefix) > s.pos-start {
+		buf := append([]rune{}, s.buf[:start]...)
+		buf = append(buf, prefix...)
+		s.buf = append(buf, s.buf[s.pos:]...)
+		s.pos = start + len(prefix)
+		return
+	}
+
+	if len(candidates) > 1 {
+		io.WriteString(e.term, "\r\n"+strings.Join(candidates, "  ")+"\r\n")
+	}
+}
+
+// refresh redraws the prompt and the line.
+func (e *lineEditor) refresh(s *editState) {
+	buf, pos := s.buf, s.pos
+
+	// scroll horizontally if the line is wider than the terminal
+	if avail := e.term.Width() - strWidth([]rune(s.prompt)) - 1; avail > 0 && strWidth(buf) > avail {
+		start := 0
+		for strWidth(buf[start:pos]) > avail {
+			start++
+		}
+		end := len(buf)
+		for strWidth(buf[start:end]) > avail {
+			end--
+		}
+		buf, pos = buf[start:end], pos-start
+	}
+
+	out := "\r" + s.prompt + string(buf) + "\x1b[K"
+	if w := strWidth(buf[pos:]); w > 0 {
+		out += fmt.Sprintf("\x1b[%dD", w)
+	}
+	io.WriteString(e.term, out)
+}
+
+func (s *editState) insert(r rune) {
+	buf := append([]rune{}, s.buf[:s.pos]...)
+	buf = append(buf, r)
+	s.buf = append(buf, s.buf[s.pos:]...)
+	s.pos++
+}
+
+func (s *editState) backspace() {
+	if s.pos == 0 {
+		return
+	}
+	s.buf = append(s.buf[:s.pos-1], s.buf[s.pos:]...)
+	s.pos--
+}
+
+func (s *editState) delete() {
+	if s.pos == len(s.buf) {
+		return
+	}
+	s.buf = append(s.buf[:s.pos], s.buf[s.pos+1:]...)
+}
+
+// deleteWord deletes the word (and following spaces) before the cursor.
+func (s *editState) deleteWord() {
+	i := s.pos
+	for i > 0 && unicode.IsSpace(s.buf[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(s.buf[i-1]) {
+		i--
+	}
+	s.buf = append(s.buf[:i], s.buf[s.pos:]...)
+	s.pos = i
+}
+
+func (s *editState) moveLeft() {
+	if s.pos > 0 {
+		s.pos--
+	}
+}
+
+func (s *editState) moveRight() {
+	if s.pos < len(s.buf) {
+		s.pos++
+	}
+}
+
+func (s *editState) setLine(line []rune) {
+	s.buf = append([]rune{}, line...)
+	s.pos = len(s.buf)
+}
+
+func commonPrefix(strs []string) string {
+	prefix := []rune(strs[0])
+	for _, str := range strs[1:] {
+		runes := []rune(str)
+		i := 0
+		for i < len(prefix) && i < len(runes) && prefix[i] == runes[i] {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	return string(prefix)
+}
+
+// strWidth returns the number of columns runes occupy in the terminal.
+func strWidth(runes []rune) int {
+	w := 0
+	for _, r := range runes {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Mn, r):
+		return 0
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul),
+		0xff01 <= r && r <= 0xff60, 0xffe0 <= r && r <= 0xffe6, 0x1f300 <= r && r <= 0x1faff:
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,275 @@
+package runscript
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fakeTerminal is a terminal which reads keys from a string.
+type fakeTerminal struct {
+	keys  io.Reader
+	out   bytes.Buffer
+	width int
+	// raw is true while the terminal is in raw mode
+	raw bool
+}
+
+func newFakeTerminal(keys string) *fakeTerminal {
+	return &fakeTerminal{keys: strings.NewReader(keys), width: 80}
+}
+
+func (t *fakeTerminal) Read(p []byte) (int, error) { return t.keys.Read(p) }
+
+func (t *fakeTerminal) Write(p []byte) (int, error) { return t.out.Write(p) }
+
+func (t *fakeTerminal) MakeRaw() (func() error, error) {
+	t.raw = true
+	return func() error {
+		t.raw = false
+		return nil
+	}, nil
+}
+
+func (t *fakeTerminal) Width() int { return t.width }
+
+func TestLineEditorReadLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     string
+		expected string
+	}{
+		{"plain", "a := 2\r", "a := 2"},
+		{"line feed", "a := 2\n", "a := 2"},
+		{"backspace", "abc\x7f\x7fd\r", "ad"},
+		{"ctrl-h", "abc\x08d\r", "abd"},
+		{"left arrow", "ac\x1b[Db\r", "abc"},
+		{"right arrow", "ac\x1b[D\x1b[Cb\r", "acb"},
+		{"ctrl-b and ctrl-f", "ac\x02\x02\x06b\r", "abc"},
+		{"home and end", "bc\x1b[Ha\x1b[Fd\r", "abcd"},
+		{"home and end (vt)", "bc\x1b[1~a\x1b[4~d\r", "abcd"},
+		{"ctrl-a and ctrl-e", "bc\x01a\x05d\r", "abcd"},
+		{"delete", "abc\x01\x1b[3~\r", "bc"},
+		{"ctrl-d deletes a char", "abc\x01\x04\r", "bc"},
+		{"ctrl-k", "abcd\x02\x02\x0b\r", "ab"},
+		{"ctrl-u", "abcd\x02\x02\x15\r", "cd"},
+		{"ctrl-w", "foo bar  \x17baz\r", "foo baz"},
+		{"multibyte", "あい\x1b[Dう\r", "あうい"},
+		{"unknown escape sequence", "a\x1b[5;2Xb\r", "ab"},
+		{"control chars are ignored", "a\x00\x1fb\r", "ab"},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			term := newFakeTerminal(tt.keys)
+			e := newLineEditor(term, loadHistory("", historyLimit), nil)
+
+			actual, err := e.ReadLine(">>> ")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != tt.expected {
+				t.Errorf("wrong line: expected=%q, got=%q", tt.expected, actual)
+			}
+
+			if term.raw {
+				t.Errorf("terminal mode must be restored")
+			}
+		})
+	}
+}
+
+func TestLineEditorReadLineErr(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     string
+		expected error
+	}{
+		{"ctrl-c", "abc\x03", errInterrupted},
+		{"ctrl-d in empty line", "\x04", io.EOF},
+		{"end of input", "abc", io.EOF},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			term := newFakeTerminal(tt.keys)
+			e := newLineEditor(term, loadHistory("", historyLimit), nil)
+
+			_, err := e.ReadLine(">>> ")
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("wrong error: expected=%v, got=%v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestLineEditorHistory(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     string
+		expected string
+	}{
+		{"up", "\x1b[A\r", "second"},
+		{"up twice", "\x1b[A\x1b[A\r", "first"},
+		{"up beyond the oldest", "\x1b[A\x1b[A\x1b[A\r", "first"},
+		{"up and down", "\x1b[A\x1b[A\x1b[B\r", "second"},
+		{"edited line is restored", "new\x1b[A\x1b[B\r", "new"},
+		{"ctrl-p and ctrl-n", "\x10\x10\x0e\r", "second"},
+		{"edit history", "\x1b[A!\r", "second!"},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			h := loadHistory("", historyLimit)
+			h.add("first")
+			h.add("second")
+
+			e := newLineEditor(newFakeTerminal(tt.keys), h, nil)
+
+			actual, err := e.ReadLine(">>> ")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != tt.expected {
+				t.Errorf("wrong line: expected=%q, got=%q", tt.expected, actual)
+			}
+
+			if h.lines[len(h.lines)-1] != tt.expected {
+				t.Errorf("line must be added to history: got=%v", h.lines)
+			}
+		})
+	}
+}
+
+func TestLineEditorComplete(t *testing.T) {
+	complete := func(line []rune, pos int) ([]string, int) {
+		start := pos
+		for start > 0 && line[start-1] != ' ' {
+			start--
+		}
+
+		candidates := []string{}
+		for _, c := range []string{"foo", "foobar", "foobaz", "hoge"} {
+			if strings.HasPrefix(c, string(line[start:pos])) {
+				candidates = append(candidates, c)
+			}
+		}
+		return candidates, start
+	}
+
+	tests := []struct {
+		name           string
+		keys           string
+		expected       string
+		expectedOutput string
+	}{
+		{"single candidate", "1 h\t\r", "1 hoge", ""},
+		{"common prefix", "fo\t\r", "foo", ""},
+		{"list candidates", "foob\t\r", "fooba", ""},
+		{"list candidates if no prefix is added", "fooba\t\r", "fooba", "\r\nfoobar  foobaz\r\n"},
+		{"no candidates", "x\t\r", "x", "\a"},
+		{"complete before cursor", "ho + 1\x1b[D\x1b[D\x1b[D\x1b[D\t\r", "hoge + 1", ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			term := newFakeTerminal(tt.keys)
+			e := newLineEditor(term, loadHistory("", historyLimit), complete)
+
+			actual, err := e.ReadLine(">>> ")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != tt.expected {
+				t.Errorf("wrong line: expected=%q, got=%q", tt.expected, actual)
+			}
+
+			if !strings.Contains(term.out.String(), tt.expectedOutput) {
+				t.Errorf("output %q must contain %q", term.out.String(), tt.expectedOutput)
+			}
+		})
+	}
+}
+
+func TestLineEditorRefresh(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   string
+		buf      string
+		pos      int
+		width    int
+		expected string
+	}{
+		{"cursor at the end", ">>> ", "abc", 3, 80, "\r>>> abc\x1b[K"},
+		{"cursor in the middle", ">>> ", "abc", 1, 80, "\r>>> abc\x1b[K\x1b[2D"},
+		{"wide chars", ">>> ", "あいa", 1, 80, "\r>>> あいa\x1b[K\x1b[3D"},
+		{"scroll", ">>> ", "abcdefghij", 10, 10, "\r>>> fghij\x1b[K"},
+		{"scroll with cursor at the head", ">>> ", "abcdefghij", 0, 10, "\r>>> abcde\x1b[K\x1b[5D"},
+		{"unknown width", ">>> ", "abcdefghij", 10, 0, "\r>>> abcdefghij\x1b[K"},
+	}
+
+	for _, tt := range tests {
+		tt := tt // pin
+
+		t.Run(tt.name, func(t *testing.T) {
+			term := newFakeTerminal("")
+			term.width = tt.width
+			e := newLineEditor(term, loadHistory("", historyLimit), nil)
+
+			e.refresh(&editState{prompt: tt.prompt, buf: []rune(tt.buf), pos: tt.pos})
+
+			if term.out.String() != tt.expected {
+				t.Errorf("wrong output: expected=%q, got=%q", tt.expected, term.out.String())
+			}
+		})
+	}
+}
+
+func TestLineEditorMultiLinePrompt(t *testing.T) {
+	term := newFakeTerminal("ab\r")
+	e := newLineEditor(term, loadHistory("", historyLimit), nil)
+
+	_, err := e.ReadLine("header\n>> ")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// header is written only once
+	expected := "header\n\r>> \x1b[K\r>> a\x1b[K\r>> ab\x1b[K\r\n"
+	if term.out.String() != expected {
+		t.Errorf("wrong output: expected=%q, got=%q", expected, term.out.String())
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	tests := []struct {
+		strs     []string
+		expected string
+	}{
+		{[]string{"abc"}, "abc"},
+		{[]string{"abc", "abd"}, "ab"},
+		{[]string{"abc", "ab", "abd"}, "ab"},
+		{[]string{"abc", "x"}, ""},
+		{[]string{"あいう", "あいえ"}, "あい"},
+	}
+
+	for _, tt := range tests {
+		actual := commonPrefix(tt.strs)
+		if actual != tt.expected {
+			t.Errorf("wrong prefix of %v: expected=%q, got=%q", tt.strs, tt.expected, actual)
+		}
+	}
+}
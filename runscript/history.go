@@ -0,0 +1,67 @@
+package runscript
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// history is a list of lines entered in REPL.
+// It is saved in the file at path (not saved if path is empty).
+type history struct {
+	lines []string
+	path  string
+	limit int
+}
+
+// loadHistory reads history from path. Missing file is treated as empty history.
+func loadHistory(path string, limit int) *history {
+	h := &history{lines: []string{}, path: path, limit: limit}
+	if path == "" {
+		return h
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return h
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		h.append(scanner.Text())
+	}
+	return h
+}
+
+// add appends line to history and saves it to the file.
+// Blank lines and the same line as the previous one are ignored.
+func (h *history) add(line string) {
+	if !h.append(line) {
+		return
+	}
+	// NOTE: history is just a convenience, so failures of saving are ignored
+	h.save()
+}
+
+func (h *history) append(line string) bool {
+	if strings.TrimSpace(line) == "" {
+		return false
+	}
+	if len(h.lines) > 0 && h.lines[len(h.lines)-1] == line {
+		return false
+	}
+
+	h.lines = append(h.lines, line)
+	if len(h.lines) > h.limit {
+		h.lines = h.lines[len(h.lines)-h.limit:]
+	}
+	return true
+}
+
+func (h *history) save() error {
+	if h.path == "" {
+		return nil
+	}
+	return os.WriteFile(h.path, []byte(strings.Join(h.lines, "\n")+"\n"), 0o600)
+}
@@ -0,0 +1,25 @@
+package runscript
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHistoryFile(t *testing.T) {
+	path := t.TempDir() + "/.pangaea_history"
+
+	h := loadHistory(path, 3)
+	for _, line := range []string{"a", "b", "", "  ", "b", "c", "d"} {
+		h.add(line)
+	}
+
+	expected := []string{"b", "c", "d"}
+	if !reflect.DeepEqual(h.lines, expected) {
+		t.Errorf("wrong history: expected=%v, got=%v", expected, h.lines)
+	}
+
+	loaded := loadHistory(path, 3)
+	if !reflect.DeepEqual(loaded.lines, expected) {
+		t.Errorf("wrong loaded history: expected=%v, got=%v", expected, loaded.lines)
+	}
+}
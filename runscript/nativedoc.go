@@ -0,0 +1,89 @@
+package runscript
+
+import (
+	"bufio"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Syuparn/pangaea/native"
+)
+
+var (
+	nativeDocPattern = regexp.MustCompile(`^  # ?(.*)$`)
+	nativeKeyPattern = regexp.MustCompile(`^  ([^\s#][^\s:]*):`)
+)
+
+// nativeDocs are doc comments of props written in native/*.pangaea.
+type nativeDocs struct {
+	// docs maps `Obj#prop` to comment lines
+	docs map[string][]string
+}
+
+func loadNativeDocs() (*nativeDocs, error) {
+	d := &nativeDocs{docs: map[string][]string{}}
+
+	fileNames, err := fs.Glob(native.FS, "*.pangaea")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fileName := range fileNames {
+		f, err := native.FS.Open(fileName)
+		if err != nil {
+			return nil, err
+		}
+		objName := strings.TrimSuffix(fileName, ".pangaea")
+
+		// comments just above the prop are its doc
+		comments := []string{}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if m := nativeDocPattern.FindStringSubmatch(line); m != nil {
+				comments = append(comments, m[1])
+				continue
+			}
+			if m := nativeKeyPattern.FindStringSubmatch(line); m != nil && len(comments) > 0 {
+				d.docs[objName+"#"+m[1]] = comments
+			}
+			comments = []string{}
+		}
+		f.Close()
+	}
+
+	return d, nil
+}
+
+// get returns formatted doc of objName#prop.
+func (d *nativeDocs) get(objName string, prop string) (string, bool) {
+	key := objName + "#" + prop
+	comments, ok := d.docs[key]
+	if !ok {
+		return "", false
+	}
+	return key + "\n  " + strings.Join(comments, "\n  "), true
+}
+
+// objNames returns names of objs which have doc of prop.
+func (d *nativeDocs) objNames(prop string) []string {
+	names := []string{}
+	for key := range d.docs {
+		if name, ok := strings.CutSuffix(key, "#"+prop); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// find returns formatted docs of prop in all objs.
+func (d *nativeDocs) find(prop string) []string {
+	found := []string{}
+	for _, name := range d.objNames(prop) {
+		doc, _ := d.get(name, prop)
+		found = append(found, doc)
+	}
+	return found
+}
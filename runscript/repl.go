@@ -6,13 +6,15 @@
 package runscript
 
 import (
-	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"github.com/mattn/go-isatty"
+
 	"github.com/Syuparn/pangaea/evaluator"
 	"github.com/Syuparn/pangaea/object"
 	"github.com/Syuparn/pangaea/parser"
@@ -20,55 +22,138 @@ import (
 
 // StartREPL starts Pangaea interpreter.
 func StartREPL(preloadSrc string, in io.Reader, out io.Writer) {
-	env := setup(in, out, object.StdinFileName)
-	scanner := newScanner(in)
-
-	// eval preloadSrc and update env
-	exitCode := runSource(parser.NewReader(strings.NewReader(preloadSrc), object.StdinFileName), in, out, env)
-	if exitCode != 0 {
-		fmt.Fprintf(os.Stderr, "errors occurred in preload sources\n\n")
-	}
+	r := newREPL(preloadSrc, in, out)
+	scanner := newScanner(newLineReader(in, out, r.currentEnv))
 
 	io.WriteString(out, fmt.Sprintf("Pangaea %s\n", Version))
 	io.WriteString(out, fmt.Sprintln("multi : multi-line mode"))
 	io.WriteString(out, fmt.Sprintln("single: single-line mode (default)"))
+	io.WriteString(out, fmt.Sprintln(":help : show REPL commands"))
 	io.WriteString(out, fmt.Sprintln())
 
 	for {
-		io.WriteString(out, scanner.Prompt())
 		scanned, ok := scanner.Scan()
 		if !ok {
 			return
 		}
 
-		program, err := parser.Parse(parser.NewReader(strings.NewReader(scanned), object.StdinFileName))
+		if strings.TrimSpace(scanned) == "" {
+			continue
+		}
 
-		if err != nil {
-			io.WriteString(out, err.Error())
+		if isCommand(scanned) {
+			r.runCommand(scanned)
 			continue
 		}
 
-		evaluated := evaluator.Eval(program, env)
+		if r.eval(scanned) {
+			r.session = append(r.session, scanned)
+		}
+	}
+}
+
+// repl is a state of a REPL session.
+type repl struct {
+	in         io.Reader
+	out        io.Writer
+	preloadSrc string
+	env        *object.Env
+	// builtInNames are names of variables defined before preloadSrc is evaluated
+	builtInNames map[string]bool
+	// loadedFiles are files loaded by `:load`, which are evaluated again by `:reload`
+	loadedFiles []string
+	// session is a list of inputs evaluated without errors, which is saved by `:save`
+	session []string
+	// width is the max width of printed values (the terminal width is used if 0)
+	width int
+}
+
+func newREPL(preloadSrc string, in io.Reader, out io.Writer) *repl {
+	r := &repl{in: in, out: out, preloadSrc: preloadSrc}
+	r.reset()
+	return r
+}
+
+// reset makes a new environment and evaluates preloadSrc in it.
+func (r *repl) reset() {
+	env := setup(r.in, r.out, object.StdinFileName)
 
-		io.WriteString(out, evaluated.Repr()+"\n")
+	r.builtInNames = map[string]bool{}
+	for h := range env.Store {
+		r.builtInNames[symHash2Name(h)] = true
+	}
+
+	// eval preloadSrc and update env
+	exitCode := runSource(parser.NewReader(strings.NewReader(r.preloadSrc), object.StdinFileName), r.in, r.out, env)
+	if exitCode != 0 {
+		fmt.Fprintf(os.Stderr, "errors occurred in preload sources\n\n")
 	}
+
+	r.env = env
+	r.loadedFiles = []string{}
+	r.session = []string{}
+}
+
+func (r *repl) currentEnv() *object.Env {
+	return r.env
 }
 
-func newScanner(in io.Reader) *_Scanner {
-	scanner := bufio.NewScanner(in)
+// eval evaluates src and prints the result. The result is assigned to `_` unless it is an error.
+func (r *repl) eval(src string) bool {
+	evaluated, err := r.parseAndEval(src, object.StdinFileName)
+	if err != nil {
+		io.WriteString(r.out, err.Error())
+		return false
+	}
+
+	io.WriteString(r.out, object.PrettyRepr(evaluated, r.prettyOptions())+"\n")
+	if _, ok := evaluated.(*object.PanErr); ok {
+		return false
+	}
+
+	r.env.Set(object.GetSymHash(lastResultName), evaluated)
+	return true
+}
+
+// prettyOptions returns options to print values.
+// Values are colored only if out is a terminal.
+func (r *repl) prettyOptions() object.PrettyOptions {
+	opts := object.PrettyOptions{
+		Width:    r.width,
+		MaxElems: object.DefaultPrettyMaxElems,
+	}
+
+	if f, ok := r.out.(*os.File); ok && isatty.IsTerminal(f.Fd()) {
+		opts.Colored = true
+		if opts.Width == 0 {
+			opts.Width = terminalWidth(f)
+		}
+	}
+	return opts
+}
+
+func (r *repl) parseAndEval(src string, fileName string) (object.PanObject, error) {
+	program, err := parser.Parse(parser.NewReader(strings.NewReader(src), fileName))
+	if err != nil {
+		return nil, err
+	}
+	return evaluator.Eval(program, r.env), nil
+}
+
+func newScanner(reader lineReader) *_Scanner {
 	return &_Scanner{
-		mode:    newScannerState("single"),
-		scanner: scanner,
+		mode:   newScannerState("single"),
+		reader: reader,
 	}
 }
 
 type _Scanner struct {
-	mode    _ScannerState
-	scanner *bufio.Scanner
+	mode   _ScannerState
+	reader lineReader
 }
 
 func (s *_Scanner) Scan() (string, bool) {
-	scanned, ok := s.mode.Scan(s.scanner)
+	scanned, ok := s.mode.Scan(s.reader)
 
 	if s.isModeChangeCode(scanned) {
 		s.changeMode(scanned)
@@ -100,7 +185,7 @@ func (s *_Scanner) changeMode(modeName string) {
 
 type _ScannerState interface {
 	Prompt() string
-	Scan(scanner *bufio.Scanner) (string, bool)
+	Scan(reader lineReader) (string, bool)
 	Name() string
 }
 
@@ -118,13 +203,33 @@ type _SingleLineScannerState struct{}
 
 func (s *_SingleLineScannerState) Prompt() string { return ">>> " }
 
-func (s *_SingleLineScannerState) Scan(scanner *bufio.Scanner) (string, bool) {
-	ok := scanner.Scan()
-	if !ok {
+func (s *_SingleLineScannerState) Scan(reader lineReader) (string, bool) {
+	line, err := reader.ReadLine(s.Prompt())
+	if errors.Is(err, errInterrupted) {
+		return "", true
+	}
+	if err != nil {
 		return "", false
 	}
 
-	return scanner.Text(), true
+	// continue reading lines while the expression is incomplete
+	lines := []string{line}
+	for isIncomplete(strings.Join(lines, "\n")) {
+		line, err := reader.ReadLine(continuationPrompt)
+		if errors.Is(err, errInterrupted) {
+			return "", true
+		}
+		if err != nil {
+			return "", false
+		}
+		// give up continuation by an empty line (parse error is shown)
+		if line == "" {
+			break
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), true
 }
 
 func (s *_SingleLineScannerState) Name() string { return "single" }
@@ -135,16 +240,20 @@ func (s *_MultiLineScannerState) Prompt() string {
 	return "<< multi-line mode (read lines until empty line is found) >>\n"
 }
 
-func (s *_MultiLineScannerState) Scan(scanner *bufio.Scanner) (string, bool) {
+func (s *_MultiLineScannerState) Scan(reader lineReader) (string, bool) {
 	// read lines until empty line is found
 	var out bytes.Buffer
 
+	prompt := s.Prompt()
 	for {
-		ok := scanner.Scan()
-		if !ok {
+		line, err := reader.ReadLine(prompt)
+		if errors.Is(err, errInterrupted) {
+			return "", true
+		}
+		if err != nil {
 			return "", false
 		}
-		line := scanner.Text()
+		prompt = ""
 		if line == "" {
 			break
 		}
@@ -159,3 +268,9 @@ func (s *_MultiLineScannerState) Scan(scanner *bufio.Scanner) (string, bool) {
 }
 
 func (s *_MultiLineScannerState) Name() string { return "multi" }
+
+// isIncomplete reports whether src ends before an expression is completed.
+func isIncomplete(src string) bool {
+	_, err := parser.Parse(parser.NewReader(strings.NewReader(src), object.StdinFileName))
+	return errors.Is(err, parser.ErrIncomplete)
+}
@@ -1,7 +1,6 @@
 package runscript
 
 import (
-	"bufio"
 	"bytes"
 	"fmt"
 	"io"
@@ -17,6 +16,7 @@ func TestStartREPL(t *testing.T) {
 		fmt.Sprintf("Pangaea %s", Version),
 		"multi : multi-line mode",
 		"single: single-line mode (default)",
+		":help : show REPL commands",
 		"",
 		">>> 2",
 		">>> 4",
@@ -41,6 +41,7 @@ func TestStartREPLPreloadSrc(t *testing.T) {
 		fmt.Sprintf("Pangaea %s", Version),
 		"multi : multi-line mode",
 		"single: single-line mode (default)",
+		":help : show REPL commands",
 		"",
 		">>> 4",
 		">>> ",
@@ -55,6 +56,29 @@ func TestStartREPLPreloadSrc(t *testing.T) {
 	}
 }
 
+func TestStartREPLContinuation(t *testing.T) {
+	in := strings.NewReader("a := [1,\n2]\n\na.sum\n")
+	out := &bytes.Buffer{}
+	expected := strings.Join([]string{
+		fmt.Sprintf("Pangaea %s", Version),
+		"multi : multi-line mode",
+		"single: single-line mode (default)",
+		":help : show REPL commands",
+		"",
+		">>> ... [1, 2]",
+		">>> >>> 3",
+		">>> ",
+	}, "\n")
+
+	StartREPL("", in, out)
+
+	actual := out.String()
+	if actual != expected {
+		t.Errorf("output is wrong: \nexpected: \n%s\nactual: \n%s\n",
+			expected, actual)
+	}
+}
+
 func TestNewScanner(t *testing.T) {
 	tests := []struct {
 		in       io.Reader
@@ -63,14 +87,14 @@ func TestNewScanner(t *testing.T) {
 		{
 			os.Stdin,
 			&_Scanner{
-				mode:    &_SingleLineScannerState{},
-				scanner: bufio.NewScanner(os.Stdin),
+				mode:   &_SingleLineScannerState{},
+				reader: newPlainReader(os.Stdin, io.Discard),
 			},
 		},
 	}
 
 	for _, tt := range tests {
-		actual := newScanner(tt.in)
+		actual := newScanner(newPlainReader(tt.in, io.Discard))
 		// NOTE: scanners cannot be compared...
 		if actual.mode.Name() != tt.expected.mode.Name() {
 			t.Errorf("wrong mode: expected=%s, got=%s",
@@ -96,10 +120,24 @@ func TestSingleLineScan(t *testing.T) {
 			strings.NewReader("'first\n'second\n"),
 			"'first",
 		},
+		// incomplete expressions are continued
+		{
+			strings.NewReader("[1,\n2\n]\n'next\n"),
+			"[1,\n2\n]",
+		},
+		{
+			strings.NewReader("`a\nb`\n"),
+			"`a\nb`",
+		},
+		// an empty line gives up continuation
+		{
+			strings.NewReader("[1,\n\n3]\n"),
+			"[1,",
+		},
 	}
 
 	for _, tt := range tests {
-		scanner := newScanner(tt.in)
+		scanner := newScanner(newPlainReader(tt.in, io.Discard))
 		actual, ok := scanner.Scan()
 		if !ok {
 			t.Fatalf("ok must be true (in testcase %s)", tt.expected)
@@ -118,13 +156,13 @@ func TestPrompt(t *testing.T) {
 		expected string
 	}{
 		{
-			newScanner(os.Stdin),
+			newScanner(newPlainReader(os.Stdin, io.Discard)),
 			">>> ",
 		},
 		{
 			&_Scanner{
-				scanner: bufio.NewScanner(os.Stdin),
-				mode:    newScannerState("multi"),
+				reader: newPlainReader(os.Stdin, io.Discard),
+				mode:   newScannerState("multi"),
 			},
 			"<< multi-line mode (read lines until empty line is found) >>\n",
 		},
@@ -158,7 +196,7 @@ func TestMultiLineScan(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		scanner := newScanner(tt.in)
+		scanner := newScanner(newPlainReader(tt.in, io.Discard))
 		scanner.changeMode("multi")
 
 		actual, ok := scanner.Scan()
@@ -198,7 +236,7 @@ func TestNewScannerState(t *testing.T) {
 
 func TestScannerChangeMode(t *testing.T) {
 	in := strings.NewReader("single\nmulti\nmulti\n\nsingle\n\n")
-	scanner := newScanner(in)
+	scanner := newScanner(newPlainReader(in, io.Discard))
 
 	_, ok := scanner.Scan()
 	if !ok {
@@ -1,6 +1,7 @@
 package runscript
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/Syuparn/pangaea/di"
+	"github.com/Syuparn/pangaea/envs"
 	"github.com/Syuparn/pangaea/evaluator"
 	"github.com/Syuparn/pangaea/object"
 	"github.com/Syuparn/pangaea/parser"
@@ -101,5 +103,11 @@ func setup(in io.Reader, out io.Writer, fileName string) *object.Env {
 	// NOTE: InjectFrom must be called after BuiltInKernelObj is set up
 	env.InjectFrom(object.BuiltInKernelObj)
 
+	// raise RecursionErr instead of Go stack overflow
+	// NOTE: depth of func calls is not limited by default (same as other resources)
+	if depth := envs.MaxDepth(); depth > 0 {
+		env.SetSandbox(object.NewSandbox(context.Background(), object.Limits{MaxDepth: depth}))
+	}
+
 	return env
 }
@@ -0,0 +1,72 @@
+package runscript
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/Syuparn/pangaea/envs"
+	"github.com/Syuparn/pangaea/object"
+)
+
+// historyLimit is the max number of lines saved in the REPL history file.
+const historyLimit = 1000
+
+// errInterrupted is returned by lineReader if the input line is canceled by Ctrl-C.
+var errInterrupted = errors.New("interrupted")
+
+// lineReader reads REPL input line by line.
+type lineReader interface {
+	// ReadLine shows prompt and reads a line (without the trailing newline).
+	ReadLine(prompt string) (string, error)
+}
+
+// terminal is an interactive terminal edited by lineEditor.
+// It is abstracted so that lineEditor can be tested without a real terminal.
+type terminal interface {
+	io.Reader
+	io.Writer
+	// MakeRaw puts the terminal into raw mode and returns a func to restore the previous mode.
+	MakeRaw() (func() error, error)
+	// Width returns the number of columns of the terminal (0 if unknown).
+	Width() int
+}
+
+// newLineReader returns lineEditor if in is a terminal, otherwise plainReader.
+func newLineReader(in io.Reader, out io.Writer, env func() *object.Env) lineReader {
+	f, ok := in.(*os.File)
+	if !ok {
+		return newPlainReader(in, out)
+	}
+
+	term, err := newTerminal(f, out)
+	if err != nil {
+		return newPlainReader(in, out)
+	}
+
+	c := &completer{env: env}
+	return newLineEditor(term, loadHistory(envs.DefaultHistoryFile(), historyLimit), c.complete)
+}
+
+// plainReader reads lines from input which is not a terminal (such as a pipe).
+type plainReader struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+func newPlainReader(in io.Reader, out io.Writer) *plainReader {
+	return &plainReader{scanner: bufio.NewScanner(in), out: out}
+}
+
+func (r *plainReader) ReadLine(prompt string) (string, error) {
+	io.WriteString(r.out, prompt)
+
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
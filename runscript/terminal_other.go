@@ -0,0 +1,17 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package runscript
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+func newTerminal(in *os.File, out io.Writer) (terminal, error) {
+	return nil, errors.New("line editing is not supported on this platform")
+}
+
+func terminalWidth(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,71 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package runscript
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// fileTerminal is a terminal connected to stdin.
+type fileTerminal struct {
+	in  *os.File
+	out io.Writer
+}
+
+func newTerminal(in *os.File, out io.Writer) (terminal, error) {
+	// NOTE: fails if in is not a terminal
+	if _, err := unix.IoctlGetTermios(int(in.Fd()), ioctlReadTermios); err != nil {
+		return nil, err
+	}
+	return &fileTerminal{in: in, out: out}, nil
+}
+
+func (t *fileTerminal) Read(p []byte) (int, error) {
+	return t.in.Read(p)
+}
+
+func (t *fileTerminal) Write(p []byte) (int, error) {
+	return t.out.Write(p)
+}
+
+func (t *fileTerminal) MakeRaw() (func() error, error) {
+	fd := int(t.in.Fd())
+	old, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	// same as cfmakeraw(3)
+	raw := *old
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+
+	if err := unix.IoctlSetTermios(fd, ioctlWriteTermios, &raw); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return unix.IoctlSetTermios(fd, ioctlWriteTermios, old)
+	}, nil
+}
+
+func (t *fileTerminal) Width() int {
+	return terminalWidth(t.in)
+}
+
+// terminalWidth returns the number of columns of the terminal f (0 if f is not a terminal).
+func terminalWidth(f *os.File) int {
+	ws, err := unix.IoctlGetWinsize(int(f.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0
+	}
+	return int(ws.Col)
+}
@@ -1,23 +1,69 @@
 const go = new Go();
 
+// key of the workspace saved in localStorage
+const workspaceKey = 'pangaea-playground-workspace';
+// prefix of the URI fragment containing compressed source code
+const sharedSourcePrefix = '#z=';
+const defaultFileName = 'main.pangaea';
+const defaultSourceCode = `"Hello, world!".p`;
+// maximum number of evaluated steps in an execution
+const maxSteps = 1000000;
+// time (ms) to wait for the canceled execution before the worker is terminated
+const cancelTimeout = 1000;
+
+// workspace is a set of files edited in the playground
+// NOTE: files can import each other by relative paths like `import("./foo")`
+let workspace = {
+  files: {[defaultFileName]: defaultSourceCode},
+  current: defaultFileName,
+};
+
+// worker executing scripts
+let worker = null;
+// resolves the running execution (null if nothing is running)
+let running = null;
+
 // run wasm
-fetch("./main.wasm").then(response => 
+// NOTE: wasm in the main thread is used for editor functions such as format
+startWorker();
+fetch("./main.wasm").then(response =>
   response.arrayBuffer()
 ).then(bytes =>
   WebAssembly.instantiate(bytes, go.importObject)
 ).then(obj => {
   go.run(obj.instance);
-  // HACK: replace "now loading..." with sample code
-  document.getElementById('source').value = initializeSourceCode();
+  return initializeWorkspace();
+}).then(() => {
+  initializeExamples();
+  // HACK: replace "now loading..." with the source code
+  showCurrentFile();
+  document.getElementById('source').addEventListener('input', onSourceChanged);
 });
 
+async function initializeWorkspace() {
+  const saved = loadWorkspace();
+  if (saved !== null) {
+    workspace = saved;
+  }
+
+  // source code in the URL is opened in the current file
+  const shared = await initializeSourceCode();
+  if (shared !== null) {
+    workspace.files[workspace.current] = shared;
+    saveWorkspace();
+  }
+}
 
-function initializeSourceCode() {
-  const fragment = trimURIFragment();
-  if (fragment === '') {
-    return `"Hello, world!".p`;
+async function initializeSourceCode() {
+  const fragment = location.hash;
+  if (fragment === '' || fragment === '#') {
+    return null;
+  }
+  if (fragment.startsWith(sharedSourcePrefix)) {
+    return decompress(fragment.slice(sharedSourcePrefix.length));
   }
-  return decodeURI(fragment);
+  // NOTE: old URLs have the source code only encoded by encodeURI
+  return decodeURI(trimURIFragment());
 }
 
 function trimURIFragment() {
@@ -25,3 +71,244 @@ function trimURIFragment() {
   // NOTE: replace replaces only the first occurence
   return location.hash.replace('#', '');
 }
+
+function initializeExamples() {
+  const select = document.getElementById('examples');
+  for (const example of pangaea.examples()) {
+    const option = document.createElement('option');
+    option.value = example.name;
+    option.textContent = example.name;
+    option.dataset.src = example.src;
+    select.appendChild(option);
+  }
+}
+
+async function runScript() {
+  if (running !== null) {
+    return;
+  }
+  saveCurrentFile();
+  const src = workspace.files[workspace.current];
+  const stdin = document.getElementById('input').value;
+  const output = document.getElementById('output');
+
+  output.textContent = 'running...';
+  showRunning(true);
+  const result = await executeInWorker([src, stdin, workspace.files, workspace.current, {maxSteps}]);
+  showRunning(false);
+  // NOTE: errmsg includes "timed out after N steps" if the execution is stopped
+  output.textContent = result.stdout + result.errmsg;
+}
+
+function cancelScript() {
+  const resolve = running;
+  if (resolve === null) {
+    return;
+  }
+  worker.postMessage({type: 'cancel'});
+
+  // NOTE: the worker cannot handle the message until the evaluation yields (e.g. in a long built-in call)
+  setTimeout(() => {
+    if (running !== resolve) {
+      return;
+    }
+    worker.terminate();
+    startWorker();
+    running = null;
+    resolve({res: '', stdout: '', errmsg: 'canceled\n', steps: 0});
+  }, cancelTimeout);
+}
+
+function startWorker() {
+  worker = new Worker('worker.js');
+  worker.onmessage = (e) => {
+    const resolve = running;
+    running = null;
+    if (resolve !== null) {
+      resolve(e.data);
+    }
+  };
+}
+
+function executeInWorker(args) {
+  return new Promise(resolve => {
+    running = resolve;
+    worker.postMessage({type: 'execute', args});
+  });
+}
+
+function showRunning(isRunning) {
+  document.getElementById('run').classList.toggle('disabled', isRunning);
+  document.getElementById('stop').classList.toggle('disabled', !isRunning);
+}
+
+function formatScript() {
+  const source = document.getElementById('source');
+  const result = pangaea.format(source.value);
+  if (result.errmsg !== '') {
+    showDiagnostics();
+    return;
+  }
+  source.value = result.src;
+  saveCurrentFile();
+}
+
+async function copyURL() {
+  saveCurrentFile();
+  navigator.clipboard.writeText(await generateURL());
+}
+
+async function generateURL() {
+  // encode compressed source code into URI fragment
+  const sourceCode = workspace.files[workspace.current];
+  const compressed = await compress(sourceCode);
+  return `${location.origin}${location.pathname}${sharedSourcePrefix}${compressed}`;
+}
+
+// compress compresses text into base64url by deflate.
+async function compress(text) {
+  const stream = new Blob([text]).stream().pipeThrough(new CompressionStream('deflate-raw'));
+  const bytes = new Uint8Array(await new Response(stream).arrayBuffer());
+  const base64 = btoa(String.fromCharCode(...bytes));
+  return base64.replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
+}
+
+// decompress decompresses text compressed by compress.
+async function decompress(encoded) {
+  const base64 = encoded.replace(/-/g, '+').replace(/_/g, '/');
+  const bytes = Uint8Array.from(atob(base64), c => c.charCodeAt(0));
+  const stream = new Blob([bytes]).stream().pipeThrough(new DecompressionStream('deflate-raw'));
+  return new Response(stream).text();
+}
+
+// diagnostics
+
+let diagnosticsTimer = null;
+
+function onSourceChanged() {
+  saveCurrentFile();
+  // NOTE: wait for typing to avoid parsing on every key
+  clearTimeout(diagnosticsTimer);
+  diagnosticsTimer = setTimeout(showDiagnostics, 300);
+}
+
+function showDiagnostics() {
+  const source = document.getElementById('source');
+  const diagnostics = document.getElementById('diagnostics');
+  const err = pangaea.parseError(source.value);
+  if (err === null) {
+    source.classList.remove('has-error');
+    diagnostics.textContent = '';
+    return;
+  }
+
+  source.classList.add('has-error');
+  const position = err.line > 0 ? `${workspace.current}:${err.line}:${err.column}: ` : '';
+  diagnostics.textContent = position + err.msg;
+}
+
+// workspace
+
+function loadWorkspace() {
+  try {
+    const saved = JSON.parse(localStorage.getItem(workspaceKey));
+    if (saved === null || !(saved.current in saved.files)) {
+      return null;
+    }
+    return saved;
+  } catch (e) {
+    return null;
+  }
+}
+
+function saveWorkspace() {
+  localStorage.setItem(workspaceKey, JSON.stringify(workspace));
+}
+
+function saveCurrentFile() {
+  workspace.files[workspace.current] = document.getElementById('source').value;
+  saveWorkspace();
+}
+
+function showCurrentFile() {
+  document.getElementById('source').value = workspace.files[workspace.current];
+  showFiles();
+  showDiagnostics();
+}
+
+function showFiles() {
+  const files = document.getElementById('files');
+  files.replaceChildren();
+  for (const name of Object.keys(workspace.files).sort()) {
+    const tab = document.createElement('a');
+    tab.href = '#';
+    tab.textContent = name;
+    tab.className = name === workspace.current ? 'file current' : 'file';
+    tab.onclick = () => { openFile(name); return false; };
+    files.appendChild(tab);
+  }
+}
+
+function openFile(name) {
+  saveCurrentFile();
+  workspace.current = name;
+  saveWorkspace();
+  showCurrentFile();
+}
+
+function newFile() {
+  const name = normalizeFileName(prompt('file name (such as "lib/util.pangaea")'));
+  if (name === null) {
+    return;
+  }
+  if (!(name in workspace.files)) {
+    workspace.files[name] = '';
+  }
+  openFile(name);
+}
+
+function deleteFile() {
+  if (Object.keys(workspace.files).length === 1) {
+    alert('the last file cannot be deleted');
+    return;
+  }
+  if (!confirm(`delete ${workspace.current}?`)) {
+    return;
+  }
+  delete workspace.files[workspace.current];
+  workspace.current = Object.keys(workspace.files).sort()[0];
+  saveWorkspace();
+  showCurrentFile();
+}
+
+function openExample() {
+  const select = document.getElementById('examples');
+  const option = select.selectedOptions[0];
+  select.selectedIndex = 0;
+  if (option === undefined || option.value === '') {
+    return;
+  }
+
+  const name = `examples/${option.value}`;
+  if (name in workspace.files && workspace.files[name] !== option.dataset.src &&
+    !confirm(`overwrite ${name}?`)) {
+    return;
+  }
+  saveCurrentFile();
+  workspace.files[name] = option.dataset.src;
+  workspace.current = name;
+  saveWorkspace();
+  showCurrentFile();
+}
+
+// normalizeFileName returns the file name with the extension (null if it is empty).
+function normalizeFileName(name) {
+  if (name === null) {
+    return null;
+  }
+  name = name.trim().replace(/^(\.\/|\/)+/, '');
+  if (name === '') {
+    return null;
+  }
+  return name.endsWith('.pangaea') ? name : `${name}.pangaea`;
+}
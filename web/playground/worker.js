@@ -0,0 +1,25 @@
+// worker runs scripts not to freeze the page by long (or infinite) evaluation
+importScripts('wasm_exec.js');
+
+const go = new Go();
+
+const ready = fetch("./main.wasm").then(response =>
+  response.arrayBuffer()
+).then(bytes =>
+  WebAssembly.instantiate(bytes, go.importObject)
+).then(obj => {
+  go.run(obj.instance);
+});
+
+onmessage = async (e) => {
+  await ready;
+  switch (e.data.type) {
+    case 'execute':
+      postMessage(await pangaea.execute(...e.data.args));
+      break;
+    case 'cancel':
+      // NOTE: the evaluation yields periodically so that this message can be handled
+      pangaea.cancel();
+      break;
+  }
+};
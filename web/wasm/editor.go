@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"sort"
+	"strings"
+	"syscall/js"
+
+	"github.com/Syuparn/pangaea/example"
+	"github.com/Syuparn/pangaea/object"
+	"github.com/Syuparn/pangaea/parser"
+)
+
+// Format indents source code by nesting of brackets (see parser.Format).
+// args: (src) => ({src, errmsg})
+func Format(this js.Value, args []js.Value) interface{} {
+	src := ""
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		src = args[0].String()
+	}
+
+	formatted, err := parser.Format(src, object.StdinFileName)
+	if err != nil {
+		return map[string]interface{}{
+			"src":    "",
+			"errmsg": err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"src":    formatted,
+		"errmsg": "",
+	}
+}
+
+// ParseError returns the parse error of source code to show diagnostics in the editor.
+// line and column start from 1 (0 if the position is unknown).
+// args: (src) => ({line, column, msg} | null)
+func ParseError(this js.Value, args []js.Value) interface{} {
+	src := ""
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		src = args[0].String()
+	}
+
+	_, err := parser.Parse(parser.NewReader(strings.NewReader(src), object.StdinFileName))
+	if err == nil {
+		return nil
+	}
+
+	line, column := 0, 0
+	var perr *parser.Error
+	if errors.As(err, &perr) && perr.Pos != nil {
+		// NOTE: add 1 otherwise first element is shown as 0
+		line, column = perr.Pos.Line+1, perr.Pos.Column+1
+	}
+
+	return map[string]interface{}{
+		"line":   line,
+		"column": column,
+		"msg":    err.Error(),
+	}
+}
+
+// Examples returns example scripts embedded in the binary.
+// args: () => ([{name, src}])
+func Examples(this js.Value, args []js.Value) interface{} {
+	names, err := fs.Glob(example.FS, "*.pangaea")
+	if err != nil {
+		return []interface{}{}
+	}
+	sort.Strings(names)
+
+	examples := []interface{}{}
+	for _, name := range names {
+		src, err := fs.ReadFile(example.FS, name)
+		if err != nil {
+			continue
+		}
+		examples = append(examples, map[string]interface{}{
+			"name": name,
+			"src":  string(src),
+		})
+	}
+	return examples
+}
@@ -2,9 +2,15 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"syscall/js"
+	"testing/fstest"
+	"time"
 
 	"github.com/Syuparn/pangaea/di"
 	"github.com/Syuparn/pangaea/evaluator"
@@ -12,9 +18,38 @@ import (
 	"github.com/Syuparn/pangaea/parser"
 )
 
+// mainFileName is the file name of the executed source if it is not specified.
+const mainFileName = "main.pangaea"
+
+// defaultMaxSteps is the maximum number of evaluated steps if it is not specified.
+// NOTE: it takes several seconds in browsers
+const defaultMaxSteps = 1_000_000
+
+// maxDepth is the maximum depth of func calls.
+// NOTE: object.DefaultMaxDepth is too large because the js call stack overflows
+const maxDepth = 1000
+
+// yieldInterval is the interval to yield to the js event loop during the evaluation.
+// NOTE: js functions (e.g. cancel) cannot be called until the evaluation yields
+const yieldInterval = 50 * time.Millisecond
+
 // Executor executes pangaea script as js function.
 type Executor struct {
 	constEnv *object.Env
+	// mu serializes executions because IO is injected globally
+	mu sync.Mutex
+	// cancelMu guards cancel
+	cancelMu sync.Mutex
+	// cancel cancels the running execution (nil if nothing is running)
+	cancel context.CancelFunc
+}
+
+// options are options of an execution.
+type options struct {
+	// maxSteps is the maximum number of evaluated steps (0 means no limits)
+	maxSteps int64
+	// timeout is the time limit of the execution (0 means no limits)
+	timeout time.Duration
 }
 
 // NewExecutor generates new Executor.
@@ -26,38 +61,86 @@ func NewExecutor() *Executor {
 }
 
 // Execute executes soruce code.
-// args: (src, stdin) => ({res, stdout, errmsg})
+// files are other files in the workspace, which can be imported by relative paths from fileName.
+// The evaluation is stopped if it exceeds options (maxSteps: 1,000,000 and timeout (ms): none by default).
+// args: (src, stdin, files?: {[fileName]: src}, fileName?, options?: {maxSteps, timeout}) => Promise<{res, stdout, errmsg, steps}>
 func (e *Executor) Execute(this js.Value, args []js.Value) interface{} {
 	src := e.setupSrc(args)
 	stdin := e.setupStdin(args)
-	stdout := &bytes.Buffer{}
-	res, errmsg := e.execute(src, stdin, stdout)
+	fsys := e.setupFiles(args)
+	fileName := e.setupFileName(args)
+	opts := e.setupOptions(args)
+
+	// NOTE: evaluate asynchronously so that the evaluation can be canceled
+	return newPromise(func() interface{} {
+		stdout := &bytes.Buffer{}
+		res, steps, errmsg := e.execute(src, fileName, fsys, opts, stdin, stdout)
+
+		if errmsg != "" {
+			return map[string]interface{}{
+				"res":    "",
+				"stdout": stdout.String(),
+				"errmsg": errmsg,
+				"steps":  steps,
+			}
+		}
 
-	if errmsg != "" {
 		return map[string]interface{}{
-			"res":    "",
+			"res":    res.Repr(),
 			"stdout": stdout.String(),
 			"errmsg": errmsg,
+			"steps":  steps,
 		}
-	}
+	})
+}
+
+// Cancel cancels the running execution.
+// args: () => undefined
+func (e *Executor) Cancel(this js.Value, args []js.Value) interface{} {
+	e.cancelMu.Lock()
+	defer e.cancelMu.Unlock()
 
-	return map[string]interface{}{
-		"res":    res.Repr(),
-		"stdout": stdout.String(),
-		"errmsg": errmsg,
+	if e.cancel != nil {
+		e.cancel()
 	}
+	return nil
 }
 
 func (e *Executor) execute(
-	src,
+	src io.Reader,
+	fileName string,
+	fsys fstest.MapFS,
+	opts options,
 	in io.Reader,
 	out io.Writer,
-) (res object.PanObject, errmsg string) {
+) (res object.PanObject, steps int64, errmsg string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ctx, cancel := e.newContext(opts)
+	defer e.clearCancel()
+	defer cancel()
+
 	// NOTE: IO must be injected globally otherwise built-in objects cannot refer it
 	e.constEnv.InjectIO(in, out)
+	// NOTE: files may be edited after the last execution
+	e.constEnv.SetModules(object.NewModuleCache())
+	e.constEnv.SetFS(fsys)
+
+	sandbox := object.NewSandbox(ctx, object.Limits{MaxSteps: opts.maxSteps, MaxDepth: maxDepth})
+	sandbox.OnCheck(yielder())
+	defer func() {
+		steps = sandbox.Steps()
+		if opts.maxSteps > 0 {
+			steps = min(steps, opts.maxSteps)
+		}
+	}()
+
 	env := object.NewEnclosedEnv(e.constEnv)
+	env.SetSourceFilePath(fileName)
+	env.SetSandbox(sandbox)
 
-	node, err := parser.Parse(src)
+	node, err := parser.Parse(parser.NewReader(src, fileName))
 	if err != nil {
 		errmsg = err.Error()
 		return
@@ -65,6 +148,10 @@ func (e *Executor) execute(
 
 	evaluated := evaluator.Eval(node, env)
 	if err, ok := evaluated.(*object.PanErr); ok {
+		if msg, stopped := stoppedMessage(err, ctx, sandbox, opts); stopped {
+			errmsg = msg
+			return
+		}
 		errmsg = err.Inspect() + "\n" + err.StackTrace + "\n"
 		return
 	}
@@ -73,6 +160,76 @@ func (e *Executor) execute(
 	return
 }
 
+func (e *Executor) newContext(opts options) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if opts.timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), opts.timeout)
+	}
+
+	e.cancelMu.Lock()
+	defer e.cancelMu.Unlock()
+	e.cancel = cancel
+
+	return ctx, cancel
+}
+
+func (e *Executor) clearCancel() {
+	e.cancelMu.Lock()
+	defer e.cancelMu.Unlock()
+	e.cancel = nil
+}
+
+// stoppedMessage returns a message if the evaluation is stopped by a timeout or cancellation.
+func stoppedMessage(
+	err *object.PanErr,
+	ctx context.Context,
+	sandbox *object.Sandbox,
+	opts options,
+) (string, bool) {
+	if err.Kind() != object.LimitErr {
+		return "", false
+	}
+
+	switch {
+	case opts.maxSteps > 0 && sandbox.Steps() > opts.maxSteps:
+		return fmt.Sprintf("timed out after %d steps\n", opts.maxSteps), true
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Sprintf("timed out after %d steps\n", sandbox.Steps()), true
+	case errors.Is(ctx.Err(), context.Canceled):
+		return fmt.Sprintf("canceled after %d steps\n", sandbox.Steps()), true
+	default:
+		return "", false
+	}
+}
+
+// yielder returns a func to yield to the js event loop at regular intervals.
+func yielder() func() {
+	last := time.Now()
+	return func() {
+		if time.Since(last) < yieldInterval {
+			return
+		}
+		// NOTE: js event handlers run while all goroutines are sleeping
+		time.Sleep(time.Millisecond)
+		last = time.Now()
+	}
+}
+
+// newPromise returns a js Promise resolved with the result of f.
+// NOTE: f runs in a new goroutine because js callbacks must not block
+func newPromise(f func() interface{}) js.Value {
+	var handler js.Func
+	handler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		resolve := args[0]
+		go func() {
+			defer handler.Release()
+			resolve.Invoke(f())
+		}()
+		return nil
+	})
+	return js.Global().Get("Promise").New(handler)
+}
+
 func (e *Executor) setupSrc(args []js.Value) io.Reader {
 	if len(args) == 0 || args[0].Type() != js.TypeString {
 		// empty source code
@@ -91,6 +248,46 @@ func (e *Executor) setupStdin(args []js.Value) io.Reader {
 	return strings.NewReader(args[1].String())
 }
 
+func (e *Executor) setupFiles(args []js.Value) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	if len(args) < 3 || args[2].Type() != js.TypeObject {
+		// no other files
+		return fsys
+	}
+
+	keys := js.Global().Get("Object").Call("keys", args[2])
+	for i := 0; i < keys.Length(); i++ {
+		name := keys.Index(i).String()
+		if v := args[2].Get(name); v.Type() == js.TypeString {
+			fsys[object.FSPath(name)] = &fstest.MapFile{Data: []byte(v.String())}
+		}
+	}
+	return fsys
+}
+
+func (e *Executor) setupFileName(args []js.Value) string {
+	if len(args) < 4 || args[3].Type() != js.TypeString || args[3].String() == "" {
+		return mainFileName
+	}
+
+	return args[3].String()
+}
+
+func (e *Executor) setupOptions(args []js.Value) options {
+	opts := options{maxSteps: defaultMaxSteps}
+	if len(args) < 5 || args[4].Type() != js.TypeObject {
+		return opts
+	}
+
+	if v := args[4].Get("maxSteps"); v.Type() == js.TypeNumber {
+		opts.maxSteps = int64(v.Float())
+	}
+	if v := args[4].Get("timeout"); v.Type() == js.TypeNumber {
+		opts.timeout = time.Duration(v.Float() * float64(time.Millisecond))
+	}
+	return opts
+}
+
 func setupEnv() *object.Env {
 	env := object.NewEnvWithConsts()
 
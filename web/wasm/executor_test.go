@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall/js"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// NOTE: run tests by the Go wasm test harness with Node:
+// GOOS=js GOARCH=wasm go test -exec="$(go env GOROOT)/lib/wasm/go_js_wasm_exec" .
+
+// infiniteSrc is a script which never stops.
+const infiniteSrc = `"start".p; <{1}>@{|x| nil}`
+
+// NOTE: share the executor because it takes time to be set up
+var ex = NewExecutor()
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		src            string
+		opts           options
+		expectedStdout string
+		expectedErrmsg string
+		expectedSteps  string
+	}{
+		{
+			`"hello".p; 1 + 2`,
+			options{maxSteps: defaultMaxSteps},
+			"hello\n",
+			"",
+			`^[1-9][0-9]*$`,
+		},
+		{
+			infiniteSrc,
+			options{maxSteps: 1000},
+			"start\n",
+			"timed out after 1000 steps\n",
+			`^1000$`,
+		},
+		{
+			infiniteSrc,
+			options{timeout: 100 * time.Millisecond},
+			"start\n",
+			`^timed out after [1-9][0-9]* steps\n$`,
+			`^[1-9][0-9]*$`,
+		},
+		{
+			`f := {|n| f(n + 1)}; f(0)`,
+			options{},
+			"",
+			"^RecursionErr: exceeded maximum recursion depth 1000\n",
+			`^[1-9][0-9]*$`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			_, steps, errmsg := ex.execute(strings.NewReader(tt.src), mainFileName,
+				fstest.MapFS{}, tt.opts, strings.NewReader(""), out)
+
+			if out.String() != tt.expectedStdout {
+				t.Errorf("wrong stdout. expected=%q, got=%q", tt.expectedStdout, out.String())
+			}
+			if tt.expectedErrmsg == "" && errmsg != "" {
+				t.Errorf("unexpected error: %s", errmsg)
+			}
+			if !regexp.MustCompile(tt.expectedErrmsg).MatchString(errmsg) {
+				t.Errorf("wrong errmsg. expected=%q, got=%q", tt.expectedErrmsg, errmsg)
+			}
+			if !regexp.MustCompile(tt.expectedSteps).MatchString(strconv.FormatInt(steps, 10)) {
+				t.Errorf("wrong steps. expected=%q, got=%d", tt.expectedSteps, steps)
+			}
+		})
+	}
+}
+
+func TestExecuteCancel(t *testing.T) {
+	done := make(chan string)
+	go func() {
+		_, _, errmsg := ex.execute(strings.NewReader(infiniteSrc), mainFileName,
+			fstest.MapFS{}, options{}, strings.NewReader(""), &bytes.Buffer{})
+		done <- errmsg
+	}()
+
+	// NOTE: the evaluation yields so that cancel can be called during it
+	time.Sleep(200 * time.Millisecond)
+	ex.Cancel(js.Undefined(), []js.Value{})
+
+	select {
+	case errmsg := <-done:
+		expected := `^canceled after [1-9][0-9]* steps\n$`
+		if !regexp.MustCompile(expected).MatchString(errmsg) {
+			t.Errorf("wrong errmsg. expected=%q, got=%q", expected, errmsg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("execution was not canceled")
+	}
+}
+
+func TestExecuteCancelWithoutExecution(t *testing.T) {
+	// nothing happens
+	ex.Cancel(js.Undefined(), []js.Value{})
+
+	_, _, errmsg := ex.execute(strings.NewReader(`1`), mainFileName,
+		fstest.MapFS{}, options{}, strings.NewReader(""), &bytes.Buffer{})
+	if errmsg != "" {
+		t.Errorf("unexpected error: %s", errmsg)
+	}
+}
+
+func TestExecuteJS(t *testing.T) {
+	tests := []struct {
+		args           []interface{}
+		expectedRes    string
+		expectedStdout string
+		expectedErrmsg string
+	}{
+		{
+			[]interface{}{`"hi".p; 3`, ""},
+			"3",
+			"hi\n",
+			"",
+		},
+		{
+			[]interface{}{`import("./lib/a").a`, "", map[string]interface{}{"lib/a.pangaea": "a := 5"}},
+			"5",
+			"",
+			"",
+		},
+		{
+			[]interface{}{infiniteSrc, "", map[string]interface{}{}, "", map[string]interface{}{"maxSteps": 100}},
+			"",
+			"start\n",
+			"timed out after 100 steps\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args[0].(string), func(t *testing.T) {
+			args := []js.Value{}
+			for _, arg := range tt.args {
+				args = append(args, js.ValueOf(arg))
+			}
+			result := await(t, ex.Execute(js.Undefined(), args).(js.Value))
+
+			if res := result.Get("res").String(); res != tt.expectedRes {
+				t.Errorf("wrong res. expected=%q, got=%q", tt.expectedRes, res)
+			}
+			if stdout := result.Get("stdout").String(); stdout != tt.expectedStdout {
+				t.Errorf("wrong stdout. expected=%q, got=%q", tt.expectedStdout, stdout)
+			}
+			if errmsg := result.Get("errmsg").String(); errmsg != tt.expectedErrmsg {
+				t.Errorf("wrong errmsg. expected=%q, got=%q", tt.expectedErrmsg, errmsg)
+			}
+			if steps := result.Get("steps").Int(); steps <= 0 {
+				t.Errorf("steps must be positive. got=%d", steps)
+			}
+		})
+	}
+}
+
+// await waits for the promise to be resolved.
+func await(t *testing.T, promise js.Value) js.Value {
+	t.Helper()
+
+	resolved := make(chan js.Value, 1)
+	onResolved := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		resolved <- args[0]
+		return nil
+	})
+	defer onResolved.Release()
+	promise.Call("then", onResolved)
+
+	select {
+	case v := <-resolved:
+		return v
+	case <-time.After(10 * time.Second):
+		t.Fatal("promise was not resolved")
+		return js.Undefined()
+	}
+}
@@ -8,7 +8,11 @@ func RegisterPangaea() {
 
 	js.Global().Set("pangaea", js.ValueOf(
 		map[string]interface{}{
-			"execute": js.FuncOf(ex.Execute),
+			"execute":    js.FuncOf(ex.Execute),
+			"cancel":     js.FuncOf(ex.Cancel),
+			"format":     js.FuncOf(Format),
+			"parseError": js.FuncOf(ParseError),
+			"examples":   js.FuncOf(Examples),
 		},
 	))
 }